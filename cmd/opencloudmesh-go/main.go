@@ -24,6 +24,8 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/server"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tracing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/wiring"
 
 	// Register cache drivers.
@@ -84,10 +86,10 @@ func run(args []string, stdout io.Writer) int {
 		return 0
 	}
 
-	cfg, logger, err := loadConfigAndLogger(
-		*configPath,
-		*modeFlag,
-		config.FlagOverrides{
+	loaderOpts := config.LoaderOptions{
+		ConfigPath: *configPath,
+		ModeFlag:   *modeFlag,
+		FlagOverrides: config.FlagOverrides{
 			ListenAddr:        listenAddr,
 			PublicOrigin:      publicOrigin,
 			ExternalBasePath:  externalBasePath,
@@ -96,7 +98,9 @@ func run(args []string, stdout io.Writer) int {
 			LoggingLevel:      loggingLevel,
 			TokenExchangePath: tokenExchangePath,
 		},
-	)
+	}
+
+	cfg, logger, logLevel, err := loadConfigAndLogger(loaderOpts)
 	if err != nil {
 		logger.Error("failed to load config", "error", err)
 
@@ -112,7 +116,18 @@ func run(args []string, stdout io.Writer) int {
 		return 1
 	}
 
-	result, err := wiring.Build(cfg, logger, wiring.BuildOpts{})
+	tracer, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Error("failed to set up tracing", "error", err)
+
+		return 1
+	}
+	defer shutdownTracing(tracer, logger)
+
+	result, err := wiring.Build(cfg, logger, wiring.BuildOpts{
+		ReloadConfig: reloadConfigFunc(loaderOpts, logger),
+		LogLevel:     logLevel,
+	})
 	if err != nil {
 		logger.Error("failed to bootstrap dependencies", "error", err)
 
@@ -158,38 +173,84 @@ func run(args []string, stdout io.Writer) int {
 	return 0
 }
 
-func loadConfigAndLogger(configPath, modeFlag string, overrides config.FlagOverrides) (*config.Config, *slog.Logger, error) {
+// loadConfigAndLogger loads the config and builds the process logger. The
+// logger's level lives in the returned LevelVar so a config reload can change
+// it.
+func loadConfigAndLogger(opts config.LoaderOptions) (*config.Config, *slog.Logger, *slog.LevelVar, error) {
 	bootstrapLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}))
 
-	cfg, err := config.Load(config.LoaderOptions{
-		ConfigPath:    configPath,
-		ModeFlag:      modeFlag,
-		FlagOverrides: overrides,
-		Logger:        bootstrapLogger,
-	})
+	opts.Logger = bootstrapLogger
+
+	cfg, err := config.Load(opts)
 	if err != nil {
-		return nil, bootstrapLogger, fmt.Errorf("main: load config: %w", err)
+		return nil, bootstrapLogger, nil, fmt.Errorf("main: load config: %w", err)
+	}
+
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(logutil.ParseLevel(cfg.Logging.Level))
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
+
+	return cfg, logger, logLevel, nil
+}
+
+// reloadConfigFunc re-runs the config loader with the startup options, so
+// command-line overrides keep winning over the file.
+func reloadConfigFunc(opts config.LoaderOptions, logger *slog.Logger) func() (*config.Config, error) {
+	opts.Logger = logger
+
+	return func() (*config.Config, error) {
+		cfg, err := config.Load(opts)
+		if err != nil {
+			return nil, fmt.Errorf("main: load config: %w", err)
+		}
+
+		return cfg, nil
 	}
+}
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: parseLogLevel(cfg.Logging.Level)}))
+// shutdownTracing flushes buffered spans before exit.
+func shutdownTracing(tracer *tracing.Provider, logger *slog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	return cfg, logger, nil
+	if err := tracer.Shutdown(ctx); err != nil {
+		logger.Warn("error flushing traces", "error", err)
+	}
 }
 
-func parseLogLevel(level string) slog.Level {
-	switch level {
-	case "trace":
-		return slog.LevelDebug - 4 // slog has no trace, use debug-4
-	case "debug":
-		return slog.LevelDebug
-	case "warn":
-		return slog.LevelWarn
-	case "error":
-		return slog.LevelError
-	default:
-		return slog.LevelInfo
+// waitForShutdown blocks until a shutdown signal (nil) or a server error.
+// Each SIGHUP meanwhile reloads the config.
+func waitForShutdown(
+	ctx context.Context,
+	srvErr <-chan error,
+	hangup <-chan os.Signal,
+	reloader *wiring.Reloader,
+	logger *slog.Logger,
+) error {
+	for {
+		select {
+		case err := <-srvErr:
+			return err
+		case <-hangup:
+			logger.Info("SIGHUP received, reloading configuration")
+
+			if reloader == nil {
+				logger.Warn("configuration reload is not available")
+
+				continue
+			}
+
+			if _, err := reloader.Reload(); err != nil {
+				logger.Error("configuration reload failed; keeping the running configuration", "error", err)
+			}
+		case <-ctx.Done():
+			logger.Info("shutdown signal received")
+
+			return nil
+		}
 	}
 }
 
@@ -416,6 +477,11 @@ func runServer(ctx context.Context, cfg *config.Config, logger *slog.Logger, res
 	serverCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	defer signal.Stop(hangup)
+
 	srvErr := make(chan error, 1)
 
 	go func() { //nolint:contextcheck // startup: goroutine runs detached srv.Start() lifecycle with internal context; threading ctx would change the public Start signature
@@ -426,11 +492,8 @@ func runServer(ctx context.Context, cfg *config.Config, logger *slog.Logger, res
 
 	logger.Info("server started, press Ctrl+C to stop")
 
-	select {
-	case err := <-srvErr:
+	if err := waitForShutdown(serverCtx, srvErr, hangup, result.Deps.Reloader, logger); err != nil {
 		return err
-	case <-serverCtx.Done():
-		logger.Info("shutdown signal received")
 	}
 
 	shutdownCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
@@ -440,6 +503,18 @@ func runServer(ctx context.Context, cfg *config.Config, logger *slog.Logger, res
 		return fmt.Errorf("shutdown error: %w", err)
 	}
 
+	if result.TokenStore != nil {
+		if err := result.TokenStore.Close(); err != nil {
+			logger.Warn("error closing token store", "error", err)
+		}
+	}
+
+	if result.Audit != nil {
+		if err := result.Audit.Close(); err != nil {
+			logger.Warn("error closing audit log", "error", err)
+		}
+	}
+
 	if result.Persistence != nil {
 		if err := result.Persistence.Close(); err != nil {
 			logger.Warn("error closing persistence", "error", err)
@@ -25,6 +25,8 @@ func TestSlogKeysAreSnakeCase(t *testing.T) {
 	packagesToScan := []string{
 		"internal/services/ocm",
 		"internal/components/webdav",
+		"internal/components/sftp",
+		"internal/components/ocm/datatx",
 		"internal/platform/crypto",
 		"internal/components/ocmaux",
 		"internal/components/ocm/peertrust",
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+)
+
+// RequireAdmin resolves the session user of r and writes 401 when there is
+// none and 403 when it is not an admin. Handlers return when ok is false.
+func RequireAdmin(
+	w http.ResponseWriter,
+	r *http.Request,
+	currentUser func(context.Context) (*identity.User, error),
+) (*identity.User, bool) {
+	user, err := currentUser(r.Context())
+	if err != nil {
+		WriteUnauthorized(w, ReasonUnauthenticated, "authentication required")
+
+		return nil, false
+	}
+
+	if !user.IsAdmin() {
+		WriteForbidden(w, ReasonUnauthorized, "admin role required")
+
+		return nil, false
+	}
+
+	return user, true
+}
+
+// DecodeJSONBody decodes at most maxBytes of the request body into dst and
+// writes 400 when the body is not valid JSON.
+func DecodeJSONBody(w http.ResponseWriter, r *http.Request, maxBytes int64, dst any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		WriteBadRequest(w, ReasonBadRequest, "invalid JSON body")
+
+		return false
+	}
+
+	return true
+}
+
+// WriteJSON writes body as a JSON response with the given status. The status
+// is already committed when encoding fails, so the error is only logged.
+func WriteJSON(w http.ResponseWriter, logger *slog.Logger, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("failed to encode response", "error", err)
+	}
+}
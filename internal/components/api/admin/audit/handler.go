@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package audit provides the admin-only handlers for GET /api/admin/audit
+// (query the audit log) and GET /api/admin/audit/verify (check its hash
+// chain).
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// Log is the queryable audit log; *audit.Log implements it.
+type Log interface {
+	Query(ctx context.Context, f audit.Filter) ([]audit.Event, error)
+	Verify(ctx context.Context) (audit.VerifyResult, error)
+}
+
+// Handler serves the admin audit endpoints. It requires an admin or super
+// admin session.
+type Handler struct {
+	log         Log
+	currentUser func(context.Context) (*identity.User, error)
+	logger      *slog.Logger
+}
+
+// NewHandler returns a Handler. A nil log (audit disabled) answers 503 to
+// admins.
+func NewHandler(
+	log Log,
+	currentUser func(context.Context) (*identity.User, error),
+	logger *slog.Logger,
+) *Handler {
+	return &Handler{
+		log:         log,
+		currentUser: currentUser,
+		logger:      logutil.NoopIfNil(logger),
+	}
+}
+
+// eventsResponse is the GET /api/admin/audit body.
+type eventsResponse struct {
+	Events []audit.Event `json:"events"`
+}
+
+// HandleList handles GET /api/admin/audit. Query parameters: since and until
+// (RFC 3339; since inclusive, until exclusive), actor, peer, action, and limit
+// (1..1000, default 100). Events are returned newest first.
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	filter, ok := parseFilter(w, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	events, err := h.log.Query(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to query audit log", "error", err)
+		api.WriteInternalError(w, "failed to query audit log")
+
+		return
+	}
+
+	api.WriteJSON(w, h.logger, http.StatusOK, eventsResponse{Events: events})
+}
+
+// HandleVerify handles GET /api/admin/audit/verify. It re-reads the whole log
+// and reports whether the hash chain is intact and, if not, the first line
+// that breaks it.
+func (h *Handler) HandleVerify(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	res, err := h.log.Verify(r.Context())
+	if err != nil {
+		h.logger.Error("failed to verify audit log", "error", err)
+		api.WriteInternalError(w, "failed to verify audit log")
+
+		return
+	}
+
+	if !res.Valid {
+		h.logger.Warn("audit log hash chain is broken", "broken_at", res.BrokenAt, "reason", res.Reason)
+	}
+
+	api.WriteJSON(w, h.logger, http.StatusOK, res)
+}
+
+func (h *Handler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if _, ok := api.RequireAdmin(w, r, h.currentUser); !ok {
+		return false
+	}
+
+	if h.log == nil {
+		api.WriteError(w, http.StatusServiceUnavailable, api.ReasonInternalError, "audit log is disabled")
+
+		return false
+	}
+
+	return true
+}
+
+func parseFilter(w http.ResponseWriter, q url.Values) (audit.Filter, bool) {
+	filter := audit.Filter{
+		Actor:  q.Get("actor"),
+		Peer:   q.Get("peer"),
+		Action: q.Get("action"),
+		Limit:  audit.DefaultQueryLimit,
+	}
+
+	var ok bool
+
+	if filter.Since, ok = parseTimeParam(w, q, "since"); !ok {
+		return filter, false
+	}
+
+	if filter.Until, ok = parseTimeParam(w, q, "until"); !ok {
+		return filter, false
+	}
+
+	if raw := q.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > audit.MaxQueryLimit {
+			api.WriteBadRequest(w, api.ReasonInvalidField,
+				"limit must be an integer between 1 and "+strconv.Itoa(audit.MaxQueryLimit))
+
+			return filter, false
+		}
+
+		filter.Limit = n
+	}
+
+	return filter, true
+}
+
+func parseTimeParam(w http.ResponseWriter, q url.Values, name string) (time.Time, bool) {
+	raw := q.Get(name)
+	if raw == "" {
+		return time.Time{}, true
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		api.WriteBadRequest(w, api.ReasonInvalidField, name+" must be an RFC 3339 timestamp")
+
+		return time.Time{}, false
+	}
+
+	return t, true
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	adminaudit "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/audit"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
+)
+
+var admin = &identity.User{ID: "admin-id", Username: "admin", Role: identity.RoleAdmin}
+
+func openLog(t *testing.T) *audit.Log {
+	t.Helper()
+
+	l, err := audit.Open(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("open audit log: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := l.Close(); err != nil {
+			t.Errorf("close audit log: %v", err)
+		}
+	})
+
+	ctx := audit.WithActor(context.Background(), "admin")
+	l.Record(ctx, audit.Event{Action: audit.ActionAdminLogin, Resource: "admin-id"})
+	l.Record(ctx, audit.Event{Action: audit.ActionShareCreate, Peer: "bob.example.com", Resource: "share-1"})
+	l.Record(context.Background(), audit.Event{
+		Action:   audit.ActionTokenIssue,
+		Actor:    "bob.example.com",
+		Peer:     "bob.example.com",
+		Resource: "share-1",
+	})
+
+	return l
+}
+
+func serve(t *testing.T, log adminaudit.Log, caller *identity.User, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	currentUser := func(context.Context) (*identity.User, error) {
+		if caller == nil {
+			return nil, errors.New("no session")
+		}
+
+		return caller, nil
+	}
+
+	h := adminaudit.NewHandler(log, currentUser, nil)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, target, nil)
+
+	if r.URL.Path == "/api/admin/audit/verify" {
+		h.HandleVerify(w, r)
+	} else {
+		h.HandleList(w, r)
+	}
+
+	return w
+}
+
+func TestHandleList_Filters(t *testing.T) {
+	t.Parallel()
+
+	l := openLog(t)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	cases := []struct {
+		query string
+		want  []int64
+	}{
+		{"", []int64{3, 2, 1}},
+		{"?actor=admin", []int64{2, 1}},
+		{"?peer=bob.example.com&action=token.issue", []int64{3}},
+		{"?limit=1", []int64{3}},
+		{"?since=" + future, []int64{}},
+		{"?until=" + future + "&actor=bob.example.com", []int64{3}},
+	}
+
+	for _, tc := range cases {
+		w := serve(t, l, admin, "/api/admin/audit"+tc.query)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: expected 200, got %d: %s", tc.query, w.Code, w.Body.String())
+		}
+
+		var body struct {
+			Events []audit.Event `json:"events"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%q: decode: %v", tc.query, err)
+		}
+
+		got := make([]int64, 0, len(body.Events))
+		for _, e := range body.Events {
+			got = append(got, e.Seq)
+		}
+
+		if len(got) != len(tc.want) {
+			t.Errorf("%q: seqs = %v, want %v", tc.query, got, tc.want)
+
+			continue
+		}
+
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%q: seqs = %v, want %v", tc.query, got, tc.want)
+
+				break
+			}
+		}
+	}
+}
+
+func TestHandleList_RejectsBadParams(t *testing.T) {
+	t.Parallel()
+
+	l := openLog(t)
+
+	for _, query := range []string{"?since=yesterday", "?until=2026-13-01", "?limit=0", "?limit=1001", "?limit=ten"} {
+		if w := serve(t, l, admin, "/api/admin/audit"+query); w.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestHandleVerify_ReportsChain(t *testing.T) {
+	t.Parallel()
+
+	w := serve(t, openLog(t), admin, "/api/admin/audit/verify")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var res audit.VerifyResult
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if !res.Valid || res.Events != 3 {
+		t.Errorf("verify = %+v, want a valid chain of 3 events", res)
+	}
+}
+
+func TestHandlers_RequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	l := openLog(t)
+
+	cases := []struct {
+		name   string
+		log    adminaudit.Log
+		caller *identity.User
+		want   int
+	}{
+		{"anonymous", l, nil, http.StatusUnauthorized},
+		{"user", l, &identity.User{ID: "alice-id", Role: identity.RoleUser}, http.StatusForbidden},
+		{"audit disabled", nil, admin, http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range cases {
+		for _, target := range []string{"/api/admin/audit", "/api/admin/audit/verify"} {
+			if w := serve(t, tc.log, tc.caller, target); w.Code != tc.want {
+				t.Errorf("%s %s: got %d, want %d", tc.name, target, w.Code, tc.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package groups provides the admin-only handlers for /api/admin/groups
+// (list, create, update, and delete local groups used by group shares).
+package groups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// maxBodyBytes caps admin request bodies.
+const maxBodyBytes = 16 << 10
+
+// errUnknownMember marks a member reference that matches no local user.
+var errUnknownMember = errors.New("unknown member")
+
+// Handler serves /api/admin/groups. Every endpoint requires an admin or super
+// admin session. Members are stored as canonical user ids; requests may name
+// them by id or username.
+type Handler struct {
+	groups      identity.GroupRepo
+	users       identity.PartyRepo
+	currentUser func(context.Context) (*identity.User, error)
+	logger      *slog.Logger
+}
+
+// NewHandler returns a Handler with the given identity components.
+func NewHandler(
+	groups identity.GroupRepo,
+	users identity.PartyRepo,
+	currentUser func(context.Context) (*identity.User, error),
+	logger *slog.Logger,
+) *Handler {
+	return &Handler{
+		groups:      groups,
+		users:       users,
+		currentUser: currentUser,
+		logger:      logutil.NoopIfNil(logger),
+	}
+}
+
+// GroupView is the admin API representation of a group.
+type GroupView struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+	Members     []string  `json:"members"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// NewGroupView maps a group to its admin API view.
+func NewGroupView(g *identity.Group) GroupView {
+	members := slices.Clone(g.Members)
+	if members == nil {
+		members = []string{}
+	}
+
+	return GroupView{
+		ID:          g.ID,
+		Name:        g.Name,
+		DisplayName: g.DisplayName,
+		Members:     members,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
+
+// ListResponse is the JSON body for GET /api/admin/groups.
+type ListResponse struct {
+	Groups []GroupView `json:"groups"`
+}
+
+// GroupRequest is the JSON body for POST /api/admin/groups and
+// PUT /api/admin/groups/{groupId}. Name is the shareWith identifier remote
+// servers use to address the group; members are user ids or usernames.
+type GroupRequest struct {
+	Name        string   `json:"name"`
+	DisplayName string   `json:"displayName"`
+	Members     []string `json:"members"`
+}
+
+// HandleList handles GET /api/admin/groups. Groups are ordered by name.
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	if _, ok := api.RequireAdmin(w, r, h.currentUser); !ok {
+		return
+	}
+
+	all, err := h.groups.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list groups", "error", err)
+		api.WriteInternalError(w, "failed to list groups")
+
+		return
+	}
+
+	views := make([]GroupView, 0, len(all))
+	for _, g := range all {
+		views = append(views, NewGroupView(g))
+	}
+
+	api.WriteJSON(w, h.logger, http.StatusOK, ListResponse{Groups: views})
+}
+
+// HandleCreate handles POST /api/admin/groups.
+func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	admin, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return
+	}
+
+	var req GroupRequest
+	if !api.DecodeJSONBody(w, r, maxBodyBytes, &req) || !validName(w, &req) {
+		return
+	}
+
+	members, ok := h.resolveMembers(w, r.Context(), req.Members)
+	if !ok {
+		return
+	}
+
+	group := &identity.Group{
+		Name:        req.Name,
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		Members:     members,
+	}
+	if err := h.groups.Create(r.Context(), group); err != nil {
+		h.writeRepoError(w, err, "failed to create group")
+
+		return
+	}
+
+	h.logger.Info("admin created group",
+		"admin_id", admin.ID, "group_id", group.ID, "name", group.Name, "members", len(group.Members))
+
+	api.WriteJSON(w, h.logger, http.StatusCreated, NewGroupView(group))
+}
+
+// HandleGet handles GET /api/admin/groups/{groupId}.
+func (h *Handler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	if _, ok := api.RequireAdmin(w, r, h.currentUser); !ok {
+		return
+	}
+
+	group, err := h.groups.Get(r.Context(), chi.URLParam(r, "groupId"))
+	if err != nil {
+		h.writeRepoError(w, err, "failed to load group")
+
+		return
+	}
+
+	api.WriteJSON(w, h.logger, http.StatusOK, NewGroupView(group))
+}
+
+// HandleUpdate handles PUT /api/admin/groups/{groupId}; the body replaces the
+// group's name, display name, and member list.
+func (h *Handler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
+	admin, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return
+	}
+
+	var req GroupRequest
+	if !api.DecodeJSONBody(w, r, maxBodyBytes, &req) || !validName(w, &req) {
+		return
+	}
+
+	members, ok := h.resolveMembers(w, r.Context(), req.Members)
+	if !ok {
+		return
+	}
+
+	group, err := h.groups.Get(r.Context(), chi.URLParam(r, "groupId"))
+	if err != nil {
+		h.writeRepoError(w, err, "failed to load group")
+
+		return
+	}
+
+	group.Name = req.Name
+	group.DisplayName = strings.TrimSpace(req.DisplayName)
+	group.Members = members
+
+	if err := h.groups.Update(r.Context(), group); err != nil {
+		h.writeRepoError(w, err, "failed to update group")
+
+		return
+	}
+
+	h.logger.Info("admin updated group",
+		"admin_id", admin.ID, "group_id", group.ID, "name", group.Name, "members", len(group.Members))
+
+	api.WriteJSON(w, h.logger, http.StatusOK, NewGroupView(group))
+}
+
+// HandleDelete handles DELETE /api/admin/groups/{groupId}. Shares already
+// fanned out to members stay in their inboxes.
+func (h *Handler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	admin, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return
+	}
+
+	groupID := chi.URLParam(r, "groupId")
+	if err := h.groups.Delete(r.Context(), groupID); err != nil {
+		h.writeRepoError(w, err, "failed to delete group")
+
+		return
+	}
+
+	h.logger.Info("admin deleted group", "admin_id", admin.ID, "group_id", groupID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resolveMembers maps each member reference (user id or username) to its
+// canonical user id, dropping duplicates and keeping request order.
+func (h *Handler) resolveMembers(w http.ResponseWriter, ctx context.Context, refs []string) ([]string, bool) {
+	members := make([]string, 0, len(refs))
+
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			api.WriteBadRequest(w, api.ReasonInvalidField, "members must not contain empty entries")
+
+			return nil, false
+		}
+
+		user, err := h.lookupUser(ctx, ref)
+		if errors.Is(err, errUnknownMember) {
+			api.WriteBadRequest(w, api.ReasonInvalidField, "unknown member: "+ref)
+
+			return nil, false
+		}
+
+		if err != nil {
+			h.logger.Error("failed to resolve group member", "member", ref, "error", err)
+			api.WriteInternalError(w, "failed to resolve group members")
+
+			return nil, false
+		}
+
+		if !slices.Contains(members, user.ID) {
+			members = append(members, user.ID)
+		}
+	}
+
+	return members, true
+}
+
+func (h *Handler) lookupUser(ctx context.Context, ref string) (*identity.User, error) {
+	user, err := h.users.Get(ctx, ref)
+	if err == nil {
+		return user, nil
+	}
+
+	if !errors.Is(err, identity.ErrUserNotFound) {
+		return nil, fmt.Errorf("groups: get user: %w", err)
+	}
+
+	user, err = h.users.GetByUsername(ctx, ref)
+	if errors.Is(err, identity.ErrUserNotFound) {
+		return nil, errUnknownMember
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("groups: get user by username: %w", err)
+	}
+
+	return user, nil
+}
+
+// writeRepoError maps group repo errors to HTTP responses.
+func (h *Handler) writeRepoError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, identity.ErrGroupNotFound):
+		api.WriteNotFound(w, "group not found")
+	case errors.Is(err, identity.ErrGroupExists):
+		api.WriteConflict(w, "group name already exists")
+	default:
+		h.logger.Error(msg, "error", err)
+		api.WriteInternalError(w, msg)
+	}
+}
+
+// validName trims the group name and rejects empty names or names that would
+// not survive as the identifier part of a shareWith address.
+func validName(w http.ResponseWriter, req *GroupRequest) bool {
+	req.Name = strings.TrimSpace(req.Name)
+
+	switch {
+	case req.Name == "":
+		api.WriteBadRequest(w, api.ReasonMissingField, "name is required")
+	case strings.ContainsAny(req.Name, "@/ \t"):
+		api.WriteBadRequest(w, api.ReasonInvalidField, "name must not contain '@', '/', or whitespace")
+	default:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,260 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package groups_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/groups"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+)
+
+type fixture struct {
+	repos  *repos.Repos
+	router chi.Router
+}
+
+// newFixture seeds an admin and two regular users, and routes requests as
+// the user with callerID ("" for no session).
+func newFixture(t *testing.T, callerID string) *fixture {
+	t.Helper()
+
+	f := &fixture{repos: tsrepos.OpenMemory(t)}
+
+	for _, u := range []*identity.User{
+		{ID: "admin-id", Username: "admin", Role: identity.RoleAdmin},
+		{ID: "alice-id", Username: "alice", Role: identity.RoleUser},
+		{ID: "bob-id", Username: "bob", Role: identity.RoleUser},
+	} {
+		u.CreatedAt = time.Now()
+		if err := f.repos.Parties.Create(t.Context(), u); err != nil {
+			t.Fatalf("seed %s: %v", u.Username, err)
+		}
+	}
+
+	currentUser := func(ctx context.Context) (*identity.User, error) {
+		if callerID == "" {
+			return nil, errors.New("no session")
+		}
+
+		return f.repos.Parties.Get(ctx, callerID)
+	}
+
+	h := groups.NewHandler(f.repos.Groups, f.repos.Parties, currentUser, nil)
+
+	r := chi.NewRouter()
+	r.Get("/api/admin/groups", h.HandleList)
+	r.Post("/api/admin/groups", h.HandleCreate)
+	r.Get("/api/admin/groups/{groupId}", h.HandleGet)
+	r.Put("/api/admin/groups/{groupId}", h.HandleUpdate)
+	r.Delete("/api/admin/groups/{groupId}", h.HandleDelete)
+	f.router = r
+
+	return f
+}
+
+func (f *fixture) do(t *testing.T, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func (f *fixture) create(t *testing.T, body string) groups.GroupView {
+	t.Helper()
+
+	w := f.do(t, http.MethodPost, "/api/admin/groups", body)
+	requireStatus(t, w, http.StatusCreated)
+
+	var view groups.GroupView
+	if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	return view
+}
+
+func requireStatus(t *testing.T, w *httptest.ResponseRecorder, want int) {
+	t.Helper()
+
+	if w.Code != want {
+		t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body.String())
+	}
+}
+
+func requireReason(t *testing.T, w *httptest.ResponseRecorder, want string) {
+	t.Helper()
+
+	var body api.ErrorEnvelope
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+
+	if body.Error.ReasonCode != want {
+		t.Fatalf("reasonCode = %q, want %q", body.Error.ReasonCode, want)
+	}
+}
+
+func TestAdminGroups_RequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	endpoints := []struct{ method, target, body string }{
+		{http.MethodGet, "/api/admin/groups", ""},
+		{http.MethodPost, "/api/admin/groups", `{"name":"eng"}`},
+		{http.MethodGet, "/api/admin/groups/g-1", ""},
+		{http.MethodPut, "/api/admin/groups/g-1", `{"name":"eng"}`},
+		{http.MethodDelete, "/api/admin/groups/g-1", ""},
+	}
+
+	for _, ep := range endpoints {
+		t.Run(ep.method+" "+ep.target, func(t *testing.T) {
+			t.Parallel()
+
+			w := newFixture(t, "").do(t, ep.method, ep.target, ep.body)
+			requireStatus(t, w, http.StatusUnauthorized)
+			requireReason(t, w, api.ReasonUnauthenticated)
+
+			w = newFixture(t, "alice-id").do(t, ep.method, ep.target, ep.body)
+			requireStatus(t, w, http.StatusForbidden)
+			requireReason(t, w, api.ReasonUnauthorized)
+		})
+	}
+}
+
+func TestAdminGroups_CreateResolvesMembers(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	view := f.create(t, `{"name":"eng","displayName":"Engineering","members":["alice","bob-id","alice-id"]}`)
+	if view.ID == "" || view.Name != "eng" || view.DisplayName != "Engineering" {
+		t.Fatalf("created view = %+v", view)
+	}
+
+	if !slices.Equal(view.Members, []string{"alice-id", "bob-id"}) {
+		t.Fatalf("members = %v, want canonical ids without duplicates", view.Members)
+	}
+
+	stored, err := f.repos.Groups.GetByName(t.Context(), "eng")
+	if err != nil {
+		t.Fatalf("GetByName: %v", err)
+	}
+
+	if !stored.HasMember("alice-id") || !stored.HasMember("bob-id") {
+		t.Fatalf("stored members = %v", stored.Members)
+	}
+
+	w := f.do(t, http.MethodGet, "/api/admin/groups/"+view.ID, "")
+	requireStatus(t, w, http.StatusOK)
+}
+
+func TestAdminGroups_CreateValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name, body, reason string
+		status             int
+	}{
+		{"invalid json", `{`, api.ReasonBadRequest, http.StatusBadRequest},
+		{"missing name", `{"members":[]}`, api.ReasonMissingField, http.StatusBadRequest},
+		{"name with at sign", `{"name":"eng@example.org"}`, api.ReasonInvalidField, http.StatusBadRequest},
+		{"unknown member", `{"name":"eng","members":["carol"]}`, api.ReasonInvalidField, http.StatusBadRequest},
+		{"empty member", `{"name":"eng","members":[" "]}`, api.ReasonInvalidField, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := newFixture(t, "admin-id").do(t, http.MethodPost, "/api/admin/groups", tt.body)
+			requireStatus(t, w, tt.status)
+			requireReason(t, w, tt.reason)
+		})
+	}
+}
+
+func TestAdminGroups_DuplicateNameConflicts(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+	f.create(t, `{"name":"eng"}`)
+	design := f.create(t, `{"name":"design"}`)
+
+	w := f.do(t, http.MethodPost, "/api/admin/groups", `{"name":"eng"}`)
+	requireStatus(t, w, http.StatusConflict)
+
+	w = f.do(t, http.MethodPut, "/api/admin/groups/"+design.ID, `{"name":"eng"}`)
+	requireStatus(t, w, http.StatusConflict)
+}
+
+func TestAdminGroups_UpdateReplacesMembers(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+	view := f.create(t, `{"name":"eng","members":["alice"]}`)
+
+	w := f.do(t, http.MethodPut, "/api/admin/groups/"+view.ID, `{"name":"engineering","members":["bob"]}`)
+	requireStatus(t, w, http.StatusOK)
+
+	stored, err := f.repos.Groups.Get(t.Context(), view.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if stored.Name != "engineering" || !slices.Equal(stored.Members, []string{"bob-id"}) {
+		t.Fatalf("stored = %+v, want renamed with [bob-id]", stored)
+	}
+
+	w = f.do(t, http.MethodPut, "/api/admin/groups/missing", `{"name":"x"}`)
+	requireStatus(t, w, http.StatusNotFound)
+}
+
+func TestAdminGroups_ListAndDelete(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+	f.create(t, `{"name":"zeta"}`)
+	alpha := f.create(t, `{"name":"alpha"}`)
+
+	w := f.do(t, http.MethodGet, "/api/admin/groups", "")
+	requireStatus(t, w, http.StatusOK)
+
+	var list groups.ListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if len(list.Groups) != 2 || list.Groups[0].Name != "alpha" || list.Groups[1].Name != "zeta" {
+		t.Fatalf("list = %+v, want [alpha zeta]", list.Groups)
+	}
+
+	w = f.do(t, http.MethodDelete, "/api/admin/groups/"+alpha.ID, "")
+	requireStatus(t, w, http.StatusNoContent)
+
+	w = f.do(t, http.MethodDelete, "/api/admin/groups/"+alpha.ID, "")
+	requireStatus(t, w, http.StatusNotFound)
+
+	w = f.do(t, http.MethodGet, "/api/admin/groups/"+alpha.ID, "")
+	requireStatus(t, w, http.StatusNotFound)
+}
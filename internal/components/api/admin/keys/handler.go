@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package keys provides the admin-only handlers for /api/admin/keys (list the
+// signing keyring and trigger a rotation).
+package keys
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// Keyring is the part of crypto.KeyManager the handlers use.
+type Keyring interface {
+	Keys() []crypto.KeyInfo
+	StageNext(now, promoteAt time.Time) (crypto.KeyInfo, error)
+}
+
+// Handler serves /api/admin/keys. Every endpoint requires an admin or super
+// admin session.
+type Handler struct {
+	keyring      Keyring
+	publishAhead time.Duration
+	currentUser  func(context.Context) (*identity.User, error)
+	logger       *slog.Logger
+	now          func() time.Time
+}
+
+// NewHandler returns a Handler. publishAhead is how long a key staged by
+// HandleRotate is published before the rotator promotes it.
+func NewHandler(
+	keyring Keyring,
+	publishAhead time.Duration,
+	currentUser func(context.Context) (*identity.User, error),
+	logger *slog.Logger,
+) *Handler {
+	return &Handler{
+		keyring:      keyring,
+		publishAhead: publishAhead,
+		currentUser:  currentUser,
+		logger:       logutil.NoopIfNil(logger),
+		now:          time.Now,
+	}
+}
+
+// KeyView is the admin API representation of a keyring entry. Private key
+// material never leaves the process.
+type KeyView struct {
+	KeyID       string          `json:"keyId"`
+	Fragment    string          `json:"fragment"`
+	Algorithm   string          `json:"algorithm"`
+	State       crypto.KeyState `json:"state"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	ActivatedAt *time.Time      `json:"activatedAt,omitempty"`
+	PromoteAt   *time.Time      `json:"promoteAt,omitempty"`
+	RetiredAt   *time.Time      `json:"retiredAt,omitempty"`
+}
+
+// NewKeyView maps a keyring entry to its admin API view.
+func NewKeyView(k crypto.KeyInfo) KeyView {
+	return KeyView{
+		KeyID:       k.KeyID,
+		Fragment:    k.Fragment,
+		Algorithm:   k.Algorithm,
+		State:       k.State,
+		CreatedAt:   k.CreatedAt,
+		ActivatedAt: k.ActivatedAt,
+		PromoteAt:   k.PromoteAt,
+		RetiredAt:   k.RetiredAt,
+	}
+}
+
+// ListResponse is the JSON body for GET /api/admin/keys.
+type ListResponse struct {
+	Keys []KeyView `json:"keys"`
+}
+
+// HandleList handles GET /api/admin/keys. Keys are listed newest first.
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+
+	all := h.keyring.Keys()
+
+	views := make([]KeyView, 0, len(all))
+	for _, k := range all {
+		views = append(views, NewKeyView(k))
+	}
+
+	api.WriteJSON(w, h.logger, http.StatusOK, ListResponse{Keys: views})
+}
+
+// HandleRotate handles POST /api/admin/keys/rotate. It stages and publishes a
+// next key; the rotator promotes it once the publish-ahead window passes, so
+// the response is 202 with the staged key.
+func (h *Handler) HandleRotate(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+
+	now := h.now()
+
+	staged, err := h.keyring.StageNext(now, now.Add(h.publishAhead))
+	if errors.Is(err, crypto.ErrNextKeyStaged) {
+		api.WriteConflict(w, "a next signing key is already staged")
+
+		return
+	}
+
+	if err != nil {
+		h.logger.Error("failed to stage signing key", "error", err)
+		api.WriteInternalError(w, "failed to stage signing key")
+
+		return
+	}
+
+	h.logger.Info("admin staged next signing key", "key_id", staged.KeyID, "promote_at", staged.PromoteAt)
+
+	api.WriteJSON(w, h.logger, http.StatusAccepted, NewKeyView(staged))
+}
+
+// requireAdmin also answers 503 to admins when the node runs without
+// signing keys.
+func (h *Handler) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if _, ok := api.RequireAdmin(w, r, h.currentUser); !ok {
+		return false
+	}
+
+	if h.keyring == nil {
+		api.WriteError(w, http.StatusServiceUnavailable, api.ReasonInternalError, "signing keys unavailable")
+
+		return false
+	}
+
+	return true
+}
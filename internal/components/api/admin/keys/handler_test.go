@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package keys_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/keys"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
+)
+
+func newRouter(t *testing.T, keyring keys.Keyring, caller *identity.User) chi.Router {
+	t.Helper()
+
+	currentUser := func(context.Context) (*identity.User, error) {
+		if caller == nil {
+			return nil, errors.New("no session")
+		}
+
+		return caller, nil
+	}
+
+	h := keys.NewHandler(keyring, 15*time.Minute, currentUser, nil)
+
+	r := chi.NewRouter()
+	r.Get("/api/admin/keys", h.HandleList)
+	r.Post("/api/admin/keys/rotate", h.HandleRotate)
+
+	return r
+}
+
+func do(t *testing.T, r chi.Router, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), method, target, nil))
+
+	return w
+}
+
+func TestHandleRotate_StagesAndListsNextKey(t *testing.T) {
+	t.Parallel()
+
+	km := crypto.NewKeyManager("", "https://example.com")
+	if err := km.LoadOrGenerate(); err != nil {
+		t.Fatalf("LoadOrGenerate: %v", err)
+	}
+
+	r := newRouter(t, km, &identity.User{ID: "admin-id", Role: identity.RoleAdmin})
+
+	w := do(t, r, http.MethodPost, "/api/admin/keys/rotate")
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("rotate: expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var staged keys.KeyView
+	if err := json.Unmarshal(w.Body.Bytes(), &staged); err != nil {
+		t.Fatalf("decode rotate response: %v", err)
+	}
+
+	if staged.State != crypto.KeyStateNext || staged.KeyID != "example.com#key1-r2" || staged.PromoteAt == nil {
+		t.Fatalf("staged = %+v", staged)
+	}
+
+	if left := time.Until(*staged.PromoteAt); left < 14*time.Minute || left > 15*time.Minute {
+		t.Errorf("promoteAt is %s away, want about the publish-ahead window", left)
+	}
+
+	if w := do(t, r, http.MethodPost, "/api/admin/keys/rotate"); w.Code != http.StatusConflict {
+		t.Fatalf("second rotate: expected 409, got %d", w.Code)
+	}
+
+	w = do(t, r, http.MethodGet, "/api/admin/keys")
+	if w.Code != http.StatusOK {
+		t.Fatalf("list: expected 200, got %d", w.Code)
+	}
+
+	var list keys.ListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+
+	if len(list.Keys) != 2 || list.Keys[0].State != crypto.KeyStateNext || list.Keys[1].State != crypto.KeyStateCurrent {
+		t.Fatalf("keys = %+v", list.Keys)
+	}
+}
+
+func TestHandlers_RequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	km := crypto.NewKeyManager("", "https://example.com")
+	if err := km.LoadOrGenerate(); err != nil {
+		t.Fatalf("LoadOrGenerate: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		keyring keys.Keyring
+		caller  *identity.User
+		want    int
+	}{
+		{"anonymous", km, nil, http.StatusUnauthorized},
+		{"user", km, &identity.User{ID: "alice-id", Role: identity.RoleUser}, http.StatusForbidden},
+		{"no signing keys", nil, &identity.User{ID: "admin-id", Role: identity.RoleAdmin}, http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range cases {
+		r := newRouter(t, tc.keyring, tc.caller)
+
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			target := "/api/admin/keys"
+			if method == http.MethodPost {
+				target += "/rotate"
+			}
+
+			if w := do(t, r, method, target); w.Code != tc.want {
+				t.Errorf("%s %s %s: got %d, want %d", tc.name, method, target, w.Code, tc.want)
+			}
+		}
+	}
+
+	if len(km.Keys()) != 1 {
+		t.Errorf("rejected requests changed the keyring: %+v", km.Keys())
+	}
+}
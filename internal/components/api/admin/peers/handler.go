@@ -0,0 +1,481 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package peers provides the admin-only handlers for /api/admin/peers
+// (inspect peer trust decisions, edit the runtime allow/deny lists, attach
+// and detach Directory Service trust groups, and force a membership refresh).
+package peers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/directoryservice"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// maxBodyBytes caps admin request bodies; trust group documents carry keys.
+const maxBodyBytes = 64 << 10
+
+// Handler serves /api/admin/peers. Every endpoint requires an admin or super
+// admin session. Runtime entries and trust groups are stored in repo and
+// applied to the policy engine and trust group manager; the configured ones
+// from [peer_trust] are shown but cannot be edited here.
+type Handler struct {
+	engine      *peertrust.PolicyEngine
+	groups      *peertrust.TrustGroupManager
+	repo        peertrust.Repo
+	currentUser func(context.Context) (*identity.User, error)
+	logger      *slog.Logger
+
+	// mu orders writes with the engine updates that follow them, so the
+	// engine never ends up with an older list than the store.
+	mu sync.Mutex
+}
+
+// NewHandler returns a Handler. A nil engine, trust group manager or repo
+// (peer trust disabled) answers 503 to admins.
+func NewHandler(
+	engine *peertrust.PolicyEngine,
+	groups *peertrust.TrustGroupManager,
+	repo peertrust.Repo,
+	currentUser func(context.Context) (*identity.User, error),
+	logger *slog.Logger,
+) *Handler {
+	return &Handler{
+		engine:      engine,
+		groups:      groups,
+		repo:        repo,
+		currentUser: currentUser,
+		logger:      logutil.NoopIfNil(logger),
+	}
+}
+
+// ListsView holds the configured allow and deny lists.
+type ListsView struct {
+	AllowList []string `json:"allowList"`
+	DenyList  []string `json:"denyList"`
+}
+
+// TrustGroupView is the admin API representation of a trust group.
+type TrustGroupView struct {
+	TrustGroupID      string                            `json:"trustGroupId"`
+	Source            string                            `json:"source"`
+	Enabled           bool                              `json:"enabled"`
+	EnforceMembership bool                              `json:"enforceMembership"`
+	DirectoryServices []directoryservice.EndpointConfig `json:"directoryServices"`
+	Keys              int                               `json:"keys"`
+	Members           int                               `json:"members"`
+	LastRefresh       *time.Time                        `json:"lastRefresh,omitempty"`
+	Refreshing        bool                              `json:"refreshing"`
+}
+
+// NewTrustGroupView maps a trust group status to its admin API view.
+func NewTrustGroupView(s *peertrust.TrustGroupStatus) TrustGroupView {
+	view := TrustGroupView{
+		TrustGroupID:      s.Config.TrustGroupID,
+		Source:            s.Source,
+		Enabled:           s.Config.Enabled,
+		EnforceMembership: s.Config.EnforceMembership,
+		DirectoryServices: s.Config.DirectoryServices,
+		Keys:              len(s.Config.Keys),
+		Members:           s.Members,
+		Refreshing:        s.Refreshing,
+	}
+
+	if view.DirectoryServices == nil {
+		view.DirectoryServices = []directoryservice.EndpointConfig{}
+	}
+
+	if !s.LastRefresh.IsZero() {
+		lastRefresh := s.LastRefresh
+		view.LastRefresh = &lastRefresh
+	}
+
+	return view
+}
+
+// OverviewResponse is the JSON body for GET /api/admin/peers.
+type OverviewResponse struct {
+	// Configured holds the [peer_trust.policy] lists.
+	Configured ListsView `json:"configured"`
+	// Runtime holds the entries added through this API.
+	Runtime     []*peertrust.PolicyEntry `json:"runtime"`
+	TrustGroups []TrustGroupView         `json:"trustGroups"`
+}
+
+// TrustGroupsResponse is the JSON body for
+// POST /api/admin/peers/trust-groups/refresh.
+type TrustGroupsResponse struct {
+	TrustGroups []TrustGroupView `json:"trustGroups"`
+}
+
+// DecisionResponse is the JSON body for GET /api/admin/peers/decision.
+type DecisionResponse struct {
+	Host       string `json:"host"`
+	Allowed    bool   `json:"allowed"`
+	Reason     string `json:"reason"`
+	ReasonCode string `json:"reasonCode"`
+}
+
+// EntryRequest is the JSON body for POST /api/admin/peers/allow and
+// POST /api/admin/peers/deny.
+type EntryRequest struct {
+	Host string `json:"host"`
+}
+
+// HandleOverview handles GET /api/admin/peers.
+func (h *Handler) HandleOverview(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.requireAdmin(w, r); !ok {
+		return
+	}
+
+	entries, err := h.repo.ListEntries(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list peer policy entries", "error", err)
+		api.WriteInternalError(w, "failed to list peer policy entries")
+
+		return
+	}
+
+	if entries == nil {
+		entries = []*peertrust.PolicyEntry{}
+	}
+
+	configured, _ := h.engine.Policies()
+
+	api.WriteJSON(w, h.logger, http.StatusOK, OverviewResponse{
+		Configured: ListsView{
+			AllowList: nonNil(configured.AllowList),
+			DenyList:  nonNil(configured.DenyList),
+		},
+		Runtime:     entries,
+		TrustGroups: h.trustGroupViews(),
+	})
+}
+
+// HandleDecision handles GET /api/admin/peers/decision?host=. The host is
+// evaluated as an unauthenticated peer, like an inbound request without a
+// verified signature.
+func (h *Handler) HandleDecision(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.requireAdmin(w, r); !ok {
+		return
+	}
+
+	host, ok := normalizeHost(w, r.URL.Query().Get("host"))
+	if !ok {
+		return
+	}
+
+	decision := h.engine.Evaluate(r.Context(), host, false)
+
+	api.WriteJSON(w, h.logger, http.StatusOK, DecisionResponse{
+		Host:       host,
+		Allowed:    decision.Allowed,
+		Reason:     decision.Reason,
+		ReasonCode: decision.ReasonCode,
+	})
+}
+
+// HandleAddAllow handles POST /api/admin/peers/allow.
+func (h *Handler) HandleAddAllow(w http.ResponseWriter, r *http.Request) {
+	h.addEntry(w, r, peertrust.ListAllow)
+}
+
+// HandleRemoveAllow handles DELETE /api/admin/peers/allow/{host}.
+func (h *Handler) HandleRemoveAllow(w http.ResponseWriter, r *http.Request) {
+	h.removeEntry(w, r, peertrust.ListAllow)
+}
+
+// HandleAddDeny handles POST /api/admin/peers/deny.
+func (h *Handler) HandleAddDeny(w http.ResponseWriter, r *http.Request) {
+	h.addEntry(w, r, peertrust.ListDeny)
+}
+
+// HandleRemoveDeny handles DELETE /api/admin/peers/deny/{host}.
+func (h *Handler) HandleRemoveDeny(w http.ResponseWriter, r *http.Request) {
+	h.removeEntry(w, r, peertrust.ListDeny)
+}
+
+func (h *Handler) addEntry(w http.ResponseWriter, r *http.Request, list string) {
+	admin, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var req EntryRequest
+	if !api.DecodeJSONBody(w, r, maxBodyBytes, &req) {
+		return
+	}
+
+	host, ok := normalizeHost(w, req.Host)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entry := &peertrust.PolicyEntry{List: list, Host: host}
+	if err := h.repo.CreateEntry(r.Context(), entry); err != nil {
+		h.writeRepoError(w, err, "failed to add peer policy entry")
+
+		return
+	}
+
+	if !h.applyEntries(w, r.Context()) {
+		return
+	}
+
+	h.logger.Info("admin added peer policy entry", "admin_id", admin.ID, "list", list, "host", host)
+
+	api.WriteJSON(w, h.logger, http.StatusCreated, entry)
+}
+
+func (h *Handler) removeEntry(w http.ResponseWriter, r *http.Request, list string) {
+	admin, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	host, ok := normalizeHost(w, chi.URLParam(r, "host"))
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.repo.DeleteEntry(r.Context(), list, host); err != nil {
+		h.writeRepoError(w, err, "failed to remove peer policy entry")
+
+		return
+	}
+
+	if !h.applyEntries(w, r.Context()) {
+		return
+	}
+
+	h.logger.Info("admin removed peer policy entry", "admin_id", admin.ID, "list", list, "host", host)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleAttachTrustGroup handles POST /api/admin/peers/trust-groups. The
+// body is a trust group document in the peer_trust.config_paths format.
+func (h *Handler) HandleAttachTrustGroup(w http.ResponseWriter, r *http.Request) {
+	admin, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	cfg, ok := decodeTrustGroup(w, r)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	group := &peertrust.AttachedTrustGroup{Config: cfg}
+	if err := h.repo.CreateTrustGroup(r.Context(), group); err != nil {
+		h.writeRepoError(w, err, "failed to attach trust group")
+
+		return
+	}
+
+	// The manager also knows the trust groups from config files, which the
+	// store does not.
+	if err := h.groups.AttachTrustGroup(cfg); err != nil {
+		if delErr := h.repo.DeleteTrustGroup(r.Context(), cfg.TrustGroupID); delErr != nil {
+			h.logger.Error("failed to roll back stored trust group",
+				"trust_group_id", cfg.TrustGroupID, "error", delErr)
+		}
+
+		h.writeRepoError(w, err, "failed to attach trust group")
+
+		return
+	}
+
+	h.logger.Info("admin attached trust group",
+		"admin_id", admin.ID, "trust_group_id", cfg.TrustGroupID, "enabled", cfg.Enabled)
+
+	status := &peertrust.TrustGroupStatus{Config: cfg, Source: peertrust.SourceAdmin}
+	api.WriteJSON(w, h.logger, http.StatusCreated, NewTrustGroupView(status))
+}
+
+// HandleDetachTrustGroup handles DELETE /api/admin/peers/trust-groups/{trustGroupId}.
+// Only trust groups attached through this API can be detached.
+func (h *Handler) HandleDetachTrustGroup(w http.ResponseWriter, r *http.Request) {
+	admin, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	trustGroupID := chi.URLParam(r, "trustGroupId")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.repo.DeleteTrustGroup(r.Context(), trustGroupID); err != nil {
+		h.writeRepoError(w, err, "failed to detach trust group")
+
+		return
+	}
+
+	// Not found here means a config file group with the same ID took its
+	// place; the stored copy was all there was to remove.
+	if err := h.groups.DetachTrustGroup(trustGroupID); err != nil && !errors.Is(err, peertrust.ErrTrustGroupNotFound) {
+		h.logger.Error("failed to detach trust group", "trust_group_id", trustGroupID, "error", err)
+		api.WriteInternalError(w, "failed to detach trust group")
+
+		return
+	}
+
+	h.logger.Info("admin detached trust group", "admin_id", admin.ID, "trust_group_id", trustGroupID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRefreshTrustGroups handles POST /api/admin/peers/trust-groups/refresh.
+// It refreshes every enabled trust group, ignoring the cache TTL, and
+// answers with the trust groups once the refreshes finish.
+func (h *Handler) HandleRefreshTrustGroups(w http.ResponseWriter, r *http.Request) {
+	admin, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	h.logger.Info("admin triggered trust group refresh", "admin_id", admin.ID)
+
+	h.groups.RefreshTrustGroups(r.Context())
+
+	api.WriteJSON(w, h.logger, http.StatusOK, TrustGroupsResponse{TrustGroups: h.trustGroupViews()})
+}
+
+// requireAdmin writes 401 without a session user, 403 for non-admins and
+// 503 when peer trust is disabled.
+func (h *Handler) requireAdmin(w http.ResponseWriter, r *http.Request) (*identity.User, bool) {
+	user, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return nil, false
+	}
+
+	if h.engine == nil || h.groups == nil || h.repo == nil {
+		api.WriteError(w, http.StatusServiceUnavailable, api.ReasonInternalError, "peer trust is disabled")
+
+		return nil, false
+	}
+
+	return user, true
+}
+
+// applyEntries reloads the runtime entries from the store into the engine.
+func (h *Handler) applyEntries(w http.ResponseWriter, ctx context.Context) bool {
+	entries, err := h.repo.ListEntries(ctx)
+	if err != nil {
+		h.logger.Error("failed to reload peer policy entries", "error", err)
+		api.WriteInternalError(w, "failed to reload peer policy entries")
+
+		return false
+	}
+
+	h.engine.SetStoredPolicy(peertrust.PolicyFromEntries(entries))
+
+	return true
+}
+
+func (h *Handler) trustGroupViews() []TrustGroupView {
+	statuses := h.groups.TrustGroupStatuses()
+
+	views := make([]TrustGroupView, 0, len(statuses))
+	for i := range statuses {
+		views = append(views, NewTrustGroupView(&statuses[i]))
+	}
+
+	return views
+}
+
+// writeRepoError maps peer trust errors to HTTP responses.
+func (h *Handler) writeRepoError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, peertrust.ErrEntryNotFound):
+		api.WriteNotFound(w, "host is not on the list")
+	case errors.Is(err, peertrust.ErrEntryExists):
+		api.WriteConflict(w, "host is already on the list")
+	case errors.Is(err, peertrust.ErrTrustGroupNotFound):
+		api.WriteNotFound(w, "trust group not found")
+	case errors.Is(err, peertrust.ErrTrustGroupExists):
+		api.WriteConflict(w, "trust group already exists")
+	default:
+		h.logger.Error(msg, "error", err)
+		api.WriteInternalError(w, msg)
+	}
+}
+
+// normalizeHost trims and lowercases a list host and rejects values that are
+// not a bare host or host:port, such as URLs and OCM addresses.
+func normalizeHost(w http.ResponseWriter, host string) (string, bool) {
+	host = strings.ToLower(strings.TrimSpace(host))
+
+	switch {
+	case host == "":
+		api.WriteBadRequest(w, api.ReasonMissingField, "host is required")
+	case strings.ContainsAny(host, "/@ \t"):
+		api.WriteBadRequest(w, api.ReasonInvalidField, "host must be a bare host or host:port, without scheme, path or '@'")
+	default:
+		return host, true
+	}
+
+	return "", false
+}
+
+func decodeTrustGroup(w http.ResponseWriter, r *http.Request) (*peertrust.TrustGroupConfig, bool) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		api.WriteBadRequest(w, api.ReasonBadRequest, "invalid JSON body")
+
+		return nil, false
+	}
+
+	cfg, err := peertrust.ParseTrustGroupConfig(data)
+	if err != nil {
+		api.WriteBadRequest(w, api.ReasonBadRequest, err.Error())
+
+		return nil, false
+	}
+
+	cfg.TrustGroupID = strings.TrimSpace(cfg.TrustGroupID)
+
+	switch {
+	case cfg.TrustGroupID == "":
+		api.WriteBadRequest(w, api.ReasonMissingField, "trustGroupId is required")
+	case strings.ContainsAny(cfg.TrustGroupID, "/ \t"):
+		api.WriteBadRequest(w, api.ReasonInvalidField, "trustGroupId must not contain '/' or whitespace")
+	default:
+		return cfg, true
+	}
+
+	return nil, false
+}
+
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+
+	return list
+}
@@ -0,0 +1,340 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package peers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/peers"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/directoryservice"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
+	httpclient "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/client"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+)
+
+type fixture struct {
+	repos  *repos.Repos
+	engine *peertrust.PolicyEngine
+	groups *peertrust.TrustGroupManager
+	router chi.Router
+}
+
+// newFixture seeds an admin and a regular user, configures one allowlist
+// host and one trust group from "config", and routes requests as the user
+// with callerID ("" for no session).
+func newFixture(t *testing.T, callerID string) *fixture {
+	t.Helper()
+
+	return buildFixture(t, callerID, true)
+}
+
+func buildFixture(t *testing.T, callerID string, peerTrust bool) *fixture {
+	t.Helper()
+
+	f := &fixture{repos: tsrepos.OpenMemory(t)}
+
+	for _, u := range []*identity.User{
+		{ID: "admin-id", Username: "admin", Role: identity.RoleAdmin},
+		{ID: "alice-id", Username: "alice", Role: identity.RoleUser},
+	} {
+		u.CreatedAt = time.Now()
+		if err := f.repos.Parties.Create(t.Context(), u); err != nil {
+			t.Fatalf("seed %s: %v", u.Username, err)
+		}
+	}
+
+	currentUser := func(ctx context.Context) (*identity.User, error) {
+		if callerID == "" {
+			return nil, errors.New("no session")
+		}
+
+		return f.repos.Parties.Get(ctx, callerID)
+	}
+
+	var h *peers.Handler
+
+	if peerTrust {
+		dirClient := directoryservice.NewClient(httpclient.New(tshttp.PermissiveConfig(), nil), "off", nil)
+		f.groups = peertrust.NewTrustGroupManager(peertrust.DefaultCacheConfig(), dirClient, "https", nil, 10*time.Second)
+		f.groups.AddTrustGroup(&peertrust.TrustGroupConfig{TrustGroupID: "configured-tg"})
+		f.engine = peertrust.NewPolicyEngine(&peertrust.PolicyConfig{AllowList: []string{"config.example.com"}}, f.groups, nil)
+		h = peers.NewHandler(f.engine, f.groups, f.repos.PeerTrust, currentUser, nil)
+	} else {
+		h = peers.NewHandler(nil, nil, nil, currentUser, nil)
+	}
+
+	r := chi.NewRouter()
+	r.Get("/api/admin/peers", h.HandleOverview)
+	r.Get("/api/admin/peers/decision", h.HandleDecision)
+	r.Post("/api/admin/peers/allow", h.HandleAddAllow)
+	r.Delete("/api/admin/peers/allow/{host}", h.HandleRemoveAllow)
+	r.Post("/api/admin/peers/deny", h.HandleAddDeny)
+	r.Delete("/api/admin/peers/deny/{host}", h.HandleRemoveDeny)
+	r.Post("/api/admin/peers/trust-groups", h.HandleAttachTrustGroup)
+	r.Post("/api/admin/peers/trust-groups/refresh", h.HandleRefreshTrustGroups)
+	r.Delete("/api/admin/peers/trust-groups/{trustGroupId}", h.HandleDetachTrustGroup)
+	f.router = r
+
+	return f
+}
+
+func (f *fixture) do(t *testing.T, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func (f *fixture) decision(t *testing.T, host string) peers.DecisionResponse {
+	t.Helper()
+
+	w := f.do(t, http.MethodGet, "/api/admin/peers/decision?host="+host, "")
+	requireStatus(t, w, http.StatusOK)
+
+	var got peers.DecisionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	return got
+}
+
+func (f *fixture) overview(t *testing.T) peers.OverviewResponse {
+	t.Helper()
+
+	w := f.do(t, http.MethodGet, "/api/admin/peers", "")
+	requireStatus(t, w, http.StatusOK)
+
+	var got peers.OverviewResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	return got
+}
+
+func requireStatus(t *testing.T, w *httptest.ResponseRecorder, want int) {
+	t.Helper()
+
+	if w.Code != want {
+		t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body.String())
+	}
+}
+
+func requireReason(t *testing.T, w *httptest.ResponseRecorder, want string) {
+	t.Helper()
+
+	var body api.ErrorEnvelope
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+
+	if body.Error.ReasonCode != want {
+		t.Fatalf("reasonCode = %q, want %q", body.Error.ReasonCode, want)
+	}
+}
+
+func TestAdminPeers_RequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	endpoints := []struct{ method, target, body string }{
+		{http.MethodGet, "/api/admin/peers", ""},
+		{http.MethodGet, "/api/admin/peers/decision?host=a.example.com", ""},
+		{http.MethodPost, "/api/admin/peers/allow", `{"host":"a.example.com"}`},
+		{http.MethodDelete, "/api/admin/peers/allow/a.example.com", ""},
+		{http.MethodPost, "/api/admin/peers/deny", `{"host":"a.example.com"}`},
+		{http.MethodDelete, "/api/admin/peers/deny/a.example.com", ""},
+		{http.MethodPost, "/api/admin/peers/trust-groups", `{"trustGroupId":"tg"}`},
+		{http.MethodDelete, "/api/admin/peers/trust-groups/tg", ""},
+		{http.MethodPost, "/api/admin/peers/trust-groups/refresh", ""},
+	}
+
+	for _, ep := range endpoints {
+		t.Run(ep.method+" "+ep.target, func(t *testing.T) {
+			t.Parallel()
+
+			w := newFixture(t, "").do(t, ep.method, ep.target, ep.body)
+			requireStatus(t, w, http.StatusUnauthorized)
+			requireReason(t, w, api.ReasonUnauthenticated)
+
+			w = newFixture(t, "alice-id").do(t, ep.method, ep.target, ep.body)
+			requireStatus(t, w, http.StatusForbidden)
+			requireReason(t, w, api.ReasonUnauthorized)
+
+			w = buildFixture(t, "admin-id", false).do(t, ep.method, ep.target, ep.body)
+			requireStatus(t, w, http.StatusServiceUnavailable)
+		})
+	}
+}
+
+func TestAdminPeers_DenyEntryLifecycle(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	if got := f.decision(t, "config.example.com"); !got.Allowed || got.ReasonCode != "allowed_by_allowlist" {
+		t.Fatalf("configured host decision = %+v", got)
+	}
+
+	w := f.do(t, http.MethodPost, "/api/admin/peers/deny", `{"host":" Config.Example.com "}`)
+	requireStatus(t, w, http.StatusCreated)
+
+	if got := f.decision(t, "config.example.com"); got.Allowed || got.ReasonCode != "denied_by_denylist" {
+		t.Errorf("decision after deny = %+v, want denied_by_denylist", got)
+	}
+
+	if !f.engine.HasDenylist() {
+		t.Error("HasDenylist = false after a runtime deny entry; discovery would not advertise it")
+	}
+
+	requireStatus(t, f.do(t, http.MethodPost, "/api/admin/peers/deny", `{"host":"config.example.com"}`), http.StatusConflict)
+
+	stored, err := f.repos.PeerTrust.ListEntries(t.Context())
+	if err != nil || len(stored) != 1 || stored[0].List != peertrust.ListDeny || stored[0].Host != "config.example.com" {
+		t.Fatalf("stored entries = %+v, %v", stored, err)
+	}
+
+	ov := f.overview(t)
+	if !slices.Equal(ov.Configured.AllowList, []string{"config.example.com"}) || len(ov.Configured.DenyList) != 0 {
+		t.Errorf("configured lists = %+v", ov.Configured)
+	}
+
+	if len(ov.Runtime) != 1 || ov.Runtime[0].Host != "config.example.com" {
+		t.Errorf("runtime entries = %+v", ov.Runtime)
+	}
+
+	requireStatus(t, f.do(t, http.MethodDelete, "/api/admin/peers/deny/config.example.com", ""), http.StatusNoContent)
+	requireStatus(t, f.do(t, http.MethodDelete, "/api/admin/peers/deny/config.example.com", ""), http.StatusNotFound)
+
+	if got := f.decision(t, "config.example.com"); !got.Allowed {
+		t.Errorf("decision after removing the deny entry = %+v", got)
+	}
+
+	if f.engine.HasDenylist() {
+		t.Error("HasDenylist = true after the last deny entry was removed")
+	}
+}
+
+func TestAdminPeers_AllowEntry(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	if got := f.decision(t, "new.example.com:8443"); got.Allowed {
+		t.Fatalf("unknown host decision = %+v", got)
+	}
+
+	requireStatus(t, f.do(t, http.MethodPost, "/api/admin/peers/allow", `{"host":"new.example.com:8443"}`), http.StatusCreated)
+
+	if got := f.decision(t, "new.example.com:8443"); !got.Allowed || got.ReasonCode != "allowed_by_allowlist" {
+		t.Errorf("decision after allow = %+v", got)
+	}
+
+	// Allow and deny are separate lists.
+	requireStatus(t, f.do(t, http.MethodDelete, "/api/admin/peers/deny/new.example.com:8443", ""), http.StatusNotFound)
+	requireStatus(t, f.do(t, http.MethodDelete, "/api/admin/peers/allow/new.example.com:8443", ""), http.StatusNoContent)
+}
+
+func TestAdminPeers_RejectsInvalidHosts(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	for _, host := range []string{"", "https://a.example.com", "alice@a.example.com", "a.example.com/path", "a b"} {
+		body, _ := json.Marshal(peers.EntryRequest{Host: host})
+
+		w := f.do(t, http.MethodPost, "/api/admin/peers/allow", string(body))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("host %q: status = %d, want 400", host, w.Code)
+		}
+	}
+
+	requireStatus(t, f.do(t, http.MethodGet, "/api/admin/peers/decision", ""), http.StatusBadRequest)
+	requireStatus(t, f.do(t, http.MethodPost, "/api/admin/peers/allow", `{`), http.StatusBadRequest)
+}
+
+func TestAdminPeers_TrustGroupLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"federation":"fed","servers":[{"url":"https://member.example.com","displayName":"Member"}]}`))
+	}))
+	defer ts.Close()
+
+	f := newFixture(t, "admin-id")
+
+	// A config file group owns its ID.
+	w := f.do(t, http.MethodPost, "/api/admin/peers/trust-groups", `{"trustGroupId":"configured-tg"}`)
+	requireStatus(t, w, http.StatusConflict)
+
+	if stored, err := f.repos.PeerTrust.ListTrustGroups(t.Context()); err != nil || len(stored) != 0 {
+		t.Fatalf("conflicting attach left stored groups %+v, %v", stored, err)
+	}
+
+	requireStatus(t, f.do(t, http.MethodPost, "/api/admin/peers/trust-groups", `{"trustGroupId":"tg","bogus":true}`), http.StatusBadRequest)
+	requireStatus(t, f.do(t, http.MethodPost, "/api/admin/peers/trust-groups", `{"enabled":true}`), http.StatusBadRequest)
+
+	body := `{"trustGroupId":"runtime-tg","enabled":true,"directoryServices":[{"url":"` + ts.URL + `","enabled":true,"verification":"off"}]}`
+	requireStatus(t, f.do(t, http.MethodPost, "/api/admin/peers/trust-groups", body), http.StatusCreated)
+	requireStatus(t, f.do(t, http.MethodPost, "/api/admin/peers/trust-groups", body), http.StatusConflict)
+
+	w = f.do(t, http.MethodPost, "/api/admin/peers/trust-groups/refresh", "")
+	requireStatus(t, w, http.StatusOK)
+
+	var refreshed peers.TrustGroupsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &refreshed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if len(refreshed.TrustGroups) != 2 {
+		t.Fatalf("trust groups = %+v, want configured-tg and runtime-tg", refreshed.TrustGroups)
+	}
+
+	runtimeTG := refreshed.TrustGroups[1]
+	if runtimeTG.TrustGroupID != "runtime-tg" || runtimeTG.Source != peertrust.SourceAdmin || runtimeTG.Members != 1 || runtimeTG.LastRefresh == nil {
+		t.Errorf("runtime trust group = %+v", runtimeTG)
+	}
+
+	if refreshed.TrustGroups[0].Source != peertrust.SourceConfig {
+		t.Errorf("configured trust group source = %q", refreshed.TrustGroups[0].Source)
+	}
+
+	if got := f.decision(t, "member.example.com"); !got.Allowed || got.ReasonCode != "allowed_by_federation" {
+		t.Errorf("member decision = %+v", got)
+	}
+
+	requireStatus(t, f.do(t, http.MethodDelete, "/api/admin/peers/trust-groups/configured-tg", ""), http.StatusNotFound)
+	requireStatus(t, f.do(t, http.MethodDelete, "/api/admin/peers/trust-groups/runtime-tg", ""), http.StatusNoContent)
+	requireStatus(t, f.do(t, http.MethodDelete, "/api/admin/peers/trust-groups/runtime-tg", ""), http.StatusNotFound)
+
+	if got := f.decision(t, "member.example.com"); got.Allowed {
+		t.Errorf("member decision after detach = %+v", got)
+	}
+
+	if ov := f.overview(t); len(ov.TrustGroups) != 1 || ov.TrustGroups[0].TrustGroupID != "configured-tg" {
+		t.Errorf("trust groups after detach = %+v", ov.TrustGroups)
+	}
+}
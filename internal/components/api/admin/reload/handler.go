@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package reload provides the admin-only handler for
+// POST /api/admin/config/reload (re-read the config and apply the settings
+// that do not need a restart).
+package reload
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// Reloader applies a config reload; wiring.Reloader implements it.
+type Reloader interface {
+	Reload() (config.ReloadReport, error)
+}
+
+// Handler serves POST /api/admin/config/reload. It requires an admin or
+// super admin session.
+type Handler struct {
+	reloader    Reloader
+	currentUser func(context.Context) (*identity.User, error)
+	logger      *slog.Logger
+}
+
+// NewHandler returns a Handler. A nil reloader answers 503 to admins.
+func NewHandler(
+	reloader Reloader,
+	currentUser func(context.Context) (*identity.User, error),
+	logger *slog.Logger,
+) *Handler {
+	return &Handler{
+		reloader:    reloader,
+		currentUser: currentUser,
+		logger:      logutil.NoopIfNil(logger),
+	}
+}
+
+// HandleReload handles POST /api/admin/config/reload. The response is the
+// reload report: the reloadable keys that changed and the changed keys that
+// need a restart. A config that fails to load or validate is answered with
+// 422 and nothing is applied.
+func (h *Handler) HandleReload(w http.ResponseWriter, r *http.Request) {
+	user, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return
+	}
+
+	if h.reloader == nil {
+		api.WriteError(w, http.StatusServiceUnavailable, api.ReasonInternalError, "config reload unavailable")
+
+		return
+	}
+
+	h.logger.Info("admin triggered configuration reload", "user_id", user.ID)
+
+	report, err := h.reloader.Reload()
+	if err != nil {
+		h.logger.Error("configuration reload failed; keeping the running configuration", "error", err)
+		api.WriteError(w, http.StatusUnprocessableEntity, api.ReasonBadRequest, err.Error())
+
+		return
+	}
+
+	api.WriteJSON(w, h.logger, http.StatusOK, report)
+}
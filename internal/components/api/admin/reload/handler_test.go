@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package reload_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/reload"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+)
+
+type fakeReloader struct {
+	report config.ReloadReport
+	err    error
+	calls  int
+}
+
+func (f *fakeReloader) Reload() (config.ReloadReport, error) {
+	f.calls++
+
+	return f.report, f.err
+}
+
+func serve(t *testing.T, reloader reload.Reloader, caller *identity.User) *httptest.ResponseRecorder {
+	t.Helper()
+
+	currentUser := func(context.Context) (*identity.User, error) {
+		if caller == nil {
+			return nil, errors.New("no session")
+		}
+
+		return caller, nil
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/admin/config/reload", nil)
+	reload.NewHandler(reloader, currentUser, nil).HandleReload(w, r)
+
+	return w
+}
+
+func TestHandleReload_ReturnsReport(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeReloader{report: config.ReloadReport{
+		Applied:         []string{"logging.level"},
+		RestartRequired: []string{"listen_addr"},
+	}}
+
+	w := serve(t, fake, &identity.User{ID: "admin-id", Role: identity.RoleAdmin})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var got config.ReloadReport
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+
+	if !slices.Equal(got.Applied, fake.report.Applied) || !slices.Equal(got.RestartRequired, fake.report.RestartRequired) {
+		t.Errorf("report = %+v, want %+v", got, fake.report)
+	}
+}
+
+func TestHandleReload_InvalidConfigIs422(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeReloader{err: errors.New("reload config: invalid mode")}
+
+	w := serve(t, fake, &identity.User{ID: "admin-id", Role: identity.RoleSuperAdmin})
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandleReload_RequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		reloader reload.Reloader
+		caller   *identity.User
+		want     int
+	}{
+		{"anonymous", &fakeReloader{}, nil, http.StatusUnauthorized},
+		{"user", &fakeReloader{}, &identity.User{ID: "alice-id", Role: identity.RoleUser}, http.StatusForbidden},
+		{"reload unavailable", nil, &identity.User{ID: "admin-id", Role: identity.RoleAdmin}, http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range cases {
+		if w := serve(t, tc.reloader, tc.caller); w.Code != tc.want {
+			t.Errorf("%s: got %d, want %d", tc.name, w.Code, tc.want)
+		}
+
+		if fake, ok := tc.reloader.(*fakeReloader); ok && fake.calls != 0 {
+			t.Errorf("%s: rejected request triggered a reload", tc.name)
+		}
+	}
+}
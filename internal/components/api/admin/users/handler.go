@@ -0,0 +1,509 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package users provides the admin-only handlers for /api/admin/users
+// (create, list, reset password, change role, delete, and expire probe users).
+package users
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+const (
+	// maxBodyBytes caps admin request bodies.
+	maxBodyBytes = 8 << 10
+	// minPasswordLength is the shortest password an admin may set.
+	minPasswordLength = 8
+)
+
+// Handler serves /api/admin/users. Every endpoint requires an admin or super
+// admin session; super admins cannot be deleted, demoted, or re-roled.
+type Handler struct {
+	repo        identity.PartyRepo
+	sessions    identity.SessionRepo
+	auth        *identity.UserAuth
+	currentUser func(context.Context) (*identity.User, error)
+	logger      *slog.Logger
+	// storageDir is the absolute directory storage roots must live under;
+	// empty rejects every storage root.
+	storageDir string
+}
+
+// NewHandler returns a Handler with the given identity components. Storage
+// roots set through the API must be directories under storageDir, the
+// directory datatx writes received transfers into.
+func NewHandler(
+	repo identity.PartyRepo,
+	sessions identity.SessionRepo,
+	auth *identity.UserAuth,
+	storageDir string,
+	currentUser func(context.Context) (*identity.User, error),
+	logger *slog.Logger,
+) *Handler {
+	if storageDir != "" {
+		if abs, err := filepath.Abs(storageDir); err == nil {
+			storageDir = abs
+		} else {
+			storageDir = ""
+		}
+	}
+
+	return &Handler{
+		repo:        repo,
+		sessions:    sessions,
+		auth:        auth,
+		currentUser: currentUser,
+		logger:      logutil.NoopIfNil(logger),
+		storageDir:  storageDir,
+	}
+}
+
+// UserView is the admin API representation of a user; the password hash is
+// never included.
+type UserView struct {
+	ID          string     `json:"id"`
+	Username    string     `json:"username"`
+	Email       string     `json:"email"`
+	DisplayName string     `json:"displayName"`
+	Role        string     `json:"role"`
+	Realm       string     `json:"realm"`
+	StorageRoot string     `json:"storageRoot"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+// NewUserView maps a user to its admin API view.
+func NewUserView(u *identity.User) UserView {
+	return UserView{
+		ID:          u.ID,
+		Username:    u.Username,
+		Email:       u.Email,
+		DisplayName: u.DisplayName,
+		Role:        u.Role,
+		Realm:       u.Realm,
+		StorageRoot: u.StorageRoot,
+		CreatedAt:   u.CreatedAt,
+		ExpiresAt:   u.ExpiresAt,
+	}
+}
+
+// ListResponse is the JSON body for GET /api/admin/users.
+type ListResponse struct {
+	Users []UserView `json:"users"`
+}
+
+// CreateRequest is the JSON body for POST /api/admin/users. Role defaults to
+// user; ExpiresAt applies to probe users only and defaults to
+// identity.ProbeUserTTL from now.
+type CreateRequest struct {
+	Username    string     `json:"username"`
+	Password    string     `json:"password"`
+	Email       string     `json:"email"`
+	DisplayName string     `json:"displayName"`
+	Role        string     `json:"role"`
+	Realm       string     `json:"realm"`
+	StorageRoot string     `json:"storageRoot"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+}
+
+// PasswordRequest is the JSON body for POST /api/admin/users/{userId}/password.
+type PasswordRequest struct {
+	Password string `json:"password"`
+}
+
+// RoleRequest is the JSON body for PUT /api/admin/users/{userId}/role.
+type RoleRequest struct {
+	Role string `json:"role"`
+}
+
+// ExpireProbesResponse is the JSON body for POST /api/admin/users/expire-probes.
+type ExpireProbesResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// HandleList handles GET /api/admin/users; the optional realm query parameter
+// narrows the list to one realm. Users are ordered by username.
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	if _, ok := api.RequireAdmin(w, r, h.currentUser); !ok {
+		return
+	}
+
+	all, err := h.repo.List(r.Context(), r.URL.Query().Get("realm"))
+	if err != nil {
+		h.logger.Error("failed to list users", "error", err)
+		api.WriteInternalError(w, "failed to list users")
+
+		return
+	}
+
+	slices.SortFunc(all, func(a, b *identity.User) int {
+		return strings.Compare(a.Username, b.Username)
+	})
+
+	views := make([]UserView, 0, len(all))
+	for _, u := range all {
+		views = append(views, NewUserView(u))
+	}
+
+	api.WriteJSON(w, h.logger, http.StatusOK, ListResponse{Users: views})
+}
+
+// HandleCreate handles POST /api/admin/users.
+func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	admin, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return
+	}
+
+	var req CreateRequest
+	if !api.DecodeJSONBody(w, r, maxBodyBytes, &req) {
+		return
+	}
+
+	user, ok := h.newUser(w, &req)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Create(r.Context(), user); err != nil {
+		h.writeRepoError(w, err, "failed to create user")
+
+		return
+	}
+
+	h.logger.Info("admin created user",
+		"admin_id", admin.ID, "user_id", user.ID, "username", user.Username, "role", user.Role)
+
+	api.WriteJSON(w, h.logger, http.StatusCreated, NewUserView(user))
+}
+
+// HandleResetPassword handles POST /api/admin/users/{userId}/password. The
+// user's existing sessions are revoked. Only a super admin may reset a super
+// admin's password.
+func (h *Handler) HandleResetPassword(w http.ResponseWriter, r *http.Request) {
+	admin, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return
+	}
+
+	var req PasswordRequest
+	if !api.DecodeJSONBody(w, r, maxBodyBytes, &req) || !validPassword(w, req.Password) {
+		return
+	}
+
+	user, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	if user.IsSuperAdmin() && !admin.IsSuperAdmin() {
+		api.WriteForbidden(w, api.ReasonNotAllowed, "only a super admin can reset a super admin password")
+
+		return
+	}
+
+	hash, err := h.auth.HashPassword(req.Password)
+	if err != nil {
+		h.logger.Error("failed to hash password", "error", err)
+		api.WriteInternalError(w, "failed to reset password")
+
+		return
+	}
+
+	user.PasswordHash = hash
+	if err := h.repo.Update(r.Context(), user); err != nil {
+		h.writeRepoError(w, err, "failed to reset password")
+
+		return
+	}
+
+	h.revokeSessions(r.Context(), user.ID)
+	h.logger.Info("admin reset user password", "admin_id", admin.ID, "user_id", user.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleChangeRole handles PUT /api/admin/users/{userId}/role. Admins cannot
+// change their own role, grant super_admin, or change a super admin's role.
+// Leaving the probe role clears the expiry; entering it sets the default one.
+func (h *Handler) HandleChangeRole(w http.ResponseWriter, r *http.Request) {
+	admin, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return
+	}
+
+	var req RoleRequest
+	if !api.DecodeJSONBody(w, r, maxBodyBytes, &req) || !validAssignableRole(w, req.Role) {
+		return
+	}
+
+	user, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	if user.ID == admin.ID {
+		api.WriteForbidden(w, api.ReasonNotAllowed, "cannot change your own role")
+
+		return
+	}
+
+	if user.Role != req.Role {
+		switch {
+		case req.Role == identity.RoleProbe:
+			expiresAt := time.Now().Add(identity.ProbeUserTTL)
+			user.ExpiresAt = &expiresAt
+		case user.Role == identity.RoleProbe:
+			user.ExpiresAt = nil
+		}
+	}
+
+	previous := user.Role
+	user.Role = req.Role
+
+	if err := h.repo.Update(r.Context(), user); err != nil {
+		h.writeRepoError(w, err, "failed to change role")
+
+		return
+	}
+
+	h.logger.Info("admin changed user role",
+		"admin_id", admin.ID, "user_id", user.ID, "from", previous, "to", user.Role)
+
+	api.WriteJSON(w, h.logger, http.StatusOK, NewUserView(user))
+}
+
+// HandleDelete handles DELETE /api/admin/users/{userId}. The user's sessions
+// are revoked; admins cannot delete themselves.
+func (h *Handler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	admin, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return
+	}
+
+	userID := chi.URLParam(r, "userId")
+	if userID == admin.ID {
+		api.WriteForbidden(w, api.ReasonNotAllowed, "cannot delete your own account")
+
+		return
+	}
+
+	if err := h.repo.Delete(r.Context(), userID); err != nil {
+		h.writeRepoError(w, err, "failed to delete user")
+
+		return
+	}
+
+	h.revokeSessions(r.Context(), userID)
+	h.logger.Info("admin deleted user", "admin_id", admin.ID, "user_id", userID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleExpireProbes handles POST /api/admin/users/expire-probes; it deletes
+// every probe user past its expiry and reports how many were removed.
+func (h *Handler) HandleExpireProbes(w http.ResponseWriter, r *http.Request) {
+	admin, ok := api.RequireAdmin(w, r, h.currentUser)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.repo.DeleteExpired(r.Context())
+	if err != nil {
+		h.logger.Error("failed to expire probe users", "error", err)
+		api.WriteInternalError(w, "failed to expire probe users")
+
+		return
+	}
+
+	h.logger.Info("admin expired probe users", "admin_id", admin.ID, "deleted", deleted)
+
+	api.WriteJSON(w, h.logger, http.StatusOK, ExpireProbesResponse{Deleted: deleted})
+}
+
+func (h *Handler) newUser(w http.ResponseWriter, req *CreateRequest) (*identity.User, bool) {
+	req.Username = strings.TrimSpace(req.Username)
+	if req.Username == "" {
+		api.WriteBadRequest(w, api.ReasonMissingField, "username is required")
+
+		return nil, false
+	}
+
+	if !validPassword(w, req.Password) {
+		return nil, false
+	}
+
+	if req.Role == "" {
+		req.Role = identity.RoleUser
+	}
+
+	if !validAssignableRole(w, req.Role) {
+		return nil, false
+	}
+
+	if !h.validStorageRoot(w, req) {
+		return nil, false
+	}
+
+	now := time.Now()
+
+	var expiresAt *time.Time
+
+	switch {
+	case req.Role != identity.RoleProbe && req.ExpiresAt != nil:
+		api.WriteBadRequest(w, api.ReasonInvalidField, "expiresAt is only allowed for probe users")
+
+		return nil, false
+	case req.Role == identity.RoleProbe && req.ExpiresAt == nil:
+		t := now.Add(identity.ProbeUserTTL)
+		expiresAt = &t
+	case req.Role == identity.RoleProbe:
+		if !req.ExpiresAt.After(now) {
+			api.WriteBadRequest(w, api.ReasonInvalidField, "expiresAt must be in the future")
+
+			return nil, false
+		}
+
+		expiresAt = req.ExpiresAt
+	}
+
+	hash, err := h.auth.HashPassword(req.Password)
+	if err != nil {
+		h.logger.Error("failed to hash password", "error", err)
+		api.WriteInternalError(w, "failed to create user")
+
+		return nil, false
+	}
+
+	id, err := identity.UUIDv7()
+	if err != nil {
+		h.logger.Error("failed to generate user id", "error", err)
+		api.WriteInternalError(w, "failed to create user")
+
+		return nil, false
+	}
+
+	return &identity.User{
+		ID:           id,
+		Username:     req.Username,
+		Email:        strings.TrimSpace(req.Email),
+		DisplayName:  req.DisplayName,
+		PasswordHash: hash,
+		Role:         req.Role,
+		Realm:        req.Realm,
+		StorageRoot:  req.StorageRoot,
+		CreatedAt:    now,
+		ExpiresAt:    expiresAt,
+	}, true
+}
+
+func (h *Handler) loadUser(w http.ResponseWriter, r *http.Request) (*identity.User, bool) {
+	user, err := h.repo.Get(r.Context(), chi.URLParam(r, "userId"))
+	if err != nil {
+		h.writeRepoError(w, err, "failed to load user")
+
+		return nil, false
+	}
+
+	return user, true
+}
+
+// revokeSessions drops every session of userID; failures are logged because
+// the primary change already succeeded.
+func (h *Handler) revokeSessions(ctx context.Context, userID string) {
+	if err := h.sessions.DeleteByUser(ctx, userID); err != nil {
+		h.logger.Warn("failed to revoke user sessions", "user_id", userID, "error", err)
+	}
+}
+
+// writeRepoError maps identity repo errors to HTTP responses.
+func (h *Handler) writeRepoError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, identity.ErrUserNotFound):
+		api.WriteNotFound(w, "user not found")
+	case errors.Is(err, identity.ErrUserExists):
+		api.WriteConflict(w, "username already exists")
+	case errors.Is(err, identity.ErrEmailExists):
+		api.WriteConflict(w, "email already in use")
+	case errors.Is(err, identity.ErrSuperAdminProtected), errors.Is(err, identity.ErrSuperAdminRoleChange):
+		api.WriteForbidden(w, api.ReasonNotAllowed, err.Error())
+	default:
+		h.logger.Error(msg, "error", err)
+		api.WriteInternalError(w, msg)
+	}
+}
+
+// validStorageRoot accepts an empty storage root or an absolute path without
+// ".." elements strictly under h.storageDir, and stores it cleaned.
+func (h *Handler) validStorageRoot(w http.ResponseWriter, req *CreateRequest) bool {
+	root := strings.TrimSpace(req.StorageRoot)
+	if root == "" {
+		req.StorageRoot = ""
+
+		return true
+	}
+
+	switch {
+	case !filepath.IsAbs(root):
+		api.WriteBadRequest(w, api.ReasonInvalidField, "storageRoot must be an absolute path")
+	case slices.Contains(strings.Split(filepath.ToSlash(root), "/"), ".."):
+		api.WriteBadRequest(w, api.ReasonInvalidField, "storageRoot must not contain '..'")
+	case h.storageDir == "":
+		api.WriteBadRequest(w, api.ReasonInvalidField, "storageRoot cannot be set: no storage directory is configured")
+	default:
+		root = filepath.Clean(root)
+
+		rel, err := filepath.Rel(h.storageDir, root)
+		if err != nil || rel == "." || !filepath.IsLocal(rel) {
+			api.WriteBadRequest(w, api.ReasonInvalidField, "storageRoot must be under "+h.storageDir)
+
+			return false
+		}
+
+		req.StorageRoot = root
+
+		return true
+	}
+
+	return false
+}
+
+// validAssignableRole accepts the roles an admin may grant; super_admin is
+// reserved for the bootstrap admin.
+func validAssignableRole(w http.ResponseWriter, role string) bool {
+	switch role {
+	case identity.RoleUser, identity.RoleAdmin, identity.RoleProbe:
+		return true
+	case "":
+		api.WriteBadRequest(w, api.ReasonMissingField, "role is required")
+	default:
+		api.WriteBadRequest(w, api.ReasonInvalidField, "role must be one of user, admin, probe")
+	}
+
+	return false
+}
+
+func validPassword(w http.ResponseWriter, password string) bool {
+	if len(password) < minPasswordLength {
+		api.WriteBadRequest(w, api.ReasonInvalidField, "password must be at least 8 characters")
+
+		return false
+	}
+
+	return true
+}
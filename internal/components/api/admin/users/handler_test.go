@@ -0,0 +1,430 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package users_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/users"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+)
+
+// testStorageDir is the storage directory storage roots must live under.
+const testStorageDir = "/srv/ocm/storage"
+
+type fixture struct {
+	repo     *identity.MemoryPartyRepo
+	sessions *identity.MemorySessionRepo
+	auth     *identity.UserAuth
+	router   chi.Router
+}
+
+// newFixture seeds a super admin, an admin and a regular user, and routes
+// requests as the user with callerID ("" for no session).
+func newFixture(t *testing.T, callerID string) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		repo:     identity.NewMemoryPartyRepo(),
+		sessions: identity.NewMemorySessionRepo(),
+		auth:     identity.NewUserAuthFast(),
+	}
+
+	for _, u := range []*identity.User{
+		{ID: "root-id", Username: "root", Role: identity.RoleSuperAdmin},
+		{ID: "admin-id", Username: "admin", Role: identity.RoleAdmin, Realm: "ops"},
+		{ID: "alice-id", Username: "alice", Email: "alice@example.org", Role: identity.RoleUser, Realm: "ops"},
+	} {
+		u.CreatedAt = time.Now()
+		if err := f.repo.Create(t.Context(), u); err != nil {
+			t.Fatalf("seed %s: %v", u.Username, err)
+		}
+	}
+
+	currentUser := func(ctx context.Context) (*identity.User, error) {
+		if callerID == "" {
+			return nil, errors.New("no session")
+		}
+
+		return f.repo.Get(ctx, callerID)
+	}
+
+	h := users.NewHandler(f.repo, f.sessions, f.auth, testStorageDir, currentUser, nil)
+
+	r := chi.NewRouter()
+	r.Get("/api/admin/users", h.HandleList)
+	r.Post("/api/admin/users", h.HandleCreate)
+	r.Post("/api/admin/users/expire-probes", h.HandleExpireProbes)
+	r.Post("/api/admin/users/{userId}/password", h.HandleResetPassword)
+	r.Put("/api/admin/users/{userId}/role", h.HandleChangeRole)
+	r.Delete("/api/admin/users/{userId}", h.HandleDelete)
+	f.router = r
+
+	return f
+}
+
+func (f *fixture) do(t *testing.T, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func requireStatus(t *testing.T, w *httptest.ResponseRecorder, want int) {
+	t.Helper()
+
+	if w.Code != want {
+		t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body.String())
+	}
+}
+
+func requireReason(t *testing.T, w *httptest.ResponseRecorder, want string) {
+	t.Helper()
+
+	var body api.ErrorEnvelope
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+
+	if body.Error.ReasonCode != want {
+		t.Fatalf("reasonCode = %q, want %q", body.Error.ReasonCode, want)
+	}
+}
+
+func TestAdminUsers_RequiresAdmin(t *testing.T) {
+	t.Parallel()
+
+	endpoints := []struct{ method, target, body string }{
+		{http.MethodGet, "/api/admin/users", ""},
+		{http.MethodPost, "/api/admin/users", `{"username":"bob","password":"password123"}`},
+		{http.MethodPost, "/api/admin/users/expire-probes", ""},
+		{http.MethodPost, "/api/admin/users/admin-id/password", `{"password":"password123"}`},
+		{http.MethodPut, "/api/admin/users/admin-id/role", `{"role":"user"}`},
+		{http.MethodDelete, "/api/admin/users/admin-id", ""},
+	}
+
+	for _, ep := range endpoints {
+		t.Run(ep.method+" "+ep.target, func(t *testing.T) {
+			t.Parallel()
+
+			w := newFixture(t, "").do(t, ep.method, ep.target, ep.body)
+			requireStatus(t, w, http.StatusUnauthorized)
+			requireReason(t, w, api.ReasonUnauthenticated)
+
+			w = newFixture(t, "alice-id").do(t, ep.method, ep.target, ep.body)
+			requireStatus(t, w, http.StatusForbidden)
+			requireReason(t, w, api.ReasonUnauthorized)
+		})
+	}
+}
+
+func TestAdminUsers_ListFiltersByRealm(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	w := f.do(t, http.MethodGet, "/api/admin/users", "")
+	requireStatus(t, w, http.StatusOK)
+
+	var all users.ListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &all); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	var names []string
+	for _, u := range all.Users {
+		names = append(names, u.Username)
+	}
+
+	if strings.Join(names, ",") != "admin,alice,root" {
+		t.Fatalf("users = %v, want sorted [admin alice root]", names)
+	}
+
+	if strings.Contains(w.Body.String(), "passwordHash") || strings.Contains(w.Body.String(), "PasswordHash") {
+		t.Fatalf("list leaked password hash: %s", w.Body.String())
+	}
+
+	w = f.do(t, http.MethodGet, "/api/admin/users?realm=ops", "")
+	requireStatus(t, w, http.StatusOK)
+
+	var ops users.ListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &ops); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if len(ops.Users) != 2 {
+		t.Fatalf("realm=ops returned %d users, want 2", len(ops.Users))
+	}
+}
+
+func TestAdminUsers_Create(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	w := f.do(t, http.MethodPost, "/api/admin/users",
+		`{"username":"bob","password":"password123","email":"bob@example.org","displayName":"Bob","realm":"ops","storageRoot":"/srv/ocm/storage/bob/"}`)
+	requireStatus(t, w, http.StatusCreated)
+
+	var view users.UserView
+	if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if view.ID == "" || view.Role != identity.RoleUser || view.ExpiresAt != nil {
+		t.Fatalf("created view = %+v, want id set, role user, no expiry", view)
+	}
+
+	stored, err := f.repo.GetByUsername(t.Context(), "bob")
+	if err != nil {
+		t.Fatalf("GetByUsername: %v", err)
+	}
+
+	if err := f.auth.VerifyPassword(stored.PasswordHash, "password123"); err != nil {
+		t.Fatalf("stored password hash does not verify: %v", err)
+	}
+
+	if stored.StorageRoot != "/srv/ocm/storage/bob" {
+		t.Fatalf("storage root = %q, want the cleaned path", stored.StorageRoot)
+	}
+}
+
+func TestAdminUsers_CreateProbeDefaultsExpiry(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	w := f.do(t, http.MethodPost, "/api/admin/users", `{"username":"probe","password":"password123","role":"probe"}`)
+	requireStatus(t, w, http.StatusCreated)
+
+	stored, err := f.repo.GetByUsername(t.Context(), "probe")
+	if err != nil {
+		t.Fatalf("GetByUsername: %v", err)
+	}
+
+	if stored.ExpiresAt == nil {
+		t.Fatal("probe user has no expiry")
+	}
+
+	if d := time.Until(*stored.ExpiresAt); d < identity.ProbeUserTTL-time.Minute || d > identity.ProbeUserTTL {
+		t.Fatalf("probe expiry in %v, want about %v", d, identity.ProbeUserTTL)
+	}
+}
+
+func TestAdminUsers_CreateRejects(t *testing.T) {
+	t.Parallel()
+
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		reason string
+	}{
+		{"invalid json", `{`, http.StatusBadRequest, api.ReasonBadRequest},
+		{"missing username", `{"password":"password123"}`, http.StatusBadRequest, api.ReasonMissingField},
+		{"short password", `{"username":"bob","password":"short"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"super admin role", `{"username":"bob","password":"password123","role":"super_admin"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"unknown role", `{"username":"bob","password":"password123","role":"owner"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"expiry on non-probe", `{"username":"bob","password":"password123","expiresAt":"` + future + `"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"probe expiry in past", `{"username":"bob","password":"password123","role":"probe","expiresAt":"` + past + `"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"relative storage root", `{"username":"bob","password":"password123","storageRoot":"srv/ocm/storage/bob"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"storage root with dot-dot", `{"username":"bob","password":"password123","storageRoot":"/srv/ocm/storage/bob/../../etc"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"storage root outside storage dir", `{"username":"bob","password":"password123","storageRoot":"/etc/bob"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"storage root is storage dir", `{"username":"bob","password":"password123","storageRoot":"/srv/ocm/storage"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"storage root sibling prefix", `{"username":"bob","password":"password123","storageRoot":"/srv/ocm/storage-other/bob"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"duplicate username", `{"username":"alice","password":"password123"}`, http.StatusConflict, api.ReasonConflict},
+		{"duplicate email", `{"username":"bob","password":"password123","email":"ALICE@example.org"}`, http.StatusConflict, api.ReasonConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := newFixture(t, "admin-id").do(t, http.MethodPost, "/api/admin/users", tt.body)
+			requireStatus(t, w, tt.status)
+			requireReason(t, w, tt.reason)
+		})
+	}
+}
+
+func TestAdminUsers_ResetPasswordRevokesSessions(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	session, err := f.sessions.Create(t.Context(), "alice-id", time.Hour)
+	if err != nil {
+		t.Fatalf("Create session: %v", err)
+	}
+
+	w := f.do(t, http.MethodPost, "/api/admin/users/alice-id/password", `{"password":"new-password"}`)
+	requireStatus(t, w, http.StatusNoContent)
+
+	stored, err := f.repo.Get(t.Context(), "alice-id")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if err := f.auth.VerifyPassword(stored.PasswordHash, "new-password"); err != nil {
+		t.Fatalf("new password does not verify: %v", err)
+	}
+
+	if _, err := f.sessions.Get(t.Context(), session.Token); err == nil {
+		t.Fatal("session survived password reset")
+	}
+
+	w = f.do(t, http.MethodPost, "/api/admin/users/missing/password", `{"password":"new-password"}`)
+	requireStatus(t, w, http.StatusNotFound)
+}
+
+func TestAdminUsers_ResetSuperAdminPassword(t *testing.T) {
+	t.Parallel()
+
+	w := newFixture(t, "admin-id").do(t, http.MethodPost, "/api/admin/users/root-id/password", `{"password":"new-password"}`)
+	requireStatus(t, w, http.StatusForbidden)
+	requireReason(t, w, api.ReasonNotAllowed)
+
+	w = newFixture(t, "root-id").do(t, http.MethodPost, "/api/admin/users/root-id/password", `{"password":"new-password"}`)
+	requireStatus(t, w, http.StatusNoContent)
+}
+
+func TestAdminUsers_ChangeRole(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	w := f.do(t, http.MethodPut, "/api/admin/users/alice-id/role", `{"role":"probe"}`)
+	requireStatus(t, w, http.StatusOK)
+
+	stored, err := f.repo.Get(t.Context(), "alice-id")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if stored.Role != identity.RoleProbe || stored.ExpiresAt == nil {
+		t.Fatalf("after probe: role=%q expiresAt=%v, want probe with expiry", stored.Role, stored.ExpiresAt)
+	}
+
+	w = f.do(t, http.MethodPut, "/api/admin/users/alice-id/role", `{"role":"admin"}`)
+	requireStatus(t, w, http.StatusOK)
+
+	stored, err = f.repo.Get(t.Context(), "alice-id")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if stored.Role != identity.RoleAdmin || stored.ExpiresAt != nil {
+		t.Fatalf("after admin: role=%q expiresAt=%v, want admin without expiry", stored.Role, stored.ExpiresAt)
+	}
+}
+
+func TestAdminUsers_ChangeRoleRejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		status int
+		reason string
+	}{
+		{"super admin target", "root-id", `{"role":"admin"}`, http.StatusForbidden, api.ReasonNotAllowed},
+		{"own role", "admin-id", `{"role":"user"}`, http.StatusForbidden, api.ReasonNotAllowed},
+		{"grant super admin", "alice-id", `{"role":"super_admin"}`, http.StatusBadRequest, api.ReasonInvalidField},
+		{"missing role", "alice-id", `{}`, http.StatusBadRequest, api.ReasonMissingField},
+		{"unknown user", "missing", `{"role":"user"}`, http.StatusNotFound, api.ReasonNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := newFixture(t, "admin-id").do(t, http.MethodPut, "/api/admin/users/"+tt.target+"/role", tt.body)
+			requireStatus(t, w, tt.status)
+			requireReason(t, w, tt.reason)
+		})
+	}
+}
+
+func TestAdminUsers_Delete(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	if _, err := f.sessions.Create(t.Context(), "alice-id", time.Hour); err != nil {
+		t.Fatalf("Create session: %v", err)
+	}
+
+	w := f.do(t, http.MethodDelete, "/api/admin/users/alice-id", "")
+	requireStatus(t, w, http.StatusNoContent)
+
+	if _, err := f.repo.Get(t.Context(), "alice-id"); !errors.Is(err, identity.ErrUserNotFound) {
+		t.Fatalf("Get after delete = %v, want ErrUserNotFound", err)
+	}
+
+	w = f.do(t, http.MethodDelete, "/api/admin/users/alice-id", "")
+	requireStatus(t, w, http.StatusNotFound)
+
+	w = f.do(t, http.MethodDelete, "/api/admin/users/root-id", "")
+	requireStatus(t, w, http.StatusForbidden)
+	requireReason(t, w, api.ReasonNotAllowed)
+
+	w = f.do(t, http.MethodDelete, "/api/admin/users/admin-id", "")
+	requireStatus(t, w, http.StatusForbidden)
+	requireReason(t, w, api.ReasonNotAllowed)
+}
+
+func TestAdminUsers_ExpireProbes(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, "admin-id")
+
+	expired := time.Now().Add(-time.Minute)
+	live := time.Now().Add(time.Hour)
+
+	for _, u := range []*identity.User{
+		{ID: "probe-old", Username: "probe-old", Role: identity.RoleProbe, ExpiresAt: &expired},
+		{ID: "probe-new", Username: "probe-new", Role: identity.RoleProbe, ExpiresAt: &live},
+	} {
+		if err := f.repo.Create(t.Context(), u); err != nil {
+			t.Fatalf("seed %s: %v", u.Username, err)
+		}
+	}
+
+	w := f.do(t, http.MethodPost, "/api/admin/users/expire-probes", "")
+	requireStatus(t, w, http.StatusOK)
+
+	var resp users.ExpireProbesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if resp.Deleted != 1 {
+		t.Fatalf("deleted = %d, want 1", resp.Deleted)
+	}
+
+	if _, err := f.repo.Get(t.Context(), "probe-new"); err != nil {
+		t.Fatalf("live probe removed: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	t.Parallel()
+
+	admin := &identity.User{ID: "a", Role: identity.RoleAdmin}
+	user := &identity.User{ID: "u", Role: identity.RoleUser}
+
+	tests := []struct {
+		name   string
+		user   *identity.User
+		err    error
+		wantOK bool
+		status int
+	}{
+		{name: "no session", err: errors.New("no session"), status: http.StatusUnauthorized},
+		{name: "non-admin", user: user, status: http.StatusForbidden},
+		{name: "admin", user: admin, wantOK: true, status: http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/admin/users", nil)
+
+			got, ok := api.RequireAdmin(w, r, func(context.Context) (*identity.User, error) {
+				return tc.user, tc.err
+			})
+			if ok != tc.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tc.wantOK)
+			}
+
+			if ok && got != tc.user {
+				t.Errorf("user = %v, want %v", got, tc.user)
+			}
+
+			if w.Code != tc.status {
+				t.Errorf("status = %d, want %d", w.Code, tc.status)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONBody(t *testing.T) {
+	t.Parallel()
+
+	var dst struct {
+		Name string `json:"name"`
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"x"}`))
+
+	if !api.DecodeJSONBody(w, r, 64, &dst) || dst.Name != "x" {
+		t.Fatalf("valid body rejected: ok=false or name=%q", dst.Name)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"`+strings.Repeat("x", 64)+`"}`))
+
+	if api.DecodeJSONBody(w, r, 64, &dst) {
+		t.Fatal("oversized body accepted")
+	}
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+
+	api.WriteJSON(w, slog.New(slog.DiscardHandler), http.StatusCreated, map[string]string{"id": "1"})
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("status = %d, want 201", w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	if got := strings.TrimSpace(w.Body.String()); got != `{"id":"1"}` {
+		t.Errorf("body = %s", got)
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/appctx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 )
 
 const (
@@ -27,6 +28,7 @@ type AuthHandler struct {
 	repo     identity.PartyRepo
 	sessions identity.SessionRepo
 	auth     *identity.UserAuth
+	audit    audit.Recorder
 }
 
 // NewAuthHandler returns an AuthHandler with the given identity components.
@@ -35,9 +37,15 @@ func NewAuthHandler(repo identity.PartyRepo, sessions identity.SessionRepo, auth
 		repo:     repo,
 		sessions: sessions,
 		auth:     auth,
+		audit:    audit.Nop(),
 	}
 }
 
+// SetAudit sets the audit recorder for admin logins; nil disables it.
+func (h *AuthHandler) SetAudit(r audit.Recorder) {
+	h.audit = audit.OrNop(r)
+}
+
 // LoginRequest carries the body for POST /api/auth/login.
 type LoginRequest struct {
 	Username string `json:"username"`
@@ -104,6 +112,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		h.auditFailedAdminLogin(r, req.Username)
 		writeJSONError(w, http.StatusUnauthorized, "invalid_credentials", "invalid username or password")
 
 		return
@@ -137,9 +146,34 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	resp.User.DisplayName = user.DisplayName
 	resp.User.Role = user.Role
 
+	if user.IsAdmin() {
+		h.audit.Record(ctx, audit.Event{
+			Action:   audit.ActionAdminLogin,
+			Outcome:  audit.OutcomeSuccess,
+			Actor:    user.Username,
+			Resource: user.ID,
+		})
+	}
+
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// auditFailedAdminLogin records a failed login when username names an admin
+// account. Failures for unknown or regular accounts are not audited.
+func (h *AuthHandler) auditFailedAdminLogin(r *http.Request, username string) {
+	user, err := h.repo.GetByUsername(r.Context(), username)
+	if err != nil || !user.IsAdmin() {
+		return
+	}
+
+	h.audit.Record(r.Context(), audit.Event{
+		Action:   audit.ActionAdminLogin,
+		Outcome:  audit.OutcomeFailure,
+		Actor:    user.Username,
+		Resource: user.ID,
+	})
+}
+
 // Logout handles POST /api/auth/logout and clears the session cookie.
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -179,11 +213,36 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 
 // GetCurrentUser handles GET /api/auth/me and returns the authenticated user.
 func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.sessionUser(w, r)
+	if !ok {
+		return
+	}
+
+	resp := struct {
+		ID          string `json:"id"`
+		Username    string `json:"username"`
+		DisplayName string `json:"displayName"`
+		Email       string `json:"email,omitempty"`
+		Role        string `json:"role"`
+	}{
+		ID:          user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		Role:        user.Role,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// sessionUser resolves the session token to its user. On failure it writes
+// the error response and returns false.
+func (h *AuthHandler) sessionUser(w http.ResponseWriter, r *http.Request) (*identity.User, bool) {
 	token := extractToken(r)
 	if token == "" {
 		writeJSONError(w, http.StatusUnauthorized, "no_session", "no session token provided")
 
-		return
+		return nil, false
 	}
 
 	ctx := r.Context()
@@ -194,12 +253,12 @@ func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 			appctx.GetLogger(ctx).Warn("session lookup failed", "error", err)
 			WriteInternalError(w, "internal server error")
 
-			return
+			return nil, false
 		}
 
 		writeJSONError(w, http.StatusUnauthorized, "invalid_session", "session expired or invalid")
 
-		return
+		return nil, false
 	}
 
 	user, err := h.repo.Get(ctx, session.UserID)
@@ -208,29 +267,15 @@ func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 			appctx.GetLogger(ctx).Warn("user lookup failed", "error", err)
 			WriteInternalError(w, "internal server error")
 
-			return
+			return nil, false
 		}
 
 		writeJSONError(w, http.StatusUnauthorized, "user_not_found", "user not found")
 
-		return
+		return nil, false
 	}
 
-	resp := struct {
-		ID          string `json:"id"`
-		Username    string `json:"username"`
-		DisplayName string `json:"displayName"`
-		Email       string `json:"email,omitempty"`
-		Role        string `json:"role"`
-	}{
-		ID:          user.ID,
-		Username:    user.Username,
-		DisplayName: user.DisplayName,
-		Email:       user.Email,
-		Role:        user.Role,
-	}
-
-	writeJSON(w, http.StatusOK, resp)
+	return user, true
 }
 
 // extractToken returns the session token from Authorization header or session cookie.
@@ -13,9 +13,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
 )
 
@@ -298,3 +300,68 @@ func TestAuthHandler_Login_ResponseContentType(t *testing.T) {
 		t.Errorf("expected application/json Content-Type, got %q", ct)
 	}
 }
+
+type recordedEvents struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func (r *recordedEvents) Record(_ context.Context, e audit.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, e)
+}
+
+func TestAuthHandler_Login_AuditsAdminAccounts(t *testing.T) {
+	t.Parallel()
+	handler, repo, _, auth := newTestAuthHandler(t)
+	seedUser(t, repo, auth, "alice", "secret123")
+
+	hash, err := auth.HashPassword("adminpass")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	admin := &identity.User{Username: "root", PasswordHash: hash, Role: identity.RoleAdmin}
+	if err := repo.Create(context.Background(), admin); err != nil {
+		t.Fatalf("Create admin: %v", err)
+	}
+
+	rec := &recordedEvents{}
+	handler.SetAudit(rec)
+
+	attempts := []struct {
+		username, password string
+		want               int
+	}{
+		{"root", "wrong", http.StatusUnauthorized},
+		{"root", "adminpass", http.StatusOK},
+		{"alice", "secret123", http.StatusOK},
+		{"alice", "wrong", http.StatusUnauthorized},
+		{"nobody", "wrong", http.StatusUnauthorized},
+	}
+
+	for _, a := range attempts {
+		body, _ := json.Marshal(LoginRequest{Username: a.username, Password: a.password}) //nolint:errchkjson // fixed struct of strings
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.Login(w, req)
+
+		if w.Code != a.want {
+			t.Fatalf("login %s/%s: got %d, want %d", a.username, a.password, w.Code, a.want)
+		}
+	}
+
+	if len(rec.events) != 2 {
+		t.Fatalf("audited %d logins, want only the 2 admin attempts: %+v", len(rec.events), rec.events)
+	}
+
+	for i, wantOutcome := range []string{audit.OutcomeFailure, audit.OutcomeSuccess} {
+		e := rec.events[i]
+		if e.Action != audit.ActionAdminLogin || e.Actor != "root" || e.Resource != admin.ID || e.Outcome != wantOutcome {
+			t.Errorf("event %d = %+v, want admin.login by root with outcome %s", i, e, wantOutcome)
+		}
+	}
+}
@@ -21,9 +21,12 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
 	invitesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // InboxInviteView carries the API view fields for one inbox invite.
@@ -60,6 +63,8 @@ type Handler struct {
 	localScheme   string // scheme from PublicOrigin for sender host comparison normalization
 	currentUser   func(context.Context) (*identity.User, error)
 	log           *slog.Logger
+	outbox        *outbox.Dispatcher
+	audit         audit.Recorder
 }
 
 // NewHandler returns a Handler with the given dependencies.
@@ -80,9 +85,15 @@ func NewHandler(
 		localScheme:   localScheme,
 		currentUser:   currentUser,
 		log:           log,
+		audit:         audit.Nop(),
 	}
 }
 
+// SetAudit sets the audit recorder for accepted invites; nil disables it.
+func (h *Handler) SetAudit(r audit.Recorder) {
+	h.audit = audit.OrNop(r)
+}
+
 // HandleList handles GET /api/inbox/invites; returns only invites for the authenticated user.
 func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
 	user, err := h.currentUser(r.Context())
@@ -178,6 +189,8 @@ func (h *Handler) HandleImport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics.InviteTransition(metrics.DirectionIncoming, string(invite.Status))
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 
@@ -224,14 +237,7 @@ func (h *Handler) HandleAccept(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if invite.Status == invites.InviteStatusAccepted {
-		w.Header().Set("Content-Type", "application/json")
-
-		if encErr := json.NewEncoder(w).Encode(map[string]string{
-			jsonKeyStatus:   string(invites.InviteStatusAccepted),
-			jsonKeyInviteID: inviteID,
-		}); encErr != nil {
-			h.log.Error("failed to encode accepted invite", "error", encErr)
-		}
+		h.writeAcceptStatus(w, http.StatusOK, inviteID, invites.InviteStatusAccepted)
 
 		return
 	}
@@ -261,6 +267,12 @@ func (h *Handler) HandleAccept(w http.ResponseWriter, r *http.Request) {
 		Name:              user.DisplayName,
 	}
 
+	if h.outbox != nil {
+		h.acceptViaOutbox(w, r, invite, user.ID, reqBody)
+
+		return
+	}
+
 	result, err := invitesincoming.SendInviteAccepted(ctx, h.poster, reqBody, invite.SenderFQDN)
 	if err != nil {
 		h.log.Error("failed to send invite-accepted",
@@ -285,20 +297,31 @@ func (h *Handler) HandleAccept(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics.InviteTransition(metrics.DirectionIncoming, string(invites.InviteStatusAccepted))
+	h.auditInviteAccepted(audit.WithActor(ctx, user.Username), user.ID, invite)
+
 	if result.AlreadyAccepted {
 		h.log.Info("invite already accepted by sender", "invite_id", inviteID, "sender_fqdn", invite.SenderFQDN)
 	} else {
 		h.log.Info("invite accepted", "invite_id", inviteID, "sender_fqdn", invite.SenderFQDN)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	h.writeAcceptStatus(w, http.StatusOK, inviteID, invites.InviteStatusAccepted)
+}
 
-	if err := json.NewEncoder(w).Encode(map[string]string{
-		jsonKeyStatus:   string(invites.InviteStatusAccepted),
-		jsonKeyInviteID: inviteID,
-	}); err != nil {
-		h.log.Error("failed to encode accepted invite", "error", err)
-	}
+// auditInviteAccepted records a local user accepting an incoming invite. The
+// actor comes from ctx; a retried outbox delivery has none and is identified
+// by the user ID in the details.
+func (h *Handler) auditInviteAccepted(ctx context.Context, userID string, invite *invitesincoming.IncomingInvite) {
+	h.audit.Record(ctx, audit.Event{
+		Action:   audit.ActionInviteAccept,
+		Peer:     invite.SenderFQDN,
+		Resource: invite.ID,
+		Details: map[string]string{
+			audit.DetailDirection: audit.DirectionIncoming,
+			"user_id":             userID,
+		},
+	})
 }
 
 // HandleDecline handles POST /api/inbox/invites/{inviteId}/decline; deletes the invite locally (no outbound call).
@@ -359,6 +382,7 @@ func (h *Handler) HandleDecline(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics.InviteTransition(metrics.DirectionIncoming, string(invites.InviteStatusDeclined))
 	h.log.Info("invite declined", "invite_id", inviteID, "sender_fqdn", invite.SenderFQDN)
 
 	w.Header().Set("Content-Type", "application/json")
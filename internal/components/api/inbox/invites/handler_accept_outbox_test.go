@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package invites_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	inboxinvites "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/inbox/invites"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
+	invitesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	tslocalid "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/localidentity"
+)
+
+const outboxSenderIdentity = `{"userID":"remote-sender@sender.example","email":"s@example","name":"Sender"}`
+
+// startScriptedSenderServer answers invite-accepted with statuses in order,
+// repeating the last one, and always returns the sender identity body.
+func startScriptedSenderServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	calls := &atomic.Int32{}
+
+	var srv *httptest.Server
+
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/ocm":
+			w.Header().Set("Content-Type", "application/json")
+			mustEncodeJSON(t, w, spec.Discovery{
+				Enabled:    true,
+				APIVersion: "1.4.0",
+				EndPoint:   srv.URL + "/ocm",
+			})
+		case "/ocm/invite-accepted":
+			n := int(calls.Add(1))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(statuses[min(n, len(statuses))-1])
+
+			if _, err := w.Write([]byte(outboxSenderIdentity)); err != nil {
+				t.Errorf("write response: %v", err)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, calls
+}
+
+type outboxAcceptFixture struct {
+	repo       invitesincoming.IncomingInviteRepo
+	messages   outbox.MessageRepo
+	dispatcher *outbox.Dispatcher
+	router     http.Handler
+	now        time.Time
+}
+
+func newOutboxAcceptFixture(t *testing.T) *outboxAcceptFixture {
+	t.Helper()
+
+	r := tsrepos.OpenMemory(t)
+	requestClient, discoveryClient := newTestOutboundClients(t)
+	localIdentity := tslocalid.MustTestIdentity(t, testPublicOrigin, "")
+
+	f := &outboxAcceptFixture{
+		repo:     r.IncomingInvites,
+		messages: r.Outbox,
+		now:      time.Now(),
+	}
+
+	f.dispatcher = outbox.NewDispatcher(r.Outbox, outbound.NewPoster(requestClient, discoveryClient, nil, nil),
+		outbox.Policy{InitialBackoff: time.Minute}, testLogger)
+	f.dispatcher.SetClock(func() time.Time { return f.now })
+
+	h := inboxinvites.NewHandler(
+		r.IncomingInvites,
+		nil,
+		localIdentity.ProviderDomain,
+		localIdentity.Scheme,
+		currentUserFunc(&identity.User{ID: userAID, Username: "alice"}),
+		testLogger,
+	)
+	h.SetOutbox(f.dispatcher)
+
+	router := chi.NewRouter()
+	router.Post("/inbox/invites/{inviteId}/accept", h.HandleAccept)
+	f.router = router
+
+	return f
+}
+
+func (f *outboxAcceptFixture) accept(t *testing.T, inviteID string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/inbox/invites/"+inviteID+"/accept", nil)
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	var resp map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v (%s)", err, w.Body.String())
+	}
+
+	return w, resp
+}
+
+func (f *outboxAcceptFixture) stored(t *testing.T, inviteID string) *invitesincoming.IncomingInvite {
+	t.Helper()
+
+	stored, err := f.repo.GetByIDForRecipientUserID(t.Context(), inviteID, userAID)
+	if err != nil {
+		t.Fatalf("get invite: %v", err)
+	}
+
+	return stored
+}
+
+func TestHandleAccept_Outbox_DeliveredPersistsAcceptance(t *testing.T) {
+	t.Parallel()
+
+	srv, calls := startScriptedSenderServer(t, http.StatusCreated)
+	f := newOutboxAcceptFixture(t)
+	senderFQDN := strings.TrimPrefix(srv.URL, "https://")
+	invite := createInviteForUser(t, f.repo, userAID, "outbox-ok-token", senderFQDN)
+
+	w, resp := f.accept(t, invite.ID)
+	if w.Code != http.StatusOK || resp["status"] != string(invites.InviteStatusAccepted) {
+		t.Fatalf("expected 200 accepted, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if calls.Load() != 1 {
+		t.Fatalf("invite-accepted calls = %d, want 1", calls.Load())
+	}
+
+	stored := f.stored(t, invite.ID)
+	if stored.Status != invites.InviteStatusAccepted || stored.SenderUserID != "remote-sender@sender.example" ||
+		stored.SenderFQDNNormalized != senderFQDN {
+		t.Fatalf("stored invite = %+v", stored)
+	}
+
+	if left, _ := f.messages.List(t.Context()); len(left) != 0 {
+		t.Fatalf("delivered message must leave the outbox, %d left", len(left))
+	}
+}
+
+func TestHandleAccept_Outbox_RetryableFailureCompletesInBackground(t *testing.T) {
+	t.Parallel()
+
+	srv, calls := startScriptedSenderServer(t, http.StatusServiceUnavailable, http.StatusCreated)
+	f := newOutboxAcceptFixture(t)
+	invite := createInviteForUser(t, f.repo, userAID, "outbox-retry-token", strings.TrimPrefix(srv.URL, "https://"))
+
+	w, resp := f.accept(t, invite.ID)
+	if w.Code != http.StatusAccepted || resp["status"] != string(invites.InviteStatusPending) {
+		t.Fatalf("expected 202 pending, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if stored := f.stored(t, invite.ID); stored.Status != invites.InviteStatusPending {
+		t.Fatalf("invite status after queued accept = %s, want pending", stored.Status)
+	}
+
+	f.now = f.now.Add(time.Minute)
+
+	if n, err := f.dispatcher.ProcessDue(t.Context()); err != nil || n != 1 {
+		t.Fatalf("ProcessDue = %d, %v; want one attempt", n, err)
+	}
+
+	if calls.Load() != 2 {
+		t.Fatalf("invite-accepted calls = %d, want 2", calls.Load())
+	}
+
+	stored := f.stored(t, invite.ID)
+	if stored.Status != invites.InviteStatusAccepted || stored.SenderUserID != "remote-sender@sender.example" {
+		t.Fatalf("stored invite after retry = %+v", stored)
+	}
+}
+
+func TestHandleAccept_Outbox_ConflictWithIdentityAccepts(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := startScriptedSenderServer(t, http.StatusConflict)
+	f := newOutboxAcceptFixture(t)
+	invite := createInviteForUser(t, f.repo, userAID, "outbox-conflict-token", strings.TrimPrefix(srv.URL, "https://"))
+
+	if w, _ := f.accept(t, invite.ID); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 for 409-with-identity, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if stored := f.stored(t, invite.ID); stored.Status != invites.InviteStatusAccepted {
+		t.Fatalf("invite status = %s, want accepted", stored.Status)
+	}
+}
+
+func TestHandleAccept_Outbox_RejectionLeavesInvitePending(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := startScriptedSenderServer(t, http.StatusForbidden)
+	f := newOutboxAcceptFixture(t)
+	invite := createInviteForUser(t, f.repo, userAID, "outbox-reject-token", strings.TrimPrefix(srv.URL, "https://"))
+
+	if w, _ := f.accept(t, invite.ID); w.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if stored := f.stored(t, invite.ID); stored.Status != invites.InviteStatusPending {
+		t.Fatalf("invite status = %s, want pending", stored.Status)
+	}
+
+	left, err := f.messages.List(t.Context())
+	if err != nil || len(left) != 1 || left[0].Status != outbox.StatusDead || left[0].UserID != userAID {
+		t.Fatalf("outbox = %+v (%v), want one dead message for the user", left, err)
+	}
+}
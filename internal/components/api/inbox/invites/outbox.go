@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package invites
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
+	invitesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
+)
+
+// SetOutbox routes invite-accepted calls through the durable outbox and
+// registers the completion that persists the acceptance once the sender
+// answers. Without an outbox, HandleAccept posts synchronously.
+func (h *Handler) SetOutbox(d *outbox.Dispatcher) {
+	h.outbox = d
+	d.Register(outbox.TopicInviteAccepted, outbox.Completion{
+		// 409 INVITE_ALREADY_ACCEPTED is idempotent success when it carries
+		// the sender identity; the completion decides.
+		Accept: func(statusCode int) bool { return statusCode == http.StatusConflict },
+		Done:   h.completeInviteAccepted,
+	})
+}
+
+// acceptViaOutbox queues the invite-accepted call and makes the first attempt.
+// It answers 200 once the acceptance is persisted, 502 when the sender
+// rejected it, and 202 with status pending while retries are outstanding.
+func (h *Handler) acceptViaOutbox(
+	w http.ResponseWriter,
+	r *http.Request,
+	invite *invitesincoming.IncomingInvite,
+	userID string,
+	reqBody spec.InviteAcceptedRequest,
+) {
+	ctx := r.Context()
+
+	body, err := json.Marshal(reqBody) //nolint:errchkjson // payload type cannot fail to encode, so the checked error is always nil
+	if err != nil {
+		h.log.Error("failed to encode invite-accepted request", "invite_id", invite.ID, "error", err)
+		api.WriteInternalError(w, "failed to queue invite acceptance")
+
+		return
+	}
+
+	msg := &outbox.Message{
+		Topic:     outbox.TopicInviteAccepted,
+		SubjectID: invite.ID,
+		UserID:    userID,
+		Request: outbound.Request{
+			TargetHost:   invite.SenderFQDN,
+			EndpointPath: "invite-accepted",
+			Kind:         outbound.EndpointInvites,
+			Body:         body,
+		},
+	}
+
+	if _, err := h.outbox.Deliver(ctx, msg); err != nil {
+		if msg.Attempts == 0 {
+			h.log.Error("failed to queue invite-accepted", "invite_id", invite.ID, "error", err)
+			api.WriteInternalError(w, "failed to queue invite acceptance")
+
+			return
+		}
+
+		h.log.Error("failed to complete invite-accepted", "invite_id", invite.ID, "error", err)
+	}
+
+	if msg.Status == outbox.StatusPending {
+		h.log.Info("invite-accepted queued for retry",
+			"invite_id", invite.ID,
+			"sender_fqdn", invite.SenderFQDN,
+			"next_attempt_at", msg.NextAttemptAt,
+			"error", msg.LastError)
+		h.writeAcceptStatus(w, http.StatusAccepted, invite.ID, invites.InviteStatusPending)
+
+		return
+	}
+
+	stored, err := h.incomingRepo.GetByIDForRecipientUserID(ctx, invite.ID, userID)
+	if err != nil || stored.Status != invites.InviteStatusAccepted {
+		h.log.Error("invite-accepted was not completed",
+			"invite_id", invite.ID, "sender_fqdn", invite.SenderFQDN, "error", msg.LastError)
+		api.WriteError(w, http.StatusBadGateway, api.ReasonPeerUnreachable, "failed to notify sender")
+
+		return
+	}
+
+	h.log.Info("invite accepted", "invite_id", invite.ID, "sender_fqdn", invite.SenderFQDN)
+	h.writeAcceptStatus(w, http.StatusOK, invite.ID, invites.InviteStatusAccepted)
+}
+
+// completeInviteAccepted persists the acceptance once the sender answered the
+// invite-accepted call. Dead messages leave the invite pending so the user
+// can accept again.
+func (h *Handler) completeInviteAccepted(ctx context.Context, msg *outbox.Message, res outbox.Result) error {
+	if msg.Status != outbox.StatusDelivered {
+		return nil
+	}
+
+	invite, err := h.incomingRepo.GetByIDForRecipientUserID(ctx, msg.SubjectID, msg.UserID)
+	if err != nil {
+		return fmt.Errorf("api: load invite %s: %w", msg.SubjectID, err)
+	}
+
+	if invite.Status == invites.InviteStatusAccepted {
+		return nil
+	}
+
+	senderFQDNNormalized, err := hostport.Normalize(invite.SenderFQDN, h.localScheme)
+	if err != nil {
+		return fmt.Errorf("api: normalize sender fqdn: %w", err)
+	}
+
+	result, err := invitesincoming.DecodeInviteAcceptedResponse(res.StatusCode, bytes.NewReader(res.Body))
+	if err != nil {
+		return fmt.Errorf("api: decode invite-accepted response: %w", err)
+	}
+
+	acceptance := &invitesincoming.Acceptance{
+		UserID:                 result.Response.UserID,
+		ProviderFQDN:           invite.SenderFQDN,
+		ProviderFQDNNormalized: senderFQDNNormalized,
+	}
+	if err := h.incomingRepo.UpdateStatusForRecipientUserID(
+		ctx, invite.ID, msg.UserID, invites.InviteStatusAccepted, acceptance,
+	); err != nil {
+		return fmt.Errorf("api: persist invite acceptance: %w", err)
+	}
+
+	metrics.InviteTransition(metrics.DirectionIncoming, string(invites.InviteStatusAccepted))
+	h.auditInviteAccepted(ctx, msg.UserID, invite)
+
+	return nil
+}
+
+// writeAcceptStatus answers an accept call with the invite id and status.
+func (h *Handler) writeAcceptStatus(w http.ResponseWriter, code int, inviteID string, status invites.InviteStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(map[string]string{
+		jsonKeyStatus:   string(status),
+		jsonKeyInviteID: inviteID,
+	}); err != nil {
+		h.log.Error("failed to encode accepted invite", "error", err)
+	}
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package sharerequests provides session-gated API handlers for the share
+// request inbox (list, approve, decline).
+package sharerequests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// ShareCreator runs the outgoing share pipeline for a user and writes the
+// HTTP response. It returns nil when the response reports a failure.
+type ShareCreator interface {
+	CreateForUser(
+		w http.ResponseWriter,
+		r *http.Request,
+		user *identity.User,
+		req sharesoutgoing.OutgoingShareRequest,
+	) *sharesoutgoing.OutgoingShare
+}
+
+// ListResponse carries the share requests returned by GET /api/inbox/share-requests.
+type ListResponse struct {
+	Requests []*sharerequests.ShareRequest `json:"requests"`
+}
+
+// Handler serves list, approve, and decline for the share request inbox.
+type Handler struct {
+	repo         sharerequests.Repo
+	outgoingRepo sharesoutgoing.OutgoingShareRepo
+	creator      ShareCreator
+	currentUser  func(context.Context) (*identity.User, error)
+	log          *slog.Logger
+}
+
+// NewHandler returns a Handler with the given dependencies.
+func NewHandler(
+	repo sharerequests.Repo,
+	outgoingRepo sharesoutgoing.OutgoingShareRepo,
+	creator ShareCreator,
+	currentUser func(context.Context) (*identity.User, error),
+	log *slog.Logger,
+) *Handler {
+	log = logutil.NoopIfNil(log)
+
+	return &Handler{
+		repo:         repo,
+		outgoingRepo: outgoingRepo,
+		creator:      creator,
+		currentUser:  currentUser,
+		log:          log,
+	}
+}
+
+// HandleList handles GET /api/inbox/share-requests; returns only requests for
+// shares the authenticated user owns, newest first.
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	user, err := h.currentUser(r.Context())
+	if err != nil {
+		api.WriteUnauthorized(w, api.ReasonUnauthenticated, "authentication required")
+
+		return
+	}
+
+	list, err := h.repo.ListByOwnerUserID(r.Context(), user.ID)
+	if err != nil {
+		h.log.Error("failed to list share requests", "user_id", user.ID, "error", err)
+		api.WriteInternalError(w, "failed to list share requests")
+
+		return
+	}
+
+	if list == nil {
+		list = []*sharerequests.ShareRequest{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(ListResponse{Requests: list}); err != nil {
+		h.log.Error("failed to encode share requests", "error", err)
+	}
+}
+
+// HandleApprove handles POST /api/inbox/share-requests/{requestId}/approve.
+// It sends the requester a new share of the same resource with the original
+// permissions and answers with the outgoing share create response.
+func (h *Handler) HandleApprove(w http.ResponseWriter, r *http.Request) {
+	user, req, ok := h.loadPending(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+
+	original, err := h.outgoingRepo.GetByID(ctx, req.ShareID)
+	if err != nil {
+		if errors.Is(err, sharesoutgoing.ErrShareNotFound) {
+			api.WriteNotFound(w, "requested share no longer exists")
+
+			return
+		}
+
+		h.log.Error("failed to get requested share", "share_id", req.ShareID, "error", err)
+		api.WriteInternalError(w, "failed to get requested share")
+
+		return
+	}
+
+	share := h.creator.CreateForUser(w, r, user, sharesoutgoing.OutgoingShareRequest{
+		ReceiverDomain: req.RequesterHost,
+		ShareWith:      req.ShareWith,
+		LocalPath:      original.LocalPath,
+		Permissions:    original.Permissions,
+	})
+	if share == nil {
+		return
+	}
+
+	// The share is already sent and answered; a failed status update leaves
+	// the request pending so the owner can see it was not recorded.
+	if err := h.repo.UpdateStatusForOwnerUserID(ctx, req.ID, user.ID, sharerequests.StatusApproved, share.ShareID); err != nil {
+		h.log.Error("failed to mark share request approved",
+			"request_id", req.ID, "share_id", share.ShareID, "error", err)
+
+		return
+	}
+
+	h.log.Info("share request approved",
+		"request_id", req.ID, "share_id", share.ShareID, "requester", req.ShareWith)
+}
+
+// HandleDecline handles POST /api/inbox/share-requests/{requestId}/decline;
+// marks the request declined locally (no outbound call).
+func (h *Handler) HandleDecline(w http.ResponseWriter, r *http.Request) {
+	user, req, ok := h.loadPending(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.UpdateStatusForOwnerUserID(r.Context(), req.ID, user.ID, sharerequests.StatusDeclined, ""); err != nil {
+		h.log.Error("failed to mark share request declined", "request_id", req.ID, "error", err)
+		api.WriteInternalError(w, "failed to decline share request")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(map[string]string{
+		"status":    string(sharerequests.StatusDeclined),
+		"requestId": req.ID,
+	}); err != nil {
+		h.log.Error("failed to encode declined share request", "error", err)
+	}
+}
+
+// loadPending resolves the session user and their pending request from the
+// URL, writing the error response when either is missing.
+func (h *Handler) loadPending(w http.ResponseWriter, r *http.Request) (*identity.User, *sharerequests.ShareRequest, bool) {
+	user, err := h.currentUser(r.Context())
+	if err != nil {
+		api.WriteUnauthorized(w, api.ReasonUnauthenticated, "authentication required")
+
+		return nil, nil, false
+	}
+
+	requestID := chi.URLParam(r, "requestId")
+	if requestID == "" {
+		api.WriteBadRequest(w, api.ReasonMissingField, "requestId is required")
+
+		return nil, nil, false
+	}
+
+	req, err := h.repo.GetByIDForOwnerUserID(r.Context(), requestID, user.ID)
+	if err != nil {
+		if errors.Is(err, sharerequests.ErrRequestNotFound) {
+			api.WriteNotFound(w, "share request not found")
+
+			return nil, nil, false
+		}
+
+		h.log.Error("failed to get share request", "request_id", requestID, "user_id", user.ID, "error", err)
+		api.WriteInternalError(w, "failed to get share request")
+
+		return nil, nil, false
+	}
+
+	if req.Status != sharerequests.StatusPending {
+		api.WriteConflict(w, "share request is already "+string(req.Status))
+
+		return nil, nil, false
+	}
+
+	return user, req, true
+}
@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sharerequests_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	inboxsharerequests "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/inbox/sharerequests"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+)
+
+var testLogger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+// stubCreator records the share request it was asked to create and answers
+// like the outgoing shares handler: 201 with a share, or 502 and nil.
+type stubCreator struct {
+	fail bool
+	got  *sharesoutgoing.OutgoingShareRequest
+}
+
+func (c *stubCreator) CreateForUser(
+	w http.ResponseWriter,
+	_ *http.Request,
+	_ *identity.User,
+	req sharesoutgoing.OutgoingShareRequest,
+) *sharesoutgoing.OutgoingShare {
+	c.got = &req
+
+	if c.fail {
+		w.WriteHeader(http.StatusBadGateway)
+
+		return nil
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	return &sharesoutgoing.OutgoingShare{ShareID: "approved-share"}
+}
+
+type fixture struct {
+	repos   *repos.Repos
+	creator *stubCreator
+	handler *inboxsharerequests.Handler
+	owner   *identity.User
+	request *sharerequests.ShareRequest
+}
+
+func newFixture(t *testing.T, user *identity.User) *fixture {
+	t.Helper()
+
+	r := tsrepos.OpenMemory(t)
+	ctx := context.Background()
+	owner := &identity.User{ID: "owner-1", Username: "alice"}
+
+	original := &sharesoutgoing.OutgoingShare{
+		ShareID:     "share-1",
+		ProviderID:  "provider-1",
+		Name:        "report.txt",
+		LocalPath:   "/data/report.txt",
+		Permissions: []string{"read", "write"},
+		Status:      shares.OutgoingShareStatusAccepted,
+		CreatedAt:   time.Now(),
+	}
+	if err := r.OutgoingShares.Create(ctx, original); err != nil {
+		t.Fatalf("create outgoing share: %v", err)
+	}
+
+	request := &sharerequests.ShareRequest{
+		OwnerUserID:   owner.ID,
+		ProviderID:    "provider-1",
+		ShareID:       "share-1",
+		Name:          "report.txt",
+		ResourceType:  "file",
+		ShareWith:     "bob@requester.example",
+		RequesterHost: "requester.example",
+		Status:        sharerequests.StatusPending,
+	}
+	if err := r.ShareRequests.Create(ctx, request); err != nil {
+		t.Fatalf("create share request: %v", err)
+	}
+
+	if user == nil {
+		user = owner
+	}
+
+	creator := &stubCreator{}
+	currentUser := func(_ context.Context) (*identity.User, error) { return user, nil }
+
+	return &fixture{
+		repos:   r,
+		creator: creator,
+		handler: inboxsharerequests.NewHandler(r.ShareRequests, r.OutgoingShares, creator, currentUser, testLogger),
+		owner:   owner,
+		request: request,
+	}
+}
+
+func (f *fixture) do(t *testing.T, handler http.HandlerFunc, action string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := chi.NewRouter()
+	r.Post("/api/inbox/share-requests/{requestId}/"+action, handler)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost,
+		"/api/inbox/share-requests/"+f.request.ID+"/"+action, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func (f *fixture) stored(t *testing.T) *sharerequests.ShareRequest {
+	t.Helper()
+
+	got, err := f.repos.ShareRequests.GetByIDForOwnerUserID(context.Background(), f.request.ID, f.owner.ID)
+	if err != nil {
+		t.Fatalf("GetByIDForOwnerUserID: %v", err)
+	}
+
+	return got
+}
+
+func TestHandleList_ReturnsOwnRequests(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, nil)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/api/inbox/share-requests", nil)
+	w := httptest.NewRecorder()
+	f.handler.HandleList(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+
+	var resp inboxsharerequests.ListResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	if len(resp.Requests) != 1 || resp.Requests[0].ID != f.request.ID {
+		t.Fatalf("requests = %+v, want the fixture request", resp.Requests)
+	}
+}
+
+func TestHandleList_OtherUserSeesEmptyList(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, &identity.User{ID: "someone-else"})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/api/inbox/share-requests", nil)
+	w := httptest.NewRecorder()
+	f.handler.HandleList(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+
+	if body := w.Body.String(); body != "{\"requests\":[]}\n" {
+		t.Errorf("body = %q, want an empty requests array", body)
+	}
+}
+
+func TestHandleApprove_CreatesShareAndMarksApproved(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, nil)
+
+	w := f.do(t, f.handler.HandleApprove, "approve")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201; body=%s", w.Code, w.Body.String())
+	}
+
+	got := f.creator.got
+	if got == nil {
+		t.Fatal("share creator was not called")
+	}
+
+	if got.ReceiverDomain != "requester.example" || got.ShareWith != "bob@requester.example" ||
+		got.LocalPath != "/data/report.txt" || !slices.Equal(got.Permissions, []string{"read", "write"}) {
+		t.Errorf("create request = %+v", got)
+	}
+
+	stored := f.stored(t)
+	if stored.Status != sharerequests.StatusApproved || stored.ApprovedShareID != "approved-share" {
+		t.Errorf("stored request = %+v, want approved with approved-share", stored)
+	}
+}
+
+func TestHandleApprove_DeliveryFailureKeepsPending(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, nil)
+	f.creator.fail = true
+
+	w := f.do(t, f.handler.HandleApprove, "approve")
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("status = %d, want 502", w.Code)
+	}
+
+	if stored := f.stored(t); stored.Status != sharerequests.StatusPending {
+		t.Errorf("status = %q, want pending", stored.Status)
+	}
+}
+
+func TestHandleDecline_MarksDeclinedOnce(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, nil)
+
+	if w := f.do(t, f.handler.HandleDecline, "decline"); w.Code != http.StatusOK {
+		t.Fatalf("first decline status = %d, want 200", w.Code)
+	}
+
+	if stored := f.stored(t); stored.Status != sharerequests.StatusDeclined {
+		t.Errorf("status = %q, want declined", stored.Status)
+	}
+
+	if w := f.do(t, f.handler.HandleDecline, "decline"); w.Code != http.StatusConflict {
+		t.Errorf("second decline status = %d, want 409", w.Code)
+	}
+
+	if w := f.do(t, f.handler.HandleApprove, "approve"); w.Code != http.StatusConflict {
+		t.Errorf("approve after decline status = %d, want 409", w.Code)
+	}
+
+	if f.creator.got != nil {
+		t.Error("declined request must not create a share")
+	}
+}
+
+func TestHandleApprove_OtherUserGets404(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, &identity.User{ID: "someone-else"})
+
+	if w := f.do(t, f.handler.HandleApprove, "approve"); w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", w.Code)
+	}
+
+	if f.creator.got != nil {
+		t.Error("foreign request must not create a share")
+	}
+}
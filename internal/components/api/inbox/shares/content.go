@@ -0,0 +1,340 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+// Entry types in a ContentListing.
+const (
+	contentEntryFile   = "file"
+	contentEntryFolder = "folder"
+)
+
+// contentPassHeaders are the sender response headers a content download
+// keeps; everything else, cookies included, stays behind.
+var contentPassHeaders = []string{"Content-Length", "Content-Range", "Accept-Ranges", "ETag", "Last-Modified"}
+
+// ContentListing is the content response for a folder: its direct children.
+type ContentListing struct {
+	Path    string         `json:"path"`
+	Entries []ContentEntry `json:"entries"`
+}
+
+// ContentEntry is one child in a ContentListing. Path is the value to pass
+// as ?path= to download or list it.
+type ContentEntry struct {
+	Name         string     `json:"name"`
+	Path         string     `json:"path"`
+	Type         string     `json:"type"`
+	Size         *int64     `json:"size,omitempty"`
+	ContentType  string     `json:"contentType,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+}
+
+// HandleContent handles GET /api/inbox/shares/{shareId}/content. A file
+// share, or the file at ?path= inside a folder share, is streamed from the
+// sender with Range support; a folder is answered with a ContentListing.
+// Like verify-access, the sender is reached server-side, so tokens and the
+// shared secret never reach the browser.
+func (h *Handler) HandleContent(w http.ResponseWriter, r *http.Request) {
+	user, err := h.currentUser(r.Context())
+	if err != nil {
+		api.WriteUnauthorized(w, api.ReasonUnauthenticated, "authentication required")
+
+		return
+	}
+
+	shareID := chi.URLParam(r, "shareId")
+	if shareID == "" {
+		api.WriteBadRequest(w, api.ReasonMissingField, "shareId is required")
+
+		return
+	}
+
+	ctx := r.Context()
+
+	share, err := h.repo.GetByIDForRecipientUserID(ctx, shareID, user.ID)
+	if err != nil {
+		if errors.Is(err, sharesincoming.ErrShareNotFound) {
+			api.WriteNotFound(w, "share not found")
+
+			return
+		}
+
+		h.log.Error("failed to get share", "share_id", shareID, "user_id", user.ID, "error", err)
+		api.WriteInternalError(w, "failed to get share")
+
+		return
+	}
+
+	if writeIfExpired(w, share) {
+		return
+	}
+
+	if share.Status != shares.ShareStatusAccepted {
+		api.WriteBadRequest(w, verifyReasonShareNotAccepted, "share must be accepted before reading it")
+
+		return
+	}
+
+	if share.WebDAVID == "" || strings.EqualFold(share.ProtocolName, access.ProtocolWebapp) {
+		api.WriteError(w, http.StatusNotImplemented, verifyReasonUnsupportedProtocol, "share has no webdav access")
+
+		return
+	}
+
+	rel, ok := cleanContentPath(r.URL.Query().Get("path"))
+	if !ok {
+		api.WriteBadRequest(w, api.ReasonInvalidField, "path must be a relative path inside the share")
+
+		return
+	}
+
+	info := access.ShareInfoFromIncoming(share)
+
+	if share.ResourceType != spec.ResourceTypeFolder {
+		if rel != "" {
+			api.WriteBadRequest(w, api.ReasonInvalidField, "path is only valid for folder shares")
+
+			return
+		}
+
+		h.streamContent(w, r, info, "", share.Name)
+
+		return
+	}
+
+	listing, ok := h.listContent(w, r, info, rel)
+	if !ok {
+		return
+	}
+
+	if rel != "" && listing.Self != nil && !listing.Self.Collection {
+		h.streamContent(w, r, info, rel, path.Base(rel))
+
+		return
+	}
+
+	h.writeContentListing(w, rel, listing.Children)
+}
+
+// listContent sends a Depth 1 PROPFIND for rel. On failure the error
+// response is already written and ok is false.
+func (h *Handler) listContent(w http.ResponseWriter, r *http.Request, info *access.ShareInfo, rel string) (*access.Listing, bool) {
+	result, err := h.accessClient.Access(r.Context(), access.AccessOptions{
+		Share:    info,
+		Protocol: access.ProtocolWebDAV,
+		Method:   "PROPFIND",
+		SubPath:  access.EscapeSubPath(rel),
+		Depth:    "1",
+	})
+	if err != nil {
+		h.log.Warn("share content listing failed", "share_id", info.ShareID, "error", err)
+		writeContentAccessError(w, err)
+
+		return nil, false
+	}
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		result.Response.Body.Close()
+	}()
+
+	if result.Response.StatusCode != http.StatusMultiStatus {
+		writeContentRemoteStatus(w, result.Response.StatusCode)
+
+		return nil, false
+	}
+
+	listing, err := access.ReadListing(result.Response)
+	if err != nil {
+		h.log.Warn("share content listing unreadable", "share_id", info.ShareID, "error", err)
+		api.WriteError(w, reason.APIStatus(reason.PeerUnreachable), reason.PeerUnreachable, "remote server sent an unreadable folder listing")
+
+		return nil, false
+	}
+
+	return listing, true
+}
+
+// streamContent proxies a GET for rel, passing the client's Range through.
+func (h *Handler) streamContent(w http.ResponseWriter, r *http.Request, info *access.ShareInfo, rel, name string) {
+	header := http.Header{}
+
+	for _, key := range []string{"Range", "If-Range"} {
+		if v := r.Header.Get(key); v != "" {
+			header.Set(key, v)
+		}
+	}
+
+	result, err := h.accessClient.Access(r.Context(), access.AccessOptions{
+		Share:    info,
+		Protocol: access.ProtocolWebDAV,
+		Method:   http.MethodGet,
+		SubPath:  access.EscapeSubPath(rel),
+		Header:   header,
+	})
+	if err != nil {
+		h.log.Warn("share content download failed", "share_id", info.ShareID, "error", err)
+		writeContentAccessError(w, err)
+
+		return
+	}
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		result.Response.Body.Close()
+	}()
+
+	resp := result.Response
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+	case http.StatusRequestedRangeNotSatisfiable:
+		w.Header().Set("Content-Range", resp.Header.Get("Content-Range"))
+		api.WriteError(w, http.StatusRequestedRangeNotSatisfiable, api.ReasonBadRequest, "requested range not satisfiable")
+
+		return
+	default:
+		writeContentRemoteStatus(w, resp.StatusCode)
+
+		return
+	}
+
+	for _, key := range contentPassHeaders {
+		if v := resp.Header.Get(key); v != "" {
+			w.Header().Set(key, v)
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType(resp.Header.Get("Content-Type"), name))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	// The bytes are the sender's, served from our origin: never sniff or
+	// render them as active content.
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.WriteHeader(resp.StatusCode)
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		h.log.Warn("share content stream interrupted", "share_id", info.ShareID, "error", err)
+	}
+}
+
+// writeContentListing writes children of the folder at rel, folders first.
+func (h *Handler) writeContentListing(w http.ResponseWriter, rel string, children []access.ListingEntry) {
+	entries := make([]ContentEntry, 0, len(children))
+
+	for _, c := range children {
+		e := ContentEntry{
+			Name:        c.Name,
+			Path:        path.Join(rel, c.Name),
+			Type:        contentEntryFile,
+			ContentType: c.ContentType,
+		}
+
+		if c.Collection {
+			e.Type = contentEntryFolder
+			e.ContentType = ""
+		} else if c.Size >= 0 {
+			e.Size = &c.Size
+		}
+
+		if !c.LastModified.IsZero() {
+			e.LastModified = &c.LastModified
+		}
+
+		entries = append(entries, e)
+	}
+
+	slices.SortFunc(entries, func(a, b ContentEntry) int {
+		if a.Type != b.Type {
+			return cmp.Compare(b.Type, a.Type)
+		}
+
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(ContentListing{Path: rel, Entries: entries}); err != nil {
+		h.log.Error("failed to encode share content listing", "error", err)
+	}
+}
+
+// cleanContentPath validates ?path= as a relative path below the share
+// root. Surrounding slashes are ignored; empty, "." and ".." segments are
+// refused rather than resolved.
+func cleanContentPath(p string) (string, bool) {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return "", true
+	}
+
+	for segment := range strings.SplitSeq(p, "/") {
+		if !access.ValidListingName(segment) {
+			return "", false
+		}
+	}
+
+	return p, true
+}
+
+// contentType returns the sender's content type, or one guessed from name.
+func contentType(remote, name string) string {
+	if _, _, err := mime.ParseMediaType(remote); err == nil {
+		return remote
+	}
+
+	if ct := mime.TypeByExtension(path.Ext(name)); ct != "" {
+		return ct
+	}
+
+	return "application/octet-stream"
+}
+
+// writeContentRemoteStatus maps a sender status to the content response.
+func writeContentRemoteStatus(w http.ResponseWriter, status int) {
+	if status == http.StatusNotFound || status == http.StatusGone {
+		api.WriteNotFound(w, "not found in share")
+
+		return
+	}
+
+	api.WriteError(w, reason.APIStatus(reason.PeerUnreachable), reason.PeerUnreachable,
+		fmt.Sprintf("remote server returned %d", status))
+}
+
+func writeContentAccessError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, access.ErrShareNotAccepted):
+		api.WriteBadRequest(w, verifyReasonShareNotAccepted, "share must be accepted before reading it")
+	case errors.Is(err, access.ErrTokenExchangeRequired):
+		api.WriteError(w, reason.APIStatus(reason.PeerCapabilityMismatch), reason.PeerCapabilityMismatch,
+			"token exchange required but not available")
+	default:
+		reasonCode := reason.CanonicalFromError(err)
+		api.WriteError(w, reason.APIStatus(reasonCode), reasonCode, "remote access failed")
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+// TransferView is the datatx progress of an accepted share in the detail
+// response.
+type TransferView struct {
+	Status      datatx.Status `json:"status"`
+	BytesTotal  int64         `json:"bytesTotal"`
+	BytesDone   int64         `json:"bytesDone"`
+	FilesTotal  int           `json:"filesTotal"`
+	FilesDone   int           `json:"filesDone"`
+	Attempts    int           `json:"attempts"`
+	LastError   string        `json:"lastError,omitempty"`
+	Destination string        `json:"destination,omitempty"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+	CompletedAt *time.Time    `json:"completedAt,omitempty"`
+}
+
+// SetTransfers sets the datatx queue. Without one, datatx shares are only
+// reachable remotely and the detail response carries no transfer.
+func (h *Handler) SetTransfers(transfers datatx.Queue) {
+	h.transfers = transfers
+}
+
+// enqueueTransfer schedules the copy of a just-accepted datatx share. A
+// failure is logged and does not undo the accept.
+func (h *Handler) enqueueTransfer(ctx context.Context, share *sharesincoming.IncomingShare) {
+	if h.transfers == nil || !slices.Contains(share.WebDAVAccessTypes, spec.AccessTypeDataTx) {
+		return
+	}
+
+	if err := h.transfers.Enqueue(ctx, share); err != nil {
+		h.log.Error("failed to enqueue datatx transfer", "share_id", share.ShareID, "error", err)
+	}
+}
+
+// transferView returns the transfer of share, or nil when it has none.
+func (h *Handler) transferView(ctx context.Context, share *sharesincoming.IncomingShare) *TransferView {
+	if h.transfers == nil {
+		return nil
+	}
+
+	t, err := h.transfers.Get(ctx, share.ShareID, share.RecipientUserID)
+	if err != nil {
+		if !errors.Is(err, datatx.ErrTransferNotFound) {
+			h.log.Error("failed to get datatx transfer", "share_id", share.ShareID, "error", err)
+		}
+
+		return nil
+	}
+
+	return &TransferView{
+		Status:      t.Status,
+		BytesTotal:  t.BytesTotal,
+		BytesDone:   t.BytesDone,
+		FilesTotal:  t.FilesTotal,
+		FilesDone:   t.FilesDone,
+		Attempts:    t.Attempts,
+		LastError:   t.LastError,
+		Destination: t.Destination,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		CompletedAt: t.CompletedAt,
+	}
+}
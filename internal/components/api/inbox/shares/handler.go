@@ -14,8 +14,10 @@ import (
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
 	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
 )
 
@@ -23,6 +25,7 @@ import (
 const (
 	verifyReasonShareNotAccepted    = "share_not_accepted"
 	verifyReasonUnsupportedProtocol = "unsupported_protocol"
+	verifyReasonShareExpired        = "share_expired"
 )
 
 // InboxShareView omits sensitive fields (e.g. SharedSecret) from API responses.
@@ -40,12 +43,27 @@ type InboxShareView struct {
 	Permissions       []string           `json:"permissions"`
 	Status            shares.ShareStatus `json:"status"`
 	CreatedAt         time.Time          `json:"createdAt"`
+	ExpiresAt         *time.Time         `json:"expiresAt,omitempty"`
 	OwnerDisplayName  string             `json:"ownerDisplayName,omitempty"`
 	SenderDisplayName string             `json:"senderDisplayName,omitempty"`
 }
 
-// NewInboxShareView maps an incoming share to a list-safe API view without secrets.
+// NewInboxShareView maps an incoming share to a list-safe API view without
+// secrets. A share past its expiration reports status expired even before
+// the expiry janitor has stored that.
 func NewInboxShareView(s *sharesincoming.IncomingShare) InboxShareView {
+	status := s.Status
+	if s.Expired(time.Now()) {
+		status = shares.ShareStatusExpired
+	}
+
+	var expiresAt *time.Time
+
+	if s.Expiration != nil && *s.Expiration > 0 {
+		t := time.Unix(*s.Expiration, 0).UTC()
+		expiresAt = &t
+	}
+
 	return InboxShareView{
 		ShareID:           s.ShareID,
 		ProviderID:        s.ProviderID,
@@ -58,8 +76,9 @@ func NewInboxShareView(s *sharesincoming.IncomingShare) InboxShareView {
 		ResourceType:      s.ResourceType,
 		ShareType:         s.ShareType,
 		Permissions:       s.Permissions,
-		Status:            s.Status,
+		Status:            status,
 		CreatedAt:         s.CreatedAt,
+		ExpiresAt:         expiresAt,
 		OwnerDisplayName:  s.OwnerDisplayName,
 		SenderDisplayName: s.SenderDisplayName,
 	}
@@ -72,13 +91,16 @@ type InboxShareDetailView struct {
 	WebDAVID                 string              `json:"webdavId,omitempty"`
 	AbsoluteWebDAVURIPresent bool                `json:"webdavUriAbsolutePresent"`
 	Protocol                 *ProtocolDetailView `json:"protocol"`
+	// Transfer is set for accepted datatx shares.
+	Transfer *TransferView `json:"transfer,omitempty"`
 }
 
-// ProtocolDetailView groups WebDAV and webapp protocol arms for a share detail response.
+// ProtocolDetailView groups WebDAV, webapp, and ssh protocol arms for a share detail response.
 type ProtocolDetailView struct {
 	Name   string            `json:"name"`
 	WebDAV *WebDAVDetailView `json:"webdav,omitempty"`
 	Webapp *WebappDetailView `json:"webapp,omitempty"`
+	SSH    *SSHDetailView    `json:"ssh,omitempty"`
 }
 
 // WebDAVDetailView exposes WebDAV protocol fields; SharedSecret is masked in responses.
@@ -98,6 +120,13 @@ type WebappDetailView struct {
 	Permissions []string `json:"permissions,omitempty"`
 }
 
+// SSHDetailView exposes the ssh arm: the SFTP uri the recipient logs in to
+// with one of their registered SSH keys.
+type SSHDetailView struct {
+	URI         string   `json:"uri"`
+	AccessTypes []string `json:"accessTypes"`
+}
+
 func isAbsoluteWebDAVURI(uri string) bool {
 	u, err := url.Parse(uri)
 	if err != nil {
@@ -145,6 +174,14 @@ func NewInboxShareDetailView(s *sharesincoming.IncomingShare) InboxShareDetailVi
 		}
 	}
 
+	// An ssh-only share has no webdav arm to show.
+	if s.SSHURI != "" {
+		proto.SSH = &SSHDetailView{URI: s.SSHURI, AccessTypes: s.SSHAccessTypes}
+		if s.WebDAVID == "" {
+			proto.WebDAV = nil
+		}
+	}
+
 	return InboxShareDetailView{
 		InboxShareView:           NewInboxShareView(s),
 		WebDAVID:                 s.WebDAVID,
@@ -171,13 +208,17 @@ type VerifyAccessResponse struct {
 
 const maxPreviewBytes = 4096
 
-// Handler serves list, detail, accept, decline, and verify-access for inbox shares.
+// Handler serves list, detail, accept, decline, verify-access, and
+// open-webapp for inbox shares, and starts datatx copies on accept.
 type Handler struct {
 	repo         sharesincoming.IncomingShareRepo
 	accessClient access.RemoteAccessor
+	webappOpener access.WebappOpener
+	transfers    datatx.Queue
 	notifier     Notifier
 	currentUser  func(context.Context) (*identity.User, error)
 	log          *slog.Logger
+	audit        audit.Recorder
 }
 
 // NewHandler returns a Handler with the given dependencies.
@@ -196,5 +237,12 @@ func NewHandler(
 		notifier:     notifier,
 		currentUser:  currentUser,
 		log:          log,
+		audit:        audit.Nop(),
 	}
 }
+
+// SetAudit sets the audit recorder for accepted and declined shares; nil
+// disables it.
+func (h *Handler) SetAudit(r audit.Recorder) {
+	h.audit = audit.OrNop(r)
+}
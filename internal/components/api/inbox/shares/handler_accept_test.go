@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
 
@@ -75,6 +76,31 @@ func TestHandleAccept_IdempotentForAlreadyAccepted(t *testing.T) {
 	}
 }
 
+func TestHandleAccept_ExpiredShareReturns410(t *testing.T) {
+	t.Parallel()
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	share := createExpiringShareForUser(t, repo, "prov-expired", time.Now().Add(-time.Minute).Unix())
+
+	router := newTestRouter(repo, &identity.User{ID: userAID, Username: "alice"})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/inbox/shares/"+share.ShareID+"/accept", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusGone {
+		t.Fatalf("expected 410 for expired share, got %d: %s", w.Code, w.Body.String())
+	}
+
+	got, err := repo.GetByIDForRecipientUserID(t.Context(), share.ShareID, userAID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Status != shares.ShareStatusPending {
+		t.Errorf("status = %s, want pending left for the janitor", got.Status)
+	}
+}
+
 func TestHandleAccept_ConflictForDeclinedShare(t *testing.T) {
 	t.Parallel()
 	repo := tsrepos.OpenMemory(t).IncomingShares
@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/net/webdav"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	inboxshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/inbox/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+)
+
+// davAccessor serves AccessOptions from a real WebDAV tree rooted at root,
+// standing in for the sender.
+type davAccessor struct {
+	url   string
+	calls atomic.Int32
+}
+
+func newDAVAccessor(t *testing.T, root string) *davAccessor {
+	t.Helper()
+
+	srv := httptest.NewServer(&webdav.Handler{
+		Prefix:     "/dav",
+		FileSystem: webdav.Dir(root),
+		LockSystem: webdav.NewMemLS(),
+	})
+	t.Cleanup(srv.Close)
+
+	return &davAccessor{url: srv.URL + "/dav/"}
+}
+
+func (a *davAccessor) Access(ctx context.Context, opts access.AccessOptions) (*access.AccessResult, error) {
+	a.calls.Add(1)
+
+	req, err := http.NewRequestWithContext(ctx, opts.Method, a.url+opts.SubPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	for key, values := range opts.Header {
+		req.Header[key] = values
+	}
+
+	if opts.Depth != "" {
+		req.Header.Set("Depth", opts.Depth)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+
+	return &access.AccessResult{Response: resp}, nil
+}
+
+func newContentRouter(repo sharesincoming.IncomingShareRepo, ac access.RemoteAccessor) http.Handler {
+	h := inboxshares.NewHandler(repo, ac, nil, currentUserFunc(&identity.User{ID: userAID, Username: "alice"}), testLogger)
+	r := chi.NewRouter()
+	r.Get("/inbox/shares/{shareId}/content", h.HandleContent)
+
+	return r
+}
+
+func getContent(t *testing.T, router http.Handler, shareID, query string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/inbox/shares/"+shareID+"/content"+query, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func writeContentFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func createAcceptedFolderShare(t *testing.T, repo sharesincoming.IncomingShareRepo) *sharesincoming.IncomingShare {
+	t.Helper()
+
+	share := &sharesincoming.IncomingShare{
+		ProviderID:      "prov-content-folder",
+		SenderHost:      "sender.example.com",
+		ShareWith:       userAID + "@example.com",
+		RecipientUserID: userAID,
+		Status:          shares.ShareStatusAccepted,
+		ResourceType:    "folder",
+		Name:            "results",
+		Owner:           "owner@sender.example.com",
+		Sender:          "sender@sender.example.com",
+		ShareType:       "user",
+		Permissions:     []string{"read"},
+		WebDAVID:        "webdav-id-prov-content-folder",
+		SharedSecret:    "secret-prov-content-folder",
+	}
+	if err := repo.Create(context.Background(), share); err != nil {
+		t.Fatal(err)
+	}
+
+	return share
+}
+
+func TestHandleContent_StreamsFileShare(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeContentFile(t, filepath.Join(root, "report.txt"), "hello, content")
+
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	share := createAcceptedShareForUser(t, repo, "prov-content-file", "sender.example.com", "report.txt")
+	router := newContentRouter(repo, newDAVAccessor(t, filepath.Join(root, "report.txt")))
+
+	w := getContent(t, router, share.ShareID, "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if got := w.Body.String(); got != "hello, content" {
+		t.Errorf("body = %q", got)
+	}
+
+	for key, want := range map[string]string{
+		"Content-Type":           "text/plain; charset=utf-8",
+		"Content-Disposition":    `attachment; filename=report.txt`,
+		"Content-Length":         "14",
+		"Accept-Ranges":          "bytes",
+		"X-Content-Type-Options": "nosniff",
+	} {
+		if got := w.Header().Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+
+	w = getContent(t, router, share.ShareID, "", http.Header{"Range": {"bytes=7-"}})
+	if w.Code != http.StatusPartialContent {
+		t.Fatalf("expected 206, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if got := w.Body.String(); got != "content" {
+		t.Errorf("ranged body = %q", got)
+	}
+
+	if got := w.Header().Get("Content-Range"); got != "bytes 7-13/14" {
+		t.Errorf("Content-Range = %q", got)
+	}
+}
+
+func TestHandleContent_ListsAndDownloadsFolderShare(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeContentFile(t, filepath.Join(root, "summary.txt"), "summary")
+	writeContentFile(t, filepath.Join(root, "run 1", "out.csv"), "a,b\n1,2\n")
+
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	share := createAcceptedFolderShare(t, repo)
+	router := newContentRouter(repo, newDAVAccessor(t, root))
+
+	w := getContent(t, router, share.ShareID, "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var listing inboxshares.ContentListing
+	if err := json.Unmarshal(w.Body.Bytes(), &listing); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if len(listing.Entries) != 2 {
+		t.Fatalf("entries = %+v", listing.Entries)
+	}
+
+	folder, file := listing.Entries[0], listing.Entries[1]
+	if folder.Name != "run 1" || folder.Type != "folder" || folder.Size != nil {
+		t.Errorf("first entry = %+v, want the folder", folder)
+	}
+
+	if file.Name != "summary.txt" || file.Type != "file" || file.Size == nil || *file.Size != 7 {
+		t.Errorf("second entry = %+v, want summary.txt", file)
+	}
+
+	w = getContent(t, router, share.ShareID, "?path=run%201", nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &listing); err != nil {
+		t.Fatalf("Unmarshal nested: %v (%d %s)", err, w.Code, w.Body.String())
+	}
+
+	if len(listing.Entries) != 1 || listing.Entries[0].Path != "run 1/out.csv" {
+		t.Fatalf("nested entries = %+v", listing.Entries)
+	}
+
+	w = getContent(t, router, share.ShareID, "?path=run%201/out.csv", nil)
+	if w.Code != http.StatusOK || w.Body.String() != "a,b\n1,2\n" {
+		t.Fatalf("download = %d %q", w.Code, w.Body.String())
+	}
+
+	if got := w.Header().Get("Content-Disposition"); got != "attachment; filename=out.csv" {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+}
+
+func TestHandleContent_Rejections(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	folder := createAcceptedFolderShare(t, repo)
+	file := createAcceptedShareForUser(t, repo, "prov-content-reject", "sender.example.com", "a.txt")
+	pending := createShareForUser(t, repo, userAID, "prov-content-pending", "sender.example.com")
+	ac := newDAVAccessor(t, root)
+	router := newContentRouter(repo, ac)
+
+	tests := []struct {
+		name    string
+		shareID string
+		query   string
+		want    int
+	}{
+		{"pending share", pending.ShareID, "", http.StatusBadRequest},
+		{"unknown share", "no-such-share", "", http.StatusNotFound},
+		{"path on file share", file.ShareID, "?path=a.txt", http.StatusBadRequest},
+		{"parent segment", folder.ShareID, "?path=../etc", http.StatusBadRequest},
+		{"empty segment", folder.ShareID, "?path=a//b", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		if w := getContent(t, router, tt.shareID, tt.query, nil); w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, w.Code, w.Body.String())
+		}
+	}
+
+	if n := ac.calls.Load(); n != 0 {
+		t.Errorf("rejected requests reached the sender %d times", n)
+	}
+
+	if w := getContent(t, router, folder.ShareID, "?path=missing.txt", nil); w.Code != http.StatusNotFound {
+		t.Errorf("missing path: expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandleContent_SharesOnlyForRecipient(t *testing.T) {
+	t.Parallel()
+
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	share := createAcceptedShareForUser(t, repo, "prov-content-owner", "sender.example.com", "a.txt")
+
+	h := inboxshares.NewHandler(repo, newDAVAccessor(t, t.TempDir()), nil,
+		currentUserFunc(&identity.User{ID: userBID, Username: "bob"}), testLogger)
+	r := chi.NewRouter()
+	r.Get("/inbox/shares/{shareId}/content", h.HandleContent)
+
+	if w := getContent(t, r, share.ShareID, "", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another user's share, got %d", w.Code)
+	}
+}
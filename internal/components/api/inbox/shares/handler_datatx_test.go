@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	inboxshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/inbox/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+// fakeQueue records enqueued shares and serves them back as queued transfers.
+type fakeQueue struct {
+	mu        sync.Mutex
+	transfers map[string]*datatx.Transfer
+}
+
+func (q *fakeQueue) Enqueue(_ context.Context, share *sharesincoming.IncomingShare) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.transfers == nil {
+		q.transfers = make(map[string]*datatx.Transfer)
+	}
+
+	q.transfers[share.ShareID] = &datatx.Transfer{
+		ShareID:         share.ShareID,
+		RecipientUserID: share.RecipientUserID,
+		Status:          datatx.StatusQueued,
+		CreatedAt:       time.Unix(1_700_000_000, 0).UTC(),
+	}
+
+	return nil
+}
+
+func (q *fakeQueue) Get(_ context.Context, shareID, recipientUserID string) (*datatx.Transfer, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t, ok := q.transfers[shareID]
+	if !ok || t.RecipientUserID != recipientUserID {
+		return nil, datatx.ErrTransferNotFound
+	}
+
+	return t, nil
+}
+
+func newDataTxRouter(repo sharesincoming.IncomingShareRepo, queue datatx.Queue) http.Handler {
+	h := inboxshares.NewHandler(repo, nil, nil, currentUserFunc(&identity.User{ID: userAID, Username: "alice"}), testLogger)
+	h.SetTransfers(queue)
+
+	r := chi.NewRouter()
+	r.Get("/inbox/shares/{shareId}", h.HandleGetDetail)
+	r.Post("/inbox/shares/{shareId}/accept", h.HandleAccept)
+
+	return r
+}
+
+func TestHandleAccept_EnqueuesDataTxTransfer(t *testing.T) {
+	t.Parallel()
+
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	queue := &fakeQueue{}
+	router := newDataTxRouter(repo, queue)
+
+	share := &sharesincoming.IncomingShare{
+		ProviderID:        "prov-datatx",
+		SenderHost:        "sender.example.com",
+		RecipientUserID:   userAID,
+		Status:            shares.ShareStatusPending,
+		ResourceType:      spec.ResourceTypeFile,
+		Name:              "dataset.bin",
+		ShareType:         spec.ShareTypeUser,
+		WebDAVAccessTypes: []string{spec.AccessTypeDataTx},
+	}
+	if err := repo.Create(context.Background(), share); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	remote := createShareForUser(t, repo, userAID, "prov-remote", "sender.example.com")
+
+	for _, id := range []string{share.ShareID, remote.ShareID} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/inbox/shares/"+id+"/accept", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("accept %s: %d %s", id, w.Code, w.Body.String())
+		}
+	}
+
+	if _, err := queue.Get(context.Background(), remote.ShareID, userAID); err == nil {
+		t.Error("a remote-only share must not be enqueued")
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/inbox/shares/"+share.ShareID, nil))
+
+	var detail inboxshares.InboxShareDetailView
+	if err := json.NewDecoder(w.Body).Decode(&detail); err != nil {
+		t.Fatalf("decode detail: %v", err)
+	}
+
+	if detail.Status != shares.ShareStatusAccepted || detail.Transfer == nil || detail.Transfer.Status != datatx.StatusQueued {
+		t.Fatalf("detail = %+v, transfer %+v; want an accepted share with a queued transfer", detail, detail.Transfer)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/inbox/shares/"+remote.ShareID, nil))
+
+	var raw map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&raw); err != nil {
+		t.Fatalf("decode detail: %v", err)
+	}
+
+	if _, ok := raw["transfer"]; ok {
+		t.Error("a share without a transfer must omit the transfer field")
+	}
+}
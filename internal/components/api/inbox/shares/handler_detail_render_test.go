@@ -223,3 +223,46 @@ func assertWebdavArmURI(t *testing.T, proto map[string]any, wantURI string) {
 		t.Errorf("expected webdav.uri %s, got %v", wantURI, webdav["uri"])
 	}
 }
+
+func TestHandleGetDetail_RendersSSHOnlyArm(t *testing.T) {
+	t.Parallel()
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	userA := &identity.User{ID: userAID, Username: "alice"}
+
+	share := &sharesincoming.IncomingShare{
+		ProviderID:      "prov-ssh-arm",
+		SenderHost:      "sender.example.com",
+		ShareWith:       userAID + "@example.com",
+		RecipientUserID: userAID,
+		Status:          shares.ShareStatusPending,
+		ResourceType:    "file",
+		Name:            "dataset.tar",
+		Owner:           "owner@sender.example.com",
+		Sender:          "sender@sender.example.com",
+		ShareType:       "user",
+		ProtocolName:    "multi",
+		SSHURI:          "w1@sftp.sender.example.com:2022/dataset.tar",
+		SSHAccessTypes:  []string{"remote", "datatx"},
+	}
+	if err := repo.Create(context.Background(), share); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	router := newTestRouter(repo, userA)
+	proto := requireProtocolObject(t, getShareDetailResponse(t, router, share.ShareID))
+
+	ssh, ok := proto["ssh"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected protocol.ssh to be an object, got %T", proto["ssh"])
+	}
+
+	if ssh["uri"] != share.SSHURI {
+		t.Errorf("expected ssh.uri %q, got %v", share.SSHURI, ssh["uri"])
+	}
+
+	assertStringArrayField(t, ssh, "accessTypes", share.SSHAccessTypes)
+
+	if _, present := proto["webdav"]; present {
+		t.Errorf("ssh-only share must not render a webdav arm: %v", proto["webdav"])
+	}
+}
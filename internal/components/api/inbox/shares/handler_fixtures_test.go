@@ -77,6 +77,31 @@ func createShareForUser(t *testing.T, repo sharesincoming.IncomingShareRepo, rec
 	return share
 }
 
+// createExpiringShareForUser stores a pending share for user A that expires
+// at the given Unix time.
+func createExpiringShareForUser(t *testing.T, repo sharesincoming.IncomingShareRepo, providerID string, expiration int64) *sharesincoming.IncomingShare {
+	t.Helper()
+
+	share := &sharesincoming.IncomingShare{
+		ProviderID:      providerID,
+		SenderHost:      "sender.example.com",
+		ShareWith:       userAID + "@example.com",
+		RecipientUserID: userAID,
+		Status:          shares.ShareStatusPending,
+		ResourceType:    "file",
+		Name:            "test-share-" + providerID,
+		Owner:           "owner@sender.example.com",
+		Sender:          "sender@sender.example.com",
+		ShareType:       "user",
+		Expiration:      &expiration,
+	}
+	if err := repo.Create(context.Background(), share); err != nil {
+		t.Fatal(err)
+	}
+
+	return share
+}
+
 // runShareStatusTransition posts the given accept/decline action for a fresh
 // share and asserts a 200 response and the resulting stored status.
 func runShareStatusTransition(t *testing.T, action, providerID string, want shares.ShareStatus) {
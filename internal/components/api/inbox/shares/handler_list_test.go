@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
 
@@ -52,6 +53,45 @@ func TestHandleList_ReturnsOnlyCurrentUserShares(t *testing.T) {
 	}
 }
 
+func TestHandleList_ReportsExpiration(t *testing.T) {
+	t.Parallel()
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	past := time.Now().Add(-time.Minute).Truncate(time.Second)
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+	expired := createExpiringShareForUser(t, repo, "prov-past", past.Unix())
+	createExpiringShareForUser(t, repo, "prov-future", future.Unix())
+
+	router := newTestRouter(repo, &identity.User{ID: userAID, Username: "alice"})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/inbox/shares/", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp inboxshares.InboxListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(resp.Shares) != 2 {
+		t.Fatalf("expected 2 shares, got %d", len(resp.Shares))
+	}
+
+	for _, v := range resp.Shares {
+		want, wantAt := shares.ShareStatusPending, future
+		if v.ShareID == expired.ShareID {
+			want, wantAt = shares.ShareStatusExpired, past
+		}
+
+		if v.Status != want {
+			t.Errorf("%s status = %s, want %s", v.ProviderID, v.Status, want)
+		}
+
+		if v.ExpiresAt == nil || !v.ExpiresAt.Equal(wantAt) {
+			t.Errorf("%s expiresAt = %v, want %v", v.ProviderID, v.ExpiresAt, wantAt)
+		}
+	}
+}
+
 func TestHandleList_EmptyForUserWithNoShares(t *testing.T) {
 	t.Parallel()
 	repo := tsrepos.OpenMemory(t).IncomingShares
@@ -12,6 +12,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -20,6 +21,9 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
 	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // HandleList handles GET /api/inbox/shares; returns only shares for the authenticated user.
@@ -81,6 +85,10 @@ func (h *Handler) HandleAccept(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if writeIfExpired(w, share) {
+		return
+	}
+
 	if share.Status == shares.ShareStatusAccepted {
 		w.Header().Set("Content-Type", "application/json")
 		//nolint:errcheck,errchkjson // response already committed after WriteHeader; write error cannot be recovered or meaningfully handled; payload encodes to fixed JSON, so encode error is always nil
@@ -111,8 +119,13 @@ func (h *Handler) HandleAccept(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics.ShareTransition(metrics.DirectionIncoming, string(shares.ShareStatusAccepted))
+	h.auditShareDecision(r, audit.ActionShareAccept, user.Username, share)
 	h.notifyShareAcceptedAsync(r, share.SenderHost, share.ProviderID, share.ResourceType)
 
+	share.Status = shares.ShareStatusAccepted
+	h.enqueueTransfer(ctx, share)
+
 	w.Header().Set("Content-Type", "application/json")
 	//nolint:errcheck,errchkjson // response already committed after WriteHeader; write error cannot be recovered or meaningfully handled; payload encodes to fixed JSON, so encode error is always nil
 	json.NewEncoder(w).Encode(map[string]string{
@@ -152,6 +165,7 @@ func (h *Handler) HandleGetDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	detail := NewInboxShareDetailView(share)
+	detail.Transfer = h.transferView(r.Context(), share)
 
 	w.Header().Set("Content-Type", "application/json")
 
@@ -192,6 +206,10 @@ func (h *Handler) HandleDecline(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if writeIfExpired(w, share) {
+		return
+	}
+
 	if share.Status == shares.ShareStatusDeclined {
 		w.Header().Set("Content-Type", "application/json")
 
@@ -231,6 +249,8 @@ func (h *Handler) HandleDecline(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics.ShareTransition(metrics.DirectionIncoming, string(shares.ShareStatusDeclined))
+	h.auditShareDecision(r, audit.ActionShareDecline, user.Username, share)
 	h.notifyShareDeclinedAsync(r, share.SenderHost, share.ProviderID, share.ResourceType)
 
 	w.Header().Set("Content-Type", "application/json")
@@ -243,6 +263,18 @@ func (h *Handler) HandleDecline(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// auditShareDecision records the local recipient accepting or declining an
+// incoming share.
+func (h *Handler) auditShareDecision(r *http.Request, action, username string, share *sharesincoming.IncomingShare) {
+	h.audit.Record(r.Context(), audit.Event{
+		Action:   action,
+		Actor:    username,
+		Peer:     share.SenderHost,
+		Resource: share.ShareID,
+		Details:  map[string]string{audit.DetailDirection: audit.DirectionIncoming},
+	})
+}
+
 // HandleVerifyAccess handles POST /api/inbox/shares/{shareId}/verify-access; all access is server-side (no secrets to browser).
 func (h *Handler) HandleVerifyAccess(w http.ResponseWriter, r *http.Request) {
 	user, err := h.currentUser(r.Context())
@@ -275,13 +307,21 @@ func (h *Handler) HandleVerifyAccess(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if share.Expired(time.Now()) {
+		writeVerifyError(w, http.StatusGone, verifyReasonShareExpired, "share has expired")
+
+		return
+	}
+
 	if share.Status != shares.ShareStatusAccepted {
 		writeVerifyError(w, http.StatusBadRequest, verifyReasonShareNotAccepted, "share must be accepted before verifying access")
 
 		return
 	}
 
-	if share.WebappURI != "" || strings.EqualFold(share.ProtocolName, access.ProtocolWebapp) {
+	// A webapp arm next to a webdav arm is verified over WebDAV; webapp-only
+	// shares can only be opened through open-webapp.
+	if share.WebDAVID == "" || strings.EqualFold(share.ProtocolName, access.ProtocolWebapp) {
 		writeVerifyError(w, http.StatusNotImplemented, verifyReasonUnsupportedProtocol, "webapp access is not supported; only webdav is served")
 
 		return
@@ -289,24 +329,19 @@ func (h *Handler) HandleVerifyAccess(w http.ResponseWriter, r *http.Request) {
 
 	protocol := access.ProtocolWebDAV
 
-	shareInfo := access.ShareInfo{
-		Status:            string(share.Status),
-		SenderHost:        share.SenderHost,
-		OwnerHost:         share.OwnerHost,
-		SharedSecret:      share.SharedSecret,
-		ProtocolName:      share.ProtocolName,
-		Requirements:      share.Requirements,
-		WebDAVID:          share.WebDAVID,
-		WebappURI:         share.WebappURI,
-		WebappTargets:     share.WebappTargets,
-		WebappPermissions: share.WebappPermissions,
-	}
-
-	result, err := h.accessClient.Access(ctx, access.AccessOptions{
-		Share:    &shareInfo,
+	// Folder shares are collections: GET is not defined on them, so probe the
+	// folder itself with a Depth 0 PROPFIND instead.
+	opts := access.AccessOptions{
+		Share:    access.ShareInfoFromIncoming(share),
 		Protocol: protocol,
-		Method:   "GET",
-	})
+		Method:   http.MethodGet,
+	}
+	if share.ResourceType == spec.ResourceTypeFolder {
+		opts.Method = "PROPFIND"
+		opts.Depth = "0"
+	}
+
+	result, err := h.accessClient.Access(ctx, opts)
 	if err != nil {
 		h.writeAccessError(w, err)
 
@@ -375,6 +410,18 @@ func redactPeerValue(value, secret string) string {
 }
 
 // writeVerifyError writes a VerifyAccessResponse with ok=false.
+// writeIfExpired answers 410 share_expired when share is past its
+// expiration and reports whether it did.
+func writeIfExpired(w http.ResponseWriter, share *sharesincoming.IncomingShare) bool {
+	if !share.Expired(time.Now()) {
+		return false
+	}
+
+	api.WriteError(w, http.StatusGone, verifyReasonShareExpired, "share has expired")
+
+	return true
+}
+
 func writeVerifyError(w http.ResponseWriter, statusCode int, reasonCode, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	inboxshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/inbox/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+)
+
+type mockWebappOpener struct {
+	openFn func(ctx context.Context, share *access.ShareInfo) (*access.WebappLaunch, error)
+}
+
+func (m *mockWebappOpener) OpenWebapp(ctx context.Context, share *access.ShareInfo) (*access.WebappLaunch, error) {
+	return m.openFn(ctx, share)
+}
+
+func newOpenWebappRouter(repo sharesincoming.IncomingShareRepo, opener access.WebappOpener, user *identity.User) http.Handler {
+	h := inboxshares.NewHandler(repo, nil, nil, currentUserFunc(user), testLogger)
+	if opener != nil {
+		h.SetWebappOpener(opener)
+	}
+
+	r := chi.NewRouter()
+	r.Post("/inbox/shares/{shareId}/open-webapp", h.HandleOpenWebapp)
+
+	return r
+}
+
+func postOpenWebapp(t *testing.T, router http.Handler, shareID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/inbox/shares/"+shareID+"/open-webapp", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestHandleOpenWebapp_ReturnsLaunch(t *testing.T) {
+	t.Parallel()
+
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	share := createAcceptedWebappShareForUser(t, repo, userAID, "prov-ow", "sender.example.com", "doc.odt")
+
+	var got *access.ShareInfo
+
+	opener := &mockWebappOpener{openFn: func(_ context.Context, s *access.ShareInfo) (*access.WebappLaunch, error) {
+		got = s
+
+		return &access.WebappLaunch{
+			URL:         s.WebappURI,
+			Targets:     s.WebappTargets,
+			Permissions: s.WebappPermissions,
+			AccessToken: "launch-token",
+			ExpiresIn:   600,
+		}, nil
+	}}
+
+	w := postOpenWebapp(t, newOpenWebappRouter(repo, opener, &identity.User{ID: userAID}), share.ShareID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp inboxshares.OpenWebappResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if resp.URL != share.WebappURI || resp.AccessToken != "launch-token" || resp.ExpiresIn != 600 {
+		t.Errorf("response = %+v", resp)
+	}
+
+	if !slices.Equal(resp.Targets, share.WebappTargets) || !slices.Equal(resp.Permissions, share.WebappPermissions) {
+		t.Errorf("targets/permissions = %v/%v", resp.Targets, resp.Permissions)
+	}
+
+	if got == nil || got.SharedSecret != share.SharedSecret || got.SenderHost != share.SenderHost {
+		t.Errorf("opener share info = %+v", got)
+	}
+}
+
+func TestHandleOpenWebapp_Rejections(t *testing.T) {
+	t.Parallel()
+
+	opener := &mockWebappOpener{openFn: func(context.Context, *access.ShareInfo) (*access.WebappLaunch, error) {
+		return nil, errors.New("opener must not be called")
+	}}
+
+	tests := []struct {
+		name       string
+		user       *identity.User
+		prepare    func(*sharesincoming.IncomingShare)
+		shareID    string
+		opener     access.WebappOpener
+		wantStatus int
+	}{
+		{name: "no session", wantStatus: http.StatusUnauthorized, opener: opener},
+		{name: "unknown share", user: &identity.User{ID: userAID}, shareID: "missing", opener: opener, wantStatus: http.StatusNotFound},
+		{name: "other user", user: &identity.User{ID: "someone-else"}, opener: opener, wantStatus: http.StatusNotFound},
+		{
+			name: "pending", user: &identity.User{ID: userAID}, opener: opener, wantStatus: http.StatusBadRequest,
+			prepare: func(s *sharesincoming.IncomingShare) { s.Status = shares.ShareStatusPending },
+		},
+		{
+			name: "no webapp arm", user: &identity.User{ID: userAID}, opener: opener, wantStatus: http.StatusConflict,
+			prepare: func(s *sharesincoming.IncomingShare) { s.WebappURI = "" },
+		},
+		{name: "no opener", user: &identity.User{ID: userAID}, wantStatus: http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := tsrepos.OpenMemory(t).IncomingShares
+			share := &sharesincoming.IncomingShare{
+				ProviderID:      "prov-ow-reject",
+				SenderHost:      "sender.example.com",
+				ShareWith:       userAID + "@example.com",
+				RecipientUserID: userAID,
+				Status:          shares.ShareStatusAccepted,
+				ResourceType:    "file",
+				Name:            "doc.odt",
+				Owner:           "owner@sender.example.com",
+				Sender:          "sender@sender.example.com",
+				ShareType:       "user",
+				Permissions:     []string{"read"},
+				WebDAVID:        "webdav-id-prov-ow-reject",
+				SharedSecret:    "secret-prov-ow-reject",
+				ProtocolName:    "multi",
+				WebappURI:       "https://app.sender.example.com/launch?share=prov-ow-reject",
+			}
+
+			if tt.prepare != nil {
+				tt.prepare(share)
+			}
+
+			if err := repo.Create(context.Background(), share); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+
+			shareID := tt.shareID
+			if shareID == "" {
+				shareID = share.ShareID
+			}
+
+			w := postOpenWebapp(t, newOpenWebappRouter(repo, tt.opener, tt.user), shareID)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleOpenWebapp_MapsOpenerErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"exchange unavailable", access.ErrTokenExchangeRequired, reason.APIStatus(reason.PeerCapabilityMismatch)},
+		{
+			"discovery failed",
+			reason.NewClassifiedError(reason.ReasonDiscoveryFailed, "failed to discover owner", nil),
+			reason.APIStatus(reason.PeerDiscoveryFailed),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := tsrepos.OpenMemory(t).IncomingShares
+			share := createAcceptedWebappShareForUser(t, repo, userAID, "prov-ow-err", "sender.example.com", "doc.odt")
+			opener := &mockWebappOpener{openFn: func(context.Context, *access.ShareInfo) (*access.WebappLaunch, error) {
+				return nil, tt.err
+			}}
+
+			w := postOpenWebapp(t, newOpenWebappRouter(repo, opener, &identity.User{ID: userAID}), share.ShareID)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -160,27 +160,29 @@ func TestHandleVerifyAccess_RejectsWebappShare(t *testing.T) {
 	assertVerifyAccessUnsupportedProtocol(t, repo, share.ShareID)
 }
 
-func TestHandleVerifyAccess_RejectsWebappByWebappURI(t *testing.T) {
+// TestHandleVerifyAccess_MultiArmUsesWebDAV verifies a share carrying both a
+// webdav and a webapp arm over the webdav arm.
+func TestHandleVerifyAccess_MultiArmUsesWebDAV(t *testing.T) {
 	t.Parallel()
 	repo := tsrepos.OpenMemory(t).IncomingShares
 
 	share := &sharesincoming.IncomingShare{
-		ProviderID:        "prov-va-webapp-uri",
+		ProviderID:        "prov-va-multi",
 		SenderHost:        "sender.example.com",
 		ShareWith:         userAID + "@example.com",
 		RecipientUserID:   userAID,
 		Status:            shares.ShareStatusAccepted,
 		ResourceType:      "file",
-		Name:              "uri-only.txt",
+		Name:              "multi-arm.txt",
 		Owner:             "owner@sender.example.com",
 		Sender:            "sender@sender.example.com",
 		ShareType:         "user",
 		Permissions:       []string{"read"},
-		WebDAVID:          "webdav-id-prov-va-webapp-uri",
-		SharedSecret:      "secret-prov-va-webapp-uri",
+		WebDAVID:          "webdav-id-prov-va-multi",
+		SharedSecret:      "secret-prov-va-multi",
 		Requirements:      []string{"must-exchange-token"},
-		ProtocolName:      "webdav",
-		WebappURI:         "https://app.sender.example.com/launch?share=prov-va-webapp-uri",
+		ProtocolName:      "multi",
+		WebappURI:         "https://app.sender.example.com/launch?share=prov-va-multi",
 		WebappTargets:     []string{"blank", "_self"},
 		WebappPermissions: []string{"view", "share"},
 	}
@@ -188,7 +190,10 @@ func TestHandleVerifyAccess_RejectsWebappByWebappURI(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	assertVerifyAccessUnsupportedProtocol(t, repo, share.ShareID)
+	gotProtocol, gotShareInfo := runVerifyAccess(t, repo, &identity.User{ID: userAID, Username: "alice"}, share.ShareID, "text/plain", "ok")
+	if gotProtocol != access.ProtocolWebDAV || gotShareInfo.WebDAVID != share.WebDAVID {
+		t.Errorf("access protocol = %q webdav id = %q, want webdav arm", gotProtocol, gotShareInfo.WebDAVID)
+	}
 }
 
 func TestHandleVerifyAccess_RejectsWebappByProtocolName(t *testing.T) {
@@ -220,3 +225,53 @@ func TestHandleVerifyAccess_RejectsWebappByProtocolName(t *testing.T) {
 
 	assertVerifyAccessUnsupportedProtocol(t, repo, share.ShareID)
 }
+
+func TestHandleVerifyAccess_FolderSharePropfindsDepthZero(t *testing.T) {
+	t.Parallel()
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	share := &sharesincoming.IncomingShare{
+		ProviderID:      "prov-va-folder",
+		SenderHost:      "sender.example.com",
+		ShareWith:       userAID + "@example.com",
+		RecipientUserID: userAID,
+		Status:          shares.ShareStatusAccepted,
+		ResourceType:    "folder",
+		Name:            "project",
+		Owner:           "owner@sender.example.com",
+		Sender:          "sender@sender.example.com",
+		ShareType:       "user",
+		Permissions:     []string{"read"},
+		WebDAVID:        "webdav-id-prov-va-folder",
+		SharedSecret:    "secret-prov-va-folder",
+	}
+	if err := repo.Create(context.Background(), share); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotOpts access.AccessOptions
+
+	ac := &mockAccessor{accessFn: func(_ context.Context, opts access.AccessOptions) (*access.AccessResult, error) {
+		gotOpts = opts
+
+		return &access.AccessResult{
+			Response: &http.Response{
+				StatusCode: http.StatusMultiStatus,
+				Header:     http.Header{"Content-Type": []string{"text/xml; charset=utf-8"}},
+				Body:       io.NopCloser(bytes.NewBufferString("<multistatus/>")),
+			},
+		}, nil
+	}}
+	router := newTestRouterWithAccess(repo, ac, &identity.User{ID: userAID, Username: "alice"})
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/inbox/shares/"+share.ShareID+"/verify-access", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if gotOpts.Method != "PROPFIND" || gotOpts.Depth != "0" {
+		t.Errorf("access method = %q depth = %q, want PROPFIND depth 0", gotOpts.Method, gotOpts.Depth)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+)
+
+// OpenWebappResponse is the body of the open-webapp endpoint. The browser
+// opens URL in one of Targets and hands AccessToken to the remote app.
+type OpenWebappResponse struct {
+	URL         string   `json:"url"`
+	Targets     []string `json:"targets"`
+	Permissions []string `json:"permissions"`
+	AccessToken string   `json:"accessToken"`
+	ExpiresIn   int      `json:"expiresIn,omitempty"`
+}
+
+// SetWebappOpener sets the client used by open-webapp. Without one the
+// endpoint answers 501.
+func (h *Handler) SetWebappOpener(opener access.WebappOpener) {
+	h.webappOpener = opener
+}
+
+// HandleOpenWebapp handles POST /api/inbox/shares/{shareId}/open-webapp.
+// Unlike verify-access, the exchanged token is returned to the browser: it is
+// short-lived, scoped to this share, and the remote app needs it.
+func (h *Handler) HandleOpenWebapp(w http.ResponseWriter, r *http.Request) {
+	user, err := h.currentUser(r.Context())
+	if err != nil {
+		api.WriteUnauthorized(w, api.ReasonUnauthenticated, "authentication required")
+
+		return
+	}
+
+	shareID := chi.URLParam(r, "shareId")
+	if shareID == "" {
+		api.WriteBadRequest(w, api.ReasonMissingField, "shareId is required")
+
+		return
+	}
+
+	ctx := r.Context()
+
+	share, err := h.repo.GetByIDForRecipientUserID(ctx, shareID, user.ID)
+	if err != nil {
+		if errors.Is(err, sharesincoming.ErrShareNotFound) {
+			api.WriteNotFound(w, "share not found")
+
+			return
+		}
+
+		h.log.Error("failed to get share", "share_id", shareID, "user_id", user.ID, "error", err)
+		api.WriteInternalError(w, "failed to get share")
+
+		return
+	}
+
+	if writeIfExpired(w, share) {
+		return
+	}
+
+	if share.Status != shares.ShareStatusAccepted {
+		api.WriteBadRequest(w, verifyReasonShareNotAccepted, "share must be accepted before opening it")
+
+		return
+	}
+
+	if share.WebappURI == "" {
+		api.WriteConflict(w, "share does not offer a webapp")
+
+		return
+	}
+
+	if h.webappOpener == nil {
+		api.WriteError(w, http.StatusNotImplemented, verifyReasonUnsupportedProtocol, "webapp access is not configured")
+
+		return
+	}
+
+	launch, err := h.webappOpener.OpenWebapp(ctx, access.ShareInfoFromIncoming(share))
+	if err != nil {
+		h.log.Warn("open webapp failed", "share_id", shareID, "error", err)
+		writeOpenWebappError(w, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(OpenWebappResponse{
+		URL:         launch.URL,
+		Targets:     nonNil(launch.Targets),
+		Permissions: nonNil(launch.Permissions),
+		AccessToken: launch.AccessToken,
+		ExpiresIn:   launch.ExpiresIn,
+	}); err != nil {
+		h.log.Error("failed to encode open webapp response", "error", err)
+	}
+}
+
+func writeOpenWebappError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, access.ErrShareNotAccepted):
+		api.WriteBadRequest(w, verifyReasonShareNotAccepted, "share must be accepted before opening it")
+	case errors.Is(err, access.ErrWebappNotOffered):
+		api.WriteConflict(w, "share does not offer a webapp")
+	case errors.Is(err, access.ErrTokenExchangeRequired):
+		api.WriteError(w, reason.APIStatus(reason.PeerCapabilityMismatch), reason.PeerCapabilityMismatch,
+			"token exchange required but not available")
+	default:
+		reasonCode := reason.CanonicalFromError(err)
+		api.WriteError(w, reason.APIStatus(reasonCode), reasonCode, "failed to open remote webapp")
+	}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return slices.Clone(s)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
 	invitesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // DefaultInviteTTL is the default time-to-live for invites.
@@ -104,6 +105,7 @@ func (h *Handler) HandleCreateOutgoing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics.InviteTransition(metrics.DirectionOutgoing, string(invite.Status))
 	h.logger.Info("invite created", "id", invite.ID)
 
 	w.Header().Set("Content-Type", "application/json")
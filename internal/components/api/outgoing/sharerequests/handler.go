@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package sharerequests provides the session-gated handler for POST
+// /api/share-requests/outgoing (ask a remote owner for access to a share).
+package sharerequests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
+	sharerequestsoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// CreateRequest is the body for POST /api/share-requests/outgoing.
+type CreateRequest struct {
+	// Owner is the OCM address of the remote user who owns the share.
+	Owner string `json:"owner"`
+	// Share is the share identifier (providerId) known to the requester.
+	Share string `json:"share"`
+}
+
+// Handler serves POST /api/share-requests/outgoing.
+type Handler struct {
+	poster        sharerequestsoutgoing.RequestSharePoster
+	localProvider string // raw host[:port] for the requester's shareWith address
+	currentUser   func(context.Context) (*identity.User, error)
+	log           *slog.Logger
+}
+
+// NewHandler returns a Handler with the given dependencies.
+func NewHandler(
+	poster sharerequestsoutgoing.RequestSharePoster,
+	localProvider string,
+	currentUser func(context.Context) (*identity.User, error),
+	log *slog.Logger,
+) *Handler {
+	log = logutil.NoopIfNil(log)
+
+	return &Handler{
+		poster:        poster,
+		localProvider: localProvider,
+		currentUser:   currentUser,
+		log:           log,
+	}
+}
+
+// HandleCreate handles POST /api/share-requests/outgoing. It answers 201 when
+// the owner's server stored the request, 404 when it does not know the share,
+// 501 when it does not support share requests, and 502 on any other failure.
+func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	user, err := h.currentUser(r.Context())
+	if err != nil {
+		api.WriteUnauthorized(w, api.ReasonUnauthenticated, "authentication required")
+
+		return
+	}
+
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteBadRequest(w, api.ReasonBadRequest, "failed to parse request")
+
+		return
+	}
+
+	if req.Owner == "" {
+		api.WriteBadRequest(w, api.ReasonMissingField, "owner is required")
+
+		return
+	}
+
+	if req.Share == "" {
+		api.WriteBadRequest(w, api.ReasonMissingField, "share is required")
+
+		return
+	}
+
+	_, ownerProvider, err := address.Parse(req.Owner)
+	if err != nil {
+		api.WriteBadRequest(w, api.ReasonInvalidField, "owner must be an OCM address (user@provider)")
+
+		return
+	}
+
+	payload := spec.RequestShareRequest{
+		Owner:     req.Owner,
+		ShareWith: address.FormatOutgoingOCMAddressFromUserID(user.ID, h.localProvider),
+		Share:     req.Share,
+	}
+
+	err = sharerequestsoutgoing.SendRequestShare(r.Context(), h.poster, payload, ownerProvider)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, sharerequestsoutgoing.ErrShareNotFound):
+		api.WriteNotFound(w, "remote share not found")
+
+		return
+	case errors.Is(err, sharerequestsoutgoing.ErrNotSupported):
+		api.WriteError(w, http.StatusNotImplemented, api.ReasonNotAllowed, "remote server does not support share requests")
+
+		return
+	default:
+		h.log.Warn("failed to send share request", "owner", req.Owner, "error", err)
+		api.WriteError(w, http.StatusBadGateway, api.ReasonPeerUnreachable, "failed to send share request")
+
+		return
+	}
+
+	h.log.Info("share request sent", "owner", req.Owner, "share", req.Share, "user_id", user.ID)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if err := json.NewEncoder(w).Encode(map[string]string{"status": "requested"}); err != nil {
+		h.log.Error("failed to encode share request response", "error", err)
+	}
+}
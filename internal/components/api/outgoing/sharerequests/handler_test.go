@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sharerequests_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	outgoingsharerequests "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/sharerequests"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+var testLogger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+type stubPoster struct {
+	status     int
+	err        error
+	targetHost string
+	body       []byte
+}
+
+func (p *stubPoster) PostRequestShare(_ context.Context, targetHost string, body []byte) (*http.Response, error) {
+	p.targetHost = targetHost
+	p.body = body
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &http.Response{StatusCode: p.status, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func newHandler(poster *stubPoster) *outgoingsharerequests.Handler {
+	user := &identity.User{ID: "user-1", Username: "bob"}
+
+	return outgoingsharerequests.NewHandler(poster, "requester.example",
+		func(_ context.Context) (*identity.User, error) { return user, nil }, testLogger)
+}
+
+func post(t *testing.T, h *outgoingsharerequests.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/share-requests/outgoing", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.HandleCreate(w, req)
+
+	return w
+}
+
+func TestHandleCreate_SendsToOwnerProvider(t *testing.T) {
+	t.Parallel()
+
+	poster := &stubPoster{status: http.StatusCreated}
+
+	w := post(t, newHandler(poster), `{"owner":"alice@owner.example","share":"provider-1"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201; body=%s", w.Code, w.Body.String())
+	}
+
+	if poster.targetHost != "owner.example" {
+		t.Errorf("target host = %q, want owner.example", poster.targetHost)
+	}
+
+	var sent spec.RequestShareRequest
+	if err := json.Unmarshal(poster.body, &sent); err != nil {
+		t.Fatalf("decode sent body: %v", err)
+	}
+
+	if sent.Owner != "alice@owner.example" || sent.Share != "provider-1" ||
+		!strings.HasSuffix(sent.ShareWith, "@requester.example") {
+		t.Errorf("sent body = %+v", sent)
+	}
+}
+
+func TestHandleCreate_MapsRemoteAnswers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		poster *stubPoster
+		want   int
+	}{
+		{"not found", &stubPoster{status: http.StatusNotFound}, http.StatusNotFound},
+		{"not implemented", &stubPoster{status: http.StatusNotImplemented}, http.StatusNotImplemented},
+		{"forbidden", &stubPoster{status: http.StatusForbidden}, http.StatusBadGateway},
+		{"transport error", &stubPoster{err: errors.New("dial failed")}, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := post(t, newHandler(tt.poster), `{"owner":"alice@owner.example","share":"provider-1"}`)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleCreate_ValidatesBody(t *testing.T) {
+	t.Parallel()
+
+	for _, body := range []string{
+		`not json`,
+		`{"share":"provider-1"}`,
+		`{"owner":"alice@owner.example"}`,
+		`{"owner":"no-provider","share":"provider-1"}`,
+	} {
+		poster := &stubPoster{status: http.StatusCreated}
+
+		w := post(t, newHandler(poster), body)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("body %s: status = %d, want 400", body, w.Code)
+		}
+
+		if poster.body != nil {
+			t.Errorf("body %s: request must not be sent", body)
+		}
+	}
+}
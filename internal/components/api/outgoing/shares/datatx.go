@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares
+
+import (
+	"os"
+
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+// applyDataTx asks the receiver to copy the resource instead of accessing it
+// remotely: the webdav arm gets accessTypes ["datatx"] and, for a file, its
+// size.
+func applyDataTx(proto *spec.WebDAVProtocol, req sharesoutgoing.OutgoingShareRequest, cleanPath, resourceType string) {
+	if !req.DataTx {
+		return
+	}
+
+	proto.AccessTypes = []string{spec.AccessTypeDataTx}
+
+	if resourceType != spec.ResourceTypeFile {
+		return
+	}
+
+	if info, err := os.Stat(cleanPath); err == nil {
+		size := info.Size()
+		proto.Size = &size
+	}
+}
@@ -37,15 +37,17 @@ func (h *Handler) resolvePeerOrigin(peerDomain string) resolvedPeerOrigin {
 	}
 }
 
+// sendShareToReceiver posts payload to the receiver and returns the
+// recipientPublicKeys of its create-share response.
 func (h *Handler) sendShareToReceiver(
 	ctx context.Context,
 	origin resolvedPeerOrigin,
 	disc *spec.Discovery,
 	payload spec.NewShareRequest,
-) error {
+) ([]string, error) {
 	body, err := json.Marshal(payload) //nolint:errchkjson // payload type cannot fail to encode, so the checked error is always nil
 	if err != nil {
-		return fmt.Errorf("failed to encode payload: %w", err)
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
 	}
 
 	poster := outbound.NewPoster(h.httpClient, h.discoveryClient, h.signer, h.peerOrigin)
@@ -59,29 +61,31 @@ func (h *Handler) sendShareToReceiver(
 		Discovery: disc,
 	})
 	if err != nil {
-		return fmt.Errorf("api: send outgoing share: %w", err)
+		return nil, fmt.Errorf("api: send outgoing share: %w", err)
 	}
 	defer func() {
 		//nolint:errcheck // best-effort cleanup; error is not actionable
 		resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		maxBytes := int64(config.DefaultMaxResponseBytes)
+	maxBytes := int64(config.DefaultMaxResponseBytes)
+
+	respBody, readErr := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
 
-		respBody, readErr := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		if readErr != nil {
-			return fmt.Errorf("receiver returned status %d: %w", resp.StatusCode, readErr)
+			return nil, fmt.Errorf("receiver returned status %d: %w", resp.StatusCode, readErr)
 		}
 
 		if int64(len(respBody)) > maxBytes {
-			return fmt.Errorf("receiver returned status %d: response body too large (%d bytes read)", resp.StatusCode, len(respBody))
+			return nil, fmt.Errorf("receiver returned status %d: response body too large (%d bytes read)", resp.StatusCode, len(respBody))
 		}
 
-		return fmt.Errorf("receiver returned status %d (response body %d bytes)", resp.StatusCode, len(respBody))
+		return nil, fmt.Errorf("receiver returned status %d (response body %d bytes)", resp.StatusCode, len(respBody))
 	}
 
-	return nil
+	// A truncated or unreadable body fails to decode and yields no keys.
+	return recipientPublicKeys(respBody), nil
 }
 
 func generateSharedSecret() (string, error) {
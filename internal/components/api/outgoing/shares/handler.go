@@ -3,7 +3,8 @@
 //
 // OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
 
-// Package shares provides the session-gated handler for POST /api/shares/outgoing (create shares to remote receivers).
+// Package shares provides the session-gated handlers for /api/shares/outgoing
+// (list, inspect, create, and revoke file or folder shares to remote receivers).
 package shares
 
 import (
@@ -25,15 +26,19 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peerorigin"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/policy"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
 	ocmshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
 	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
 	httpclient "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/client"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // PeerFactsResolver resolves code-flow facts for a remote peer.
@@ -41,7 +46,7 @@ type PeerFactsResolver interface {
 	ResolveFacts(host string) policy.Facts
 }
 
-// Handler serves POST /api/shares/outgoing to create and send shares.
+// Handler serves /api/shares/outgoing to list, create, send, and revoke shares.
 type Handler struct {
 	repo               sharesoutgoing.OutgoingShareRepo
 	discoveryClient    *discovery.Client
@@ -54,6 +59,12 @@ type Handler struct {
 	currentUser        func(context.Context) (*identity.User, error)
 	logger             *slog.Logger
 	allowedPaths       []string
+	tokens             sharesoutgoing.TokenRevoker
+	notifier           sharesoutgoing.Notifier
+	outbox             *outbox.Dispatcher
+	webapp             config.WebappConfig
+	sshAddr            string
+	audit              audit.Recorder
 }
 
 // NewHandler returns a Handler with the given dependencies. Panics if discoveryClient is nil.
@@ -84,6 +95,7 @@ func NewHandler(
 		currentUser:        currentUser,
 		logger:             logger,
 		resolver:           resolver,
+		audit:              audit.Nop(),
 	}
 }
 
@@ -97,6 +109,12 @@ func (h *Handler) SetPeerOrigin(peerOrigin *peerorigin.Resolver) {
 	h.peerOrigin = peerOrigin
 }
 
+// SetAudit sets the audit recorder for created and revoked shares; nil
+// disables it.
+func (h *Handler) SetAudit(r audit.Recorder) {
+	h.audit = audit.OrNop(r)
+}
+
 // HandleCreate handles POST /api/shares/outgoing.
 func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 	req, user, ok := h.parseOutgoingRequest(w, r)
@@ -104,24 +122,41 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.CreateForUser(w, r, user, req)
+}
+
+// CreateForUser runs the outgoing share pipeline for an already validated
+// request on behalf of user and writes the HTTP response. It returns the
+// stored share when the share was sent or queued, and nil when the response
+// reports a failure.
+func (h *Handler) CreateForUser(
+	w http.ResponseWriter,
+	r *http.Request,
+	user *identity.User,
+	req sharesoutgoing.OutgoingShareRequest,
+) *sharesoutgoing.OutgoingShare {
 	cleanPath, resourceType, name, ok := h.resolveLocalResource(w, r, req)
 	if !ok {
-		return
+		return nil
+	}
+
+	if req.ShareType == "" {
+		req.ShareType = spec.ShareTypeUser
 	}
 
 	providerID, webdavID, sharedSecret, ok := h.generateShareIdentifiers(w, r)
 	if !ok {
-		return
+		return nil
 	}
 
 	origin, disc, requirements, ok := h.resolveReceiverAndRequirements(w, r, req)
 	if !ok {
-		return
+		return nil
 	}
 
 	webdavURI, ok := h.buildWebDAVURI(w, r, req, webdavID, disc)
 	if !ok {
-		return
+		return nil
 	}
 
 	owner := address.FormatOutgoingOCMAddressFromUserID(user.ID, h.localProvider)
@@ -134,20 +169,44 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		Requirements: requirements,
 	}
 
+	applyDataTx(webdavProto, req, cleanPath, resourceType)
+
+	webappProto, ok := h.buildWebappProtocol(w, req, disc, webdavID.String(), name)
+	if !ok {
+		return nil
+	}
+
+	sshProto, ok := h.buildSSHProtocol(w, req, disc, webdavID.String(), name)
+	if !ok {
+		return nil
+	}
+
 	payload := spec.NewShareRequest{
 		ShareWith:    req.ShareWith,
 		Name:         name,
 		ProviderID:   providerID.String(),
 		Owner:        owner,
 		Sender:       sender,
-		ShareType:    "user",
+		ShareType:    req.ShareType,
 		ResourceType: resourceType,
 		Protocol: spec.Protocol{
 			Name:   "multi",
 			WebDAV: webdavProto,
+			Webapp: webappProto,
+			SSH:    sshProto,
 		},
 	}
 
+	// The wire carries whole seconds; store the same instant we send.
+	var expiresAt *time.Time
+
+	if req.ExpiresAt != nil {
+		t := req.ExpiresAt.UTC().Truncate(time.Second)
+		expiration := t.Unix()
+		expiresAt = &t
+		payload.Expiration = &expiration
+	}
+
 	share := &sharesoutgoing.OutgoingShare{
 		ProviderID:       providerID.String(),
 		WebDAVID:         webdavID.String(),
@@ -158,22 +217,41 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 		ShareWith:        req.ShareWith,
 		Name:             name,
 		ResourceType:     resourceType,
-		ShareType:        "user",
+		ShareType:        req.ShareType,
 		Permissions:      req.Permissions,
 		Owner:            owner,
 		Sender:           sender,
 		Status:           ocmshares.OutgoingShareStatusPending,
 		Requirements:     requirements,
+		ExpiresAt:        expiresAt,
+	}
+	if webappProto != nil {
+		share.WebappURI = webappProto.URI
+		share.WebappPermissions = webappProto.Permissions
+		share.WebappTargets = webappProto.Targets
+		share.WebappSharedSecret = webappProto.SharedSecret
+	}
+
+	if sshProto != nil {
+		share.SSHURI = sshProto.URI
 	}
 
 	if err := h.repo.Create(r.Context(), share); err != nil {
 		h.logger.Error("failed to store outgoing share", "error", err)
 		api.WriteInternalError(w, "failed to create share")
 
-		return
+		return nil
+	}
+
+	metrics.ShareTransition(metrics.DirectionOutgoing, string(share.Status))
+	h.auditShare(r.Context(), audit.ActionShareCreate, user.Username, share)
+
+	if h.outbox != nil {
+		return h.deliverViaOutbox(w, r, share, req.ReceiverDomain, payload)
 	}
 
-	if err := h.sendShareToReceiver(r.Context(), origin, disc, payload); err != nil {
+	keys, err := h.sendShareToReceiver(r.Context(), origin, disc, payload)
+	if err != nil {
 		h.logger.Warn("failed to deliver share to receiver", "receiver", req.ReceiverDomain, "error", err)
 
 		share.Status = ocmshares.OutgoingShareStatusFailed
@@ -181,31 +259,57 @@ func (h *Handler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 
 		if uerr := h.repo.Update(r.Context(), share); uerr != nil {
 			h.logger.Error("failed to mark outgoing share as failed", "share_id", share.ShareID, "error", uerr)
+		} else {
+			metrics.ShareTransition(metrics.DirectionOutgoing, string(share.Status))
 		}
 
 		api.WriteError(w, http.StatusBadGateway, reason.PeerUnreachable, "failed to deliver share to receiver")
 
-		return
+		return nil
 	}
 
 	share.Status = ocmshares.OutgoingShareStatusSent
 	sentAt := time.Now()
 	share.SentAt = &sentAt
+	share.RecipientPublicKeys = keys
 
 	if err := h.repo.Update(r.Context(), share); err != nil {
 		h.logger.Error("failed to mark outgoing share as sent", "share_id", share.ShareID, "error", err)
 		api.WriteInternalError(w, "share sent but local persistence failed")
 
-		return
+		return nil
 	}
 
+	metrics.ShareTransition(metrics.DirectionOutgoing, string(share.Status))
+
 	h.logger.Info("outgoing share created and sent",
 		"share_id", share.ShareID,
 		"provider_id", share.ProviderID,
 		"receiver", req.ReceiverDomain)
 
+	h.writeCreated(w, http.StatusCreated, share)
+
+	return share
+}
+
+// writeCreated answers a share create with its identifiers and status.
+// auditShare records a local user acting on one of their outgoing shares.
+func (h *Handler) auditShare(ctx context.Context, action, username string, share *sharesoutgoing.OutgoingShare) {
+	h.audit.Record(ctx, audit.Event{
+		Action:   action,
+		Actor:    username,
+		Peer:     share.ReceiverHost,
+		Resource: share.ShareID,
+		Details: map[string]string{
+			audit.DetailDirection: audit.DirectionOutgoing,
+			"share_with":          share.ShareWith,
+		},
+	})
+}
+
+func (h *Handler) writeCreated(w http.ResponseWriter, status int, share *sharesoutgoing.OutgoingShare) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(status)
 
 	if err := json.NewEncoder(w).Encode(map[string]string{
 		"shareId":    share.ShareID,
@@ -243,7 +347,11 @@ func (h *Handler) validateLocalPath(path string) (string, error) {
 }
 
 func (h *Handler) isPathAllowed(cleanPath string) bool {
-	for _, prefix := range h.allowedPaths {
+	return pathWithinPrefixes(cleanPath, h.allowedPaths)
+}
+
+func pathWithinPrefixes(cleanPath string, prefixes []string) bool {
+	for _, prefix := range prefixes {
 		cleanPrefix := filepath.Clean(prefix)
 		if cleanPrefix == string(os.PathSeparator) {
 			if filepath.IsAbs(cleanPath) {
@@ -262,6 +370,24 @@ func (h *Handler) isPathAllowed(cleanPath string) bool {
 	return false
 }
 
+// isResolvedPathAllowed repeats the allowlist check with symlinks resolved on
+// both the candidate path and the allowed prefixes.
+func (h *Handler) isResolvedPathAllowed(cleanPath string) bool {
+	resolved, err := filepath.EvalSymlinks(cleanPath)
+	if err != nil {
+		return false
+	}
+
+	resolvedPrefixes := make([]string, 0, len(h.allowedPaths))
+	for _, prefix := range h.allowedPaths {
+		if rp, err := filepath.EvalSymlinks(prefix); err == nil {
+			resolvedPrefixes = append(resolvedPrefixes, rp)
+		}
+	}
+
+	return pathWithinPrefixes(resolved, resolvedPrefixes)
+}
+
 func (h *Handler) parseOutgoingRequest(w http.ResponseWriter, r *http.Request) (sharesoutgoing.OutgoingShareRequest, *identity.User, bool) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -311,12 +437,32 @@ func (h *Handler) parseOutgoingRequest(w http.ResponseWriter, r *http.Request) (
 		supported := slices.Contains(spec.SupportedWebDAVPermissions, perm)
 
 		if !supported {
-			api.WriteBadRequest(w, api.ReasonInvalidField, "permissions must be read-only")
+			api.WriteBadRequest(w, api.ReasonInvalidField, `permissions must be "read" and/or "write"`)
 
 			return sharesoutgoing.OutgoingShareRequest{}, nil, false
 		}
 	}
 
+	for _, perm := range req.WebappPermissions {
+		if !slices.Contains(spec.OutgoingWebappPermissions, perm) {
+			api.WriteBadRequest(w, api.ReasonInvalidField, `webappPermissions must be "view", "read", and/or "write"`)
+
+			return sharesoutgoing.OutgoingShareRequest{}, nil, false
+		}
+	}
+
+	if req.ShareType != "" && !spec.IsSupportedShareType(req.ShareType) {
+		api.WriteBadRequest(w, api.ReasonInvalidField, `shareType must be "user" or "group"`)
+
+		return sharesoutgoing.OutgoingShareRequest{}, nil, false
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		api.WriteBadRequest(w, api.ReasonInvalidField, "expiresAt must be in the future")
+
+		return sharesoutgoing.OutgoingShareRequest{}, nil, false
+	}
+
 	return req, user, true
 }
 
@@ -335,21 +481,17 @@ func (h *Handler) resolveLocalResource(w http.ResponseWriter, _ *http.Request, r
 		return "", "", "", false
 	}
 
-	if stat.IsDir() {
-		api.WriteBadRequest(w, api.ReasonInvalidField, "directory shares are not supported; only single files")
+	resourceType, msg := resolveResourceType(req.ResourceType, stat.IsDir())
+	if msg != "" {
+		api.WriteBadRequest(w, api.ReasonInvalidField, msg)
 
 		return "", "", "", false
 	}
 
-	resourceType := req.ResourceType
-	if resourceType == "" {
-		resourceType = "file"
-	} else if resourceType != spec.SupportedResourceTypes[0] {
-		api.WriteBadRequest(w, api.ReasonInvalidField, fmt.Sprintf(
-			"resource type %q is not supported; only %q is supported",
-			resourceType,
-			spec.SupportedResourceTypes[0],
-		))
+	// A folder share exposes everything beneath it, so the directory must
+	// stay inside the allowlist after symlinks are resolved.
+	if resourceType == spec.ResourceTypeFolder && !h.isResolvedPathAllowed(cleanPath) {
+		api.WriteBadRequest(w, api.ReasonInvalidField, "path not in allowed directories")
 
 		return "", "", "", false
 	}
@@ -362,6 +504,31 @@ func (h *Handler) resolveLocalResource(w http.ResponseWriter, _ *http.Request, r
 	return cleanPath, resourceType, name, true
 }
 
+// resolveResourceType defaults the resource type from the stat result (folder
+// for directories, file otherwise) and rejects explicit values that are not
+// supported or do not match what is on disk. A non-empty message means reject.
+func resolveResourceType(requested string, isDir bool) (string, string) {
+	actual := spec.ResourceTypeFile
+	if isDir {
+		actual = spec.ResourceTypeFolder
+	}
+
+	if requested == "" {
+		return actual, ""
+	}
+
+	if !spec.IsSupportedResourceType(requested) {
+		return "", fmt.Sprintf("resource type %q is not supported; only %q and %q are supported",
+			requested, spec.ResourceTypeFile, spec.ResourceTypeFolder)
+	}
+
+	if requested != actual {
+		return "", fmt.Sprintf("resource type %q does not match localPath, which is a %s", requested, actual)
+	}
+
+	return actual, ""
+}
+
 func (h *Handler) generateShareIdentifiers(w http.ResponseWriter, _ *http.Request) (uuid.UUID, uuid.UUID, string, bool) {
 	providerID, err := uuid.NewV7()
 	if err != nil {
@@ -409,6 +576,14 @@ func (h *Handler) resolveReceiverAndRequirements(w http.ResponseWriter, r *http.
 		return resolvedPeerOrigin{}, nil, nil, false
 	}
 
+	if req.ShareType == spec.ShareTypeGroup && !disc.AcceptsShareType(spec.ShareTypeGroup) {
+		h.logger.Warn("receiver does not accept group shares", "receiver", req.ReceiverDomain)
+		api.WriteError(w, reason.APIStatus(reason.PeerCapabilityMismatch), reason.PeerCapabilityMismatch,
+			"receiver does not advertise group shares")
+
+		return resolvedPeerOrigin{}, nil, nil, false
+	}
+
 	facts := policy.Facts{}
 	if h.resolver != nil {
 		facts = h.resolver.ResolveFacts(origin.peerDomain)
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"net/http"
+	"os"
+	"slices"
+	"testing"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+func TestHandleCreate_DataTxMarksWebDAVArm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		datatx     string
+		wantTypes  []string
+		wantSizeOK bool
+	}{
+		{"datatx", `, "datatx": true`, []string{spec.AccessTypeDataTx}, true},
+		{"remote", ``, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, _, captured := makeCapturingReceiverTLSServer(t, []string{"exchange-token"}, []string{})
+			defer srv.Close()
+
+			user := &identity.User{ID: "user-uuid", Username: "alice"}
+			discClient, ctxClient := makeTLSClients()
+			handler := newStrictOutgoingHandler(t, tsrepos.OpenMemory(t).OutgoingShares, discClient, ctxClient, user)
+
+			tmpFile := createTempShareFile(t, "outgoing-datatx-*")
+			if err := os.WriteFile(tmpFile, []byte("twelve bytes"), 0o600); err != nil {
+				t.Fatalf("write share file: %v", err)
+			}
+
+			host := srv.Listener.Addr().String()
+			body := `{"receiverDomain": "` + host + `", "shareWith": "bob@` + host + `", "localPath": "` + tmpFile +
+				`", "permissions": ["read"]` + tt.datatx + `}`
+
+			w := postWebappCreate(t, handler, body)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+			}
+
+			webdav := captured.Protocol.WebDAV
+			if webdav == nil || !slices.Equal(webdav.AccessTypes, tt.wantTypes) {
+				t.Fatalf("webdav arm = %+v, want accessTypes %v", webdav, tt.wantTypes)
+			}
+
+			if gotSize := webdav.Size != nil && *webdav.Size == 12; gotSize != tt.wantSizeOK {
+				t.Errorf("webdav size = %v, want set to 12: %v", webdav.Size, tt.wantSizeOK)
+			}
+		})
+	}
+}
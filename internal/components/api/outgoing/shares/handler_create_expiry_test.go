@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+)
+
+func TestHandleCreate_ExpiresAtSentAndStored(t *testing.T) {
+	t.Parallel()
+
+	srv, _, captured := makeCapturingReceiverTLSServer(t, []string{"exchange-token"}, []string{})
+	defer srv.Close()
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	repo := tsrepos.OpenMemory(t).OutgoingShares
+	discClient, ctxClient := makeTLSClients()
+	handler := newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	host := srv.Listener.Addr().String()
+	body := `{"receiverDomain": "` + host + `", "shareWith": "bob@` + host + `", "localPath": "` +
+		createTempShareFile(t, "outgoing-expiry-*") + `", "permissions": ["read"], "expiresAt": "` +
+		expiresAt.Format(time.RFC3339) + `"}`
+
+	w := postWebappCreate(t, handler, body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if captured.Expiration == nil || *captured.Expiration != expiresAt.Unix() {
+		t.Fatalf("wire expiration = %v, want %d", captured.Expiration, expiresAt.Unix())
+	}
+
+	var created struct {
+		ShareID string `json:"shareId"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	share, err := repo.GetByID(t.Context(), created.ShareID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if share.ExpiresAt == nil || !share.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("stored expiresAt = %v, want %v", share.ExpiresAt, expiresAt)
+	}
+}
+
+func TestHandleCreate_RejectsPastExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	handler := newTestHandler(t, testCurrentUser(user))
+
+	body := `{"receiverDomain": "receiver.example.com", "shareWith": "bob@receiver.example.com", "localPath": "` +
+		createTempShareFile(t, "outgoing-expiry-*") + `", "permissions": ["read"], "expiresAt": "` +
+		time.Now().Add(-time.Hour).UTC().Format(time.RFC3339) + `"}`
+
+	w := postWebappCreate(t, handler, body)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a past expiresAt, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	outgoingshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peerorigin"
+	ocmshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+)
+
+// makeScriptedReceiverTLSServer answers POST /ocm/shares with statuses in
+// order, repeating the last one once the script runs out.
+func makeScriptedReceiverTLSServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	postCount := &atomic.Int32{}
+
+	var srv *httptest.Server
+
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/ocm" {
+			w.Header().Set("Content-Type", "application/json")
+			tshttp.WriteJSON(w, spec.Discovery{
+				Enabled:       true,
+				APIVersion:    "1.4.0",
+				EndPoint:      srv.URL + "/ocm",
+				Capabilities:  []string{"exchange-token"},
+				TokenEndPoint: srv.URL + "/ocm/token",
+			})
+
+			return
+		}
+
+		if r.Method == http.MethodPost && r.URL.Path == "/ocm/shares" {
+			n := int(postCount.Add(1))
+			w.WriteHeader(statuses[min(n, len(statuses))-1])
+
+			return
+		}
+
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, postCount
+}
+
+type outboxShareFixture struct {
+	repo       sharesoutgoing.OutgoingShareRepo
+	messages   outbox.MessageRepo
+	dispatcher *outbox.Dispatcher
+	handler    *outgoingshares.Handler
+	notifier   *revokeRecordingNotifier
+	now        time.Time
+}
+
+func newOutboxShareFixture(t *testing.T) *outboxShareFixture {
+	t.Helper()
+
+	r := tsrepos.OpenMemory(t)
+	discClient, ctxClient := makeTLSClients()
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+
+	f := &outboxShareFixture{
+		repo:     r.OutgoingShares,
+		messages: r.Outbox,
+		notifier: newRevokeRecordingNotifier(),
+		now:      time.Now(),
+	}
+
+	poster := outbound.NewPoster(ctxClient, discClient, makeTestSigner(t), peerorigin.NewResolver(false))
+	f.dispatcher = outbox.NewDispatcher(r.Outbox, poster, outbox.Policy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Minute,
+	}, testLogger)
+	f.dispatcher.SetClock(func() time.Time { return f.now })
+
+	f.handler = newStrictOutgoingHandler(t, r.OutgoingShares, discClient, ctxClient, user)
+	f.handler.SetNotifier(f.notifier)
+	f.handler.SetOutbox(f.dispatcher)
+
+	return f
+}
+
+func (f *outboxShareFixture) create(t *testing.T, receiverHost string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	tmpFile := createTempShareFile(t, "outgoing-outbox-*")
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/shares/outgoing",
+		bytes.NewBufferString(outgoingCreateBody(receiverHost, tmpFile)))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	f.handler.HandleCreate(w, req)
+
+	var resp map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v (%s)", err, w.Body.String())
+	}
+
+	return w, resp
+}
+
+func (f *outboxShareFixture) onlyShare(t *testing.T) *sharesoutgoing.OutgoingShare {
+	t.Helper()
+
+	all, err := f.repo.List(t.Context())
+	if err != nil || len(all) != 1 {
+		t.Fatalf("expected one stored share, got %d (%v)", len(all), err)
+	}
+
+	return all[0]
+}
+
+func (f *outboxShareFixture) messageList(t *testing.T) []*outbox.Message {
+	t.Helper()
+
+	msgs, err := f.messages.List(t.Context())
+	if err != nil {
+		t.Fatalf("list outbox: %v", err)
+	}
+
+	return msgs
+}
+
+func TestHandleCreate_Outbox_DeliveredReturns201(t *testing.T) {
+	t.Parallel()
+
+	srv, postCount := makeScriptedReceiverTLSServer(t, http.StatusCreated)
+	f := newOutboxShareFixture(t)
+
+	w, resp := f.create(t, srv.Listener.Addr().String())
+	if w.Code != http.StatusCreated || resp["status"] != string(ocmshares.OutgoingShareStatusSent) {
+		t.Fatalf("expected 201 sent, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if postCount.Load() != 1 {
+		t.Fatalf("expected one delivery POST, got %d", postCount.Load())
+	}
+
+	if share := f.onlyShare(t); share.Status != ocmshares.OutgoingShareStatusSent || share.SentAt == nil {
+		t.Fatalf("stored share = %q (sentAt %v), want sent with SentAt", share.Status, share.SentAt)
+	}
+
+	if msgs := f.messageList(t); len(msgs) != 0 {
+		t.Fatalf("delivered message must be removed from the outbox, found %d", len(msgs))
+	}
+}
+
+func TestHandleCreate_Outbox_RetryableFailureQueuesAndRetries(t *testing.T) {
+	t.Parallel()
+
+	srv, postCount := makeScriptedReceiverTLSServer(t, http.StatusServiceUnavailable, http.StatusCreated)
+	f := newOutboxShareFixture(t)
+
+	w, resp := f.create(t, srv.Listener.Addr().String())
+	if w.Code != http.StatusAccepted || resp["status"] != string(ocmshares.OutgoingShareStatusPending) {
+		t.Fatalf("expected 202 pending, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if share := f.onlyShare(t); share.Status != ocmshares.OutgoingShareStatusPending {
+		t.Fatalf("stored share status = %q, want pending", share.Status)
+	}
+
+	msgs := f.messageList(t)
+	if len(msgs) != 1 || msgs[0].Status != outbox.StatusPending || msgs[0].Attempts != 1 ||
+		msgs[0].LastStatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("outbox after first attempt = %+v", msgs)
+	}
+
+	if n, err := f.dispatcher.ProcessDue(t.Context()); err != nil || n != 0 {
+		t.Fatalf("ProcessDue before backoff = %d, %v; want nothing due", n, err)
+	}
+
+	f.now = f.now.Add(time.Minute)
+
+	if n, err := f.dispatcher.ProcessDue(t.Context()); err != nil || n != 1 {
+		t.Fatalf("ProcessDue after backoff = %d, %v; want one attempt", n, err)
+	}
+
+	if postCount.Load() != 2 {
+		t.Fatalf("expected two delivery POSTs, got %d", postCount.Load())
+	}
+
+	if share := f.onlyShare(t); share.Status != ocmshares.OutgoingShareStatusSent || share.SentAt == nil {
+		t.Fatalf("stored share after retry = %q (sentAt %v), want sent", share.Status, share.SentAt)
+	}
+
+	if msgs := f.messageList(t); len(msgs) != 0 {
+		t.Fatalf("delivered message must be removed from the outbox, found %d", len(msgs))
+	}
+}
+
+func TestHandleCreate_Outbox_RejectionDeadLettersAndFailsShare(t *testing.T) {
+	t.Parallel()
+
+	srv, postCount := makeScriptedReceiverTLSServer(t, http.StatusBadRequest)
+	f := newOutboxShareFixture(t)
+
+	w, _ := f.create(t, srv.Listener.Addr().String())
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if postCount.Load() != 1 {
+		t.Fatalf("a 4xx rejection must not be retried, got %d POSTs", postCount.Load())
+	}
+
+	share := f.onlyShare(t)
+	if share.Status != ocmshares.OutgoingShareStatusFailed || share.Error == "" {
+		t.Fatalf("stored share = %q (error %q), want failed with error", share.Status, share.Error)
+	}
+
+	msgs := f.messageList(t)
+	if len(msgs) != 1 || msgs[0].Status != outbox.StatusDead {
+		t.Fatalf("outbox = %+v, want one dead message", msgs)
+	}
+}
+
+func TestHandleCreate_Outbox_MaxAttemptsFailsShare(t *testing.T) {
+	t.Parallel()
+
+	srv, postCount := makeScriptedReceiverTLSServer(t, http.StatusBadGateway)
+	f := newOutboxShareFixture(t)
+
+	if w, _ := f.create(t, srv.Listener.Addr().String()); w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for range 2 {
+		f.now = f.now.Add(time.Hour)
+
+		if _, err := f.dispatcher.ProcessDue(t.Context()); err != nil {
+			t.Fatalf("ProcessDue: %v", err)
+		}
+	}
+
+	if postCount.Load() != 3 {
+		t.Fatalf("expected three delivery POSTs, got %d", postCount.Load())
+	}
+
+	if share := f.onlyShare(t); share.Status != ocmshares.OutgoingShareStatusFailed {
+		t.Fatalf("stored share status = %q, want failed", share.Status)
+	}
+
+	msgs := f.messageList(t)
+	if len(msgs) != 1 || msgs[0].Status != outbox.StatusDead || msgs[0].Attempts != 3 {
+		t.Fatalf("outbox = %+v, want one dead message after 3 attempts", msgs)
+	}
+}
+
+func TestHandleCreate_Outbox_RevokedWhileQueuedNotifiesAfterDelivery(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := makeScriptedReceiverTLSServer(t, http.StatusServiceUnavailable, http.StatusCreated)
+	f := newOutboxShareFixture(t)
+
+	if w, _ := f.create(t, srv.Listener.Addr().String()); w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+
+	share := f.onlyShare(t)
+	share.Status = ocmshares.OutgoingShareStatusRevoked
+
+	if err := f.repo.Update(t.Context(), share); err != nil {
+		t.Fatalf("revoke share: %v", err)
+	}
+
+	f.now = f.now.Add(time.Minute)
+
+	if _, err := f.dispatcher.ProcessDue(t.Context()); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+
+	f.notifier.waitForCall(t)
+
+	calls := f.notifier.snapshot()
+	if len(calls) != 1 || calls[0].notificationType != spec.NotificationTypeShareUnshared ||
+		calls[0].providerID != share.ProviderID {
+		t.Fatalf("notifications = %+v, want one SHARE_UNSHARED for %s", calls, share.ProviderID)
+	}
+
+	if stored := f.onlyShare(t); stored.Status != ocmshares.OutgoingShareStatusRevoked {
+		t.Fatalf("stored share status = %q, want revoked", stored.Status)
+	}
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	outgoingshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	ocmshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+)
+
+const (
+	testSSHAddr      = "sftp.sender.example:2022"
+	testRecipientKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGtQUDlYd2v0t0K3f4bsMl4Sd0oI4dZbL2n9C4jW1uO7"
+)
+
+// makeSSHReceiverTLSServer advertises ssh-receive when sshReceive is set and
+// answers share POSTs with recipientPublicKeys, capturing the request.
+func makeSSHReceiverTLSServer(t *testing.T, sshReceive bool) (*httptest.Server, *atomic.Int32, *spec.NewShareRequest) {
+	t.Helper()
+
+	postCount := &atomic.Int32{}
+
+	var (
+		captured spec.NewShareRequest
+		srv      *httptest.Server
+	)
+
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/.well-known/ocm" {
+			protocols := spec.Protocols{spec.ProtocolWebDAV: spec.StringProtocolRole("/webdav/ocm/")}
+			if sshReceive {
+				protocols[spec.ProtocolSSHReceive] = spec.SSHReceiveRole()
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			tshttp.WriteJSON(w, spec.Discovery{
+				Enabled:    true,
+				APIVersion: "1.4.0",
+				EndPoint:   srv.URL + "/ocm",
+				ResourceTypes: []spec.ResourceType{{
+					Name:       "file",
+					ShareTypes: []string{spec.ShareTypeUser},
+					Protocols:  protocols,
+				}},
+				Capabilities:  []string{"exchange-token"},
+				TokenEndPoint: srv.URL + "/ocm/token",
+			})
+
+			return
+		}
+
+		if r.Method == http.MethodPost && r.URL.Path == "/ocm/shares" {
+			postCount.Add(1)
+
+			if err := json.NewDecoder(r.Body).Decode(&captured); err != nil {
+				t.Errorf("decode share request: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			tshttp.WriteJSON(w, spec.CreateShareResponse{RecipientPublicKeys: []string{testRecipientKey}})
+
+			return
+		}
+
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, postCount, &captured
+}
+
+func sshCreateBody(receiverHost, localPath string) string {
+	return `{
+		"receiverDomain": "` + receiverHost + `",
+		"shareWith": "bob@` + receiverHost + `",
+		"localPath": "` + localPath + `",
+		"permissions": ["read"],
+		"ssh": true
+	}`
+}
+
+func postSSHCreate(t *testing.T, handler *outgoingshares.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/shares/outgoing", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handler.HandleCreate(w, req)
+
+	return w
+}
+
+func TestHandleCreate_SSHArmSentAndRecipientKeysStored(t *testing.T) {
+	t.Parallel()
+
+	srv, _, captured := makeSSHReceiverTLSServer(t, true)
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	repo := tsrepos.OpenMemory(t).OutgoingShares
+	discClient, ctxClient := makeTLSClients()
+	handler := newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+	handler.SetSSH(testSSHAddr)
+
+	tmpFile := createTempShareFile(t, "outgoing-ssh-*")
+
+	w := postSSHCreate(t, handler, sshCreateBody(srv.Listener.Addr().String(), tmpFile))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	ssh := captured.Protocol.SSH
+	if captured.Protocol.Name != "multi" || captured.Protocol.WebDAV == nil || ssh == nil {
+		t.Fatalf("protocol = %+v, want multi with webdav and ssh arms", captured.Protocol)
+	}
+
+	all, err := repo.List(context.Background())
+	if err != nil || len(all) != 1 {
+		t.Fatalf("list shares: %v (%d)", err, len(all))
+	}
+
+	share := all[0]
+	wantURI := share.WebDAVID + "@" + testSSHAddr + "/" + filepath.Base(tmpFile)
+
+	if ssh.URI != wantURI || share.SSHURI != wantURI {
+		t.Errorf("ssh uri sent %q stored %q, want %q", ssh.URI, share.SSHURI, wantURI)
+	}
+
+	if !slices.Equal(ssh.AccessTypes, []string{spec.AccessTypeRemote, spec.AccessTypeDataTx}) {
+		t.Errorf("ssh accessTypes = %v", ssh.AccessTypes)
+	}
+
+	if !slices.Equal(share.RecipientPublicKeys, []string{testRecipientKey}) {
+		t.Errorf("stored recipient keys = %v, want the receiver's response keys", share.RecipientPublicKeys)
+	}
+
+	view := outgoingshares.NewOutgoingShareView(share)
+	if view.SSHURI != wantURI || len(view.RecipientPublicKeys) != 1 {
+		t.Errorf("list view ssh fields = %q %v", view.SSHURI, view.RecipientPublicKeys)
+	}
+}
+
+func TestHandleCreate_Outbox_SSHRecipientKeysStored(t *testing.T) {
+	t.Parallel()
+
+	srv, _, _ := makeSSHReceiverTLSServer(t, true)
+	f := newOutboxShareFixture(t)
+	f.handler.SetSSH(testSSHAddr)
+
+	tmpFile := createTempShareFile(t, "outgoing-ssh-outbox-*")
+
+	w := postSSHCreate(t, f.handler, sshCreateBody(srv.Listener.Addr().String(), tmpFile))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	share := f.onlyShare(t)
+	if share.Status != ocmshares.OutgoingShareStatusSent || !slices.Equal(share.RecipientPublicKeys, []string{testRecipientKey}) {
+		t.Fatalf("stored share = %q keys %v, want sent with the receiver's keys", share.Status, share.RecipientPublicKeys)
+	}
+}
+
+func TestHandleCreate_SSHRejections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		sshAddr    string
+		sshReceive bool
+		wantStatus int
+		wantReason string
+	}{
+		{"disabled", "", true, http.StatusBadRequest, "invalid_field"},
+		{
+			"receiver without ssh-receive", testSSHAddr, false,
+			reason.APIStatus(reason.PeerCapabilityMismatch), reason.PeerCapabilityMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, postCount, _ := makeSSHReceiverTLSServer(t, tt.sshReceive)
+
+			user := &identity.User{ID: "user-uuid", Username: "alice"}
+			repo := tsrepos.OpenMemory(t).OutgoingShares
+			discClient, ctxClient := makeTLSClients()
+			handler := newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+			handler.SetSSH(tt.sshAddr)
+
+			tmpFile := createTempShareFile(t, "outgoing-ssh-reject-*")
+
+			w := postSSHCreate(t, handler, sshCreateBody(srv.Listener.Addr().String(), tmpFile))
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantReason) {
+				t.Fatalf("got %d %s, want %d with %s", w.Code, w.Body.String(), tt.wantStatus, tt.wantReason)
+			}
+
+			if postCount.Load() != 0 {
+				t.Fatalf("expected no remote POST, got %d", postCount.Load())
+			}
+
+			assertNoStoredShares(t, repo)
+		})
+	}
+}
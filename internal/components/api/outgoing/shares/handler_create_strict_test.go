@@ -413,3 +413,33 @@ func makeCapturingReceiverWithMismatchedEndpoint(
 
 	return srv, postCount
 }
+
+func TestHandleCreate_RejectsGroupShareWhenReceiverLacksGroupType(t *testing.T) {
+	t.Parallel()
+
+	srv, postCount := makeReceiverTLSServer(t, []string{"exchange-token"}, []string{})
+	defer srv.Close()
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	repo := tsrepos.OpenMemory(t).OutgoingShares
+	discClient, ctxClient := makeTLSClients()
+	handler := newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+
+	tmpFile := createTempShareFile(t, "outgoing-no-group-*")
+	receiverHost := srv.Listener.Addr().String()
+	body := strings.Replace(outgoingCreateBody(receiverHost, tmpFile), `"permissions"`, `"shareType": "group", "permissions"`, 1)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/shares/outgoing", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handler.HandleCreate(w, req)
+
+	if w.Code != reason.APIStatus(reason.PeerCapabilityMismatch) {
+		t.Fatalf("expected %d, got %d: %s", reason.APIStatus(reason.PeerCapabilityMismatch), w.Code, w.Body.String())
+	}
+
+	if postCount.Load() != 0 {
+		t.Fatalf("expected no remote POST, got %d", postCount.Load())
+	}
+}
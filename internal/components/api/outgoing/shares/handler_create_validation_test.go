@@ -12,6 +12,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"slices"
+	"strings"
 	"testing"
 
 	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
@@ -123,7 +125,7 @@ func TestHandleCreate_RejectsUnsupportedPermissions(t *testing.T) {
 		"receiverDomain": "example.com",
 		"shareWith": "user@example.com",
 		"localPath": "` + tmpFile.Name() + `",
-		"permissions": ["write"]
+		"permissions": ["read", "share"]
 	}`
 
 	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/shares/outgoing", bytes.NewBufferString(body))
@@ -137,8 +139,8 @@ func TestHandleCreate_RejectsUnsupportedPermissions(t *testing.T) {
 		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
 	}
 
-	if !bytes.Contains(w.Body.Bytes(), []byte("permissions must be read-only")) {
-		t.Fatalf("expected read-only permissions error, got: %s", w.Body.String())
+	if !bytes.Contains(w.Body.Bytes(), []byte(`permissions must be \"read\" and/or \"write\"`)) {
+		t.Fatalf("expected permissions error, got: %s", w.Body.String())
 	}
 }
 
@@ -277,32 +279,24 @@ func TestHandleCreate_RejectsUnsupportedResourceTargets(t *testing.T) {
 		wantNotMsg string
 	}{
 		{
-			name: "directory path",
+			name: "directory path with file resourceType",
 			body: func(t *testing.T) string {
 				t.Helper()
 
-				tmpDir, err := os.MkdirTemp("/tmp", "ocm-dir-*") //nolint:usetesting // must stay under /tmp to match SetAllowedPaths allowlist
-				if err != nil {
-					t.Fatalf("failed to create temp directory: %v", err)
-				}
-
-				t.Cleanup(func() {
-					if err := os.RemoveAll(tmpDir); err != nil {
-						t.Errorf("remove temp directory: %v", err)
-					}
-				})
+				tmpDir := createTempShareDir(t, "ocm-dir-*")
 
 				return `{
 					"receiverDomain": "example.com",
 					"shareWith": "user@example.com",
 					"localPath": "` + tmpDir + `",
+					"resourceType": "file",
 					"permissions": ["read"]
 				}`
 			},
-			wantMsg: "directory shares are not supported",
+			wantMsg: `resource type \"file\" does not match localPath, which is a folder`,
 		},
 		{
-			name: "explicit folder resourceType",
+			name: "file path with folder resourceType",
 			body: func(t *testing.T) string {
 				t.Helper()
 
@@ -316,7 +310,32 @@ func TestHandleCreate_RejectsUnsupportedResourceTargets(t *testing.T) {
 					"permissions": ["read"]
 				}`
 			},
-			wantMsg: `resource type \"folder\" is not supported; only \"file\" is supported`,
+			wantMsg: `resource type \"folder\" does not match localPath, which is a file`,
+		},
+		{
+			name: "directory symlink escaping allowed paths",
+			body: func(t *testing.T) string {
+				t.Helper()
+
+				// The package directory sits outside the /tmp allowlist.
+				outside, err := os.Getwd()
+				if err != nil {
+					t.Fatalf("getwd: %v", err)
+				}
+
+				link := createTempShareDir(t, "ocm-link-*") + "/escape"
+				if err := os.Symlink(outside, link); err != nil {
+					t.Fatalf("symlink: %v", err)
+				}
+
+				return `{
+					"receiverDomain": "example.com",
+					"shareWith": "user@example.com",
+					"localPath": "` + link + `",
+					"permissions": ["read"]
+				}`
+			},
+			wantMsg: "path not in allowed directories",
 		},
 		{
 			name: "explicit calendar resourceType",
@@ -333,7 +352,7 @@ func TestHandleCreate_RejectsUnsupportedResourceTargets(t *testing.T) {
 					"permissions": ["read"]
 				}`
 			},
-			wantMsg:    `resource type \"calendar\" is not supported; only \"file\" is supported`,
+			wantMsg:    `resource type \"calendar\" is not supported; only \"file\" and \"folder\" are supported`,
 			wantNotMsg: "folder shares",
 		},
 	}
@@ -405,3 +424,161 @@ func TestHandleCreate_FileWithoutResourceType_SucceedsWithFile(t *testing.T) {
 		t.Fatalf("expected resourceType file in delivered payload, got %q", captured.ResourceType)
 	}
 }
+
+func TestHandleCreate_DirectoryWithoutResourceType_SucceedsWithFolder(t *testing.T) {
+	t.Parallel()
+
+	srv, postCount, captured := makeCapturingReceiverTLSServer(t, []string{"exchange-token"}, []string{})
+	defer srv.Close()
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	repo := tsrepos.OpenMemory(t).OutgoingShares
+	discClient, ctxClient := makeTLSClients()
+	handler := newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+
+	tmpDir := createTempShareDir(t, "outgoing-folder-*")
+	receiverHost := srv.Listener.Addr().String()
+
+	req := httptest.NewRequestWithContext(
+		t.Context(),
+		http.MethodPost,
+		"/api/shares/outgoing",
+		bytes.NewBufferString(outgoingCreateBody(receiverHost, tmpDir)),
+	)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handler.HandleCreate(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if postCount.Load() != 1 {
+		t.Fatalf("expected one delivery POST, got %d", postCount.Load())
+	}
+
+	if captured.ResourceType != "folder" {
+		t.Fatalf("expected resourceType folder in delivered payload, got %q", captured.ResourceType)
+	}
+
+	shares, err := repo.List(t.Context())
+	if err != nil || len(shares) != 1 {
+		t.Fatalf("expected one stored share, got %d (err=%v)", len(shares), err)
+	}
+
+	if shares[0].ResourceType != "folder" || shares[0].LocalPath != tmpDir {
+		t.Errorf("stored share = %+v, want folder at %s", shares[0], tmpDir)
+	}
+}
+
+func TestHandleCreate_WritePermission_DeliveredAndStored(t *testing.T) {
+	t.Parallel()
+
+	srv, postCount, captured := makeCapturingReceiverTLSServer(t, []string{"exchange-token"}, []string{})
+	defer srv.Close()
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	repo := tsrepos.OpenMemory(t).OutgoingShares
+	discClient, ctxClient := makeTLSClients()
+	handler := newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+
+	tmpDir := createTempShareDir(t, "outgoing-write-*")
+	receiverHost := srv.Listener.Addr().String()
+	body := strings.Replace(outgoingCreateBody(receiverHost, tmpDir), `["read"]`, `["read", "write"]`, 1)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/shares/outgoing", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handler.HandleCreate(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if postCount.Load() != 1 {
+		t.Fatalf("expected one delivery POST, got %d", postCount.Load())
+	}
+
+	if captured.Protocol.WebDAV == nil || !slices.Equal(captured.Protocol.WebDAV.Permissions, []string{"read", "write"}) {
+		t.Fatalf("delivered webdav permissions = %+v, want [read write]", captured.Protocol.WebDAV)
+	}
+
+	shares, err := repo.List(t.Context())
+	if err != nil || len(shares) != 1 {
+		t.Fatalf("expected one stored share, got %d (err=%v)", len(shares), err)
+	}
+
+	if !slices.Equal(shares[0].Permissions, []string{"read", "write"}) {
+		t.Errorf("stored permissions = %v, want [read write]", shares[0].Permissions)
+	}
+}
+
+func TestHandleCreate_RejectsUnsupportedShareType(t *testing.T) {
+	t.Parallel()
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	handler := newTestHandler(t, testCurrentUser(user))
+
+	body := `{
+		"receiverDomain": "example.com",
+		"shareWith": "team@example.com",
+		"localPath": "/tmp/file.txt",
+		"permissions": ["read"],
+		"shareType": "federation"
+	}`
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/shares/outgoing", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handler.HandleCreate(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if !bytes.Contains(w.Body.Bytes(), []byte(`shareType must be`)) {
+		t.Fatalf("expected shareType error, got: %s", w.Body.String())
+	}
+}
+
+func TestHandleCreate_GroupShare_DeliveredAndStored(t *testing.T) {
+	t.Parallel()
+
+	srv, postCount, captured := makeCapturingReceiverTLSServer(t, []string{"exchange-token"}, []string{})
+	defer srv.Close()
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	repo := tsrepos.OpenMemory(t).OutgoingShares
+	discClient, ctxClient := makeTLSClients()
+	handler := newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+
+	tmpFile := createTempShareFile(t, "outgoing-group-*")
+	receiverHost := srv.Listener.Addr().String()
+	body := strings.Replace(outgoingCreateBody(receiverHost, tmpFile), `"permissions"`, `"shareType": "group", "permissions"`, 1)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/shares/outgoing", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handler.HandleCreate(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if postCount.Load() != 1 || captured.ShareType != "group" {
+		t.Fatalf("delivered %d POSTs with shareType %q, want one group share", postCount.Load(), captured.ShareType)
+	}
+
+	shares, err := repo.List(t.Context())
+	if err != nil || len(shares) != 1 {
+		t.Fatalf("expected one stored share, got %d (err=%v)", len(shares), err)
+	}
+
+	if shares[0].ShareType != "group" {
+		t.Errorf("stored shareType = %q, want group", shares[0].ShareType)
+	}
+}
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	outgoingshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+)
+
+const testViewerTemplate = "https://viewer.example.org/open/{webdavId}?name={name}"
+
+func webappCreateBody(receiverHost, localPath, webappPerms string) string {
+	return `{
+		"receiverDomain": "` + receiverHost + `",
+		"shareWith": "bob@` + receiverHost + `",
+		"localPath": "` + localPath + `",
+		"permissions": ["read"],
+		"webappPermissions": [` + webappPerms + `]
+	}`
+}
+
+func postWebappCreate(t *testing.T, handler *outgoingshares.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/api/shares/outgoing", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	handler.HandleCreate(w, req)
+
+	return w
+}
+
+func TestHandleCreate_WebappArmSentAndPersisted(t *testing.T) {
+	t.Parallel()
+
+	srv, _, captured := makeCapturingReceiverTLSServer(t, []string{"exchange-token"}, []string{})
+	defer srv.Close()
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	repo := tsrepos.OpenMemory(t).OutgoingShares
+	discClient, ctxClient := makeTLSClients()
+	handler := newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+	handler.SetWebapp(config.WebappConfig{ViewerURLTemplate: testViewerTemplate, Targets: []string{"blank", "iframe"}})
+
+	tmpFile := createTempShareFile(t, "outgoing-webapp-*")
+
+	w := postWebappCreate(t, handler, webappCreateBody(srv.Listener.Addr().String(), tmpFile, `"view", "write"`))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if captured.Protocol.Name != "multi" || captured.Protocol.WebDAV == nil || captured.Protocol.Webapp == nil {
+		t.Fatalf("protocol = %+v, want multi with webdav and webapp arms", captured.Protocol)
+	}
+
+	webapp := captured.Protocol.Webapp
+	wantURI := "https://viewer.example.org/open/" + captured.Protocol.WebDAV.URI + "?name=" + filepath.Base(tmpFile)
+
+	if webapp.URI != wantURI {
+		t.Errorf("webapp uri = %q, want %q", webapp.URI, wantURI)
+	}
+
+	if !slices.Equal(webapp.Permissions, []string{"view", "write"}) || !slices.Equal(webapp.Targets, []string{"blank", "iframe"}) {
+		t.Errorf("webapp permissions/targets = %v/%v", webapp.Permissions, webapp.Targets)
+	}
+
+	if !slices.Equal(webapp.Requirements, []string{spec.RequirementMustExchangeToken}) {
+		t.Errorf("webapp requirements = %v, want must-exchange-token", webapp.Requirements)
+	}
+
+	if webapp.SharedSecret == "" || webapp.SharedSecret == captured.Protocol.WebDAV.SharedSecret {
+		t.Error("webapp arm must carry its own shared secret")
+	}
+
+	all, err := repo.List(context.Background())
+	if err != nil || len(all) != 1 {
+		t.Fatalf("list shares: %v (%d)", err, len(all))
+	}
+
+	if all[0].WebappURI != wantURI || !slices.Equal(all[0].WebappPermissions, []string{"view", "write"}) ||
+		!slices.Equal(all[0].WebappTargets, []string{"blank", "iframe"}) {
+		t.Errorf("stored share webapp fields = %q %v %v", all[0].WebappURI, all[0].WebappPermissions, all[0].WebappTargets)
+	}
+
+	if all[0].WebappSharedSecret != webapp.SharedSecret {
+		t.Error("stored share must keep the webapp secret sent to the receiver")
+	}
+
+	if view := outgoingshares.NewOutgoingShareView(all[0]); view.WebappURI != wantURI {
+		t.Errorf("list view webappUri = %q, want %q", view.WebappURI, wantURI)
+	}
+}
+
+func TestHandleCreate_WithoutWebappPermissionsSendsWebDAVOnly(t *testing.T) {
+	t.Parallel()
+
+	srv, _, captured := makeCapturingReceiverTLSServer(t, []string{"exchange-token"}, []string{})
+	defer srv.Close()
+
+	user := &identity.User{ID: "user-uuid", Username: "alice"}
+	repo := tsrepos.OpenMemory(t).OutgoingShares
+	discClient, ctxClient := makeTLSClients()
+	handler := newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+	handler.SetWebapp(config.WebappConfig{ViewerURLTemplate: testViewerTemplate, Targets: []string{"blank"}})
+
+	tmpFile := createTempShareFile(t, "outgoing-webdav-only-*")
+
+	w := postWebappCreate(t, handler, outgoingCreateBody(srv.Listener.Addr().String(), tmpFile))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if captured.Protocol.Webapp != nil {
+		t.Fatalf("unexpected webapp arm: %+v", captured.Protocol.Webapp)
+	}
+}
+
+func TestHandleCreate_WebappRejections(t *testing.T) {
+	t.Parallel()
+
+	enabled := config.WebappConfig{ViewerURLTemplate: testViewerTemplate, Targets: []string{"blank"}}
+
+	tests := []struct {
+		name         string
+		webapp       config.WebappConfig
+		capabilities []string
+		perms        string
+		wantStatus   int
+		wantReason   string
+	}{
+		{"disabled", config.WebappConfig{}, []string{"exchange-token"}, `"view"`, http.StatusBadRequest, "invalid_field"},
+		{"share permission", enabled, []string{"exchange-token"}, `"share"`, http.StatusBadRequest, "invalid_field"},
+		{"unknown permission", enabled, []string{"exchange-token"}, `"edit"`, http.StatusBadRequest, "invalid_field"},
+		{
+			"receiver without exchange", enabled, []string{}, `"view"`,
+			reason.APIStatus(reason.PeerCapabilityMismatch), reason.PeerCapabilityMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, postCount := makeReceiverTLSServer(t, tt.capabilities, []string{})
+			defer srv.Close()
+
+			user := &identity.User{ID: "user-uuid", Username: "alice"}
+			repo := tsrepos.OpenMemory(t).OutgoingShares
+			discClient, ctxClient := makeTLSClients()
+
+			var handler *outgoingshares.Handler
+			if len(tt.capabilities) == 0 {
+				handler = newLegacyVoluntaryOutgoingHandler(t, repo, discClient, ctxClient, user)
+			} else {
+				handler = newStrictOutgoingHandler(t, repo, discClient, ctxClient, user)
+			}
+
+			handler.SetWebapp(tt.webapp)
+
+			tmpFile := createTempShareFile(t, "outgoing-webapp-reject-*")
+
+			w := postWebappCreate(t, handler, webappCreateBody(srv.Listener.Addr().String(), tmpFile, tt.perms))
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantReason) {
+				t.Fatalf("got %d %s, want %d with %s", w.Code, w.Body.String(), tt.wantStatus, tt.wantReason)
+			}
+
+			if postCount.Load() != 0 {
+				t.Fatalf("expected no remote POST, got %d", postCount.Load())
+			}
+
+			assertNoStoredShares(t, repo)
+		})
+	}
+}
+
+func assertNoStoredShares(t *testing.T, repo sharesoutgoing.OutgoingShareRepo) {
+	t.Helper()
+
+	all, err := repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("list shares: %v", err)
+	}
+
+	if len(all) != 0 {
+		t.Fatalf("expected no stored shares, got %d", len(all))
+	}
+}
@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	outgoingshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
+	ocmshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+)
+
+var listBase = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// seedListShare stores a share owned by ownerUserID, created hoursAfter
+// listBase, with a recognizable secret derived from id.
+func (f *ownerFixture) seedListShare(
+	t *testing.T,
+	id string,
+	ownerUserID string,
+	receiverHost string,
+	status ocmshares.OutgoingShareStatus,
+	hoursAfter int,
+) *sharesoutgoing.OutgoingShare {
+	t.Helper()
+
+	share := &sharesoutgoing.OutgoingShare{
+		ShareID:      id,
+		ProviderID:   "provider-" + id,
+		WebDAVID:     "webdav-" + id,
+		SharedSecret: "secret-" + id,
+		LocalPath:    "/tmp/" + id + ".txt",
+		ReceiverHost: receiverHost,
+		Name:         id + ".txt",
+		ResourceType: "file",
+		ShareType:    "user",
+		Permissions:  []string{"read"},
+		Owner:        address.FormatOutgoingOCMAddressFromUserID(ownerUserID, testProvider),
+		Status:       status,
+		CreatedAt:    listBase.Add(time.Duration(hoursAfter) * time.Hour),
+	}
+	if status == ocmshares.OutgoingShareStatusFailed {
+		share.Error = "receiver returned 503"
+	}
+
+	if err := f.repo.Create(t.Context(), share); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	return share
+}
+
+func (f *ownerFixture) get(t *testing.T, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func decodeList(t *testing.T, w *httptest.ResponseRecorder) outgoingshares.OutgoingListResponse {
+	t.Helper()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp outgoingshares.OutgoingListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+
+	return resp
+}
+
+func listIDs(resp outgoingshares.OutgoingListResponse) string {
+	ids := make([]string, 0, len(resp.Shares))
+	for _, s := range resp.Shares {
+		ids = append(ids, s.ShareID)
+	}
+
+	return strings.Join(ids, ",")
+}
+
+func newListFixture(t *testing.T) *ownerFixture {
+	t.Helper()
+
+	f := newOwnerFixture(t, &identity.User{ID: "alice-uuid", Username: "alice"})
+	f.seedListShare(t, "s1", "alice-uuid", "one.example.com", ocmshares.OutgoingShareStatusSent, 0)
+	f.seedListShare(t, "s2", "alice-uuid", "two.example.com", ocmshares.OutgoingShareStatusFailed, 1)
+	f.seedListShare(t, "s3", "alice-uuid", "one.example.com", ocmshares.OutgoingShareStatusAccepted, 2)
+	f.seedListShare(t, "s4", "alice-uuid", "one.example.com", ocmshares.OutgoingShareStatusDeclined, 3)
+	f.seedListShare(t, "other", "bob-uuid", "one.example.com", ocmshares.OutgoingShareStatusSent, 4)
+
+	return f
+}
+
+func TestHandleList_ScopedToOwnerNewestFirst(t *testing.T) {
+	t.Parallel()
+
+	f := newListFixture(t)
+
+	resp := decodeList(t, f.get(t, "/api/shares/outgoing"))
+	if got := listIDs(resp); got != "s4,s3,s2,s1" {
+		t.Fatalf("ids = %s, want s4,s3,s2,s1", got)
+	}
+
+	if resp.Total != 4 || resp.Limit != 50 || resp.Offset != 0 {
+		t.Errorf("total/limit/offset = %d/%d/%d, want 4/50/0", resp.Total, resp.Limit, resp.Offset)
+	}
+
+	failed := resp.Shares[2]
+	if failed.Status != ocmshares.OutgoingShareStatusFailed || failed.Error != "receiver returned 503" {
+		t.Errorf("failed share view = %+v, want status failed with error", failed)
+	}
+}
+
+func TestHandleList_Filters(t *testing.T) {
+	t.Parallel()
+
+	f := newListFixture(t)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"status=sent", "s1"},
+		{"status=failed", "s2"},
+		{"receiverHost=ONE.example.com", "s4,s3,s1"},
+		{"createdAfter=2026-03-01T10:00:00Z", "s4,s3,s2"},
+		{"createdBefore=2026-03-01T11:00:00Z", "s2,s1"},
+		{"receiverHost=one.example.com&createdAfter=2026-03-01T10:00:00Z&createdBefore=2026-03-01T12:00:00Z", "s3"},
+		{"status=revoked", ""},
+	}
+
+	for _, tc := range tests {
+		resp := decodeList(t, f.get(t, "/api/shares/outgoing?"+tc.query))
+		if got := listIDs(resp); got != tc.want {
+			t.Errorf("%s: ids = %q, want %q", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestHandleList_Pagination(t *testing.T) {
+	t.Parallel()
+
+	f := newListFixture(t)
+
+	first := decodeList(t, f.get(t, "/api/shares/outgoing?limit=3"))
+	if got := listIDs(first); got != "s4,s3,s2" || first.Total != 4 {
+		t.Fatalf("first page = %s (total %d), want s4,s3,s2 (total 4)", got, first.Total)
+	}
+
+	second := decodeList(t, f.get(t, "/api/shares/outgoing?limit=3&offset=3"))
+	if got := listIDs(second); got != "s1" || second.Offset != 3 {
+		t.Fatalf("second page = %s (offset %d), want s1 (offset 3)", got, second.Offset)
+	}
+
+	for _, offset := range []string{"10", "9223372036854775807"} {
+		past := decodeList(t, f.get(t, "/api/shares/outgoing?offset="+offset))
+		if len(past.Shares) != 0 || past.Total != 4 {
+			t.Fatalf("offset %s: page past end = %+v, want empty with total 4", offset, past)
+		}
+	}
+}
+
+func TestHandleList_RejectsInvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	f := newListFixture(t)
+
+	for _, query := range []string{
+		"status=bogus",
+		"createdAfter=yesterday",
+		"createdBefore=2026-03-01",
+		"limit=0",
+		"limit=201",
+		"limit=ten",
+		"offset=-1",
+	} {
+		w := f.get(t, "/api/shares/outgoing?"+query)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestHandleList_RedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	f := newListFixture(t)
+
+	w := f.get(t, "/api/shares/outgoing")
+	body := w.Body.String()
+
+	for _, leaked := range []string{"secret-s1", "secret-s2", "sharedSecret"} {
+		if strings.Contains(body, leaked) {
+			t.Errorf("list response contains %q: %s", leaked, body)
+		}
+	}
+}
+
+func TestHandleList_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(t, failCurrentUser())
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/api/shares/outgoing", nil)
+	w := httptest.NewRecorder()
+	handler.HandleList(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestHandleGetDetail_ReturnsOwnedShareRedacted(t *testing.T) {
+	t.Parallel()
+
+	f := newListFixture(t)
+
+	w := f.get(t, "/api/shares/outgoing/s2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var view outgoingshares.OutgoingShareView
+	if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+		t.Fatalf("decode detail: %v", err)
+	}
+
+	if view.ShareID != "s2" || view.ReceiverHost != "two.example.com" || view.Error != "receiver returned 503" {
+		t.Errorf("detail = %+v", view)
+	}
+
+	if view.WebDAVID != "webdav-s2" {
+		t.Errorf("detail webdavId = %q, want webdav-s2", view.WebDAVID)
+	}
+
+	if body := w.Body.String(); strings.Contains(body, "secret-s2") || strings.Contains(body, "sharedSecret") {
+		t.Errorf("detail response contains the shared secret: %s", body)
+	}
+}
+
+func TestHandleGetDetail_OtherOwnerOrUnknownIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	f := newListFixture(t)
+
+	for _, id := range []string{"other", "missing"} {
+		w := f.get(t, "/api/shares/outgoing/"+id)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d: %s", id, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestHandleGetDetail_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(t, failCurrentUser())
+
+	r := chi.NewRouter()
+	r.Get("/api/shares/outgoing/{shareId}", handler.HandleGetDetail)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/api/shares/outgoing/s1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	outgoingshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/policy"
+	ocmshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+)
+
+type revokeNotifyCall struct {
+	targetHost       string
+	providerID       string
+	resourceType     string
+	notificationType string
+}
+
+type revokeRecordingNotifier struct {
+	mu    sync.Mutex
+	calls []revokeNotifyCall
+	done  chan struct{}
+}
+
+func newRevokeRecordingNotifier() *revokeRecordingNotifier {
+	return &revokeRecordingNotifier{done: make(chan struct{}, 8)}
+}
+
+func (n *revokeRecordingNotifier) Notify(
+	_ context.Context,
+	targetHost string,
+	providerID string,
+	resourceType string,
+	notificationType string,
+	_ json.RawMessage,
+) error {
+	n.mu.Lock()
+	n.calls = append(n.calls, revokeNotifyCall{
+		targetHost:       targetHost,
+		providerID:       providerID,
+		resourceType:     resourceType,
+		notificationType: notificationType,
+	})
+	n.mu.Unlock()
+
+	n.done <- struct{}{}
+
+	return nil
+}
+
+func (n *revokeRecordingNotifier) waitForCall(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-n.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+}
+
+func (n *revokeRecordingNotifier) snapshot() []revokeNotifyCall {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]revokeNotifyCall(nil), n.calls...)
+}
+
+type failingTokenRevoker struct{}
+
+func (failingTokenRevoker) DeleteByShareID(context.Context, string) error {
+	return errors.New("token store unavailable")
+}
+
+type ownerFixture struct {
+	repo     sharesoutgoing.OutgoingShareRepo
+	tokens   *token.MemoryTokenStore
+	notifier *revokeRecordingNotifier
+	handler  *outgoingshares.Handler
+	router   http.Handler
+}
+
+func newOwnerFixture(t *testing.T, user *identity.User) *ownerFixture {
+	t.Helper()
+
+	f := &ownerFixture{
+		repo:     tsrepos.OpenMemory(t).OutgoingShares,
+		tokens:   token.NewMemoryTokenStore(),
+		notifier: newRevokeRecordingNotifier(),
+	}
+
+	f.handler = outgoingshares.NewHandler(
+		f.repo,
+		makeDummyDiscoveryClient(),
+		nil,
+		nil,
+		testProvider,
+		testCurrentUser(user),
+		testLogger,
+		&stubResolver{facts: policy.NewCodeFlow().Evaluate()},
+		"https://example.com/ocm/token",
+	)
+	f.handler.SetTokenRevoker(f.tokens)
+	f.handler.SetNotifier(f.notifier)
+
+	r := chi.NewRouter()
+	r.Get("/api/shares/outgoing", f.handler.HandleList)
+	r.Get("/api/shares/outgoing/{shareId}", f.handler.HandleGetDetail)
+	r.Delete("/api/shares/outgoing/{shareId}", f.handler.HandleRevoke)
+	f.router = r
+
+	return f
+}
+
+func (f *ownerFixture) seedShare(t *testing.T, ownerUserID string, status ocmshares.OutgoingShareStatus) *sharesoutgoing.OutgoingShare {
+	t.Helper()
+
+	share := &sharesoutgoing.OutgoingShare{
+		ProviderID:   "provider-" + string(status),
+		WebDAVID:     "webdav-" + string(status),
+		SharedSecret: "secret-" + string(status),
+		LocalPath:    "/tmp/revoke.txt",
+		ReceiverHost: "receiver.example.com",
+		ResourceType: spec.ResourceTypeFile,
+		Owner:        address.FormatOutgoingOCMAddressFromUserID(ownerUserID, testProvider),
+		Status:       status,
+	}
+	if err := f.repo.Create(t.Context(), share); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	return share
+}
+
+func (f *ownerFixture) storeToken(t *testing.T, accessToken, shareID string) {
+	t.Helper()
+
+	if err := f.tokens.Store(t.Context(), &token.IssuedToken{
+		AccessToken: accessToken,
+		ShareID:     shareID,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("Store token: %v", err)
+	}
+}
+
+func (f *ownerFixture) revoke(t *testing.T, shareID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodDelete, "/api/shares/outgoing/"+shareID, nil)
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestHandleRevoke_RevokesInvalidatesTokensAndNotifies(t *testing.T) {
+	t.Parallel()
+
+	user := &identity.User{ID: "owner-uuid", Username: "alice"}
+	f := newOwnerFixture(t, user)
+	share := f.seedShare(t, user.ID, ocmshares.OutgoingShareStatusAccepted)
+	other := f.seedShare(t, user.ID, ocmshares.OutgoingShareStatusSent)
+
+	f.storeToken(t, "token-1", share.ShareID)
+	f.storeToken(t, "token-2", share.ShareID)
+	f.storeToken(t, "token-other", other.ShareID)
+
+	w := f.revoke(t, share.ShareID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	if resp["status"] != "revoked" || resp["shareId"] != share.ShareID {
+		t.Errorf("response = %v, want revoked %s", resp, share.ShareID)
+	}
+
+	stored, err := f.repo.GetByID(t.Context(), share.ShareID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.Status != ocmshares.OutgoingShareStatusRevoked {
+		t.Errorf("stored status = %q, want revoked", stored.Status)
+	}
+
+	for _, accessToken := range []string{"token-1", "token-2"} {
+		if _, err := f.tokens.Get(t.Context(), accessToken); !errors.Is(err, token.ErrTokenNotFound) {
+			t.Errorf("token %s still valid after revoke: err=%v", accessToken, err)
+		}
+	}
+
+	if _, err := f.tokens.Get(t.Context(), "token-other"); err != nil {
+		t.Errorf("token for another share was invalidated: %v", err)
+	}
+
+	f.notifier.waitForCall(t)
+
+	calls := f.notifier.snapshot()
+	if len(calls) != 1 {
+		t.Fatalf("expected one notification, got %d", len(calls))
+	}
+
+	want := revokeNotifyCall{
+		targetHost:       "receiver.example.com",
+		providerID:       share.ProviderID,
+		resourceType:     spec.ResourceTypeFile,
+		notificationType: spec.NotificationTypeShareUnshared,
+	}
+	if calls[0] != want {
+		t.Errorf("notification = %+v, want %+v", calls[0], want)
+	}
+}
+
+func TestHandleRevoke_AlreadyRevokedIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	user := &identity.User{ID: "owner-uuid", Username: "alice"}
+	f := newOwnerFixture(t, user)
+	share := f.seedShare(t, user.ID, ocmshares.OutgoingShareStatusRevoked)
+
+	w := f.revoke(t, share.ShareID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if calls := f.notifier.snapshot(); len(calls) != 0 {
+		t.Errorf("expected no notification for already revoked share, got %+v", calls)
+	}
+}
+
+func TestHandleRevoke_UndeliveredShareSkipsNotification(t *testing.T) {
+	t.Parallel()
+
+	user := &identity.User{ID: "owner-uuid", Username: "alice"}
+	f := newOwnerFixture(t, user)
+	share := f.seedShare(t, user.ID, ocmshares.OutgoingShareStatusFailed)
+
+	w := f.revoke(t, share.ShareID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	stored, err := f.repo.GetByID(t.Context(), share.ShareID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.Status != ocmshares.OutgoingShareStatusRevoked {
+		t.Errorf("stored status = %q, want revoked", stored.Status)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if calls := f.notifier.snapshot(); len(calls) != 0 {
+		t.Errorf("expected no notification for undelivered share, got %+v", calls)
+	}
+}
+
+func TestHandleRevoke_OtherOwnersShareIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	user := &identity.User{ID: "intruder-uuid", Username: "mallory"}
+	f := newOwnerFixture(t, user)
+	share := f.seedShare(t, "owner-uuid", ocmshares.OutgoingShareStatusSent)
+
+	w := f.revoke(t, share.ShareID)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+
+	stored, err := f.repo.GetByID(t.Context(), share.ShareID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.Status != ocmshares.OutgoingShareStatusSent {
+		t.Errorf("stored status = %q, want sent", stored.Status)
+	}
+}
+
+func TestHandleRevoke_UnknownShareIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	user := &identity.User{ID: "owner-uuid", Username: "alice"}
+	f := newOwnerFixture(t, user)
+
+	w := f.revoke(t, "does-not-exist")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandleRevoke_Unauthenticated(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(t, failCurrentUser())
+
+	r := chi.NewRouter()
+	r.Delete("/api/shares/outgoing/{shareId}", handler.HandleRevoke)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodDelete, "/api/shares/outgoing/some-id", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestHandleRevoke_TokenInvalidationFailureReturns500(t *testing.T) {
+	t.Parallel()
+
+	user := &identity.User{ID: "owner-uuid", Username: "alice"}
+	f := newOwnerFixture(t, user)
+	f.handler.SetTokenRevoker(failingTokenRevoker{})
+	share := f.seedShare(t, user.ID, ocmshares.OutgoingShareStatusSent)
+
+	w := f.revoke(t, share.ShareID)
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d: %s", w.Code, w.Body.String())
+	}
+
+	stored, err := f.repo.GetByID(t.Context(), share.ShareID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.Status != ocmshares.OutgoingShareStatusRevoked {
+		t.Errorf("stored status = %q, want revoked even when token cleanup fails", stored.Status)
+	}
+}
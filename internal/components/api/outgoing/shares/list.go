@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
+	ocmshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+)
+
+// List pagination bounds for GET /api/shares/outgoing.
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// OutgoingShareView omits sensitive fields (e.g. SharedSecret) from API responses.
+type OutgoingShareView struct {
+	ShareID      string                        `json:"shareId"`
+	ProviderID   string                        `json:"providerId"`
+	WebDAVID     string                        `json:"webdavId"`
+	Name         string                        `json:"name"`
+	LocalPath    string                        `json:"localPath"`
+	ReceiverHost string                        `json:"receiverHost"`
+	ShareWith    string                        `json:"shareWith"`
+	ResourceType string                        `json:"resourceType"`
+	ShareType    string                        `json:"shareType"`
+	Permissions  []string                      `json:"permissions"`
+	Owner        string                        `json:"owner"`
+	Sender       string                        `json:"sender"`
+	Status       ocmshares.OutgoingShareStatus `json:"status"`
+	CreatedAt    time.Time                     `json:"createdAt"`
+	SentAt       *time.Time                    `json:"sentAt,omitempty"`
+	ExpiresAt    *time.Time                    `json:"expiresAt,omitempty"`
+	Error        string                        `json:"error,omitempty"`
+	Requirements []string                      `json:"requirements,omitempty"`
+
+	WebappURI         string   `json:"webappUri,omitempty"`
+	WebappPermissions []string `json:"webappPermissions,omitempty"`
+
+	SSHURI              string   `json:"sshUri,omitempty"`
+	RecipientPublicKeys []string `json:"recipientPublicKeys,omitempty"`
+}
+
+// NewOutgoingShareView maps an outgoing share to an API view without secrets.
+func NewOutgoingShareView(s *sharesoutgoing.OutgoingShare) OutgoingShareView {
+	permissions := s.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return OutgoingShareView{
+		ShareID:      s.ShareID,
+		ProviderID:   s.ProviderID,
+		WebDAVID:     s.WebDAVID,
+		Name:         s.Name,
+		LocalPath:    s.LocalPath,
+		ReceiverHost: s.ReceiverHost,
+		ShareWith:    s.ShareWith,
+		ResourceType: s.ResourceType,
+		ShareType:    s.ShareType,
+		Permissions:  permissions,
+		Owner:        s.Owner,
+		Sender:       s.Sender,
+		Status:       s.Status,
+		CreatedAt:    s.CreatedAt,
+		SentAt:       s.SentAt,
+		ExpiresAt:    s.ExpiresAt,
+		Error:        s.Error,
+		Requirements: s.Requirements,
+
+		WebappURI:         s.WebappURI,
+		WebappPermissions: s.WebappPermissions,
+
+		SSHURI:              s.SSHURI,
+		RecipientPublicKeys: s.RecipientPublicKeys,
+	}
+}
+
+// OutgoingListResponse is the JSON body for the outgoing shares list endpoint.
+// Total counts every share matching the filters, not just this page.
+type OutgoingListResponse struct {
+	Shares []OutgoingShareView `json:"shares"`
+	Total  int                 `json:"total"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
+
+// HandleList handles GET /api/shares/outgoing; returns a page of the
+// authenticated user's shares, newest first. Query parameters: status,
+// receiverHost, createdAfter and createdBefore (RFC 3339), limit, and offset.
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	user, err := h.currentUser(r.Context())
+	if err != nil {
+		api.WriteUnauthorized(w, api.ReasonUnauthenticated, "authentication required")
+
+		return
+	}
+
+	filter, limit, offset, ok := parseListQuery(w, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	filter.Owner = address.FormatOutgoingOCMAddressFromUserID(user.ID, h.localProvider)
+
+	all, err := h.repo.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list outgoing shares", "user_id", user.ID, "error", err)
+		api.WriteInternalError(w, "failed to list outgoing shares")
+
+		return
+	}
+
+	matched := filter.Apply(all)
+
+	start := min(offset, len(matched))
+	page := matched[start:min(start+limit, len(matched))]
+
+	views := make([]OutgoingShareView, 0, len(page))
+	for _, s := range page {
+		views = append(views, NewOutgoingShareView(s))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(OutgoingListResponse{
+		Shares: views,
+		Total:  len(matched),
+		Limit:  limit,
+		Offset: offset,
+	}); err != nil {
+		h.logger.Error("failed to encode outgoing share list", "error", err)
+	}
+}
+
+// HandleGetDetail handles GET /api/shares/outgoing/{shareId}; shares owned by
+// another user are reported as not found.
+func (h *Handler) HandleGetDetail(w http.ResponseWriter, r *http.Request) {
+	user, err := h.currentUser(r.Context())
+	if err != nil {
+		api.WriteUnauthorized(w, api.ReasonUnauthenticated, "authentication required")
+
+		return
+	}
+
+	shareID := chi.URLParam(r, "shareId")
+	if shareID == "" {
+		api.WriteBadRequest(w, api.ReasonMissingField, "shareId is required")
+
+		return
+	}
+
+	share, ok := h.loadOwnedShare(w, r.Context(), shareID, user)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(NewOutgoingShareView(share)); err != nil {
+		h.logger.Error("failed to encode outgoing share detail", "error", err)
+	}
+}
+
+// parseListQuery validates the list query parameters. On failure it writes a
+// 400 response and returns ok=false.
+func parseListQuery(w http.ResponseWriter, q url.Values) (sharesoutgoing.ListFilter, int, int, bool) {
+	var filter sharesoutgoing.ListFilter
+
+	if status := q.Get("status"); status != "" {
+		filter.Status = ocmshares.OutgoingShareStatus(status)
+		if !filter.Status.Valid() {
+			api.WriteBadRequest(w, api.ReasonInvalidField,
+				"status must be one of pending, sent, accepted, declined, failed, revoked, expired")
+
+			return filter, 0, 0, false
+		}
+	}
+
+	filter.ReceiverHost = q.Get("receiverHost")
+
+	var ok bool
+
+	if filter.CreatedAfter, ok = parseTimeParam(w, q, "createdAfter"); !ok {
+		return filter, 0, 0, false
+	}
+
+	if filter.CreatedBefore, ok = parseTimeParam(w, q, "createdBefore"); !ok {
+		return filter, 0, 0, false
+	}
+
+	limit, ok := parseIntParam(w, q, "limit", defaultListLimit, 1, maxListLimit)
+	if !ok {
+		return filter, 0, 0, false
+	}
+
+	offset, ok := parseIntParam(w, q, "offset", 0, 0, -1)
+	if !ok {
+		return filter, 0, 0, false
+	}
+
+	return filter, limit, offset, true
+}
+
+func parseTimeParam(w http.ResponseWriter, q url.Values, name string) (time.Time, bool) {
+	raw := q.Get(name)
+	if raw == "" {
+		return time.Time{}, true
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		api.WriteBadRequest(w, api.ReasonInvalidField, name+" must be an RFC 3339 timestamp")
+
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// parseIntParam parses an integer query parameter within [lo, hi]; a negative
+// hi means no upper bound.
+func parseIntParam(w http.ResponseWriter, q url.Values, name string, def, lo, hi int) (int, bool) {
+	raw := q.Get(name)
+	if raw == "" {
+		return def, true
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < lo || (hi >= 0 && n > hi) {
+		msg := name + " must be an integer of at least " + strconv.Itoa(lo)
+		if hi >= 0 {
+			msg = name + " must be an integer between " + strconv.Itoa(lo) + " and " + strconv.Itoa(hi)
+		}
+
+		api.WriteBadRequest(w, api.ReasonInvalidField, msg)
+
+		return 0, false
+	}
+
+	return n, true
+}
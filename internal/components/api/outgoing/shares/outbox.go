@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	ocmshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
+)
+
+// SetOutbox routes share delivery through the durable outbox and registers
+// the completion that records the final share status. Without an outbox,
+// HandleCreate posts synchronously and marks the share failed on any error.
+func (h *Handler) SetOutbox(d *outbox.Dispatcher) {
+	h.outbox = d
+	d.Register(outbox.TopicShare, outbox.Completion{Done: h.completeShareDelivery})
+}
+
+// deliverViaOutbox queues the share POST and makes the first attempt. The
+// share answers 201 once the receiver accepted it, 502 when the receiver
+// rejected it, and 202 with status pending while retries are outstanding.
+// receiverDomain is the receiver as the user gave it, so retries resolve the
+// same origin (scheme included) that discovery used here. It returns the share
+// when it was sent or queued, and nil otherwise.
+func (h *Handler) deliverViaOutbox(
+	w http.ResponseWriter,
+	r *http.Request,
+	share *sharesoutgoing.OutgoingShare,
+	receiverDomain string,
+	payload spec.NewShareRequest,
+) *sharesoutgoing.OutgoingShare {
+	ctx := r.Context()
+
+	body, err := json.Marshal(payload) //nolint:errchkjson // payload type cannot fail to encode, so the checked error is always nil
+	if err != nil {
+		h.failShare(w, ctx, share, fmt.Errorf("failed to encode payload: %w", err))
+
+		return nil
+	}
+
+	msg := &outbox.Message{
+		Topic:     outbox.TopicShare,
+		SubjectID: share.ShareID,
+		Request: outbound.Request{
+			TargetHost:   receiverDomain,
+			EndpointPath: "shares",
+			Kind:         outbound.EndpointShares,
+			Body:         body,
+		},
+	}
+
+	if _, err := h.outbox.Deliver(ctx, msg); err != nil {
+		if msg.Attempts == 0 {
+			h.failShare(w, ctx, share, fmt.Errorf("failed to queue share delivery: %w", err))
+
+			return nil
+		}
+
+		h.logger.Error("outbox bookkeeping failed for share delivery", "share_id", share.ShareID, "error", err)
+	}
+
+	switch msg.Status {
+	case outbox.StatusDead:
+		h.logger.Warn("failed to deliver share to receiver",
+			"receiver", share.ReceiverHost, "attempts", msg.Attempts, "error", msg.LastError)
+		api.WriteError(w, http.StatusBadGateway, reason.PeerUnreachable, "failed to deliver share to receiver")
+
+		return nil
+	case outbox.StatusPending:
+		h.logger.Info("outgoing share created, delivery queued for retry",
+			"share_id", share.ShareID,
+			"receiver", share.ReceiverHost,
+			"next_attempt_at", msg.NextAttemptAt,
+			"error", msg.LastError)
+		h.writeCreated(w, http.StatusAccepted, share)
+
+		return share
+	case outbox.StatusDelivered:
+	}
+
+	stored, err := h.repo.GetByID(ctx, share.ShareID)
+	if err != nil || stored.Status != ocmshares.OutgoingShareStatusSent {
+		h.logger.Error("failed to record delivered outgoing share", "share_id", share.ShareID, "error", err)
+		api.WriteInternalError(w, "share sent but local persistence failed")
+
+		return nil
+	}
+
+	h.logger.Info("outgoing share created and sent",
+		"share_id", stored.ShareID,
+		"provider_id", stored.ProviderID,
+		"receiver", stored.ReceiverHost)
+
+	h.writeCreated(w, http.StatusCreated, stored)
+
+	return stored
+}
+
+// completeShareDelivery records the outcome of a share message on the share.
+// A share revoked while its delivery was queued gets a SHARE_UNSHARED
+// notification once the receiver has it, so the receiver does not keep a
+// dangling copy.
+func (h *Handler) completeShareDelivery(ctx context.Context, msg *outbox.Message, res outbox.Result) error {
+	share, err := h.repo.GetByID(ctx, msg.SubjectID)
+	if err != nil {
+		return fmt.Errorf("api: load share %s: %w", msg.SubjectID, err)
+	}
+
+	if share.Status == ocmshares.OutgoingShareStatusRevoked {
+		if msg.Status == outbox.StatusDelivered {
+			h.notifyShareUnsharedAsync(ctx, share)
+		}
+
+		return nil
+	}
+
+	if share.Status != ocmshares.OutgoingShareStatusPending {
+		return nil
+	}
+
+	if msg.Status == outbox.StatusDelivered {
+		share.Status = ocmshares.OutgoingShareStatusSent
+		sentAt := time.Now()
+		share.SentAt = &sentAt
+		share.Error = ""
+		share.RecipientPublicKeys = recipientPublicKeys(res.Body)
+	} else {
+		share.Status = ocmshares.OutgoingShareStatusFailed
+		share.Error = msg.LastError
+	}
+
+	if err := h.repo.Update(ctx, share); err != nil {
+		return fmt.Errorf("api: record share delivery: %w", err)
+	}
+
+	metrics.ShareTransition(metrics.DirectionOutgoing, string(share.Status))
+
+	return nil
+}
+
+// failShare marks a share failed before any delivery attempt and answers 500.
+func (h *Handler) failShare(
+	w http.ResponseWriter,
+	ctx context.Context,
+	share *sharesoutgoing.OutgoingShare,
+	cause error,
+) {
+	h.logger.Error("failed to queue outgoing share", "share_id", share.ShareID, "error", cause)
+
+	share.Status = ocmshares.OutgoingShareStatusFailed
+	share.Error = cause.Error()
+
+	if err := h.repo.Update(ctx, share); err != nil {
+		h.logger.Error("failed to mark outgoing share as failed", "share_id", share.ShareID, "error", err)
+	} else {
+		metrics.ShareTransition(metrics.DirectionOutgoing, string(share.Status))
+	}
+
+	api.WriteInternalError(w, "failed to queue share delivery")
+}
@@ -108,10 +108,16 @@ func makeCapturingReceiverTLSServer(t *testing.T, capabilities, criteria []strin
 				tokenEndPoint = srv.URL + "/ocm/token"
 			}
 
+			// Unlike makeReceiverTLSServer, advertise resource types so
+			// group shares pass the receiver share-type check.
 			disc := spec.Discovery{
-				Enabled:       true,
-				APIVersion:    "1.4.0",
-				EndPoint:      srv.URL + "/ocm",
+				Enabled:    true,
+				APIVersion: "1.4.0",
+				EndPoint:   srv.URL + "/ocm",
+				ResourceTypes: []spec.ResourceType{{
+					Name:       "file",
+					ShareTypes: slices.Clone(spec.SupportedShareTypes),
+				}},
 				Capabilities:  capabilities,
 				Criteria:      criteria,
 				TokenEndPoint: tokenEndPoint,
@@ -180,6 +186,23 @@ func createTempShareFile(t *testing.T, pattern string) string {
 	return path
 }
 
+func createTempShareDir(t *testing.T, pattern string) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("/tmp", pattern) //nolint:usetesting // must stay under /tmp to match SetAllowedPaths allowlist
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("remove temp directory: %v", err)
+		}
+	})
+
+	return dir
+}
+
 func failCurrentUser() func(context.Context) (*identity.User, error) {
 	return func(_ context.Context) (*identity.User, error) {
 		return nil, http.ErrNoCookie
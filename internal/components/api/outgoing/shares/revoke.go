@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/notifications"
+	ocmshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
+)
+
+const notifyTimeout = 30 * time.Second
+
+// SetTokenRevoker wires the token store used to invalidate tokens on revoke.
+func (h *Handler) SetTokenRevoker(tokens sharesoutgoing.TokenRevoker) {
+	h.tokens = tokens
+}
+
+// SetNotifier wires the sender used for SHARE_UNSHARED notifications.
+func (h *Handler) SetNotifier(notifier sharesoutgoing.Notifier) {
+	h.notifier = notifier
+}
+
+// HandleRevoke handles DELETE /api/shares/outgoing/{shareId}. It marks the
+// share revoked, invalidates every token issued for it, and notifies the
+// receiver with SHARE_UNSHARED when the share had been delivered. Revoking an
+// already revoked share is a no-op that reports the revoked status again.
+func (h *Handler) HandleRevoke(w http.ResponseWriter, r *http.Request) {
+	user, err := h.currentUser(r.Context())
+	if err != nil {
+		api.WriteUnauthorized(w, api.ReasonUnauthenticated, "authentication required")
+
+		return
+	}
+
+	shareID := chi.URLParam(r, "shareId")
+	if shareID == "" {
+		api.WriteBadRequest(w, api.ReasonMissingField, "shareId is required")
+
+		return
+	}
+
+	ctx := r.Context()
+
+	share, ok := h.loadOwnedShare(w, ctx, shareID, user)
+	if !ok {
+		return
+	}
+
+	if share.Status == ocmshares.OutgoingShareStatusRevoked {
+		h.writeRevoked(w, share.ShareID)
+
+		return
+	}
+
+	delivered := wasDelivered(share.Status)
+
+	share.Status = ocmshares.OutgoingShareStatusRevoked
+	if err := h.repo.Update(ctx, share); err != nil {
+		h.logger.Error("failed to mark outgoing share as revoked", "share_id", share.ShareID, "error", err)
+		api.WriteInternalError(w, "failed to revoke share")
+
+		return
+	}
+
+	metrics.ShareTransition(metrics.DirectionOutgoing, string(share.Status))
+	h.auditShare(ctx, audit.ActionShareUnshare, user.Username, share)
+
+	if h.tokens != nil {
+		if err := h.tokens.DeleteByShareID(ctx, share.ShareID); err != nil {
+			h.logger.Error("failed to invalidate tokens for revoked share", "share_id", share.ShareID, "error", err)
+			api.WriteInternalError(w, "share revoked but token invalidation failed")
+
+			return
+		}
+	}
+
+	h.logger.Info("outgoing share revoked",
+		"share_id", share.ShareID,
+		"provider_id", share.ProviderID,
+		"receiver", share.ReceiverHost)
+
+	if delivered {
+		h.notifyShareUnsharedAsync(r.Context(), share)
+	}
+
+	h.writeRevoked(w, share.ShareID)
+}
+
+// loadOwnedShare returns the share when it exists and belongs to user. Shares
+// owned by someone else are reported as not found.
+func (h *Handler) loadOwnedShare(
+	w http.ResponseWriter,
+	ctx context.Context,
+	shareID string,
+	user *identity.User,
+) (*sharesoutgoing.OutgoingShare, bool) {
+	share, err := h.repo.GetByID(ctx, shareID)
+	if err != nil {
+		if errors.Is(err, sharesoutgoing.ErrShareNotFound) {
+			api.WriteNotFound(w, "share not found")
+
+			return nil, false
+		}
+
+		h.logger.Error("failed to get outgoing share", "share_id", shareID, "user_id", user.ID, "error", err)
+		api.WriteInternalError(w, "failed to get share")
+
+		return nil, false
+	}
+
+	if share.Owner != address.FormatOutgoingOCMAddressFromUserID(user.ID, h.localProvider) {
+		api.WriteNotFound(w, "share not found")
+
+		return nil, false
+	}
+
+	return share, true
+}
+
+func (h *Handler) writeRevoked(w http.ResponseWriter, shareID string) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(map[string]string{
+		"shareId": shareID,
+		"status":  string(ocmshares.OutgoingShareStatusRevoked),
+	}); err != nil {
+		h.logger.Error("failed to encode revoked share", "error", err)
+	}
+}
+
+// wasDelivered reports whether the receiver has a copy of the share to revoke.
+func wasDelivered(status ocmshares.OutgoingShareStatus) bool {
+	switch status {
+	case ocmshares.OutgoingShareStatusSent,
+		ocmshares.OutgoingShareStatusAccepted,
+		ocmshares.OutgoingShareStatusDeclined:
+		return true
+	case ocmshares.OutgoingShareStatusPending,
+		ocmshares.OutgoingShareStatusFailed,
+		ocmshares.OutgoingShareStatusRevoked,
+		ocmshares.OutgoingShareStatusExpired:
+		return false
+	}
+
+	return false
+}
+
+func (h *Handler) notifyShareUnsharedAsync(ctx context.Context, share *sharesoutgoing.OutgoingShare) {
+	if h.notifier == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+	notifier := h.notifier
+	log := h.logger
+	receiverHost := share.ReceiverHost
+	providerID := share.ProviderID
+	resourceType := share.ResourceType
+
+	go func() {
+		defer cancel()
+
+		err := notifier.Notify(ctx, receiverHost, providerID, resourceType, spec.NotificationTypeShareUnshared, nil)
+		if errors.Is(err, notifications.ErrNotificationsNotAdvertised) {
+			return
+		}
+
+		if err != nil {
+			log.Warn("failed to send share notification",
+				"notification_type", spec.NotificationTypeShareUnshared,
+				"provider_id", providerID,
+				"receiver_host", receiverHost,
+				"error", err)
+		}
+	}()
+}
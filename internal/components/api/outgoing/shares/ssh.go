@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+// SetSSH enables the outbound ssh arm. addr is the host:port of the built-in
+// SFTP server as receivers reach it; empty disables the arm.
+func (h *Handler) SetSSH(addr string) {
+	h.sshAddr = addr
+}
+
+// buildSSHProtocol returns the ssh arm for a request that asks for one, or
+// nil when it does not. The SFTP username is the share's webdavId, and the
+// path is the share name under the per-share virtual root.
+func (h *Handler) buildSSHProtocol(
+	w http.ResponseWriter,
+	req sharesoutgoing.OutgoingShareRequest,
+	disc *spec.Discovery,
+	webdavID, name string,
+) (*spec.SSHProtocol, bool) {
+	if !req.SSH {
+		return nil, true
+	}
+
+	if h.sshAddr == "" {
+		api.WriteBadRequest(w, api.ReasonInvalidField, "ssh shares are not enabled on this server")
+
+		return nil, false
+	}
+
+	if !disc.SupportsSSHReceive() {
+		h.logger.Warn("receiver lacks ssh-receive capability for ssh share", "receiver", req.ReceiverDomain)
+		api.WriteError(w, reason.APIStatus(reason.PeerCapabilityMismatch), reason.PeerCapabilityMismatch,
+			"receiver does not advertise ssh-receive")
+
+		return nil, false
+	}
+
+	return &spec.SSHProtocol{
+		AccessTypes: []string{spec.AccessTypeRemote, spec.AccessTypeDataTx},
+		URI:         spec.FormatSSHURI(webdavID, h.sshAddr, name),
+	}, true
+}
+
+// recipientPublicKeys extracts recipientPublicKeys from a create-share
+// response body. Bodies that are empty or not JSON yield no keys: only ssh
+// shares need them, and the SFTP server then simply admits nobody.
+func recipientPublicKeys(body []byte) []string {
+	var resp spec.CreateShareResponse
+	if len(body) == 0 || json.Unmarshal(body, &resp) != nil {
+		return nil
+	}
+
+	return resp.RecipientPublicKeys
+}
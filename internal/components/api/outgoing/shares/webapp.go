@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package shares
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+)
+
+// SetWebapp enables the outbound webapp arm using the configured viewer URL
+// template and targets.
+func (h *Handler) SetWebapp(cfg config.WebappConfig) {
+	h.webapp = cfg
+}
+
+// buildWebappProtocol returns the webapp arm for a request that asks for
+// webapp permissions, or nil when it does not. The arm carries its own secret
+// and always must-exchange-token: receivers trade the secret at our token
+// endpoint for a short-lived token scoped to the webapp permissions, so it
+// cannot unlock the wider WebDAV permissions.
+func (h *Handler) buildWebappProtocol(
+	w http.ResponseWriter,
+	req sharesoutgoing.OutgoingShareRequest,
+	disc *spec.Discovery,
+	webdavID, name string,
+) (*spec.WebappProtocol, bool) {
+	if len(req.WebappPermissions) == 0 {
+		return nil, true
+	}
+
+	if !h.webapp.Enabled() {
+		api.WriteBadRequest(w, api.ReasonInvalidField, "webapp shares are not enabled on this server")
+
+		return nil, false
+	}
+
+	if h.localTokenEndPoint == "" {
+		h.logger.Warn("webapp share requested but local sender is not configured for token exchange")
+		api.WriteError(w, reason.APIStatus(reason.PeerCapabilityMismatch), reason.PeerCapabilityMismatch,
+			"local sender is not configured for token exchange")
+
+		return nil, false
+	}
+
+	if !disc.SupportsTokenExchange() {
+		h.logger.Warn("receiver lacks token-exchange capability for webapp share", "receiver", req.ReceiverDomain)
+		api.WriteError(w, reason.APIStatus(reason.PeerCapabilityMismatch), reason.PeerCapabilityMismatch,
+			"receiver does not advertise exchange-token with tokenEndPoint")
+
+		return nil, false
+	}
+
+	sharedSecret, err := generateSharedSecret()
+	if err != nil {
+		h.logger.Error("failed to generate webapp shared secret", "error", err)
+		api.WriteInternalError(w, "failed to create share")
+
+		return nil, false
+	}
+
+	return &spec.WebappProtocol{
+		URI:          h.webapp.ViewerURL(webdavID, name),
+		Targets:      slices.Clone(h.webapp.Targets),
+		Permissions:  slices.Clone(req.WebappPermissions),
+		Requirements: []string{spec.RequirementMustExchangeToken},
+		SharedSecret: sharedSecret,
+	}, true
+}
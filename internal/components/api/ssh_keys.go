@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/appctx"
+)
+
+const maxSSHKeysBodyBytes = 64 << 10
+
+// SSHKeysBody is the request and response body of /api/auth/me/ssh-keys.
+type SSHKeysBody struct {
+	Keys []string `json:"keys"`
+}
+
+// GetSSHKeys handles GET /api/auth/me/ssh-keys and lists the current user's
+// registered SSH public keys.
+func (h *AuthHandler) GetSSHKeys(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.sessionUser(w, r)
+	if !ok {
+		return
+	}
+
+	keys := user.SSHPublicKeys
+	if keys == nil {
+		keys = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, SSHKeysBody{Keys: keys})
+}
+
+// PutSSHKeys handles PUT /api/auth/me/ssh-keys and replaces the current
+// user's SSH public keys. Senders of ssh shares receive these keys as
+// recipientPublicKeys and grant SFTP access to them.
+func (h *AuthHandler) PutSSHKeys(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.sessionUser(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxSSHKeysBodyBytes)
+
+	var req SSHKeysBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "payload_too_large", "request body too large")
+
+			return
+		}
+
+		writeJSONError(w, http.StatusBadRequest, "invalid_request", "invalid JSON body")
+
+		return
+	}
+
+	keys, err := identity.NormalizeSSHPublicKeys(req.Keys)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid_request", err.Error())
+
+		return
+	}
+
+	user.SSHPublicKeys = keys
+
+	ctx := r.Context()
+	if err := h.repo.Update(ctx, user); err != nil {
+		appctx.GetLogger(ctx).Warn("ssh key update failed", "error", err)
+		WriteInternalError(w, "internal server error")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, SSHKeysBody{Keys: keys})
+}
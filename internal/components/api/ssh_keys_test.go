@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	tscrypto "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/crypto"
+)
+
+func sshKeysRequest(t *testing.T, h *AuthHandler, method, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), method, "/api/auth/me/ssh-keys", bytes.NewBufferString(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+
+	if method == http.MethodPut {
+		h.PutSSHKeys(w, req)
+	} else {
+		h.GetSSHKeys(w, req)
+	}
+
+	return w
+}
+
+func TestAuthHandler_SSHKeys_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	handler, repo, sessions, auth := newTestAuthHandler(t)
+	user := seedUser(t, repo, auth, "alice", "secret123")
+
+	session, err := sessions.Create(context.Background(), user.ID, SessionTTL)
+	if err != nil {
+		t.Fatalf("Create session: %v", err)
+	}
+
+	w := sshKeysRequest(t, handler, http.MethodGet, session.Token, "")
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"keys":[]}` {
+		t.Fatalf("initial GET = %d %s", w.Code, w.Body.String())
+	}
+
+	key := tscrypto.MustSSHKey(t)
+	body := `{"keys":["` + key.AuthorizedKey + ` alice@laptop", "` + key.AuthorizedKey + `"]}`
+
+	w = sshKeysRequest(t, handler, http.MethodPut, session.Token, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("PUT = %d %s", w.Code, w.Body.String())
+	}
+
+	var resp SSHKeysBody
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if !slices.Equal(resp.Keys, []string{key.AuthorizedKey}) {
+		t.Errorf("keys = %v, want the normalized key once", resp.Keys)
+	}
+
+	stored, err := repo.Get(context.Background(), user.ID)
+	if err != nil || !slices.Equal(stored.SSHPublicKeys, []string{key.AuthorizedKey}) {
+		t.Fatalf("stored keys = %v, %v", stored, err)
+	}
+}
+
+func TestAuthHandler_SSHKeys_Rejections(t *testing.T) {
+	t.Parallel()
+
+	handler, repo, sessions, auth := newTestAuthHandler(t)
+	user := seedUser(t, repo, auth, "alice", "secret123")
+
+	session, err := sessions.Create(context.Background(), user.ID, SessionTTL)
+	if err != nil {
+		t.Fatalf("Create session: %v", err)
+	}
+
+	if w := sshKeysRequest(t, handler, http.MethodGet, "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("GET without session = %d, want 401", w.Code)
+	}
+
+	if w := sshKeysRequest(t, handler, http.MethodPut, "", `{"keys":[]}`); w.Code != http.StatusUnauthorized {
+		t.Errorf("PUT without session = %d, want 401", w.Code)
+	}
+
+	for _, body := range []string{`{`, `{"keys":["not a key"]}`, `{"keys":[` + strings.Repeat(`"x",`, 20) + `"x"]}`} {
+		if w := sshKeysRequest(t, handler, http.MethodPut, session.Token, body); w.Code != http.StatusBadRequest {
+			t.Errorf("PUT %s = %d, want 400", body, w.Code)
+		}
+	}
+}
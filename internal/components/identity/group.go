@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package identity
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrGroupNotFound is returned when a group lookup finds no match.
+	ErrGroupNotFound = errors.New("group not found")
+	// ErrGroupExists is returned when creating or renaming a group to a taken name.
+	ErrGroupExists = errors.New("group already exists")
+)
+
+// Group is a named set of local users. Inbound OCM shares with shareType
+// "group" address a group by Name and fan out to every member.
+type Group struct {
+	ID          string    `json:"id"`          // UUIDv7
+	Name        string    `json:"name"`        // Unique; the shareWith identifier
+	DisplayName string    `json:"displayName"` // Human-readable name
+	Members     []string  `json:"members"`     // Canonical local user ids
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// HasMember reports whether userID belongs to the group.
+func (g *Group) HasMember(userID string) bool {
+	return slices.Contains(g.Members, userID)
+}
+
+// GroupRepo provides group storage operations.
+type GroupRepo interface {
+	// Create creates a new group. Returns ErrGroupExists if the name is taken.
+	Create(ctx context.Context, group *Group) error
+
+	// Get retrieves a group by ID. Returns ErrGroupNotFound if not found.
+	Get(ctx context.Context, id string) (*Group, error)
+
+	// GetByName retrieves a group by name. Returns ErrGroupNotFound if not found.
+	GetByName(ctx context.Context, name string) (*Group, error)
+
+	// Update replaces an existing group. Returns ErrGroupExists if the new
+	// name is taken.
+	Update(ctx context.Context, group *Group) error
+
+	// Delete removes a group by ID.
+	Delete(ctx context.Context, id string) error
+
+	// List returns all groups ordered by name.
+	List(ctx context.Context) ([]*Group, error)
+}
@@ -59,6 +59,9 @@ type User struct {
 	StorageRoot  string     `json:"storageRoot"` // User's storage root path
 	CreatedAt    time.Time  `json:"createdAt"`
 	ExpiresAt    *time.Time `json:"expiresAt,omitempty"` // For probe users
+	// SSHPublicKeys are authorized_keys lines (type and base64 blob, no
+	// comment) returned to senders of ssh shares as recipientPublicKeys.
+	SSHPublicKeys []string `json:"sshPublicKeys,omitempty"`
 }
 
 // IsProbe reports whether the user has the probe role.
@@ -8,7 +8,9 @@ package identity
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"sync"
 	"time"
@@ -55,6 +57,15 @@ func GenerateToken() (string, error) {
 	return base64.URLEncoding.EncodeToString(b), nil
 }
 
+// HashSessionToken returns the hex SHA-256 digest session stores key
+// sessions by, so a leaked store does not leak live sessions. Session tokens
+// are 256-bit random values, so an unsalted fast hash is enough.
+func HashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
 // MemorySessionRepo is an in-memory implementation of SessionRepo.
 type MemorySessionRepo struct {
 	mu       sync.RWMutex
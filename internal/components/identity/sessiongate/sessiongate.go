@@ -17,6 +17,7 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/appctx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/localidentity"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
 )
@@ -121,6 +122,8 @@ func NewAuthGate(cfg AuthGateConfig) func(http.Handler) http.Handler {
 			ctx := r.Context()
 			ctx = context.WithValue(ctx, sessionContextKey, session)
 			ctx = context.WithValue(ctx, userContextKey, user)
+			// Audit events recorded for this request default to the user.
+			ctx = audit.WithActor(ctx, user.Username)
 
 			// Enrich handler logger with user_id (not used by access log, handler-only)
 			reqLogger := appctx.GetLogger(ctx).With("user_id", session.UserID)
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// MaxSSHPublicKeys caps how many SSH public keys one user may register.
+const MaxSSHPublicKeys = 16
+
+// ErrTooManySSHKeys is returned when more than MaxSSHPublicKeys are given.
+var ErrTooManySSHKeys = fmt.Errorf("at most %d SSH public keys are allowed", MaxSSHPublicKeys)
+
+// NormalizeSSHPublicKey parses one authorized_keys line and returns it as
+// "type base64" without options or comment.
+func NormalizeSSHPublicKey(line string) (string, error) {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(line)))
+	if err != nil {
+		return "", fmt.Errorf("parse SSH public key: %w", err)
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub))), nil
+}
+
+// NormalizeSSHPublicKeys normalizes each key, drops duplicates, and keeps the
+// first-seen order.
+func NormalizeSSHPublicKeys(lines []string) ([]string, error) {
+	if len(lines) > MaxSSHPublicKeys {
+		return nil, ErrTooManySSHKeys
+	}
+
+	out := make([]string, 0, len(lines))
+
+	for i, line := range lines {
+		key, err := NormalizeSSHPublicKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+
+		if !slices.Contains(out, key) {
+			out = append(out, key)
+		}
+	}
+
+	return out, nil
+}
+
+// errNoSSHKey is returned by ParseSSHPublicKeys when no line parses.
+var errNoSSHKey = errors.New("no valid SSH public key")
+
+// ParseSSHPublicKeys parses normalized authorized_keys lines, skipping lines
+// that do not parse. It fails only when nothing parses.
+func ParseSSHPublicKeys(lines []string) ([]ssh.PublicKey, error) {
+	keys := make([]ssh.PublicKey, 0, len(lines))
+
+	for _, line := range lines {
+		pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			continue
+		}
+
+		keys = append(keys, pub)
+	}
+
+	if len(keys) == 0 {
+		return nil, errNoSSHKey
+	}
+
+	return keys, nil
+}
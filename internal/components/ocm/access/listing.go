@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package access
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxListingBody caps a single Depth 1 PROPFIND response.
+const maxListingBody = 8 << 20
+
+// ErrUnsafeListingName reports a listing entry whose name is not usable as a
+// single path element.
+var ErrUnsafeListingName = errors.New("folder listing has an unsafe name")
+
+// ListingEntry is one resource of a PROPFIND listing. Size is -1 when the
+// sender did not report it.
+type ListingEntry struct {
+	Name         string
+	Collection   bool
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// Listing is a Depth 1 PROPFIND response: the requested resource and, for a
+// collection, its direct children.
+type Listing struct {
+	Self     *ListingEntry
+	Children []ListingEntry
+}
+
+// multistatus is the subset of a PROPFIND response a listing needs.
+type multistatus struct {
+	Responses []struct {
+		Href      string `xml:"DAV: href"`
+		Propstats []struct {
+			Status string  `xml:"DAV: status"`
+			Prop   davProp `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+type davProp struct {
+	ResourceType struct {
+		Collection *struct{} `xml:"DAV: collection"`
+	} `xml:"DAV: resourcetype"`
+	ContentLength string `xml:"DAV: getcontentlength"`
+	ContentType   string `xml:"DAV: getcontenttype"`
+	ETag          string `xml:"DAV: getetag"`
+	LastModified  string `xml:"DAV: getlastmodified"`
+}
+
+// ReadListing parses the 207 body of a Depth 1 PROPFIND sent for
+// resp.Request. Children are named relative to the requested resource;
+// anything not directly below it is skipped. The caller checks the status.
+func ReadListing(resp *http.Response) (*Listing, error) {
+	var ms multistatus
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, maxListingBody)).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("access: decode folder listing: %w", err)
+	}
+
+	base := strings.TrimSuffix(resp.Request.URL.Path, "/")
+	listing := &Listing{}
+
+	for _, r := range ms.Responses {
+		href, err := url.Parse(strings.TrimSpace(r.Href))
+		if err != nil {
+			return nil, fmt.Errorf("access: folder listing has a malformed href: %w", err)
+		}
+
+		p := strings.TrimSuffix(href.Path, "/")
+
+		e := ListingEntry{Size: -1}
+		for _, ps := range r.Propstats {
+			if strings.Contains(ps.Status, " 200 ") {
+				e.merge(&ps.Prop)
+			}
+		}
+
+		switch {
+		case p == base:
+			listing.Self = &e
+		case path.Dir(p) == base:
+			e.Name = path.Base(p)
+			if !ValidListingName(e.Name) {
+				return nil, fmt.Errorf("%w: %q", ErrUnsafeListingName, e.Name)
+			}
+
+			listing.Children = append(listing.Children, e)
+		}
+	}
+
+	return listing, nil
+}
+
+// merge folds the properties of one 200 propstat into e.
+func (e *ListingEntry) merge(p *davProp) {
+	if p.ResourceType.Collection != nil {
+		e.Collection = true
+	}
+
+	if n, err := strconv.ParseInt(p.ContentLength, 10, 64); err == nil && n >= 0 {
+		e.Size = n
+	}
+
+	if p.ContentType != "" {
+		e.ContentType = p.ContentType
+	}
+
+	if p.ETag != "" {
+		e.ETag = p.ETag
+	}
+
+	if t, err := http.ParseTime(p.LastModified); err == nil {
+		e.LastModified = t
+	}
+}
+
+// ValidListingName reports whether name is safe as a single path element.
+func ValidListingName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, "/\\\x00")
+}
+
+// EscapeSubPath escapes each segment of a slash-separated path below a
+// shared folder for use as AccessOptions.SubPath.
+func EscapeSubPath(rel string) string {
+	if rel == "" {
+		return ""
+	}
+
+	segments := strings.Split(rel, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package access
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func listingResponse(t *testing.T, body string) *http.Response {
+	t.Helper()
+
+	u, err := url.Parse("https://sender.example.org/webdav/ocm/abc/sub%20dir")
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusMultiStatus,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{URL: u},
+	}
+}
+
+func listingEntry(href, props string) string {
+	return `<D:response><D:href>` + href + `</D:href><D:propstat><D:prop>` + props +
+		`</D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat>` +
+		`<D:propstat><D:prop><D:getetag/></D:prop><D:status>HTTP/1.1 404 Not Found</D:status></D:propstat></D:response>`
+}
+
+func TestReadListing(t *testing.T) {
+	t.Parallel()
+
+	body := `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:">` +
+		listingEntry("/webdav/ocm/abc/sub%20dir/", `<D:resourcetype><D:collection/></D:resourcetype>`) +
+		listingEntry("/webdav/ocm/abc/sub%20dir/a.txt", `<D:resourcetype/><D:getcontentlength>12</D:getcontentlength>`+
+			`<D:getcontenttype>text/plain</D:getcontenttype><D:getlastmodified>Mon, 02 Jan 2006 15:04:05 GMT</D:getlastmodified>`) +
+		listingEntry("/webdav/ocm/abc/sub%20dir/nested/deeper.txt", `<D:resourcetype/>`) +
+		`</D:multistatus>`
+
+	listing, err := ReadListing(listingResponse(t, body))
+	if err != nil {
+		t.Fatalf("ReadListing: %v", err)
+	}
+
+	if listing.Self == nil || !listing.Self.Collection {
+		t.Fatalf("Self = %+v, want the collection", listing.Self)
+	}
+
+	want := ListingEntry{
+		Name:         "a.txt",
+		Size:         12,
+		ContentType:  "text/plain",
+		LastModified: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+	if len(listing.Children) != 1 || listing.Children[0] != want {
+		t.Fatalf("Children = %+v, want [%+v]", listing.Children, want)
+	}
+}
+
+func TestReadListing_RejectsUnsafeNames(t *testing.T) {
+	t.Parallel()
+
+	body := `<D:multistatus xmlns:D="DAV:">` + listingEntry("/webdav/ocm/abc/sub%20dir/..%5Cx", `<D:resourcetype/>`) +
+		`</D:multistatus>`
+
+	if _, err := ReadListing(listingResponse(t, body)); !errors.Is(err, ErrUnsafeListingName) {
+		t.Fatalf("ReadListing = %v, want ErrUnsafeListingName", err)
+	}
+}
+
+func TestEscapeSubPath(t *testing.T) {
+	t.Parallel()
+
+	if got := EscapeSubPath("run 1/a#b?.txt"); got != "run%201/a%23b%3F.txt" {
+		t.Errorf("EscapeSubPath = %q", got)
+	}
+
+	if got := EscapeSubPath(""); got != "" {
+		t.Errorf("EscapeSubPath(\"\") = %q", got)
+	}
+}
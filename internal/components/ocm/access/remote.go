@@ -19,9 +19,11 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peerorigin"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 	tokenoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token/outgoing"
 	httpclient "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/client"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // Share status constants; duplicated here to avoid import cycles.
@@ -57,8 +59,10 @@ var (
 	ErrProtocolRequired = errors.New("access protocol must be webdav")
 )
 
-// ShareInfo holds the minimal share fields needed for remote access (avoids import cycles).
+// ShareInfo holds the minimal share fields needed for remote access.
+// ShareID keys the exchanged-token cache; leave it empty to exchange on every access.
 type ShareInfo struct {
+	ShareID           string
 	Status            string
 	SenderHost        string
 	OwnerHost         string // resource-hosting server; falls back to SenderHost when empty
@@ -69,6 +73,27 @@ type ShareInfo struct {
 	WebappURI         string
 	WebappTargets     []string
 	WebappPermissions []string
+	// WebappSharedSecret is the webapp arm's own secret; OpenWebapp falls
+	// back to SharedSecret when it is empty.
+	WebappSharedSecret string
+}
+
+// ShareInfoFromIncoming copies the access fields of an incoming share.
+func ShareInfoFromIncoming(share *sharesincoming.IncomingShare) *ShareInfo {
+	return &ShareInfo{
+		ShareID:            share.ShareID,
+		Status:             string(share.Status),
+		SenderHost:         share.SenderHost,
+		OwnerHost:          share.OwnerHost,
+		SharedSecret:       share.SharedSecret,
+		ProtocolName:       share.ProtocolName,
+		Requirements:       share.Requirements,
+		WebDAVID:           share.WebDAVID,
+		WebappURI:          share.WebappURI,
+		WebappTargets:      share.WebappTargets,
+		WebappPermissions:  share.WebappPermissions,
+		WebappSharedSecret: share.WebappSecret,
+	}
 }
 
 // RemoteAccessor is the interface for remote share access; extracted for mocks.
@@ -77,11 +102,13 @@ type RemoteAccessor interface {
 }
 
 // Client accesses files from remote OCM shares via exchanged Bearer tokens.
+// Exchanged tokens are cached per share until their expires_in lapses.
 type Client struct {
 	httpClient      *httpclient.ContextClient
 	discoveryClient *discovery.Client
 	tokenClient     *tokenoutgoing.Client
 	peerOrigin      *peerorigin.Resolver
+	tokens          *tokenCache
 }
 
 // NewClient returns a Client; panics if discoveryClient is nil. A nil peer
@@ -101,6 +128,7 @@ func NewClient(
 		discoveryClient: discoveryClient,
 		tokenClient:     tokenClient,
 		peerOrigin:      peerOrigin,
+		tokens:          newTokenCache(),
 	}
 }
 
@@ -110,6 +138,10 @@ type AccessOptions struct {
 	Protocol string // "webdav"; must be set explicitly
 	Method   string // GET, PROPFIND, etc.
 	SubPath  string
+	Depth    string // WebDAV Depth header for PROPFIND; omitted when empty
+	// Header holds extra request headers such as Range. Authorization is
+	// always set by the client and cannot be overridden here.
+	Header http.Header
 }
 
 // AccessResult holds the HTTP response and access token from a remote access request.
@@ -372,40 +404,37 @@ func (c *Client) accessTokenExchange(ctx context.Context, share *ShareInfo, opts
 		return nil, ErrTokenExchangeRequired
 	}
 
-	exchangeResult, err := c.tokenClient.Exchange(ctx, tokenoutgoing.ExchangeRequest{
-		TokenEndPoint: disc.TokenEndPoint,
-		SharedSecret:  share.SharedSecret,
-	}, disc)
+	webdavURL, err := c.buildWebDAVURL(share, opts.SubPath, disc)
 	if err != nil {
-		return nil, fmt.Errorf("ocm: exchange access token: %w", err)
+		return nil, err
 	}
 
-	accessToken := exchangeResult.AccessToken
-
-	webdavURL, err := c.buildWebDAVURL(share, opts.SubPath, disc)
+	accessToken, cached, err := c.acquireAccessToken(ctx, share, disc)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, opts.Method, webdavURL, nil)
+	resp, err := c.doWebDAV(ctx, opts, webdavURL, accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("ocm: build webdav request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if cached && resp.StatusCode == http.StatusUnauthorized {
+		// The Sending Server no longer honors the cached token (revoked, or
+		// lost on restart with an in-memory store): exchange afresh once.
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		resp.Body.Close()
+		c.tokens.drop(share.ShareID)
 
-	resp, err := c.httpClient.Do(ctx, req)
-	if err != nil {
-		if resp != nil {
-			//nolint:errcheck // best-effort cleanup; error is not actionable
-			resp.Body.Close()
+		accessToken, _, err = c.acquireAccessToken(ctx, share, disc)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil, reason.NewClassifiedError(
-			reason.ReasonNetworkError,
-			"WebDAV request failed",
-			err,
-		)
+		resp, err = c.doWebDAV(ctx, opts, webdavURL, accessToken)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &AccessResult{
@@ -414,18 +443,76 @@ func (c *Client) accessTokenExchange(ctx context.Context, share *ShareInfo, opts
 	}, nil
 }
 
+// acquireAccessToken returns a cached access token for the share when one is
+// still valid (cached is true), otherwise refreshes or exchanges a new one and
+// caches it.
+func (c *Client) acquireAccessToken(ctx context.Context, share *ShareInfo, disc *spec.Discovery) (string, bool, error) {
+	if share.ShareID != "" {
+		entry, ok := c.tokens.get(share.ShareID, disc.TokenEndPoint)
+		if ok && c.tokens.valid(entry) {
+			metrics.CacheLookup(metrics.CacheAccessToken, true)
+
+			return entry.accessToken, true, nil
+		}
+
+		metrics.CacheLookup(metrics.CacheAccessToken, false)
+
+		if ok && entry.refreshToken != "" {
+			result, err := c.tokenClient.Refresh(ctx, tokenoutgoing.RefreshRequest{
+				TokenEndPoint: disc.TokenEndPoint,
+				RefreshToken:  entry.refreshToken,
+			}, disc)
+			if err == nil {
+				c.tokens.put(share.ShareID, disc.TokenEndPoint, result)
+
+				return result.AccessToken, false, nil
+			}
+
+			slog.WarnContext(ctx, "token refresh failed; exchanging the shared secret again")
+			c.tokens.drop(share.ShareID)
+		}
+	}
+
+	result, err := c.tokenClient.Exchange(ctx, tokenoutgoing.ExchangeRequest{
+		TokenEndPoint: disc.TokenEndPoint,
+		SharedSecret:  share.SharedSecret,
+	}, disc)
+	if err != nil {
+		return "", false, fmt.Errorf("ocm: exchange access token: %w", err)
+	}
+
+	if share.ShareID != "" {
+		c.tokens.put(share.ShareID, disc.TokenEndPoint, result)
+	}
+
+	return result.AccessToken, false, nil
+}
+
 func (c *Client) accessSharedSecret(ctx context.Context, share *ShareInfo, opts AccessOptions, disc *spec.Discovery) (*AccessResult, error) {
 	webdavURL, err := c.buildWebDAVURL(share, opts.SubPath, disc)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, opts.Method, webdavURL, nil)
+	resp, err := c.doWebDAV(ctx, opts, webdavURL, share.SharedSecret)
 	if err != nil {
-		return nil, fmt.Errorf("ocm: build webdav request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+share.SharedSecret)
+	return &AccessResult{
+		Response:    resp,
+		AccessToken: share.SharedSecret,
+	}, nil
+}
+
+// doWebDAV sends the WebDAV request with bearer as the Authorization token.
+func (c *Client) doWebDAV(ctx context.Context, opts AccessOptions, webdavURL, bearer string) (*http.Response, error) {
+	req, err := newWebDAVRequest(ctx, opts, webdavURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+bearer)
 
 	resp, err := c.httpClient.Do(ctx, req)
 	if err != nil {
@@ -441,10 +528,26 @@ func (c *Client) accessSharedSecret(ctx context.Context, share *ShareInfo, opts
 		)
 	}
 
-	return &AccessResult{
-		Response:    resp,
-		AccessToken: share.SharedSecret,
-	}, nil
+	return resp, nil
+}
+
+func newWebDAVRequest(ctx context.Context, opts AccessOptions, webdavURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, opts.Method, webdavURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ocm: build webdav request: %w", err)
+	}
+
+	for name, values := range opts.Header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	if opts.Depth != "" {
+		req.Header.Set("Depth", opts.Depth)
+	}
+
+	return req, nil
 }
 
 func (c *Client) checkSignaturePolicy(disc *spec.Discovery) error {
@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package access
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	tokenoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token/outgoing"
+)
+
+// refreshingPeer is a Sending Server stub issuing numbered access and refresh
+// tokens. WebDAV accepts any access token it issued and has not revoked.
+type refreshingPeer struct {
+	t *testing.T
+
+	mu             sync.Mutex
+	codeGrants     int
+	refreshGrants  int
+	rejectRefresh  bool
+	issued         int
+	valid          map[string]bool
+	refreshTokens  map[string]bool
+	lastRefreshArg string
+}
+
+func newRefreshingPeer(t *testing.T) (*refreshingPeer, *httptest.Server) {
+	t.Helper()
+
+	p := &refreshingPeer{t: t, valid: map[string]bool{}, refreshTokens: map[string]bool{}}
+	srv := httptest.NewServer(http.HandlerFunc(p.serve))
+	t.Cleanup(srv.Close)
+
+	return p, srv
+}
+
+func (p *refreshingPeer) serve(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/ocm/token" {
+		p.serveToken(w, r)
+
+		return
+	}
+
+	if exchangeDiscoveryHandler(p.t, w, r, "unused") {
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/webdav/ocm/") {
+		p.mu.Lock()
+		ok := p.valid[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		p.mu.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+func (p *refreshingPeer) serveToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch r.FormValue("grant_type") {
+	case spec.GrantTypeAuthorizationCode:
+		p.codeGrants++
+	case spec.GrantTypeRefreshToken:
+		p.refreshGrants++
+		p.lastRefreshArg = r.FormValue("refresh_token")
+
+		if p.rejectRefresh || !p.refreshTokens[p.lastRefreshArg] {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			tshttp.MustWrite(p.t, w, []byte(`{"error":"invalid_grant"}`))
+
+			return
+		}
+
+		delete(p.refreshTokens, p.lastRefreshArg)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	p.issued++
+	n := strconv.Itoa(p.issued)
+	p.valid["access-"+n] = true
+	p.refreshTokens["refresh-"+n] = true
+
+	w.Header().Set("Content-Type", "application/json")
+	tshttp.MustWrite(p.t, w, []byte(
+		`{"access_token":"access-`+n+`","token_type":"Bearer","expires_in":3600,"refresh_token":"refresh-`+n+`"}`))
+}
+
+func (p *refreshingPeer) grants() (code, refresh int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.codeGrants, p.refreshGrants
+}
+
+func cachedShare(srv *httptest.Server, shareID string) *ShareInfo {
+	return &ShareInfo{
+		ShareID:      shareID,
+		Status:       "accepted",
+		SenderHost:   srv.URL,
+		SharedSecret: "secret-" + shareID,
+		WebDAVID:     "file-" + shareID,
+		Requirements: []string{spec.RequirementMustExchangeToken},
+	}
+}
+
+func accessShare(t *testing.T, client *Client, share *ShareInfo) *AccessResult {
+	t.Helper()
+
+	result, err := client.Access(context.Background(), AccessOptions{
+		Share:    share,
+		Protocol: ProtocolWebDAV,
+		Method:   http.MethodGet,
+	})
+	if err != nil {
+		t.Fatalf("Access: %v", err)
+	}
+
+	tshttp.MustClose(t, result.Response.Body)
+
+	if result.Response.StatusCode != http.StatusOK {
+		t.Fatalf("StatusCode = %d, want 200", result.Response.StatusCode)
+	}
+
+	return result
+}
+
+func TestAccess_CachesExchangedTokenPerShare(t *testing.T) {
+	t.Parallel()
+
+	peer, srv := newRefreshingPeer(t)
+	client := newExchangeAccessClient(t, srv)
+
+	first := accessShare(t, client, cachedShare(srv, "s1"))
+	second := accessShare(t, client, cachedShare(srv, "s1"))
+
+	if first.AccessToken != second.AccessToken {
+		t.Errorf("second access used %q, want cached %q", second.AccessToken, first.AccessToken)
+	}
+
+	accessShare(t, client, cachedShare(srv, "s2"))
+
+	if code, refresh := peer.grants(); code != 2 || refresh != 0 {
+		t.Errorf("grants = %d code / %d refresh, want one code exchange per share", code, refresh)
+	}
+}
+
+func TestAccess_WithoutShareIDExchangesEveryTime(t *testing.T) {
+	t.Parallel()
+
+	peer, srv := newRefreshingPeer(t)
+	client := newExchangeAccessClient(t, srv)
+
+	accessShare(t, client, cachedShare(srv, ""))
+	accessShare(t, client, cachedShare(srv, ""))
+
+	if code, _ := peer.grants(); code != 2 {
+		t.Errorf("code grants = %d, want 2", code)
+	}
+}
+
+func TestAccess_ExpiredCachedTokenIsRefreshed(t *testing.T) {
+	t.Parallel()
+
+	peer, srv := newRefreshingPeer(t)
+	client := newExchangeAccessClient(t, srv)
+
+	accessShare(t, client, cachedShare(srv, "s1"))
+
+	client.tokens.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	result := accessShare(t, client, cachedShare(srv, "s1"))
+	if result.AccessToken != "access-2" {
+		t.Errorf("AccessToken = %q, want refreshed access-2", result.AccessToken)
+	}
+
+	if code, refresh := peer.grants(); code != 1 || refresh != 1 {
+		t.Errorf("grants = %d code / %d refresh, want 1/1", code, refresh)
+	}
+
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+
+	if peer.lastRefreshArg != "refresh-1" {
+		t.Errorf("refresh_token sent = %q, want refresh-1", peer.lastRefreshArg)
+	}
+}
+
+func TestAccess_FailedRefreshFallsBackToExchange(t *testing.T) {
+	t.Parallel()
+
+	peer, srv := newRefreshingPeer(t)
+	peer.rejectRefresh = true
+	client := newExchangeAccessClient(t, srv)
+
+	accessShare(t, client, cachedShare(srv, "s1"))
+
+	client.tokens.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	accessShare(t, client, cachedShare(srv, "s1"))
+
+	if code, refresh := peer.grants(); code != 2 || refresh != 1 {
+		t.Errorf("grants = %d code / %d refresh, want 2/1", code, refresh)
+	}
+}
+
+func TestAccess_RejectedCachedTokenIsReexchanged(t *testing.T) {
+	t.Parallel()
+
+	peer, srv := newRefreshingPeer(t)
+	client := newExchangeAccessClient(t, srv)
+
+	accessShare(t, client, cachedShare(srv, "s1"))
+
+	peer.mu.Lock()
+	delete(peer.valid, "access-1")
+	peer.mu.Unlock()
+
+	result := accessShare(t, client, cachedShare(srv, "s1"))
+	if result.AccessToken != "access-2" {
+		t.Errorf("AccessToken = %q, want re-exchanged access-2", result.AccessToken)
+	}
+
+	if code, _ := peer.grants(); code != 2 {
+		t.Errorf("code grants = %d, want 2", code)
+	}
+}
+
+func TestTokenCache_ExpiryKeepsSkew(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newTokenCache()
+	cache.now = func() time.Time { return now }
+
+	cache.put("long", "ep", &tokenoutgoing.ExchangeResult{AccessToken: "a", ExpiresIn: 3600})
+	cache.put("short", "ep", &tokenoutgoing.ExchangeResult{AccessToken: "b", ExpiresIn: 10})
+
+	long, _ := cache.get("long", "ep")
+	if want := now.Add(3600*time.Second - tokenCacheSkew); !long.expiresAt.Equal(want) {
+		t.Errorf("long expiresAt = %v, want %v", long.expiresAt, want)
+	}
+
+	short, _ := cache.get("short", "ep")
+	if want := now.Add(5 * time.Second); !short.expiresAt.Equal(want) {
+		t.Errorf("short expiresAt = %v, want %v (half the lifetime)", short.expiresAt, want)
+	}
+
+	if _, ok := cache.get("long", "other-endpoint"); ok {
+		t.Error("entry from another token endpoint must not be returned")
+	}
+}
@@ -101,3 +101,99 @@ func TestAccess_SharedSecretSuccess(t *testing.T) {
 		t.Errorf("AccessToken = %q, want shared-secret", result.AccessToken)
 	}
 }
+
+func TestAccess_PropfindForwardsDepth(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod, gotDepth string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sharedSecretDiscoveryHandler(w, r) {
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/webdav/ocm/") {
+			gotMethod = r.Method
+			gotDepth = r.Header.Get("Depth")
+
+			w.WriteHeader(http.StatusMultiStatus)
+
+			return
+		}
+
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	client := newExchangeAccessClient(t, srv)
+
+	result, err := client.Access(context.Background(), AccessOptions{
+		Share: &ShareInfo{
+			Status:       "accepted",
+			SenderHost:   srv.URL,
+			SharedSecret: "shared-secret",
+			WebDAVID:     "folder-123",
+		},
+		Protocol: "webdav",
+		Method:   "PROPFIND",
+		Depth:    "1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer tshttp.MustClose(t, result.Response.Body)
+
+	if gotMethod != "PROPFIND" || gotDepth != "1" {
+		t.Errorf("remote saw method %q depth %q, want PROPFIND depth 1", gotMethod, gotDepth)
+	}
+}
+
+func TestAccess_ForwardsExtraHeaders(t *testing.T) {
+	t.Parallel()
+
+	var gotRange, gotAuth string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sharedSecretDiscoveryHandler(w, r) {
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/webdav/ocm/") {
+			gotRange = r.Header.Get("Range")
+			gotAuth = r.Header.Get("Authorization")
+
+			w.WriteHeader(http.StatusPartialContent)
+
+			return
+		}
+
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	client := newExchangeAccessClient(t, srv)
+
+	result, err := client.Access(context.Background(), AccessOptions{
+		Share: &ShareInfo{
+			Status:       "accepted",
+			SenderHost:   srv.URL,
+			SharedSecret: "shared-secret",
+			WebDAVID:     "file-123",
+		},
+		Protocol: "webdav",
+		Method:   "GET",
+		Header:   http.Header{"Range": {"bytes=10-"}, "Authorization": {"Bearer injected"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer tshttp.MustClose(t, result.Response.Body)
+
+	if gotRange != "bytes=10-" {
+		t.Errorf("remote saw Range %q, want bytes=10-", gotRange)
+	}
+
+	if gotAuth != "Bearer shared-secret" {
+		t.Errorf("remote saw Authorization %q, want the share bearer", gotAuth)
+	}
+}
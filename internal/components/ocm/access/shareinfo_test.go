@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package access
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+)
+
+func TestShareInfoFromIncoming(t *testing.T) {
+	t.Parallel()
+
+	share := &sharesincoming.IncomingShare{
+		ShareID:           "share-1",
+		Status:            shares.ShareStatusAccepted,
+		SenderHost:        "sender.example.com",
+		OwnerHost:         "owner.example.com",
+		SharedSecret:      "webdav-secret",
+		ProtocolName:      "multi",
+		Requirements:      []string{"must-exchange-token"},
+		WebDAVID:          "file-1",
+		WebappURI:         "https://sender.example.com/apps/file-1",
+		WebappTargets:     []string{"edit"},
+		WebappPermissions: []string{"view"},
+		WebappSecret:      "webapp-secret",
+	}
+
+	want := &ShareInfo{
+		ShareID:            "share-1",
+		Status:             "accepted",
+		SenderHost:         "sender.example.com",
+		OwnerHost:          "owner.example.com",
+		SharedSecret:       "webdav-secret",
+		ProtocolName:       "multi",
+		Requirements:       []string{"must-exchange-token"},
+		WebDAVID:           "file-1",
+		WebappURI:          "https://sender.example.com/apps/file-1",
+		WebappTargets:      []string{"edit"},
+		WebappPermissions:  []string{"view"},
+		WebappSharedSecret: "webapp-secret",
+	}
+
+	if got := ShareInfoFromIncoming(share); !reflect.DeepEqual(got, want) {
+		t.Errorf("ShareInfoFromIncoming = %+v, want %+v", got, want)
+	}
+}
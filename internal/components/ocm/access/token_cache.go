@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package access
+
+import (
+	"sync"
+	"time"
+
+	tokenoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token/outgoing"
+)
+
+const (
+	// tokenCacheSkew is taken off expires_in so a cached token is not sent in
+	// the last moments of its lifetime.
+	tokenCacheSkew = 30 * time.Second
+
+	// tokenCacheMaxEntries bounds the cache; a full cache sweeps entries
+	// whose access token has lapsed before adding another.
+	tokenCacheMaxEntries = 10000
+)
+
+// cachedToken is one exchanged token pair for an incoming share.
+type cachedToken struct {
+	accessToken   string
+	refreshToken  string
+	tokenEndPoint string
+	expiresAt     time.Time
+}
+
+// tokenCache holds exchanged tokens per incoming share in process memory so
+// repeated access reuses a token until its expires_in lapses. Entries keep
+// the refresh token past access expiry so the next access can refresh
+// instead of exchanging the shared secret again.
+type tokenCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedToken
+	now     func() time.Time
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{
+		entries: make(map[string]cachedToken),
+		now:     time.Now,
+	}
+}
+
+// get returns the entry for shareID when it was issued by tokenEndPoint.
+func (c *tokenCache) get(shareID, tokenEndPoint string) (cachedToken, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[shareID]
+	if !ok || entry.tokenEndPoint != tokenEndPoint {
+		return cachedToken{}, false
+	}
+
+	return entry, true
+}
+
+// valid reports whether the entry's access token is still usable.
+func (c *tokenCache) valid(entry cachedToken) bool {
+	return c.now().Before(entry.expiresAt)
+}
+
+func (c *tokenCache) put(shareID, tokenEndPoint string, result *tokenoutgoing.ExchangeResult) {
+	lifetime := time.Duration(result.ExpiresIn) * time.Second
+	lifetime -= min(tokenCacheSkew, lifetime/2)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	if _, exists := c.entries[shareID]; !exists && len(c.entries) >= tokenCacheMaxEntries {
+		for id, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+
+		if len(c.entries) >= tokenCacheMaxEntries {
+			return
+		}
+	}
+
+	c.entries[shareID] = cachedToken{
+		accessToken:   result.AccessToken,
+		refreshToken:  result.RefreshToken,
+		tokenEndPoint: tokenEndPoint,
+		expiresAt:     now.Add(lifetime),
+	}
+}
+
+func (c *tokenCache) drop(shareID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, shareID)
+}
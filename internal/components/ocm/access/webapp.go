@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	tokenoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token/outgoing"
+)
+
+// ErrWebappNotOffered reports an open-in-remote-app request for a share
+// without a webapp arm.
+var ErrWebappNotOffered = errors.New("share does not offer a webapp")
+
+// WebappLaunch is what a receiver's browser needs to open a share in the
+// sender's web application: the viewer URL plus a freshly exchanged bearer.
+type WebappLaunch struct {
+	URL         string
+	Targets     []string
+	Permissions []string
+	AccessToken string
+	ExpiresIn   int
+}
+
+// WebappOpener is the interface for opening shares in the remote webapp;
+// extracted for mocks.
+type WebappOpener interface {
+	OpenWebapp(ctx context.Context, share *ShareInfo) (*WebappLaunch, error)
+}
+
+// OpenWebapp exchanges the webapp arm's shared secret for a short-lived access
+// token at the owner's token endpoint. Webapp arms sent by this server always
+// carry must-exchange-token, so the legacy shared-secret fallback of the
+// WebDAV path is never used here. The token is not cached: every launch gets
+// its own.
+func (c *Client) OpenWebapp(ctx context.Context, share *ShareInfo) (*WebappLaunch, error) {
+	if share == nil || share.Status != ShareStatusAccepted {
+		return nil, ErrShareNotAccepted
+	}
+
+	if share.WebappURI == "" {
+		return nil, ErrWebappNotOffered
+	}
+
+	if u, err := url.Parse(share.WebappURI); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, reason.NewClassifiedError(
+			reason.ReasonProtocolMismatch,
+			"webapp uri must be an absolute http(s) URL",
+			err,
+		)
+	}
+
+	origin := c.resolvePeerOrigin(accessHostForDiscovery(share))
+
+	disc, err := c.discoveryClient.Discover(ctx, origin.baseURL)
+	if err != nil {
+		return nil, reason.NewClassifiedError(
+			reason.ReasonDiscoveryFailed,
+			"failed to discover owner",
+			err,
+		)
+	}
+
+	if !disc.SupportsTokenExchange() || c.tokenClient == nil {
+		return nil, ErrTokenExchangeRequired
+	}
+
+	if err := c.checkSignaturePolicy(disc); err != nil {
+		return nil, err
+	}
+
+	secret := share.WebappSharedSecret
+	if secret == "" {
+		secret = share.SharedSecret
+	}
+
+	result, err := c.tokenClient.Exchange(ctx, tokenoutgoing.ExchangeRequest{
+		TokenEndPoint: disc.TokenEndPoint,
+		SharedSecret:  secret,
+	}, disc)
+	if err != nil {
+		return nil, fmt.Errorf("ocm: exchange webapp token: %w", err)
+	}
+
+	return &WebappLaunch{
+		URL:         share.WebappURI,
+		Targets:     slices.Clone(share.WebappTargets),
+		Permissions: slices.Clone(share.WebappPermissions),
+		AccessToken: result.AccessToken,
+		ExpiresIn:   result.ExpiresIn,
+	}, nil
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package access
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+)
+
+func webappShareInfo(senderHost string) *ShareInfo {
+	return &ShareInfo{
+		ShareID:            "share-1",
+		Status:             ShareStatusAccepted,
+		SenderHost:         senderHost,
+		SharedSecret:       "webdav-secret",
+		WebDAVID:           "file-123",
+		WebappURI:          "https://viewer.example.org/open/file-123",
+		WebappTargets:      []string{"blank"},
+		WebappPermissions:  []string{"view"},
+		WebappSharedSecret: "webapp-secret",
+	}
+}
+
+func TestOpenWebapp_ExchangesWebappSecret(t *testing.T) {
+	t.Parallel()
+
+	var gotSecret atomic.Value
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ocm/token" {
+			if err := r.ParseForm(); err == nil {
+				gotSecret.Store(r.PostForm.Get("code"))
+			}
+		}
+
+		if exchangeDiscoveryHandler(t, w, r, "webapp-token") {
+			return
+		}
+
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	client := newExchangeAccessClient(t, srv)
+
+	launch, err := client.OpenWebapp(context.Background(), webappShareInfo(srv.URL))
+	if err != nil {
+		t.Fatalf("OpenWebapp: %v", err)
+	}
+
+	if launch.URL != "https://viewer.example.org/open/file-123" || launch.AccessToken != "webapp-token" || launch.ExpiresIn != 3600 {
+		t.Errorf("launch = %+v", launch)
+	}
+
+	if !slices.Equal(launch.Targets, []string{"blank"}) || !slices.Equal(launch.Permissions, []string{"view"}) {
+		t.Errorf("launch targets/permissions = %v/%v", launch.Targets, launch.Permissions)
+	}
+
+	if got, _ := gotSecret.Load().(string); got != "webapp-secret" {
+		t.Errorf("exchanged secret = %q, want the webapp arm's secret", got)
+	}
+}
+
+func TestOpenWebapp_FallsBackToWebDAVSecret(t *testing.T) {
+	t.Parallel()
+
+	var gotSecret atomic.Value
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ocm/token" {
+			if err := r.ParseForm(); err == nil {
+				gotSecret.Store(r.PostForm.Get("code"))
+			}
+		}
+
+		if exchangeDiscoveryHandler(t, w, r, "webapp-token") {
+			return
+		}
+
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	share := webappShareInfo(srv.URL)
+	share.WebappSharedSecret = ""
+
+	if _, err := newExchangeAccessClient(t, srv).OpenWebapp(context.Background(), share); err != nil {
+		t.Fatalf("OpenWebapp: %v", err)
+	}
+
+	if got, _ := gotSecret.Load().(string); got != "webdav-secret" {
+		t.Errorf("exchanged secret = %q, want the webdav secret", got)
+	}
+}
+
+func TestOpenWebapp_RejectsBeforeDiscovery(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(nil, &discovery.Client{}, nil, nil)
+
+	notAccepted := webappShareInfo("sender.example.com")
+	notAccepted.Status = "pending"
+
+	noWebapp := webappShareInfo("sender.example.com")
+	noWebapp.WebappURI = ""
+
+	badURI := webappShareInfo("sender.example.com")
+	badURI.WebappURI = "javascript:alert(1)"
+
+	tests := []struct {
+		name  string
+		share *ShareInfo
+		check func(error) bool
+	}{
+		{"nil share", nil, func(err error) bool { return errors.Is(err, ErrShareNotAccepted) }},
+		{"not accepted", notAccepted, func(err error) bool { return errors.Is(err, ErrShareNotAccepted) }},
+		{"no webapp arm", noWebapp, func(err error) bool { return errors.Is(err, ErrWebappNotOffered) }},
+		{"non-http uri", badURI, func(err error) bool {
+			var ce *reason.ClassifiedError
+
+			return errors.As(err, &ce) && ce.ReasonCode == reason.ReasonProtocolMismatch
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := client.OpenWebapp(context.Background(), tt.share); !tt.check(err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestOpenWebapp_RequiresOwnerTokenExchange(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestDiscoveryServer()
+	defer srv.Close()
+
+	client := newExchangeAccessClient(t, srv)
+
+	_, err := client.OpenWebapp(context.Background(), webappShareInfo(srv.URL))
+	if !errors.Is(err, ErrTokenExchangeRequired) {
+		t.Fatalf("expected ErrTokenExchangeRequired, got %v", err)
+	}
+}
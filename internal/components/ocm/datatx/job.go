@@ -0,0 +1,673 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package datatx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/digest"
+)
+
+const (
+	// stagingDirName holds in-flight transfers inside the storage root, so
+	// the final rename never crosses file systems.
+	stagingDirName = ".ocm-transfers"
+
+	// maxFiles and maxDepth bound what a single folder share may unpack.
+	maxFiles = 10000
+	maxDepth = 32
+
+	// maxNameConflicts bounds the "name (n)" suffixes tried at the destination.
+	maxNameConflicts = 100
+)
+
+var (
+	errChecksumMismatch = errors.New("datatx: checksum mismatch")
+	errShortBody        = errors.New("datatx: download ended before the announced size")
+	// errRestart means the partial download cannot be resumed and must start
+	// over from the first byte.
+	errRestart = errors.New("datatx: cannot resume partial download")
+)
+
+// entry is one file or directory of the shared resource; rel is
+// slash-separated and empty for a file share itself.
+type entry struct {
+	rel  string
+	dir  bool
+	size int64
+}
+
+// job is a single attempt of a transfer.
+type job struct {
+	m        *Manager
+	t        *Transfer
+	share    *access.ShareInfo
+	folder   bool
+	staging  *os.Root
+	lastSave time.Time
+}
+
+// transfer runs one attempt: list the remote resource, download what is
+// missing into the staging area, then move the result into place.
+func (m *Manager) transfer(ctx context.Context, t *Transfer) error {
+	share, err := m.shares.GetByIDForRecipientUserID(ctx, t.ShareID, t.RecipientUserID)
+	if err != nil {
+		if errors.Is(err, sharesincoming.ErrShareNotFound) {
+			return permanent(fmt.Errorf("datatx: share is gone: %w", err))
+		}
+
+		return fmt.Errorf("datatx: load share: %w", err)
+	}
+
+	if share.Status != shares.ShareStatusAccepted {
+		return permanent(fmt.Errorf("datatx: share is %s, not accepted", share.Status))
+	}
+
+	root, err := m.storageRoot(ctx, t.RecipientUserID)
+	if err != nil {
+		return err
+	}
+
+	stagingDir := filepath.Join(root, stagingDirName, t.ShareID)
+
+	// A previous attempt may have moved the copy into place and stopped
+	// before recording it.
+	if t.Destination != "" {
+		if _, err := os.Lstat(filepath.Join(stagingDir, "data")); errors.Is(err, fs.ErrNotExist) {
+			if _, err := os.Lstat(t.Destination); err == nil {
+				return m.cleanupStaging(root, stagingDir)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(stagingDir, 0o700); err != nil {
+		return fmt.Errorf("datatx: create staging directory: %w", err)
+	}
+
+	staging, err := os.OpenRoot(stagingDir)
+	if err != nil {
+		return fmt.Errorf("datatx: open staging directory: %w", err)
+	}
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		staging.Close()
+	}()
+
+	j := &job{
+		m:       m,
+		t:       t,
+		share:   access.ShareInfoFromIncoming(share),
+		folder:  share.ResourceType == spec.ResourceTypeFolder,
+		staging: staging,
+	}
+
+	if err := j.download(ctx); err != nil {
+		return err
+	}
+
+	return m.finalize(ctx, t, share, root, stagingDir)
+}
+
+// storageRoot returns the recipient's storage root, creating it if needed.
+func (m *Manager) storageRoot(ctx context.Context, userID string) (string, error) {
+	user, err := m.parties.Get(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("datatx: load recipient: %w", err)
+	}
+
+	root := user.StorageRoot
+	if root == "" {
+		root = filepath.Join(m.storageDir, userID)
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return "", fmt.Errorf("datatx: create storage root: %w", err)
+	}
+
+	return root, nil
+}
+
+// finalize moves the downloaded copy to its destination in root and removes
+// the staging area.
+func (m *Manager) finalize(ctx context.Context, t *Transfer, share *sharesincoming.IncomingShare, root, stagingDir string) error {
+	dest, err := uniquePath(root, safeName(share), share.ResourceType == spec.ResourceTypeFolder)
+	if err != nil {
+		return err
+	}
+
+	// Record the destination first so a crash between the rename and the
+	// completion write is recognized on resume.
+	t.Destination = dest
+	t.UpdatedAt = m.now()
+
+	if err := m.repo.Update(ctx, t); err != nil {
+		return fmt.Errorf("datatx: record destination: %w", err)
+	}
+
+	if err := os.Rename(filepath.Join(stagingDir, "data"), dest); err != nil {
+		return fmt.Errorf("datatx: move transfer into place: %w", err)
+	}
+
+	return m.cleanupStaging(root, stagingDir)
+}
+
+func (m *Manager) cleanupStaging(root, stagingDir string) error {
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return fmt.Errorf("datatx: remove staging directory: %w", err)
+	}
+
+	// Drop the staging parent once no transfer uses it.
+	//nolint:errcheck // fails while other transfers are staged, which is fine
+	os.Remove(filepath.Join(root, stagingDirName))
+
+	return nil
+}
+
+// download lists the remote resource and fetches every file not yet staged.
+func (j *job) download(ctx context.Context) error {
+	entries := []entry{{size: -1}}
+
+	if j.folder {
+		var err error
+
+		entries, err = j.listFolder(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := j.tally(entries); err != nil {
+		return err
+	}
+
+	if err := j.checkSize(j.t.BytesTotal); err != nil {
+		return err
+	}
+
+	if err := j.save(ctx, true); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if e.dir {
+			continue
+		}
+
+		if err := j.fetch(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	return j.save(ctx, true)
+}
+
+// tally creates the staged directory tree and recounts progress from what
+// earlier attempts left on disk.
+func (j *job) tally(entries []entry) error {
+	t := j.t
+	t.BytesTotal, t.BytesDone, t.FilesTotal, t.FilesDone = 0, 0, 0, 0
+
+	if j.folder {
+		if err := j.staging.MkdirAll("data", 0o700); err != nil {
+			return fmt.Errorf("datatx: create staged folder: %w", err)
+		}
+	}
+
+	for _, e := range entries {
+		if e.dir {
+			if err := j.staging.MkdirAll(j.dataPath(e), 0o700); err != nil {
+				return fmt.Errorf("datatx: create staged folder: %w", err)
+			}
+
+			continue
+		}
+
+		t.FilesTotal++
+		t.BytesTotal += max(e.size, 0)
+
+		if info, err := j.staging.Stat(j.dataPath(e)); err == nil {
+			t.FilesDone++
+			t.BytesDone += info.Size()
+
+			if e.size < 0 {
+				t.BytesTotal += info.Size()
+			}
+
+			continue
+		}
+
+		if info, err := j.staging.Stat(j.partPath(e)); err == nil {
+			t.BytesDone += info.Size()
+		}
+	}
+
+	return nil
+}
+
+func (j *job) dataPath(e entry) string {
+	return path.Join("data", e.rel)
+}
+
+func (j *job) partPath(e entry) string {
+	if e.rel == "" {
+		return "parts/file"
+	}
+
+	return path.Join("parts", e.rel)
+}
+
+// fetch downloads one file into its part file, resuming from whatever an
+// earlier attempt stored, and moves it into the staged tree once complete
+// and verified.
+func (j *job) fetch(ctx context.Context, e entry) error {
+	if _, err := j.staging.Stat(j.dataPath(e)); err == nil {
+		return nil
+	}
+
+	if err := j.staging.MkdirAll(path.Dir(j.partPath(e)), 0o700); err != nil {
+		return fmt.Errorf("datatx: create parts directory: %w", err)
+	}
+
+	err := j.fetchPart(ctx, e)
+	if errors.Is(err, errRestart) {
+		if err := j.discardPart(e); err != nil {
+			return err
+		}
+
+		err = j.fetchPart(ctx, e)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := j.staging.MkdirAll(path.Dir(j.dataPath(e)), 0o700); err != nil {
+		return fmt.Errorf("datatx: create staged folder: %w", err)
+	}
+
+	if err := j.staging.Rename(j.partPath(e), j.dataPath(e)); err != nil {
+		return fmt.Errorf("datatx: stage %s: %w", displayName(e), err)
+	}
+
+	j.t.FilesDone++
+
+	return j.save(ctx, false)
+}
+
+// fetchPart completes the part file of e with a ranged GET and verifies it.
+func (j *job) fetchPart(ctx context.Context, e entry) error {
+	var offset int64
+	if info, err := j.staging.Stat(j.partPath(e)); err == nil {
+		offset = info.Size()
+	}
+
+	header := http.Header{}
+	digest.RequestSHA256(header)
+
+	if offset > 0 {
+		header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	resp, err := j.access(ctx, http.MethodGet, e.rel, "", header)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		resp.Body.Close()
+	}()
+
+	total := resp.ContentLength
+	flags := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		// The server ignored the range: start over.
+		flags |= os.O_TRUNC
+		j.t.BytesDone -= offset
+		offset = 0
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		start, size, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			return errRestart
+		}
+
+		total = size
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		return errRestart
+	default:
+		return statusError("download "+displayName(e), resp.StatusCode)
+	}
+
+	if e.size < 0 && total >= 0 {
+		j.t.BytesTotal += total
+		e.size = total
+	}
+
+	if err := j.checkSize(j.t.BytesTotal); err != nil {
+		return err
+	}
+
+	if err := j.writePart(ctx, e, flags, resp.Body); err != nil {
+		return err
+	}
+
+	size, err := j.partSize(e)
+	if err != nil {
+		return err
+	}
+
+	if total >= 0 && size != total {
+		return fmt.Errorf("%w: %s has %d of %d bytes", errShortBody, displayName(e), size, total)
+	}
+
+	return j.verify(e, resp.Header)
+}
+
+func (j *job) writePart(ctx context.Context, e entry, flags int, body io.Reader) error {
+	f, err := j.staging.OpenFile(j.partPath(e), flags, 0o600)
+	if err != nil {
+		return fmt.Errorf("datatx: open part file: %w", err)
+	}
+
+	// Read one byte past the limit so a sender that sends more than it
+	// announced is caught.
+	limited := io.LimitReader(body, max(j.m.policy.MaxBytes-j.t.BytesDone, 0)+1)
+
+	_, copyErr := io.Copy(f, &progressReader{r: limited, onRead: func(n int) {
+		j.t.BytesDone += int64(n)
+
+		//nolint:errcheck // progress writes are best effort; the final write reports errors
+		j.save(ctx, false)
+	}})
+
+	if err := f.Close(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+
+	if copyErr != nil {
+		return fmt.Errorf("datatx: download %s: %w", displayName(e), copyErr)
+	}
+
+	return j.checkSize(j.t.BytesDone)
+}
+
+// checkSize fails the transfer once size exceeds the byte limit.
+func (j *job) checkSize(size int64) error {
+	if size > j.m.policy.MaxBytes {
+		return permanent(fmt.Errorf("datatx: transfer exceeds the limit of %d bytes", j.m.policy.MaxBytes))
+	}
+
+	return nil
+}
+
+func (j *job) partSize(e entry) (int64, error) {
+	info, err := j.staging.Stat(j.partPath(e))
+	if err != nil {
+		return 0, fmt.Errorf("datatx: stat part file: %w", err)
+	}
+
+	return info.Size(), nil
+}
+
+// verify checks the part file of e against the sender's SHA-256, when the
+// sender offered one. A mismatch discards the part so the next attempt
+// downloads it from scratch.
+func (j *job) verify(e entry, h http.Header) error {
+	want, ok, err := digest.SHA256(h)
+	if err != nil {
+		j.m.log.Warn("datatx: ignoring malformed checksum", "share_id", j.t.ShareID, "file", displayName(e), "error", err)
+
+		return nil
+	}
+
+	if !ok {
+		return nil
+	}
+
+	f, err := j.staging.Open(j.partPath(e))
+	if err != nil {
+		return fmt.Errorf("datatx: open part file: %w", err)
+	}
+
+	got, err := digest.SumSHA256(f)
+
+	//nolint:errcheck // read-only handle; close errors are not actionable
+	f.Close()
+
+	if err != nil {
+		return fmt.Errorf("datatx: checksum %s: %w", displayName(e), err)
+	}
+
+	if bytes.Equal(got, want) {
+		return nil
+	}
+
+	if err := j.discardPart(e); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", errChecksumMismatch, displayName(e))
+}
+
+// discardPart removes the part file of e and its bytes from the progress.
+func (j *job) discardPart(e entry) error {
+	if size, err := j.partSize(e); err == nil {
+		j.t.BytesDone -= size
+	}
+
+	if err := j.staging.Remove(j.partPath(e)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("datatx: remove part file: %w", err)
+	}
+
+	return nil
+}
+
+// listFolder walks the shared folder breadth-first with Depth 1 PROPFINDs.
+func (j *job) listFolder(ctx context.Context) ([]entry, error) {
+	var entries []entry
+
+	files := 0
+	pending := []string{""}
+
+	for len(pending) > 0 {
+		dir := pending[0]
+		pending = pending[1:]
+
+		resp, err := j.access(ctx, "PROPFIND", dir, "1", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		children, err := readChildren(resp)
+
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		resp.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range children {
+			rel := path.Join(dir, child.rel)
+
+			if strings.Count(rel, "/") >= maxDepth {
+				return nil, permanent(fmt.Errorf("datatx: folder is nested deeper than %d levels", maxDepth))
+			}
+
+			entries = append(entries, entry{rel: rel, dir: child.dir, size: child.size})
+
+			if child.dir {
+				pending = append(pending, rel)
+
+				continue
+			}
+
+			if files++; files > maxFiles {
+				return nil, permanent(fmt.Errorf("datatx: folder holds more than %d files", maxFiles))
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// access sends one WebDAV request for rel below the shared resource and
+// classifies failures: refusals are permanent, everything else is retried.
+func (j *job) access(ctx context.Context, method, rel, depth string, header http.Header) (*http.Response, error) {
+	result, err := j.m.accessor.Access(ctx, access.AccessOptions{
+		Share:    j.share,
+		Protocol: access.ProtocolWebDAV,
+		Method:   method,
+		SubPath:  access.EscapeSubPath(rel),
+		Depth:    depth,
+		Header:   header,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("datatx: %s %s: %w", method, displayName(entry{rel: rel}), err)
+	}
+
+	return result.Response, nil
+}
+
+// save persists progress; unless forced, at most once per ProgressInterval.
+func (j *job) save(ctx context.Context, force bool) error {
+	now := j.m.now()
+	if !force && now.Sub(j.lastSave) < j.m.policy.ProgressInterval {
+		return nil
+	}
+
+	j.lastSave = now
+	j.t.UpdatedAt = now
+
+	if err := j.m.repo.Update(ctx, j.t); err != nil {
+		return fmt.Errorf("datatx: record progress: %w", err)
+	}
+
+	return nil
+}
+
+// statusError maps a WebDAV response status to an error; statuses that mean
+// the share is no longer readable are permanent.
+func statusError(op string, status int) error {
+	err := fmt.Errorf("datatx: %s: remote returned %d", op, status)
+
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusGone:
+		return permanent(err)
+	default:
+		return err
+	}
+}
+
+// parseContentRange parses "bytes start-end/size"; size is -1 when "*".
+func parseContentRange(v string) (start, size int64, ok bool) {
+	ranges, found := strings.CutPrefix(v, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+
+	rng, sizeStr, found := strings.Cut(ranges, "/")
+	if !found {
+		return 0, 0, false
+	}
+
+	startStr, _, found := strings.Cut(rng, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	if sizeStr == "*" {
+		return start, -1, true
+	}
+
+	size, err = strconv.ParseInt(sizeStr, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return start, size, true
+}
+
+func displayName(e entry) string {
+	if e.rel == "" {
+		return "shared file"
+	}
+
+	return strconv.Quote(e.rel)
+}
+
+// safeName returns the share name when it is usable as a single, visible
+// path element, and "share-{shareId}" otherwise. Hidden names are refused so
+// a share can never land on the staging directory.
+func safeName(share *sharesincoming.IncomingShare) string {
+	name := strings.TrimSpace(share.Name)
+	if name == "" || name == "." || name == ".." || strings.HasPrefix(name, ".") ||
+		strings.ContainsAny(name, "/\\\x00") {
+		return "share-" + share.ShareID
+	}
+
+	return name
+}
+
+// uniquePath returns dir/name, or dir/"name (n).ext" when that is taken.
+func uniquePath(dir, name string, isDir bool) (string, error) {
+	stem, ext := name, ""
+	if !isDir {
+		ext = filepath.Ext(name)
+		stem = strings.TrimSuffix(name, ext)
+	}
+
+	for n := range maxNameConflicts {
+		candidate := name
+		if n > 0 {
+			candidate = fmt.Sprintf("%s (%d)%s", stem, n, ext)
+		}
+
+		p := filepath.Join(dir, candidate)
+		if _, err := os.Lstat(p); errors.Is(err, fs.ErrNotExist) {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("datatx: no free name for %q in the storage root", name)
+}
+
+// progressReader reports every successful read to onRead.
+type progressReader struct {
+	r      io.Reader
+	onRead func(n int)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.onRead(n)
+	}
+
+	return n, err //nolint:wrapcheck // io.Reader contract: io.EOF must reach io.Copy unwrapped
+}
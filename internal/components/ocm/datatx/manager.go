@@ -0,0 +1,372 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package datatx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// Policy defaults.
+const (
+	DefaultMaxConcurrent    = 2
+	DefaultMaxAttempts      = 5
+	DefaultInitialBackoff   = 10 * time.Second
+	DefaultMaxBackoff       = 10 * time.Minute
+	DefaultProgressInterval = time.Second
+	DefaultMaxBytes         = 10 << 30 // 10 GiB
+)
+
+// errNotDataTx rejects Enqueue for shares that did not ask for datatx.
+var errNotDataTx = errors.New("datatx: share does not carry the datatx access type")
+
+// Policy controls concurrency and retry pacing. Zero fields fall back to the
+// defaults.
+type Policy struct {
+	// MaxConcurrent caps how many transfers download at once.
+	MaxConcurrent int
+	// MaxAttempts is the number of attempts before a transfer fails.
+	MaxAttempts int
+	// InitialBackoff is the delay after the first failed attempt; each further
+	// failure doubles it up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// ProgressInterval is the minimum time between progress writes.
+	ProgressInterval time.Duration
+	// MaxBytes caps the size of one transfer, checked against the sizes the
+	// sender announces and against the bytes actually written.
+	MaxBytes int64
+}
+
+// withDefaults returns p with zero fields replaced by the defaults.
+func (p Policy) withDefaults() Policy {
+	if p.MaxConcurrent <= 0 {
+		p.MaxConcurrent = DefaultMaxConcurrent
+	}
+
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultMaxAttempts
+	}
+
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultInitialBackoff
+	}
+
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = max(DefaultMaxBackoff, p.InitialBackoff)
+	}
+
+	if p.ProgressInterval <= 0 {
+		p.ProgressInterval = DefaultProgressInterval
+	}
+
+	if p.MaxBytes <= 0 {
+		p.MaxBytes = DefaultMaxBytes
+	}
+
+	return p
+}
+
+// backoff returns the delay before the next attempt once attempts attempts
+// have failed.
+func (p Policy) backoff(attempts int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempts && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxBackoff)
+}
+
+// Manager runs datatx transfers in the background. Transfers left queued or
+// running by an earlier process are resumed on Start.
+type Manager struct {
+	repo       Repo
+	shares     sharesincoming.IncomingShareRepo
+	parties    identity.PartyRepo
+	accessor   access.RemoteAccessor
+	storageDir string
+	policy     Policy
+	log        *slog.Logger
+	now        func() time.Time
+
+	mu        sync.Mutex
+	inflight  map[string]struct{}
+	notBefore map[string]time.Time
+
+	kick   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+	jobs   sync.WaitGroup
+}
+
+// NewManager returns a Manager; call Start to run transfers. Recipients
+// without a storage root receive transfers under storageDir/{userId}.
+func NewManager(
+	repo Repo,
+	shares sharesincoming.IncomingShareRepo,
+	parties identity.PartyRepo,
+	accessor access.RemoteAccessor,
+	storageDir string,
+	policy Policy,
+	log *slog.Logger,
+) *Manager {
+	return &Manager{
+		repo:       repo,
+		shares:     shares,
+		parties:    parties,
+		accessor:   accessor,
+		storageDir: storageDir,
+		policy:     policy.withDefaults(),
+		log:        logutil.NoopIfNil(log),
+		now:        time.Now,
+		inflight:   make(map[string]struct{}),
+		notBefore:  make(map[string]time.Time),
+		kick:       make(chan struct{}, 1),
+	}
+}
+
+// Enqueue records a queued transfer for an accepted datatx share and wakes
+// the worker. Enqueueing a share that already has a transfer is a no-op.
+func (m *Manager) Enqueue(ctx context.Context, share *sharesincoming.IncomingShare) error {
+	if !slices.Contains(share.WebDAVAccessTypes, spec.AccessTypeDataTx) {
+		return errNotDataTx
+	}
+
+	now := m.now()
+
+	err := m.repo.Create(ctx, &Transfer{
+		ShareID:         share.ShareID,
+		RecipientUserID: share.RecipientUserID,
+		Status:          StatusQueued,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	})
+	if err != nil && !errors.Is(err, ErrTransferExists) {
+		return fmt.Errorf("datatx: persist transfer: %w", err)
+	}
+
+	m.wake()
+
+	return nil
+}
+
+// Get returns the transfer of shareID when it belongs to recipientUserID.
+func (m *Manager) Get(ctx context.Context, shareID, recipientUserID string) (*Transfer, error) {
+	t, err := m.repo.Get(ctx, shareID)
+	if err != nil {
+		return nil, fmt.Errorf("datatx: get transfer: %w", err)
+	}
+
+	if t.RecipientUserID != recipientUserID {
+		return nil, ErrTransferNotFound
+	}
+
+	return t, nil
+}
+
+// Start runs the scheduler until Close.
+func (m *Manager) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+
+	go m.run(ctx)
+}
+
+// Close stops the scheduler and waits for running transfers to stop. Their
+// partial downloads stay on disk and resume on the next Start. Safe to call
+// when the scheduler never started.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	cancel, done := m.cancel, m.done
+	m.cancel = nil
+	m.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	<-done
+
+	return nil
+}
+
+func (m *Manager) run(ctx context.Context) {
+	defer close(m.done)
+	defer m.jobs.Wait()
+
+	for {
+		if err := m.schedule(ctx); err != nil && ctx.Err() == nil {
+			m.log.Warn("datatx scheduling pass failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.kick:
+		}
+	}
+}
+
+// schedule starts queued and interrupted transfers while slots are free.
+func (m *Manager) schedule(ctx context.Context) error {
+	transfers, err := m.repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("datatx: list transfers: %w", err)
+	}
+
+	slices.SortFunc(transfers, func(a, b *Transfer) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	for _, t := range transfers {
+		if t.Status != StatusQueued && t.Status != StatusRunning {
+			continue
+		}
+
+		if !m.claim(t.ShareID) {
+			continue
+		}
+
+		m.jobs.Add(1)
+
+		go m.execute(ctx, t)
+	}
+
+	return nil
+}
+
+func (m *Manager) wake() {
+	select {
+	case m.kick <- struct{}{}:
+	default:
+	}
+}
+
+// claim reserves a slot for shareID unless it is already running, waiting
+// out a backoff, or every slot is taken.
+func (m *Manager) claim(shareID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, busy := m.inflight[shareID]; busy || len(m.inflight) >= m.policy.MaxConcurrent {
+		return false
+	}
+
+	if m.now().Before(m.notBefore[shareID]) {
+		return false
+	}
+
+	m.inflight[shareID] = struct{}{}
+
+	return true
+}
+
+// release frees the slot of shareID; a non-zero retryAt holds the transfer
+// back until then.
+func (m *Manager) release(shareID string, retryAt time.Time) {
+	m.mu.Lock()
+	delete(m.inflight, shareID)
+
+	if retryAt.IsZero() {
+		delete(m.notBefore, shareID)
+	} else {
+		m.notBefore[shareID] = retryAt
+		time.AfterFunc(retryAt.Sub(m.now()), m.wake)
+	}
+	m.mu.Unlock()
+
+	m.wake()
+}
+
+// execute runs one attempt of t and records its outcome.
+func (m *Manager) execute(ctx context.Context, t *Transfer) {
+	defer m.jobs.Done()
+
+	var retryAt time.Time
+
+	defer func() { m.release(t.ShareID, retryAt) }()
+
+	t.Status = StatusRunning
+	t.Attempts++
+	t.LastError = ""
+	t.UpdatedAt = m.now()
+
+	if err := m.repo.Update(ctx, t); err != nil {
+		m.log.Error("datatx: mark transfer running", "share_id", t.ShareID, "error", err)
+
+		return
+	}
+
+	m.log.Info("datatx transfer started", "share_id", t.ShareID, "attempt", t.Attempts)
+
+	err := m.transfer(ctx, t)
+
+	// A shutdown leaves the transfer running so the next Start resumes it;
+	// the final write must outlive the cancelled context.
+	saveCtx := context.WithoutCancel(ctx)
+	now := m.now()
+	t.UpdatedAt = now
+
+	var permanent *permanentError
+
+	switch {
+	case ctx.Err() != nil:
+	case err == nil:
+		t.Status = StatusCompleted
+		t.CompletedAt = &now
+
+		m.log.Info("datatx transfer completed",
+			"share_id", t.ShareID, "bytes", t.BytesDone, "files", t.FilesDone, "destination", t.Destination)
+	case errors.As(err, &permanent) || t.Attempts >= m.policy.MaxAttempts:
+		t.Status = StatusFailed
+		t.LastError = err.Error()
+
+		m.log.Warn("datatx transfer failed", "share_id", t.ShareID, "attempts", t.Attempts, "error", err)
+	default:
+		t.Status = StatusQueued
+		t.LastError = err.Error()
+		retryAt = now.Add(m.policy.backoff(t.Attempts))
+
+		m.log.Info("datatx attempt failed, will retry",
+			"share_id", t.ShareID, "attempts", t.Attempts, "next_attempt_at", retryAt, "error", err)
+	}
+
+	if err := m.repo.Update(saveCtx, t); err != nil {
+		m.log.Error("datatx: record transfer outcome", "share_id", t.ShareID, "error", err)
+	}
+}
+
+// permanentError marks failures that retrying cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
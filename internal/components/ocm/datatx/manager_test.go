@@ -0,0 +1,542 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package datatx_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/webdav"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/digest"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+)
+
+// remote is a sender serving its export directory over WebDAV at
+// /dav/{webdavId}, with a Repr-Digest on GET when asked for one.
+type remote struct {
+	dir    string
+	server *httptest.Server
+
+	mu     sync.Mutex
+	ranges []string
+	// abortAfter cuts the body of the next GET after that many bytes.
+	abortAfter int
+	// badDigest answers every GET with a wrong checksum.
+	badDigest bool
+	// chunked streams GET bodies without a Content-Length.
+	chunked bool
+}
+
+func newRemote(t *testing.T) *remote {
+	t.Helper()
+
+	r := &remote{dir: t.TempDir()}
+	dav := &webdav.Handler{Prefix: "/dav", FileSystem: webdav.Dir(r.dir), LockSystem: webdav.NewMemLS()}
+
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			dav.ServeHTTP(w, req)
+
+			return
+		}
+
+		r.mu.Lock()
+		r.ranges = append(r.ranges, req.Header.Get("Range"))
+		abortAfter, badDigest, chunked := r.abortAfter, r.badDigest, r.chunked
+		r.abortAfter = 0
+		r.mu.Unlock()
+
+		data, err := os.ReadFile(filepath.Join(r.dir, filepath.FromSlash(strings.TrimPrefix(req.URL.Path, "/dav/"))))
+		if err != nil {
+			http.NotFound(w, req)
+
+			return
+		}
+
+		if digest.WantsSHA256(req.Header) {
+			sum := sha256.Sum256(data)
+			if badDigest {
+				sum = sha256.Sum256([]byte("tampered"))
+			}
+
+			w.Header().Set(digest.HeaderReprDigest, digest.FormatSHA256(sum[:]))
+		}
+
+		if abortAfter > 0 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(data[:abortAfter])
+			w.(http.Flusher).Flush() //nolint:forcetypeassert // httptest recorder always flushes
+
+			panic(http.ErrAbortHandler)
+		}
+
+		if chunked {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush() //nolint:forcetypeassert // httptest recorder always flushes
+			_, _ = w.Write(data)
+
+			return
+		}
+
+		dav.ServeHTTP(w, req)
+	}))
+	t.Cleanup(r.server.Close)
+
+	return r
+}
+
+func (r *remote) write(t *testing.T, rel, content string) {
+	t.Helper()
+
+	p := filepath.Join(r.dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func (r *remote) seenRanges() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.ranges...)
+}
+
+// Access implements access.RemoteAccessor against the test server.
+func (r *remote) Access(ctx context.Context, opts access.AccessOptions) (*access.AccessResult, error) {
+	u := r.server.URL + "/dav/" + opts.Share.WebDAVID
+	if opts.SubPath != "" {
+		u += "/" + opts.SubPath
+	}
+
+	req, err := http.NewRequestWithContext(ctx, opts.Method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range opts.Header {
+		req.Header[name] = values
+	}
+
+	if opts.Depth != "" {
+		req.Header.Set("Depth", opts.Depth)
+	}
+
+	resp, err := r.server.Client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &access.AccessResult{Response: resp}, nil
+}
+
+type fixture struct {
+	repos   *repos.Repos
+	remote  *remote
+	manager *datatx.Manager
+	user    *identity.User
+}
+
+func newFixture(t *testing.T, policy datatx.Policy) *fixture {
+	t.Helper()
+
+	r := tsrepos.OpenMemory(t)
+
+	user := &identity.User{Username: "bob", StorageRoot: filepath.Join(t.TempDir(), "bob")}
+	if err := r.Parties.Create(context.Background(), user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	rem := newRemote(t)
+
+	if policy.InitialBackoff == 0 {
+		policy.InitialBackoff = 10 * time.Millisecond
+	}
+
+	m := datatx.NewManager(r.Transfers, r.IncomingShares, r.Parties, rem, t.TempDir(), policy, nil)
+	t.Cleanup(func() {
+		if err := m.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	})
+
+	return &fixture{repos: r, remote: rem, manager: m, user: user}
+}
+
+// addShare stores an accepted datatx share of the remote resource webdavID.
+func (f *fixture) addShare(t *testing.T, webdavID, name, resourceType string) *sharesincoming.IncomingShare {
+	t.Helper()
+
+	share := &sharesincoming.IncomingShare{
+		ShareID:           "share-" + strings.ReplaceAll(webdavID, "/", "-"),
+		ProviderID:        "provider-" + webdavID,
+		SenderHost:        "sender.example.org",
+		WebDAVID:          webdavID,
+		SharedSecret:      "secret",
+		Permissions:       []string{"read"},
+		WebDAVAccessTypes: []string{spec.AccessTypeDataTx},
+		Name:              name,
+		ResourceType:      resourceType,
+		ShareType:         spec.ShareTypeUser,
+		RecipientUserID:   f.user.ID,
+		Status:            shares.ShareStatusAccepted,
+	}
+	if err := f.repos.IncomingShares.Create(context.Background(), share); err != nil {
+		t.Fatalf("create share: %v", err)
+	}
+
+	return share
+}
+
+// wait polls the transfer of shareID until it completes or fails.
+func (f *fixture) wait(t *testing.T, shareID string) *datatx.Transfer {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+
+	for time.Now().Before(deadline) {
+		tr, err := f.manager.Get(context.Background(), shareID, f.user.ID)
+		if err == nil && (tr.Status == datatx.StatusCompleted || tr.Status == datatx.StatusFailed) {
+			return tr
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("transfer %s did not finish", shareID)
+
+	return nil
+}
+
+func readFile(t *testing.T, p string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatalf("read %s: %v", p, err)
+	}
+
+	return string(data)
+}
+
+func TestManager_CopiesFileShare(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{})
+	f.remote.write(t, "w-file", "quarterly numbers")
+	share := f.addShare(t, "w-file", "report.pdf", spec.ResourceTypeFile)
+
+	f.manager.Start()
+
+	if err := f.manager.Enqueue(context.Background(), share); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	tr := f.wait(t, share.ShareID)
+	if tr.Status != datatx.StatusCompleted {
+		t.Fatalf("status = %s (%s), want completed", tr.Status, tr.LastError)
+	}
+
+	want := filepath.Join(f.user.StorageRoot, "report.pdf")
+	if tr.Destination != want || readFile(t, want) != "quarterly numbers" {
+		t.Errorf("destination = %q, want %q with the shared content", tr.Destination, want)
+	}
+
+	if tr.BytesTotal != 17 || tr.BytesDone != 17 || tr.FilesTotal != 1 || tr.FilesDone != 1 ||
+		tr.Attempts != 1 || tr.CompletedAt == nil {
+		t.Errorf("progress = %+v", tr)
+	}
+
+	if _, err := os.Stat(filepath.Join(f.user.StorageRoot, ".ocm-transfers")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("staging directory left behind: %v", err)
+	}
+}
+
+func TestManager_CopiesFolderShare(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{})
+	f.remote.write(t, "w-dir/summary.txt", "ok")
+	f.remote.write(t, "w-dir/run 1/out.csv", "a,b\n1,2\n")
+
+	if err := os.MkdirAll(filepath.Join(f.remote.dir, "w-dir", "empty"), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	share := f.addShare(t, "w-dir", "results", spec.ResourceTypeFolder)
+
+	f.manager.Start()
+
+	if err := f.manager.Enqueue(context.Background(), share); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	tr := f.wait(t, share.ShareID)
+	if tr.Status != datatx.StatusCompleted {
+		t.Fatalf("status = %s (%s), want completed", tr.Status, tr.LastError)
+	}
+
+	dest := filepath.Join(f.user.StorageRoot, "results")
+	if readFile(t, filepath.Join(dest, "summary.txt")) != "ok" ||
+		readFile(t, filepath.Join(dest, "run 1", "out.csv")) != "a,b\n1,2\n" {
+		t.Error("folder content differs from the remote")
+	}
+
+	if info, err := os.Stat(filepath.Join(dest, "empty")); err != nil || !info.IsDir() {
+		t.Errorf("empty folder not copied: %v", err)
+	}
+
+	if tr.FilesTotal != 2 || tr.FilesDone != 2 || tr.BytesTotal != 10 || tr.BytesDone != 10 {
+		t.Errorf("progress = %+v", tr)
+	}
+}
+
+func TestManager_ResumesWithRangedGet(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{})
+	f.remote.write(t, "w-resume", "0123456789abcdef")
+	f.remote.abortAfter = 6
+	share := f.addShare(t, "w-resume", "blob.bin", spec.ResourceTypeFile)
+
+	f.manager.Start()
+
+	if err := f.manager.Enqueue(context.Background(), share); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	tr := f.wait(t, share.ShareID)
+	if tr.Status != datatx.StatusCompleted || tr.Attempts != 2 {
+		t.Fatalf("transfer = %+v, want completed on the second attempt", tr)
+	}
+
+	if got := readFile(t, tr.Destination); got != "0123456789abcdef" {
+		t.Errorf("content = %q", got)
+	}
+
+	ranges := f.remote.seenRanges()
+	if len(ranges) != 2 || ranges[0] != "" || ranges[1] != "bytes=6-" {
+		t.Errorf("GET ranges = %q, want a full GET then bytes=6-", ranges)
+	}
+}
+
+func TestManager_ChecksumMismatchFails(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{MaxAttempts: 2})
+	f.remote.write(t, "w-bad", "payload")
+	f.remote.badDigest = true
+	share := f.addShare(t, "w-bad", "payload.txt", spec.ResourceTypeFile)
+
+	f.manager.Start()
+
+	if err := f.manager.Enqueue(context.Background(), share); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	tr := f.wait(t, share.ShareID)
+	if tr.Status != datatx.StatusFailed || tr.Attempts != 2 || !strings.Contains(tr.LastError, "checksum mismatch") {
+		t.Fatalf("transfer = %+v, want failed with a checksum mismatch after 2 attempts", tr)
+	}
+
+	// Every attempt restarts from scratch: the corrupt copy was discarded.
+	if ranges := f.remote.seenRanges(); len(ranges) != 2 || ranges[1] != "" {
+		t.Errorf("GET ranges = %q, want two full GETs", ranges)
+	}
+
+	if _, err := os.Stat(filepath.Join(f.user.StorageRoot, "payload.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("unverified copy reached the destination: %v", err)
+	}
+}
+
+func TestManager_AnnouncedSizeOverLimitFails(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{MaxBytes: 8})
+	f.remote.write(t, "w-big/a.txt", "12345")
+	f.remote.write(t, "w-big/b.txt", "67890")
+	share := f.addShare(t, "w-big", "big", spec.ResourceTypeFolder)
+
+	f.manager.Start()
+
+	if err := f.manager.Enqueue(context.Background(), share); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	tr := f.wait(t, share.ShareID)
+	if tr.Status != datatx.StatusFailed || tr.Attempts != 1 || !strings.Contains(tr.LastError, "exceeds the limit of 8 bytes") {
+		t.Fatalf("transfer = %+v, want failed at once over the byte limit", tr)
+	}
+
+	if ranges := f.remote.seenRanges(); len(ranges) != 0 {
+		t.Errorf("GET ranges = %q, want no download", ranges)
+	}
+}
+
+func TestManager_WrittenBytesOverLimitFail(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{MaxBytes: 8})
+	f.remote.write(t, "w-stream", strings.Repeat("x", 64))
+	f.remote.chunked = true
+	share := f.addShare(t, "w-stream", "stream.txt", spec.ResourceTypeFile)
+
+	f.manager.Start()
+
+	if err := f.manager.Enqueue(context.Background(), share); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	tr := f.wait(t, share.ShareID)
+	if tr.Status != datatx.StatusFailed || tr.Attempts != 1 || !strings.Contains(tr.LastError, "exceeds the limit of 8 bytes") {
+		t.Fatalf("transfer = %+v, want failed at once over the byte limit", tr)
+	}
+
+	if tr.BytesDone > 9 {
+		t.Errorf("BytesDone = %d, want the copy stopped just past the limit", tr.BytesDone)
+	}
+
+	if _, err := os.Stat(filepath.Join(f.user.StorageRoot, "stream.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("oversized copy reached the destination: %v", err)
+	}
+}
+
+func TestManager_MissingRemoteFailsPermanently(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{})
+	share := f.addShare(t, "w-missing", "gone.txt", spec.ResourceTypeFile)
+
+	f.manager.Start()
+
+	if err := f.manager.Enqueue(context.Background(), share); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	tr := f.wait(t, share.ShareID)
+	if tr.Status != datatx.StatusFailed || tr.Attempts != 1 || !strings.Contains(tr.LastError, "404") {
+		t.Fatalf("transfer = %+v, want failed after a single attempt", tr)
+	}
+}
+
+func TestManager_AvoidsNameConflicts(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{})
+	f.remote.write(t, "w-conflict", "new")
+	share := f.addShare(t, "w-conflict", "notes.txt", spec.ResourceTypeFile)
+
+	if err := os.MkdirAll(f.user.StorageRoot, 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	existing := filepath.Join(f.user.StorageRoot, "notes.txt")
+	if err := os.WriteFile(existing, []byte("mine"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	f.manager.Start()
+
+	if err := f.manager.Enqueue(context.Background(), share); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	tr := f.wait(t, share.ShareID)
+	if want := filepath.Join(f.user.StorageRoot, "notes (1).txt"); tr.Destination != want {
+		t.Fatalf("destination = %q, want %q", tr.Destination, want)
+	}
+
+	if readFile(t, existing) != "mine" || readFile(t, tr.Destination) != "new" {
+		t.Error("existing file was overwritten")
+	}
+}
+
+func TestManager_ResumesInterruptedTransfersOnStart(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{})
+	f.remote.write(t, "w-restart", "survives restarts")
+	share := f.addShare(t, "w-restart", "restart.txt", spec.ResourceTypeFile)
+
+	// A transfer a previous process was running when it stopped.
+	now := time.Now()
+	if err := f.repos.Transfers.Create(context.Background(), &datatx.Transfer{
+		ShareID:         share.ShareID,
+		RecipientUserID: f.user.ID,
+		Status:          datatx.StatusRunning,
+		Attempts:        1,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}); err != nil {
+		t.Fatalf("create transfer: %v", err)
+	}
+
+	f.manager.Start()
+
+	tr := f.wait(t, share.ShareID)
+	if tr.Status != datatx.StatusCompleted || tr.Attempts != 2 || readFile(t, tr.Destination) != "survives restarts" {
+		t.Fatalf("transfer = %+v, want completed by the resumed attempt", tr)
+	}
+}
+
+func TestManager_EnqueueAndGet(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, datatx.Policy{})
+	ctx := context.Background()
+
+	remoteOnly := f.addShare(t, "w-remote", "remote.txt", spec.ResourceTypeFile)
+	remoteOnly.WebDAVAccessTypes = nil
+
+	if err := f.manager.Enqueue(ctx, remoteOnly); err == nil {
+		t.Error("expected Enqueue to reject a share without datatx")
+	}
+
+	share := f.addShare(t, "w-queued", "queued.txt", spec.ResourceTypeFile)
+
+	// Without Start nothing runs, and enqueueing twice is harmless.
+	for range 2 {
+		if err := f.manager.Enqueue(ctx, share); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	tr, err := f.manager.Get(ctx, share.ShareID, f.user.ID)
+	if err != nil || tr.Status != datatx.StatusQueued || tr.Attempts != 0 {
+		t.Fatalf("Get = %+v, %v; want a queued transfer", tr, err)
+	}
+
+	if _, err := f.manager.Get(ctx, share.ShareID, "someone-else"); !errors.Is(err, datatx.ErrTransferNotFound) {
+		t.Errorf("Get by another user = %v, want ErrTransferNotFound", err)
+	}
+
+	if _, err := f.manager.Get(ctx, remoteOnly.ShareID, f.user.ID); !errors.Is(err, datatx.ErrTransferNotFound) {
+		t.Errorf("Get without transfer = %v, want ErrTransferNotFound", err)
+	}
+}
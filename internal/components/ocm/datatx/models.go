@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package datatx implements the receiving side of the WebDAV datatx access
+// type: once a datatx share is accepted, a background job copies the shared
+// file or folder over WebDAV into the recipient's storage. Downloads resume
+// with ranged GETs, are verified against the sender's SHA-256 when one is
+// offered, and report progress through the transfer record.
+package datatx
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+)
+
+// Status tracks the lifecycle state of a transfer.
+type Status string
+
+const (
+	// StatusQueued transfers wait for a worker.
+	StatusQueued Status = "queued"
+	// StatusRunning transfers are being downloaded.
+	StatusRunning Status = "running"
+	// StatusCompleted transfers sit at Destination.
+	StatusCompleted Status = "completed"
+	// StatusFailed transfers ran out of attempts or hit a permanent error.
+	StatusFailed Status = "failed"
+)
+
+// ErrTransferNotFound is returned when a share has no transfer.
+var ErrTransferNotFound = errors.New("transfer not found")
+
+// ErrTransferExists is returned when creating a second transfer for a share.
+var ErrTransferExists = errors.New("transfer already exists")
+
+// Transfer is the datatx copy of one accepted incoming share, keyed by the
+// incoming share id. Byte and file totals are known once the remote resource
+// has been listed; BytesTotal stays 0 while it is unknown.
+type Transfer struct {
+	ShareID         string `json:"shareId"`
+	RecipientUserID string `json:"-"`
+	Status          Status `json:"status"`
+	// Destination is the local path of the completed copy.
+	Destination string `json:"destination,omitempty"`
+	BytesTotal  int64  `json:"bytesTotal"`
+	BytesDone   int64  `json:"bytesDone"`
+	FilesTotal  int    `json:"filesTotal"`
+	FilesDone   int    `json:"filesDone"`
+	Attempts    int    `json:"attempts"`
+	// LastError describes the last failed attempt.
+	LastError   string     `json:"lastError,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// Repo persists transfers.
+type Repo interface {
+	// Create stores a new transfer; ErrTransferExists when the share has one.
+	Create(ctx context.Context, transfer *Transfer) error
+	// Get returns the transfer of shareID or ErrTransferNotFound.
+	Get(ctx context.Context, shareID string) (*Transfer, error)
+	// Update replaces a stored transfer.
+	Update(ctx context.Context, transfer *Transfer) error
+	// List returns every transfer.
+	List(ctx context.Context) ([]*Transfer, error)
+}
+
+// Queue is the part of the Manager the inbox API uses.
+type Queue interface {
+	// Enqueue schedules the copy of an accepted datatx share.
+	Enqueue(ctx context.Context, share *sharesincoming.IncomingShare) error
+	// Get returns the transfer of a share owned by recipientUserID, or
+	// ErrTransferNotFound.
+	Get(ctx context.Context, shareID, recipientUserID string) (*Transfer, error)
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package datatx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+)
+
+// readChildren parses a Depth 1 PROPFIND response into the direct children
+// of the requested collection, named relative to it.
+func readChildren(resp *http.Response) ([]entry, error) {
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, statusError("list folder", resp.StatusCode)
+	}
+
+	listing, err := access.ReadListing(resp)
+	if err != nil {
+		if errors.Is(err, access.ErrUnsafeListingName) {
+			return nil, permanent(fmt.Errorf("datatx: %w", err))
+		}
+
+		return nil, fmt.Errorf("datatx: %w", err)
+	}
+
+	children := make([]entry, 0, len(listing.Children))
+	for _, c := range listing.Children {
+		children = append(children, entry{rel: c.Name, dir: c.Collection, size: c.Size})
+	}
+
+	return children, nil
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package datatx
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+)
+
+func multistatusResponse(t *testing.T, body string) *http.Response {
+	t.Helper()
+
+	u, err := url.Parse("https://sender.example.org/webdav/ocm/abc/sub")
+	if err != nil {
+		t.Fatalf("parse url: %v", err)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusMultiStatus,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{URL: u},
+	}
+}
+
+func davEntry(href, props string) string {
+	return `<D:response><D:href>` + href + `</D:href><D:propstat><D:prop>` + props +
+		`</D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat></D:response>`
+}
+
+func TestReadChildren(t *testing.T) {
+	t.Parallel()
+
+	body := `<?xml version="1.0"?><D:multistatus xmlns:D="DAV:">` +
+		davEntry("/webdav/ocm/abc/sub/", `<D:resourcetype><D:collection/></D:resourcetype>`) +
+		davEntry("/webdav/ocm/abc/sub/a%20b.txt", `<D:resourcetype/><D:getcontentlength>12</D:getcontentlength>`) +
+		davEntry("https://sender.example.org/webdav/ocm/abc/sub/nested/", `<D:resourcetype><D:collection/></D:resourcetype>`) +
+		davEntry("/webdav/ocm/abc/sub/nested/deeper.txt", `<D:resourcetype/>`) +
+		`</D:multistatus>`
+
+	children, err := readChildren(multistatusResponse(t, body))
+	if err != nil {
+		t.Fatalf("readChildren: %v", err)
+	}
+
+	want := []entry{{rel: "a b.txt", size: 12}, {rel: "nested", dir: true, size: -1}}
+	if len(children) != len(want) || children[0] != want[0] || children[1] != want[1] {
+		t.Fatalf("children = %+v, want %+v", children, want)
+	}
+}
+
+func TestReadChildren_RejectsUnsafeNames(t *testing.T) {
+	t.Parallel()
+
+	body := `<D:multistatus xmlns:D="DAV:">` + davEntry("/webdav/ocm/abc/sub/a%5Cb", `<D:resourcetype/>`) + `</D:multistatus>`
+
+	_, err := readChildren(multistatusResponse(t, body))
+
+	var perm *permanentError
+	if !errors.As(err, &perm) {
+		t.Fatalf("readChildren = %v, want a permanent error", err)
+	}
+}
+
+func TestReadChildren_StatusErrors(t *testing.T) {
+	t.Parallel()
+
+	resp := multistatusResponse(t, "")
+	resp.StatusCode = http.StatusForbidden
+
+	var perm *permanentError
+	if _, err := readChildren(resp); !errors.As(err, &perm) {
+		t.Errorf("403 = %v, want a permanent error", err)
+	}
+
+	resp.StatusCode = http.StatusBadGateway
+	if _, err := readChildren(resp); err == nil || errors.As(err, &perm) {
+		t.Errorf("502 = %v, want a retryable error", err)
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in          string
+		start, size int64
+		ok          bool
+	}{
+		{"bytes 6-15/16", 6, 16, true},
+		{"bytes 0-0/*", 0, -1, true},
+		{"bytes */16", 0, 0, false},
+		{"items 1-2/3", 0, 0, false},
+		{"", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		start, size, ok := parseContentRange(tt.in)
+		if start != tt.start || size != tt.size || ok != tt.ok {
+			t.Errorf("parseContentRange(%q) = %d, %d, %v", tt.in, start, size, ok)
+		}
+	}
+}
+
+func TestSafeName(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]string{
+		"report.pdf":     "report.pdf",
+		" spaced ":       "spaced",
+		"":               "share-s1",
+		"..":             "share-s1",
+		".ocm-transfers": "share-s1",
+		"a/b":            "share-s1",
+		`a\b`:            "share-s1",
+	} {
+		if got := safeName(&sharesincoming.IncomingShare{ShareID: "s1", Name: name}); got != want {
+			t.Errorf("safeName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
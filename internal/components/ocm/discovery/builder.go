@@ -8,6 +8,7 @@ package discovery
 import (
 	"log/slog"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
@@ -61,6 +62,12 @@ type BuildParams struct {
 
 	// AdvertiseNotifications emits the notifications capability when true.
 	AdvertiseNotifications bool
+
+	// SSHAddr is the host:port of the built-in SFTP server; non-empty
+	// advertises the ssh send role with that value.
+	SSHAddr string
+	// SSHReceive advertises the ssh-receive role.
+	SSHReceive bool
 }
 
 // BuildDiscovery constructs the static discovery document (Reva pattern:
@@ -103,6 +110,14 @@ func buildDiscoveryProtocols(p BuildParams) spec.Protocols {
 		protocols[spec.ProtocolWebDAVReceive] = spec.WebDAVReceiveRole(spec.WebDAVReceiveURIKind(p.WebDAVReceiveURI))
 	}
 
+	if p.SSHAddr != "" {
+		protocols[spec.ProtocolSSH] = spec.StringProtocolRole(p.SSHAddr)
+	}
+
+	if p.SSHReceive {
+		protocols[spec.ProtocolSSHReceive] = spec.SSHReceiveRole()
+	}
+
 	return protocols
 }
 
@@ -155,9 +170,11 @@ func buildDiscoveryResourceTypes(protocols spec.Protocols) []spec.ResourceType {
 			Name: rtName,
 			// Core OCM share types are "user" and "group"; "federation" is registered by
 			// OCM-MLS, not core OCM (https://github.com/cs3org/OCM-API/blob/6a0586183cbef10ecae9dedc42561806447eb2f5/IETF-OCM.md#L1874-L1876).
-			// ocmgo deliberately does not advertise "federation"; it advertises only "user"
-			// because it does not implement group shares.
-			ShareTypes: []string{"user"},
+			// ocmgo deliberately does not advertise "federation"; it advertises the two
+			// core types, with group shares fanned out to the local group's members.
+			// Every supported resource type (file and folder) is served through the
+			// same WebDAV protocols.
+			ShareTypes: slices.Clone(spec.SupportedShareTypes),
 			Protocols:  protocols,
 		})
 	}
@@ -233,6 +233,36 @@ func TestBuildDiscovery_ProtocolInventory(t *testing.T) {
 	}
 }
 
+func TestBuildDiscovery_SSHRoles(t *testing.T) {
+	t.Parallel()
+
+	disc := discovery.BuildDiscovery(discovery.BuildParams{
+		EndPoint:   "https://example.com/ocm",
+		WebDAVRoot: "/webdav/ocm/",
+		SSHAddr:    "sftp.example.com:2022",
+		SSHReceive: true,
+	}, nil)
+
+	protocols := disc.ResourceTypes[0].Protocols
+
+	if keys := sortedProtocolKeys(protocols); !slices.Equal(keys, []string{"ssh", "ssh-receive", "webdav"}) {
+		t.Fatalf("protocol keys = %v, want ssh, ssh-receive and webdav", keys)
+	}
+
+	if addr, ok := protocols[spec.ProtocolSSH].StringValue(); !ok || addr != "sftp.example.com:2022" {
+		t.Errorf("ssh role = %q (string %v), want the SFTP address", addr, ok)
+	}
+
+	if !disc.SupportsSSHReceive() {
+		t.Error("expected SupportsSSHReceive")
+	}
+
+	body, err := json.Marshal(protocols[spec.ProtocolSSHReceive])
+	if err != nil || string(body) != "{}" {
+		t.Errorf("ssh-receive role = %s (%v), want {}", body, err)
+	}
+}
+
 func TestBuildDiscovery_DisabledWhenRelativePathEndPoint(t *testing.T) {
 	t.Parallel()
 
@@ -355,25 +385,28 @@ func assertStrictCapabilities(t *testing.T, disc *spec.Discovery) {
 	}
 }
 
-// assertStrictResourceTypes checks the file-only resource type row of a strict
-// discovery document.
+// assertStrictResourceTypes checks the file and folder resource type rows of a
+// strict discovery document.
 func assertStrictResourceTypes(t *testing.T, disc *spec.Discovery) {
 	t.Helper()
 
-	if len(disc.ResourceTypes) != 1 {
-		t.Fatalf("ResourceTypes = %+v, want file only", disc.ResourceTypes)
+	if len(disc.ResourceTypes) != 2 {
+		t.Fatalf("ResourceTypes = %+v, want file and folder", disc.ResourceTypes)
 	}
 
-	if disc.ResourceTypes[0].Name != "file" {
-		t.Errorf("ResourceTypes[0].Name = %q, want file", disc.ResourceTypes[0].Name)
-	}
+	for i, want := range []string{"file", "folder"} {
+		rt := disc.ResourceTypes[i]
+		if rt.Name != want {
+			t.Errorf("ResourceTypes[%d].Name = %q, want %s", i, rt.Name, want)
+		}
 
-	if len(disc.ResourceTypes[0].ShareTypes) != 1 || disc.ResourceTypes[0].ShareTypes[0] != "user" {
-		t.Errorf("ResourceTypes[0].ShareTypes = %v, want [user]", disc.ResourceTypes[0].ShareTypes)
+		if !slices.Equal(rt.ShareTypes, []string{"user", "group"}) {
+			t.Errorf("ResourceTypes[%d].ShareTypes = %v, want [user group]", i, rt.ShareTypes)
+		}
 	}
 }
 
-func TestBuildDiscovery_AdvertisesFileOnlyResourceType(t *testing.T) {
+func TestBuildDiscovery_AdvertisesFileAndFolderResourceTypes(t *testing.T) {
 	t.Parallel()
 
 	disc := discovery.BuildDiscovery(discovery.BuildParams{
@@ -381,12 +414,17 @@ func TestBuildDiscovery_AdvertisesFileOnlyResourceType(t *testing.T) {
 		WebDAVRoot: "/webdav/ocm/",
 	}, nil)
 
-	if len(disc.ResourceTypes) != 1 {
-		t.Fatalf("ResourceTypes = %+v, want exactly one entry", disc.ResourceTypes)
+	if len(disc.ResourceTypes) != 2 {
+		t.Fatalf("ResourceTypes = %+v, want exactly two entries", disc.ResourceTypes)
+	}
+
+	if disc.ResourceTypes[0].Name != "file" || disc.ResourceTypes[1].Name != "folder" {
+		t.Errorf("ResourceTypes names = %q, %q, want file, folder", disc.ResourceTypes[0].Name, disc.ResourceTypes[1].Name)
 	}
 
-	if disc.ResourceTypes[0].Name != "file" {
-		t.Errorf("ResourceTypes[0].Name = %q, want file", disc.ResourceTypes[0].Name)
+	folderPath, ok := disc.ResourceTypes[1].Protocols.StringRole("webdav")
+	if !ok || folderPath != "/webdav/ocm/" {
+		t.Errorf("folder webdav protocol = %q (ok=%v), want /webdav/ocm/", folderPath, ok)
 	}
 }
 
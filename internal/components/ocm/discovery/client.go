@@ -15,9 +15,13 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache"
 	httpclient "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/client"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tracing"
 )
 
 // ErrDiscoveryDisabled is returned when the discovery client is nil or disabled.
@@ -110,6 +114,8 @@ func (c *Client) Discover(ctx context.Context, baseURL string) (*spec.Discovery,
 	if data, err := c.cache.Get(ctx, cacheKey); err == nil {
 		disc, err := c.normalizeDiscovery(data, discoveryOriginFromURL(baseURL), false)
 		if err == nil {
+			metrics.CacheLookup(metrics.CacheDiscovery, true)
+
 			return &disc, nil
 		}
 
@@ -117,7 +123,15 @@ func (c *Client) Discover(ctx context.Context, baseURL string) (*spec.Discovery,
 		c.cache.Delete(ctx, cacheKey)
 	}
 
-	rawBytes, disc, err := c.fetchDiscovery(ctx, baseURL+"/.well-known/ocm")
+	metrics.CacheLookup(metrics.CacheDiscovery, false)
+
+	discoveryURL := baseURL + "/.well-known/ocm"
+
+	fetchCtx, span := tracing.Start(ctx, "ocm.discovery.fetch", attribute.String("url.full", discoveryURL))
+	rawBytes, disc, err := c.fetchDiscovery(fetchCtx, discoveryURL)
+	tracing.End(span, err)
+	metrics.CacheFetch(metrics.CacheDiscovery, metrics.FetchMiss, err)
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover OCM at %s: %w", baseURL, err)
 	}
@@ -12,6 +12,13 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/localidentity"
 )
 
+// PeerTrustLists reports whether the effective peer trust lists are
+// nonempty. *peertrust.PolicyEngine implements it.
+type PeerTrustLists interface {
+	HasDenylist() bool
+	HasAllowlist() bool
+}
+
 // ResolveInputs bundles the cross-cutting values discovery resolution needs.
 // Wiring assembles this struct; resolve does not read the global deps bag.
 type ResolveInputs struct {
@@ -34,10 +41,20 @@ type ResolveInputs struct {
 	AdvertiseDenylist  bool
 	AdvertiseAllowlist bool
 
+	// PeerTrustLists, when set, is the live source of the denylist and
+	// allowlist criteria and overrides the two flags above. The wellknown
+	// handler re-reads it on every request, so entries added or removed at
+	// runtime show up in discovery without a restart.
+	PeerTrustLists PeerTrustLists
+
 	// AdvertiseMustInvite reflects must-invite enforcement wired by the caller.
 	// Enforcement is on by default; an explicit opt-out clears this flag.
 	AdvertiseMustInvite bool
 
 	// AdvertiseNotifications reflects whether the local notifications handler is wired.
 	AdvertiseNotifications bool
+
+	// SSHAddr is the advertised address of the built-in SFTP server, empty
+	// when [ocm.ssh] is disabled.
+	SSHAddr string
 }
@@ -97,6 +97,11 @@ func Resolve(c *ProviderConfig, rawOCMProvider map[string]any, in ResolveInputs)
 	advertiseHTTPSig := in.KeyManager != nil
 	facts := resolveFacts(in)
 
+	advertiseDenylist, advertiseAllowlist := in.AdvertiseDenylist, in.AdvertiseAllowlist
+	if in.PeerTrustLists != nil {
+		advertiseDenylist, advertiseAllowlist = in.PeerTrustLists.HasDenylist(), in.PeerTrustLists.HasAllowlist()
+	}
+
 	return BuildInputs{
 		Params: discovery.BuildParams{
 			Provider:               c.Provider,
@@ -112,10 +117,12 @@ func Resolve(c *ProviderConfig, rawOCMProvider map[string]any, in ResolveInputs)
 			TokenExchangeCapable:   in.CodeFlow != nil,
 			RequiresTokenExchange:  facts.RequiresTokenExchange,
 			RequiresHTTPSignatures: facts.RequiresHTTPRequestSignatures,
-			AdvertiseDenylist:      in.AdvertiseDenylist,
-			AdvertiseAllowlist:     in.AdvertiseAllowlist,
+			AdvertiseDenylist:      advertiseDenylist,
+			AdvertiseAllowlist:     advertiseAllowlist,
 			AdvertiseMustInvite:    in.AdvertiseMustInvite,
 			AdvertiseNotifications: in.AdvertiseNotifications,
+			SSHAddr:                in.SSHAddr,
+			SSHReceive:             true,
 		},
 	}
 }
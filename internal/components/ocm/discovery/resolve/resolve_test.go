@@ -313,6 +313,32 @@ func TestResolve_ProtocolRolesInDiscoveryDocument(t *testing.T) {
 	if _, ok := disc.ResourceTypes[0].Protocols.WebDAVReceive(); !ok {
 		t.Error("expected webdav-receive protocol role in discovery document")
 	}
+
+	if !disc.SupportsSSHReceive() {
+		t.Error("expected ssh-receive protocol role in discovery document")
+	}
+
+	if _, ok := disc.ResourceTypes[0].Protocols.StringRole("ssh"); ok {
+		t.Error("ssh send role must be omitted without an SFTP address")
+	}
+}
+
+func TestResolve_ThreadsSSHAddrIntoSendRole(t *testing.T) {
+	t.Parallel()
+
+	in := resolve.ResolveInputs{
+		LocalIdentity: tslocalid.MustTestIdentity(t, "https://cloud.example.com", "/ocm"),
+		RouteOpts:     service.RouteOpts{ExternalBasePath: "/ocm"},
+		Resolver:      newPeerMappingResolver(t, &config.PeerMappingConfig{}, config.CompatibilityScopeGlobal),
+		SSHAddr:       "cloud.example.com:2022",
+	}
+
+	built := resolve.Resolve(&resolve.ProviderConfig{}, map[string]any{}, in)
+	disc := discovery.BuildDiscovery(built.Params, nil)
+
+	if addr, ok := disc.ResourceTypes[0].Protocols.StringRole("ssh"); !ok || addr != "cloud.example.com:2022" {
+		t.Errorf("ssh role = %q (%v), want cloud.example.com:2022", addr, ok)
+	}
 }
 
 // TestResolve_ThreadsAdvertiseFlagsIntoCriteria confirms ResolveInputs
@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package expiry moves shares and invites past their expiration into the
+// expired state. Readers already refuse expired records by time; the janitor
+// makes the stored status catch up, invalidates tokens, and tells receivers.
+package expiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
+	invitesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/notifications"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
+)
+
+// DefaultInterval is the sweep interval used when none is configured.
+const DefaultInterval = time.Minute
+
+// notifyTimeout bounds one SHARE_UNSHARED notification.
+const notifyTimeout = 30 * time.Second
+
+// Janitor sweeps outgoing shares, incoming shares, and outgoing invites at a
+// fixed interval and marks the ones past their expiration expired.
+type Janitor struct {
+	outgoing sharesoutgoing.OutgoingShareRepo
+	incoming sharesincoming.IncomingShareRepo
+	invites  invitesoutgoing.OutgoingInviteRepo
+	notifier sharesoutgoing.Notifier
+	tokens   sharesoutgoing.TokenRevoker
+	interval time.Duration
+	log      *slog.Logger
+	now      func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewJanitor returns a Janitor; call Start to run the background sweep. A
+// non-positive interval uses DefaultInterval.
+func NewJanitor(
+	outgoing sharesoutgoing.OutgoingShareRepo,
+	incoming sharesincoming.IncomingShareRepo,
+	invites invitesoutgoing.OutgoingInviteRepo,
+	interval time.Duration,
+	log *slog.Logger,
+) *Janitor {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Janitor{
+		outgoing: outgoing,
+		incoming: incoming,
+		invites:  invites,
+		interval: interval,
+		log:      logutil.NoopIfNil(log),
+		now:      time.Now,
+	}
+}
+
+// SetNotifier wires the sender used for SHARE_UNSHARED notifications.
+func (j *Janitor) SetNotifier(notifier sharesoutgoing.Notifier) {
+	j.notifier = notifier
+}
+
+// SetTokenRevoker wires the token store used to invalidate tokens of
+// expired outgoing shares.
+func (j *Janitor) SetTokenRevoker(tokens sharesoutgoing.TokenRevoker) {
+	j.tokens = tokens
+}
+
+// Start runs the sweep until Close. The first sweep runs immediately so
+// records that expired while the process was down are caught up on boot.
+func (j *Janitor) Start() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	j.done = make(chan struct{})
+
+	go j.run(ctx)
+}
+
+// Close stops the sweep and waits for a running pass. Safe to call when the
+// janitor never started.
+func (j *Janitor) Close() error {
+	j.mu.Lock()
+	cancel, done := j.cancel, j.done
+	j.cancel = nil
+	j.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	<-done
+
+	return nil
+}
+
+func (j *Janitor) run(ctx context.Context) {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.Sweep(ctx); err != nil && ctx.Err() == nil {
+			j.log.Warn("expiry sweep failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep runs one pass over every share and invite. A failure on one record
+// does not stop the pass; the errors are joined.
+func (j *Janitor) Sweep(ctx context.Context) error {
+	now := j.now()
+
+	return errors.Join(
+		j.expireOutgoingShares(ctx, now),
+		j.expireIncomingShares(ctx, now),
+		j.expireOutgoingInvites(ctx, now),
+	)
+}
+
+// expireOutgoingShares expires live outgoing shares, drops their tokens, and
+// sends SHARE_UNSHARED to receivers that hold a copy. Tokens go first: a
+// share whose tokens could not be dropped stays live for the next sweep.
+func (j *Janitor) expireOutgoingShares(ctx context.Context, now time.Time) error {
+	list, err := j.outgoing.ListExpiring(ctx, now)
+	if err != nil {
+		return fmt.Errorf("expiry: list expiring outgoing shares: %w", err)
+	}
+
+	var errs []error
+
+	for _, share := range list {
+		if j.tokens != nil {
+			if err := j.tokens.DeleteByShareID(ctx, share.ShareID); err != nil {
+				errs = append(errs, fmt.Errorf("expiry: invalidate tokens for share %s: %w", share.ShareID, err))
+
+				continue
+			}
+		}
+
+		delivered := share.Status != shares.OutgoingShareStatusPending
+
+		share.Status = shares.OutgoingShareStatusExpired
+		if err := j.outgoing.Update(ctx, share); err != nil {
+			errs = append(errs, fmt.Errorf("expiry: update outgoing share %s: %w", share.ShareID, err))
+
+			continue
+		}
+
+		metrics.ShareTransition(metrics.DirectionOutgoing, string(share.Status))
+		j.log.Info("outgoing share expired",
+			"share_id", share.ShareID,
+			"provider_id", share.ProviderID,
+			"receiver", share.ReceiverHost)
+
+		if delivered {
+			j.notifyUnshared(ctx, share)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// expireIncomingShares expires pending and accepted incoming shares of every
+// recipient.
+func (j *Janitor) expireIncomingShares(ctx context.Context, now time.Time) error {
+	list, err := j.incoming.ListExpiring(ctx, now)
+	if err != nil {
+		return fmt.Errorf("expiry: list expiring incoming shares: %w", err)
+	}
+
+	var errs []error
+
+	for _, share := range list {
+		if err := j.incoming.UpdateStatusForRecipientUserID(ctx, share.ShareID, share.RecipientUserID, shares.ShareStatusExpired); err != nil {
+			errs = append(errs, fmt.Errorf("expiry: update incoming share %s: %w", share.ShareID, err))
+
+			continue
+		}
+
+		metrics.ShareTransition(metrics.DirectionIncoming, string(shares.ShareStatusExpired))
+		j.log.Info("incoming share expired", "share_id", share.ShareID, "sender_host", share.SenderHost)
+	}
+
+	return errors.Join(errs...)
+}
+
+// expireOutgoingInvites expires pending outgoing invites past ExpiresAt.
+func (j *Janitor) expireOutgoingInvites(ctx context.Context, now time.Time) error {
+	list, err := j.invites.ListExpiring(ctx, now)
+	if err != nil {
+		return fmt.Errorf("expiry: list expiring outgoing invites: %w", err)
+	}
+
+	var errs []error
+
+	for _, invite := range list {
+		if err := j.invites.UpdateStatus(ctx, invite.ID, invites.InviteStatusExpired, nil); err != nil {
+			errs = append(errs, fmt.Errorf("expiry: update outgoing invite %s: %w", invite.ID, err))
+
+			continue
+		}
+
+		metrics.InviteTransition(metrics.DirectionOutgoing, string(invites.InviteStatusExpired))
+		j.log.Info("outgoing invite expired", "invite_id", invite.ID)
+	}
+
+	return errors.Join(errs...)
+}
+
+// notifyUnshared tells the receiver the share is gone. Receivers that do not
+// advertise notifications are skipped quietly.
+func (j *Janitor) notifyUnshared(ctx context.Context, share *sharesoutgoing.OutgoingShare) {
+	if j.notifier == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+
+	err := j.notifier.Notify(ctx, share.ReceiverHost, share.ProviderID, share.ResourceType, spec.NotificationTypeShareUnshared, nil)
+	if err != nil && !errors.Is(err, notifications.ErrNotificationsNotAdvertised) {
+		j.log.Warn("failed to send share notification",
+			"notification_type", spec.NotificationTypeShareUnshared,
+			"provider_id", share.ProviderID,
+			"receiver_host", share.ReceiverHost,
+			"error", err)
+	}
+}
@@ -0,0 +1,300 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package expiry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/expiry"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
+	invitesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+)
+
+type recordingNotifier struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, targetHost, providerID, _, notificationType string, _ json.RawMessage) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.calls = append(n.calls, notificationType+" "+providerID+"@"+targetHost)
+
+	return nil
+}
+
+// flakyRevoker fails DeleteByShareID until healed. Sweep runs inline, so
+// no locking is needed.
+type flakyRevoker struct {
+	healed bool
+	calls  int
+}
+
+func (r *flakyRevoker) DeleteByShareID(context.Context, string) error {
+	r.calls++
+	if !r.healed {
+		return errors.New("token store down")
+	}
+
+	return nil
+}
+
+func createOutgoing(t *testing.T, repo sharesoutgoing.OutgoingShareRepo, id string, status shares.OutgoingShareStatus, expiresAt *time.Time) {
+	t.Helper()
+
+	share := &sharesoutgoing.OutgoingShare{
+		ProviderID:   id,
+		WebDAVID:     "webdav-" + id,
+		SharedSecret: "secret-" + id,
+		ReceiverHost: "receiver.example.org",
+		ShareWith:    "bob@receiver.example.org",
+		Name:         id + ".txt",
+		ResourceType: spec.ResourceTypeFile,
+		ShareType:    spec.ShareTypeUser,
+		Permissions:  []string{"read"},
+		Status:       status,
+		ExpiresAt:    expiresAt,
+	}
+	if err := repo.Create(t.Context(), share); err != nil {
+		t.Fatalf("create outgoing %s: %v", id, err)
+	}
+}
+
+func createIncoming(t *testing.T, repo sharesincoming.IncomingShareRepo, id string, status shares.ShareStatus, expiration *int64) string {
+	t.Helper()
+
+	share := &sharesincoming.IncomingShare{
+		ProviderID:      id,
+		SenderHost:      "sender.example.org",
+		ShareWith:       "alice@example.org",
+		RecipientUserID: "alice-id",
+		Status:          status,
+		ResourceType:    spec.ResourceTypeFile,
+		Name:            id + ".txt",
+		Owner:           "carol@sender.example.org",
+		Sender:          "carol@sender.example.org",
+		ShareType:       spec.ShareTypeUser,
+		Permissions:     []string{"read"},
+		WebDAVID:        "webdav-" + id,
+		SharedSecret:    "secret-" + id,
+		Expiration:      expiration,
+	}
+	if err := repo.Create(t.Context(), share); err != nil {
+		t.Fatalf("create incoming %s: %v", id, err)
+	}
+
+	return share.ShareID
+}
+
+func TestJanitor_SweepExpiresPastRecords(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	repos := tsrepos.OpenMemory(t)
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	pastUnix, futureUnix := past.Unix(), future.Unix()
+
+	createOutgoing(t, repos.OutgoingShares, "out-sent", shares.OutgoingShareStatusSent, &past)
+	createOutgoing(t, repos.OutgoingShares, "out-pending", shares.OutgoingShareStatusPending, &past)
+	createOutgoing(t, repos.OutgoingShares, "out-live", shares.OutgoingShareStatusAccepted, &future)
+	createOutgoing(t, repos.OutgoingShares, "out-declined", shares.OutgoingShareStatusDeclined, &past)
+
+	inAccepted := createIncoming(t, repos.IncomingShares, "in-accepted", shares.ShareStatusAccepted, &pastUnix)
+	inLive := createIncoming(t, repos.IncomingShares, "in-live", shares.ShareStatusPending, &futureUnix)
+	inForever := createIncoming(t, repos.IncomingShares, "in-forever", shares.ShareStatusAccepted, nil)
+
+	for id, expiresAt := range map[string]time.Time{"inv-stale": past, "inv-live": future} {
+		if err := repos.OutgoingInvites.Create(ctx, &invitesoutgoing.OutgoingInvite{
+			ID: id, Token: "token-" + id, ProviderFQDN: "example.org", ExpiresAt: expiresAt,
+		}); err != nil {
+			t.Fatalf("create invite %s: %v", id, err)
+		}
+	}
+
+	sent, err := repos.OutgoingShares.GetByProviderID(ctx, "out-sent")
+	if err != nil {
+		t.Fatalf("GetByProviderID: %v", err)
+	}
+
+	tokens := token.NewMemoryTokenStore()
+	if err := tokens.Store(ctx, &token.IssuedToken{AccessToken: "sent-tok", ShareID: sent.ShareID, ExpiresAt: future}); err != nil {
+		t.Fatalf("store token: %v", err)
+	}
+
+	notifier := &recordingNotifier{}
+	j := expiry.NewJanitor(repos.OutgoingShares, repos.IncomingShares, repos.OutgoingInvites, time.Minute, nil)
+	j.SetNotifier(notifier)
+	j.SetTokenRevoker(tokens)
+
+	if err := j.Sweep(ctx); err != nil {
+		t.Fatalf("Sweep: %v", err)
+	}
+
+	for providerID, want := range map[string]shares.OutgoingShareStatus{
+		"out-sent":     shares.OutgoingShareStatusExpired,
+		"out-pending":  shares.OutgoingShareStatusExpired,
+		"out-live":     shares.OutgoingShareStatusAccepted,
+		"out-declined": shares.OutgoingShareStatusDeclined,
+	} {
+		got, err := repos.OutgoingShares.GetByProviderID(ctx, providerID)
+		if err != nil {
+			t.Fatalf("GetByProviderID(%s): %v", providerID, err)
+		}
+
+		if got.Status != want {
+			t.Errorf("%s status = %s, want %s", providerID, got.Status, want)
+		}
+	}
+
+	if _, err := tokens.Get(ctx, "sent-tok"); !errors.Is(err, token.ErrTokenNotFound) {
+		t.Errorf("token of expired share: Get = %v, want ErrTokenNotFound", err)
+	}
+
+	if want := spec.NotificationTypeShareUnshared + " out-sent@receiver.example.org"; len(notifier.calls) != 1 || notifier.calls[0] != want {
+		t.Errorf("notifications = %v, want only %q (pending shares were never delivered)", notifier.calls, want)
+	}
+
+	for shareID, want := range map[string]shares.ShareStatus{
+		inAccepted: shares.ShareStatusExpired,
+		inLive:     shares.ShareStatusPending,
+		inForever:  shares.ShareStatusAccepted,
+	} {
+		got, err := repos.IncomingShares.GetByIDForRecipientUserID(ctx, shareID, "alice-id")
+		if err != nil {
+			t.Fatalf("GetByIDForRecipientUserID(%s): %v", shareID, err)
+		}
+
+		if got.Status != want {
+			t.Errorf("%s status = %s, want %s", got.ProviderID, got.Status, want)
+		}
+	}
+
+	for id, want := range map[string]invites.InviteStatus{
+		"inv-stale": invites.InviteStatusExpired,
+		"inv-live":  invites.InviteStatusPending,
+	} {
+		got, err := repos.OutgoingInvites.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetByID(%s): %v", id, err)
+		}
+
+		if got.Status != want {
+			t.Errorf("%s status = %s, want %s", id, got.Status, want)
+		}
+	}
+
+	// A second pass finds nothing left to do.
+	if err := j.Sweep(ctx); err != nil {
+		t.Fatalf("second Sweep: %v", err)
+	}
+
+	if len(notifier.calls) != 1 {
+		t.Errorf("second sweep notified again: %v", notifier.calls)
+	}
+}
+
+func TestJanitor_TokenFailureLeavesShareForNextSweep(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	repos := tsrepos.OpenMemory(t)
+	past := time.Now().Add(-time.Minute)
+	createOutgoing(t, repos.OutgoingShares, "out-sent", shares.OutgoingShareStatusSent, &past)
+
+	revoker := &flakyRevoker{}
+	notifier := &recordingNotifier{}
+	j := expiry.NewJanitor(repos.OutgoingShares, repos.IncomingShares, repos.OutgoingInvites, time.Minute, nil)
+	j.SetNotifier(notifier)
+	j.SetTokenRevoker(revoker)
+
+	if err := j.Sweep(ctx); err == nil {
+		t.Fatal("Sweep with a failing token store returned nil")
+	}
+
+	got, err := repos.OutgoingShares.GetByProviderID(ctx, "out-sent")
+	if err != nil {
+		t.Fatalf("GetByProviderID: %v", err)
+	}
+
+	if got.Status != shares.OutgoingShareStatusSent {
+		t.Fatalf("status after failed token drop = %s, want %s", got.Status, shares.OutgoingShareStatusSent)
+	}
+
+	if len(notifier.calls) != 0 {
+		t.Errorf("notified before the share expired: %v", notifier.calls)
+	}
+
+	revoker.healed = true
+
+	if err := j.Sweep(ctx); err != nil {
+		t.Fatalf("retry Sweep: %v", err)
+	}
+
+	got, err = repos.OutgoingShares.GetByProviderID(ctx, "out-sent")
+	if err != nil {
+		t.Fatalf("GetByProviderID: %v", err)
+	}
+
+	if got.Status != shares.OutgoingShareStatusExpired {
+		t.Errorf("status after retry = %s, want %s", got.Status, shares.OutgoingShareStatusExpired)
+	}
+
+	if revoker.calls != 2 || len(notifier.calls) != 1 {
+		t.Errorf("token drops = %d, notifications = %v; want 2 and one", revoker.calls, notifier.calls)
+	}
+}
+
+func TestJanitor_StartSweepsImmediately(t *testing.T) {
+	t.Parallel()
+
+	repos := tsrepos.OpenMemory(t)
+	past := time.Now().Add(-time.Minute)
+	createOutgoing(t, repos.OutgoingShares, "out-boot", shares.OutgoingShareStatusSent, &past)
+
+	j := expiry.NewJanitor(repos.OutgoingShares, repos.IncomingShares, repos.OutgoingInvites, time.Hour, nil)
+	j.Start()
+	j.Start() // second Start is a no-op
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, err := repos.OutgoingShares.GetByProviderID(t.Context(), "out-boot")
+		if err != nil {
+			t.Fatalf("GetByProviderID: %v", err)
+		}
+
+		if got.Status == shares.OutgoingShareStatusExpired {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("janitor did not expire the share on start")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := j.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if err := expiry.NewJanitor(nil, nil, nil, 0, nil).Close(); err != nil {
+		t.Fatalf("Close without Start: %v", err)
+	}
+}
@@ -8,17 +8,27 @@ package signature
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/keyid"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/sigalg"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tracing"
 )
 
 // contextKey is used for storing values in request context.
@@ -58,9 +68,22 @@ type PeerDiscovery interface {
 	ResolveVerificationKey(ctx context.Context, keyID string) (sigalg.ResolvedPublicKey, error)
 }
 
+// replayKeyPrefix namespaces seen-signature entries in the shared cache.
+const replayKeyPrefix = "sigreplay:"
+
+// replayPolicy is the seen-signature check derived from SignatureConfig.
+type replayPolicy struct {
+	enabled bool
+	// window is how long a seen signature is remembered: the longest time
+	// a signature stays acceptable under the created checks.
+	window time.Duration
+}
+
 // SignatureMiddleware verifies HTTP request signatures.
 type SignatureMiddleware struct {
-	verifier                           *crypto.RFC9421Verifier
+	verifier                           atomic.Pointer[crypto.RFC9421Verifier]
+	replay                             atomic.Pointer[replayPolicy]
+	replayCache                        cache.Counter
 	peerDiscovery                      PeerDiscovery
 	logger                             *slog.Logger
 	localScheme                        string // scheme from PublicOrigin for unverified peer normalization
@@ -80,16 +103,39 @@ func NewSignatureMiddleware(
 
 	localScheme := config.SchemeFromOrigin(publicOrigin)
 
-	return &SignatureMiddleware{
-		verifier: crypto.NewRFC9421VerifierWithOptions(
-			crypto.RFC9421OptionsFromConfig(sigCfg),
-		),
+	m := &SignatureMiddleware{
 		peerDiscovery:                      pd,
 		logger:                             logger,
 		localScheme:                        localScheme,
 		localRequiresHTTPRequestSignatures: true,
 		localAdvertiseHTTPSig:              true,
 	}
+	m.UpdateSignatureConfig(sigCfg)
+
+	return m
+}
+
+// UpdateSignatureConfig replaces the verifier policy (timing windows,
+// allowed algorithms, label, RSA floor, replay protection) with one built
+// from sigCfg. Requests that start afterwards are verified under the new
+// policy.
+func (m *SignatureMiddleware) UpdateSignatureConfig(sigCfg config.SignatureConfig) {
+	opts := crypto.RFC9421OptionsFromConfig(sigCfg)
+
+	m.verifier.Store(crypto.NewRFC9421VerifierWithOptions(opts))
+	m.replay.Store(&replayPolicy{
+		enabled: sigCfg.ReplayProtection,
+		window:  opts.CreatedMaxAge + opts.CreatedMaxSkew,
+	})
+}
+
+// SetReplayCache sets the store of seen signatures used when
+// signature.replay_protection is on. Entries are counters keyed by keyId and
+// signature hash, so the first Increment is an atomic first-seen check on
+// both the memory and redis drivers. The cache must not evict live entries
+// early. Without a cache the replay check is skipped. Call before serving.
+func (m *SignatureMiddleware) SetReplayCache(c cache.Counter) {
+	m.replayCache = c
 }
 
 // SetLocalHTTPSigPolicy sets the local discovery policy used by the request
@@ -209,16 +255,32 @@ func (m *SignatureMiddleware) verifySignatureAttempt(
 	optionalSignature bool,
 	next http.Handler,
 ) (*PeerIdentity, bool) {
-	hasOCMSignature := m.verifier.HasOCMSignatureAttempt(r)
+	verifier := m.verifier.Load()
+
+	hasOCMSignature := verifier.HasOCMSignatureAttempt(r)
 	if !hasOCMSignature {
+		metrics.SignatureVerification(crypto.ReasonUnsigned)
 		m.serveUnsigned(w, r, body, optionalSignature, next)
 
 		return nil, false
 	}
 
-	result := m.verifier.VerifyRequest(r, body, func(keyID string) (sigalg.ResolvedPublicKey, error) {
-		return m.peerDiscovery.ResolveVerificationKey(r.Context(), keyID)
+	verifyCtx, span := tracing.Start(r.Context(), "ocm.signature.verify")
+	result := verifier.VerifyRequest(r, body, func(keyID string) (sigalg.ResolvedPublicKey, error) {
+		return m.peerDiscovery.ResolveVerificationKey(verifyCtx, keyID)
 	})
+	if result.Verified {
+		result = m.checkReplay(verifyCtx, result)
+	}
+
+	outcome := metrics.SignatureVerified
+	if !result.Verified {
+		outcome = result.Reason
+	}
+
+	span.SetAttributes(attribute.String("ocm.signature.result", outcome), attribute.String("ocm.signature.key_id", result.KeyID))
+	span.End()
+	metrics.SignatureVerification(outcome)
 
 	if !result.Verified {
 		if result.Reason == crypto.ReasonUnsigned {
@@ -235,6 +297,39 @@ func (m *SignatureMiddleware) verifySignatureAttempt(
 	return m.buildVerifiedIdentity(w, result, declaredPeer)
 }
 
+// checkReplay records a verified signature as seen and turns a repeat
+// within the replay window into a ReasonReplayed failure. Signatures are
+// keyed by their signature base, so a re-encoded signature over the same
+// request (an ECDSA s flipped to n-s) is still a repeat.
+func (m *SignatureMiddleware) checkReplay(ctx context.Context, result *crypto.VerificationResult) *crypto.VerificationResult {
+	policy := m.replay.Load()
+	if !policy.enabled || m.replayCache == nil {
+		return result
+	}
+
+	sum := sha256.Sum256(result.SignatureBase)
+	key := replayKeyPrefix + result.KeyID + ":" + hex.EncodeToString(sum[:])
+
+	seen, _, err := m.replayCache.Increment(ctx, key, 1, policy.window)
+	if err != nil {
+		return &crypto.VerificationResult{
+			KeyID:  result.KeyID,
+			Reason: crypto.ReasonReplayCheckFailed,
+			Error:  fmt.Errorf("record seen signature: %w", err),
+		}
+	}
+
+	if seen > 1 {
+		return &crypto.VerificationResult{
+			KeyID:  result.KeyID,
+			Reason: crypto.ReasonReplayed,
+			Error:  errors.New("signature already seen"),
+		}
+	}
+
+	return result
+}
+
 func (m *SignatureMiddleware) buildVerifiedIdentity(
 	w http.ResponseWriter,
 	result *crypto.VerificationResult,
@@ -348,6 +443,10 @@ func httpBodyForVerifyReason(reason string) string {
 		return "content digest mismatch"
 	case crypto.ReasonUnsigned:
 		return "signature required"
+	case crypto.ReasonReplayed:
+		return "signature replayed"
+	case crypto.ReasonReplayCheckFailed:
+		return "signature replay check unavailable"
 	default:
 		return "signature verification failed"
 	}
@@ -359,6 +458,8 @@ func httpStatusForVerifyReason(reason string) int {
 		return http.StatusBadGateway
 	case crypto.ReasonContentDigest:
 		return http.StatusBadRequest
+	case crypto.ReasonReplayCheckFailed:
+		return http.StatusServiceUnavailable
 	default:
 		return http.StatusUnauthorized
 	}
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package signature_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sig "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/inbound/signature"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache"
+	_ "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache/loader"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/sigalg"
+)
+
+// failingCounter is a cache.Counter whose every call fails.
+type failingCounter struct{}
+
+func (failingCounter) Increment(context.Context, string, int64, time.Duration) (int64, time.Time, error) {
+	return 0, time.Time{}, errors.New("cache down")
+}
+
+func (failingCounter) GetCount(context.Context, string) (int64, error) {
+	return 0, errors.New("cache down")
+}
+
+func (failingCounter) Reset(context.Context, string) error { return errors.New("cache down") }
+
+func newReplayTestMiddleware(t *testing.T, replayProtection bool, counter cache.Counter) (*sig.SignatureMiddleware, *crypto.RFC9421Signer) {
+	t.Helper()
+
+	km := crypto.NewKeyManager("", "https://sender.example.com")
+	if err := km.LoadOrGenerate(); err != nil {
+		t.Fatalf("LoadOrGenerate: %v", err)
+	}
+
+	return replayTestMiddlewareFor(t, km, resolvedKeyFromManager(km), replayProtection, counter)
+}
+
+func replayTestMiddlewareFor(
+	t *testing.T,
+	km *crypto.KeyManager,
+	key sigalg.ResolvedPublicKey,
+	replayProtection bool,
+	counter cache.Counter,
+) (*sig.SignatureMiddleware, *crypto.RFC9421Signer) {
+	t.Helper()
+
+	cfg := defaultSigTestConfig()
+	cfg.ReplayProtection = replayProtection
+	pd := &mockPeerDiscovery{
+		publicKeys: map[string]sigalg.ResolvedPublicKey{
+			km.GetKeyID(): key,
+		},
+	}
+
+	mw := newTestSignatureMiddleware(cfg, pd, "https://receiver.example.com", slog.New(slog.DiscardHandler))
+	mw.SetReplayCache(counter)
+
+	opts := crypto.DefaultRFC9421Options()
+	opts.SignNonce = true
+
+	return mw, crypto.NewRFC9421SignerWithOptions(km, opts)
+}
+
+func signedNotification(t *testing.T, signer *crypto.RFC9421Signer, body []byte) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "https://receiver.example.com/ocm/notifications", bytes.NewReader(body))
+	req.Host = "receiver.example.com"
+	req.Header.Set("Content-Type", "application/json")
+
+	if err := signer.SignRequest(req, body); err != nil {
+		t.Fatalf("SignRequest: %v", err)
+	}
+
+	return req
+}
+
+// replay sends a copy of req with the same headers and body.
+func replay(req *http.Request, body []byte) *http.Request {
+	again := req.Clone(context.Background())
+	again.Body = io.NopCloser(bytes.NewReader(body))
+
+	return again
+}
+
+func serve(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestSignatureMiddleware_ReplayProtection_RejectsRepeatedSignature(t *testing.T) {
+	t.Parallel()
+
+	mw, signer := newReplayTestMiddleware(t, true, cache.NewDefault())
+	handler := mw.VerifyOCMRequestRequireSignature()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := []byte(`{"notificationType":"SHARE_ACCEPTED"}`)
+	req := signedNotification(t, signer, body)
+	captured := replay(req, body)
+
+	if w := serve(handler, req); w.Code != http.StatusOK {
+		t.Fatalf("first delivery: status %d, body %q", w.Code, w.Body.String())
+	}
+
+	w := serve(handler, captured)
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "signature replayed") {
+		t.Fatalf("replay: status %d, body %q; want 401 signature replayed", w.Code, w.Body.String())
+	}
+
+	// The signer adds a fresh nonce, so re-signing the same request is a new
+	// signature, not a replay.
+	if w := serve(handler, signedNotification(t, signer, body)); w.Code != http.StatusOK {
+		t.Errorf("re-signed request: status %d, body %q", w.Code, w.Body.String())
+	}
+}
+
+// flipECDSAS rewrites the raw r||s P-256 signature in req's Signature header
+// to r||(n-s), which verifies just as well as the original.
+func flipECDSAS(t *testing.T, req *http.Request, curveOrder *big.Int) {
+	t.Helper()
+
+	label, value, ok := strings.Cut(req.Header.Get("Signature"), "=:")
+	if !ok {
+		t.Fatalf("unexpected Signature header %q", req.Header.Get("Signature"))
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(value, ":"))
+	if err != nil || len(raw) != 64 {
+		t.Fatalf("decode signature: len %d, err %v", len(raw), err)
+	}
+
+	s := new(big.Int).SetBytes(raw[32:])
+	s.Sub(curveOrder, s)
+
+	flipped := append(raw[:32:32], s.FillBytes(make([]byte, 32))...)
+	req.Header.Set("Signature", label+"=:"+base64.StdEncoding.EncodeToString(flipped)+":")
+}
+
+func TestSignatureMiddleware_ReplayProtection_RejectsMalleatedECDSASignature(t *testing.T) {
+	t.Parallel()
+
+	km, err := crypto.NewKeyManagerWithOptions("", "https://sender.example.com", crypto.KeyManagerOptions{
+		Algorithm: sigalg.ECDSAP256SHA256,
+	})
+	if err != nil {
+		t.Fatalf("NewKeyManagerWithOptions: %v", err)
+	}
+
+	if err := km.LoadOrGenerate(); err != nil {
+		t.Fatalf("LoadOrGenerate: %v", err)
+	}
+
+	pub, ok := km.GetSigningKey().PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		t.Fatalf("public key is %T, want *ecdsa.PublicKey", km.GetSigningKey().PublicKey)
+	}
+
+	key := sigalg.ResolvedPublicKey{
+		KeyID:     km.GetKeyID(),
+		PublicKey: pub,
+		JWKKty:    "EC",
+		JWKCrv:    "P-256",
+		JWKAlg:    "ES256",
+	}
+
+	mw, signer := replayTestMiddlewareFor(t, km, key, true, cache.NewDefault())
+	handler := mw.VerifyOCMRequestRequireSignature()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := []byte(`{"notificationType":"SHARE_ACCEPTED"}`)
+	req := signedNotification(t, signer, body)
+	malleated := replay(req, body)
+	flipECDSAS(t, malleated, pub.Curve.Params().N)
+
+	if malleated.Header.Get("Signature") == req.Header.Get("Signature") {
+		t.Fatal("flipping s did not change the signature")
+	}
+
+	if w := serve(handler, req); w.Code != http.StatusOK {
+		t.Fatalf("first delivery: status %d, body %q", w.Code, w.Body.String())
+	}
+
+	w := serve(handler, malleated)
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "signature replayed") {
+		t.Fatalf("s-flipped replay: status %d, body %q; want 401 signature replayed", w.Code, w.Body.String())
+	}
+}
+
+func TestSignatureMiddleware_ReplayProtection_OffAcceptsRepeats(t *testing.T) {
+	t.Parallel()
+
+	mw, signer := newReplayTestMiddleware(t, false, cache.NewDefault())
+	handler := mw.VerifyOCMRequestRequireSignature()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := []byte(`{"notificationType":"SHARE_ACCEPTED"}`)
+	req := signedNotification(t, signer, body)
+	captured := replay(req, body)
+
+	for i, r := range []*http.Request{req, captured} {
+		if w := serve(handler, r); w.Code != http.StatusOK {
+			t.Errorf("delivery %d: status %d, body %q", i+1, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestSignatureMiddleware_ReplayProtection_CacheFailureFailsClosed(t *testing.T) {
+	t.Parallel()
+
+	mw, signer := newReplayTestMiddleware(t, true, failingCounter{})
+	handler := mw.VerifyOCMRequestRequireSignature()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("handler must not run when the replay check fails")
+	}))
+
+	body := []byte(`{"notificationType":"SHARE_ACCEPTED"}`)
+
+	w := serve(handler, signedNotification(t, signer, body))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status %d, body %q; want 503", w.Code, w.Body.String())
+	}
+}
@@ -55,15 +55,21 @@ func SendInviteAccepted(ctx context.Context, poster InviteAcceptedPoster, req sp
 		resp.Body.Close()
 	}()
 
-	switch resp.StatusCode {
+	return DecodeInviteAcceptedResponse(resp.StatusCode, resp.Body)
+}
+
+// DecodeInviteAcceptedResponse interprets the sender's answer to an
+// invite-accepted call with the rules documented on SendInviteAccepted.
+func DecodeInviteAcceptedResponse(statusCode int, body io.Reader) (AcceptResult, error) {
+	switch statusCode {
 	case http.StatusOK, http.StatusCreated:
 		var acceptedResp spec.InviteAcceptedResponse
-		if err := json.NewDecoder(resp.Body).Decode(&acceptedResp); err != nil {
+		if err := json.NewDecoder(body).Decode(&acceptedResp); err != nil {
 			return AcceptResult{}, fmt.Errorf("failed to decode invite-accepted response: %w", err)
 		}
 
 		if acceptedResp.UserID == "" {
-			return AcceptResult{}, fmt.Errorf("invite-accepted response missing userID (status %d)", resp.StatusCode)
+			return AcceptResult{}, fmt.Errorf("invite-accepted response missing userID (status %d)", statusCode)
 		}
 
 		return AcceptResult{Response: acceptedResp}, nil
@@ -73,7 +79,7 @@ func SendInviteAccepted(ctx context.Context, poster InviteAcceptedPoster, req sp
 		// sender returned its identity; otherwise the retry cannot recover the
 		// sender identity and must surface an honest error.
 		var acceptedResp spec.InviteAcceptedResponse
-		if err := json.NewDecoder(resp.Body).Decode(&acceptedResp); err != nil {
+		if err := json.NewDecoder(body).Decode(&acceptedResp); err != nil {
 			return AcceptResult{}, fmt.Errorf("invite-accepted returned 409 without a decodable identity body: %w", err)
 		}
 
@@ -84,11 +90,11 @@ func SendInviteAccepted(ctx context.Context, poster InviteAcceptedPoster, req sp
 		return AcceptResult{Response: acceptedResp, AlreadyAccepted: true}, nil
 
 	default:
-		respBody, readErr := io.ReadAll(resp.Body)
+		respBody, readErr := io.ReadAll(body)
 		if readErr != nil {
-			return AcceptResult{}, fmt.Errorf("invite-accepted rejected with status %d: %w", resp.StatusCode, readErr)
+			return AcceptResult{}, fmt.Errorf("invite-accepted rejected with status %d: %w", statusCode, readErr)
 		}
 
-		return AcceptResult{}, fmt.Errorf("invite-accepted rejected with status %d: %s", resp.StatusCode, string(respBody))
+		return AcceptResult{}, fmt.Errorf("invite-accepted rejected with status %d: %s", statusCode, string(respBody))
 	}
 }
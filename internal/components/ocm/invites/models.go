@@ -16,7 +16,7 @@ import (
 	"time"
 )
 
-// InviteStatus tracks the lifecycle state of an OCM invite (pending, accepted, declined).
+// InviteStatus tracks the lifecycle state of an OCM invite (pending, accepted, declined, expired).
 type InviteStatus string
 
 const (
@@ -26,6 +26,8 @@ const (
 	InviteStatusAccepted InviteStatus = "accepted"
 	// InviteStatusDeclined is the declined invite status.
 	InviteStatusDeclined InviteStatus = "declined"
+	// InviteStatusExpired marks a pending invite whose expiry passed.
+	InviteStatusExpired InviteStatus = "expired"
 )
 
 var (
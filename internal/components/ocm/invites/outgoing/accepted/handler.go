@@ -25,7 +25,9 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/appctx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // Handler serves POST /ocm/invite-accepted with outgoing invite lookup and peer-trust gating.
@@ -35,6 +37,7 @@ type Handler struct {
 	policyEngine *peertrust.PolicyEngine // may be nil when peer trust is disabled
 	providerFQDN string
 	localScheme  string // scheme from PublicOrigin for comparison normalization
+	audit        audit.Recorder
 }
 
 // NewHandler creates the invite-accepted handler. partyRepo is required.
@@ -56,9 +59,15 @@ func NewHandler(
 		policyEngine: policyEngine,
 		providerFQDN: localProviderDomain,
 		localScheme:  localScheme,
+		audit:        audit.Nop(),
 	}
 }
 
+// SetAudit sets the audit recorder for accepted invites; nil disables it.
+func (h *Handler) SetAudit(r audit.Recorder) {
+	h.audit = audit.OrNop(r)
+}
+
 // HandleInviteAccepted handles POST /ocm/invite-accepted.
 // The mounted signature middleware enforces verify-if-present (rule 3) and
 // unsigned-admission gating (rule 4, conditional on must-use-http-sig), so
@@ -103,6 +112,14 @@ func (h *Handler) HandleInviteAccepted(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics.InviteTransition(metrics.DirectionOutgoing, string(invites.InviteStatusAccepted))
+	h.audit.Record(r.Context(), audit.Event{
+		Action:   audit.ActionInviteAccept,
+		Actor:    req.UserID,
+		Peer:     req.RecipientProvider,
+		Resource: invite.ID,
+		Details:  map[string]string{audit.DetailDirection: audit.DirectionOutgoing},
+	})
 	log.Info("invite accepted",
 		"recipient_provider", req.RecipientProvider,
 		"user_id", req.UserID)
@@ -7,6 +7,7 @@ package outgoing
 
 import (
 	"context"
+	"time"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
 )
@@ -16,6 +17,9 @@ type OutgoingInviteRepo interface { //nolint:revive // exported: self-explanator
 	GetByID(ctx context.Context, id string) (*OutgoingInvite, error)
 	GetByToken(ctx context.Context, token string) (*OutgoingInvite, error)
 	List(ctx context.Context) ([]*OutgoingInvite, error)
+	// ListExpiring spans every user: it returns the pending invites whose
+	// expiry has passed at now, for the expiry janitor.
+	ListExpiring(ctx context.Context, now time.Time) ([]*OutgoingInvite, error)
 	UpdateStatus(ctx context.Context, id string, status invites.InviteStatus, acceptance *Acceptance) error
 	// FindAcceptedForRecipient finds an accepted outgoing invite created by the
 	// local senderUserID whose remote accepter matches both recipientUserID and
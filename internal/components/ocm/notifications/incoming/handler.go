@@ -18,8 +18,10 @@ import (
 	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
 	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 const (
@@ -33,6 +35,7 @@ type Handler struct {
 	incomingRepo sharesincoming.IncomingShareRepo
 	localScheme  string
 	log          *slog.Logger
+	audit        audit.Recorder
 }
 
 // NewHandler creates the notifications handler.
@@ -47,9 +50,16 @@ func NewHandler(
 		incomingRepo: incomingRepo,
 		localScheme:  localScheme,
 		log:          logutil.NoopIfNil(log),
+		audit:        audit.Nop(),
 	}
 }
 
+// SetAudit sets the audit recorder for share lifecycle notifications; nil
+// disables it.
+func (h *Handler) SetAudit(r audit.Recorder) {
+	h.audit = audit.OrNop(r)
+}
+
 // HandleNotification handles POST /ocm/notifications.
 func (h *Handler) HandleNotification(w http.ResponseWriter, r *http.Request) {
 	req, ok := h.parseNotificationRequest(w, r)
@@ -170,6 +180,21 @@ func (h *Handler) updateOutgoingShareStatus(
 		return
 	}
 
+	metrics.ShareTransition(metrics.DirectionOutgoing, string(wantStatus))
+
+	action := audit.ActionShareAccept
+	if wantStatus == shares.OutgoingShareStatusDeclined {
+		action = audit.ActionShareDecline
+	}
+
+	h.audit.Record(ctx, audit.Event{
+		Action:   action,
+		Actor:    senderHost,
+		Peer:     senderHost,
+		Resource: share.ShareID,
+		Details:  map[string]string{audit.DetailDirection: audit.DirectionOutgoing},
+	})
+
 	h.log.Info(successLog, "provider_id", req.ProviderID)
 	writeNotificationSuccess(w)
 }
@@ -180,14 +205,10 @@ func (h *Handler) handleShareUnshared(
 	senderHost string,
 	req *spec.NotificationRequest,
 ) {
-	share, err := h.incomingRepo.GetByProviderID(ctx, senderHost, req.ProviderID)
+	// A group share fans out to one incoming row per member; unsharing
+	// applies to every copy under the provider key.
+	existing, err := h.incomingRepo.ListByProviderID(ctx, senderHost, req.ProviderID)
 	if err != nil {
-		if errors.Is(err, sharesincoming.ErrShareNotFound) {
-			spec.WriteOCMError(w, http.StatusNotFound, "SHARE_NOT_FOUND")
-
-			return
-		}
-
 		h.log.Error("failed to load incoming share for notification",
 			"provider_id", req.ProviderID,
 			"sender_host", senderHost,
@@ -197,40 +218,68 @@ func (h *Handler) handleShareUnshared(
 		return
 	}
 
-	if !h.senderMatchesHost(senderHost, share.SenderHost) {
-		h.log.Warn("notification sender host mismatch for incoming share",
-			"provider_id", req.ProviderID,
-			"sender_host", senderHost,
-			"stored_sender_host", share.SenderHost)
-		spec.WriteOCMError(w, http.StatusForbidden, ocmErrSenderNotAuthorized)
+	if len(existing) == 0 {
+		spec.WriteOCMError(w, http.StatusNotFound, "SHARE_NOT_FOUND")
 
 		return
 	}
 
-	if share.Status == shares.ShareStatusUnshared {
-		writeNotificationSuccess(w)
+	for _, share := range existing {
+		if !h.senderMatchesHost(senderHost, share.SenderHost) {
+			h.log.Warn("notification sender host mismatch for incoming share",
+				"provider_id", req.ProviderID,
+				"sender_host", senderHost,
+				"stored_sender_host", share.SenderHost)
+			spec.WriteOCMError(w, http.StatusForbidden, ocmErrSenderNotAuthorized)
 
-		return
+			return
+		}
 	}
 
-	if err := h.incomingRepo.UpdateStatusForRecipientUserID(
-		ctx,
-		share.ShareID,
-		share.RecipientUserID,
-		shares.ShareStatusUnshared,
-	); err != nil {
-		h.log.Error("failed to update incoming share status",
-			"provider_id", req.ProviderID,
-			"sender_host", senderHost,
-			"error", err)
-		spec.WriteOCMError(w, http.StatusInternalServerError, "INTERNAL_ERROR")
+	updated := 0
+
+	for _, share := range existing {
+		if share.Status == shares.ShareStatusUnshared {
+			continue
+		}
+
+		if err := h.incomingRepo.UpdateStatusForRecipientUserID(
+			ctx,
+			share.ShareID,
+			share.RecipientUserID,
+			shares.ShareStatusUnshared,
+		); err != nil {
+			h.log.Error("failed to update incoming share status",
+				"provider_id", req.ProviderID,
+				"sender_host", senderHost,
+				"error", err)
+			spec.WriteOCMError(w, http.StatusInternalServerError, "INTERNAL_ERROR")
+
+			return
+		}
+
+		updated++
+
+		metrics.ShareTransition(metrics.DirectionIncoming, string(shares.ShareStatusUnshared))
+		h.audit.Record(ctx, audit.Event{
+			Action:   audit.ActionShareUnshare,
+			Actor:    senderHost,
+			Peer:     senderHost,
+			Resource: share.ShareID,
+			Details:  map[string]string{audit.DetailDirection: audit.DirectionIncoming},
+		})
+	}
+
+	if updated == 0 {
+		writeNotificationSuccess(w)
 
 		return
 	}
 
 	h.log.Info("incoming share unshared via notification",
 		"provider_id", req.ProviderID,
-		"sender_host", senderHost)
+		"sender_host", senderHost,
+		"copies", updated)
 	writeNotificationSuccess(w)
 }
 
@@ -248,7 +297,14 @@ func (h *Handler) senderMatchesHost(senderHost, storedHost string) bool {
 	return normalizedSender == normalizedStored
 }
 
+// isOppositeOutgoingTerminalStatus reports whether a receiver notification
+// would move an outgoing share out of a terminal state. A revoked share stays
+// revoked whatever the receiver reports.
 func isOppositeOutgoingTerminalStatus(current, want shares.OutgoingShareStatus) bool {
+	if current == shares.OutgoingShareStatusRevoked {
+		return true
+	}
+
 	if current == shares.OutgoingShareStatusAccepted {
 		return want == shares.OutgoingShareStatusDeclined
 	}
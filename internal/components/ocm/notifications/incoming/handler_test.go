@@ -167,6 +167,49 @@ func TestHandleNotification_ShareUnsharedUpdatesIncoming(t *testing.T) {
 	}
 }
 
+func TestHandleNotification_ShareUnsharedUpdatesEveryGroupCopy(t *testing.T) {
+	t.Parallel()
+
+	repos := tsrepos.OpenMemory(t)
+	ctx := context.Background()
+
+	for i, recipient := range []string{"user-a", "user-b"} {
+		inShare := &sharesincoming.IncomingShare{
+			ProviderID:      "provider-group",
+			SenderHost:      "sender.example.com",
+			RecipientUserID: recipient,
+			ShareType:       "group",
+			Status:          shares.ShareStatusPending,
+			CreatedAt:       time.Now().Add(time.Duration(i) * time.Second),
+		}
+		if err := repos.IncomingShares.Create(ctx, inShare); err != nil {
+			t.Fatalf("create incoming share for %s: %v", recipient, err)
+		}
+	}
+
+	handler := incoming.NewHandler(repos.OutgoingShares, repos.IncomingShares, "https", nil)
+
+	w := postNotification(t, handler, `{"notificationType":"SHARE_UNSHARED","providerId":"provider-group"}`, "sender.example.com")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	copies, err := repos.IncomingShares.ListByProviderID(ctx, "sender.example.com", "provider-group")
+	if err != nil {
+		t.Fatalf("list incoming shares: %v", err)
+	}
+
+	if len(copies) != 2 {
+		t.Fatalf("copies = %d, want 2", len(copies))
+	}
+
+	for _, c := range copies {
+		if c.Status != shares.ShareStatusUnshared {
+			t.Fatalf("%s status = %q, want unshared", c.RecipientUserID, c.Status)
+		}
+	}
+}
+
 func TestHandleNotification_ShareUnsharedUnauthorizedSender(t *testing.T) {
 	t.Parallel()
 
@@ -227,6 +270,14 @@ func (s *incomingShareLookupStub) GetByProviderID(_ context.Context, _, provider
 	return s.share, nil
 }
 
+func (s *incomingShareLookupStub) ListByProviderID(_ context.Context, _, providerID string) ([]*sharesincoming.IncomingShare, error) {
+	if providerID != s.share.ProviderID {
+		return []*sharesincoming.IncomingShare{}, nil
+	}
+
+	return []*sharesincoming.IncomingShare{s.share}, nil
+}
+
 func TestHandleNotification_OutgoingNotFound(t *testing.T) {
 	t.Parallel()
 
@@ -377,6 +428,23 @@ func TestHandleNotification_DeclinedThenAcceptedReturnsConflict(t *testing.T) {
 	})
 }
 
+func TestHandleNotification_RevokedShareReturnsConflict(t *testing.T) {
+	t.Parallel()
+
+	for _, notificationType := range []string{spec.NotificationTypeShareAccepted, spec.NotificationTypeShareDeclined} {
+		runOutgoingNotificationStatusTest(t, outgoingNotificationCase{
+			notificationType: notificationType,
+			providerID:       "provider-revoked",
+			receiverHost:     "receiver.example.com",
+			senderHost:       "receiver.example.com",
+			initialStatus:    shares.OutgoingShareStatusRevoked,
+			wantHTTP:         http.StatusConflict,
+			wantStatus:       shares.OutgoingShareStatusRevoked,
+			wantMessage:      "SHARE_STATUS_CONFLICT",
+		})
+	}
+}
+
 type outgoingNotificationCase struct {
 	notificationType string
 	providerID       string
@@ -14,12 +14,17 @@ import (
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/notifications"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 )
 
+// notificationsPath is the endpoint path relative to the peer's OCM endpoint.
+const notificationsPath = "notifications"
+
 // Sender posts share lifecycle notifications through the shared outbound Poster.
 type Sender struct {
 	poster *outbound.Poster
+	outbox *outbox.Dispatcher
 }
 
 // NewSender wraps an outbound Poster for notification dispatch.
@@ -27,7 +32,15 @@ func NewSender(poster *outbound.Poster) *Sender {
 	return &Sender{poster: poster}
 }
 
+// SetOutbox queues notifications in the durable outbox instead of posting
+// them inline. The outbox checks the notifications capability at delivery
+// time and dead-letters notifications to peers that do not advertise it.
+func (s *Sender) SetOutbox(d *outbox.Dispatcher) {
+	s.outbox = d
+}
+
 // Notify sends one OCM notification to targetHost. extra is optional opaque JSON.
+// With an outbox set, Notify only queues the notification for delivery.
 func (s *Sender) Notify(
 	ctx context.Context,
 	targetHost string,
@@ -36,6 +49,10 @@ func (s *Sender) Notify(
 	notificationType string,
 	extra json.RawMessage,
 ) error {
+	if s.outbox != nil {
+		return s.enqueue(ctx, targetHost, providerID, resourceType, notificationType, extra)
+	}
+
 	disc, err := s.poster.DiscoverPeer(ctx, targetHost)
 	if err != nil {
 		return fmt.Errorf("ocm: discover peer for notification: %w", err)
@@ -59,7 +76,7 @@ func (s *Sender) Notify(
 
 	resp, err := s.poster.SendResolved(ctx, outbound.Request{
 		TargetHost:   targetHost,
-		EndpointPath: "notifications",
+		EndpointPath: notificationsPath,
 		Kind:         outbound.EndpointNotifications,
 		Body:         body,
 	}, outbound.ResolvedPeer{
@@ -84,3 +101,37 @@ func (s *Sender) Notify(
 
 	return fmt.Errorf("notification rejected with status %d: %s", resp.StatusCode, string(respBody))
 }
+
+func (s *Sender) enqueue(
+	ctx context.Context,
+	targetHost string,
+	providerID string,
+	resourceType string,
+	notificationType string,
+	extra json.RawMessage,
+) error {
+	body, err := json.Marshal(spec.NotificationRequest{
+		NotificationType: notificationType,
+		ProviderID:       providerID,
+		ResourceType:     resourceType,
+		Notification:     extra,
+	})
+	if err != nil {
+		return fmt.Errorf("ocm: encode notification request: %w", err)
+	}
+
+	if err := s.outbox.Enqueue(ctx, &outbox.Message{
+		Topic:     outbox.TopicNotification,
+		SubjectID: providerID,
+		Request: outbound.Request{
+			TargetHost:   targetHost,
+			EndpointPath: notificationsPath,
+			Kind:         outbound.EndpointNotifications,
+			Body:         body,
+		},
+	}); err != nil {
+		return fmt.Errorf("ocm: queue notification: %w", err)
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/notifications"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/notifications/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peerorigin"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 	_ "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache/loader"
@@ -26,6 +27,7 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
 	httpclient "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/client"
 	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
 )
 
 type notifyHarness struct {
@@ -33,6 +35,7 @@ type notifyHarness struct {
 	gotNotificationURL  string
 	gotNotificationBody string
 	notifyStatus        int
+	poster              *outbound.Poster
 }
 
 func newNotifyHarness(t *testing.T, capabilities []string, notifyStatus int) (*outgoing.Sender, *notifyHarness) {
@@ -93,6 +96,8 @@ func newNotifyHarness(t *testing.T, capabilities []string, notifyStatus int) (*o
 		peerorigin.NewResolver(true),
 	)
 
+	h.poster = poster
+
 	return outgoing.NewSender(poster), h
 }
 
@@ -195,3 +200,78 @@ func TestSender_Notify_2xxCreated(t *testing.T) {
 		t.Fatal("expected notification POST")
 	}
 }
+
+func TestSender_Notify_OutboxQueuesUntilDelivered(t *testing.T) {
+	t.Parallel()
+
+	sender, harness := newNotifyHarness(t, []string{spec.CapabilityNotifications}, http.StatusOK)
+	messages := tsrepos.OpenMemory(t).Outbox
+	dispatcher := outbox.NewDispatcher(messages, harness.poster, outbox.Policy{}, nil)
+	sender.SetOutbox(dispatcher)
+
+	err := sender.Notify(
+		context.Background(),
+		harness.targetHost,
+		"provider-1",
+		"file",
+		spec.NotificationTypeShareAccepted,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if harness.gotNotificationURL != "" {
+		t.Fatal("Notify with an outbox must only queue the notification")
+	}
+
+	queued, err := messages.List(t.Context())
+	if err != nil || len(queued) != 1 || queued[0].Topic != outbox.TopicNotification {
+		t.Fatalf("queued messages = %+v (%v), want one notification", queued, err)
+	}
+
+	if _, err := dispatcher.ProcessDue(t.Context()); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+
+	if !strings.Contains(harness.gotNotificationBody, `"notificationType":"SHARE_ACCEPTED"`) {
+		t.Fatalf("body = %q, want share accepted notification", harness.gotNotificationBody)
+	}
+
+	if left, _ := messages.List(t.Context()); len(left) != 0 {
+		t.Fatalf("delivered notification must leave the outbox, %d left", len(left))
+	}
+}
+
+func TestSender_Notify_OutboxDeadLettersWhenNotAdvertised(t *testing.T) {
+	t.Parallel()
+
+	sender, harness := newNotifyHarness(t, nil, http.StatusOK)
+	messages := tsrepos.OpenMemory(t).Outbox
+	dispatcher := outbox.NewDispatcher(messages, harness.poster, outbox.Policy{}, nil)
+	sender.SetOutbox(dispatcher)
+
+	if err := sender.Notify(
+		context.Background(),
+		harness.targetHost,
+		"provider-1",
+		"file",
+		spec.NotificationTypeShareDeclined,
+		nil,
+	); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if _, err := dispatcher.ProcessDue(t.Context()); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+
+	if harness.gotNotificationURL != "" {
+		t.Fatalf("expected no notification POST when capability missing, got %q", harness.gotNotificationURL)
+	}
+
+	left, err := messages.List(t.Context())
+	if err != nil || len(left) != 1 || left[0].Status != outbox.StatusDead {
+		t.Fatalf("outbox = %+v (%v), want one dead notification", left, err)
+	}
+}
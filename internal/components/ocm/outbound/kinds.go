@@ -15,4 +15,6 @@ const (
 	EndpointInvites EndpointKind = "invites"
 	// EndpointNotifications is the notifications outbound endpoint kind.
 	EndpointNotifications EndpointKind = "notifications"
+	// EndpointRequestShare is the request-share outbound endpoint kind.
+	EndpointRequestShare EndpointKind = "request-share"
 )
@@ -15,12 +15,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peerorigin"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
 	httpclient "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/client"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // Poster performs the shared peer-origin resolve, discovery, signing, and HTTP
@@ -77,6 +79,8 @@ func (p *Poster) Send(ctx context.Context, req Request) (*http.Response, error)
 
 	disc, err := p.discoveryClient.Discover(ctx, origin.BaseURL)
 	if err != nil {
+		metrics.OutboundRequest(string(req.Kind), 0, metrics.OutboundDiscoveryError, 0)
+
 		return nil, fmt.Errorf("discovery failed for %s: %w", req.TargetHost, err)
 	}
 
@@ -119,17 +123,23 @@ func (p *Poster) SendResolved(ctx context.Context, req Request, peer ResolvedPee
 		return nil, signErr
 	}
 
+	start := time.Now()
+
 	resp, err := p.httpClient.Do(ctx, httpReq)
 	if err != nil {
+		metrics.OutboundRequest(string(req.Kind), 0, metrics.OutboundError, time.Since(start))
+
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 
+	metrics.OutboundRequest(string(req.Kind), resp.StatusCode, "", time.Since(start))
+
 	return resp, nil
 }
 
 func (p *Poster) applySigning(httpReq *http.Request, req Request, disc *spec.Discovery) error {
 	switch req.Kind {
-	case EndpointShares, EndpointInvites, EndpointNotifications:
+	case EndpointShares, EndpointInvites, EndpointNotifications, EndpointRequestShare:
 		// Only sign when the peer advertises the http-sig capability.
 		// A server implementing http-sig MUST use it when interacting with a
 		// peer advertising http-sig, and MAY interact unsigned with a peer not
@@ -0,0 +1,409 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/notifications"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+const (
+	// dueBatchSize caps how many due messages one worker pass attempts.
+	dueBatchSize = 50
+	// maxResultBodyBytes caps the peer response body kept for completions.
+	maxResultBodyBytes = 64 << 10
+)
+
+// Poster is the outbound transport the dispatcher delivers through;
+// *outbound.Poster satisfies it.
+type Poster interface {
+	DiscoverPeer(ctx context.Context, targetHost string) (*spec.Discovery, error)
+	SendResolved(ctx context.Context, req outbound.Request, peer outbound.ResolvedPeer) (*http.Response, error)
+}
+
+// Result is the peer response to the last delivery attempt. StatusCode is 0
+// when the attempt got no response (discovery or transport failure).
+type Result struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Completion customizes delivery of one topic.
+type Completion struct {
+	// Accept reports whether a non-2xx status still counts as delivered.
+	// Nil accepts 2xx only.
+	Accept func(statusCode int) bool
+	// Done runs once a message reaches delivered or dead, before the final
+	// state is persisted.
+	Done func(ctx context.Context, msg *Message, res Result) error
+}
+
+// Dispatcher persists outbound messages and delivers them with retries.
+// Delivered messages are removed once their completion has run; dead
+// messages stay in the repo as dead letters.
+type Dispatcher struct {
+	repo   MessageRepo
+	poster Poster
+	policy Policy
+	log    *slog.Logger
+	now    func() time.Time
+
+	mu          sync.Mutex
+	completions map[Topic]Completion
+	inflight    map[string]struct{}
+
+	kick   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewDispatcher returns a Dispatcher; call Start to run the background worker.
+func NewDispatcher(repo MessageRepo, poster Poster, policy Policy, log *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		repo:        repo,
+		poster:      poster,
+		policy:      policy.withDefaults(),
+		log:         logutil.NoopIfNil(log),
+		now:         time.Now,
+		completions: make(map[Topic]Completion),
+		inflight:    make(map[string]struct{}),
+		kick:        make(chan struct{}, 1),
+	}
+}
+
+// SetClock replaces the time source; tests use it to step through backoff.
+func (d *Dispatcher) SetClock(now func() time.Time) {
+	d.now = now
+}
+
+// Register installs the completion for topic, replacing any earlier one.
+// Register before Start so recovered messages find their completion.
+func (d *Dispatcher) Register(topic Topic, c Completion) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.completions[topic] = c
+}
+
+// Enqueue persists msg for background delivery and wakes the worker.
+func (d *Dispatcher) Enqueue(ctx context.Context, msg *Message) error {
+	if err := d.create(ctx, msg); err != nil {
+		return err
+	}
+
+	d.wake()
+
+	return nil
+}
+
+// Deliver persists msg and makes its first attempt before returning, so
+// callers can answer synchronously when the peer responds right away. A
+// retryable failure leaves msg pending for the worker. The returned error
+// reports local persistence or completion failures only; check msg.Status
+// for the delivery outcome.
+func (d *Dispatcher) Deliver(ctx context.Context, msg *Message) (Result, error) {
+	if msg.ID == "" {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return Result{}, fmt.Errorf("outbox: generate message id: %w", err)
+		}
+
+		msg.ID = id.String()
+	}
+
+	// Claim before persisting so the worker cannot race the first attempt.
+	if !d.claim(msg.ID) {
+		return Result{}, fmt.Errorf("outbox: message %s is already in flight", msg.ID)
+	}
+	defer d.release(msg.ID)
+
+	if err := d.create(ctx, msg); err != nil {
+		return Result{}, err
+	}
+
+	return d.attempt(ctx, msg)
+}
+
+// ProcessDue attempts every pending message that is due, up to one batch,
+// and returns how many it attempted.
+func (d *Dispatcher) ProcessDue(ctx context.Context) (int, error) {
+	due, err := d.repo.ListDue(ctx, d.now(), dueBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("outbox: list due messages: %w", err)
+	}
+
+	attempted := 0
+
+	for _, msg := range due {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if !d.claim(msg.ID) {
+			continue
+		}
+
+		if _, err := d.attempt(ctx, msg); err != nil {
+			d.log.Error("outbox attempt failed", "message_id", msg.ID, "topic", msg.Topic, "error", err)
+		}
+
+		d.release(msg.ID)
+
+		attempted++
+	}
+
+	if len(due) == dueBatchSize {
+		d.wake()
+	}
+
+	return attempted, nil
+}
+
+// Start runs the background worker until Close. It processes messages left
+// pending by an earlier run right away.
+func (d *Dispatcher) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	go d.run(ctx)
+}
+
+// Close stops the background worker and waits for the current pass. Safe to
+// call when the worker never started.
+func (d *Dispatcher) Close() error {
+	d.mu.Lock()
+	cancel, done := d.cancel, d.done
+	d.cancel = nil
+	d.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	<-done
+
+	return nil
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.policy.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.ProcessDue(ctx); err != nil && ctx.Err() == nil {
+			d.log.Warn("outbox worker pass failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.kick:
+		}
+	}
+}
+
+func (d *Dispatcher) wake() {
+	select {
+	case d.kick <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Dispatcher) claim(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, busy := d.inflight[id]; busy {
+		return false
+	}
+
+	d.inflight[id] = struct{}{}
+
+	return true
+}
+
+func (d *Dispatcher) release(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.inflight, id)
+}
+
+func (d *Dispatcher) completion(topic Topic) Completion {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.completions[topic]
+}
+
+func (d *Dispatcher) create(ctx context.Context, msg *Message) error {
+	now := d.now()
+
+	msg.Status = StatusPending
+	msg.Attempts = 0
+	msg.NextAttemptAt = now
+	msg.CreatedAt = now
+	msg.UpdatedAt = now
+
+	if msg.MaxAttempts <= 0 {
+		msg.MaxAttempts = d.policy.MaxAttempts
+	}
+
+	if err := d.repo.Create(ctx, msg); err != nil {
+		return fmt.Errorf("outbox: persist message: %w", err)
+	}
+
+	return nil
+}
+
+// attempt makes one delivery attempt for a claimed message and records the
+// outcome. The attempt runs detached from ctx cancellation so a cancelled
+// caller cannot abort a POST the peer may already have accepted.
+func (d *Dispatcher) attempt(ctx context.Context, msg *Message) (Result, error) {
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.policy.AttemptTimeout)
+	defer cancel()
+
+	completion := d.completion(msg.Topic)
+	res, permanent, sendErr := d.send(sendCtx, msg, completion.Accept)
+
+	now := d.now()
+	msg.Attempts++
+	msg.UpdatedAt = now
+	msg.LastStatusCode = res.StatusCode
+
+	switch {
+	case sendErr == nil:
+		msg.Status = StatusDelivered
+		msg.DeliveredAt = &now
+		msg.LastError = ""
+	case permanent || msg.Attempts >= msg.MaxAttempts:
+		msg.Status = StatusDead
+		msg.LastError = sendErr.Error()
+	default:
+		msg.NextAttemptAt = now.Add(d.policy.Backoff(msg.Attempts))
+		msg.LastError = sendErr.Error()
+
+		d.log.Info("outbox delivery failed, will retry",
+			"message_id", msg.ID,
+			"topic", msg.Topic,
+			"target_host", msg.Request.TargetHost,
+			"attempts", msg.Attempts,
+			"next_attempt_at", msg.NextAttemptAt,
+			"error", sendErr)
+
+		if err := d.repo.Update(sendCtx, msg); err != nil {
+			return res, fmt.Errorf("outbox: record failed attempt: %w", err)
+		}
+
+		return res, nil
+	}
+
+	return res, d.finish(sendCtx, msg, res, completion)
+}
+
+// finish runs the completion for a delivered or dead message, then removes
+// delivered messages and persists dead ones.
+func (d *Dispatcher) finish(ctx context.Context, msg *Message, res Result, completion Completion) error {
+	var doneErr error
+	if completion.Done != nil {
+		if err := completion.Done(ctx, msg, res); err != nil {
+			doneErr = fmt.Errorf("outbox: complete message: %w", err)
+		}
+	}
+
+	var storeErr error
+
+	if msg.Status == StatusDelivered {
+		if err := d.repo.Delete(ctx, msg.ID); err != nil {
+			storeErr = fmt.Errorf("outbox: remove delivered message: %w", err)
+		}
+	} else {
+		d.log.Warn("outbox message dead-lettered",
+			"message_id", msg.ID,
+			"topic", msg.Topic,
+			"target_host", msg.Request.TargetHost,
+			"attempts", msg.Attempts,
+			"error", msg.LastError)
+
+		if err := d.repo.Update(ctx, msg); err != nil {
+			storeErr = fmt.Errorf("outbox: record dead message: %w", err)
+		}
+	}
+
+	return errors.Join(doneErr, storeErr)
+}
+
+// send discovers the peer and posts the message. permanent reports failures
+// that retrying cannot fix: a 4xx rejection other than 408, 425, and 429, or
+// a notification to a peer that does not advertise notifications.
+func (d *Dispatcher) send(ctx context.Context, msg *Message, accept func(int) bool) (Result, bool, error) {
+	disc, err := d.poster.DiscoverPeer(ctx, msg.Request.TargetHost)
+	if err != nil {
+		return Result{}, false, fmt.Errorf("outbox: discover peer: %w", err)
+	}
+
+	if msg.Request.Kind == outbound.EndpointNotifications && !disc.HasCapability(spec.CapabilityNotifications) {
+		return Result{}, true, notifications.ErrNotificationsNotAdvertised
+	}
+
+	resp, err := d.poster.SendResolved(ctx, msg.Request, outbound.ResolvedPeer{Discovery: disc})
+	if err != nil {
+		return Result{}, false, fmt.Errorf("outbox: send: %w", err)
+	}
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResultBodyBytes))
+	if err != nil {
+		return Result{StatusCode: resp.StatusCode}, false, fmt.Errorf("outbox: read response: %w", err)
+	}
+
+	res := Result{StatusCode: resp.StatusCode, Body: body}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 || accept != nil && accept(resp.StatusCode) {
+		return res, false, nil
+	}
+
+	return res, !retryableStatus(resp.StatusCode),
+		fmt.Errorf("peer returned status %d (response body %d bytes)", resp.StatusCode, len(body))
+}
+
+// retryableStatus reports whether a non-success status is worth retrying.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	default:
+		return code >= http.StatusInternalServerError || code < http.StatusBadRequest
+	}
+}
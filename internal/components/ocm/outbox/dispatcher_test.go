@@ -0,0 +1,457 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+// fakePoster answers discovery from disc (or discErr) and POSTs with the
+// scripted statuses, repeating the last one.
+type fakePoster struct {
+	mu       sync.Mutex
+	disc     *spec.Discovery
+	discErr  error
+	sendErr  error
+	statuses []int
+	body     string
+	sent     []outbound.Request
+}
+
+func newFakePoster(statuses ...int) *fakePoster {
+	return &fakePoster{
+		disc:     &spec.Discovery{Enabled: true, EndPoint: "https://peer.example/ocm"},
+		statuses: statuses,
+	}
+}
+
+func (p *fakePoster) DiscoverPeer(context.Context, string) (*spec.Discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discErr != nil {
+		return nil, p.discErr
+	}
+
+	return p.disc, nil
+}
+
+func (p *fakePoster) SendResolved(_ context.Context, req outbound.Request, _ outbound.ResolvedPeer) (*http.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sent = append(p.sent, req)
+
+	if p.sendErr != nil {
+		return nil, p.sendErr
+	}
+
+	status := p.statuses[min(len(p.sent), len(p.statuses))-1]
+
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(p.body))}, nil
+}
+
+func (p *fakePoster) sendCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.sent)
+}
+
+type dispatcherFixture struct {
+	repo   outbox.MessageRepo
+	poster *fakePoster
+	d      *outbox.Dispatcher
+	now    time.Time
+
+	mu    sync.Mutex
+	done  []outbox.Message
+	doneR []outbox.Result
+}
+
+func newDispatcherFixture(t *testing.T, poster *fakePoster) *dispatcherFixture {
+	t.Helper()
+
+	f := &dispatcherFixture{
+		repo:   tsrepos.OpenMemory(t).Outbox,
+		poster: poster,
+		now:    time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.d = newFixtureDispatcher(f)
+
+	return f
+}
+
+// newFixtureDispatcher builds a dispatcher over the fixture's repo and poster,
+// the way a restarted process would.
+func newFixtureDispatcher(f *dispatcherFixture) *outbox.Dispatcher {
+	d := outbox.NewDispatcher(f.repo, f.poster, outbox.Policy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     10 * time.Minute,
+		PollInterval:   10 * time.Millisecond,
+	}, nil)
+	d.SetClock(func() time.Time {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		return f.now
+	})
+	d.Register(outbox.TopicShare, outbox.Completion{Done: f.recordDone})
+
+	return d
+}
+
+func (f *dispatcherFixture) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+}
+
+func (f *dispatcherFixture) recordDone(_ context.Context, msg *outbox.Message, res outbox.Result) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.done = append(f.done, *msg)
+	f.doneR = append(f.doneR, res)
+
+	return nil
+}
+
+func (f *dispatcherFixture) doneCalls() []outbox.Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]outbox.Message(nil), f.done...)
+}
+
+func newShareMessage() *outbox.Message {
+	return &outbox.Message{
+		Topic:     outbox.TopicShare,
+		SubjectID: "share-1",
+		Request: outbound.Request{
+			TargetHost:   "peer.example",
+			EndpointPath: "shares",
+			Kind:         outbound.EndpointShares,
+			Body:         []byte(`{"name":"a.txt"}`),
+		},
+	}
+}
+
+func TestDeliver_SuccessRunsCompletionAndRemovesMessage(t *testing.T) {
+	t.Parallel()
+
+	poster := newFakePoster(http.StatusCreated)
+	poster.body = `{"ok":true}`
+	f := newDispatcherFixture(t, poster)
+	msg := newShareMessage()
+
+	res, err := f.d.Deliver(t.Context(), msg)
+	if err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	if msg.ID == "" || msg.Status != outbox.StatusDelivered || msg.Attempts != 1 || msg.DeliveredAt == nil {
+		t.Fatalf("message after delivery = %+v", msg)
+	}
+
+	if res.StatusCode != http.StatusCreated || string(res.Body) != `{"ok":true}` {
+		t.Errorf("result = %d %q", res.StatusCode, res.Body)
+	}
+
+	done := f.doneCalls()
+	if len(done) != 1 || done[0].Status != outbox.StatusDelivered || done[0].SubjectID != "share-1" {
+		t.Fatalf("completions = %+v, want one delivered call", done)
+	}
+
+	if _, err := f.repo.GetByID(t.Context(), msg.ID); !errors.Is(err, outbox.ErrMessageNotFound) {
+		t.Fatalf("delivered message must be removed, GetByID err = %v", err)
+	}
+}
+
+func TestDeliver_RetryableFailuresBackOff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		setup      func(p *fakePoster)
+		wantStatus int
+	}{
+		{"server error", func(p *fakePoster) { p.statuses = []int{http.StatusServiceUnavailable} }, http.StatusServiceUnavailable},
+		{"too many requests", func(p *fakePoster) { p.statuses = []int{http.StatusTooManyRequests} }, http.StatusTooManyRequests},
+		{"transport error", func(p *fakePoster) { p.sendErr = errors.New("connection refused") }, 0},
+		{"discovery error", func(p *fakePoster) { p.discErr = errors.New("no such host") }, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			poster := newFakePoster(http.StatusCreated)
+			tc.setup(poster)
+			f := newDispatcherFixture(t, poster)
+			msg := newShareMessage()
+
+			if _, err := f.d.Deliver(t.Context(), msg); err != nil {
+				t.Fatalf("Deliver: %v", err)
+			}
+
+			stored, err := f.repo.GetByID(t.Context(), msg.ID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+
+			if stored.Status != outbox.StatusPending || stored.Attempts != 1 || stored.LastError == "" ||
+				stored.LastStatusCode != tc.wantStatus {
+				t.Fatalf("stored message = %+v", stored)
+			}
+
+			if want := f.now.Add(time.Minute); !stored.NextAttemptAt.Equal(want) {
+				t.Errorf("NextAttemptAt = %v, want %v", stored.NextAttemptAt, want)
+			}
+
+			if len(f.doneCalls()) != 0 {
+				t.Error("completion must not run while the message is still pending")
+			}
+		})
+	}
+}
+
+func TestDeliver_PermanentRejectionIsDeadLettered(t *testing.T) {
+	t.Parallel()
+
+	f := newDispatcherFixture(t, newFakePoster(http.StatusNotFound))
+	msg := newShareMessage()
+
+	if _, err := f.d.Deliver(t.Context(), msg); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	stored, err := f.repo.GetByID(t.Context(), msg.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.Status != outbox.StatusDead || stored.Attempts != 1 || stored.LastStatusCode != http.StatusNotFound {
+		t.Fatalf("stored message = %+v, want dead after one attempt", stored)
+	}
+
+	done := f.doneCalls()
+	if len(done) != 1 || done[0].Status != outbox.StatusDead {
+		t.Fatalf("completions = %+v, want one dead call", done)
+	}
+}
+
+func TestProcessDue_RetriesUntilMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	f := newDispatcherFixture(t, newFakePoster(http.StatusBadGateway))
+	msg := newShareMessage()
+
+	if _, err := f.d.Deliver(t.Context(), msg); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	// Not yet due: the first backoff is one minute.
+	if n, err := f.d.ProcessDue(t.Context()); err != nil || n != 0 {
+		t.Fatalf("ProcessDue before backoff = %d, %v", n, err)
+	}
+
+	f.advance(time.Minute)
+
+	if n, err := f.d.ProcessDue(t.Context()); err != nil || n != 1 {
+		t.Fatalf("second attempt = %d, %v", n, err)
+	}
+
+	stored, err := f.repo.GetByID(t.Context(), msg.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	// The second failure doubles the backoff.
+	if want := f.now.Add(2 * time.Minute); stored.Attempts != 2 || !stored.NextAttemptAt.Equal(want) {
+		t.Fatalf("after second attempt: attempts %d next %v, want 2 and %v", stored.Attempts, stored.NextAttemptAt, want)
+	}
+
+	f.advance(2 * time.Minute)
+
+	if n, err := f.d.ProcessDue(t.Context()); err != nil || n != 1 {
+		t.Fatalf("third attempt = %d, %v", n, err)
+	}
+
+	stored, err = f.repo.GetByID(t.Context(), msg.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if stored.Status != outbox.StatusDead || stored.Attempts != 3 {
+		t.Fatalf("stored message = %+v, want dead after 3 attempts", stored)
+	}
+
+	f.advance(time.Hour)
+
+	if n, err := f.d.ProcessDue(t.Context()); err != nil || n != 0 {
+		t.Fatalf("dead messages must not be retried, ProcessDue = %d, %v", n, err)
+	}
+
+	if got := f.poster.sendCount(); got != 3 {
+		t.Errorf("POSTs = %d, want 3", got)
+	}
+}
+
+func TestDeliver_AcceptTreatsStatusAsDelivered(t *testing.T) {
+	t.Parallel()
+
+	f := newDispatcherFixture(t, newFakePoster(http.StatusConflict))
+	f.d.Register(outbox.TopicShare, outbox.Completion{
+		Accept: func(code int) bool { return code == http.StatusConflict },
+		Done:   f.recordDone,
+	})
+
+	msg := newShareMessage()
+
+	res, err := f.d.Deliver(t.Context(), msg)
+	if err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	if msg.Status != outbox.StatusDelivered || res.StatusCode != http.StatusConflict {
+		t.Fatalf("status %q result %d, want delivered with 409", msg.Status, res.StatusCode)
+	}
+}
+
+func TestDeliver_NotificationWithoutCapabilityIsDead(t *testing.T) {
+	t.Parallel()
+
+	f := newDispatcherFixture(t, newFakePoster(http.StatusCreated))
+	msg := &outbox.Message{
+		Topic:     outbox.TopicNotification,
+		SubjectID: "provider-1",
+		Request: outbound.Request{
+			TargetHost:   "peer.example",
+			EndpointPath: "notifications",
+			Kind:         outbound.EndpointNotifications,
+			Body:         []byte(`{}`),
+		},
+	}
+
+	if _, err := f.d.Deliver(t.Context(), msg); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	if msg.Status != outbox.StatusDead || msg.LastError == "" {
+		t.Fatalf("message = %+v, want dead", msg)
+	}
+
+	if got := f.poster.sendCount(); got != 0 {
+		t.Fatalf("POSTs = %d, want none to a peer without notifications", got)
+	}
+}
+
+func TestDeliver_CompletionErrorStillRemovesDeliveredMessage(t *testing.T) {
+	t.Parallel()
+
+	f := newDispatcherFixture(t, newFakePoster(http.StatusOK))
+	f.d.Register(outbox.TopicShare, outbox.Completion{
+		Done: func(context.Context, *outbox.Message, outbox.Result) error {
+			return errors.New("local record gone")
+		},
+	})
+
+	msg := newShareMessage()
+
+	if _, err := f.d.Deliver(t.Context(), msg); err == nil {
+		t.Fatal("Deliver must report the completion error")
+	}
+
+	if _, err := f.repo.GetByID(t.Context(), msg.ID); !errors.Is(err, outbox.ErrMessageNotFound) {
+		t.Fatalf("delivered message must be removed even when completion fails, err = %v", err)
+	}
+}
+
+func TestProcessDue_RecoversMessagesFromEarlierRun(t *testing.T) {
+	t.Parallel()
+
+	f := newDispatcherFixture(t, newFakePoster(http.StatusServiceUnavailable, http.StatusCreated))
+	msg := newShareMessage()
+
+	if _, err := f.d.Deliver(t.Context(), msg); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+
+	// A fresh dispatcher over the same repo stands in for a restarted process.
+	restarted := newFixtureDispatcher(f)
+
+	f.advance(time.Minute)
+
+	if n, err := restarted.ProcessDue(t.Context()); err != nil || n != 1 {
+		t.Fatalf("ProcessDue after restart = %d, %v", n, err)
+	}
+
+	done := f.doneCalls()
+	if len(done) != 1 || done[0].ID != msg.ID || done[0].Status != outbox.StatusDelivered || done[0].Attempts != 2 {
+		t.Fatalf("completions = %+v, want the recovered message delivered on attempt 2", done)
+	}
+}
+
+func TestStart_WorkerDeliversEnqueuedMessages(t *testing.T) {
+	t.Parallel()
+
+	f := newDispatcherFixture(t, newFakePoster(http.StatusCreated))
+	f.d.Start()
+	f.d.Start() // idempotent
+
+	msg := newShareMessage()
+
+	if err := f.d.Enqueue(t.Context(), msg); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(f.doneCalls()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the worker to deliver")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := f.d.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if err := f.d.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+
+	if _, err := f.repo.GetByID(t.Context(), msg.ID); !errors.Is(err, outbox.ErrMessageNotFound) {
+		t.Fatalf("delivered message must be removed, err = %v", err)
+	}
+}
+
+func TestClose_WithoutStart(t *testing.T) {
+	t.Parallel()
+
+	f := newDispatcherFixture(t, newFakePoster(http.StatusCreated))
+
+	if err := f.d.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
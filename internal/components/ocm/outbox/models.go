@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package outbox is the durable delivery queue for outbound OCM POSTs (new
+// shares, notifications, and invite-accepted calls). Messages are persisted
+// before the first attempt, retried with exponential backoff, and moved to a
+// dead-letter state when the peer rejects them or attempts run out.
+package outbox
+
+import (
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+)
+
+// Status is the delivery state of an outbox message.
+type Status string
+
+const (
+	// StatusPending messages are waiting for their next attempt.
+	StatusPending Status = "pending"
+	// StatusDelivered messages were accepted by the peer.
+	StatusDelivered Status = "delivered"
+	// StatusDead messages were rejected or exhausted their attempts.
+	StatusDead Status = "dead"
+)
+
+// Topic names what a message delivers; completions are registered per topic.
+type Topic string
+
+const (
+	// TopicShare delivers a new share to its receiver.
+	TopicShare Topic = "share"
+	// TopicNotification delivers a share lifecycle notification.
+	TopicNotification Topic = "notification"
+	// TopicInviteAccepted delivers an invite-accepted call to the inviter.
+	TopicInviteAccepted Topic = "invite-accepted"
+)
+
+// Message is one queued outbound POST and its delivery bookkeeping.
+type Message struct {
+	ID    string
+	Topic Topic
+	// SubjectID is the local record the message is about (share id, invite
+	// id); UserID scopes it when the record is user-owned.
+	SubjectID string
+	UserID    string
+	Request   outbound.Request
+
+	Status         Status
+	Attempts       int
+	MaxAttempts    int
+	NextAttemptAt  time.Time
+	LastError      string
+	LastStatusCode int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeliveredAt    *time.Time
+}
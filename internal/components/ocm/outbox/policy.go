@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package outbox
+
+import "time"
+
+// Policy defaults.
+const (
+	DefaultMaxAttempts    = 10
+	DefaultInitialBackoff = 30 * time.Second
+	DefaultMaxBackoff     = time.Hour
+	DefaultPollInterval   = 5 * time.Second
+	DefaultAttemptTimeout = 30 * time.Second
+)
+
+// Policy controls retry pacing. Zero fields fall back to the defaults.
+type Policy struct {
+	// MaxAttempts is the number of attempts before a message goes dead.
+	MaxAttempts int
+	// InitialBackoff is the delay after the first failed attempt; each further
+	// failure doubles it up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// PollInterval is how often the worker looks for due messages.
+	PollInterval time.Duration
+	// AttemptTimeout bounds one delivery attempt, discovery included.
+	AttemptTimeout time.Duration
+}
+
+// withDefaults returns p with zero fields replaced by the defaults.
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultMaxAttempts
+	}
+
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultInitialBackoff
+	}
+
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultMaxBackoff
+	}
+
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+
+	if p.PollInterval <= 0 {
+		p.PollInterval = DefaultPollInterval
+	}
+
+	if p.AttemptTimeout <= 0 {
+		p.AttemptTimeout = DefaultAttemptTimeout
+	}
+
+	return p
+}
+
+// Backoff returns the delay before the next attempt once attempts attempts
+// have failed: InitialBackoff doubled per extra failure, capped at MaxBackoff.
+func (p Policy) Backoff(attempts int) time.Duration {
+	p = p.withDefaults()
+
+	delay := p.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		if delay >= p.MaxBackoff/2 {
+			return p.MaxBackoff
+		}
+
+		delay *= 2
+	}
+
+	return min(delay, p.MaxBackoff)
+}
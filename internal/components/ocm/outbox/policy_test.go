@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package outbox_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+)
+
+func TestPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	p := outbox.Policy{InitialBackoff: 10 * time.Second, MaxBackoff: time.Minute}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{4, time.Minute},
+		{50, time.Minute},
+	}
+
+	for _, tc := range tests {
+		if got := p.Backoff(tc.attempts); got != tc.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tc.attempts, got, tc.want)
+		}
+	}
+}
+
+func TestPolicy_BackoffDefaults(t *testing.T) {
+	t.Parallel()
+
+	var p outbox.Policy
+
+	if got := p.Backoff(1); got != outbox.DefaultInitialBackoff {
+		t.Errorf("Backoff(1) = %v, want %v", got, outbox.DefaultInitialBackoff)
+	}
+
+	if got := p.Backoff(100); got != outbox.DefaultMaxBackoff {
+		t.Errorf("Backoff(100) = %v, want %v", got, outbox.DefaultMaxBackoff)
+	}
+}
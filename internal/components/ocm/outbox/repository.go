@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMessageNotFound is returned when an outbox message is not found.
+var ErrMessageNotFound = errors.New("outbox message not found")
+
+// MessageRepo persists outbox messages. ListDue returns pending messages due
+// at or before now, oldest due first, capped at limit when limit > 0.
+type MessageRepo interface {
+	Create(ctx context.Context, msg *Message) error
+	GetByID(ctx context.Context, id string) (*Message, error)
+	Update(ctx context.Context, msg *Message) error
+	Delete(ctx context.Context, id string) error
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*Message, error)
+	List(ctx context.Context) ([]*Message, error)
+}
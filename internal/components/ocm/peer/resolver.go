@@ -52,6 +52,29 @@ func (p *Resolver) ResolveSharesRequest(_ *http.Request, body []byte) (string, e
 	return provider, nil
 }
 
+// ResolveRequestShareRequest extracts the requester provider (last-@ of
+// shareWith) from POST /ocm/request-share.
+func (p *Resolver) ResolveRequestShareRequest(_ *http.Request, body []byte) (string, error) {
+	var req struct {
+		ShareWith string `json:"shareWith"`
+	}
+
+	if err := json.Unmarshal(body, &req); err != nil {
+		return "", fmt.Errorf("failed to parse request-share request: %w", err)
+	}
+
+	if req.ShareWith == "" {
+		return "", errors.New("no shareWith in request-share request")
+	}
+
+	_, provider, err := address.Parse(req.ShareWith)
+	if err != nil {
+		return "", fmt.Errorf("ocm: parse request-share shareWith address: %w", err)
+	}
+
+	return provider, nil
+}
+
 // ResolveInviteAcceptedRequest extracts the recipient provider from POST /ocm/invite-accepted.
 func (p *Resolver) ResolveInviteAcceptedRequest(_ *http.Request, body []byte) (string, error) {
 	var req struct {
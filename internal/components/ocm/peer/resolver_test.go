@@ -96,6 +96,33 @@ func TestResolveSharesRequest_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestResolveRequestShareRequest(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"owner":"alice@owner.example","shareWith":"bob@team@requester.example:8443","share":"p-1"}`)
+	r := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/request-share", nil)
+
+	got, err := NewResolver().ResolveRequestShareRequest(r, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != "requester.example:8443" {
+		t.Errorf("got %q, want %q", got, "requester.example:8443")
+	}
+}
+
+func TestResolveRequestShareRequest_MissingShareWith(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"owner":"alice@owner.example","share":"p-1"}`)
+	r := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/request-share", nil)
+
+	if _, err := NewResolver().ResolveRequestShareRequest(r, body); err == nil {
+		t.Error("expected error for missing shareWith")
+	}
+}
+
 func TestResolveInviteAcceptedRequest(t *testing.T) {
 	t.Parallel()
 
@@ -8,6 +8,7 @@ package peertrust
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -32,16 +33,28 @@ func LoadTrustGroupConfig(path string) (*TrustGroupConfig, error) {
 		return nil, fmt.Errorf("reading trust group config %s: %w", path, err)
 	}
 
+	cfg, err := ParseTrustGroupConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("trust group config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// ParseTrustGroupConfig decodes and validates one trust group JSON document,
+// as read from a config file or sent to the admin API. Unknown JSON keys and
+// trailing content are rejected.
+func ParseTrustGroupConfig(data []byte) (*TrustGroupConfig, error) {
 	dec := json.NewDecoder(bytes.NewReader(data))
 	dec.DisallowUnknownFields()
 
 	var cfg TrustGroupConfig
 	if err := dec.Decode(&cfg); err != nil {
-		return nil, fmt.Errorf("decoding trust group config %s: %w", path, err)
+		return nil, fmt.Errorf("decoding trust group config: %w", err)
 	}
 
 	if err := dec.Decode(&json.RawMessage{}); err != io.EOF {
-		return nil, fmt.Errorf("trust group config %s: unexpected trailing content after JSON object", path)
+		return nil, errors.New("unexpected trailing content after JSON object")
 	}
 
 	// Validate directory service verification policies.
@@ -50,7 +63,7 @@ func LoadTrustGroupConfig(path string) (*TrustGroupConfig, error) {
 		case "", "required", "optional", "off":
 			// valid
 		default:
-			return nil, fmt.Errorf("trust group config %s: directoryServices[%d] has invalid verification value %q (must be required, optional, or off)", path, i, ds.Verification)
+			return nil, fmt.Errorf("directoryServices[%d] has invalid verification value %q (must be required, optional, or off)", i, ds.Verification)
 		}
 	}
 
@@ -8,7 +8,10 @@ package peertrust
 import (
 	"context"
 	"log/slog"
+	"maps"
 	"net/url"
+	"reflect"
+	"slices"
 	"sync"
 	"time"
 
@@ -41,11 +44,21 @@ type TrustGroupManager struct {
 	scheme                 string // for hostport.Normalize (from PublicScheme)
 	logger                 *slog.Logger
 	refreshTimeout         time.Duration
+	listingsListeners      []func()
 }
 
+// Trust group sources.
+const (
+	// SourceConfig marks a trust group loaded from peer_trust.config_paths.
+	SourceConfig = "config"
+	// SourceAdmin marks a trust group attached through the admin API.
+	SourceAdmin = "admin"
+)
+
 // TrustGroup represents a single trust group with its state.
 type TrustGroup struct {
 	config            *TrustGroupConfig
+	attached          bool                       // attached at runtime rather than loaded from a file
 	memberAuthorities []memberAuthority          // precomputed normalized host authorities
 	directoryListings []directoryservice.Listing // cached listings (verified and unverified)
 	lastRefresh       time.Time
@@ -95,6 +108,196 @@ func (m *TrustGroupManager) AddTrustGroup(cfg *TrustGroupConfig) {
 	}
 }
 
+// ReplaceTrustGroups swaps the configured trust groups for cfgs in one step.
+// A group whose ID, directory services, and keys are unchanged keeps its
+// cached listings; any other group starts unrefreshed. Attached groups are
+// kept unless a configured group now uses the same ID.
+func (m *TrustGroupManager) ReplaceTrustGroups(cfgs []*TrustGroupConfig) {
+	m.mu.Lock()
+
+	next := make(map[string]*TrustGroup, len(cfgs))
+
+	for _, cfg := range cfgs {
+		tg := &TrustGroup{config: cfg}
+
+		// Copy rather than reuse the previous group: a refresh in flight
+		// reads its config without m.mu.
+		if prev, ok := m.trustGroups[cfg.TrustGroupID]; ok && sameDirectorySources(prev.config, cfg) {
+			tg.memberAuthorities = prev.memberAuthorities
+			tg.directoryListings = prev.directoryListings
+			tg.lastRefresh = prev.lastRefresh
+		}
+
+		next[cfg.TrustGroupID] = tg
+	}
+
+	for id, tg := range m.trustGroups {
+		if !tg.attached {
+			continue
+		}
+
+		if _, shadowed := next[id]; shadowed {
+			m.logger.Warn("configured trust group replaces the attached one with the same ID", "trust_group", id)
+
+			continue
+		}
+
+		next[id] = tg
+	}
+
+	m.trustGroups = next
+	m.mu.Unlock()
+
+	m.notifyListingsChanged()
+}
+
+// AttachTrustGroup adds a trust group at runtime. It returns
+// ErrTrustGroupExists when a configured or attached group has the same ID.
+// The group is refreshed on first use, or by RefreshTrustGroups.
+func (m *TrustGroupManager) AttachTrustGroup(cfg *TrustGroupConfig) error {
+	m.mu.Lock()
+
+	if _, exists := m.trustGroups[cfg.TrustGroupID]; exists {
+		m.mu.Unlock()
+
+		return ErrTrustGroupExists
+	}
+
+	m.trustGroups[cfg.TrustGroupID] = &TrustGroup{config: cfg, attached: true}
+	m.mu.Unlock()
+
+	m.notifyListingsChanged()
+
+	return nil
+}
+
+// DetachTrustGroup removes a trust group added by AttachTrustGroup. It
+// returns ErrTrustGroupNotFound when no attached group has the ID; configured
+// groups are not detached.
+func (m *TrustGroupManager) DetachTrustGroup(trustGroupID string) error {
+	m.mu.Lock()
+
+	tg, exists := m.trustGroups[trustGroupID]
+	if !exists || !tg.attached {
+		m.mu.Unlock()
+
+		return ErrTrustGroupNotFound
+	}
+
+	delete(m.trustGroups, trustGroupID)
+	m.mu.Unlock()
+
+	m.notifyListingsChanged()
+
+	return nil
+}
+
+// TrustGroupStatus describes a trust group and its cached membership.
+type TrustGroupStatus struct {
+	Config *TrustGroupConfig
+	// Source is SourceConfig or SourceAdmin.
+	Source string
+	// Members counts the distinct member hosts in the cached listings.
+	Members int
+	// LastRefresh is zero until a refresh stores listings.
+	LastRefresh time.Time
+	Refreshing  bool
+}
+
+// TrustGroupStatuses returns the status of every trust group, ordered by ID.
+func (m *TrustGroupManager) TrustGroupStatuses() []TrustGroupStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]TrustGroupStatus, 0, len(m.trustGroups))
+
+	for _, id := range slices.Sorted(maps.Keys(m.trustGroups)) {
+		tg := m.trustGroups[id]
+
+		status := TrustGroupStatus{
+			Config:      tg.config,
+			Source:      SourceConfig,
+			Members:     len(tg.memberAuthorities),
+			LastRefresh: tg.lastRefresh,
+		}
+
+		if tg.attached {
+			status.Source = SourceAdmin
+		}
+
+		tg.refreshMu.Lock()
+		status.Refreshing = tg.refreshing
+		tg.refreshMu.Unlock()
+
+		result = append(result, status)
+	}
+
+	return result
+}
+
+// RefreshTrustGroups refreshes every enabled trust group now, regardless of
+// the cache TTL, and returns when the refreshes finish. A group whose refresh
+// is already in flight is left to it. A directory service that fails keeps
+// the cached listings, as in a background refresh.
+func (m *TrustGroupManager) RefreshTrustGroups(ctx context.Context) {
+	m.mu.RLock()
+
+	groups := make([]*TrustGroup, 0, len(m.trustGroups))
+	for _, tg := range m.trustGroups {
+		if tg.config.Enabled {
+			groups = append(groups, tg)
+		}
+	}
+
+	m.mu.RUnlock()
+
+	var wg sync.WaitGroup
+
+	for _, tg := range groups {
+		if !tg.startRefresh() {
+			continue
+		}
+
+		wg.Go(func() {
+			ctx, cancel := context.WithTimeout(ctx, m.refreshTimeoutFor(tg))
+			defer cancel()
+
+			m.refreshTrustGroup(ctx, tg)
+		})
+	}
+
+	wg.Wait()
+}
+
+// OnListingsChanged registers fn to run after the cached listings change: a
+// trust group refresh stored new listings or ReplaceTrustGroups swapped the
+// groups. fn runs on the goroutine that made the change and must not block.
+func (m *TrustGroupManager) OnListingsChanged(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.listingsListeners = append(m.listingsListeners, fn)
+}
+
+// notifyListingsChanged calls the OnListingsChanged listeners. The caller
+// must not hold m.mu: the listeners are copied under the lock and run after
+// it is released, so they may call back into the manager.
+func (m *TrustGroupManager) notifyListingsChanged() {
+	m.mu.RLock()
+	listeners := slices.Clone(m.listingsListeners)
+	m.mu.RUnlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// sameDirectorySources reports whether two configs of a trust group fetch
+// and verify listings the same way, so cached listings stay valid.
+func sameDirectorySources(a, b *TrustGroupConfig) bool {
+	return reflect.DeepEqual(a.DirectoryServices, b.DirectoryServices) && reflect.DeepEqual(a.Keys, b.Keys)
+}
+
 // IsMember checks if a host is a member of any enabled trust group.
 // When requireVerified is true, only members from verified directory listings match.
 func (m *TrustGroupManager) IsMember(ctx context.Context, host string, requireVerified bool) bool {
@@ -136,14 +339,16 @@ func (m *TrustGroupManager) GetTrustGroups() []*TrustGroupConfig {
 }
 
 // GetDirectoryListings returns all cached Directory Service listings (verified
-// and unverified) for enabled trust groups. Consumed by ocmaux handler.
+// and unverified) for enabled trust groups, ordered by trust group ID.
+// Consumed by ocmaux handler.
 func (m *TrustGroupManager) GetDirectoryListings(ctx context.Context) []directoryservice.Listing {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	var allListings []directoryservice.Listing
 
-	for _, tg := range m.trustGroups {
+	for _, id := range slices.Sorted(maps.Keys(m.trustGroups)) {
+		tg := m.trustGroups[id]
 		if !tg.config.Enabled {
 			continue
 		}
@@ -158,19 +363,24 @@ func (m *TrustGroupManager) GetDirectoryListings(ctx context.Context) []director
 	return allListings
 }
 
-// SetCacheForTesting allows tests to set cache directly.
+// SetCacheForTesting allows tests to set cache directly. Like a refresh, it
+// notifies the OnListingsChanged listeners.
 func (m *TrustGroupManager) SetCacheForTesting(trustGroupID string, listings []directoryservice.Listing, lastRefresh time.Time) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
 	tg, ok := m.trustGroups[trustGroupID]
 	if !ok {
+		m.mu.Unlock()
+
 		return
 	}
 
 	tg.directoryListings = listings
 	tg.lastRefresh = lastRefresh
 	tg.memberAuthorities = m.precomputeAuthorities(listings)
+	m.mu.Unlock()
+
+	m.notifyListingsChanged()
 }
 
 // isMemberOf checks if a host matches any precomputed member authority in the trust group.
@@ -204,30 +414,12 @@ func (m *TrustGroupManager) triggerRefreshIfNeeded(_ context.Context, tg *TrustG
 	age := time.Since(tg.lastRefresh)
 
 	if age > m.cacheConfig.TTL {
-		enabledCount := 0
-
-		for _, ds := range tg.config.DirectoryServices {
-			if ds.Enabled {
-				enabledCount++
-			}
-		}
-
-		if enabledCount == 0 {
-			enabledCount = 1
-		}
-
-		timeout := m.refreshTimeout * time.Duration(enabledCount)
-
-		tg.refreshMu.Lock()
-		if tg.refreshing {
-			tg.refreshMu.Unlock()
+		timeout := m.refreshTimeoutFor(tg)
 
+		if !tg.startRefresh() {
 			return
 		}
 
-		tg.refreshing = true
-		tg.refreshMu.Unlock()
-
 		//nolint:gosec,contextcheck // intentional fire-and-forget background work that must outlive the request; cannot use the request context
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), timeout)
@@ -238,8 +430,40 @@ func (m *TrustGroupManager) triggerRefreshIfNeeded(_ context.Context, tg *TrustG
 	}
 }
 
+// refreshTimeoutFor allows refreshTimeout per enabled directory service.
+func (m *TrustGroupManager) refreshTimeoutFor(tg *TrustGroup) time.Duration {
+	enabledCount := 0
+
+	for _, ds := range tg.config.DirectoryServices {
+		if ds.Enabled {
+			enabledCount++
+		}
+	}
+
+	if enabledCount == 0 {
+		enabledCount = 1
+	}
+
+	return m.refreshTimeout * time.Duration(enabledCount)
+}
+
+// startRefresh marks tg as refreshing. It returns false when a refresh is
+// already in flight.
+func (tg *TrustGroup) startRefresh() bool {
+	tg.refreshMu.Lock()
+	defer tg.refreshMu.Unlock()
+
+	if tg.refreshing {
+		return false
+	}
+
+	tg.refreshing = true
+
+	return true
+}
+
 // refreshTrustGroup fetches and updates membership for a trust group.
-// The caller must have set tg.refreshing under tg.refreshMu before launching
+// The caller must have set tg.refreshing with startRefresh before launching
 // the refresh goroutine.
 func (m *TrustGroupManager) refreshTrustGroup(ctx context.Context, tg *TrustGroup) {
 	defer func() {
@@ -279,6 +503,8 @@ func (m *TrustGroupManager) refreshTrustGroup(ctx context.Context, tg *TrustGrou
 		tg.lastRefresh = time.Now()
 		m.mu.Unlock()
 
+		m.notifyListingsChanged()
+
 		m.logger.Info("updated trust group membership",
 			"trust_group", tg.config.TrustGroupID,
 			"member_count", len(authorities))
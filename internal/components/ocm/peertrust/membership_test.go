@@ -7,6 +7,7 @@ package peertrust_test
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -415,3 +416,134 @@ func TestTrustGroupManager_MembershipRequiresVerifiedListings(t *testing.T) {
 		t.Error("expected unverified directory listing to be ignored for membership")
 	}
 }
+
+func TestTrustGroupManager_ReplaceTrustGroupsKeepsCacheForUnchangedSources(t *testing.T) {
+	t.Parallel()
+
+	m := peertrust.NewTrustGroupManager(peertrust.DefaultCacheConfig(), nil, "https", nil, 10*time.Second)
+
+	m.AddTrustGroup(&peertrust.TrustGroupConfig{TrustGroupID: "kept", Enabled: true})
+	m.AddTrustGroup(&peertrust.TrustGroupConfig{TrustGroupID: "moved", Enabled: true})
+	m.AddTrustGroup(&peertrust.TrustGroupConfig{TrustGroupID: "dropped", Enabled: true})
+
+	for id, host := range map[string]string{"kept": "kept.example.com", "moved": "moved.example.com", "dropped": "dropped.example.com"} {
+		m.SetCacheForTesting(id, []directoryservice.Listing{
+			{Federation: id, Servers: []directoryservice.Server{{URL: "https://" + host}}},
+		}, time.Now())
+	}
+
+	m.ReplaceTrustGroups([]*peertrust.TrustGroupConfig{
+		{TrustGroupID: "kept", Enabled: true, EnforceMembership: true},
+		{
+			TrustGroupID:      "moved",
+			Enabled:           true,
+			DirectoryServices: []directoryservice.EndpointConfig{{URL: "https://ds.example.com/listing"}},
+		},
+	})
+
+	if got := len(m.GetTrustGroups()); got != 2 {
+		t.Fatalf("trust groups after replace = %d, want 2", got)
+	}
+
+	if !m.IsMember(context.Background(), "kept.example.com", false) {
+		t.Error("unchanged directory sources should keep the cached listing")
+	}
+
+	if m.IsMember(context.Background(), "moved.example.com", false) {
+		t.Error("changed directory sources should drop the cached listing")
+	}
+
+	if m.IsMember(context.Background(), "dropped.example.com", false) {
+		t.Error("removed trust group should no longer grant membership")
+	}
+}
+
+func TestTrustGroupManager_AttachDetachAndReplace(t *testing.T) {
+	t.Parallel()
+
+	m := peertrust.NewTrustGroupManager(peertrust.DefaultCacheConfig(), nil, "https", nil, 10*time.Second)
+	m.AddTrustGroup(&peertrust.TrustGroupConfig{TrustGroupID: "configured", Enabled: true})
+
+	if err := m.AttachTrustGroup(&peertrust.TrustGroupConfig{TrustGroupID: "configured"}); !errors.Is(err, peertrust.ErrTrustGroupExists) {
+		t.Errorf("attach over a configured group = %v, want ErrTrustGroupExists", err)
+	}
+
+	if err := m.AttachTrustGroup(&peertrust.TrustGroupConfig{TrustGroupID: "attached", Enabled: true}); err != nil {
+		t.Fatalf("AttachTrustGroup: %v", err)
+	}
+
+	m.SetCacheForTesting("attached", []directoryservice.Listing{
+		{Federation: "attached", Servers: []directoryservice.Server{{URL: "https://attached.example.com"}}},
+	}, time.Now())
+
+	// A reload replaces the configured groups and keeps the attached one.
+	m.ReplaceTrustGroups([]*peertrust.TrustGroupConfig{{TrustGroupID: "reloaded", Enabled: true}})
+
+	statuses := m.TrustGroupStatuses()
+	if len(statuses) != 2 || statuses[0].Config.TrustGroupID != "attached" || statuses[1].Config.TrustGroupID != "reloaded" {
+		t.Fatalf("statuses after replace = %+v, want attached and reloaded", statuses)
+	}
+
+	if statuses[0].Source != peertrust.SourceAdmin || statuses[0].Members != 1 || statuses[0].LastRefresh.IsZero() {
+		t.Errorf("attached status = %+v", statuses[0])
+	}
+
+	if statuses[1].Source != peertrust.SourceConfig {
+		t.Errorf("reloaded source = %q, want %q", statuses[1].Source, peertrust.SourceConfig)
+	}
+
+	if !m.IsMember(context.Background(), "attached.example.com", false) {
+		t.Error("attached group should keep its cached listing across a reload")
+	}
+
+	if err := m.DetachTrustGroup("reloaded"); !errors.Is(err, peertrust.ErrTrustGroupNotFound) {
+		t.Errorf("detach of a configured group = %v, want ErrTrustGroupNotFound", err)
+	}
+
+	if err := m.DetachTrustGroup("attached"); err != nil {
+		t.Fatalf("DetachTrustGroup: %v", err)
+	}
+
+	if m.IsMember(context.Background(), "attached.example.com", false) {
+		t.Error("detached group should no longer grant membership")
+	}
+}
+
+func TestTrustGroupManager_RefreshTrustGroupsIgnoresTTL(t *testing.T) {
+	t.Parallel()
+
+	var fetches atomic.Int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"federation":"fresh","servers":[{"url":"https://fresh.example.com","displayName":"Fresh"}]}`))
+	}))
+	defer ts.Close()
+
+	dirClient := directoryservice.NewClient(httpclient.New(tshttp.PermissiveConfig(), nil), "off", nil)
+	m := peertrust.NewTrustGroupManager(peertrust.DefaultCacheConfig(), dirClient, "https", nil, 10*time.Second)
+
+	if err := m.AttachTrustGroup(&peertrust.TrustGroupConfig{
+		TrustGroupID:      "tg",
+		Enabled:           true,
+		DirectoryServices: []directoryservice.EndpointConfig{{URL: ts.URL, Enabled: true, Verification: "off"}},
+	}); err != nil {
+		t.Fatalf("AttachTrustGroup: %v", err)
+	}
+
+	// A cache refreshed just now is within the TTL, so lookups do not fetch.
+	m.SetCacheForTesting("tg", []directoryservice.Listing{
+		{Federation: "stale", Servers: []directoryservice.Server{{URL: "https://stale.example.com"}}},
+	}, time.Now())
+
+	m.RefreshTrustGroups(context.Background())
+
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("directory fetches = %d, want 1", got)
+	}
+
+	if !m.IsMember(context.Background(), "fresh.example.com", false) || m.IsMember(context.Background(), "stale.example.com", false) {
+		t.Error("forced refresh should replace the cached listing")
+	}
+}
@@ -8,6 +8,7 @@ package peertrust
 import (
 	"context"
 	"log/slog"
+	"slices"
 	"strings"
 	"sync"
 
@@ -23,6 +24,8 @@ type PolicyDecision struct {
 }
 
 // PolicyEngine evaluates peer hosts against denylist, allowlist, and trust groups.
+// Each list is the union of the configured entries and the runtime entries
+// added through the admin API; UpdatePolicy replaces only the configured ones.
 // Denylist and allowlist entries are matched case-insensitively against a
 // lowercased peerHost. Trust-group membership uses hostport.Normalize separately.
 // The OCM spec defines denylist/allowlist as IP-address based
@@ -30,6 +33,7 @@ type PolicyDecision struct {
 // and does not define FQDN matching.
 type PolicyEngine struct {
 	cfg           *PolicyConfig
+	runtime       *PolicyConfig
 	trustGroupMgr *TrustGroupManager
 	logger        *slog.Logger
 	mu            sync.RWMutex
@@ -41,6 +45,7 @@ func NewPolicyEngine(cfg *PolicyConfig, trustGroupMgr *TrustGroupManager, logger
 
 	return &PolicyEngine{
 		cfg:           cfg,
+		runtime:       &PolicyConfig{},
 		trustGroupMgr: trustGroupMgr,
 		logger:        logger,
 	}
@@ -53,7 +58,7 @@ func (pe *PolicyEngine) Evaluate(ctx context.Context, peerHost string, authentic
 
 	peerHost = strings.ToLower(peerHost)
 
-	if pe.isInList(peerHost, pe.cfg.DenyList) {
+	if pe.isInList(peerHost, pe.cfg.DenyList) || pe.isInList(peerHost, pe.runtime.DenyList) {
 		pe.logger.Warn("peer denied by denylist", "peer", peerHost)
 
 		return &PolicyDecision{
@@ -64,7 +69,7 @@ func (pe *PolicyEngine) Evaluate(ctx context.Context, peerHost string, authentic
 		}
 	}
 
-	if pe.isInList(peerHost, pe.cfg.AllowList) {
+	if pe.isInList(peerHost, pe.cfg.AllowList) || pe.isInList(peerHost, pe.runtime.AllowList) {
 		return &PolicyDecision{
 			Allowed:       true,
 			Reason:        "peer in allowlist",
@@ -105,6 +110,56 @@ func (pe *PolicyEngine) UpdatePolicy(cfg *PolicyConfig) {
 	pe.cfg = cfg
 }
 
+// SetStoredPolicy replaces the runtime entries read from the store, which are
+// kept across UpdatePolicy. A nil cfg clears them.
+func (pe *PolicyEngine) SetStoredPolicy(cfg *PolicyConfig) {
+	if cfg == nil {
+		cfg = &PolicyConfig{}
+	}
+
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	pe.runtime = cfg
+}
+
+// Policies returns copies of the configured and the runtime lists.
+func (pe *PolicyEngine) Policies() (configured, runtime PolicyConfig) {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	return clonePolicy(pe.cfg), clonePolicy(pe.runtime)
+}
+
+// HasDenylist reports whether the effective denylist is nonempty. Discovery
+// reads it to advertise the denylist criterion.
+func (pe *PolicyEngine) HasDenylist() bool {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	return pe.cfg.HasDenylist() || pe.runtime.HasDenylist()
+}
+
+// HasAllowlist reports whether the effective allowlist is nonempty.
+// Discovery reads it to advertise the allowlist criterion.
+func (pe *PolicyEngine) HasAllowlist() bool {
+	pe.mu.RLock()
+	defer pe.mu.RUnlock()
+
+	return pe.cfg.HasAllowlist() || pe.runtime.HasAllowlist()
+}
+
+func clonePolicy(cfg *PolicyConfig) PolicyConfig {
+	if cfg == nil {
+		return PolicyConfig{}
+	}
+
+	return PolicyConfig{
+		AllowList: slices.Clone(cfg.AllowList),
+		DenyList:  slices.Clone(cfg.DenyList),
+	}
+}
+
 // isInList checks if host is in list using case-insensitive string equality.
 // Evaluate lowercases peerHost before calling this; list entries are not passed
 // through hostport.Normalize here.
@@ -117,3 +117,48 @@ func TestPolicyEngine_NotAllowed(t *testing.T) {
 		t.Errorf("expected reason_code 'not_allowed', got %q", result.ReasonCode)
 	}
 }
+
+func TestPolicyEngine_StoredPolicySurvivesUpdatePolicy(t *testing.T) {
+	t.Parallel()
+
+	pe := peertrust.NewPolicyEngine(&peertrust.PolicyConfig{}, nil, nil)
+	if pe.HasDenylist() || pe.HasAllowlist() {
+		t.Fatal("empty engine should advertise no lists")
+	}
+
+	pe.SetStoredPolicy(peertrust.PolicyFromEntries([]*peertrust.PolicyEntry{
+		{List: peertrust.ListDeny, Host: "blocked.example.com"},
+		{List: peertrust.ListAllow, Host: "trusted.example.com"},
+	}))
+
+	// A config reload replaces only the configured lists.
+	pe.UpdatePolicy(&peertrust.PolicyConfig{AllowList: []string{"configured.example.com"}})
+
+	cases := map[string]string{
+		"blocked.example.com":    "denied_by_denylist",
+		"TRUSTED.example.com":    "allowed_by_allowlist",
+		"configured.example.com": "allowed_by_allowlist",
+		"other.example.com":      "not_allowed",
+	}
+	for host, want := range cases {
+		if got := pe.Evaluate(context.Background(), host, false).ReasonCode; got != want {
+			t.Errorf("Evaluate(%q) = %q, want %q", host, got, want)
+		}
+	}
+
+	if !pe.HasDenylist() || !pe.HasAllowlist() {
+		t.Error("runtime entries should count toward the advertised lists")
+	}
+
+	configured, runtime := pe.Policies()
+	if len(configured.AllowList) != 1 || len(configured.DenyList) != 0 ||
+		len(runtime.AllowList) != 1 || len(runtime.DenyList) != 1 {
+		t.Errorf("Policies() = %+v, %+v", configured, runtime)
+	}
+
+	pe.SetStoredPolicy(nil)
+
+	if pe.HasDenylist() {
+		t.Error("clearing the runtime entries should drop the denylist")
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package peertrust
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// Policy lists a runtime entry can be added to.
+const (
+	ListAllow = "allow"
+	ListDeny  = "deny"
+)
+
+var (
+	// ErrEntryExists is returned when a host is already on a runtime list.
+	ErrEntryExists = errors.New("peer policy entry already exists")
+	// ErrEntryNotFound is returned when a host is not on a runtime list.
+	ErrEntryNotFound = errors.New("peer policy entry not found")
+	// ErrTrustGroupExists is returned when a trust group ID is already in use.
+	ErrTrustGroupExists = errors.New("trust group already exists")
+	// ErrTrustGroupNotFound is returned when no attached trust group has the ID.
+	ErrTrustGroupNotFound = errors.New("trust group not found")
+)
+
+// PolicyEntry is an allow/deny list entry added through the admin API.
+type PolicyEntry struct {
+	List      string    `json:"list"`
+	Host      string    `json:"host"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AttachedTrustGroup is a trust group attached through the admin API.
+type AttachedTrustGroup struct {
+	Config    *TrustGroupConfig
+	CreatedAt time.Time
+}
+
+// Repo persists the allow/deny entries and trust groups added at runtime.
+// Entries from [peer_trust.policy] and peer_trust.config_paths are not
+// stored here.
+type Repo interface {
+	// CreateEntry stores a new entry; ErrEntryExists when the host is already
+	// on that list.
+	CreateEntry(ctx context.Context, entry *PolicyEntry) error
+	// DeleteEntry removes host from list or returns ErrEntryNotFound.
+	DeleteEntry(ctx context.Context, list, host string) error
+	// ListEntries returns every entry.
+	ListEntries(ctx context.Context) ([]*PolicyEntry, error)
+	// CreateTrustGroup stores a new trust group; ErrTrustGroupExists when
+	// its ID is taken.
+	CreateTrustGroup(ctx context.Context, group *AttachedTrustGroup) error
+	// DeleteTrustGroup removes a trust group or returns ErrTrustGroupNotFound.
+	DeleteTrustGroup(ctx context.Context, trustGroupID string) error
+	// ListTrustGroups returns every attached trust group.
+	ListTrustGroups(ctx context.Context) ([]*AttachedTrustGroup, error)
+}
+
+// PolicyFromEntries collects runtime entries into a PolicyConfig.
+func PolicyFromEntries(entries []*PolicyEntry) *PolicyConfig {
+	cfg := &PolicyConfig{}
+
+	for _, e := range entries {
+		switch e.List {
+		case ListAllow:
+			cfg.AllowList = append(cfg.AllowList, e.Host)
+		case ListDeny:
+			cfg.DenyList = append(cfg.DenyList, e.Host)
+		}
+	}
+
+	slices.Sort(cfg.AllowList)
+	slices.Sort(cfg.DenyList)
+
+	return cfg
+}
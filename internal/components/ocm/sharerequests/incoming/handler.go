@@ -0,0 +1,347 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package incoming serves POST /ocm/request-share: a remote party asks a local
+// owner for access to one of the owner's outgoing shares, and the request
+// waits in the owner's share request inbox.
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
+	inboundsignature "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/inbound/signature"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/appctx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
+)
+
+const (
+	ocmErrInvalidRequestShare = "INVALID_REQUEST_SHARE_REQUEST"
+	ocmErrShareNotFound       = "SHARE_NOT_FOUND"
+	ocmErrUntrustedProvider   = "UNTRUSTED_PROVIDER"
+)
+
+// Handler serves POST /ocm/request-share with peer-trust gating.
+type Handler struct {
+	repo                        sharerequests.Repo
+	outgoingRepo                sharesoutgoing.OutgoingShareRepo
+	partyRepo                   identity.PartyRepo
+	policyEngine                *peertrust.PolicyEngine
+	localProvider               string
+	localProviderFQDNForCompare string
+	localScheme                 string
+}
+
+// NewHandler creates the request-share handler. localProvider is the
+// provider part of owner addresses on outgoing shares; a nil policyEngine
+// skips peer-trust policy checks.
+func NewHandler(
+	repo sharerequests.Repo,
+	outgoingRepo sharesoutgoing.OutgoingShareRepo,
+	partyRepo identity.PartyRepo,
+	policyEngine *peertrust.PolicyEngine,
+	localProvider string,
+	localProviderFQDNForCompare string,
+	localScheme string,
+) *Handler {
+	return &Handler{
+		repo:                        repo,
+		outgoingRepo:                outgoingRepo,
+		partyRepo:                   partyRepo,
+		policyEngine:                policyEngine,
+		localProvider:               localProvider,
+		localProviderFQDNForCompare: localProviderFQDNForCompare,
+		localScheme:                 localScheme,
+	}
+}
+
+// HandleRequestShare handles POST /ocm/request-share. It answers 404 for an
+// unknown owner, an unknown or revoked share, or a share the owner does not
+// own, so a requester cannot probe which shares exist.
+func (h *Handler) HandleRequestShare(w http.ResponseWriter, r *http.Request) {
+	log := appctx.GetLogger(r.Context())
+
+	req, ok := parseRequestShareRequest(w, r, log)
+	if !ok {
+		return
+	}
+
+	requesterHost, ok := h.admitRequester(w, r, log, req.ShareWith)
+	if !ok {
+		return
+	}
+
+	share, ownerUserID, ok := h.resolveOwnedShare(w, r.Context(), log, &req)
+	if !ok {
+		return
+	}
+
+	pending, err := h.hasPending(r.Context(), ownerUserID, share.ProviderID, req.ShareWith)
+	if err != nil {
+		log.Error("failed to list share requests", "error", err)
+		spec.WriteOCMError(w, http.StatusInternalServerError, "INTERNAL_ERROR")
+
+		return
+	}
+
+	if pending {
+		log.Info("duplicate share request ignored", "provider_id", share.ProviderID)
+		writeCreated(w)
+
+		return
+	}
+
+	shareReq := &sharerequests.ShareRequest{
+		OwnerUserID:   ownerUserID,
+		ProviderID:    share.ProviderID,
+		ShareID:       share.ShareID,
+		Name:          share.Name,
+		ResourceType:  share.ResourceType,
+		ShareWith:     req.ShareWith,
+		RequesterHost: requesterHost,
+		Status:        sharerequests.StatusPending,
+	}
+	if err := h.repo.Create(r.Context(), shareReq); err != nil {
+		log.Error("failed to store share request", "error", err)
+		spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
+
+		return
+	}
+
+	log.Info("share request received",
+		"request_id", shareReq.ID,
+		"provider_id", share.ProviderID,
+		"requester_host", requesterHost)
+	writeCreated(w)
+}
+
+func parseRequestShareRequest(w http.ResponseWriter, r *http.Request, log *slog.Logger) (spec.RequestShareRequest, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Warn("failed to read request-share body", "error", err)
+		spec.WriteOCMError(w, http.StatusBadRequest, "INVALID_JSON")
+
+		return spec.RequestShareRequest{}, false
+	}
+
+	var req spec.RequestShareRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		log.Warn("failed to decode request-share body", "error", err)
+		spec.WriteOCMError(w, http.StatusBadRequest, "INVALID_JSON")
+
+		return spec.RequestShareRequest{}, false
+	}
+
+	if validationErrs := spec.ValidateRequestShareRequest(&req); len(validationErrs) > 0 {
+		log.Warn("request-share validation failed", "errors", len(validationErrs))
+		spec.WriteValidationError(w, ocmErrInvalidRequestShare, validationErrs)
+
+		return spec.RequestShareRequest{}, false
+	}
+
+	var formatErrs []spec.ValidationError
+	if _, _, err := address.Parse(req.Owner); err != nil {
+		formatErrs = append(formatErrs, spec.ValidationError{Name: "owner", Message: "INVALID_FORMAT"})
+	}
+
+	if _, _, err := address.Parse(req.ShareWith); err != nil {
+		formatErrs = append(formatErrs, spec.ValidationError{Name: "shareWith", Message: "INVALID_FORMAT"})
+	}
+
+	if len(formatErrs) > 0 {
+		log.Warn("request-share owner/shareWith format invalid", "errors", len(formatErrs))
+		spec.WriteValidationError(w, "INVALID_FIELD_FORMAT", formatErrs)
+
+		return spec.RequestShareRequest{}, false
+	}
+
+	return req, true
+}
+
+// admitRequester checks that the signing peer is the shareWith provider and
+// that peer-trust policy admits it. It returns the requester host in compare
+// form.
+func (h *Handler) admitRequester(
+	w http.ResponseWriter,
+	r *http.Request,
+	log *slog.Logger,
+	shareWith string,
+) (string, bool) {
+	peerIdentity := inboundsignature.GetPeerIdentity(r.Context())
+	if peerIdentity == nil || !peerIdentity.Authenticated || peerIdentity.AuthorityForCompare == "" {
+		log.Warn("request-share missing authenticated peer identity")
+		spec.WriteOCMError(w, http.StatusForbidden, ocmErrUntrustedProvider)
+
+		return "", false
+	}
+
+	requesterHost, err := address.NormalizedProviderFrom(shareWith, h.localScheme)
+	if err != nil || requesterHost != peerIdentity.AuthorityForCompare {
+		log.Warn("request-share shareWith provider mismatch",
+			"signature_authority", peerIdentity.AuthorityForCompare,
+			"share_with_provider", requesterHost)
+		spec.WriteOCMError(w, http.StatusForbidden, ocmErrUntrustedProvider)
+
+		return "", false
+	}
+
+	// nil policyEngine means peer-trust is off (or omitted in tests); policy
+	// checks are skipped intentionally, not a fail-closed state.
+	if h.policyEngine != nil {
+		decision := h.policyEngine.Evaluate(r.Context(), requesterHost, true)
+		if !decision.Allowed {
+			log.Warn("share request rejected by policy",
+				"requester", requesterHost,
+				"reason", decision.Reason)
+
+			translated := reason.TranslatePolicyCode(decision.ReasonCode)
+			if translated == "" {
+				translated = "SENDER_NOT_AUTHORIZED"
+			}
+
+			spec.WriteOCMError(w, reason.OCMStatus(translated), translated)
+
+			return "", false
+		}
+	}
+
+	return requesterHost, true
+}
+
+// resolveOwnedShare resolves the local owner and the active outgoing share
+// they own, returning the share and the owner's user id. It writes 404 when
+// either is missing.
+func (h *Handler) resolveOwnedShare(
+	w http.ResponseWriter,
+	ctx context.Context,
+	log *slog.Logger,
+	req *spec.RequestShareRequest,
+) (*sharesoutgoing.OutgoingShare, string, bool) {
+	owner, err := h.resolveOwner(ctx, req.Owner)
+	if err != nil {
+		if identity.IsInfrastructureError(err) {
+			log.Warn("owner lookup failed", "error", err)
+			spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
+
+			return nil, "", false
+		}
+
+		log.Info("request-share owner not found", "owner", req.Owner)
+		spec.WriteOCMError(w, http.StatusNotFound, ocmErrShareNotFound)
+
+		return nil, "", false
+	}
+
+	share, err := h.outgoingRepo.GetByProviderID(ctx, req.Share)
+	if err != nil {
+		if errors.Is(err, sharesoutgoing.ErrShareNotFound) {
+			spec.WriteOCMError(w, http.StatusNotFound, ocmErrShareNotFound)
+
+			return nil, "", false
+		}
+
+		log.Error("failed to load outgoing share for share request", "provider_id", req.Share, "error", err)
+		spec.WriteOCMError(w, http.StatusInternalServerError, "INTERNAL_ERROR")
+
+		return nil, "", false
+	}
+
+	if share.Owner != address.FormatOutgoingOCMAddressFromUserID(owner.ID, h.localProvider) ||
+		share.Status == shares.OutgoingShareStatusRevoked {
+		log.Info("request-share names a share the owner does not hold", "provider_id", req.Share)
+		spec.WriteOCMError(w, http.StatusNotFound, ocmErrShareNotFound)
+
+		return nil, "", false
+	}
+
+	return share, owner.ID, true
+}
+
+// resolveOwner maps a local owner address to a user: the provider must be this
+// instance, and the identifier is a federated opaque id, canonical id,
+// username, or email.
+func (h *Handler) resolveOwner(ctx context.Context, ownerAddr string) (*identity.User, error) {
+	identifier, provider, err := address.Parse(ownerAddr)
+	if err != nil {
+		return nil, identity.ErrUserNotFound
+	}
+
+	normalizedProvider, err := hostport.Normalize(provider, h.localScheme)
+	if err != nil || !strings.EqualFold(normalizedProvider, h.localProviderFQDNForCompare) {
+		return nil, identity.ErrUserNotFound
+	}
+
+	if userID, _, ok := address.DecodeFederatedOpaqueID(identifier); ok {
+		if user, getErr := h.partyRepo.Get(ctx, userID); getErr == nil {
+			return user, nil
+		}
+	}
+
+	var infraErr error
+
+	lookups := []func(context.Context, string) (*identity.User, error){
+		h.partyRepo.Get,
+		h.partyRepo.GetByUsername,
+		h.partyRepo.GetByEmail,
+	}
+	for _, lookup := range lookups {
+		user, lookupErr := lookup(ctx, identifier)
+		if lookupErr == nil {
+			return user, nil
+		}
+
+		if identity.IsInfrastructureError(lookupErr) {
+			infraErr = lookupErr
+		}
+	}
+
+	if infraErr != nil {
+		return nil, infraErr
+	}
+
+	return nil, identity.ErrUserNotFound
+}
+
+// hasPending reports whether shareWith already has a pending request for providerID.
+func (h *Handler) hasPending(
+	ctx context.Context,
+	ownerUserID string,
+	providerID string,
+	shareWith string,
+) (bool, error) {
+	reqs, err := h.repo.ListByOwnerUserID(ctx, ownerUserID)
+	if err != nil {
+		return false, fmt.Errorf("sharerequests: list pending: %w", err)
+	}
+
+	for _, req := range reqs {
+		if req.Status == sharerequests.StatusPending && req.ProviderID == providerID && req.ShareWith == shareWith {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func writeCreated(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	//nolint:errcheck,errchkjson // response already committed after WriteHeader; write error cannot be recovered or meaningfully handled; payload encodes to fixed JSON, so encode error is always nil
+	json.NewEncoder(w).Encode(map[string]string{})
+}
@@ -0,0 +1,303 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package incoming_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
+	inboundsignature "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/inbound/signature"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests/incoming"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+)
+
+const (
+	localProvider = "owner.example"
+	requesterHost = "requester.example"
+	requester     = "bob@" + requesterHost
+	providerID    = "provider-1"
+)
+
+type fixture struct {
+	repos   *repos.Repos
+	handler *incoming.Handler
+	owner   *identity.User
+}
+
+func newFixture(t *testing.T, policyEngine *peertrust.PolicyEngine) *fixture {
+	t.Helper()
+
+	r := tsrepos.OpenMemory(t)
+	ctx := context.Background()
+
+	owner := &identity.User{Username: "alice", Email: "alice@owner.example", Role: "user"}
+	if err := r.Parties.Create(ctx, owner); err != nil {
+		t.Fatalf("create owner: %v", err)
+	}
+
+	share := &sharesoutgoing.OutgoingShare{
+		ShareID:      "share-1",
+		ProviderID:   providerID,
+		Name:         "report.txt",
+		ResourceType: "file",
+		LocalPath:    "/report.txt",
+		Permissions:  []string{"read"},
+		Owner:        address.FormatOutgoingOCMAddressFromUserID(owner.ID, localProvider),
+		Status:       shares.OutgoingShareStatusAccepted,
+		CreatedAt:    time.Now(),
+	}
+	if err := r.OutgoingShares.Create(ctx, share); err != nil {
+		t.Fatalf("create outgoing share: %v", err)
+	}
+
+	handler := incoming.NewHandler(
+		r.ShareRequests,
+		r.OutgoingShares,
+		r.Parties,
+		policyEngine,
+		localProvider,
+		localProvider,
+		"https",
+	)
+
+	return &fixture{repos: r, handler: handler, owner: owner}
+}
+
+func (f *fixture) ownerAddress() string {
+	return address.FormatOutgoingOCMAddressFromUserID(f.owner.ID, localProvider)
+}
+
+func postRequestShare(t *testing.T, handler *incoming.Handler, body any, peerHost string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal body: %v", err)
+	}
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/ocm/request-share", bytes.NewReader(raw))
+	if peerHost != "" {
+		ctx := context.WithValue(req.Context(), inboundsignature.PeerIdentityKey, &inboundsignature.PeerIdentity{
+			AuthorityForCompare: peerHost,
+			Authenticated:       true,
+		})
+		req = req.WithContext(ctx)
+	}
+
+	w := httptest.NewRecorder()
+	handler.HandleRequestShare(w, req)
+
+	return w
+}
+
+func decodeOCMMessage(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var resp spec.OCMErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v body=%q", err, w.Body.String())
+	}
+
+	return resp.Message
+}
+
+func TestHandleRequestShare_StoresPendingRequest(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, nil)
+
+	w := postRequestShare(t, f.handler, spec.RequestShareRequest{
+		Owner:     f.ownerAddress(),
+		ShareWith: requester,
+		Share:     providerID,
+	}, requesterHost)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201; body=%s", w.Code, w.Body.String())
+	}
+
+	list, err := f.repos.ShareRequests.ListByOwnerUserID(context.Background(), f.owner.ID)
+	if err != nil {
+		t.Fatalf("ListByOwnerUserID: %v", err)
+	}
+
+	if len(list) != 1 {
+		t.Fatalf("stored %d requests, want 1", len(list))
+	}
+
+	got := list[0]
+	if got.Status != sharerequests.StatusPending || got.ShareWith != requester ||
+		got.RequesterHost != requesterHost || got.ShareID != "share-1" || got.Name != "report.txt" {
+		t.Errorf("stored request = %+v", got)
+	}
+}
+
+func TestHandleRequestShare_OwnerByUsername(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, nil)
+
+	w := postRequestShare(t, f.handler, spec.RequestShareRequest{
+		Owner:     "alice@" + localProvider,
+		ShareWith: requester,
+		Share:     providerID,
+	}, requesterHost)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201; body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandleRequestShare_DuplicatePendingIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, nil)
+	body := spec.RequestShareRequest{Owner: f.ownerAddress(), ShareWith: requester, Share: providerID}
+
+	for i := range 2 {
+		if w := postRequestShare(t, f.handler, body, requesterHost); w.Code != http.StatusCreated {
+			t.Fatalf("attempt %d: status = %d, want 201", i, w.Code)
+		}
+	}
+
+	list, err := f.repos.ShareRequests.ListByOwnerUserID(context.Background(), f.owner.ID)
+	if err != nil {
+		t.Fatalf("ListByOwnerUserID: %v", err)
+	}
+
+	if len(list) != 1 {
+		t.Errorf("stored %d requests, want 1", len(list))
+	}
+}
+
+func TestHandleRequestShare_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		mutate func(t *testing.T, f *fixture, body *spec.RequestShareRequest)
+	}{
+		{
+			name:   "unknown share",
+			mutate: func(_ *testing.T, _ *fixture, body *spec.RequestShareRequest) { body.Share = "missing" },
+		},
+		{
+			name:   "unknown owner",
+			mutate: func(_ *testing.T, _ *fixture, body *spec.RequestShareRequest) { body.Owner = "nobody@" + localProvider },
+		},
+		{
+			name:   "remote owner",
+			mutate: func(_ *testing.T, _ *fixture, body *spec.RequestShareRequest) { body.Owner = "alice@elsewhere.example" },
+		},
+		{
+			name: "owner does not hold share",
+			mutate: func(t *testing.T, f *fixture, body *spec.RequestShareRequest) {
+				other := &identity.User{Username: "carol", Role: "user"}
+				if err := f.repos.Parties.Create(context.Background(), other); err != nil {
+					t.Fatalf("create other user: %v", err)
+				}
+
+				body.Owner = "carol@" + localProvider
+			},
+		},
+		{
+			name: "revoked share",
+			mutate: func(t *testing.T, f *fixture, _ *spec.RequestShareRequest) {
+				ctx := context.Background()
+
+				share, err := f.repos.OutgoingShares.GetByProviderID(ctx, providerID)
+				if err != nil {
+					t.Fatalf("get outgoing share: %v", err)
+				}
+
+				share.Status = shares.OutgoingShareStatusRevoked
+				if err := f.repos.OutgoingShares.Update(ctx, share); err != nil {
+					t.Fatalf("revoke outgoing share: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newFixture(t, nil)
+			body := spec.RequestShareRequest{Owner: f.ownerAddress(), ShareWith: requester, Share: providerID}
+			tt.mutate(t, f, &body)
+
+			w := postRequestShare(t, f.handler, body, requesterHost)
+			if w.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want 404; body=%s", w.Code, w.Body.String())
+			}
+
+			if msg := decodeOCMMessage(t, w); msg != "SHARE_NOT_FOUND" {
+				t.Errorf("message = %q, want SHARE_NOT_FOUND", msg)
+			}
+		})
+	}
+}
+
+func TestHandleRequestShare_MissingFields(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, nil)
+
+	w := postRequestShare(t, f.handler, map[string]string{"owner": f.ownerAddress()}, requesterHost)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestHandleRequestShare_RejectsPeerMismatch(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, nil)
+	body := spec.RequestShareRequest{Owner: f.ownerAddress(), ShareWith: requester, Share: providerID}
+
+	for _, peerHost := range []string{"", "other.example"} {
+		w := postRequestShare(t, f.handler, body, peerHost)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("peer %q: status = %d, want 403", peerHost, w.Code)
+		}
+	}
+}
+
+func TestHandleRequestShare_PolicyDenied(t *testing.T) {
+	t.Parallel()
+
+	engine := peertrust.NewPolicyEngine(&peertrust.PolicyConfig{DenyList: []string{requesterHost}}, nil, nil)
+	f := newFixture(t, engine)
+
+	w := postRequestShare(t, f.handler, spec.RequestShareRequest{
+		Owner:     f.ownerAddress(),
+		ShareWith: requester,
+		Share:     providerID,
+	}, requesterHost)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403; body=%s", w.Code, w.Body.String())
+	}
+
+	list, err := f.repos.ShareRequests.ListByOwnerUserID(context.Background(), f.owner.ID)
+	if err != nil {
+		t.Fatalf("ListByOwnerUserID: %v", err)
+	}
+
+	if len(list) != 0 {
+		t.Errorf("denied request was stored: %+v", list)
+	}
+}
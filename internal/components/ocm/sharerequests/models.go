@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package sharerequests holds the share request model and repository. A share
+// request is a POST /ocm/request-share from a remote party asking a local
+// owner for access to one of the owner's shared resources; it waits in the
+// owner's inbox until approved (by sharing the resource with the requester)
+// or declined.
+package sharerequests
+
+import "time"
+
+// Status tracks the lifecycle state of a received share request.
+type Status string
+
+const (
+	// StatusPending requests wait for the owner's decision.
+	StatusPending Status = "pending"
+	// StatusApproved requests were answered with a new outgoing share.
+	StatusApproved Status = "approved"
+	// StatusDeclined requests were refused by the owner.
+	StatusDeclined Status = "declined"
+)
+
+// ShareRequest is one received request for access, scoped to the local owner.
+type ShareRequest struct {
+	ID          string `json:"id"`
+	OwnerUserID string `json:"-"` // canonical local user id that owns this inbox entry
+	// ProviderID is the share identifier the requester sent; ShareID is the
+	// local outgoing share it resolved to.
+	ProviderID string `json:"providerId"`
+	ShareID    string `json:"shareId"`
+	// Name and ResourceType are copied from the outgoing share for display.
+	Name         string `json:"name"`
+	ResourceType string `json:"resourceType"`
+	// ShareWith is the requester's OCM address and RequesterHost its
+	// provider in host compare form.
+	ShareWith     string `json:"shareWith"`
+	RequesterHost string `json:"requesterHost"`
+	Status        Status `json:"status"`
+	// ApprovedShareID is the outgoing share created on approval.
+	ApprovedShareID string    `json:"approvedShareId,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package outgoing sends POST /ocm/request-share to a remote owner's server
+// so a local user can ask for access to a share they know about.
+package outgoing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+var (
+	// ErrShareNotFound is returned when the remote server answers 404: the
+	// share is missing or the requester has no right to ask for it.
+	ErrShareNotFound = errors.New("remote share not found")
+	// ErrNotSupported is returned when the remote server answers 501.
+	ErrNotSupported = errors.New("remote server does not support share requests")
+)
+
+// RequestSharePoster posts the request-share protocol call to a remote
+// owner's server. Wired with an outbound.Poster-based adapter at the service
+// layer.
+type RequestSharePoster interface {
+	PostRequestShare(ctx context.Context, targetHost string, body []byte) (*http.Response, error)
+}
+
+// SendRequestShare sends POST /ocm/request-share to targetHost. It returns
+// nil on 200/201, ErrShareNotFound on 404, ErrNotSupported on 501, and a
+// descriptive error for any other answer or transport failure.
+func SendRequestShare(ctx context.Context, poster RequestSharePoster, req spec.RequestShareRequest, targetHost string) error {
+	body, err := json.Marshal(req) //nolint:errchkjson // payload type cannot fail to encode, so the checked error is always nil
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	resp, err := poster.PostRequestShare(ctx, targetHost, body)
+	if err != nil {
+		return fmt.Errorf("ocm: post request share: %w", err)
+	}
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		resp.Body.Close()
+	}()
+
+	return DecodeRequestShareResponse(resp.StatusCode, resp.Body)
+}
+
+// DecodeRequestShareResponse interprets the owner server's answer to a
+// request-share call with the rules documented on SendRequestShare.
+func DecodeRequestShareResponse(statusCode int, body io.Reader) error {
+	switch statusCode {
+	case http.StatusOK, http.StatusCreated:
+		return nil
+	case http.StatusNotFound:
+		return ErrShareNotFound
+	case http.StatusNotImplemented:
+		return ErrNotSupported
+	default:
+		respBody, readErr := io.ReadAll(io.LimitReader(body, 4096))
+		if readErr != nil {
+			return fmt.Errorf("request-share rejected with status %d: %w", statusCode, readErr)
+		}
+
+		return fmt.Errorf("request-share rejected with status %d: %s", statusCode, string(respBody))
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package outgoing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+// stubPoster records the request-share call and returns a canned response.
+type stubPoster struct {
+	status int
+	body   string
+	err    error
+
+	gotHost string
+	gotBody []byte
+}
+
+func (p *stubPoster) PostRequestShare(_ context.Context, targetHost string, body []byte) (*http.Response, error) {
+	p.gotHost = targetHost
+	p.gotBody = body
+
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return &http.Response{
+		StatusCode: p.status,
+		Body:       io.NopCloser(strings.NewReader(p.body)),
+	}, nil
+}
+
+func sendTestRequestShare(poster *stubPoster) error {
+	return outgoing.SendRequestShare(context.Background(), poster, spec.RequestShareRequest{
+		Owner:     "alice@owner.example",
+		ShareWith: "bob@requester.example",
+		Share:     "provider-1",
+	}, "owner.example")
+}
+
+func TestSendRequestShare_Created(t *testing.T) {
+	t.Parallel()
+
+	poster := &stubPoster{status: http.StatusCreated, body: `{}`}
+	if err := sendTestRequestShare(poster); err != nil {
+		t.Fatalf("SendRequestShare: %v", err)
+	}
+
+	if poster.gotHost != "owner.example" {
+		t.Errorf("target host = %q, want owner.example", poster.gotHost)
+	}
+
+	var sent spec.RequestShareRequest
+	if err := json.Unmarshal(poster.gotBody, &sent); err != nil {
+		t.Fatalf("decode sent body: %v", err)
+	}
+
+	if sent.Owner != "alice@owner.example" || sent.ShareWith != "bob@requester.example" || sent.Share != "provider-1" {
+		t.Errorf("sent body = %+v", sent)
+	}
+}
+
+func TestSendRequestShare_StatusMapping(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		poster  *stubPoster
+		wantErr error
+	}{
+		{name: "not found", poster: &stubPoster{status: http.StatusNotFound}, wantErr: outgoing.ErrShareNotFound},
+		{name: "not implemented", poster: &stubPoster{status: http.StatusNotImplemented}, wantErr: outgoing.ErrNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := sendTestRequestShare(tt.poster); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSendRequestShare_OtherFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		poster *stubPoster
+	}{
+		{name: "forbidden", poster: &stubPoster{status: http.StatusForbidden, body: `{"message":"UNTRUSTED_PROVIDER"}`}},
+		{name: "server error", poster: &stubPoster{status: http.StatusInternalServerError}},
+		{name: "transport", poster: &stubPoster{err: errors.New("dial failed")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := sendTestRequestShare(tt.poster)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if errors.Is(err, outgoing.ErrShareNotFound) || errors.Is(err, outgoing.ErrNotSupported) {
+				t.Errorf("error = %v, want a generic failure", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sharerequests
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrRequestNotFound is returned when a share request is not found (including
+// cross-owner mismatch).
+var ErrRequestNotFound = errors.New("share request not found")
+
+// Repo manages received share requests; all ops are scoped by ownerUserID.
+// Cross-owner access = not found. ListByOwnerUserID returns newest first.
+type Repo interface {
+	Create(ctx context.Context, req *ShareRequest) error
+	GetByIDForOwnerUserID(ctx context.Context, id string, ownerUserID string) (*ShareRequest, error)
+	ListByOwnerUserID(ctx context.Context, ownerUserID string) ([]*ShareRequest, error)
+	UpdateStatusForOwnerUserID(ctx context.Context, id string, ownerUserID string, status Status, approvedShareID string) error
+}
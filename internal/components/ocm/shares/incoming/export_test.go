@@ -13,14 +13,15 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 )
 
-// HandleExistingIncomingShareForTest exercises duplicate create-share handling.
-func HandleExistingIncomingShareForTest(
+// HandleExistingIncomingSharesForTest exercises duplicate create-share handling.
+func HandleExistingIncomingSharesForTest(
 	w http.ResponseWriter,
 	log *slog.Logger,
-	existing *IncomingShare,
+	existing []*IncomingShare,
 	req *spec.NewShareRequest,
 	senderHost string,
-	resolvedUser *identity.User,
-) bool {
-	return handleExistingIncomingShare(w, log, existing, req, senderHost, resolvedUser)
+	recipients []*identity.User,
+	recipientDisplayName string,
+) ([]*identity.User, bool) {
+	return handleExistingIncomingShares(w, log, existing, req, senderHost, recipients, recipientDisplayName)
 }
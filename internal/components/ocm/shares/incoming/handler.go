@@ -7,6 +7,8 @@ package incoming
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -17,13 +19,17 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/policy"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
 )
 
 // Handler serves POST /ocm/shares with recipient resolution and peer-trust gating.
+// Group shares fan out into one incoming share per group member; a nil
+// groupRepo rejects them as unknown recipients.
 type Handler struct {
 	repo                        IncomingShareRepo
 	partyRepo                   identity.PartyRepo
+	groupRepo                   identity.GroupRepo
 	policyEngine                *peertrust.PolicyEngine
 	resolver                    *policy.PeerMappingResolver
 	incomingInviteRepo          invitesincoming.IncomingInviteRepo
@@ -31,11 +37,13 @@ type Handler struct {
 	mustInviteEnforced          bool
 	localProviderFQDNForCompare string
 	localScheme                 string
+	audit                       audit.Recorder
 }
 
 func NewHandler( //nolint:revive // exported: trivial constructor wiring the handler dependencies
 	repo IncomingShareRepo,
 	partyRepo identity.PartyRepo,
+	groupRepo identity.GroupRepo,
 	policyEngine *peertrust.PolicyEngine,
 	incomingInviteRepo invitesincoming.IncomingInviteRepo,
 	outgoingInviteRepo invitesoutgoing.OutgoingInviteRepo,
@@ -47,6 +55,7 @@ func NewHandler( //nolint:revive // exported: trivial constructor wiring the han
 	return &Handler{
 		repo:                        repo,
 		partyRepo:                   partyRepo,
+		groupRepo:                   groupRepo,
 		policyEngine:                policyEngine,
 		resolver:                    resolver,
 		incomingInviteRepo:          incomingInviteRepo,
@@ -54,9 +63,15 @@ func NewHandler( //nolint:revive // exported: trivial constructor wiring the han
 		mustInviteEnforced:          mustInviteEnforced,
 		localProviderFQDNForCompare: localProviderFQDNForCompare,
 		localScheme:                 localScheme,
+		audit:                       audit.Nop(),
 	}
 }
 
+// SetAudit sets the audit recorder for received shares; nil disables it.
+func (h *Handler) SetAudit(r audit.Recorder) {
+	h.audit = audit.OrNop(r)
+}
+
 // CreateShare handles POST /ocm/shares: parses, resolves the recipient, and persists the incoming share.
 func (h *Handler) CreateShare(w http.ResponseWriter, r *http.Request) {
 	req, rawFields, ok := h.parseCreateShareRequest(w, r)
@@ -76,7 +91,7 @@ func (h *Handler) CreateShare(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resolvedUser, ok := h.resolveShareRecipient(w, r, &req)
+	recipients, recipientDisplayName, ok := h.resolveShareRecipients(w, r, &req)
 	if !ok {
 		return
 	}
@@ -86,11 +101,16 @@ func (h *Handler) CreateShare(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !h.gateMustInvite(w, r, &req, resolvedUser) {
+	recipients, ok = h.gateMustInvite(w, r, &req, recipients)
+	if !ok {
 		return
 	}
 
-	h.storeIncomingShare(w, r, &req, senderHost, ownerHost, resolvedUser)
+	if !requireRecipientSSHKeys(w, r, &req, recipients) {
+		return
+	}
+
+	h.storeIncomingShare(w, r, &req, senderHost, ownerHost, recipients, recipientDisplayName)
 }
 
 func dedupeValidationErrors(errs []spec.ValidationError) []spec.ValidationError {
@@ -179,3 +199,68 @@ func (h *Handler) resolveRecipient(ctx context.Context, identifier string) (*ide
 
 	return nil, identity.ErrUserNotFound
 }
+
+// resolveGroupMembers resolves identifier to a local group (by name, then id)
+// and loads its members. Members whose user no longer exists are skipped; a
+// group with no remaining members is reported as not found.
+func (h *Handler) resolveGroupMembers(ctx context.Context, identifier string) (*identity.Group, []*identity.User, error) {
+	if h.groupRepo == nil {
+		return nil, nil, identity.ErrGroupNotFound
+	}
+
+	group, err := h.groupRepo.GetByName(ctx, identifier)
+	if errors.Is(err, identity.ErrGroupNotFound) {
+		group, err = h.groupRepo.Get(ctx, identifier)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("incoming: load group: %w", err)
+	}
+
+	members := make([]*identity.User, 0, len(group.Members))
+
+	for _, userID := range group.Members {
+		user, err := h.partyRepo.Get(ctx, userID)
+		if errors.Is(err, identity.ErrUserNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("incoming: load group member: %w", err)
+		}
+
+		members = append(members, user)
+	}
+
+	if len(members) == 0 {
+		return nil, nil, identity.ErrGroupNotFound
+	}
+
+	return group, members, nil
+}
+
+// lookupRecipients resolves the shareWith identifier to the local users that
+// receive the share and the display name reported back to the sender. User
+// shares (and unsupported types, rejected afterwards) resolve a single user.
+func (h *Handler) lookupRecipients(ctx context.Context, shareType, identifier string) ([]*identity.User, string, error) {
+	if shareType == spec.ShareTypeGroup {
+		group, members, err := h.resolveGroupMembers(ctx, identifier)
+		if err != nil {
+			return nil, "", err
+		}
+
+		displayName := group.DisplayName
+		if displayName == "" {
+			displayName = group.Name
+		}
+
+		return members, displayName, nil
+	}
+
+	user, err := h.resolveRecipient(ctx, identifier)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return []*identity.User{user}, user.DisplayName, nil
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
@@ -113,3 +114,32 @@ func TestCreateShare_Success_ResolvesByFederatedOpaqueID(t *testing.T) {
 		t.Errorf("expected recipientDisplayName 'Alice A', got %q", resp.RecipientDisplayName)
 	}
 }
+
+func TestCreateShare_FolderResourceType_Admitted(t *testing.T) {
+	t.Parallel()
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	partyRepo := setupTestPartyRepo(t)
+	handler, ownerHost := newAcceptedShareHandler(t, repo, partyRepo)
+
+	body := strings.Replace(validShareBodyWithHosts("alice@localhost:9200", ownerHost),
+		`"resourceType": "file"`, `"resourceType": "folder"`, 1)
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/shares", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	handler.CreateShare(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 for folder resourceType, got %d: %s", w.Code, w.Body.String())
+	}
+
+	stored, err := repo.ListByRecipientUserID(context.Background(), "user-a-uuid")
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("expected one stored share, got %d (err=%v)", len(stored), err)
+	}
+
+	if stored[0].ResourceType != "folder" {
+		t.Errorf("stored resourceType = %q, want folder", stored[0].ResourceType)
+	}
+}
@@ -389,7 +389,11 @@ func TestCreateShare_DuplicateReorderedPermissionsReturns409(t *testing.T) {
 	w := httptest.NewRecorder()
 	resolvedUser := &identity.User{DisplayName: "Alice A"}
 
-	if !incoming.HandleExistingIncomingShareForTest(w, slog.Default(), existing, req, "sender.com", resolvedUser) {
+	_, handled := incoming.HandleExistingIncomingSharesForTest(
+		w, slog.Default(), []*incoming.IncomingShare{existing}, req, "sender.com",
+		[]*identity.User{resolvedUser}, resolvedUser.DisplayName,
+	)
+	if !handled {
 		t.Fatal("expected existing share to be handled")
 	}
 
@@ -407,7 +411,11 @@ func TestCreateShare_DuplicateReorderedRequirementsReturns409(t *testing.T) {
 	w := httptest.NewRecorder()
 	resolvedUser := &identity.User{DisplayName: "Alice A"}
 
-	if !incoming.HandleExistingIncomingShareForTest(w, slog.Default(), existing, req, "sender.com", resolvedUser) {
+	_, handled := incoming.HandleExistingIncomingSharesForTest(
+		w, slog.Default(), []*incoming.IncomingShare{existing}, req, "sender.com",
+		[]*identity.User{resolvedUser}, resolvedUser.DisplayName,
+	)
+	if !handled {
 		t.Fatal("expected existing share to be handled")
 	}
 
@@ -61,6 +61,7 @@ func newTestHandlerWithResolver(
 	return incoming.NewHandler(
 		repo,
 		partyRepo,
+		nil,   // no group repo
 		nil,   // no policy engine
 		nil,   // no incoming invite repo
 		nil,   // no outgoing invite repo
@@ -71,6 +72,30 @@ func newTestHandlerWithResolver(
 	)
 }
 
+// newTestHandlerWithGroups creates a handler that resolves group shares
+// against groupRepo, with optional invite repositories for gate tests.
+func newTestHandlerWithGroups(
+	repo incoming.IncomingShareRepo,
+	partyRepo identity.PartyRepo,
+	groupRepo identity.GroupRepo,
+	incomingInvites invitesincoming.IncomingInviteRepo,
+	outgoingInvites invitesoutgoing.OutgoingInviteRepo,
+	enforced bool,
+) *incoming.Handler {
+	return incoming.NewHandler(
+		repo,
+		partyRepo,
+		groupRepo,
+		nil, // no policy engine
+		incomingInvites,
+		outgoingInvites,
+		enforced,
+		"localhost:9200",
+		"https",
+		nil,
+	)
+}
+
 // newTestHandlerWithInvites creates a handler with invite repositories and an
 // explicit must-invite enforcement flag for gate tests.
 func newTestHandlerWithInvites(
@@ -83,6 +108,7 @@ func newTestHandlerWithInvites(
 	return incoming.NewHandler(
 		repo,
 		partyRepo,
+		nil, // no group repo
 		nil, // no policy engine
 		incomingInvites,
 		outgoingInvites,
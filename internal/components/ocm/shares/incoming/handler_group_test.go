@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package incoming_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/address"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
+)
+
+const groupShareProviderID = "group-provider-1"
+
+// groupShareBody builds a valid webdav group share addressed to groupName on
+// the local test provider.
+func groupShareBody(groupName string) string {
+	body := validShareBodyWithOwnerAndSenderHosts(groupName+"@localhost:9200", "sender.com", "sender.com", groupShareProviderID)
+
+	return strings.Replace(body, `"shareType": "user"`, `"shareType": "group"`, 1)
+}
+
+func seedGroup(t *testing.T, repo identity.GroupRepo, name string, members ...string) *identity.Group {
+	t.Helper()
+
+	group := &identity.Group{Name: name, DisplayName: "Group " + name, Members: members}
+	if err := repo.Create(context.Background(), group); err != nil {
+		t.Fatalf("seed group %s: %v", name, err)
+	}
+
+	return group
+}
+
+func recipientsOf(t *testing.T, repo incoming.IncomingShareRepo) []string {
+	t.Helper()
+
+	copies, err := repo.ListByProviderID(context.Background(), "sender.com", groupShareProviderID)
+	if err != nil {
+		t.Fatalf("ListByProviderID: %v", err)
+	}
+
+	ids := make([]string, 0, len(copies))
+	for _, c := range copies {
+		if c.ShareType != "group" {
+			t.Errorf("copy for %s has shareType %q, want group", c.RecipientUserID, c.ShareType)
+		}
+
+		ids = append(ids, c.RecipientUserID)
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
+
+func TestCreateShare_GroupFansOutToMembers(t *testing.T) {
+	t.Parallel()
+
+	repos := tsrepos.OpenMemory(t)
+	partyRepo := setupTestPartyRepo(t)
+	seedGroup(t, repos.Groups, "eng", "user-a-uuid", "user-b-uuid", "departed-uuid")
+	handler := newTestHandlerWithGroups(repos.IncomingShares, partyRepo, repos.Groups, nil, nil, false)
+
+	w := postShare(t, handler, groupShareBody("eng"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if !strings.Contains(w.Body.String(), `"recipientDisplayName":"Group eng"`) {
+		t.Errorf("expected group display name in response, got %s", w.Body.String())
+	}
+
+	if got := recipientsOf(t, repos.IncomingShares); !slices.Equal(got, []string{"user-a-uuid", "user-b-uuid"}) {
+		t.Fatalf("recipients = %v, want both existing members", got)
+	}
+}
+
+func TestCreateShare_GroupResendIsIdempotentAndAddsNewMembers(t *testing.T) {
+	t.Parallel()
+
+	repos := tsrepos.OpenMemory(t)
+	partyRepo := setupTestPartyRepo(t)
+	group := seedGroup(t, repos.Groups, "eng", "user-a-uuid")
+	handler := newTestHandlerWithGroups(repos.IncomingShares, partyRepo, repos.Groups, nil, nil, false)
+
+	if w := postShare(t, handler, groupShareBody("eng")); w.Code != http.StatusCreated {
+		t.Fatalf("first post: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := postShare(t, handler, groupShareBody("eng")); w.Code != http.StatusCreated {
+		t.Fatalf("resend: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if got := recipientsOf(t, repos.IncomingShares); !slices.Equal(got, []string{"user-a-uuid"}) {
+		t.Fatalf("after resend recipients = %v, want one copy", got)
+	}
+
+	group.Members = append(group.Members, "user-b-uuid")
+	if err := repos.Groups.Update(context.Background(), group); err != nil {
+		t.Fatalf("update group: %v", err)
+	}
+
+	if w := postShare(t, handler, groupShareBody("eng")); w.Code != http.StatusCreated {
+		t.Fatalf("resend after join: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if got := recipientsOf(t, repos.IncomingShares); !slices.Equal(got, []string{"user-a-uuid", "user-b-uuid"}) {
+		t.Fatalf("after join recipients = %v, want both members", got)
+	}
+}
+
+func TestCreateShare_GroupResendWithDifferentPayloadConflicts(t *testing.T) {
+	t.Parallel()
+
+	repos := tsrepos.OpenMemory(t)
+	partyRepo := setupTestPartyRepo(t)
+	seedGroup(t, repos.Groups, "eng", "user-a-uuid")
+	handler := newTestHandlerWithGroups(repos.IncomingShares, partyRepo, repos.Groups, nil, nil, false)
+
+	if w := postShare(t, handler, groupShareBody("eng")); w.Code != http.StatusCreated {
+		t.Fatalf("first post: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	changed := strings.Replace(groupShareBody("eng"), `"name": "test.txt"`, `"name": "other.txt"`, 1)
+	if w := postShare(t, handler, changed); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateShare_GroupNotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		members []string
+	}{
+		{name: "unknown group"},
+		{name: "group without existing members", members: []string{"departed-uuid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repos := tsrepos.OpenMemory(t)
+			if tt.members != nil {
+				seedGroup(t, repos.Groups, "eng", tt.members...)
+			}
+
+			handler := newTestHandlerWithGroups(repos.IncomingShares, setupTestPartyRepo(t), repos.Groups, nil, nil, false)
+
+			w := postShare(t, handler, groupShareBody("eng"))
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+
+			if !strings.Contains(w.Body.String(), "RECIPIENT_NOT_FOUND") {
+				t.Fatalf("expected RECIPIENT_NOT_FOUND, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCreateShare_GroupMustInviteFiltersMembers(t *testing.T) {
+	t.Parallel()
+
+	repos := tsrepos.OpenMemory(t)
+	partyRepo := setupTestPartyRepo(t)
+	seedGroup(t, repos.Groups, "eng", "user-a-uuid", "user-b-uuid")
+	seedAcceptedIncomingInvite(t, repos.IncomingInvites,
+		address.EncodeFederatedOpaqueID(mustInviteRemoteUser, mustInviteRemoteHost), mustInviteRemoteHost)
+	handler := newTestHandlerWithGroups(repos.IncomingShares, partyRepo, repos.Groups, repos.IncomingInvites, repos.OutgoingInvites, true)
+
+	body := strings.Replace(mustInviteShareBody(mustInviteSenderString(t), groupShareProviderID),
+		`"shareWith": "alice@localhost:9200"`, `"shareWith": "eng@localhost:9200"`, 1)
+	body = strings.Replace(body, `"shareType": "user"`, `"shareType": "group"`, 1)
+
+	w := postShare(t, handler, body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if got := recipientsOf(t, repos.IncomingShares); !slices.Equal(got, []string{mustInviteRecipientID}) {
+		t.Fatalf("recipients = %v, want only the member with an invite", got)
+	}
+}
+
+func TestCreateShare_GroupMustInviteRejectsWhenNoMemberQualifies(t *testing.T) {
+	t.Parallel()
+
+	repos := tsrepos.OpenMemory(t)
+	partyRepo := setupTestPartyRepo(t)
+	seedGroup(t, repos.Groups, "eng", "user-b-uuid")
+	handler := newTestHandlerWithGroups(repos.IncomingShares, partyRepo, repos.Groups, repos.IncomingInvites, repos.OutgoingInvites, true)
+
+	body := strings.Replace(mustInviteShareBody(mustInviteSenderString(t), groupShareProviderID),
+		`"shareWith": "alice@localhost:9200"`, `"shareWith": "eng@localhost:9200"`, 1)
+	body = strings.Replace(body, `"shareType": "user"`, `"shareType": "group"`, 1)
+
+	w := postShare(t, handler, body)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if got := recipientsOf(t, repos.IncomingShares); len(got) != 0 {
+		t.Fatalf("recipients = %v, want none", got)
+	}
+}
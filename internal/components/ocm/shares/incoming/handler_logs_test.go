@@ -57,6 +57,7 @@ func TestHandler_DoesNotLogSensitiveValues(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				false,
 				"localhost:9200",
 				"https",
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package incoming_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	tscrypto "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/crypto"
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+// sshShareBody returns a multi share with only an ssh arm carrying sshArm.
+func sshShareBody(providerID, sshArm string) string {
+	return `{
+		"shareWith": "alice@localhost:9200",
+		"name": "dataset.tar",
+		"providerId": "` + providerID + `",
+		"owner": "owner@sender.com",
+		"sender": "sender@sender.com",
+		"shareType": "user",
+		"resourceType": "file",
+		"protocol": {"name": "multi", "ssh": ` + sshArm + `}
+	}`
+}
+
+// setSSHKeys registers keys for the fixture user alice.
+func setSSHKeys(t *testing.T, partyRepo identity.PartyRepo, keys ...string) {
+	t.Helper()
+
+	alice, err := partyRepo.Get(context.Background(), "user-a-uuid")
+	if err != nil {
+		t.Fatalf("Get alice: %v", err)
+	}
+
+	alice.SSHPublicKeys = keys
+	if err := partyRepo.Update(context.Background(), alice); err != nil {
+		t.Fatalf("Update alice: %v", err)
+	}
+}
+
+func TestCreateShare_AdmitsSSHArmAndReturnsRecipientKeys(t *testing.T) {
+	t.Parallel()
+
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	partyRepo := setupTestPartyRepo(t)
+	key := tscrypto.MustSSHKey(t)
+	setSSHKeys(t, partyRepo, key.AuthorizedKey)
+
+	handler := newTestHandler(repo, partyRepo)
+	body := sshShareBody("ssh-1", `{"uri": "w1@sftp.sender.com:2022/dataset.tar"}`)
+
+	w := postShare(t, handler, body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp spec.CreateShareResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if !slices.Equal(resp.RecipientPublicKeys, []string{key.AuthorizedKey}) {
+		t.Errorf("recipientPublicKeys = %v, want alice's key", resp.RecipientPublicKeys)
+	}
+
+	stored, err := repo.GetByProviderID(context.Background(), "sender.com", "ssh-1")
+	if err != nil {
+		t.Fatalf("GetByProviderID: %v", err)
+	}
+
+	if stored.SSHURI != "w1@sftp.sender.com:2022/dataset.tar" || !slices.Equal(stored.SSHAccessTypes, []string{spec.AccessTypeRemote}) {
+		t.Errorf("stored ssh arm = %q/%v", stored.SSHURI, stored.SSHAccessTypes)
+	}
+
+	if stored.WebDAVID != "" {
+		t.Errorf("ssh-only share must not carry a webdav id, got %q", stored.WebDAVID)
+	}
+
+	// The idempotent resend returns the keys again; a changed uri conflicts.
+	w = postShare(t, handler, body)
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), "recipientPublicKeys") {
+		t.Fatalf("identical resend = %d %s", w.Code, w.Body.String())
+	}
+
+	if w := postShare(t, handler, sshShareBody("ssh-1", `{"uri": "w1@sftp.sender.com:2022/other"}`)); w.Code != http.StatusConflict {
+		t.Fatalf("changed ssh arm: expected 409, got %d", w.Code)
+	}
+}
+
+func TestCreateShare_WebDAVShareOmitsRecipientKeys(t *testing.T) {
+	t.Parallel()
+
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	partyRepo := setupTestPartyRepo(t)
+	setSSHKeys(t, partyRepo, tscrypto.MustSSHKey(t).AuthorizedKey)
+
+	w := postShare(t, newTestHandler(repo, partyRepo), validShareBody("alice@localhost:9200"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if strings.Contains(w.Body.String(), "recipientPublicKeys") {
+		t.Errorf("webdav-only response must not leak SSH keys: %s", w.Body.String())
+	}
+}
+
+func TestCreateShare_RejectsSSHArm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		arm        string
+		withKey    bool
+		wantStatus int
+		wantBody   string
+	}{
+		{"no recipient key", `{"uri": "w@h:22/p"}`, false, http.StatusBadRequest, "SSH_KEY_REQUIRED"},
+		{"missing uri", `{"accessTypes": ["remote"]}`, true, http.StatusBadRequest, "protocol.ssh.uri"},
+		{"malformed uri", `{"uri": "h/p"}`, true, http.StatusBadRequest, "INVALID_FORMAT"},
+		{"unknown access type", `{"uri": "w@h/p", "accessTypes": ["stream"]}`, true, http.StatusNotImplemented, "PROTOCOL_NOT_SUPPORTED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := tsrepos.OpenMemory(t).IncomingShares
+			partyRepo := setupTestPartyRepo(t)
+
+			if tt.withKey {
+				setSSHKeys(t, partyRepo, tscrypto.MustSSHKey(t).AuthorizedKey)
+			}
+
+			w := postShare(t, newTestHandler(repo, partyRepo), sshShareBody("ssh-reject", tt.arm))
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Fatalf("got %d %s, want %d with %s", w.Code, w.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+
+			assertShareNotStored(t, repo, "sender.com", "ssh-reject")
+		})
+	}
+}
@@ -68,21 +68,13 @@ func TestCreateShare_RejectsValidMultiWebapp(t *testing.T) {
 	assertShareNotStored(t, repo, ownerHost, "webapp-reject")
 }
 
-// TestCreateShare_RejectsMultiArmWithWebappAndWebDAV covers the 501-at-admit
-// path when a share carries both a valid webdav arm and a valid webapp arm.
-// ocmgo does not support inbound webapp receive, so the webapp arm must
-// trigger 501 at admit regardless of a co-present webdav arm, and no share
-// may be persisted.
-func TestCreateShare_RejectsMultiArmWithWebappAndWebDAV(t *testing.T) {
-	t.Parallel()
-	repo := tsrepos.OpenMemory(t).IncomingShares
-	partyRepo := setupTestPartyRepo(t)
-	handler, ownerHost := newAcceptedShareHandler(t, repo, partyRepo)
-
-	body := `{
+// multiArmShareBody returns a share carrying both a webdav arm and a webapp
+// arm with webappPerms.
+func multiArmShareBody(ownerHost, webappPerms string) string {
+	return `{
 		"shareWith": "alice@localhost:9200",
 		"name": "multi-arm-resource",
-		"providerId": "multi-arm-webapp-reject",
+		"providerId": "multi-arm-webapp",
 		"owner": "owner@` + ownerHost + `",
 		"sender": "sender@` + ownerHost + `",
 		"shareType": "user",
@@ -98,32 +90,57 @@ func TestCreateShare_RejectsMultiArmWithWebappAndWebDAV(t *testing.T) {
 			"webapp": {
 				"uri": "https://` + ownerHost + `/apps/files/abc",
 				"targets": ["blank"],
-				"permissions": ["view", "read"],
+				"permissions": [` + webappPerms + `],
 				"requirements": ["must-exchange-token"],
-				"sharedSecret": "secret123"
+				"sharedSecret": "webapp-secret123"
 			}
 		}
 	}`
-	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/shares", bytes.NewBufferString(body))
-	req.Header.Set("Content-Type", "application/json")
+}
 
-	w := httptest.NewRecorder()
-	handler.CreateShare(w, req)
+// TestCreateShare_AdmitsWebappNextToWebDAV stores the webapp arm, including
+// its own secret, when it arrives alongside a webdav arm, and treats a
+// changed webapp arm on resend as a conflicting payload.
+func TestCreateShare_AdmitsWebappNextToWebDAV(t *testing.T) {
+	t.Parallel()
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	partyRepo := setupTestPartyRepo(t)
+	handler, ownerHost := newAcceptedShareHandler(t, repo, partyRepo)
 
-	if w.Code != http.StatusNotImplemented {
-		t.Fatalf("expected 501 for multi-arm admit with webapp, got %d: %s", w.Code, w.Body.String())
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/shares", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		handler.CreateShare(w, req)
+
+		return w
 	}
 
-	var resp spec.OCMErrorResponse
-	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
-		t.Fatalf("failed to decode error response: %v", err)
+	if w := post(multiArmShareBody(ownerHost, `"view", "read"`)); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 for multi-arm admit with webapp, got %d: %s", w.Code, w.Body.String())
 	}
 
-	if resp.Message != "PROTOCOL_NOT_SUPPORTED" {
-		t.Errorf("expected PROTOCOL_NOT_SUPPORTED, got %q", resp.Message)
+	stored, err := repo.GetByProviderID(context.Background(), ownerHost, "multi-arm-webapp")
+	if err != nil {
+		t.Fatalf("GetByProviderID: %v", err)
 	}
 
-	assertShareNotStored(t, repo, ownerHost, "multi-arm-webapp-reject")
+	if stored.WebappURI != "https://"+ownerHost+"/apps/files/abc" || stored.WebappSecret != "webapp-secret123" {
+		t.Errorf("stored webapp uri/secret = %q/%q", stored.WebappURI, stored.WebappSecret)
+	}
+
+	if len(stored.WebappPermissions) != 2 || len(stored.WebappTargets) != 1 || stored.SharedSecret != "secret123" {
+		t.Errorf("stored share = %+v, want both arms kept apart", stored)
+	}
+
+	if w := post(multiArmShareBody(ownerHost, `"view", "read"`)); w.Code != http.StatusCreated {
+		t.Fatalf("identical resend: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := post(multiArmShareBody(ownerHost, `"view", "write"`)); w.Code != http.StatusConflict {
+		t.Fatalf("changed webapp arm: expected 409, got %d: %s", w.Code, w.Body.String())
+	}
 }
 
 func TestCreateShare_RejectsWebappMissingFields(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 
 	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
@@ -138,7 +139,7 @@ func TestCreateShare_RejectsUnsupportedWebDAVPermissions(t *testing.T) {
 		"sender": "sender@sender.com",
 		"shareType": "user",
 		"resourceType": "file",
-		"protocol": {"name": "webdav", "webdav": {"uri": "x", "sharedSecret": "s", "permissions": ["write"], "requirements": ["must-exchange-token"]}}
+		"protocol": {"name": "webdav", "webdav": {"uri": "x", "sharedSecret": "s", "permissions": ["share"], "requirements": ["must-exchange-token"]}}
 	}`
 	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/shares", bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -166,7 +167,7 @@ func TestCreateShare_RejectsUnsupportedWebDAVAccessTypes(t *testing.T) {
 		"sender": "sender@sender.com",
 		"shareType": "user",
 		"resourceType": "file",
-		"protocol": {"name": "webdav", "webdav": {"uri": "x", "sharedSecret": "s", "permissions": ["read"], "accessTypes": ["datatx"], "requirements": ["must-exchange-token"]}}
+		"protocol": {"name": "webdav", "webdav": {"uri": "x", "sharedSecret": "s", "permissions": ["read"], "accessTypes": ["sync"], "requirements": ["must-exchange-token"]}}
 	}`
 	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/shares", bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -180,6 +181,37 @@ func TestCreateShare_RejectsUnsupportedWebDAVAccessTypes(t *testing.T) {
 	}
 }
 
+func TestCreateShare_AdmitsDataTxAccessType(t *testing.T) {
+	t.Parallel()
+	repo := tsrepos.OpenMemory(t).IncomingShares
+	partyRepo := setupTestPartyRepo(t)
+	handler := newTestHandler(repo, partyRepo)
+
+	body := `{
+		"shareWith": "alice@localhost:9200",
+		"name": "dataset.tar",
+		"providerId": "wdav-datatx",
+		"owner": "owner@sender.com",
+		"sender": "sender@sender.com",
+		"shareType": "user",
+		"resourceType": "file",
+		"protocol": {"name": "webdav", "webdav": {"uri": "x", "sharedSecret": "s", "permissions": ["read"], "accessTypes": ["datatx"], "requirements": ["must-exchange-token"], "size": 42}}
+	}`
+
+	if w := postShare(t, handler, body); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 for a datatx share, got %d: %s", w.Code, w.Body.String())
+	}
+
+	stored, err := repo.GetByProviderID(context.Background(), "sender.com", "wdav-datatx")
+	if err != nil {
+		t.Fatalf("GetByProviderID: %v", err)
+	}
+
+	if !slices.Equal(stored.WebDAVAccessTypes, []string{"datatx"}) {
+		t.Errorf("stored webdav access types = %v, want [datatx]", stored.WebDAVAccessTypes)
+	}
+}
+
 func TestCreateShare_MissingWebDAVArm_Returns400(t *testing.T) {
 	t.Parallel()
 	repo := tsrepos.OpenMemory(t).IncomingShares
@@ -8,6 +8,7 @@ package incoming
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -21,7 +22,9 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/appctx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // parseCreateShareRequest reads and validates the initial request envelope.
@@ -132,8 +135,18 @@ func (h *Handler) validateProtocolAdmissions(
 			return false
 		}
 
-		log.Warn("share rejected: webapp protocol not supported")
-		spec.WriteProtocolNotSupported(w)
+		// The webapp arm is admitted only next to a WebDAV arm: the inbox
+		// opens it in the sender's viewer, but file access stays on WebDAV.
+		if webdav == nil {
+			log.Warn("share rejected: webapp protocol without a webdav arm is not supported")
+			spec.WriteProtocolNotSupported(w)
+
+			return false
+		}
+	}
+
+	if sshErrs := spec.ValidateSSHProtocolWire(req.Protocol.SSH); len(sshErrs) > 0 {
+		writeProtocolValidationErrors(w, sshErrs)
 
 		return false
 	}
@@ -141,12 +154,14 @@ func (h *Handler) validateProtocolAdmissions(
 	return true
 }
 
-// resolveShareRecipient validates owner/sender formats, shareWith provider, and resolves the local recipient.
-func (h *Handler) resolveShareRecipient(
+// resolveShareRecipients validates owner/sender formats and the shareWith
+// provider, then resolves the local recipients: the addressed user, or every
+// member of the addressed group. It also returns the recipient display name.
+func (h *Handler) resolveShareRecipients(
 	w http.ResponseWriter,
 	r *http.Request,
 	req *spec.NewShareRequest,
-) (*identity.User, bool) {
+) ([]*identity.User, string, bool) {
 	log := appctx.GetLogger(r.Context())
 
 	var formatErrs []spec.ValidationError
@@ -162,7 +177,7 @@ func (h *Handler) resolveShareRecipient(
 		log.Warn("share owner/sender format invalid", "errors", len(formatErrs))
 		spec.WriteValidationError(w, "INVALID_FIELD_FORMAT", formatErrs)
 
-		return nil, false
+		return nil, "", false
 	}
 
 	identifier, shareWithProvider, err := address.Parse(req.ShareWith)
@@ -172,7 +187,7 @@ func (h *Handler) resolveShareRecipient(
 			{Name: fieldShareWith, Message: validationInvalidFormat},
 		})
 
-		return nil, false
+		return nil, "", false
 	}
 
 	normalizedProvider, err := hostport.Normalize(shareWithProvider, h.localScheme)
@@ -182,7 +197,7 @@ func (h *Handler) resolveShareRecipient(
 			{Name: fieldShareWith, Message: "PROVIDER_MISMATCH"},
 		})
 
-		return nil, false
+		return nil, "", false
 	}
 
 	if !strings.EqualFold(normalizedProvider, h.localProviderFQDNForCompare) {
@@ -193,47 +208,47 @@ func (h *Handler) resolveShareRecipient(
 			{Name: fieldShareWith, Message: "PROVIDER_MISMATCH"},
 		})
 
-		return nil, false
+		return nil, "", false
 	}
 
-	resolvedUser, err := h.resolveRecipient(r.Context(), identifier)
+	recipients, recipientDisplayName, err := h.lookupRecipients(r.Context(), req.ShareType, identifier)
 	if err != nil {
-		if errors.Is(err, identity.ErrUserNotFound) {
+		if errors.Is(err, identity.ErrUserNotFound) || errors.Is(err, identity.ErrGroupNotFound) {
 			spec.WriteValidationError(w, "RECIPIENT_NOT_FOUND", []spec.ValidationError{
 				{Name: fieldShareWith, Message: "NOT_FOUND"},
 			})
 
-			return nil, false
+			return nil, "", false
 		}
 
 		if identity.IsInfrastructureError(err) {
 			log.Warn("recipient lookup failed", "error", err)
 			spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
 
-			return nil, false
+			return nil, "", false
 		}
 
 		log.Warn("unexpected recipient lookup error", "error", err)
 		spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
 
-		return nil, false
+		return nil, "", false
 	}
 
-	if req.ShareType != "user" {
+	if !spec.IsSupportedShareType(req.ShareType) {
 		log.Warn("unsupported share type", "share_type", req.ShareType)
 		spec.WriteShareTypeNotSupported(w)
 
-		return nil, false
+		return nil, "", false
 	}
 
 	if !spec.IsSupportedResourceType(req.ResourceType) {
 		log.Warn("unsupported resource type", "resource_type", req.ResourceType)
 		spec.WriteResourceTypeNotSupported(w)
 
-		return nil, false
+		return nil, "", false
 	}
 
-	return resolvedUser, true
+	return recipients, recipientDisplayName, true
 }
 
 // authenticateSenderAndResolveOwner enforces peer policy and validates the owner provider against the peer identity.
@@ -311,17 +326,20 @@ func (h *Handler) authenticateSenderAndResolveOwner(
 // against an exchanged invite is required, checked bidirectionally (the remote
 // sender either invited the local recipient, or accepted the local recipient's
 // invite). There is no host-only fallback in enforced mode; the explicit
-// opt-out retains legacy acceptance.
+// opt-out retains legacy acceptance. For group shares the check runs per
+// member: only members with an exchanged invite receive the share, and the
+// share is rejected when none qualifies.
+// It returns the recipients that passed the gate.
 // See https://github.com/cs3org/OCM-API/blob/6a0586183cbef10ecae9dedc42561806447eb2f5/IETF-OCM.md#L763-L765
 // See https://github.com/cs3org/OCM-API/blob/6a0586183cbef10ecae9dedc42561806447eb2f5/IETF-OCM.md#L1303-L1307
 func (h *Handler) gateMustInvite(
 	w http.ResponseWriter,
 	r *http.Request,
 	req *spec.NewShareRequest,
-	resolvedUser *identity.User,
-) bool {
+	recipients []*identity.User,
+) ([]*identity.User, bool) {
 	if !h.mustInviteEnforced {
-		return true
+		return recipients, true
 	}
 
 	log := appctx.GetLogger(r.Context())
@@ -330,7 +348,7 @@ func (h *Handler) gateMustInvite(
 		log.Error("must-invite enforced but invite repositories are not wired")
 		spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
 
-		return false
+		return nil, false
 	}
 
 	senderUserID, senderProvider, err := address.Parse(req.Sender)
@@ -338,7 +356,7 @@ func (h *Handler) gateMustInvite(
 		log.Warn("must-invite: malformed sender", "error", err)
 		spec.WriteOCMError(w, reason.OCMStatus(reason.SenderNotTrusted), reason.SenderNotTrusted)
 
-		return false
+		return nil, false
 	}
 
 	senderHostNormalized, err := hostport.Normalize(senderProvider, h.localScheme)
@@ -347,7 +365,7 @@ func (h *Handler) gateMustInvite(
 			"sender_provider", senderProvider, "error", err)
 		spec.WriteOCMError(w, reason.OCMStatus(reason.SenderNotTrusted), reason.SenderNotTrusted)
 
-		return false
+		return nil, false
 	}
 
 	// Anti-spoof: an authenticated peer's signature authority must match the
@@ -360,75 +378,128 @@ func (h *Handler) gateMustInvite(
 				"sender_provider", senderProvider)
 			spec.WriteOCMError(w, reason.OCMStatus(reason.SenderNotTrusted), reason.SenderNotTrusted)
 
-			return false
+			return nil, false
 		}
 
 		senderHostNormalized = peerIdentity.AuthorityForCompare
 	}
 
+	allowed := make([]*identity.User, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		exchanged, err := h.hasExchangedInvite(r, recipient.ID, senderUserID, senderHostNormalized)
+		if err != nil {
+			spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
+
+			return nil, false
+		}
+
+		if exchanged {
+			allowed = append(allowed, recipient)
+
+			continue
+		}
+
+		log.Warn("must-invite: no exchanged invite for sender",
+			"sender_user_id", senderUserID,
+			"sender_host", senderHostNormalized,
+			"recipient_user_id", recipient.ID)
+	}
+
+	if len(allowed) == 0 {
+		spec.WriteOCMError(w, reason.OCMStatus(reason.SenderNotTrusted), reason.SenderNotTrusted)
+
+		return nil, false
+	}
+
+	return allowed, true
+}
+
+// hasExchangedInvite reports whether the remote sender and the local recipient
+// exchanged an accepted invite in either direction. Lookup failures are
+// logged and returned.
+func (h *Handler) hasExchangedInvite(r *http.Request, recipientUserID, senderUserID, senderHost string) (bool, error) {
+	log := appctx.GetLogger(r.Context())
+
 	// Direction 1: the remote sender invited the local recipient and the
 	// recipient accepted (incoming invite).
-	_, err = h.incomingInviteRepo.FindAcceptedForSender(r.Context(), resolvedUser.ID, senderUserID, senderHostNormalized)
+	_, err := h.incomingInviteRepo.FindAcceptedForSender(r.Context(), recipientUserID, senderUserID, senderHost)
 	if err == nil {
-		return true
+		return true, nil
 	}
 
 	if !errors.Is(err, invites.ErrInviteNotFound) {
 		log.Error("must-invite: incoming invite lookup failed", "error", err)
-		spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
 
-		return false
+		return false, fmt.Errorf("incoming: find accepted incoming invite: %w", err)
 	}
 
 	// Direction 2: the local recipient invited the remote sender and the
 	// sender accepted (outgoing invite).
-	_, err = h.outgoingInviteRepo.FindAcceptedForRecipient(r.Context(), resolvedUser.ID, senderUserID, senderHostNormalized)
+	_, err = h.outgoingInviteRepo.FindAcceptedForRecipient(r.Context(), recipientUserID, senderUserID, senderHost)
 	if err == nil {
-		return true
+		return true, nil
 	}
 
 	if !errors.Is(err, invites.ErrInviteNotFound) {
 		log.Error("must-invite: outgoing invite lookup failed", "error", err)
-		spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
 
-		return false
+		return false, fmt.Errorf("incoming: find accepted outgoing invite: %w", err)
 	}
 
-	log.Warn("must-invite: no exchanged invite for sender",
-		"sender_user_id", senderUserID,
-		"sender_host", senderHostNormalized,
-		"recipient_user_id", resolvedUser.ID)
-	spec.WriteOCMError(w, reason.OCMStatus(reason.SenderNotTrusted), reason.SenderNotTrusted)
-
-	return false
+	return false, nil
 }
 
-// storeIncomingShare looks up an existing share by sender host and provider ID.
-// When a duplicate exists with identical compared payload fields, it responds 201
-// idempotently. When a duplicate exists with a mismatched payload, it responds
-// 409. When no duplicate exists, it persists a new share and responds 201.
+// storeIncomingShare looks up the existing copies of a share by sender host and
+// provider ID. When a copy exists with a mismatched payload, it responds 409.
+// When every recipient already holds a matching copy, it responds 201
+// idempotently. Otherwise it persists one new share per recipient still
+// missing a copy (a group share fans out to each member) and responds 201.
 func (h *Handler) storeIncomingShare(
 	w http.ResponseWriter,
 	r *http.Request,
 	req *spec.NewShareRequest,
 	senderHost,
 	ownerHost string,
-	resolvedUser *identity.User,
+	recipients []*identity.User,
+	recipientDisplayName string,
 ) {
 	log := appctx.GetLogger(r.Context())
 
-	existing, err := h.repo.GetByProviderID(r.Context(), senderHost, req.ProviderID)
-	if err != nil && !errors.Is(err, ErrShareNotFound) {
+	existing, err := h.repo.ListByProviderID(r.Context(), senderHost, req.ProviderID)
+	if err != nil {
 		log.Error("failed to look up incoming share by provider key", "error", err)
 		spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
 
 		return
 	}
 
-	if handleExistingIncomingShare(w, log, existing, req, senderHost, resolvedUser) {
+	pending, handled := handleExistingIncomingShares(w, log, existing, req, senderHost, recipients, recipientDisplayName)
+	if handled {
 		return
 	}
 
+	for _, recipient := range pending {
+		if !h.createIncomingShare(w, r, req, senderHost, ownerHost, recipient) {
+			return
+		}
+	}
+
+	writeIncomingCreateShareResponse(w, log, recipientDisplayName, recipientSSHKeys(req, recipients))
+}
+
+// createIncomingShare persists the recipient's copy of the share. On failure
+// it writes the error response and returns false.
+func (h *Handler) createIncomingShare(
+	w http.ResponseWriter,
+	r *http.Request,
+	req *spec.NewShareRequest,
+	senderHost,
+	ownerHost string,
+	recipient *identity.User,
+) bool {
+	log := appctx.GetLogger(r.Context())
+
 	webdavURI, webdavSharedSecret, webdavPermissions, webdavRequirements := extractWebDAV(req)
 
 	share := &IncomingShare{
@@ -446,36 +517,58 @@ func (h *Handler) storeIncomingShare(
 		SenderDisplayName:    req.SenderDisplayName,
 		Expiration:           req.Expiration,
 		Status:               shares.ShareStatusPending,
-		RecipientUserID:      resolvedUser.ID,
-		RecipientDisplayName: resolvedUser.DisplayName,
+		RecipientUserID:      recipient.ID,
+		RecipientDisplayName: recipient.DisplayName,
 		WebDAVID:             webdavURI,
 		SharedSecret:         webdavSharedSecret,
 		Permissions:          webdavPermissions,
 		Requirements:         webdavRequirements,
 	}
 	share.ProtocolName = req.Protocol.Name
+	share.WebappURI, share.WebappSecret, share.WebappPermissions, share.WebappTargets = extractWebapp(req)
+	share.WebDAVAccessTypes = extractWebDAVAccessTypes(req)
+	share.SSHURI, share.SSHAccessTypes = extractSSH(req)
 
 	if err := h.repo.Create(r.Context(), share); err != nil {
 		log.Error("failed to store share", "error", err)
 		spec.WriteOCMError(w, reason.OCMStatus(reason.StorageError), reason.StorageError)
 
-		return
+		return false
 	}
 
+	metrics.ShareTransition(metrics.DirectionIncoming, string(share.Status))
+	h.audit.Record(r.Context(), audit.Event{
+		Action:   audit.ActionShareCreate,
+		Actor:    req.Sender,
+		Peer:     senderHost,
+		Resource: share.ShareID,
+		Details: map[string]string{
+			audit.DetailDirection: audit.DirectionIncoming,
+			"recipient":           recipient.Username,
+		},
+	})
 	log.Info("share created",
 		"share_id", share.ShareID,
 		"provider_id", share.ProviderID,
+		"share_type", share.ShareType,
 		"sender", senderHost,
 		"recipient_user_id", share.RecipientUserID)
-	writeIncomingCreateShareResponse(w, log, share.RecipientDisplayName)
+
+	return true
 }
 
-func writeIncomingCreateShareResponse(w http.ResponseWriter, log *slog.Logger, recipientDisplayName string) {
+func writeIncomingCreateShareResponse(
+	w http.ResponseWriter,
+	log *slog.Logger,
+	recipientDisplayName string,
+	recipientPublicKeys []string,
+) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 
 	if err := json.NewEncoder(w).Encode(spec.CreateShareResponse{
 		RecipientDisplayName: recipientDisplayName,
+		RecipientPublicKeys:  recipientPublicKeys,
 	}); err != nil {
 		log.Error("failed to encode share response", "error", err)
 	}
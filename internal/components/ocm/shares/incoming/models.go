@@ -24,6 +24,10 @@ type IncomingShare struct {
 
 	Permissions []string `json:"permissions"`
 
+	// WebDAVAccessTypes is the webdav arm's accessTypes list; empty means
+	// remote access only. A datatx entry asks for a copy on accept.
+	WebDAVAccessTypes []string `json:"webdavAccessTypes,omitempty"`
+
 	Owner             string `json:"owner"`
 	Sender            string `json:"sender"`
 	ShareWith         string `json:"shareWith"`
@@ -40,6 +44,11 @@ type IncomingShare struct {
 	WebappPermissions []string `json:"webappPermissions,omitempty"`
 	WebappURI         string   `json:"webappUri,omitempty"`
 	WebappTargets     []string `json:"webappTargets,omitempty"`
+	WebappSecret      string   `json:"-"`
+
+	// SSH arm fields; empty when the share was sent without one.
+	SSHURI         string   `json:"sshUri,omitempty"`
+	SSHAccessTypes []string `json:"sshAccessTypes,omitempty"`
 
 	// ProtocolName is the stored protocol.name from the wire payload. Legacy
 	// rows have an empty value; never synthesize "multi" for them.
@@ -55,3 +64,17 @@ type IncomingShare struct {
 	UpdatedAt time.Time          `json:"updatedAt"`
 	OwnerHost string             `json:"ownerHost,omitempty"`
 }
+
+// Expired reports whether the share is marked expired, or is still pending
+// or accepted with an Expiration at or before now.
+func (s *IncomingShare) Expired(now time.Time) bool {
+	if s.Status == shares.ShareStatusExpired {
+		return true
+	}
+
+	if s.Status != shares.ShareStatusPending && s.Status != shares.ShareStatusAccepted {
+		return false
+	}
+
+	return s.Expiration != nil && *s.Expiration > 0 && now.Unix() >= *s.Expiration
+}
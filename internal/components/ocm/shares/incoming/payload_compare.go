@@ -25,11 +25,12 @@ func incomingShareMatchesRequest(existing *IncomingShare, req *spec.NewShareRequ
 
 	return incomingShareWebDAVMaterialMatch(
 		existing,
+		req,
 		webdavURI,
 		webdavSharedSecret,
 		webdavPermissions,
 		webdavRequirements,
-	)
+	) && incomingShareWebappMaterialMatch(existing, req) && incomingShareSSHMaterialMatch(existing, req)
 }
 
 func incomingShareIdentityFieldsMatch(existing *IncomingShare, req *spec.NewShareRequest) bool {
@@ -46,15 +47,26 @@ func incomingShareIdentityFieldsMatch(existing *IncomingShare, req *spec.NewShar
 
 func incomingShareWebDAVMaterialMatch(
 	existing *IncomingShare,
+	req *spec.NewShareRequest,
 	webdavURI, webdavSharedSecret string,
 	webdavPermissions, webdavRequirements []string,
 ) bool {
 	return existing.WebDAVID == webdavURI &&
 		existing.SharedSecret == webdavSharedSecret &&
+		orderedStringSlicesEqual(existing.WebDAVAccessTypes, extractWebDAVAccessTypes(req)) &&
 		orderedStringSlicesEqual(existing.Permissions, webdavPermissions) &&
 		orderedStringSlicesEqual(existing.Requirements, webdavRequirements)
 }
 
+func incomingShareWebappMaterialMatch(existing *IncomingShare, req *spec.NewShareRequest) bool {
+	uri, sharedSecret, permissions, targets := extractWebapp(req)
+
+	return existing.WebappURI == uri &&
+		existing.WebappSecret == sharedSecret &&
+		orderedStringSlicesEqual(existing.WebappPermissions, permissions) &&
+		orderedStringSlicesEqual(existing.WebappTargets, targets)
+}
+
 // extractWebDAV returns WebDAV material from req with copied permissions and
 // requirements slices so storage and comparison behave symmetrically. Nil req
 // or nil req.Protocol.WebDAV yields empty material.
@@ -71,6 +83,46 @@ func extractWebDAV(req *spec.NewShareRequest) (uri, sharedSecret string, permiss
 	return webdav.URI, webdav.SharedSecret, append([]string(nil), webdav.Permissions...), append([]string(nil), webdav.Requirements...)
 }
 
+// extractWebDAVAccessTypes returns a copy of the webdav arm's accessTypes as
+// sent, or nil when req carries no webdav arm or no list.
+func extractWebDAVAccessTypes(req *spec.NewShareRequest) []string {
+	if req == nil || req.Protocol.WebDAV == nil || len(req.Protocol.WebDAV.AccessTypes) == 0 {
+		return nil
+	}
+
+	return append([]string(nil), req.Protocol.WebDAV.AccessTypes...)
+}
+
+// extractWebapp returns webapp arm material from req with copied slices, or
+// empty material when req carries no webapp arm.
+func extractWebapp(req *spec.NewShareRequest) (uri, sharedSecret string, permissions, targets []string) {
+	if req == nil || req.Protocol.Webapp == nil {
+		return "", "", nil, nil
+	}
+
+	webapp := req.Protocol.Webapp
+
+	return webapp.URI, webapp.SharedSecret, append([]string(nil), webapp.Permissions...), append([]string(nil), webapp.Targets...)
+}
+
+func incomingShareSSHMaterialMatch(existing *IncomingShare, req *spec.NewShareRequest) bool {
+	uri, accessTypes := extractSSH(req)
+
+	return existing.SSHURI == uri && orderedStringSlicesEqual(existing.SSHAccessTypes, accessTypes)
+}
+
+// extractSSH returns ssh arm material from req with the access types
+// defaulted to ["remote"], or empty material when req carries no ssh arm.
+func extractSSH(req *spec.NewShareRequest) (uri string, accessTypes []string) {
+	if req == nil || req.Protocol.SSH == nil {
+		return "", nil
+	}
+
+	ssh := req.Protocol.SSH
+
+	return ssh.URI, append([]string(nil), ssh.SSHAccessTypes()...)
+}
+
 func orderedStringSlicesEqual(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -152,6 +152,12 @@ func TestIncomingShareMatchesRequest_FieldMismatches(t *testing.T) {
 				req.ShareType = "group"
 			},
 		},
+		{
+			name: "webdav access types",
+			mutate: func(_ *IncomingShare, req *spec.NewShareRequest) {
+				req.Protocol.WebDAV.AccessTypes = []string{"datatx"}
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -235,7 +241,11 @@ func TestHandleExistingIncomingShare_ReorderedSlicesReturns409(t *testing.T) {
 			log := slog.Default()
 			resolvedUser := &identity.User{DisplayName: "Alice A"}
 
-			if !handleExistingIncomingShare(w, log, existing, req, "sender.com", resolvedUser) {
+			_, handled := handleExistingIncomingShares(
+				w, log, []*IncomingShare{existing}, req, "sender.com",
+				[]*identity.User{resolvedUser}, resolvedUser.DisplayName,
+			)
+			if !handled {
 				t.Fatal("expected existing share to be handled")
 			}
 
@@ -8,6 +8,7 @@ package incoming
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
 )
@@ -16,11 +17,17 @@ import (
 var ErrShareNotFound = errors.New("share not found")
 
 // IncomingShareRepo manages incoming shares; all ops scoped by recipientUserID. Cross-user access = not found.
+// A group share is stored once per member under the same provider key:
+// GetByProviderID returns the oldest copy, ListByProviderID all of them.
+// ListExpiring spans every recipient: it returns the pending and accepted
+// shares whose expiration has passed at now, for the expiry janitor.
 type IncomingShareRepo interface {
 	Create(ctx context.Context, share *IncomingShare) error
 	GetByIDForRecipientUserID(ctx context.Context, shareID string, recipientUserID string) (*IncomingShare, error)
 	GetByProviderID(ctx context.Context, senderHost, providerID string) (*IncomingShare, error)
+	ListByProviderID(ctx context.Context, senderHost, providerID string) ([]*IncomingShare, error)
 	ListByRecipientUserID(ctx context.Context, recipientUserID string) ([]*IncomingShare, error)
+	ListExpiring(ctx context.Context, now time.Time) ([]*IncomingShare, error)
 	UpdateStatusForRecipientUserID(ctx context.Context, shareID string, recipientUserID string, status shares.ShareStatus) error
 	DeleteForRecipientUserID(ctx context.Context, shareID string, recipientUserID string) error
 }
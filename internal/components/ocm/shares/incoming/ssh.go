@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package incoming
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/appctx"
+)
+
+// recipientSSHKeys returns the registered SSH public keys of every recipient,
+// deduplicated, when req carries an ssh arm. A group share returns the keys
+// of all members, since any member may fetch the data.
+func recipientSSHKeys(req *spec.NewShareRequest, recipients []*identity.User) []string {
+	if req == nil || req.Protocol.SSH == nil {
+		return nil
+	}
+
+	var keys []string
+
+	for _, recipient := range recipients {
+		for _, key := range recipient.SSHPublicKeys {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// requireRecipientSSHKeys rejects an ssh share when no recipient has
+// registered an SSH public key: the spec requires recipientPublicKeys in the
+// response, and the sender could not grant access without one.
+func requireRecipientSSHKeys(
+	w http.ResponseWriter,
+	r *http.Request,
+	req *spec.NewShareRequest,
+	recipients []*identity.User,
+) bool {
+	if req.Protocol.SSH == nil || len(recipientSSHKeys(req, recipients)) > 0 {
+		return true
+	}
+
+	appctx.GetLogger(r.Context()).Warn("share rejected: ssh arm but no recipient SSH public key",
+		"provider_id", req.ProviderID)
+	spec.WriteValidationError(w, "SSH_KEY_REQUIRED", []spec.ValidationError{
+		{Name: "recipientPublicKeys", Message: validationRequired},
+	})
+
+	return false
+}
@@ -13,31 +13,54 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 )
 
-func handleExistingIncomingShare(
+// handleExistingIncomingShares compares the stored copies of a provider key
+// with the request. Any mismatched copy responds 409. When every recipient
+// already holds a copy it responds 201 idempotently. It returns the recipients
+// still missing a copy and whether a response was written.
+func handleExistingIncomingShares(
 	w http.ResponseWriter,
 	log *slog.Logger,
-	existing *IncomingShare,
+	existing []*IncomingShare,
 	req *spec.NewShareRequest,
 	senderHost string,
-	resolvedUser *identity.User,
-) bool {
-	if existing == nil {
-		return false
+	recipients []*identity.User,
+	recipientDisplayName string,
+) ([]*identity.User, bool) {
+	if len(existing) == 0 {
+		return recipients, false
 	}
 
-	if incomingShareMatchesRequest(existing, req) {
+	stored := make(map[string]struct{}, len(existing))
+
+	for _, share := range existing {
+		if !incomingShareMatchesRequest(share, req) {
+			log.Warn("duplicate share with mismatched payload",
+				"provider_id", req.ProviderID,
+				"sender", senderHost)
+			spec.WriteOCMError(w, http.StatusConflict, "SHARE_ALREADY_EXISTS_WITH_DIFFERENT_PAYLOAD")
+
+			return nil, true
+		}
+
+		stored[share.RecipientUserID] = struct{}{}
+	}
+
+	pending := make([]*identity.User, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		if _, ok := stored[recipient.ID]; !ok {
+			pending = append(pending, recipient)
+		}
+	}
+
+	if len(pending) == 0 || req.ShareType != spec.ShareTypeGroup {
 		log.Info("duplicate share, idempotent success",
 			"provider_id", req.ProviderID,
 			"sender", senderHost)
-		writeIncomingCreateShareResponse(w, log, resolvedUser.DisplayName)
+		writeIncomingCreateShareResponse(w, log, recipientDisplayName, recipientSSHKeys(req, recipients))
 
-		return true
+		return nil, true
 	}
 
-	log.Warn("duplicate share with mismatched payload",
-		"provider_id", req.ProviderID,
-		"sender", senderHost)
-	spec.WriteOCMError(w, http.StatusConflict, "SHARE_ALREADY_EXISTS_WITH_DIFFERENT_PAYLOAD")
-
-	return true
+	return pending, false
 }
@@ -8,5 +8,6 @@ package incoming
 const (
 	fieldShareWith          = "shareWith"
 	validationInvalidFormat = "INVALID_FORMAT"
+	validationRequired      = "REQUIRED"
 	validationUnsupported   = "UNSUPPORTED"
 )
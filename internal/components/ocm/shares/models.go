@@ -20,9 +20,12 @@ const (
 	// ShareStatusUnshared means the sender revoked the share; local access is revoked.
 	// There is no separate schema column for this state beyond the status value.
 	ShareStatusUnshared ShareStatus = "unshared"
+	// ShareStatusExpired means the share's expiration passed while it was
+	// pending or accepted; it can no longer be accepted or read.
+	ShareStatusExpired ShareStatus = "expired"
 )
 
-// OutgoingShareStatus tracks the lifecycle state of an outgoing share (pending, sent, accepted, declined, failed).
+// OutgoingShareStatus tracks the lifecycle state of an outgoing share (pending, sent, accepted, declined, failed, revoked, expired).
 type OutgoingShareStatus string
 
 const (
@@ -36,4 +39,26 @@ const (
 	OutgoingShareStatusPending OutgoingShareStatus = "pending"
 	// OutgoingShareStatusFailed is the failed share status (delivery failed; record kept for audit).
 	OutgoingShareStatusFailed OutgoingShareStatus = "failed"
+	// OutgoingShareStatusRevoked means the owner revoked the share; WebDAV access
+	// and token exchange are refused and issued tokens are invalidated.
+	OutgoingShareStatusRevoked OutgoingShareStatus = "revoked"
+	// OutgoingShareStatusExpired means the share passed its expiration; like a
+	// revoked share, access is refused and issued tokens are invalidated.
+	OutgoingShareStatusExpired OutgoingShareStatus = "expired"
 )
+
+// Valid reports whether s is one of the known outgoing share statuses.
+func (s OutgoingShareStatus) Valid() bool {
+	switch s {
+	case OutgoingShareStatusSent,
+		OutgoingShareStatusAccepted,
+		OutgoingShareStatusDeclined,
+		OutgoingShareStatusPending,
+		OutgoingShareStatusFailed,
+		OutgoingShareStatusRevoked,
+		OutgoingShareStatusExpired:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package outgoing
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+)
+
+// ListFilter narrows a list of outgoing shares. Zero-valued fields match
+// every share.
+type ListFilter struct {
+	// Owner matches the share owner OCM address exactly.
+	Owner string
+	// Status matches the share lifecycle status exactly.
+	Status shares.OutgoingShareStatus
+	// ReceiverHost matches the receiver host case-insensitively.
+	ReceiverHost string
+	// CreatedAfter keeps shares created at or after this instant.
+	CreatedAfter time.Time
+	// CreatedBefore keeps shares created strictly before this instant.
+	CreatedBefore time.Time
+}
+
+// Matches reports whether share satisfies every set field of f.
+func (f ListFilter) Matches(share *OutgoingShare) bool {
+	if f.Owner != "" && share.Owner != f.Owner {
+		return false
+	}
+
+	if f.Status != "" && share.Status != f.Status {
+		return false
+	}
+
+	if f.ReceiverHost != "" && !strings.EqualFold(share.ReceiverHost, f.ReceiverHost) {
+		return false
+	}
+
+	if !f.CreatedAfter.IsZero() && share.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+
+	if !f.CreatedBefore.IsZero() && !share.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// Apply returns the shares matching f, newest first. Shares created at the
+// same instant are ordered by ShareID so pagination over the result is stable.
+func (f ListFilter) Apply(all []*OutgoingShare) []*OutgoingShare {
+	result := make([]*OutgoingShare, 0, len(all))
+
+	for _, share := range all {
+		if f.Matches(share) {
+			result = append(result, share)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b *OutgoingShare) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ShareID, b.ShareID)
+	})
+
+	return result
+}
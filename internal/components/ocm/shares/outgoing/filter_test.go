@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package outgoing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+)
+
+func TestListFilter_Apply(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	all := []*outgoing.OutgoingShare{
+		{ShareID: "a", Owner: "alice@example.com", ReceiverHost: "one.example.com", Status: shares.OutgoingShareStatusSent, CreatedAt: base},
+		{ShareID: "b", Owner: "alice@example.com", ReceiverHost: "two.example.com", Status: shares.OutgoingShareStatusFailed, CreatedAt: base.Add(time.Hour)},
+		{ShareID: "c", Owner: "alice@example.com", ReceiverHost: "One.Example.com", Status: shares.OutgoingShareStatusAccepted, CreatedAt: base.Add(2 * time.Hour)},
+		{ShareID: "d", Owner: "bob@example.com", ReceiverHost: "one.example.com", Status: shares.OutgoingShareStatusSent, CreatedAt: base.Add(3 * time.Hour)},
+		{ShareID: "e", Owner: "alice@example.com", ReceiverHost: "one.example.com", Status: shares.OutgoingShareStatusSent, CreatedAt: base.Add(2 * time.Hour)},
+	}
+
+	tests := []struct {
+		name   string
+		filter outgoing.ListFilter
+		want   []string
+	}{
+		{
+			name:   "zero filter keeps everything newest first",
+			filter: outgoing.ListFilter{},
+			want:   []string{"d", "c", "e", "b", "a"},
+		},
+		{
+			name:   "owner",
+			filter: outgoing.ListFilter{Owner: "alice@example.com"},
+			want:   []string{"c", "e", "b", "a"},
+		},
+		{
+			name:   "status",
+			filter: outgoing.ListFilter{Owner: "alice@example.com", Status: shares.OutgoingShareStatusSent},
+			want:   []string{"e", "a"},
+		},
+		{
+			name:   "receiver host is case-insensitive",
+			filter: outgoing.ListFilter{Owner: "alice@example.com", ReceiverHost: "ONE.example.com"},
+			want:   []string{"c", "e", "a"},
+		},
+		{
+			name:   "created window is inclusive after and exclusive before",
+			filter: outgoing.ListFilter{CreatedAfter: base.Add(time.Hour), CreatedBefore: base.Add(3 * time.Hour)},
+			want:   []string{"c", "e", "b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.filter.Apply(all)
+
+			ids := make([]string, 0, len(got))
+			for _, s := range got {
+				ids = append(ids, s.ShareID)
+			}
+
+			if len(ids) != len(tc.want) {
+				t.Fatalf("got %v, want %v", ids, tc.want)
+			}
+
+			for i := range ids {
+				if ids[i] != tc.want[i] {
+					t.Fatalf("got %v, want %v", ids, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -32,11 +32,38 @@ type OutgoingShare struct {
 	Status       shares.OutgoingShareStatus `json:"status"`
 	CreatedAt    time.Time                  `json:"createdAt"`
 	SentAt       *time.Time                 `json:"sentAt,omitempty"`
+	ExpiresAt    *time.Time                 `json:"expiresAt,omitempty"`
 	Error        string                     `json:"error,omitempty"`
 	Requirements []string                   `json:"requirements,omitempty"`
+
+	// Webapp arm fields; empty when the share was sent without one.
+	WebappURI         string   `json:"webappUri,omitempty"`
+	WebappPermissions []string `json:"webappPermissions,omitempty"`
+	WebappTargets     []string `json:"webappTargets,omitempty"`
+	// WebappSharedSecret is the webapp arm's own secret. Tokens exchanged
+	// with it carry the webapp permissions instead of Permissions.
+	WebappSharedSecret string `json:"-"`
+
+	// SSH arm fields; empty when the share was sent without one.
+	// RecipientPublicKeys are the keys the SFTP server accepts for this share.
+	SSHURI              string   `json:"sshUri,omitempty"`
+	RecipientPublicKeys []string `json:"recipientPublicKeys,omitempty"`
+}
+
+// Expired reports whether the share is marked expired or its ExpiresAt has
+// passed at now. The expiry janitor catches up on the status; readers check
+// the time so access ends on schedule.
+func (s *OutgoingShare) Expired(now time.Time) bool {
+	return s.Status == shares.OutgoingShareStatusExpired || (s.ExpiresAt != nil && !now.Before(*s.ExpiresAt))
 }
 
 // OutgoingShareRequest carries the body for creating an outgoing share.
+// ShareType is "user" (the default) or "group" for a remote group address.
+// A non-empty WebappPermissions adds a webapp arm next to the WebDAV arm.
+// SSH adds an ssh arm served by the built-in SFTP server. DataTx asks the
+// receiver to copy the resource into its own storage (webdav accessTypes
+// ["datatx"]) instead of accessing it remotely. ExpiresAt, when set, must be
+// in the future; it is sent as the share's expiration.
 type OutgoingShareRequest struct {
 	ReceiverDomain string   `json:"receiverDomain"`
 	ShareWith      string   `json:"shareWith"`
@@ -44,4 +71,11 @@ type OutgoingShareRequest struct {
 	Name           string   `json:"name,omitempty"`
 	Permissions    []string `json:"permissions"`
 	ResourceType   string   `json:"resourceType,omitempty"`
+	ShareType      string   `json:"shareType,omitempty"`
+
+	WebappPermissions []string `json:"webappPermissions,omitempty"`
+	SSH               bool     `json:"ssh,omitempty"`
+	DataTx            bool     `json:"datatx,omitempty"`
+
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
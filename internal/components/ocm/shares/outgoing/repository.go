@@ -8,6 +8,7 @@ package outgoing
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // ErrShareNotFound is returned when an outgoing share is not found.
@@ -19,6 +20,12 @@ type OutgoingShareRepo interface { //nolint:revive // exported: self-explanatory
 	GetByProviderID(ctx context.Context, providerID string) (*OutgoingShare, error)
 	GetByWebDAVID(ctx context.Context, webdavID string) (*OutgoingShare, error)
 	GetBySharedSecret(ctx context.Context, sharedSecret string) (*OutgoingShare, error)
+	// GetByWebappSharedSecret finds the share whose webapp arm carries
+	// webappSharedSecret.
+	GetByWebappSharedSecret(ctx context.Context, webappSharedSecret string) (*OutgoingShare, error)
 	List(ctx context.Context) ([]*OutgoingShare, error)
+	// ListExpiring returns the pending, sent, and accepted shares whose
+	// expiry has passed at now, for the expiry janitor.
+	ListExpiring(ctx context.Context, now time.Time) ([]*OutgoingShare, error)
 	Update(ctx context.Context, share *OutgoingShare) error
 }
@@ -81,6 +81,22 @@ func (d *Discovery) IsHTTPSigCapable() bool {
 	return d.HasCapability(CapabilityHTTPSig)
 }
 
+// AcceptsShareType reports whether any advertised resource type lists
+// shareType in its shareTypes.
+func (d *Discovery) AcceptsShareType(shareType string) bool {
+	if d == nil {
+		return false
+	}
+
+	for _, rt := range d.ResourceTypes {
+		if slices.Contains(rt.ShareTypes, shareType) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // DiscoveryPaths holds route-derived discovery path fields before policy overlays.
 type DiscoveryPaths struct {
 	EndPoint           string
@@ -234,6 +250,22 @@ func (d *Discovery) WebDAVReceiveURIKind() WebDAVReceiveURIKind {
 	return ""
 }
 
+// SupportsSSHReceive reports whether any advertised resource type carries the
+// ssh-receive protocol role.
+func (d *Discovery) SupportsSSHReceive() bool {
+	if d == nil {
+		return false
+	}
+
+	for _, rt := range d.ResourceTypes {
+		if _, ok := rt.Protocols[ProtocolSSHReceive]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 // BuildWebDAVURL constructs the full WebDAV URL for accessing a share.
 func (d *Discovery) BuildWebDAVURL(shareID string) (string, error) {
 	webdavPath := d.GetWebDAVPath()
@@ -7,6 +7,7 @@ package spec_test
 
 import (
 	"encoding/json"
+	"slices"
 	"strings"
 	"testing"
 
@@ -57,6 +58,29 @@ func TestDiscovery_NilReceiver(t *testing.T) {
 	if disc.SupportsTokenExchange() {
 		t.Error("SupportsTokenExchange on nil discovery should be false")
 	}
+
+	if disc.AcceptsShareType(spec.ShareTypeUser) {
+		t.Error("AcceptsShareType on nil discovery should be false")
+	}
+}
+
+func TestAcceptsShareType(t *testing.T) {
+	t.Parallel()
+
+	disc := &spec.Discovery{ResourceTypes: []spec.ResourceType{
+		{Name: "file", ShareTypes: []string{spec.ShareTypeUser}},
+		{Name: "folder", ShareTypes: []string{spec.ShareTypeUser, spec.ShareTypeGroup}},
+	}}
+	if !disc.AcceptsShareType(spec.ShareTypeGroup) {
+		t.Error("group listed on one resource type should be accepted")
+	}
+
+	userOnly := &spec.Discovery{ResourceTypes: []spec.ResourceType{
+		{Name: "file", ShareTypes: []string{spec.ShareTypeUser}},
+	}}
+	if userOnly.AcceptsShareType(spec.ShareTypeGroup) {
+		t.Error("group not advertised should not be accepted")
+	}
 }
 
 func TestRequiresHTTPSigAndIsHTTPSigCapable(t *testing.T) {
@@ -219,19 +243,17 @@ func TestWebDAVReceiveURIKind(t *testing.T) {
 func TestSupportedResourceTypes(t *testing.T) {
 	t.Parallel()
 
-	if len(spec.SupportedResourceTypes) != 1 {
-		t.Fatalf("SupportedResourceTypes = %v, want [file]", spec.SupportedResourceTypes)
+	if !slices.Equal(spec.SupportedResourceTypes, []string{"file", "folder"}) {
+		t.Fatalf("SupportedResourceTypes = %v, want [file folder]", spec.SupportedResourceTypes)
 	}
 
-	if spec.SupportedResourceTypes[0] != "file" {
-		t.Errorf("SupportedResourceTypes[0] = %q, want file", spec.SupportedResourceTypes[0])
-	}
-
-	if !spec.IsSupportedResourceType("file") {
-		t.Error("IsSupportedResourceType(file) = false, want true")
+	for _, rt := range []string{"file", "folder"} {
+		if !spec.IsSupportedResourceType(rt) {
+			t.Errorf("IsSupportedResourceType(%q) = false, want true", rt)
+		}
 	}
 
-	for _, rt := range []string{"folder", "calendar"} {
+	for _, rt := range []string{"calendar", "Folder", ""} {
 		if spec.IsSupportedResourceType(rt) {
 			t.Errorf("IsSupportedResourceType(%q) = true, want false", rt)
 		}
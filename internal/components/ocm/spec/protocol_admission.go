@@ -11,29 +11,72 @@ import (
 	"slices"
 )
 
+// OCM resource type values served by the local WebDAV handler.
+const (
+	// ResourceTypeFile is a single shared file.
+	ResourceTypeFile = "file"
+	// ResourceTypeFolder is a shared directory tree.
+	ResourceTypeFolder = "folder"
+)
+
+// OCM share type values accepted for share creation.
+const (
+	// ShareTypeUser addresses a single local user.
+	ShareTypeUser = "user"
+	// ShareTypeGroup addresses a local group; the share reaches every member.
+	ShareTypeGroup = "group"
+)
+
+// SupportedShareTypes are the OCM share types accepted for share creation and
+// advertised in discovery. "federation" is registered but not implemented.
+var SupportedShareTypes = []string{ShareTypeUser, ShareTypeGroup}
+
+// IsSupportedShareType reports whether shareType is accepted for share creation.
+func IsSupportedShareType(shareType string) bool {
+	return slices.Contains(SupportedShareTypes, shareType)
+}
+
+// OCM WebDAV permission values honored by the local WebDAV handler.
+const (
+	// PermissionRead allows reading the shared resource.
+	PermissionRead = "read"
+	// PermissionWrite allows modifying the shared resource over WebDAV.
+	PermissionWrite = "write"
+	// PermissionView allows viewing the shared resource in a webapp.
+	PermissionView = "view"
+)
+
 // SupportedResourceTypes are the OCM resource types accepted for share creation.
-// Only "file" is supported: ocm-go serves a single read-only file via singleFileFS;
-// folder shares are not implemented.
+// "file" is served as a single file; "folder" is served as a directory tree
+// rooted at the shared path. Other registered types (e.g. "calendar") are not
+// implemented.
 // See the OCM-API share-creation contract: https://github.com/cs3org/OCM-API/blob/6a0586183cbef10ecae9dedc42561806447eb2f5/IETF-OCM.md?plain=1
-var SupportedResourceTypes = []string{"file"}
+var SupportedResourceTypes = []string{ResourceTypeFile, ResourceTypeFolder}
 
 // SupportedWebDAVRequirements are the WebDAV protocol requirement values
 // this implementation currently recognizes.
 var SupportedWebDAVRequirements = []string{RequirementMustExchangeToken}
 
 // SupportedWebDAVAccessTypes are the WebDAV access type values this
-// implementation currently recognizes.
-var SupportedWebDAVAccessTypes = []string{"remote"}
+// implementation currently recognizes. A datatx share is copied into the
+// recipient's storage in the background once it is accepted.
+var SupportedWebDAVAccessTypes = []string{AccessTypeRemote, AccessTypeDataTx}
 
 // SupportedWebDAVPermissions are the WebDAV permission values this
-// implementation currently honors.
-var SupportedWebDAVPermissions = []string{"read"}
+// implementation currently honors. "write" enables PUT, DELETE, MKCOL, MOVE,
+// COPY, PROPPATCH, and LOCK/UNLOCK on the served resource.
+var SupportedWebDAVPermissions = []string{PermissionRead, PermissionWrite}
 
 // SupportedWebappPermissions are the webapp protocol permission values this
 // implementation honors at admit. They are intentionally distinct from
 // SupportedWebDAVPermissions: webapp admits view/read/write/share, while
-// WebDAV currently admits only read. Do not merge the two lists.
-var SupportedWebappPermissions = []string{"view", "read", "write", "share"}
+// WebDAV admits only read and write. Do not merge the two lists.
+var SupportedWebappPermissions = []string{PermissionView, PermissionRead, PermissionWrite, "share"}
+
+// OutgoingWebappPermissions are the webapp permission values this
+// implementation offers on the webapp arm of outgoing shares. "share" is
+// admitted inbound but never offered, since receivers cannot reshare.
+var OutgoingWebappPermissions = []string{PermissionView, PermissionRead, PermissionWrite}
 
 // SupportedWebappRequirements lists the webapp requirement tokens this
 // implementation recognizes. The name is kept for consistency with the other
@@ -50,11 +93,11 @@ func IsSupportedResourceType(resourceType string) bool {
 var errUnsupportedProtocolArm = errors.New("UNSUPPORTED")
 
 // ValidateProtocolArms is the protocol-arm admission hook. It admits the
-// supported arm keys: name (the protocol shape selector), webdav, and webapp.
-// Any other key is rejected as UNSUPPORTED.
+// supported arm keys: name (the protocol shape selector), webdav, webapp, and
+// ssh. Any other key is rejected as UNSUPPORTED.
 func ValidateProtocolArms(raw map[string]json.RawMessage) error {
 	for key := range raw {
-		if key != "name" && key != ProtocolWebDAV && key != "webapp" {
+		if key != "name" && key != ProtocolWebDAV && key != "webapp" && key != ProtocolSSH {
 			return errUnsupportedProtocolArm
 		}
 	}
@@ -64,9 +107,9 @@ func ValidateProtocolArms(raw map[string]json.RawMessage) error {
 
 // ValidateProtocolShape checks the minimal current-purpose protocol shape.
 // The named "webdav" shape requires a webdav arm. The
-// "multi" shape requires at least one supported arm: webdav or webapp. This
-// admits a webapp-only arm under "multi" without altering the named "webdav"
-// shape behavior.
+// "multi" shape requires at least one supported arm: webdav, webapp, or ssh.
+// This admits a webapp-only or ssh-only arm under "multi" without altering the
+// named "webdav" shape behavior.
 func ValidateProtocolShape(p Protocol) *ValidationError {
 	if p.Name == "" {
 		return &ValidationError{Name: "protocol.name", Message: validationRequired}
@@ -80,7 +123,7 @@ func ValidateProtocolShape(p Protocol) *ValidationError {
 		return &ValidationError{Name: "protocol.webdav", Message: validationRequired}
 	}
 
-	if p.Name == "multi" && p.WebDAV == nil && p.Webapp == nil {
+	if p.Name == "multi" && p.WebDAV == nil && p.Webapp == nil && p.SSH == nil {
 		return &ValidationError{Name: "protocol", Message: validationRequired}
 	}
 
@@ -175,6 +218,11 @@ func isSupportedWebDAVPermission(perm string) bool {
 	return slices.Contains(SupportedWebDAVPermissions, perm)
 }
 
+// HasWritePermission reports whether perms grants WebDAV write access.
+func HasWritePermission(perms []string) bool {
+	return slices.Contains(perms, PermissionWrite)
+}
+
 // ValidateWebappProtocolWire checks webapp wire fields: uri, targets,
 // permissions, and sharedSecret are required; permissions must be in
 // SupportedWebappPermissions; unknown requirements return UNSUPPORTED;
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package spec
+
+import (
+	"errors"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// OCM access type values shared by the webdav and ssh arms.
+const (
+	// AccessTypeRemote means the receiver accesses the resource in place.
+	AccessTypeRemote = "remote"
+	// AccessTypeDataTx means the receiver copies the resource to its own storage.
+	AccessTypeDataTx = "datatx"
+)
+
+// SupportedSSHAccessTypes are the ssh access type values this implementation
+// recognizes. The built-in SFTP server serves both the same way: the receiver
+// reads the resource and decides whether to keep a copy.
+var SupportedSSHAccessTypes = []string{AccessTypeRemote, AccessTypeDataTx}
+
+// SSHURI is a parsed ssh arm uri: username@host[:port]/resource/path.
+type SSHURI struct {
+	User string
+	Host string
+	// Port is empty when the uri omits it (the spec example uses "host:/path").
+	Port string
+	Path string
+}
+
+// Addr returns host:port, defaulting to port 22.
+func (u SSHURI) Addr() string {
+	port := u.Port
+	if port == "" {
+		port = "22"
+	}
+
+	return net.JoinHostPort(u.Host, port)
+}
+
+var errInvalidSSHURI = errors.New("ssh uri must have the form username@host[:port]/path")
+
+// ParseSSHURI parses an ssh arm uri. IPv6 hosts must be bracketed.
+func ParseSSHURI(raw string) (SSHURI, error) {
+	user, rest, ok := strings.Cut(raw, "@")
+	if !ok || user == "" || rest == "" {
+		return SSHURI{}, errInvalidSSHURI
+	}
+
+	slash := strings.Index(rest, "/")
+	if slash <= 0 {
+		return SSHURI{}, errInvalidSSHURI
+	}
+
+	hostPort, resourcePath := rest[:slash], rest[slash:]
+
+	host, port := hostPort, ""
+	if strings.HasPrefix(hostPort, "[") {
+		end := strings.Index(hostPort, "]")
+		if end < 0 {
+			return SSHURI{}, errInvalidSSHURI
+		}
+
+		host, port = hostPort[1:end], strings.TrimPrefix(hostPort[end+1:], ":")
+	} else if h, p, found := strings.Cut(hostPort, ":"); found {
+		host, port = h, p
+	}
+
+	if host == "" || strings.ContainsAny(host, "@ ") {
+		return SSHURI{}, errInvalidSSHURI
+	}
+
+	if port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return SSHURI{}, errInvalidSSHURI
+		}
+	}
+
+	return SSHURI{User: user, Host: host, Port: port, Path: resourcePath}, nil
+}
+
+// FormatSSHURI builds an ssh arm uri from a username, a host:port address,
+// and an absolute resource path.
+func FormatSSHURI(user, addr, resourcePath string) string {
+	return user + "@" + addr + "/" + strings.TrimPrefix(resourcePath, "/")
+}
+
+// SSHAccessTypes returns the arm's access types, defaulting to ["remote"].
+func (p *SSHProtocol) SSHAccessTypes() []string {
+	if p == nil || len(p.AccessTypes) == 0 {
+		return []string{AccessTypeRemote}
+	}
+
+	return p.AccessTypes
+}
+
+// ValidateSSHProtocolWire checks ssh wire fields: uri is required and must
+// parse; accessTypes (when present) must be in SupportedSSHAccessTypes.
+func ValidateSSHProtocolWire(p *SSHProtocol) []ValidationError {
+	var errs []ValidationError
+	if p == nil {
+		return errs
+	}
+
+	if p.URI == "" {
+		errs = append(errs, ValidationError{Name: "protocol.ssh.uri", Message: validationRequired})
+	} else if _, err := ParseSSHURI(p.URI); err != nil {
+		errs = append(errs, ValidationError{Name: "protocol.ssh.uri", Message: validationInvalidFormat})
+	}
+
+	for _, accessType := range p.AccessTypes {
+		if !slices.Contains(SupportedSSHAccessTypes, accessType) {
+			errs = append(errs, ValidationError{Name: "protocol.ssh.accessTypes", Message: validationUnsupported})
+
+			break
+		}
+	}
+
+	return errs
+}
@@ -27,11 +27,22 @@ const (
 	ProtocolWebDAVReceive = "webdav-receive"
 )
 
+// ProtocolSSH and ProtocolSSHReceive are the OCM discovery protocol role keys
+// for SSH access. ProtocolSSH is also the share protocol arm key.
+const (
+	ProtocolSSH        = "ssh"
+	ProtocolSSHReceive = "ssh-receive"
+)
+
 // WebDAVReceive is the structured webdav-receive protocol role.
 type WebDAVReceive struct {
 	URI WebDAVReceiveURIKind `json:"uri"`
 }
 
+// SSHReceive is the structured ssh-receive protocol role. The spec defines it
+// as an empty object.
+type SSHReceive struct{}
+
 // ProtocolRole is either a string path/address or a structured JSON object.
 //
 //nolint:recvcheck // json contract: UnmarshalJSON needs a pointer receiver to mutate; MarshalJSON must stay a value receiver so non-addressable map values in Protocols still marshal
@@ -78,6 +89,16 @@ func WebDAVReceiveRole(uri WebDAVReceiveURIKind) ProtocolRole {
 	return role
 }
 
+// SSHReceiveRole constructs an ssh-receive protocol role.
+func SSHReceiveRole() ProtocolRole {
+	role, err := ObjectProtocolRole(SSHReceive{})
+	if err != nil {
+		return ProtocolRole{}
+	}
+
+	return role
+}
+
 // MarshalJSON encodes the role as its string or object JSON form; implements json.Marshaler.
 func (p ProtocolRole) MarshalJSON() ([]byte, error) {
 	//nolint:exhaustive // protocolRoleUnset (zero value) intentionally handled by the default invalid-role error
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package spec
+
+const (
+	fieldRequestShareOwner = "owner"
+	fieldRequestShareShare = "share"
+)
+
+// RequestShareRequest carries the wire body for POST /ocm/request-share.
+// Owner is the OCM address of the user asked to share, ShareWith the OCM
+// address of the party asking, and Share the requested resource identifier.
+// See https://github.com/cs3org/OCM-API/blob/6a0586183cbef10ecae9dedc42561806447eb2f5/IETF-OCM.md
+type RequestShareRequest struct {
+	Owner     string `json:"owner"`
+	ShareWith string `json:"shareWith"`
+	Share     string `json:"share"`
+}
+
+// ValidateRequestShareRequest returns validation errors for a share request.
+func ValidateRequestShareRequest(req *RequestShareRequest) []ValidationError {
+	var errs []ValidationError
+
+	if req.Owner == "" {
+		errs = append(errs, ValidationError{Name: fieldRequestShareOwner, Message: validationRequired})
+	}
+
+	if req.ShareWith == "" {
+		errs = append(errs, ValidationError{Name: fieldShareWith, Message: validationRequired})
+	}
+
+	if req.Share == "" {
+		errs = append(errs, ValidationError{Name: fieldRequestShareShare, Message: validationRequired})
+	}
+
+	return errs
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package spec_test
+
+import (
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+func TestValidateRequestShareRequest_RequiredFields(t *testing.T) {
+	t.Parallel()
+
+	errs := spec.ValidateRequestShareRequest(&spec.RequestShareRequest{})
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 validation errors, got %#v", errs)
+	}
+
+	for i, want := range []string{"owner", "shareWith", "share"} {
+		if errs[i].Name != want || errs[i].Message != "REQUIRED" {
+			t.Errorf("errs[%d] = %#v, want %s REQUIRED", i, errs[i], want)
+		}
+	}
+}
+
+func TestValidateRequestShareRequest_Valid(t *testing.T) {
+	t.Parallel()
+
+	errs := spec.ValidateRequestShareRequest(&spec.RequestShareRequest{
+		Owner:     "alice@owner.example",
+		ShareWith: "bob@requester.example",
+		Share:     "provider-1",
+	})
+	if len(errs) != 0 {
+		t.Fatalf("expected no validation errors, got %#v", errs)
+	}
+}
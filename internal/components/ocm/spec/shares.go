@@ -29,6 +29,7 @@ type Protocol struct {
 	Name   string          `json:"name,omitempty"`
 	WebDAV *WebDAVProtocol `json:"webdav,omitempty"`
 	Webapp *WebappProtocol `json:"webapp,omitempty"`
+	SSH    *SSHProtocol    `json:"ssh,omitempty"`
 }
 
 // WebDAVProtocol carries the WebDAV protocol fields of a share request.
@@ -38,6 +39,14 @@ type WebDAVProtocol struct {
 	SharedSecret string   `json:"sharedSecret,omitempty"`
 	Permissions  []string `json:"permissions"`
 	Requirements []string `json:"requirements,omitempty"`
+	// Size is the resource size in bytes, mostly useful for datatx.
+	Size *int64 `json:"size,omitempty"`
+}
+
+// HasDataTx reports whether the arm asks the receiver to transfer the
+// resource. A missing accessTypes list means remote access only.
+func (p *WebDAVProtocol) HasDataTx() bool {
+	return p != nil && slices.Contains(p.AccessTypes, AccessTypeDataTx)
 }
 
 // WebappProtocol is the webapp protocol arm. Its permissions are distinct
@@ -53,6 +62,14 @@ type WebappProtocol struct {
 	SharedSecret string   `json:"sharedSecret"`
 }
 
+// SSHProtocol is the ssh protocol arm. URI has the form
+// username@host[:port]/resource/path; AccessTypes defaults to ["remote"]
+// when absent.
+type SSHProtocol struct {
+	AccessTypes []string `json:"accessTypes,omitempty"`
+	URI         string   `json:"uri"`
+}
+
 // HasRequirement reports whether the WebDAV arm advertises req.
 func (p *WebDAVProtocol) HasRequirement(req string) bool {
 	return slices.Contains(p.Requirements, req)
@@ -70,4 +87,7 @@ func (p *WebappProtocol) HasRequirement(req string) bool {
 // CreateShareResponse carries the wire body returned after creating a share.
 type CreateShareResponse struct {
 	RecipientDisplayName string `json:"recipientDisplayName"`
+	// RecipientPublicKeys carries the recipient's SSH public keys in
+	// authorized_keys format. The spec requires it when the share has an ssh arm.
+	RecipientPublicKeys []string `json:"recipientPublicKeys,omitempty"`
 }
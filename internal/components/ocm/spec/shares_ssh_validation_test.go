@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package spec
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestProtocolArmsAllowSSH(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"name":"multi","ssh":{"accessTypes":["remote"],"uri":"u@host:2222/data"}}`
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		t.Fatalf("unmarshal raw: %v", err)
+	}
+
+	if err := ValidateProtocolArms(raw); err != nil {
+		t.Fatalf("ssh arm should be admitted, got %v", err)
+	}
+
+	var p Protocol
+	if err := json.Unmarshal([]byte(body), &p); err != nil {
+		t.Fatalf("unmarshal typed: %v", err)
+	}
+
+	if err := ValidateProtocolShape(p); err != nil {
+		t.Fatalf("multi+ssh-only should be admitted, got %v", err)
+	}
+}
+
+func TestParseSSHURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw  string
+		want SSHURI
+	}{
+		{"extuser@cloud.example.org:/7c08/data", SSHURI{User: "extuser", Host: "cloud.example.org", Path: "/7c08/data"}},
+		{"u@host.example:2222/res/path", SSHURI{User: "u", Host: "host.example", Port: "2222", Path: "/res/path"}},
+		{"u@host.example/res", SSHURI{User: "u", Host: "host.example", Path: "/res"}},
+		{"u@[::1]:2022/res", SSHURI{User: "u", Host: "::1", Port: "2022", Path: "/res"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSSHURI(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseSSHURI(%q) error = %v", tt.raw, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseSSHURI(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+
+	if addr := (SSHURI{Host: "h"}).Addr(); addr != "h:22" {
+		t.Errorf("default Addr() = %q, want h:22", addr)
+	}
+
+	for _, raw := range []string{"", "host:22/p", "@host/p", "u@/p", "u@host", "u@host:port/p", "u@host:70000/p", "u@[::1/p"} {
+		if _, err := ParseSSHURI(raw); err == nil {
+			t.Errorf("ParseSSHURI(%q) expected error", raw)
+		}
+	}
+}
+
+func TestFormatSSHURIRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	raw := FormatSSHURI("id-1", "sftp.example.org:2022", "/report.csv")
+	if raw != "id-1@sftp.example.org:2022/report.csv" {
+		t.Fatalf("FormatSSHURI = %q", raw)
+	}
+
+	got, err := ParseSSHURI(raw)
+	if err != nil || got.Addr() != "sftp.example.org:2022" || got.User != "id-1" {
+		t.Fatalf("round trip = %+v, %v", got, err)
+	}
+}
+
+func TestValidateSSHProtocolWire(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		proto *SSHProtocol
+		want  []ValidationError
+	}{
+		{"valid", &SSHProtocol{URI: "u@h:22/p", AccessTypes: []string{AccessTypeRemote, AccessTypeDataTx}}, nil},
+		{"missing uri", &SSHProtocol{}, []ValidationError{{Name: "protocol.ssh.uri", Message: validationRequired}}},
+		{"malformed uri", &SSHProtocol{URI: "h/p"}, []ValidationError{{Name: "protocol.ssh.uri", Message: validationInvalidFormat}}},
+		{
+			"unknown access type", &SSHProtocol{URI: "u@h/p", AccessTypes: []string{"stream"}},
+			[]ValidationError{{Name: "protocol.ssh.accessTypes", Message: validationUnsupported}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ValidateSSHProtocolWire(tt.proto); !slices.Equal(got, tt.want) {
+				t.Fatalf("ValidateSSHProtocolWire() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := (&SSHProtocol{}).SSHAccessTypes(); !slices.Equal(got, []string{AccessTypeRemote}) {
+		t.Errorf("default access types = %v, want [remote]", got)
+	}
+}
@@ -176,9 +176,9 @@ func TestWebappPermissions_DistinctFromWebDAV(t *testing.T) {
 	t.Parallel()
 
 	// The webapp allow-list must be distinct and broader than WebDAV's.
-	// view/write/share are supported for webapp but NOT for webdav.
+	// view/share are supported for webapp but NOT for webdav.
 
-	for _, perm := range []string{"view", "write", "share"} {
+	for _, perm := range []string{"view", "share"} {
 		if !isSupportedWebappPermission(perm) {
 			t.Errorf("webapp should support %q", perm)
 		}
@@ -65,7 +65,7 @@ func TestWebDAVAcceptsRemoteAccessTypes(t *testing.T) {
 func TestWebDAVRejectsUnsupportedAccessTypes(t *testing.T) {
 	t.Parallel()
 
-	const body = `{"uri":"u","sharedSecret":"s","permissions":["read"],"accessTypes":["datatx"],"requirements":["must-exchange-token"]}`
+	const body = `{"uri":"u","sharedSecret":"s","permissions":["read"],"accessTypes":["remote","sync"],"requirements":["must-exchange-token"]}`
 
 	var p WebDAVProtocol
 	if err := json.Unmarshal([]byte(body), &p); err != nil {
@@ -84,6 +84,34 @@ func TestWebDAVRejectsUnsupportedAccessTypes(t *testing.T) {
 	}
 }
 
+func TestWebDAVAcceptsDataTx(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"uri":"u","sharedSecret":"s","permissions":["read"],"accessTypes":["datatx"],"requirements":["must-exchange-token"],"size":1048576}`
+
+	var p WebDAVProtocol
+	if err := json.Unmarshal([]byte(body), &p); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if errs := ValidateWebDAVProtocol(&p); len(errs) != 0 {
+		t.Fatalf("unexpected validation errors: %v", errs)
+	}
+
+	if !p.HasDataTx() {
+		t.Error("HasDataTx() = false, want true")
+	}
+
+	if p.Size == nil || *p.Size != 1048576 {
+		t.Errorf("Size = %v, want 1048576", p.Size)
+	}
+
+	remote := &WebDAVProtocol{AccessTypes: []string{AccessTypeRemote}}
+	if remote.HasDataTx() || (*WebDAVProtocol)(nil).HasDataTx() {
+		t.Error("HasDataTx() = true for a remote-only or nil arm")
+	}
+}
+
 func TestWebDAVAcceptsMissingAccessTypesAsRemote(t *testing.T) {
 	t.Parallel()
 
@@ -98,13 +126,48 @@ func TestWebDAVAcceptsMissingAccessTypesAsRemote(t *testing.T) {
 	}
 }
 
+func TestWebDAVAcceptsWritePermission(t *testing.T) {
+	t.Parallel()
+
+	p := &WebDAVProtocol{
+		URI:          "u",
+		SharedSecret: "s",
+		Permissions:  []string{PermissionRead, PermissionWrite},
+		Requirements: []string{RequirementMustExchangeToken},
+	}
+	if errs := ValidateWebDAVProtocol(p); len(errs) != 0 {
+		t.Errorf("unexpected validation errors: %v", errs)
+	}
+}
+
+func TestHasWritePermission(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		perms []string
+		want  bool
+	}{
+		{nil, false},
+		{[]string{PermissionRead}, false},
+		{[]string{PermissionRead, PermissionWrite}, true},
+		{[]string{PermissionWrite}, true},
+		{[]string{"Write"}, false},
+	}
+
+	for _, tc := range cases {
+		if got := HasWritePermission(tc.perms); got != tc.want {
+			t.Errorf("HasWritePermission(%v) = %v, want %v", tc.perms, got, tc.want)
+		}
+	}
+}
+
 func TestWebDAVRejectsUnsupportedPermissions(t *testing.T) {
 	t.Parallel()
 
 	p := &WebDAVProtocol{
 		URI:          "u",
 		SharedSecret: "s",
-		Permissions:  []string{"write"},
+		Permissions:  []string{"share"},
 		Requirements: []string{RequirementMustExchangeToken},
 	}
 
@@ -10,6 +10,8 @@ type TokenRequest struct {
 	GrantType string `json:"grant_type"` //nolint:tagliatelle // RFC 6749 mandates snake_case for OAuth 2.0 token endpoint fields
 	ClientID  string `json:"client_id"`  //nolint:tagliatelle // RFC 6749 mandates snake_case for OAuth 2.0 token endpoint fields
 	Code      string `json:"code"`
+	// RefreshToken is set for grant_type=refresh_token.
+	RefreshToken string `json:"refresh_token"` //nolint:tagliatelle // RFC 6749 mandates snake_case for OAuth 2.0 token endpoint fields
 }
 
 // TokenResponse represents a successful token exchange response.
@@ -17,6 +19,9 @@ type TokenResponse struct {
 	AccessToken string `json:"access_token"` //nolint:tagliatelle // RFC 6749 mandates snake_case for OAuth 2.0 token endpoint fields
 	TokenType   string `json:"token_type"`   //nolint:tagliatelle // RFC 6749 mandates snake_case for OAuth 2.0 token endpoint fields
 	ExpiresIn   int    `json:"expires_in"`   //nolint:tagliatelle // RFC 6749 mandates snake_case for OAuth 2.0 token endpoint fields
+	// RefreshToken is optional (RFC 6749 section 5.1); the receiver redeems
+	// it with grant_type=refresh_token once the access token lapses.
+	RefreshToken string `json:"refresh_token,omitempty"` //nolint:tagliatelle // RFC 6749 mandates snake_case for OAuth 2.0 token endpoint fields
 }
 
 // OAuthError represents an OAuth-style error response.
@@ -28,6 +33,7 @@ type OAuthError struct {
 // GrantType constants.
 const (
 	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
 )
 
 // OAuth error codes.
@@ -46,6 +46,8 @@ var wireLiteralSet = []string{
 	// Protocol role names
 	"webdav",
 	"webdav-receive",
+	"ssh",
+	"ssh-receive",
 
 	// Signature label
 	"ocm",
@@ -58,23 +60,24 @@ var wireLiteralSet = []string{
 // wildcards are not allowed.
 var wireLiteralAllowlist = map[string]map[int]struct{}{
 	"internal/components/ocm/access/remote.go": {
-		// ProtocolWebDAV constant; modernize rollout added a "slices" import (+1); wrapcheck rollout added a "fmt" import (+1).
-		35: {},
+		// ProtocolWebDAV constant; modernize rollout added a "slices" import (+1); wrapcheck rollout added a "fmt" import (+1); token cache added a "metrics" import (+1); ShareInfoFromIncoming added a "shares/incoming" import (+1).
+		37: {},
 	},
 	"internal/components/ocm/outbound/kinds.go": {
 		15: {},
 		17: {},
 	},
 	"internal/components/ocm/notifications/outgoing/sender.go": {
-		62: {},
+		// Endpoint path constant shared by the inline and outbox senders.
+		22: {},
 	},
 	"internal/frameworks/service/descriptors.go": {
 		16: {},
 		24: {},
-		45: {},
 		47: {},
-		69: {},
+		49: {},
 		71: {},
+		73: {},
 	},
 	"internal/frameworks/service/route_aggregate.go": {
 		135: {},
@@ -85,8 +88,12 @@ var wireLiteralAllowlist = map[string]map[int]struct{}{
 	},
 	"internal/frameworks/service/route_specs.go": {
 		51:  {},
-		85:  {},
-		101: {},
+		87:  {},
+		103: {},
+	},
+	"internal/platform/config/loader.go": {
+		// TOML key path for [ocm.ssh] host_key_path, not a wire literal.
+		141: {},
 	},
 	"internal/platform/config/loader_validate_ssrf.go": {
 		// loader.go split into loader_*.go; literal moved to loader_validate_ssrf.go:300.
@@ -99,8 +106,9 @@ var wireLiteralAllowlist = map[string]map[int]struct{}{
 		60: {},
 	},
 	"internal/services/ocm/ocm.go": {
-		// wrapcheck rollout added a "fmt" import (+1).
-		60: {},
+		// wrapcheck rollout added a "fmt" import (+1); the request-share
+		// handler import added one more (+1).
+		61: {},
 	},
 	"internal/services/ocm/routes.go": {
 		20: {},
@@ -114,13 +122,15 @@ var wireLiteralAllowlist = map[string]map[int]struct{}{
 		69: {},
 	},
 	"internal/services/webdav/routes.go": {
-		25: {},
+		// the chi import and WebDAV method registration moved it (+11).
+		36: {},
 	},
 	"internal/services/webdav/webdav.go": {
-		// wrapcheck rollout added a "fmt" import (+1).
-		49: {},
-		55: {},
-		71: {},
+		// wrapcheck rollout added a "fmt" import (+1); the SFTP server
+		// added imports, a field, and its startup path.
+		54:  {},
+		60:  {},
+		110: {},
 	},
 }
 
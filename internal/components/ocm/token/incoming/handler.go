@@ -7,18 +7,24 @@ package incoming
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"mime"
 	"net/http"
+	"slices"
 	"time"
 
 	inboundsignature "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/inbound/signature"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/policy"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/appctx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // Handler serves POST /ocm/token (token exchange).
@@ -26,10 +32,12 @@ type Handler struct {
 	outgoingRepo          outgoing.OutgoingShareRepo
 	tokenStore            token.TokenStore
 	tokenTTL              time.Duration
+	refreshTTL            time.Duration
 	settings              *TokenExchangeSettings
 	codeFlow              *policy.CodeFlow
 	localScheme           string // "http" or "https", derived from PublicOrigin
 	generateAccessTokenFn func() (string, error)
+	audit                 audit.Recorder
 }
 
 // NewHandler builds a token handler. Settings must have ApplyDefaults() called (done by cfg.Decode).
@@ -41,9 +49,29 @@ func NewHandler(outgoingRepo outgoing.OutgoingShareRepo, tokenStore token.TokenS
 		outgoingRepo: outgoingRepo,
 		tokenStore:   tokenStore,
 		tokenTTL:     token.DefaultTokenTTL,
+		refreshTTL:   token.DefaultRefreshTokenTTL,
 		settings:     settings,
 		codeFlow:     codeFlow,
 		localScheme:  localScheme,
+		audit:        audit.Nop(),
+	}
+}
+
+// SetAudit sets the audit recorder for issued tokens; nil disables it.
+func (h *Handler) SetAudit(r audit.Recorder) {
+	h.audit = audit.OrNop(r)
+}
+
+// SetTokenTTLs overrides the access and refresh token lifetimes
+// ([token_exchange] access_token_ttl_seconds and refresh_token_ttl_seconds).
+// Non-positive values keep the defaults.
+func (h *Handler) SetTokenTTLs(accessTTL, refreshTTL time.Duration) {
+	if accessTTL > 0 {
+		h.tokenTTL = accessTTL
+	}
+
+	if refreshTTL > 0 {
+		h.refreshTTL = refreshTTL
 	}
 }
 
@@ -63,12 +91,30 @@ func (h *Handler) HandleToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	share, ok := h.lookupAndVerifyShare(w, r, req)
+	if req.GrantType == token.GrantTypeRefreshToken {
+		share, refresh, ok := h.lookupRefreshToken(w, r, req)
+		if !ok {
+			return
+		}
+
+		// Tokens issued before scoping carry no permissions on the refresh
+		// token; they were always issued with the share's own.
+		permissions := refresh.Permissions
+		if permissions == nil {
+			permissions = share.Permissions
+		}
+
+		h.issueTokenResponse(w, r, req, share, permissions, refresh)
+
+		return
+	}
+
+	share, permissions, ok := h.lookupAndVerifyShare(w, r, req)
 	if !ok {
 		return
 	}
 
-	h.issueTokenResponse(w, r, req, share)
+	h.issueTokenResponse(w, r, req, share, permissions, nil)
 }
 
 // sendOAuthError sends an OAuth-style error response.
@@ -80,6 +126,8 @@ func (h *Handler) HandleToken(w http.ResponseWriter, r *http.Request) {
 // per-client grant-authorization enforcement; there is no current emission
 // path because OCM permits all authenticated receivers to use authorization_code.
 func (h *Handler) sendOAuthError(w http.ResponseWriter, status int, errCode, errDesc string) {
+	metrics.TokenExchange(errCode)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 
@@ -122,6 +170,7 @@ func (h *Handler) parseTokenRequest(w http.ResponseWriter, r *http.Request) (tok
 	req.GrantType = r.FormValue("grant_type")
 	req.ClientID = r.FormValue("client_id")
 	req.Code = r.FormValue("code")
+	req.RefreshToken = r.FormValue("refresh_token")
 
 	if req.GrantType == "" {
 		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidRequest, "grant_type is required")
@@ -129,7 +178,7 @@ func (h *Handler) parseTokenRequest(w http.ResponseWriter, r *http.Request) (tok
 		return token.TokenRequest{}, false
 	}
 
-	if req.GrantType != token.GrantTypeAuthorizationCode {
+	if req.GrantType != token.GrantTypeAuthorizationCode && req.GrantType != token.GrantTypeRefreshToken {
 		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorUnsupportedGrantType, "unsupported grant_type")
 
 		return token.TokenRequest{}, false
@@ -141,12 +190,18 @@ func (h *Handler) parseTokenRequest(w http.ResponseWriter, r *http.Request) (tok
 		return token.TokenRequest{}, false
 	}
 
-	if req.Code == "" {
+	if req.GrantType == token.GrantTypeAuthorizationCode && req.Code == "" {
 		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidRequest, "code is required")
 
 		return token.TokenRequest{}, false
 	}
 
+	if req.GrantType == token.GrantTypeRefreshToken && req.RefreshToken == "" {
+		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidRequest, "refresh_token is required")
+
+		return token.TokenRequest{}, false
+	}
+
 	if h.outgoingRepo == nil {
 		appctx.GetLogger(r.Context()).Error("token exchange attempted but outgoing share repo not configured")
 		h.sendOAuthError(w, http.StatusInternalServerError, token.ErrorServerError, "token exchange not available")
@@ -157,16 +212,138 @@ func (h *Handler) parseTokenRequest(w http.ResponseWriter, r *http.Request) (tok
 	return req, true
 }
 
-func (h *Handler) lookupAndVerifyShare(w http.ResponseWriter, r *http.Request, req token.TokenRequest) (*outgoing.OutgoingShare, bool) {
+// lookupAndVerifyShare resolves an authorization_code grant to its share and
+// the permissions the issued tokens carry: the share's WebDAV permissions for
+// the WebDAV secret, or the webapp scope for the webapp arm's secret.
+func (h *Handler) lookupAndVerifyShare(
+	w http.ResponseWriter,
+	r *http.Request,
+	req token.TokenRequest,
+) (*outgoing.OutgoingShare, []string, bool) {
 	ctx := r.Context()
 	log := appctx.GetLogger(ctx)
 
 	share, err := h.outgoingRepo.GetBySharedSecret(ctx, req.Code)
+
+	var permissions []string
+
+	if err == nil {
+		permissions = share.Permissions
+	} else {
+		share, err = h.outgoingRepo.GetByWebappSharedSecret(ctx, req.Code)
+		if err == nil {
+			permissions = webappTokenPermissions(share.WebappPermissions)
+		}
+	}
+
 	if err != nil {
 		log.Warn("token exchange for unknown secret", "client_id", req.ClientID)
 		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidGrant, "invalid code")
 
-		return nil, false
+		return nil, nil, false
+	}
+
+	if !h.verifyShareClient(w, r, req, share, "invalid code") {
+		return nil, nil, false
+	}
+
+	return share, permissions, true
+}
+
+// webappTokenPermissions maps webapp permissions to the WebDAV permissions a
+// token exchanged with the webapp secret carries: always read, since viewing
+// needs the content, and write only when the webapp arm grants it.
+func webappTokenPermissions(webappPermissions []string) []string {
+	permissions := []string{spec.PermissionRead}
+	if slices.Contains(webappPermissions, spec.PermissionWrite) {
+		permissions = append(permissions, spec.PermissionWrite)
+	}
+
+	return permissions
+}
+
+// lookupRefreshToken resolves a refresh_token grant to the share it was
+// issued for, applying the same revocation and client checks as the
+// authorization_code grant. The refresh token is claimed before anything is
+// issued, so concurrent redemptions of one token yield a single new pair.
+func (h *Handler) lookupRefreshToken(
+	w http.ResponseWriter,
+	r *http.Request,
+	req token.TokenRequest,
+) (*outgoing.OutgoingShare, *token.IssuedToken, bool) {
+	ctx := r.Context()
+	log := appctx.GetLogger(ctx)
+
+	refresh, err := h.tokenStore.Get(ctx, req.RefreshToken)
+	if err != nil && !errors.Is(err, token.ErrTokenNotFound) && !errors.Is(err, token.ErrTokenExpired) {
+		log.Error("failed to load refresh token", "error", err)
+		h.sendOAuthError(w, http.StatusInternalServerError, token.ErrorServerError, "token lookup failed")
+
+		return nil, nil, false
+	}
+
+	if err != nil || !refresh.IsRefresh() {
+		log.Warn("token refresh with unknown or expired refresh token", "client_id", req.ClientID)
+		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidGrant, "invalid refresh_token")
+
+		return nil, nil, false
+	}
+
+	share, err := h.outgoingRepo.GetByID(ctx, refresh.ShareID)
+	if err != nil {
+		log.Warn("token refresh for unknown share", "share_id", refresh.ShareID, "client_id", req.ClientID)
+		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidGrant, "invalid refresh_token")
+
+		return nil, nil, false
+	}
+
+	if !h.verifyShareClient(w, r, req, share, "invalid refresh_token") {
+		return nil, nil, false
+	}
+
+	if err := h.tokenStore.Claim(ctx, req.RefreshToken); err != nil {
+		if !errors.Is(err, token.ErrTokenNotFound) {
+			log.Error("failed to claim refresh token", "share_id", share.ShareID, "error", err)
+			h.sendOAuthError(w, http.StatusInternalServerError, token.ErrorServerError, "token lookup failed")
+
+			return nil, nil, false
+		}
+
+		log.Warn("token refresh with already redeemed refresh token", "share_id", share.ShareID, "client_id", req.ClientID)
+		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidGrant, "invalid refresh_token")
+
+		return nil, nil, false
+	}
+
+	return share, refresh, true
+}
+
+// verifyShareClient rejects revoked or expired shares and callers whose
+// client_id (or verified signature identity) is not the share's receiver.
+// grantErrDesc is the invalid_grant description for the revoked and expired
+// cases.
+func (h *Handler) verifyShareClient(
+	w http.ResponseWriter,
+	r *http.Request,
+	req token.TokenRequest,
+	share *outgoing.OutgoingShare,
+	grantErrDesc string,
+) bool {
+	ctx := r.Context()
+	log := appctx.GetLogger(ctx)
+
+	if share.Status == shares.OutgoingShareStatusRevoked {
+		log.Warn("token exchange for revoked share", "share_id", share.ShareID, "client_id", req.ClientID)
+		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidGrant, grantErrDesc)
+
+		return false
+	}
+
+	if share.Expired(time.Now()) {
+		log.Warn("token exchange for expired share", "share_id", share.ShareID, "client_id", req.ClientID)
+		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidGrant, grantErrDesc)
+
+		return false
 	}
 
 	normalizedReceiver, errReceiver := hostport.Normalize(share.ReceiverHost, h.localScheme)
@@ -178,7 +355,7 @@ func (h *Handler) lookupAndVerifyShare(w http.ResponseWriter, r *http.Request, r
 			"client_err", errClient)
 		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidClient, "invalid client_id")
 
-		return nil, false
+		return false
 	}
 
 	if normalizedReceiver != normalizedClient {
@@ -187,7 +364,7 @@ func (h *Handler) lookupAndVerifyShare(w http.ResponseWriter, r *http.Request, r
 			"got", req.ClientID)
 		h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidClient, "client_id mismatch")
 
-		return nil, false
+		return false
 	}
 
 	peerIdentity := inboundsignature.GetPeerIdentity(ctx)
@@ -198,14 +375,26 @@ func (h *Handler) lookupAndVerifyShare(w http.ResponseWriter, r *http.Request, r
 				"got", peerIdentity.AuthorityForCompare)
 			h.sendOAuthError(w, http.StatusBadRequest, token.ErrorInvalidClient, "client_id mismatch")
 
-			return nil, false
+			return false
 		}
 	}
 
-	return share, true
+	return true
 }
 
-func (h *Handler) issueTokenResponse(w http.ResponseWriter, r *http.Request, req token.TokenRequest, share *outgoing.OutgoingShare) {
+// issueTokenResponse issues an access token and, when refresh tokens are
+// enabled, a refresh token, both carrying permissions so a refresh keeps the
+// scope of the original exchange. previous is the refresh token redeemed by a
+// refresh_token grant, already claimed by lookupRefreshToken: its replacement
+// keeps the original expiry so refreshing cannot extend access indefinitely.
+func (h *Handler) issueTokenResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	req token.TokenRequest,
+	share *outgoing.OutgoingShare,
+	permissions []string,
+	previous *token.IssuedToken,
+) {
 	ctx := r.Context()
 	log := appctx.GetLogger(ctx)
 
@@ -216,39 +405,65 @@ func (h *Handler) issueTokenResponse(w http.ResponseWriter, r *http.Request, req
 		return
 	}
 
-	accessToken, err := h.generateAccessToken()
-	if err != nil {
-		log.Error("failed to generate access token", "error", err)
-		h.sendOAuthError(w, http.StatusInternalServerError, token.ErrorServerError, "token generation failed")
-
-		return
-	}
-
 	now := time.Now()
-	issuedToken := &token.IssuedToken{
-		AccessToken: accessToken,
+
+	accessToken, ok := h.storeNewToken(w, r, &token.IssuedToken{
 		ShareID:     share.ShareID,
 		ClientID:    req.ClientID,
+		Permissions: slices.Clone(permissions),
 		IssuedAt:    now,
 		ExpiresAt:   now.Add(h.tokenTTL),
+	})
+	if !ok {
+		return
 	}
 
-	if err := h.tokenStore.Store(ctx, issuedToken); err != nil {
-		log.Error("failed to store token", "error", err)
-		h.sendOAuthError(w, http.StatusInternalServerError, token.ErrorServerError, "token storage failed")
+	var refreshToken string
 
-		return
+	if h.refreshTTL > 0 {
+		refreshExpiry := now.Add(h.refreshTTL)
+		if previous != nil {
+			refreshExpiry = previous.ExpiresAt
+		}
+
+		refreshToken, ok = h.storeNewToken(w, r, &token.IssuedToken{
+			ShareID:     share.ShareID,
+			ClientID:    req.ClientID,
+			Kind:        token.KindRefresh,
+			Permissions: slices.Clone(permissions),
+			IssuedAt:    now,
+			ExpiresAt:   refreshExpiry,
+		})
+		if !ok {
+			return
+		}
 	}
 
+	result := metrics.TokenIssued
+
+	if previous != nil {
+		result = metrics.TokenRefreshed
+	}
+
+	metrics.TokenExchange(result)
+	h.audit.Record(ctx, audit.Event{
+		Action:   audit.ActionTokenIssue,
+		Actor:    req.ClientID,
+		Peer:     share.ReceiverHost,
+		Resource: share.ShareID,
+		Details:  map[string]string{"grant_type": req.GrantType},
+	})
 	log.Info("token issued",
 		"share_id", share.ShareID,
 		"client_id", req.ClientID,
+		"grant_type", req.GrantType,
 		"expires_in", int(h.tokenTTL.Seconds()))
 
 	resp := token.TokenResponse{
-		AccessToken: accessToken,
-		TokenType:   "Bearer",
-		ExpiresIn:   int(h.tokenTTL.Seconds()),
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(h.tokenTTL.Seconds()),
+		RefreshToken: refreshToken,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -260,6 +475,31 @@ func (h *Handler) issueTokenResponse(w http.ResponseWriter, r *http.Request, req
 	}
 }
 
+// storeNewToken fills issued with a fresh random token value and stores it,
+// writing a server_error response on failure.
+func (h *Handler) storeNewToken(w http.ResponseWriter, r *http.Request, issued *token.IssuedToken) (string, bool) {
+	log := appctx.GetLogger(r.Context())
+
+	value, err := h.generateAccessToken()
+	if err != nil {
+		log.Error("failed to generate access token", "error", err)
+		h.sendOAuthError(w, http.StatusInternalServerError, token.ErrorServerError, "token generation failed")
+
+		return "", false
+	}
+
+	issued.AccessToken = value
+
+	if err := h.tokenStore.Store(r.Context(), issued); err != nil {
+		log.Error("failed to store token", "error", err)
+		h.sendOAuthError(w, http.StatusInternalServerError, token.ErrorServerError, "token storage failed")
+
+		return "", false
+	}
+
+	return value, true
+}
+
 func (h *Handler) generateAccessToken() (string, error) {
 	if h.generateAccessTokenFn != nil {
 		return h.generateAccessTokenFn()
@@ -14,9 +14,11 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
 
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
 	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
 	tokenincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token/incoming"
@@ -77,6 +79,96 @@ func TestHandler_ClientMismatch(t *testing.T) {
 	}
 }
 
+func TestHandler_RevokedShareRejected(t *testing.T) {
+	t.Parallel()
+	shareRepo := tsrepos.OpenMemory(t).OutgoingShares
+	tokenStore := token.NewMemoryTokenStore()
+	handler := tokenincoming.NewHandler(shareRepo, tokenStore, enabledSettings(), enabledCodeFlow(), "https://local.example.com")
+
+	share := &sharesoutgoing.OutgoingShare{
+		ProviderID:   "provider-revoked",
+		WebDAVID:     "webdav-revoked",
+		SharedSecret: "secret-revoked",
+		ReceiverHost: "receiver.example.com",
+		LocalPath:    "/tmp/test.txt",
+		Status:       shares.OutgoingShareStatusRevoked,
+	}
+	if err := shareRepo.Create(context.Background(), share); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", "receiver.example.com")
+	form.Set("code", "secret-revoked")
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+
+	handler.HandleToken(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+
+	var resp token.OAuthError
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	if resp.Error != token.ErrorInvalidGrant {
+		t.Errorf("expected error %q, got %q", token.ErrorInvalidGrant, resp.Error)
+	}
+}
+
+func TestHandler_ExpiredShareRejected(t *testing.T) {
+	t.Parallel()
+	shareRepo := tsrepos.OpenMemory(t).OutgoingShares
+	tokenStore := token.NewMemoryTokenStore()
+	handler := tokenincoming.NewHandler(shareRepo, tokenStore, enabledSettings(), enabledCodeFlow(), "https://local.example.com")
+
+	expiresAt := time.Now().Add(-time.Minute)
+	share := &sharesoutgoing.OutgoingShare{
+		ProviderID:   "provider-expired",
+		WebDAVID:     "webdav-expired",
+		SharedSecret: "secret-expired",
+		ReceiverHost: "receiver.example.com",
+		LocalPath:    "/tmp/test.txt",
+		Status:       shares.OutgoingShareStatusAccepted,
+		ExpiresAt:    &expiresAt,
+	}
+	if err := shareRepo.Create(context.Background(), share); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", "receiver.example.com")
+	form.Set("code", "secret-expired")
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+
+	handler.HandleToken(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+
+	var resp token.OAuthError
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	if resp.Error != token.ErrorInvalidGrant {
+		t.Errorf("expected error %q, got %q", token.ErrorInvalidGrant, resp.Error)
+	}
+}
+
 func TestHandler_NormalizeError_InvalidClient(t *testing.T) {
 	t.Parallel()
 
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
 
@@ -142,3 +143,52 @@ func TestHandler_AuthorizationCode_FormEncoded_Success(t *testing.T) {
 		t.Errorf("token_type = %q, want %q", resp.TokenType, "Bearer")
 	}
 }
+
+func TestHandler_IssuedTokenCarriesSharePermissions(t *testing.T) {
+	t.Parallel()
+	shareRepo := tsrepos.OpenMemory(t).OutgoingShares
+	tokenStore := token.NewMemoryTokenStore()
+	handler := tokenincoming.NewHandler(shareRepo, tokenStore, enabledSettings(), enabledCodeFlow(), "https://local.example.com")
+
+	share := &sharesoutgoing.OutgoingShare{
+		ProviderID:   "provider-rw",
+		WebDAVID:     "webdav-rw",
+		SharedSecret: "secret-code-rw",
+		ReceiverHost: "receiver.example.com",
+		LocalPath:    "/tmp/rw",
+		Permissions:  []string{"read", "write"},
+	}
+	if err := shareRepo.Create(context.Background(), share); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", "receiver.example.com")
+	form.Set("code", "secret-code-rw")
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+
+	handler.HandleToken(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp token.TokenResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	stored, err := tokenStore.Get(context.Background(), resp.AccessToken)
+	if err != nil {
+		t.Fatalf("failed to get stored token: %v", err)
+	}
+
+	if !slices.Equal(stored.Permissions, []string{"read", "write"}) {
+		t.Errorf("stored permissions = %v, want [read write]", stored.Permissions)
+	}
+}
@@ -48,6 +48,22 @@ func (s *failingTokenStore) Delete(ctx context.Context, accessToken string) erro
 	return nil
 }
 
+func (s *failingTokenStore) Claim(ctx context.Context, accessToken string) error {
+	if err := s.inner.Claim(ctx, accessToken); err != nil {
+		return fmt.Errorf("ocm: claim token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *failingTokenStore) DeleteByShareID(ctx context.Context, shareID string) error {
+	if err := s.inner.DeleteByShareID(ctx, shareID); err != nil {
+		return fmt.Errorf("ocm: delete tokens by share: %w", err)
+	}
+
+	return nil
+}
+
 func (s *failingTokenStore) CleanExpired(ctx context.Context) error {
 	if err := s.inner.CleanExpired(ctx); err != nil {
 		return fmt.Errorf("ocm: clean expired tokens: %w", err)
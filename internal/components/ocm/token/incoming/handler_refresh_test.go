@@ -0,0 +1,374 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package incoming_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	tokenincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token/incoming"
+)
+
+type refreshFixture struct {
+	shares  sharesoutgoing.OutgoingShareRepo
+	tokens  *token.MemoryTokenStore
+	handler *tokenincoming.Handler
+	share   *sharesoutgoing.OutgoingShare
+}
+
+func newRefreshFixture(t *testing.T) *refreshFixture {
+	t.Helper()
+
+	f := &refreshFixture{
+		shares: tsrepos.OpenMemory(t).OutgoingShares,
+		tokens: token.NewMemoryTokenStore(),
+	}
+	f.handler = tokenincoming.NewHandler(f.shares, f.tokens, enabledSettings(), enabledCodeFlow(), "https://local.example.com")
+
+	f.share = &sharesoutgoing.OutgoingShare{
+		ProviderID:   "provider-refresh",
+		WebDAVID:     "webdav-refresh",
+		SharedSecret: "refresh-secret-code",
+		ReceiverHost: "receiver.example.com",
+		LocalPath:    "/tmp/test.txt",
+		Permissions:  []string{"read"},
+	}
+	if err := f.shares.Create(context.Background(), f.share); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	return f
+}
+
+func (f *refreshFixture) post(t *testing.T, form url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/ocm/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	f.handler.HandleToken(w, req)
+
+	return w
+}
+
+func (f *refreshFixture) exchangeCode(t *testing.T) token.TokenResponse {
+	t.Helper()
+
+	return decodeTokenResponse(t, f.post(t, url.Values{
+		"grant_type": {"authorization_code"},
+		"client_id":  {"receiver.example.com"},
+		"code":       {f.share.SharedSecret},
+	}))
+}
+
+func refreshForm(refreshToken string) url.Values {
+	return url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {"receiver.example.com"},
+		"refresh_token": {refreshToken},
+	}
+}
+
+func decodeTokenResponse(t *testing.T, w *httptest.ResponseRecorder) token.TokenResponse {
+	t.Helper()
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp token.TokenResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode token response: %v", err)
+	}
+
+	return resp
+}
+
+func requireOAuthError(t *testing.T, w *httptest.ResponseRecorder, wantCode string) {
+	t.Helper()
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp token.OAuthError
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+
+	if resp.Error != wantCode {
+		t.Errorf("error = %q, want %q", resp.Error, wantCode)
+	}
+}
+
+func TestHandler_CodeExchangeIssuesRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	f := newRefreshFixture(t)
+	resp := f.exchangeCode(t)
+
+	if resp.RefreshToken == "" || resp.RefreshToken == resp.AccessToken {
+		t.Fatalf("refresh_token = %q, want a distinct refresh token", resp.RefreshToken)
+	}
+
+	stored, err := f.tokens.Get(context.Background(), resp.RefreshToken)
+	if err != nil {
+		t.Fatalf("Get(refresh): %v", err)
+	}
+
+	if !stored.IsRefresh() || stored.ShareID != f.share.ShareID {
+		t.Errorf("stored refresh token = %+v", stored)
+	}
+
+	if ttl := time.Until(stored.ExpiresAt); ttl < 23*time.Hour {
+		t.Errorf("refresh token lifetime = %v, want the 24h default", ttl)
+	}
+}
+
+func TestHandler_RefreshGrantRotatesRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	f := newRefreshFixture(t)
+	first := f.exchangeCode(t)
+
+	original, err := f.tokens.Get(context.Background(), first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Get(original refresh): %v", err)
+	}
+
+	second := decodeTokenResponse(t, f.post(t, refreshForm(first.RefreshToken)))
+	if second.AccessToken == "" || second.AccessToken == first.AccessToken {
+		t.Fatalf("refreshed access_token = %q, want a new token", second.AccessToken)
+	}
+
+	if second.RefreshToken == "" || second.RefreshToken == first.RefreshToken {
+		t.Fatalf("refreshed refresh_token = %q, want a rotated token", second.RefreshToken)
+	}
+
+	access, err := f.tokens.Get(context.Background(), second.AccessToken)
+	if err != nil {
+		t.Fatalf("Get(refreshed access): %v", err)
+	}
+
+	if access.IsRefresh() || access.ShareID != f.share.ShareID {
+		t.Errorf("refreshed access token = %+v", access)
+	}
+
+	rotated, err := f.tokens.Get(context.Background(), second.RefreshToken)
+	if err != nil {
+		t.Fatalf("Get(rotated refresh): %v", err)
+	}
+
+	if !rotated.ExpiresAt.Equal(original.ExpiresAt) {
+		t.Errorf("rotated refresh expiry = %v, want original %v", rotated.ExpiresAt, original.ExpiresAt)
+	}
+
+	if _, err := f.tokens.Get(context.Background(), first.RefreshToken); !errors.Is(err, token.ErrTokenNotFound) {
+		t.Errorf("Get(old refresh) = %v, want ErrTokenNotFound", err)
+	}
+
+	requireOAuthError(t, f.post(t, refreshForm(first.RefreshToken)), token.ErrorInvalidGrant)
+}
+
+// gatedTokenStore holds every Get of gate until all redeemers have looked the
+// token up, so concurrent redemptions all pass validation before any claims.
+type gatedTokenStore struct {
+	*token.MemoryTokenStore
+
+	gate    string
+	arrived sync.WaitGroup
+}
+
+func (s *gatedTokenStore) Get(ctx context.Context, accessToken string) (*token.IssuedToken, error) {
+	issued, err := s.MemoryTokenStore.Get(ctx, accessToken)
+
+	if accessToken == s.gate {
+		s.arrived.Done()
+		s.arrived.Wait()
+	}
+
+	return issued, err //nolint:wrapcheck // test double passes the wrapped store's sentinel errors through
+}
+
+func TestHandler_ConcurrentRefreshRedeemsOnce(t *testing.T) {
+	t.Parallel()
+
+	const redeemers = 8
+
+	f := newRefreshFixture(t)
+	first := f.exchangeCode(t)
+
+	gated := &gatedTokenStore{MemoryTokenStore: f.tokens, gate: first.RefreshToken}
+	gated.arrived.Add(redeemers)
+	f.handler = tokenincoming.NewHandler(f.shares, gated, enabledSettings(), enabledCodeFlow(), "https://local.example.com")
+
+	var (
+		wg    sync.WaitGroup
+		wins  atomic.Int32
+		codes [redeemers]int
+	)
+
+	for i := range redeemers {
+		wg.Go(func() {
+			w := f.post(t, refreshForm(first.RefreshToken))
+
+			codes[i] = w.Code
+			if w.Code == http.StatusOK {
+				wins.Add(1)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if got := wins.Load(); got != 1 {
+		t.Fatalf("%d concurrent redemptions succeeded (statuses %v), want exactly 1", got, codes)
+	}
+
+	for _, code := range codes {
+		if code != http.StatusOK && code != http.StatusBadRequest {
+			t.Errorf("losing redemption status = %d, want 400 invalid_grant", code)
+		}
+	}
+}
+
+func TestHandler_RefreshGrantRejections(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing refresh_token", func(t *testing.T) {
+		t.Parallel()
+
+		f := newRefreshFixture(t)
+		requireOAuthError(t, f.post(t, refreshForm("")), token.ErrorInvalidRequest)
+	})
+
+	t.Run("unknown refresh_token", func(t *testing.T) {
+		t.Parallel()
+
+		f := newRefreshFixture(t)
+		requireOAuthError(t, f.post(t, refreshForm("never-issued")), token.ErrorInvalidGrant)
+	})
+
+	t.Run("access token presented as refresh_token", func(t *testing.T) {
+		t.Parallel()
+
+		f := newRefreshFixture(t)
+		resp := f.exchangeCode(t)
+		requireOAuthError(t, f.post(t, refreshForm(resp.AccessToken)), token.ErrorInvalidGrant)
+	})
+
+	t.Run("client mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		f := newRefreshFixture(t)
+		resp := f.exchangeCode(t)
+
+		form := refreshForm(resp.RefreshToken)
+		form.Set("client_id", "attacker.example.com")
+		requireOAuthError(t, f.post(t, form), token.ErrorInvalidClient)
+	})
+
+	t.Run("revoked share", func(t *testing.T) {
+		t.Parallel()
+
+		f := newRefreshFixture(t)
+		resp := f.exchangeCode(t)
+
+		f.share.Status = shares.OutgoingShareStatusRevoked
+		if err := f.shares.Update(context.Background(), f.share); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		requireOAuthError(t, f.post(t, refreshForm(resp.RefreshToken)), token.ErrorInvalidGrant)
+	})
+}
+
+func TestHandler_SetTokenTTLs(t *testing.T) {
+	t.Parallel()
+
+	f := newRefreshFixture(t)
+	f.handler.SetTokenTTLs(5*time.Minute, 2*time.Hour)
+
+	resp := f.exchangeCode(t)
+	if resp.ExpiresIn != 300 {
+		t.Errorf("expires_in = %d, want 300", resp.ExpiresIn)
+	}
+
+	refresh, err := f.tokens.Get(context.Background(), resp.RefreshToken)
+	if err != nil {
+		t.Fatalf("Get(refresh): %v", err)
+	}
+
+	if ttl := time.Until(refresh.ExpiresAt); ttl > 2*time.Hour || ttl < time.Hour {
+		t.Errorf("refresh lifetime = %v, want about 2h", ttl)
+	}
+}
+
+func TestHandler_WebappSecretIssuesScopedTokens(t *testing.T) {
+	t.Parallel()
+
+	f := newRefreshFixture(t)
+
+	share := &sharesoutgoing.OutgoingShare{
+		ProviderID:         "provider-webapp",
+		WebDAVID:           "webdav-webapp",
+		SharedSecret:       "webdav-secret-code",
+		WebappSharedSecret: "webapp-secret-code",
+		WebappPermissions:  []string{"view"},
+		ReceiverHost:       "receiver.example.com",
+		LocalPath:          "/tmp/webapp.txt",
+		Permissions:        []string{"read", "write"},
+	}
+	if err := f.shares.Create(context.Background(), share); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	exchange := func(code string) token.TokenResponse {
+		return decodeTokenResponse(t, f.post(t, url.Values{
+			"grant_type": {"authorization_code"},
+			"client_id":  {"receiver.example.com"},
+			"code":       {code},
+		}))
+	}
+
+	requirePermissions := func(accessToken string, want []string) {
+		t.Helper()
+
+		stored, err := f.tokens.Get(context.Background(), accessToken)
+		if err != nil {
+			t.Fatalf("Get(access): %v", err)
+		}
+
+		if stored.ShareID != share.ShareID || !slices.Equal(stored.Permissions, want) {
+			t.Errorf("access token = %+v, want share %s with permissions %v", stored, share.ShareID, want)
+		}
+	}
+
+	webapp := exchange(share.WebappSharedSecret)
+	requirePermissions(webapp.AccessToken, []string{"read"})
+
+	refreshed := decodeTokenResponse(t, f.post(t, refreshForm(webapp.RefreshToken)))
+	requirePermissions(refreshed.AccessToken, []string{"read"})
+
+	webdav := exchange(share.SharedSecret)
+	requirePermissions(webdav.AccessToken, []string{"read", "write"})
+}
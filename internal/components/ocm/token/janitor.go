@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package token
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// Janitor calls CleanExpired on a TokenStore at a fixed interval so expired
+// tokens do not accumulate in durable stores.
+type Janitor struct {
+	store    TokenStore
+	interval time.Duration
+	log      *slog.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewJanitor returns a Janitor; call Start to run the background sweep.
+func NewJanitor(store TokenStore, interval time.Duration, log *slog.Logger) *Janitor {
+	return &Janitor{
+		store:    store,
+		interval: interval,
+		log:      logutil.NoopIfNil(log),
+	}
+}
+
+// Start runs the sweep until Close. The first sweep runs after one interval.
+func (j *Janitor) Start() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	j.done = make(chan struct{})
+
+	go j.run(ctx)
+}
+
+// Close stops the sweep and waits for a running pass. Safe to call when the
+// janitor never started.
+func (j *Janitor) Close() error {
+	j.mu.Lock()
+	cancel, done := j.cancel, j.done
+	j.cancel = nil
+	j.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	<-done
+
+	return nil
+}
+
+func (j *Janitor) run(ctx context.Context) {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := j.store.CleanExpired(ctx); err != nil && ctx.Err() == nil {
+			j.log.Warn("token store cleanup failed", "error", err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package token
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJanitor_SweepsExpiredTokens(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+	now := time.Now()
+
+	if err := store.Store(ctx, &IssuedToken{AccessToken: "stale", ShareID: "s", ExpiresAt: now.Add(-time.Minute)}); err != nil {
+		t.Fatalf("Store(stale): %v", err)
+	}
+
+	if err := store.Store(ctx, &IssuedToken{AccessToken: "live", ShareID: "s", ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("Store(live): %v", err)
+	}
+
+	j := NewJanitor(store, 10*time.Millisecond, nil)
+	j.Start()
+	j.Start() // second Start is a no-op
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := store.Get(ctx, "stale"); errors.Is(err, ErrTokenNotFound) {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("janitor did not remove the expired token")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := j.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if _, err := store.Get(ctx, "live"); err != nil {
+		t.Errorf("Get(live): %v, want live token kept", err)
+	}
+}
+
+func TestJanitor_CloseWithoutStart(t *testing.T) {
+	t.Parallel()
+
+	if err := NewJanitor(NewMemoryTokenStore(), time.Minute, nil).Close(); err != nil {
+		t.Fatalf("Close without Start: %v", err)
+	}
+}
+
+func TestHashAccessToken(t *testing.T) {
+	t.Parallel()
+
+	a := HashAccessToken("token-a")
+	if len(a) != 64 {
+		t.Fatalf("hash length = %d, want 64 hex chars", len(a))
+	}
+
+	if a != HashAccessToken("token-a") {
+		t.Error("hash must be deterministic")
+	}
+
+	if a == HashAccessToken("token-b") {
+		t.Error("different tokens must hash differently")
+	}
+}
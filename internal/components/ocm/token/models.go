@@ -8,6 +8,7 @@ package token
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"time"
@@ -17,6 +18,10 @@ import (
 
 const DefaultTokenTTL = 1 * time.Hour //nolint:revive // exported: obvious default token TTL duration constant
 
+// DefaultRefreshTokenTTL is the lifetime of a refresh token issued alongside
+// an access token when [token_exchange] does not override it.
+const DefaultRefreshTokenTTL = 24 * time.Hour
+
 type (
 	TokenRequest  = spec.TokenRequest  //nolint:revive // exported: alias re-exporting spec.TokenRequest
 	TokenResponse = spec.TokenResponse //nolint:revive // exported: alias re-exporting spec.TokenResponse
@@ -26,6 +31,8 @@ type (
 const (
 	// GrantTypeAuthorizationCode is the authorization-code grant type.
 	GrantTypeAuthorizationCode = spec.GrantTypeAuthorizationCode
+	// GrantTypeRefreshToken is the refresh-token grant type.
+	GrantTypeRefreshToken = spec.GrantTypeRefreshToken
 	// ErrorInvalidRequest is the invalid-request OAuth error code.
 	ErrorInvalidRequest = spec.ErrorInvalidRequest
 	// ErrorInvalidGrant is the invalid-grant OAuth error code.
@@ -40,11 +47,22 @@ const (
 	ErrorServerError = spec.ErrorServerError
 )
 
-// IssuedToken holds an access token issued by the OCM token endpoint.
+// KindRefresh marks an IssuedToken as a refresh token. Access tokens leave
+// Kind empty.
+const KindRefresh = "refresh"
+
+// IssuedToken holds a token issued by the OCM token endpoint.
+// Permissions snapshots the share's WebDAV permissions at issuance, or the
+// webapp scope when the webapp arm's secret was exchanged; the WebDAV handler
+// only allows writes when both the share and the token carry "write".
+// Refresh tokens (Kind == KindRefresh) share the store with access tokens but
+// are only redeemable at the token endpoint, never as WebDAV bearers.
 type IssuedToken struct {
 	AccessToken string    `json:"accessToken"`
 	ShareID     string    `json:"shareId"`
 	ClientID    string    `json:"clientId"`
+	Kind        string    `json:"kind,omitempty"`
+	Permissions []string  `json:"permissions,omitempty"`
 	IssuedAt    time.Time `json:"issuedAt"`
 	ExpiresAt   time.Time `json:"expiresAt"`
 }
@@ -53,6 +71,11 @@ func (t *IssuedToken) IsExpired() bool { //nolint:revive // exported: trivial ex
 	return time.Now().After(t.ExpiresAt)
 }
 
+// IsRefresh reports whether t is a refresh token.
+func (t *IssuedToken) IsRefresh() bool {
+	return t.Kind == KindRefresh
+}
+
 // GenerateAccessToken returns a fresh 32-byte random hex access token.
 func GenerateAccessToken() (string, error) {
 	b := make([]byte, 32)
@@ -62,3 +85,12 @@ func GenerateAccessToken() (string, error) {
 
 	return hex.EncodeToString(b), nil
 }
+
+// HashAccessToken returns the hex SHA-256 digest durable token stores key
+// tokens by, so a leaked store does not leak usable bearer tokens. Access
+// tokens are 256-bit random values, so an unsalted fast hash is enough.
+func HashAccessToken(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -41,11 +41,19 @@ type ExchangeRequest struct {
 	SharedSecret  string // authorization code (sharedSecret) to exchange
 }
 
-// ExchangeResult holds the exchange result.
+// RefreshRequest holds refresh_token grant parameters.
+type RefreshRequest struct {
+	TokenEndPoint string // Sending Server tokenEndPoint from peer discovery
+	RefreshToken  string // refresh token from an earlier ExchangeResult
+}
+
+// ExchangeResult holds the exchange result. RefreshToken is empty when the
+// Sending Server does not issue refresh tokens.
 type ExchangeResult struct {
-	AccessToken string
-	TokenType   string
-	ExpiresIn   int
+	AccessToken  string
+	TokenType    string
+	ExpiresIn    int
+	RefreshToken string
 }
 
 // NewClient builds a token exchange client.
@@ -69,7 +77,29 @@ func NewClient(
 // per the applicability rules at https://github.com/cs3org/OCM-API/blob/6a0586183cbef10ecae9dedc42561806447eb2f5/IETF-OCM.md#L808-L823,
 // applied via c.signer.Sign here.
 func (c *Client) Exchange(ctx context.Context, req ExchangeRequest, disc *spec.Discovery) (*ExchangeResult, error) {
-	httpReq, err := c.buildFormRequest(ctx, req, token.GrantTypeAuthorizationCode)
+	form := url.Values{}
+	form.Set("grant_type", token.GrantTypeAuthorizationCode)
+	form.Set("client_id", c.myClientID)
+	form.Set("code", req.SharedSecret)
+
+	return c.send(ctx, req.TokenEndPoint, form, disc)
+}
+
+// Refresh redeems a refresh token for a new access token (and a rotated
+// refresh token). Signing follows the same rules as Exchange.
+func (c *Client) Refresh(ctx context.Context, req RefreshRequest, disc *spec.Discovery) (*ExchangeResult, error) {
+	form := url.Values{}
+	form.Set("grant_type", token.GrantTypeRefreshToken)
+	form.Set("client_id", c.myClientID)
+	form.Set("refresh_token", req.RefreshToken)
+
+	return c.send(ctx, req.TokenEndPoint, form, disc)
+}
+
+// send posts form to the token endpoint, signing it when the peer advertises
+// http-sig.
+func (c *Client) send(ctx context.Context, tokenEndPoint string, form url.Values, disc *spec.Discovery) (*ExchangeResult, error) {
+	httpReq, err := c.buildFormRequest(ctx, tokenEndPoint, form)
 	if err != nil {
 		return nil, err
 	}
@@ -96,16 +126,11 @@ func (c *Client) Exchange(ctx context.Context, req ExchangeRequest, disc *spec.D
 }
 
 // buildFormRequest builds a form-urlencoded POST to the Sending Server tokenEndPoint.
-func (c *Client) buildFormRequest(ctx context.Context, req ExchangeRequest, grantType string) (*http.Request, error) {
-	form := url.Values{}
-	form.Set("grant_type", grantType)
-	form.Set("client_id", c.myClientID)
-	form.Set("code", req.SharedSecret)
-
+func (c *Client) buildFormRequest(ctx context.Context, tokenEndPoint string, form url.Values) (*http.Request, error) {
 	httpReq, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
-		req.TokenEndPoint,
+		tokenEndPoint,
 		strings.NewReader(form.Encode()),
 	)
 	if err != nil {
@@ -237,9 +262,10 @@ func (c *Client) parseTokenResult(body []byte, contentType string) (*ExchangeRes
 	}
 
 	return &ExchangeResult{
-		AccessToken: tokenResp.AccessToken,
-		TokenType:   tokenResp.TokenType,
-		ExpiresIn:   tokenResp.ExpiresIn,
+		AccessToken:  tokenResp.AccessToken,
+		TokenType:    tokenResp.TokenType,
+		ExpiresIn:    tokenResp.ExpiresIn,
+		RefreshToken: tokenResp.RefreshToken,
 	}, nil
 }
 
@@ -95,6 +95,59 @@ func TestClient_Exchange_DefaultGrantType_AuthorizationCode(t *testing.T) {
 	}
 }
 
+func TestClient_Refresh_SendsRefreshTokenGrant(t *testing.T) {
+	t.Parallel()
+
+	server := newTokenTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+
+		if got := r.FormValue("grant_type"); got != token.GrantTypeRefreshToken {
+			t.Errorf("grant_type = %q, want %q", got, token.GrantTypeRefreshToken)
+		}
+
+		if got := r.FormValue("refresh_token"); got != "old-refresh" {
+			t.Errorf("refresh_token = %q, want old-refresh", got)
+		}
+
+		if r.FormValue("code") != "" {
+			t.Error("refresh grant must not send code")
+		}
+
+		if r.Header.Get("Signature") == "" {
+			t.Error("refresh grant must be signed for an http-sig peer")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		tshttp.MustEncodeJSON(t, w, token.TokenResponse{
+			AccessToken:  "new-access",
+			TokenType:    "Bearer",
+			ExpiresIn:    600,
+			RefreshToken: "new-refresh",
+		})
+	}))
+	defer server.Close()
+
+	httpClient := httpclient.NewContextClient(httpclient.New(&config.OutboundHTTPConfig{
+		SSRF: config.SSRFConfig{Mode: "off"},
+	}, nil))
+
+	client := tokenoutgoing.NewClient(httpClient, &mockSigner{}, "local.example.com")
+
+	result, err := client.Refresh(context.Background(), tokenoutgoing.RefreshRequest{
+		TokenEndPoint: server.URL,
+		RefreshToken:  "old-refresh",
+	}, httpSigDiscovery())
+	if err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	if result.AccessToken != "new-access" || result.RefreshToken != "new-refresh" || result.ExpiresIn != 600 {
+		t.Errorf("Refresh result = %+v", result)
+	}
+}
+
 // isClassifiedError reports whether err is a ClassifiedError and populates ce.
 func isClassifiedError(err error, ce **reason.ClassifiedError) bool {
 	e := &reason.ClassifiedError{}
@@ -24,12 +24,16 @@ type TokenStore interface {
 	Store(ctx context.Context, token *IssuedToken) error
 	Get(ctx context.Context, accessToken string) (*IssuedToken, error)
 	Delete(ctx context.Context, accessToken string) error
+	// Claim deletes the token for accessToken and returns ErrTokenNotFound
+	// when there was none, so of several concurrent claims exactly one wins.
+	Claim(ctx context.Context, accessToken string) error
+	DeleteByShareID(ctx context.Context, shareID string) error
 	CleanExpired(ctx context.Context) error
 }
 
 // MemoryTokenStore holds exchanged bearer tokens in process memory only.
-// Tokens are intentionally ephemeral: they are not wired through repos.New and
-// do not survive restart.
+// Tokens do not survive restart and are not shared between replicas; the
+// sqlite and redis drivers in platform/tokenstore cover both.
 type MemoryTokenStore struct {
 	mu     sync.RWMutex
 	tokens map[string]*IssuedToken
@@ -78,6 +82,35 @@ func (s *MemoryTokenStore) Delete(_ context.Context, accessToken string) error {
 	return nil
 }
 
+// Claim removes the token for accessToken, reporting ErrTokenNotFound when it
+// was already gone; implements TokenStore.
+func (s *MemoryTokenStore) Claim(_ context.Context, accessToken string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tokens[accessToken]; !ok {
+		return ErrTokenNotFound
+	}
+
+	delete(s.tokens, accessToken)
+
+	return nil
+}
+
+// DeleteByShareID removes every token issued for shareID; implements TokenStore.
+func (s *MemoryTokenStore) DeleteByShareID(_ context.Context, shareID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, v := range s.tokens {
+		if v.ShareID == shareID {
+			delete(s.tokens, k)
+		}
+	}
+
+	return nil
+}
+
 // CleanExpired removes all tokens past their ExpiresAt; implements TokenStore.
 func (s *MemoryTokenStore) CleanExpired(_ context.Context) error {
 	s.mu.Lock()
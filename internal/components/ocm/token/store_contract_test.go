@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package token_test
+
+import (
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	tstokenstore "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/tokenstore"
+)
+
+func TestMemoryTokenStore_Contract(t *testing.T) {
+	t.Parallel()
+
+	tstokenstore.RunContract(t, func(*testing.T) token.TokenStore {
+		return token.NewMemoryTokenStore()
+	})
+}
@@ -60,3 +60,35 @@ func TestMemoryTokenStore_CleanExpired(t *testing.T) {
 		t.Errorf("Get(live).ShareID = %q, want %q", got.ShareID, live.ShareID)
 	}
 }
+
+func TestMemoryTokenStore_DeleteByShareID(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+	expiresAt := time.Now().Add(time.Hour)
+
+	for _, tok := range []*IssuedToken{
+		{AccessToken: "a1", ShareID: "share-a", ExpiresAt: expiresAt},
+		{AccessToken: "a2", ShareID: "share-a", ExpiresAt: expiresAt},
+		{AccessToken: "b1", ShareID: "share-b", ExpiresAt: expiresAt},
+	} {
+		if err := store.Store(ctx, tok); err != nil {
+			t.Fatalf("Store(%s): %v", tok.AccessToken, err)
+		}
+	}
+
+	if err := store.DeleteByShareID(ctx, "share-a"); err != nil {
+		t.Fatalf("DeleteByShareID: %v", err)
+	}
+
+	for _, accessToken := range []string{"a1", "a2"} {
+		if _, err := store.Get(ctx, accessToken); !errors.Is(err, ErrTokenNotFound) {
+			t.Errorf("Get(%s) = %v, want ErrTokenNotFound", accessToken, err)
+		}
+	}
+
+	if _, err := store.Get(ctx, "b1"); err != nil {
+		t.Errorf("Get(b1): %v, want token for another share to survive", err)
+	}
+}
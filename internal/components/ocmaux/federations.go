@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package ocmaux
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/directoryservice"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/reason"
+)
+
+// Federations enrichment defaults.
+const (
+	DefaultFederationsEnrichConcurrency    = 8
+	DefaultFederationsEnrichTimeoutSeconds = 5
+	DefaultFederationsMaxAgeSeconds        = 300
+)
+
+// federationsCacheControl lets browsers and proxies reuse a response briefly
+// and revalidate it with the ETag afterwards.
+const federationsCacheControl = "public, max-age=60"
+
+// federationsBuildKey is the singleflight key for snapshot builds.
+const federationsBuildKey = "federations"
+
+// FederationsSettings tunes /ocm-aux/federations discovery enrichment.
+// Implements cfg.Setter for ApplyDefaults().
+type FederationsSettings struct {
+	// EnrichConcurrency bounds the discovery calls in flight per build.
+	EnrichConcurrency int `mapstructure:"enrich_concurrency"`
+	// EnrichTimeoutSeconds is the deadline for one server's discovery call.
+	EnrichTimeoutSeconds int `mapstructure:"enrich_timeout_seconds"`
+	// MaxAgeSeconds is how old the snapshot may get before a request
+	// triggers a background rebuild.
+	MaxAgeSeconds int `mapstructure:"max_age_seconds"`
+}
+
+// DefaultFederationsSettings returns the enrichment defaults.
+func DefaultFederationsSettings() FederationsSettings {
+	s := FederationsSettings{}
+	s.ApplyDefaults()
+
+	return s
+}
+
+// ApplyDefaults sets default values. Called by cfg.Decode().
+func (s *FederationsSettings) ApplyDefaults() {
+	if s.EnrichConcurrency == 0 {
+		s.EnrichConcurrency = DefaultFederationsEnrichConcurrency
+	}
+
+	if s.EnrichTimeoutSeconds == 0 {
+		s.EnrichTimeoutSeconds = DefaultFederationsEnrichTimeoutSeconds
+	}
+
+	if s.MaxAgeSeconds == 0 {
+		s.MaxAgeSeconds = DefaultFederationsMaxAgeSeconds
+	}
+}
+
+// Validate rejects non-positive values.
+func (s *FederationsSettings) Validate() error {
+	if s.EnrichConcurrency < 1 {
+		return errors.New("federations.enrich_concurrency must be positive")
+	}
+
+	if s.EnrichTimeoutSeconds < 1 {
+		return errors.New("federations.enrich_timeout_seconds must be positive")
+	}
+
+	if s.MaxAgeSeconds < 1 {
+		return errors.New("federations.max_age_seconds must be positive")
+	}
+
+	return nil
+}
+
+func (s *FederationsSettings) maxAge() time.Duration {
+	return time.Duration(s.MaxAgeSeconds) * time.Second
+}
+
+func (s *FederationsSettings) enrichTimeout() time.Duration {
+	return time.Duration(s.EnrichTimeoutSeconds) * time.Second
+}
+
+// federationsSnapshot is an encoded /ocm-aux/federations response.
+type federationsSnapshot struct {
+	body       []byte
+	etag       string
+	builtAt    time.Time
+	generation uint64
+}
+
+// listingsChanged is the OnListingsChanged listener: it marks snapshots built
+// so far as stale and rebuilds in the background.
+func (h *AuxHandler) listingsChanged() {
+	h.listingsGen.Add(1)
+	h.refreshFederations()
+}
+
+// refreshFederations rebuilds the snapshot in the background. Concurrent
+// calls share one build.
+func (h *AuxHandler) refreshFederations() {
+	//nolint:contextcheck // background rebuild outlives the request or refresh that triggered it
+	go h.buildFederations(context.Background())
+}
+
+// buildFederations builds and stores a new snapshot, or waits for the build
+// already in flight. A build in flight that started before the latest
+// listings change is waited out and followed by another, so the change is
+// never lost. The build ignores ctx cancellation so a client that goes away
+// does not leave a snapshot of timed-out rows.
+func (h *AuxHandler) buildFederations(ctx context.Context) *federationsSnapshot {
+	ctx = context.WithoutCancel(ctx)
+	want := h.listingsGen.Load()
+
+	for {
+		v, _, _ := h.builds.Do(federationsBuildKey, func() (any, error) {
+			generation := h.listingsGen.Load()
+
+			snap := h.encodeFederations(h.collectFederations(ctx))
+			snap.generation = generation
+			h.federations.Store(snap)
+
+			return snap, nil
+		})
+
+		snap, _ := v.(*federationsSnapshot)
+		if snap == nil || snap.generation >= want {
+			return snap
+		}
+	}
+}
+
+// collectFederations merges the cached listings by federation name and
+// enriches every server with OCM discovery.
+func (h *AuxHandler) collectFederations(ctx context.Context) []federationEntry {
+	result := []federationEntry{}
+
+	if h.trustGroupMgr == nil {
+		return result
+	}
+
+	listings := h.trustGroupMgr.GetDirectoryListings(ctx)
+
+	merged := make(map[string]*federationEntry)
+
+	var order []string
+
+	for _, listing := range listings {
+		entry, exists := merged[listing.Federation]
+		if !exists {
+			entry = &federationEntry{Federation: listing.Federation, Servers: []serverEntry{}}
+			merged[listing.Federation] = entry
+			order = append(order, listing.Federation)
+		}
+
+		entry.Servers = append(entry.Servers, h.enrichServers(ctx, listing)...)
+	}
+
+	for _, name := range order {
+		result = append(result, *merged[name])
+	}
+
+	return result
+}
+
+// enrichServers runs discovery for the servers of one listing, at most
+// EnrichConcurrency at a time and each under its own deadline. Rows keep
+// the listing order.
+func (h *AuxHandler) enrichServers(ctx context.Context, listing directoryservice.Listing) []serverEntry {
+	rows := make([]serverEntry, len(listing.Servers))
+
+	for i, srv := range listing.Servers {
+		rows[i] = serverEntry{DisplayName: srv.DisplayName, URL: srv.URL}
+	}
+
+	if h.discoveryClient == nil {
+		return rows
+	}
+
+	var g errgroup.Group
+
+	g.SetLimit(h.settings.EnrichConcurrency)
+
+	for i := range rows {
+		g.Go(func() error {
+			h.enrichServer(ctx, listing.Federation, &rows[i])
+
+			return nil
+		})
+	}
+
+	_ = g.Wait() // enrichServer records failures on the row; it never returns an error
+
+	return rows
+}
+
+func (h *AuxHandler) enrichServer(ctx context.Context, federation string, se *serverEntry) {
+	ctx, cancel := context.WithTimeout(ctx, h.settings.enrichTimeout())
+	defer cancel()
+
+	disc, err := h.discoveryClient.Discover(ctx, se.URL)
+	if err != nil {
+		_, reasonCode, _ := classifyDiscoverError(err)
+		if reasonCode == "" {
+			reasonCode = reason.PeerDiscoveryFailed
+		}
+
+		se.Status = &serverEnrichmentStatus{
+			Discovery:  discoveryEnrichmentFailed,
+			ReasonCode: reasonCode,
+		}
+		h.logger.Debug("discovery enrichment failed, keeping server with status",
+			"federation", federation,
+			"server_url", se.URL,
+			"reason_code", reasonCode,
+			"error", err,
+		)
+
+		return
+	}
+
+	if disc.InviteAcceptDialog != "" {
+		se.InviteAcceptDialog = resolveInviteDialog(se.URL, disc.InviteAcceptDialog)
+	}
+}
+
+func (h *AuxHandler) encodeFederations(result []federationEntry) *federationsSnapshot {
+	body, err := json.Marshal(result)
+	if err != nil {
+		// federationEntry holds only strings; encoding cannot fail.
+		h.logger.Error("failed to encode federations", "error", err)
+
+		body = []byte("[]")
+	}
+
+	body = append(body, '\n')
+	sum := sha256.Sum256(body)
+
+	return &federationsSnapshot{
+		body:    body,
+		etag:    `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`,
+		builtAt: h.now(),
+	}
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using
+// the weak comparison RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
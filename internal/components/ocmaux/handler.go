@@ -15,6 +15,10 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
@@ -29,17 +33,46 @@ type AuxHandler struct {
 	trustGroupMgr   *peertrust.TrustGroupManager
 	discoveryClient *discovery.Client
 	logger          *slog.Logger
+	settings        FederationsSettings
+	now             func() time.Time
+
+	federations atomic.Pointer[federationsSnapshot]
+	builds      singleflight.Group
+	// listingsGen counts listings changes; a snapshot records the count it
+	// was built from so a build that started before a change is not reused.
+	listingsGen atomic.Uint64
 }
 
-// NewAuxHandler builds an auxiliary handler.
+// NewAuxHandler builds an auxiliary handler with default
+// FederationsSettings.
 func NewAuxHandler(trustGroupMgr *peertrust.TrustGroupManager, discClient *discovery.Client, logger *slog.Logger) *AuxHandler {
+	return NewAuxHandlerWithSettings(trustGroupMgr, discClient, DefaultFederationsSettings(), logger)
+}
+
+// NewAuxHandlerWithSettings builds an auxiliary handler with explicit
+// federations enrichment settings. The federations snapshot is rebuilt
+// whenever trustGroupMgr reports changed listings.
+func NewAuxHandlerWithSettings(
+	trustGroupMgr *peertrust.TrustGroupManager,
+	discClient *discovery.Client,
+	settings FederationsSettings,
+	logger *slog.Logger,
+) *AuxHandler {
 	logger = logutil.NoopIfNil(logger)
 
-	return &AuxHandler{
+	h := &AuxHandler{
 		trustGroupMgr:   trustGroupMgr,
 		discoveryClient: discClient,
 		logger:          logger,
+		settings:        settings,
+		now:             time.Now,
 	}
+
+	if trustGroupMgr != nil {
+		trustGroupMgr.OnListingsChanged(h.listingsChanged)
+	}
+
+	return h
 }
 
 // federationEntry is a single trust group in the /ocm-aux/federations response (Reva-aligned).
@@ -64,7 +97,12 @@ type serverEnrichmentStatus struct {
 
 const discoveryEnrichmentFailed = "failed"
 
-// HandleFederations serves GET /ocm-aux/federations (Reva-aligned, discovery-enriched).
+// HandleFederations serves GET /ocm-aux/federations (Reva-aligned,
+// discovery-enriched) from the enrichment snapshot. The first request builds
+// it; afterwards it is rebuilt in the background when it is older than
+// max_age_seconds or the trust group listings change, so a slow or down
+// peer never delays the response. The ETag lets clients revalidate with
+// If-None-Match.
 func (h *AuxHandler) HandleFederations(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -72,72 +110,26 @@ func (h *AuxHandler) HandleFederations(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := r.Context()
+	snap := h.federations.Load()
+	if snap == nil {
+		snap = h.buildFederations(r.Context())
+	} else if h.now().Sub(snap.builtAt) > h.settings.maxAge() {
+		h.refreshFederations()
+	}
 
-	result := []federationEntry{}
-
-	if h.trustGroupMgr != nil {
-		listings := h.trustGroupMgr.GetDirectoryListings(ctx)
-
-		merged := make(map[string]*federationEntry)
-
-		var order []string
-
-		for _, listing := range listings {
-			entry, exists := merged[listing.Federation]
-			if !exists {
-				entry = &federationEntry{Federation: listing.Federation}
-				merged[listing.Federation] = entry
-				order = append(order, listing.Federation)
-			}
-
-			for _, srv := range listing.Servers {
-				se := serverEntry{
-					DisplayName: srv.DisplayName,
-					URL:         srv.URL,
-				}
-
-				if h.discoveryClient != nil {
-					disc, err := h.discoveryClient.Discover(ctx, srv.URL)
-					if err != nil {
-						_, reasonCode, _ := classifyDiscoverError(err)
-						if reasonCode == "" {
-							reasonCode = reason.PeerDiscoveryFailed
-						}
-
-						se.Status = &serverEnrichmentStatus{
-							Discovery:  discoveryEnrichmentFailed,
-							ReasonCode: reasonCode,
-						}
-						h.logger.Debug("discovery enrichment failed, keeping server with status",
-							"federation", listing.Federation,
-							"server_url", srv.URL,
-							"reason_code", reasonCode,
-							"error", err,
-						)
-					} else if disc.InviteAcceptDialog != "" {
-						se.InviteAcceptDialog = resolveInviteDialog(srv.URL, disc.InviteAcceptDialog)
-					}
-				}
-
-				entry.Servers = append(entry.Servers, se)
-			}
-		}
+	w.Header().Set("Cache-Control", federationsCacheControl)
+	w.Header().Set("ETag", snap.etag)
 
-		for _, name := range order {
-			entry := merged[name]
-			if entry.Servers == nil {
-				entry.Servers = []serverEntry{}
-			}
+	if etagMatches(r.Header.Get("If-None-Match"), snap.etag) {
+		w.WriteHeader(http.StatusNotModified)
 
-			result = append(result, *entry)
-		}
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 
-	if err := json.NewEncoder(w).Encode(result); err != nil {
-		h.logger.Error("failed to encode federations", "error", err)
+	if _, err := w.Write(snap.body); err != nil {
+		h.logger.Debug("failed to write federations", "error", err)
 	}
 }
 
@@ -10,6 +10,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -329,3 +333,204 @@ func TestHandleFederations_MethodNotAllowed(t *testing.T) {
 		t.Errorf("expected 405, got %d", w.Code)
 	}
 }
+
+// federationsTestManager returns a trust group manager whose tg1 listing holds
+// servers under federation "TestFed".
+func federationsTestManager(servers ...directoryservice.Server) *peertrust.TrustGroupManager {
+	mgr := peertrust.NewTrustGroupManager(peertrust.DefaultCacheConfig(), nil, "https", testLogger(), 10*time.Second)
+	mgr.AddTrustGroup(&peertrust.TrustGroupConfig{TrustGroupID: "tg1", Enabled: true})
+	mgr.SetCacheForTesting("tg1", []directoryservice.Listing{{Federation: "TestFed", Servers: servers}}, time.Now())
+
+	return mgr
+}
+
+func getFederations(h *ocmaux.AuxHandler, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/federations", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+
+	w := httptest.NewRecorder()
+	h.HandleFederations(w, req)
+
+	return w
+}
+
+func TestHandleFederations_ServesSnapshotWithETag(t *testing.T) {
+	t.Parallel()
+
+	var hits atomic.Int32
+
+	discServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer discServer.Close()
+
+	discClient := discovery.NewClient(httpclient.New(tshttp.PermissiveConfig(), nil), nil)
+	h := ocmaux.NewAuxHandler(federationsTestManager(directoryservice.Server{URL: discServer.URL, DisplayName: "S"}), discClient, testLogger())
+
+	first := getFederations(h, "")
+
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("first: status %d, ETag %q", first.Code, etag)
+	}
+
+	if cc := first.Header().Get("Cache-Control"); !strings.Contains(cc, "max-age=") {
+		t.Errorf("Cache-Control = %q, want a max-age", cc)
+	}
+
+	second := getFederations(h, "")
+	if second.Body.String() != first.Body.String() || second.Header().Get("ETag") != etag {
+		t.Error("second response differs from the snapshot")
+	}
+
+	if n := hits.Load(); n != 1 {
+		t.Errorf("discovery calls = %d, want 1: a fresh snapshot must not re-run discovery", n)
+	}
+
+	notModified := getFederations(h, `"other", W/`+etag)
+	if notModified.Code != http.StatusNotModified || notModified.Body.Len() != 0 {
+		t.Errorf("If-None-Match: status %d, body %q; want 304 and no body", notModified.Code, notModified.Body.String())
+	}
+}
+
+func TestHandleFederations_BoundedParallelEnrichmentWithDeadline(t *testing.T) {
+	t.Parallel()
+
+	// Every server hangs past the per-server deadline.
+	release := make(chan struct{})
+	discServer := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer discServer.Close()
+	defer close(release)
+
+	servers := make([]directoryservice.Server, 6)
+	for i := range servers {
+		servers[i] = directoryservice.Server{URL: discServer.URL + "/s" + strconv.Itoa(i), DisplayName: "S" + strconv.Itoa(i)}
+	}
+
+	discClient := discovery.NewClient(httpclient.New(tshttp.PermissiveConfig(), nil), nil)
+	settings := ocmaux.FederationsSettings{EnrichConcurrency: 3, EnrichTimeoutSeconds: 1, MaxAgeSeconds: 300}
+	h := ocmaux.NewAuxHandlerWithSettings(federationsTestManager(servers...), discClient, settings, testLogger())
+
+	start := time.Now()
+	w := getFederations(h, "")
+	elapsed := time.Since(start)
+
+	// Serial enrichment would take six deadlines; three at a time take two.
+	if elapsed > 4*time.Second {
+		t.Errorf("enrichment took %v, want about two per-server deadlines", elapsed)
+	}
+
+	var result []struct {
+		Servers []struct {
+			DisplayName string `json:"displayName"`
+			Status      *struct {
+				Discovery string `json:"discovery"`
+			} `json:"status"`
+		} `json:"servers"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if len(result) != 1 || len(result[0].Servers) != len(servers) {
+		t.Fatalf("result = %+v, want one federation with %d servers", result, len(servers))
+	}
+
+	for i, srv := range result[0].Servers {
+		if srv.DisplayName != servers[i].DisplayName || srv.Status == nil || srv.Status.Discovery != "failed" {
+			t.Errorf("row %d = %+v, want %s in listing order with a failed status", i, srv, servers[i].DisplayName)
+		}
+	}
+}
+
+func TestHandleFederations_RebuildsWhenListingsChange(t *testing.T) {
+	t.Parallel()
+
+	mgr := federationsTestManager(directoryservice.Server{URL: "https://one.example.com", DisplayName: "One"})
+	h := ocmaux.NewAuxHandler(mgr, nil, testLogger())
+
+	before := getFederations(h, "")
+
+	mgr.SetCacheForTesting("tg1", []directoryservice.Listing{{
+		Federation: "TestFed",
+		Servers:    []directoryservice.Server{{URL: "https://two.example.com", DisplayName: "Two"}},
+	}}, time.Now())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		w := getFederations(h, before.Header().Get("ETag"))
+		if w.Code == http.StatusOK && strings.Contains(w.Body.String(), "two.example.com") {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("snapshot not rebuilt after the listings changed: status %d, body %q", w.Code, w.Body.String())
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHandleFederations_ChangeDuringBuildIsNotLost(t *testing.T) {
+	t.Parallel()
+
+	// The first discovery call holds the first build in flight until release.
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var first sync.Once
+
+	discServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		first.Do(func() {
+			close(started)
+			<-release
+		})
+
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer discServer.Close()
+
+	mgr := federationsTestManager(directoryservice.Server{URL: discServer.URL + "/one", DisplayName: "One"})
+	discClient := discovery.NewClient(httpclient.New(tshttp.PermissiveConfig(), nil), nil)
+	h := ocmaux.NewAuxHandler(mgr, discClient, testLogger())
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		getFederations(h, "")
+	}()
+
+	<-started
+
+	mgr.SetCacheForTesting("tg1", []directoryservice.Listing{{
+		Federation: "TestFed",
+		Servers:    []directoryservice.Server{{URL: discServer.URL + "/two", DisplayName: "Two"}},
+	}}, time.Now())
+
+	close(release)
+	<-done
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		w := getFederations(h, "")
+		if strings.Contains(w.Body.String(), "/two") {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("listings change during a build was lost: body %q", w.Body.String())
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,314 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sftp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+)
+
+// shareFS serves one share read-only. The SFTP root "/" is virtual and
+// holds a single entry named after the share; "/{name}" is the shared file
+// or folder, and paths below a shared folder are confined with os.Root.
+// The share is reloaded on every request so a revocation ends access to an
+// open session.
+type shareFS struct {
+	server   *Server
+	webdavID string
+}
+
+// Fileread opens a file for reading.
+func (fs *shareFS) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	share, err := fs.share()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := fs.open(share, r.Filepath)
+	if err != nil {
+		return nil, fs.clientError(r, err)
+	}
+
+	return f, nil
+}
+
+func (fs *shareFS) open(share *sharesoutgoing.OutgoingShare, name string) (*os.File, error) {
+	rel, ok := shareRelPath(share, name)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	if rel == "" {
+		if share.ResourceType == spec.ResourceTypeFolder {
+			return nil, errors.New("sftp: cannot read a folder")
+		}
+
+		//nolint:gosec // LocalPath is repository-controlled via the authenticated webdavId, not request-derived input
+		f, err := os.Open(share.LocalPath)
+		if err != nil {
+			return nil, fmt.Errorf("sftp: open shared file: %w", err)
+		}
+
+		return f, nil
+	}
+
+	root, err := os.OpenRoot(share.LocalPath)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: open share root: %w", err)
+	}
+	defer func() {
+		//nolint:errcheck // files opened through root stay valid after Close
+		root.Close()
+	}()
+
+	f, err := root.Open(rel)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: open %s: %w", name, err)
+	}
+
+	return f, nil
+}
+
+// Filewrite refuses every write: shares are served read-only.
+func (fs *shareFS) Filewrite(_ *sftp.Request) (io.WriterAt, error) {
+	return nil, sftp.ErrSSHFxPermissionDenied
+}
+
+// Filecmd refuses every mutation (setstat, rename, remove, mkdir, ...).
+func (fs *shareFS) Filecmd(_ *sftp.Request) error {
+	return sftp.ErrSSHFxPermissionDenied
+}
+
+// Filelist answers List and Stat. Readlink is unsupported.
+func (fs *shareFS) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	share, err := fs.share()
+	if err != nil {
+		return nil, err
+	}
+
+	var lister sftp.ListerAt
+
+	switch r.Method {
+	case "List":
+		lister, err = fs.list(share, r.Filepath)
+	case "Stat":
+		var info os.FileInfo
+
+		info, err = fs.stat(share, r.Filepath)
+		lister = listerAt{info}
+	default:
+		return nil, sftp.ErrSSHFxOpUnsupported
+	}
+
+	if err != nil {
+		return nil, fs.clientError(r, err)
+	}
+
+	return lister, nil
+}
+
+// clientError logs err and returns the status the client sees: "no such
+// file" for missing paths and a generic failure otherwise, so server paths
+// never reach the client.
+func (fs *shareFS) clientError(r *sftp.Request, err error) error {
+	fs.server.logger.Debug("SFTP request failed",
+		"webdav_id", fs.webdavID, "method", r.Method, "path", r.Filepath, "error", err)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return os.ErrNotExist
+	}
+
+	return sftp.ErrSSHFxFailure
+}
+
+func (fs *shareFS) share() (*sharesoutgoing.OutgoingShare, error) {
+	share, err := fs.server.loadShare(fs.webdavID)
+	if err != nil {
+		fs.server.logger.Debug("SFTP request refused", "webdav_id", fs.webdavID, "error", err)
+
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	return share, nil
+}
+
+func (fs *shareFS) stat(share *sharesoutgoing.OutgoingShare, name string) (os.FileInfo, error) {
+	if path.Clean("/"+name) == "/" {
+		return virtualRoot{modTime: share.CreatedAt}, nil
+	}
+
+	rel, ok := shareRelPath(share, name)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	if rel == "" {
+		//nolint:gosec // LocalPath is repository-controlled via the authenticated webdavId, not request-derived input
+		info, err := os.Stat(share.LocalPath)
+		if err != nil {
+			return nil, fmt.Errorf("sftp: stat shared resource: %w", err)
+		}
+
+		return namedInfo{FileInfo: info, name: share.Name}, nil
+	}
+
+	root, err := os.OpenRoot(share.LocalPath)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: open share root: %w", err)
+	}
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		root.Close()
+	}()
+
+	info, err := root.Stat(rel)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: stat %s: %w", name, err)
+	}
+
+	return info, nil
+}
+
+func (fs *shareFS) list(share *sharesoutgoing.OutgoingShare, name string) (sftp.ListerAt, error) {
+	info, err := fs.stat(share, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return listerAt{info}, nil
+	}
+
+	if path.Clean("/"+name) == "/" {
+		entry, err := fs.stat(share, "/"+share.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return listerAt{entry}, nil
+	}
+
+	rel, _ := shareRelPath(share, name)
+	if rel == "" {
+		rel = "."
+	}
+
+	root, err := os.OpenRoot(share.LocalPath)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: open share root: %w", err)
+	}
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		root.Close()
+	}()
+
+	dir, err := root.Open(rel)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: open %s: %w", name, err)
+	}
+	defer func() {
+		//nolint:errcheck // read-only handle; close errors are not actionable
+		dir.Close()
+	}()
+
+	entries, err := dir.ReadDir(-1)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: list %s: %w", name, err)
+	}
+
+	infos := make(listerAt, 0, len(entries))
+
+	for _, entry := range entries {
+		entryInfo, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		infos = append(infos, entryInfo)
+	}
+
+	return infos, nil
+}
+
+// shareRelPath maps an SFTP path to a path relative to the shared resource:
+// "" for the resource itself, and a slash-separated path below a folder.
+// It reports false for paths outside "/{share.Name}" and for paths below a
+// file share.
+func shareRelPath(share *sharesoutgoing.OutgoingShare, name string) (string, bool) {
+	clean := path.Clean("/" + name)
+	prefix := "/" + share.Name
+
+	if clean == prefix {
+		return "", true
+	}
+
+	rest, ok := strings.CutPrefix(clean, prefix+"/")
+	if !ok || share.ResourceType != spec.ResourceTypeFolder {
+		return "", false
+	}
+
+	return rest, true
+}
+
+// listerAt serves a fixed slice of FileInfo to the request server.
+type listerAt []os.FileInfo
+
+// ListAt copies entries starting at offset into ls.
+func (l listerAt) ListAt(ls []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+
+	n := copy(ls, l[offset:])
+	if n < len(ls) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// namedInfo reports the share name instead of the on-disk base name.
+type namedInfo struct {
+	os.FileInfo
+
+	name string
+}
+
+// Name returns the share name.
+func (i namedInfo) Name() string { return i.name }
+
+// virtualRoot is the FileInfo of the virtual "/" directory.
+type virtualRoot struct {
+	modTime time.Time
+}
+
+// Name returns "/".
+func (virtualRoot) Name() string { return "/" }
+
+// Size returns 0.
+func (virtualRoot) Size() int64 { return 0 }
+
+// Mode reports a read-only directory.
+func (virtualRoot) Mode() os.FileMode { return os.ModeDir | 0o555 }
+
+// ModTime returns the share creation time.
+func (v virtualRoot) ModTime() time.Time { return v.modTime }
+
+// IsDir returns true.
+func (virtualRoot) IsDir() bool { return true }
+
+// Sys returns nil.
+func (virtualRoot) Sys() any { return nil }
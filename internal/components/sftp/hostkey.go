@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sftp
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// LoadOrCreateHostKey loads the Ed25519 host key at path (PKCS#8 PEM), or
+// generates and saves one when the file does not exist, so the host key a
+// receiver pins stays the same across restarts.
+func LoadOrCreateHostKey(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return parseHostKey(data)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("sftp: read host key: %w", err)
+	}
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: generate host key: %w", err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: marshal host key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("sftp: create host key directory: %w", err)
+	}
+
+	data = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return nil, fmt.Errorf("sftp: write host key: %w", err)
+	}
+
+	return parseHostKey(data)
+}
+
+func parseHostKey(data []byte) (ssh.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("sftp: host key: no PEM block found")
+	}
+
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: parse host key: %w", err)
+	}
+
+	edPriv, ok := priv.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("sftp: host key is not an Ed25519 private key")
+	}
+
+	signer, err := ssh.NewSignerFromKey(edPriv)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: host key signer: %w", err)
+	}
+
+	return signer, nil
+}
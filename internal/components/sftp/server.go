@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package sftp serves the OCM ssh protocol arm: a built-in, read-only SFTP
+// server. The SSH username is the share's webdavId and only the public keys
+// the receiver returned as recipientPublicKeys may log in. Each connection
+// sees a virtual root holding the one shared file or folder.
+package sftp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+const (
+	// handshakeTimeout bounds the SSH handshake, including authentication.
+	handshakeTimeout = 30 * time.Second
+	// lookupTimeout bounds each share repository lookup.
+	lookupTimeout = 10 * time.Second
+	// webdavIDExtension carries the authenticated webdavId in ssh.Permissions.
+	webdavIDExtension = "ocm-webdav-id"
+)
+
+var errAccessDenied = errors.New("sftp: access denied")
+
+// Server is the built-in SFTP server for outgoing ssh shares.
+type Server struct {
+	repo   sharesoutgoing.OutgoingShareRepo
+	config *ssh.ServerConfig
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	closed    bool
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	wg        sync.WaitGroup
+}
+
+// NewServer builds a Server that authenticates against repo and presents
+// hostKey to clients.
+func NewServer(repo sharesoutgoing.OutgoingShareRepo, hostKey ssh.Signer, logger *slog.Logger) *Server {
+	s := &Server{
+		repo:      repo,
+		logger:    logutil.NoopIfNil(logger),
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+
+	s.config = &ssh.ServerConfig{
+		PublicKeyCallback: s.authenticate,
+		ServerVersion:     "SSH-2.0-OpenCloudMesh",
+	}
+	s.config.AddHostKey(hostKey)
+
+	return s
+}
+
+// Serve accepts connections on ln until Close. It returns nil after Close
+// and the accept error otherwise.
+func (s *Server) Serve(ln net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+
+		return net.ErrClosed
+	}
+
+	s.listeners[ln] = struct{}{}
+	s.mu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			delete(s.listeners, ln)
+			s.mu.Unlock()
+
+			if closed {
+				return nil
+			}
+
+			return fmt.Errorf("sftp: accept: %w", err)
+		}
+
+		if !s.track(conn) {
+			//nolint:errcheck // best-effort cleanup; server is shutting down
+			conn.Close()
+
+			return nil
+		}
+
+		go s.handleConn(conn)
+	}
+}
+
+// Close stops every listener, drops open connections, and waits for their
+// handlers to return.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+
+	var errs []error
+
+	for ln := range s.listeners {
+		if err := ln.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = append(errs, err)
+		}
+	}
+
+	for conn := range s.conns {
+		//nolint:errcheck // best-effort cleanup; the connection is being dropped
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// track registers conn unless the server is closed.
+func (s *Server) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+
+	return true
+}
+
+func (s *Server) untrack(conn net.Conn) {
+	s.mu.Lock()
+	delete(s.conns, conn)
+	s.mu.Unlock()
+
+	s.wg.Done()
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer s.untrack(conn)
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		conn.Close()
+	}()
+
+	//nolint:errcheck // a failed deadline surfaces as a handshake error below
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		s.logger.Debug("SFTP handshake failed", "remote", conn.RemoteAddr().String(), "error", err)
+
+		return
+	}
+
+	//nolint:errcheck // clearing the deadline cannot fail on a live connection
+	conn.SetDeadline(time.Time{})
+
+	webdavID := sconn.Permissions.Extensions[webdavIDExtension]
+	s.logger.Info("SFTP session opened", "webdav_id", webdavID, "remote", conn.RemoteAddr().String())
+
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			//nolint:errcheck // the client learns of the rejection; nothing to do on failure
+			newChannel.Reject(ssh.UnknownChannelType, "only session channels are supported")
+
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			s.logger.Debug("SFTP channel accept failed", "webdav_id", webdavID, "error", err)
+
+			continue
+		}
+
+		s.wg.Add(1)
+
+		go s.serveSession(channel, requests, webdavID)
+	}
+}
+
+// serveSession runs the sftp subsystem on channel. Shell, exec, and any
+// other subsystem requests are refused.
+func (s *Server) serveSession(channel ssh.Channel, requests <-chan *ssh.Request, webdavID string) {
+	defer s.wg.Done()
+	defer func() {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		channel.Close()
+	}()
+
+	for req := range requests {
+		if req.Type != "subsystem" || !isSFTPSubsystem(req.Payload) {
+			//nolint:errcheck // reply failures mean the client is gone
+			req.Reply(false, nil)
+
+			continue
+		}
+
+		//nolint:errcheck // reply failures mean the client is gone
+		req.Reply(true, nil)
+
+		go ssh.DiscardRequests(requests)
+
+		fs := &shareFS{server: s, webdavID: webdavID}
+		server := sftp.NewRequestServer(channel, sftp.Handlers{
+			FileGet:  fs,
+			FilePut:  fs,
+			FileCmd:  fs,
+			FileList: fs,
+		})
+
+		if err := server.Serve(); err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Debug("SFTP session ended", "webdav_id", webdavID, "error", err)
+		}
+
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		server.Close()
+
+		return
+	}
+}
+
+// isSFTPSubsystem reports whether a subsystem request payload names "sftp".
+// The payload is an SSH string: a uint32 length followed by the name.
+func isSFTPSubsystem(payload []byte) bool {
+	return len(payload) > 4 && string(payload[4:]) == "sftp"
+}
+
+// authenticate admits a public key when the username is the webdavId of a
+// live ssh share and the key is one of the share's recipientPublicKeys.
+func (s *Server) authenticate(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	webdavID := meta.User()
+
+	share, err := s.loadShare(webdavID)
+	if err != nil {
+		s.logger.Debug("SFTP login rejected", "webdav_id", webdavID, "error", err)
+
+		return nil, errAccessDenied
+	}
+
+	allowed, err := identity.ParseSSHPublicKeys(share.RecipientPublicKeys)
+	if err != nil {
+		s.logger.Debug("SFTP login rejected: share has no recipient keys", "webdav_id", webdavID)
+
+		return nil, errAccessDenied
+	}
+
+	offered := string(key.Marshal())
+	for _, candidate := range allowed {
+		if string(candidate.Marshal()) == offered {
+			return &ssh.Permissions{Extensions: map[string]string{webdavIDExtension: webdavID}}, nil
+		}
+	}
+
+	s.logger.Debug("SFTP login rejected: key not registered", "webdav_id", webdavID)
+
+	return nil, errAccessDenied
+}
+
+// loadShare returns the live ssh share for webdavID. Revoked or expired
+// shares and shares sent without an ssh arm are refused.
+func (s *Server) loadShare(webdavID string) (*sharesoutgoing.OutgoingShare, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+
+	share, err := s.repo.GetByWebDAVID(ctx, webdavID)
+	if err != nil {
+		return nil, fmt.Errorf("sftp: load share: %w", err)
+	}
+
+	if share.Status == shares.OutgoingShareStatusRevoked {
+		return nil, errors.New("sftp: share revoked")
+	}
+
+	if share.Expired(time.Now()) {
+		return nil, errors.New("sftp: share expired")
+	}
+
+	if share.SSHURI == "" {
+		return nil, errors.New("sftp: share has no ssh arm")
+	}
+
+	return share, nil
+}
@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sftp_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	pkgsftp "github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	tscrypto "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/crypto"
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
+	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/sftp"
+)
+
+type sftpFixture struct {
+	repo    sharesoutgoing.OutgoingShareRepo
+	addr    string
+	hostKey ssh.PublicKey
+}
+
+func newSFTPFixture(t *testing.T) *sftpFixture {
+	t.Helper()
+
+	hostKey, err := sftp.LoadOrCreateHostKey(filepath.Join(t.TempDir(), "keys", "ssh_host_ed25519.pem"))
+	if err != nil {
+		t.Fatalf("LoadOrCreateHostKey: %v", err)
+	}
+
+	repo := tsrepos.OpenMemory(t).OutgoingShares
+	server := sftp.NewServer(repo, hostKey, nil)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	done := make(chan error, 1)
+
+	go func() { done <- server.Serve(ln) }()
+
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+
+		if err := <-done; err != nil {
+			t.Errorf("Serve after Close: %v", err)
+		}
+	})
+
+	return &sftpFixture{repo: repo, addr: ln.Addr().String(), hostKey: hostKey.PublicKey()}
+}
+
+// addShare stores a sent ssh share of localPath readable with keys.
+func (f *sftpFixture) addShare(t *testing.T, webdavID, localPath, resourceType string, keys ...string) *sharesoutgoing.OutgoingShare {
+	t.Helper()
+
+	share := &sharesoutgoing.OutgoingShare{
+		ProviderID:          "provider-" + webdavID,
+		WebDAVID:            webdavID,
+		SharedSecret:        "secret-" + webdavID,
+		LocalPath:           localPath,
+		ReceiverHost:        "receiver.example.org",
+		ShareWith:           "bob@receiver.example.org",
+		Name:                filepath.Base(localPath),
+		ResourceType:        resourceType,
+		ShareType:           spec.ShareTypeUser,
+		Permissions:         []string{"read"},
+		Status:              shares.OutgoingShareStatusSent,
+		SSHURI:              webdavID + "@" + f.addr + "/" + filepath.Base(localPath),
+		RecipientPublicKeys: keys,
+	}
+	if err := f.repo.Create(context.Background(), share); err != nil {
+		t.Fatalf("Create share: %v", err)
+	}
+
+	return share
+}
+
+func (f *sftpFixture) dial(t *testing.T, user string, key tscrypto.SSHKey) (*pkgsftp.Client, error) {
+	t.Helper()
+
+	conn, err := ssh.Dial("tcp", f.addr, &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(key.Signer)},
+		HostKeyCallback: ssh.FixedHostKey(f.hostKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := pkgsftp.NewClient(conn)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	t.Cleanup(func() {
+		client.Close()
+		conn.Close()
+	})
+
+	return client, nil
+}
+
+func readRemote(t *testing.T, client *pkgsftp.Client, name string) (string, error) {
+	t.Helper()
+
+	f, err := client.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+
+	return string(data), err
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestServer_FileShareReadOnly(t *testing.T) {
+	t.Parallel()
+
+	f := newSFTPFixture(t)
+	key := tscrypto.MustSSHKey(t)
+
+	localPath := filepath.Join(t.TempDir(), "dataset.csv")
+	writeFile(t, localPath, "a,b\n1,2\n")
+	f.addShare(t, "webdav-file", localPath, spec.ResourceTypeFile, key.AuthorizedKey)
+
+	client, err := f.dial(t, "webdav-file", key)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	entries, err := client.ReadDir("/")
+	if err != nil || len(entries) != 1 || entries[0].Name() != "dataset.csv" {
+		t.Fatalf("ReadDir / = %v, %v; want the single shared file", entries, err)
+	}
+
+	got, err := readRemote(t, client, "/dataset.csv")
+	if err != nil || got != "a,b\n1,2\n" {
+		t.Fatalf("read = %q, %v", got, err)
+	}
+
+	if _, err := client.Create("/dataset.csv"); err == nil {
+		t.Error("expected write to be denied")
+	}
+
+	if err := client.Remove("/dataset.csv"); err == nil {
+		t.Error("expected remove to be denied")
+	}
+
+	if err := client.Mkdir("/new"); err == nil {
+		t.Error("expected mkdir to be denied")
+	}
+
+	if _, err := readRemote(t, client, "/dataset.csv/x"); err == nil {
+		t.Error("expected paths below a file share to be missing")
+	}
+
+	if data, err := os.ReadFile(localPath); err != nil || string(data) != "a,b\n1,2\n" {
+		t.Fatalf("shared file changed: %q, %v", data, err)
+	}
+}
+
+func TestServer_FolderShareConfinedToRoot(t *testing.T) {
+	t.Parallel()
+
+	f := newSFTPFixture(t)
+	key := tscrypto.MustSSHKey(t)
+
+	base := t.TempDir()
+	writeFile(t, filepath.Join(base, "outside.txt"), "secret")
+
+	folder := filepath.Join(base, "results")
+	if err := os.MkdirAll(filepath.Join(folder, "run1"), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	writeFile(t, filepath.Join(folder, "run1", "out.txt"), "42")
+
+	if err := os.Symlink(filepath.Join(base, "outside.txt"), filepath.Join(folder, "escape")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	f.addShare(t, "webdav-folder", folder, spec.ResourceTypeFolder, key.AuthorizedKey)
+
+	client, err := f.dial(t, "webdav-folder", key)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	entries, err := client.ReadDir("/results")
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+
+	slices.Sort(names)
+
+	if !slices.Equal(names, []string{"escape", "run1"}) {
+		t.Errorf("folder entries = %v", names)
+	}
+
+	if got, err := readRemote(t, client, "/results/run1/out.txt"); err != nil || got != "42" {
+		t.Fatalf("read nested = %q, %v", got, err)
+	}
+
+	for _, name := range []string{"/results/escape", "/results/../outside.txt", "/outside.txt"} {
+		if got, err := readRemote(t, client, name); err == nil {
+			t.Errorf("read %s = %q, want denied", name, got)
+		}
+	}
+}
+
+func TestServer_RejectsUnknownKeysAndUsers(t *testing.T) {
+	t.Parallel()
+
+	f := newSFTPFixture(t)
+	key := tscrypto.MustSSHKey(t)
+	other := tscrypto.MustSSHKey(t)
+
+	localPath := filepath.Join(t.TempDir(), "data.bin")
+	writeFile(t, localPath, "x")
+	f.addShare(t, "webdav-keys", localPath, spec.ResourceTypeFile, key.AuthorizedKey)
+
+	noSSH := f.addShare(t, "webdav-nossh", localPath, spec.ResourceTypeFile, key.AuthorizedKey)
+	noSSH.SSHURI = ""
+
+	if err := f.repo.Update(context.Background(), noSSH); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		user string
+		key  tscrypto.SSHKey
+	}{
+		{"wrong key", "webdav-keys", other},
+		{"unknown share", "webdav-missing", key},
+		{"share without ssh arm", "webdav-nossh", key},
+	}
+
+	for _, tt := range tests {
+		if _, err := f.dial(t, tt.user, tt.key); err == nil {
+			t.Errorf("%s: expected login to fail", tt.name)
+		}
+	}
+}
+
+func TestServer_RevocationEndsAccess(t *testing.T) {
+	t.Parallel()
+
+	f := newSFTPFixture(t)
+	key := tscrypto.MustSSHKey(t)
+
+	localPath := filepath.Join(t.TempDir(), "data.bin")
+	writeFile(t, localPath, "payload")
+	share := f.addShare(t, "webdav-revoke", localPath, spec.ResourceTypeFile, key.AuthorizedKey)
+
+	client, err := f.dial(t, "webdav-revoke", key)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	share.Status = shares.OutgoingShareStatusRevoked
+	if err := f.repo.Update(context.Background(), share); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if _, err := readRemote(t, client, "/data.bin"); !errors.Is(err, os.ErrPermission) {
+		t.Fatalf("read after revoke = %v, want permission denied", err)
+	}
+
+	if _, err := f.dial(t, "webdav-revoke", key); err == nil {
+		t.Fatal("expected login to a revoked share to fail")
+	}
+}
+
+func TestLoadOrCreateHostKey_IsStable(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "keys", "host.pem")
+
+	first, err := sftp.LoadOrCreateHostKey(path)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	second, err := sftp.LoadOrCreateHostKey(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if string(first.PublicKey().Marshal()) != string(second.PublicKey().Marshal()) {
+		t.Fatal("host key changed between loads")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("host key mode = %v, %v; want 0600", info, err)
+	}
+
+	writeFile(t, path, "not a key")
+
+	if _, err := sftp.LoadOrCreateHostKey(path); err == nil {
+		t.Fatal("expected a corrupt host key to fail instead of being replaced")
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package ui_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ui"
+)
+
+func TestOutgoing_ListsAndRevokesShares(t *testing.T) {
+	t.Parallel()
+
+	handler, err := ui.NewHandler("", "alice.example.com")
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/outgoing", nil)
+	w := httptest.NewRecorder()
+	handler.Outgoing(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`id="outgoing-share-list"`,
+		`id="share-status-filter"`,
+		`"api/shares/outgoing?"`,
+		`method: "DELETE"`,
+		"revoke-share",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected outgoing page to include %q", want)
+		}
+	}
+}
@@ -3,7 +3,7 @@
 //
 // OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
 
-// Package ui provides the web UI (login, inbox, outgoing, wayf, accept-invite).
+// Package ui provides the web UI (login, inbox, outgoing, users, wayf, accept-invite).
 package ui
 
 import (
@@ -16,7 +16,7 @@ import (
 //go:embed templates/*.html
 var templateFS embed.FS
 
-// Handler serves UI pages (login, inbox, outgoing, wayf, accept-invite).
+// Handler serves UI pages (login, inbox, outgoing, users, wayf, accept-invite).
 type Handler struct {
 	basePath       string
 	providerDomain string // published provider domain for WAYF invite links
@@ -78,6 +78,18 @@ func (h *Handler) Outgoing(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
+// Users serves the admin user management page. The page itself only needs a
+// session; the /api/admin/users endpoints it calls enforce the admin role.
+func (h *Handler) Users(w http.ResponseWriter, _ *http.Request) {
+	data := TemplateData{BasePath: h.basePath}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if err := h.templates.ExecuteTemplate(w, "users.html", data); err != nil {
+		http.Error(w, "template error", http.StatusInternalServerError)
+	}
+}
+
 // Wayf serves the WAYF provider selection page (pick federation provider for invite).
 func (h *Handler) Wayf(w http.ResponseWriter, r *http.Request) {
 	data := TemplateData{
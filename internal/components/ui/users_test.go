@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package ui_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ui"
+)
+
+func TestUsers_ManagesUsersThroughAdminAPI(t *testing.T) {
+	t.Parallel()
+
+	handler, err := ui.NewHandler("/base", "alice.example.com")
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/users", nil)
+	w := httptest.NewRecorder()
+	handler.Users(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`<base href="/base/" />`,
+		`id="user-create-form"`,
+		`id="user-list"`,
+		`"api/admin/users"`,
+		`"api/admin/users/expire-probes"`,
+		`"/password"`,
+		`"/role"`,
+		"delete-user",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected users page to include %q", want)
+		}
+	}
+
+	if strings.Contains(body, `<option value="super_admin"`) {
+		t.Error("users page must not offer the super_admin role")
+	}
+}
+
+func TestNavigation_UsersLinkHiddenUntilAdmin(t *testing.T) {
+	t.Parallel()
+
+	handler, err := ui.NewHandler("", "alice.example.com")
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+
+	for name, serve := range map[string]http.HandlerFunc{
+		"inbox":    handler.Inbox,
+		"outgoing": handler.Outgoing,
+	} {
+		w := httptest.NewRecorder()
+		serve(w, httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/"+name, nil))
+
+		body := w.Body.String()
+		if !strings.Contains(body, `<a href="ui/users" id="nav-users" style="display: none;">`) {
+			t.Errorf("%s: expected hidden users nav link", name)
+		}
+
+		if !strings.Contains(body, `user.role === "admin" || user.role === "super_admin"`) {
+			t.Errorf("%s: expected users nav link to be revealed for admins", name)
+		}
+	}
+}
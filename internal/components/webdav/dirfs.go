@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package webdav
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/net/webdav"
+)
+
+// dirFS implements webdav.FileSystem for a folder share. Every name is
+// resolved beneath root; names that climb out of root, or that reach outside
+// it through a symlink, are reported as not existing. Errors are always
+// *os.PathError keyed by the WebDAV name: x/net/webdav skips PathErrors while
+// walking a PROPFIND listing, and the name keeps server paths out of logs.
+//
+// Mutations go through os.Root, which confines names that do not exist yet
+// (PUT, MKCOL, MOVE destinations) as well as existing ones.
+type dirFS struct {
+	// root is the share directory with symlinks already resolved.
+	root string
+	// writable enables Mkdir, RemoveAll, Rename, and write-mode OpenFile.
+	writable bool
+}
+
+// newDirFS returns a dirFS rooted at the resolved form of dir.
+func newDirFS(dir string, writable bool) (*dirFS, error) {
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, fmt.Errorf("webdav: resolve share root: %w", err)
+	}
+
+	return &dirFS{root: root, writable: writable}, nil
+}
+
+// resolve maps a WebDAV name to a filesystem path under root. The returned
+// path has symlinks resolved and is guaranteed to be root or a descendant.
+func (fs *dirFS) resolve(name string) (string, error) {
+	rel, err := relName(name)
+	if err != nil {
+		return "", err
+	}
+
+	full := filepath.Join(fs.root, rel)
+
+	resolved, err := filepath.EvalSymlinks(full)
+	if err != nil {
+		return "", pathError("resolve", name, err)
+	}
+
+	if !fs.contains(resolved) {
+		return "", pathError("resolve", name, os.ErrNotExist)
+	}
+
+	return resolved, nil
+}
+
+func (fs *dirFS) contains(p string) bool {
+	return p == fs.root || strings.HasPrefix(p, fs.root+string(os.PathSeparator))
+}
+
+// relName maps a WebDAV name to a path relative to the share root, suitable
+// for os.Root. The share root itself maps to ".".
+func relName(name string) (string, error) {
+	if strings.ContainsRune(name, 0) || strings.Contains(name, "\\") {
+		return "", pathError("resolve", name, os.ErrNotExist)
+	}
+
+	// path.Clean on a rooted name drops every ".." that would climb above "/".
+	rel := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if rel == "" {
+		return ".", nil
+	}
+
+	return filepath.FromSlash(rel), nil
+}
+
+// mutate runs fn against an os.Root opened at the share root. It refuses the
+// operation unless the share is writable, and refuses it on the share root
+// itself unless allowRoot is set.
+func (fs *dirFS) mutate(op, name string, allowRoot bool, fn func(root *os.Root, rel string) error) error {
+	if !fs.writable {
+		return pathError(op, name, os.ErrPermission)
+	}
+
+	rel, err := relName(name)
+	if err != nil {
+		return err
+	}
+
+	if rel == "." && !allowRoot {
+		return pathError(op, name, os.ErrPermission)
+	}
+
+	root, err := os.OpenRoot(fs.root)
+	if err != nil {
+		return pathError(op, name, err)
+	}
+	defer root.Close() //nolint:errcheck // directory handle only; files opened through it stay valid and close separately
+
+	if err := fn(root, rel); err != nil {
+		return pathError(op, name, err)
+	}
+
+	return nil
+}
+
+func (fs *dirFS) Mkdir(_ context.Context, name string, perm os.FileMode) error {
+	return fs.mutate("mkdir", name, false, func(root *os.Root, rel string) error {
+		return root.Mkdir(rel, perm)
+	})
+}
+
+func (fs *dirFS) OpenFile(_ context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if isWriteFlag(flag) {
+		var f *os.File
+
+		err := fs.mutate("open", name, false, func(root *os.Root, rel string) error {
+			var openErr error
+
+			f, openErr = root.OpenFile(rel, flag, perm)
+
+			return openErr
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return f, nil
+	}
+
+	p, err := fs.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:gosec // p is confined to the share root by resolve
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, pathError("open", name, err)
+	}
+
+	return f, nil
+}
+
+func (fs *dirFS) RemoveAll(_ context.Context, name string) error {
+	return fs.mutate("removeall", name, false, func(root *os.Root, rel string) error {
+		return root.RemoveAll(rel)
+	})
+}
+
+func (fs *dirFS) Rename(_ context.Context, oldName, newName string) error {
+	newRel, err := relName(newName)
+	if err != nil {
+		return err
+	}
+
+	if newRel == "." {
+		return pathError("rename", newName, os.ErrPermission)
+	}
+
+	return fs.mutate("rename", oldName, false, func(root *os.Root, rel string) error {
+		return root.Rename(rel, newRel)
+	})
+}
+
+func (fs *dirFS) Stat(_ context.Context, name string) (os.FileInfo, error) {
+	p, err := fs.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, pathError("stat", name, err)
+	}
+
+	return info, nil
+}
+
+// pathError rebuilds err as an *os.PathError for the WebDAV name, keeping the
+// underlying cause (e.g. os.ErrNotExist) visible to errors.Is.
+func pathError(op, name string, err error) error {
+	var pe *os.PathError
+	if errors.As(err, &pe) {
+		err = pe.Err
+	}
+
+	return &os.PathError{Op: op, Path: name, Err: err}
+}
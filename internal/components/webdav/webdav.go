@@ -3,7 +3,9 @@
 //
 // OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
 
-// Package webdav provides WebDAV file serving with OCM Bearer auth and read-only behavior.
+// Package webdav provides WebDAV file and folder serving with OCM Bearer auth.
+// Shares are read-only unless both the share and the presented credential carry
+// the OCM "write" permission.
 package webdav
 
 import (
@@ -14,23 +16,53 @@ import (
 	"mime"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/net/webdav"
 
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
 	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/digest"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
 )
 
-// Handler provides WebDAV access to shared files.
+// webdavPathPrefix is the request path prefix preceding {webdavId}.
+const webdavPathPrefix = "/webdav/ocm/"
+
+// lockIdleTTL is how long a share's lock system outlives its last request.
+// WebDAV clients refresh their locks well within it; the lock systems of
+// shares that were deleted or are no longer used are dropped after it.
+const lockIdleTTL = time.Hour
+
+// lockSweepInterval bounds how often lockSystem scans for idle entries.
+const lockSweepInterval = time.Minute
+
+// Handler provides WebDAV access to shared files and folders.
 type Handler struct {
 	outgoingRepo sharesoutgoing.OutgoingShareRepo
 	tokenStore   token.TokenStore
 	logger       *slog.Logger
+
+	// locks holds one lock system per webdavId so LOCK tokens outlive the
+	// request that created them and never collide across shares. Entries
+	// idle for lockIdleTTL are dropped.
+	locksMu    sync.Mutex
+	locks      map[string]*lockEntry
+	locksSwept time.Time
+	now        func() time.Time
+}
+
+// lockEntry is a share's lock system and when a request last used it.
+type lockEntry struct {
+	ls       webdav.LockSystem
+	lastUsed time.Time
 }
 
 // NewHandler builds a WebDAV handler.
@@ -41,6 +73,8 @@ func NewHandler(outgoingRepo sharesoutgoing.OutgoingShareRepo, tokenStore token.
 		outgoingRepo: outgoingRepo,
 		tokenStore:   tokenStore,
 		logger:       logger,
+		locks:        make(map[string]*lockEntry),
+		now:          time.Now,
 	}
 }
 
@@ -61,13 +95,6 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if isWriteMethod(r.Method) {
-		h.logger.Debug("WebDAV write method rejected", "method", r.Method, "webdav_id", webdavID)
-		http.Error(w, "write operations not supported", http.StatusNotImplemented)
-
-		return
-	}
-
 	cred := extractCredential(r)
 	if cred == nil {
 		h.logger.Debug("WebDAV request missing authorization", "webdav_id", webdavID)
@@ -87,7 +114,23 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authorized := h.validateCredential(r.Context(), share, cred.Token)
+	if share.Status == shares.OutgoingShareStatusRevoked {
+		h.logger.Debug("WebDAV request for revoked share", "webdav_id", webdavID)
+		h.dropLockSystem(webdavID)
+		http.Error(w, "share revoked", http.StatusGone)
+
+		return
+	}
+
+	if share.Expired(time.Now()) {
+		h.logger.Debug("WebDAV request for expired share", "webdav_id", webdavID)
+		h.dropLockSystem(webdavID)
+		http.Error(w, "share expired", http.StatusGone)
+
+		return
+	}
+
+	granted, authorized := h.validateCredential(r.Context(), share, cred.Token)
 	if !authorized {
 		h.logger.Debug("WebDAV invalid credentials", "webdav_id", webdavID)
 		w.Header().Set("WWW-Authenticate", `Bearer realm="OCM WebDAV"`)
@@ -96,31 +139,86 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.Debug("WebDAV authorized", "webdav_id", webdavID)
+	writable := spec.HasWritePermission(share.Permissions) && spec.HasWritePermission(granted)
+	if isWriteMethod(r.Method) && !writable {
+		h.logger.Debug("WebDAV write method rejected on read-only share", "method", r.Method, "webdav_id", webdavID)
+		http.Error(w, "share is read-only", http.StatusForbidden)
+
+		return
+	}
+
+	h.logger.Debug("WebDAV authorized", "webdav_id", webdavID, "writable", writable)
+
+	if share.ResourceType == spec.ResourceTypeFolder {
+		h.serveFolder(w, r, share, writable)
 
-	h.serveFile(w, r, share)
+		return
+	}
+
+	h.serveFile(w, r, share, writable)
 }
 
 // validateCredential validates the token via the token store, or accepts the
-// shared secret as a legacy bearer for non-strict shares.
-func (h *Handler) validateCredential(ctx context.Context, share *sharesoutgoing.OutgoingShare, token string) bool {
+// shared secret as a legacy bearer for non-strict shares. On success it
+// returns the permissions the credential carries: the permissions recorded on
+// the exchanged token, or the share's own permissions for the shared secret.
+func (h *Handler) validateCredential(ctx context.Context, share *sharesoutgoing.OutgoingShare, token string) ([]string, bool) {
 	if h.tokenStore == nil {
-		return false
+		return nil, false
 	}
 
 	issuedToken, err := h.tokenStore.Get(ctx, token)
-	if err == nil && issuedToken != nil && issuedToken.ShareID == share.ShareID {
-		return true
+	if err == nil && issuedToken != nil && !issuedToken.IsRefresh() && issuedToken.ShareID == share.ShareID {
+		return issuedToken.Permissions, true
 	}
 
 	// Legacy shared-secret bearer is sanctioned for shares that do not
 	// require token exchange.
 	if !shareRequires(share.Requirements, spec.RequirementMustExchangeToken) &&
 		subtle.ConstantTimeCompare([]byte(token), []byte(share.SharedSecret)) == 1 {
-		return true
+		return share.Permissions, true
 	}
 
-	return false
+	return nil, false
+}
+
+// lockSystem returns the lock system for webdavID, creating it on first use,
+// and drops the lock systems of other shares that have been idle for
+// lockIdleTTL.
+func (h *Handler) lockSystem(webdavID string) webdav.LockSystem {
+	h.locksMu.Lock()
+	defer h.locksMu.Unlock()
+
+	now := h.now()
+
+	if now.Sub(h.locksSwept) >= lockSweepInterval {
+		for id, entry := range h.locks {
+			if now.Sub(entry.lastUsed) > lockIdleTTL {
+				delete(h.locks, id)
+			}
+		}
+
+		h.locksSwept = now
+	}
+
+	entry, ok := h.locks[webdavID]
+	if !ok {
+		entry = &lockEntry{ls: webdav.NewMemLS()}
+		h.locks[webdavID] = entry
+	}
+
+	entry.lastUsed = now
+
+	return entry.ls
+}
+
+// dropLockSystem discards the lock system for webdavID, e.g. once its share
+// has been revoked.
+func (h *Handler) dropLockSystem(webdavID string) {
+	h.locksMu.Lock()
+	defer h.locksMu.Unlock()
+
+	delete(h.locks, webdavID)
 }
 
 // shareRequires reports whether reqs contains the given requirement.
@@ -128,8 +226,10 @@ func shareRequires(reqs []string, req string) bool {
 	return slices.Contains(reqs, req)
 }
 
-// serveFile serves share.LocalPath via WebDAV.
-func (h *Handler) serveFile(w http.ResponseWriter, r *http.Request, share *sharesoutgoing.OutgoingShare) {
+// serveFile serves share.LocalPath via WebDAV. A writable file share accepts
+// PUT over the shared file; it can never be deleted, renamed, or copied,
+// because every name under the resource root maps to the same file.
+func (h *Handler) serveFile(w http.ResponseWriter, r *http.Request, share *sharesoutgoing.OutgoingShare, writable bool) {
 	localPath := share.LocalPath
 
 	//nolint:gosec // localPath is repository-controlled via sanitized webdavID lookup, not request-derived input
@@ -141,15 +241,29 @@ func (h *Handler) serveFile(w http.ResponseWriter, r *http.Request, share *share
 		return
 	}
 
+	if stat.IsDir() {
+		h.logger.Error("WebDAV file share points at a directory", "path", localPath)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+
+		return
+	}
+
+	if r.Method == "COPY" || r.Method == "MOVE" {
+		http.Error(w, "not supported on file shares", http.StatusMethodNotAllowed)
+
+		return
+	}
+
 	singleFS := &singleFileFS{
-		path: localPath,
-		info: stat,
+		path:     localPath,
+		info:     stat,
+		writable: writable,
 	}
 
 	davHandler := &webdav.Handler{
 		Prefix:     strings.TrimSuffix(r.URL.Path, "/"+filepath.Base(localPath)),
 		FileSystem: singleFS,
-		LockSystem: webdav.NewMemLS(),
+		LockSystem: h.lockSystem(share.WebDAVID),
 		Logger: func(r *http.Request, err error) {
 			if err != nil {
 				h.logger.Debug("WebDAV operation", "method", r.Method, "error", err)
@@ -161,19 +275,96 @@ func (h *Handler) serveFile(w http.ResponseWriter, r *http.Request, share *share
 		if ct := mime.TypeByExtension(filepath.Ext(localPath)); ct != "" {
 			w.Header().Set("Content-Type", ct)
 		}
+
+		h.setReprDigest(w, r, singleFS, filepath.Base(localPath))
+	}
+
+	davHandler.ServeHTTP(w, r)
+}
+
+// serveFolder serves the directory tree at share.LocalPath via WebDAV. The
+// resource root is /webdav/ocm/{webdavId}; everything after it is a path
+// inside the shared folder, confined by dirFS.
+func (h *Handler) serveFolder(w http.ResponseWriter, r *http.Request, share *sharesoutgoing.OutgoingShare, writable bool) {
+	localPath := share.LocalPath
+
+	//nolint:gosec // localPath is repository-controlled via sanitized webdavID lookup, not request-derived input
+	stat, err := os.Stat(localPath)
+	if err != nil || !stat.IsDir() {
+		h.logger.Error("WebDAV folder stat failed", "path", localPath, "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+
+		return
+	}
+
+	dirFS, err := newDirFS(localPath, writable)
+	if err != nil {
+		h.logger.Error("WebDAV folder root resolution failed", "path", localPath, "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+
+		return
+	}
+
+	davHandler := &webdav.Handler{
+		Prefix:     webdavPathPrefix + share.WebDAVID,
+		FileSystem: dirFS,
+		LockSystem: h.lockSystem(share.WebDAVID),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				h.logger.Debug("WebDAV operation", "method", r.Method, "error", err)
+			}
+		},
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		if ct := mime.TypeByExtension(path.Ext(r.URL.Path)); ct != "" {
+			w.Header().Set("Content-Type", ct)
+		}
+
+		h.setReprDigest(w, r, dirFS, strings.TrimPrefix(r.URL.Path, davHandler.Prefix))
 	}
 
 	davHandler.ServeHTTP(w, r)
 }
 
+// setReprDigest answers a Want-Repr-Digest asking for SHA-256 with the
+// digest of the file at name, so datatx receivers can verify a download.
+// Errors are left for the WebDAV handler to report.
+func (h *Handler) setReprDigest(w http.ResponseWriter, r *http.Request, fs webdav.FileSystem, name string) {
+	if !digest.WantsSHA256(r.Header) {
+		return
+	}
+
+	f, err := fs.OpenFile(r.Context(), name, os.O_RDONLY, 0)
+	if err != nil {
+		return
+	}
+	defer func() {
+		//nolint:errcheck // read-only handle; close errors are not actionable
+		f.Close()
+	}()
+
+	if info, err := f.Stat(); err != nil || info.IsDir() {
+		return
+	}
+
+	sum, err := digest.SumSHA256(f)
+	if err != nil {
+		h.logger.Debug("WebDAV digest failed", "path", r.URL.Path, "error", err)
+
+		return
+	}
+
+	w.Header().Set(digest.HeaderReprDigest, digest.FormatSHA256(sum))
+}
+
 // extractWebDAVID extracts webdavId from path /webdav/ocm/{webdavId} or /webdav/ocm/{webdavId}/...
-func extractWebDAVID(path string) string {
-	prefix := "/webdav/ocm/"
-	if !strings.HasPrefix(path, prefix) {
+func extractWebDAVID(urlPath string) string {
+	if !strings.HasPrefix(urlPath, webdavPathPrefix) {
 		return ""
 	}
 
-	rest := strings.TrimPrefix(path, prefix)
+	rest := strings.TrimPrefix(urlPath, webdavPathPrefix)
 	if rest == "" {
 		return ""
 	}
@@ -221,10 +412,12 @@ func isHexDigit(c rune) bool {
 	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
 }
 
-// isWriteMethod returns true if the HTTP method is a write operation.
+// isWriteMethod returns true if the HTTP method is a write operation. LOCK and
+// UNLOCK count as writes: a lock only matters to writers, and x/net/webdav
+// creates the resource when locking an unmapped name.
 func isWriteMethod(method string) bool {
 	switch method {
-	case http.MethodPut, http.MethodDelete, "MKCOL", "MOVE", "COPY", "PROPPATCH":
+	case http.MethodPut, http.MethodDelete, "MKCOL", "MOVE", "COPY", "PROPPATCH", "LOCK", "UNLOCK":
 		return true
 	}
 
@@ -256,19 +449,30 @@ func extractCredential(r *http.Request) *credentialResult {
 // singleFileFS implements webdav.FileSystem for a single file. The webdavId
 // resource root is the shared file itself; any trailing URL name is cosmetic
 // metadata (e.g. the share display name) and must never be used for filesystem
-// lookup. Only fs.path / share.LocalPath is opened.
+// lookup. Only fs.path / share.LocalPath is opened. When writable, OpenFile may
+// open the file for writing; Mkdir, RemoveAll, and Rename are always refused.
 type singleFileFS struct {
-	path string
-	info os.FileInfo
+	path     string
+	info     os.FileInfo
+	writable bool
 }
 
 func (fs *singleFileFS) Mkdir(_ context.Context, _ string, _ os.FileMode) error {
 	return os.ErrPermission
 }
 
-func (fs *singleFileFS) OpenFile(_ context.Context, _ string, flag int, _ os.FileMode) (webdav.File, error) {
-	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0 {
-		return nil, os.ErrPermission
+func (fs *singleFileFS) OpenFile(_ context.Context, _ string, flag int, perm os.FileMode) (webdav.File, error) {
+	if isWriteFlag(flag) {
+		if !fs.writable {
+			return nil, os.ErrPermission
+		}
+
+		f, err := os.OpenFile(fs.path, flag, perm)
+		if err != nil {
+			return nil, fmt.Errorf("webdav: open webdav file for writing: %w", err)
+		}
+
+		return f, nil
 	}
 
 	f, err := os.Open(fs.path)
@@ -290,3 +494,8 @@ func (fs *singleFileFS) Rename(_ context.Context, _, _ string) error {
 func (fs *singleFileFS) Stat(_ context.Context, _ string) (os.FileInfo, error) {
 	return fs.info, nil
 }
+
+// isWriteFlag reports whether an OpenFile flag set requests write access.
+func isWriteFlag(flag int) bool {
+	return flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0
+}
@@ -69,6 +69,16 @@ func (m *mockOutgoingShareRepo) GetBySharedSecret(_ context.Context, sharedSecre
 	return nil, errNotFound
 }
 
+func (m *mockOutgoingShareRepo) GetByWebappSharedSecret(_ context.Context, webappSharedSecret string) (*sharesoutgoing.OutgoingShare, error) {
+	for _, s := range m.shares {
+		if s.WebappSharedSecret != "" && s.WebappSharedSecret == webappSharedSecret {
+			return s, nil
+		}
+	}
+
+	return nil, errNotFound
+}
+
 func (m *mockOutgoingShareRepo) List(_ context.Context) ([]*sharesoutgoing.OutgoingShare, error) {
 	result := make([]*sharesoutgoing.OutgoingShare, 0, len(m.shares))
 	for _, s := range m.shares {
@@ -78,6 +88,17 @@ func (m *mockOutgoingShareRepo) List(_ context.Context) ([]*sharesoutgoing.Outgo
 	return result, nil
 }
 
+func (m *mockOutgoingShareRepo) ListExpiring(_ context.Context, now time.Time) ([]*sharesoutgoing.OutgoingShare, error) {
+	result := make([]*sharesoutgoing.OutgoingShare, 0)
+	for _, s := range m.shares {
+		if s.Expired(now) {
+			result = append(result, s)
+		}
+	}
+
+	return result, nil
+}
+
 func (m *mockOutgoingShareRepo) Update(_ context.Context, share *sharesoutgoing.OutgoingShare) error {
 	m.shares[share.ShareID] = share
 
@@ -117,6 +138,26 @@ func (m *mockTokenStore) Delete(_ context.Context, accessToken string) error {
 	return nil
 }
 
+func (m *mockTokenStore) Claim(_ context.Context, accessToken string) error {
+	if _, ok := m.tokens[accessToken]; !ok {
+		return token.ErrTokenNotFound
+	}
+
+	delete(m.tokens, accessToken)
+
+	return nil
+}
+
+func (m *mockTokenStore) DeleteByShareID(_ context.Context, shareID string) error {
+	for k, t := range m.tokens {
+		if t.ShareID == shareID {
+			delete(m.tokens, k)
+		}
+	}
+
+	return nil
+}
+
 func (m *mockTokenStore) CleanExpired(_ context.Context) error {
 	return nil
 }
@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package webdav
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/digest"
+)
+
+// seedFolderShare builds a folder share tree:
+//
+//	root/top.txt
+//	root/sub/nested.txt
+//	root/escape -> outside/ (symlink leaving the share)
+//
+// and returns a read-only handler authorized by "valid-token".
+func seedFolderShare(t *testing.T) *Handler {
+	t.Helper()
+
+	handler, _, _ := seedFolderShareWithPermissions(t, []string{"read"}, []string{"read"})
+
+	return handler
+}
+
+// seedFolderShareWithPermissions is seedFolderShare with explicit share and
+// token permissions. It also returns the share root and the outside directory.
+func seedFolderShareWithPermissions(t *testing.T, sharePerms, tokenPerms []string) (*Handler, string, string) {
+	t.Helper()
+
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	mustWrite(t, filepath.Join(root, "top.txt"), "top")
+
+	if err := os.Mkdir(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	mustWrite(t, filepath.Join(root, "sub", "nested.txt"), "nested")
+	mustWrite(t, filepath.Join(outside, "secret.txt"), "secret")
+
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := newMockOutgoingShareRepo()
+	share := seedShare(t, repo)
+	share.LocalPath = root
+	share.ResourceType = spec.ResourceTypeFolder
+	share.Permissions = sharePerms
+
+	tok := unexpiredTestToken("valid-token", share.ShareID)
+	tok.Permissions = tokenPerms
+
+	tokenStore := newMockTokenStore()
+	if err := tokenStore.Store(context.Background(), tok); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewHandler(repo, tokenStore, nil), root, outside
+}
+
+func mustWrite(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func serveFolderRequest(t *testing.T, handler *Handler, method, subPath, depth string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(context.Background(), method, "/webdav/ocm/"+testWebDAVID+subPath, nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+
+	if depth != "" {
+		req.Header.Set("Depth", depth)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestServeHTTP_FolderGetNestedFile(t *testing.T) {
+	t.Parallel()
+
+	handler := seedFolderShare(t)
+
+	w := serveFolderRequest(t, handler, http.MethodGet, "/sub/nested.txt", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w.Body.String() != "nested" {
+		t.Errorf("body = %q, want nested", w.Body.String())
+	}
+}
+
+func TestServeHTTP_FolderPropfindDepthOne(t *testing.T) {
+	t.Parallel()
+
+	handler := seedFolderShare(t)
+
+	w := serveFolderRequest(t, handler, "PROPFIND", "", "1")
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d: %s", w.Code, w.Body.String())
+	}
+
+	body := w.Body.String()
+	for _, want := range []string{"top.txt", "/sub/"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("depth 1 listing missing %q: %s", want, body)
+		}
+	}
+
+	if strings.Contains(body, "nested.txt") {
+		t.Errorf("depth 1 listing must not recurse: %s", body)
+	}
+
+	if strings.Contains(body, "escape") || strings.Contains(body, "secret.txt") {
+		t.Errorf("listing leaked symlink escape: %s", body)
+	}
+}
+
+func TestServeHTTP_FolderPropfindDepthInfinity(t *testing.T) {
+	t.Parallel()
+
+	handler := seedFolderShare(t)
+
+	w := serveFolderRequest(t, handler, "PROPFIND", "", "infinity")
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d: %s", w.Code, w.Body.String())
+	}
+
+	body := w.Body.String()
+	if !strings.Contains(body, "nested.txt") {
+		t.Errorf("depth infinity listing missing nested.txt: %s", body)
+	}
+
+	if strings.Contains(body, "secret.txt") {
+		t.Errorf("listing leaked symlink escape: %s", body)
+	}
+}
+
+func TestServeHTTP_FolderRejectsEscapes(t *testing.T) {
+	t.Parallel()
+
+	handler := seedFolderShare(t)
+
+	for _, subPath := range []string{
+		"/escape/secret.txt",
+		"/../../etc/passwd",
+		"/sub/../../top.txt/../../secret.txt",
+	} {
+		w := serveFolderRequest(t, handler, http.MethodGet, subPath, "")
+		if w.Code == http.StatusOK {
+			t.Errorf("GET %s: expected non-200, got 200: %s", subPath, w.Body.String())
+		}
+
+		if strings.Contains(w.Body.String(), "secret") {
+			t.Errorf("GET %s leaked content outside the share: %s", subPath, w.Body.String())
+		}
+	}
+}
+
+func TestServeHTTP_FolderStaysReadOnly(t *testing.T) {
+	t.Parallel()
+
+	handler := seedFolderShare(t)
+
+	for _, method := range []string{http.MethodPut, http.MethodDelete, "MKCOL", "MOVE", "COPY", "PROPPATCH", "LOCK", "UNLOCK"} {
+		w := serveFolderRequest(t, handler, method, "/top.txt", "")
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected 403 on read-only share, got %d", method, w.Code)
+		}
+	}
+}
+
+func TestServeHTTP_FolderReprDigestOnRequest(t *testing.T) {
+	t.Parallel()
+
+	handler := seedFolderShare(t)
+	want := digest.FormatSHA256(sha256Sum("nested"))
+
+	get := func(subPath string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/webdav/ocm/"+testWebDAVID+subPath, nil)
+		req.Header = header
+		req.Header.Set("Authorization", "Bearer valid-token")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		return w
+	}
+
+	if w := get("/sub/nested.txt", http.Header{}); w.Header().Get(digest.HeaderReprDigest) != "" {
+		t.Errorf("Repr-Digest sent without Want-Repr-Digest: %q", w.Header().Get(digest.HeaderReprDigest))
+	}
+
+	wanted := http.Header{}
+	digest.RequestSHA256(wanted)
+
+	if w := get("/sub/nested.txt", wanted.Clone()); w.Header().Get(digest.HeaderReprDigest) != want {
+		t.Errorf("Repr-Digest = %q, want %q", w.Header().Get(digest.HeaderReprDigest), want)
+	}
+
+	// The digest covers the whole representation, not the requested range.
+	ranged := wanted.Clone()
+	ranged.Set("Range", "bytes=2-")
+
+	w := get("/sub/nested.txt", ranged)
+	if w.Code != http.StatusPartialContent || w.Body.String() != "sted" {
+		t.Fatalf("ranged GET = %d %q, want 206 \"sted\"", w.Code, w.Body.String())
+	}
+
+	if w.Header().Get(digest.HeaderReprDigest) != want {
+		t.Errorf("ranged Repr-Digest = %q, want %q", w.Header().Get(digest.HeaderReprDigest), want)
+	}
+
+	for _, subPath := range []string{"/sub", "/escape/secret.txt"} {
+		if w := get(subPath, wanted.Clone()); w.Header().Get(digest.HeaderReprDigest) != "" {
+			t.Errorf("GET %s sent Repr-Digest %q", subPath, w.Header().Get(digest.HeaderReprDigest))
+		}
+	}
+}
+
+func sha256Sum(s string) []byte {
+	sum := sha256.Sum256([]byte(s))
+
+	return sum[:]
+}
@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/spec"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
 )
@@ -33,7 +34,7 @@ func TestValidateCredential_ExchangedTokenSucceeds(t *testing.T) {
 
 	handler := NewHandler(repo, tokenStore, nil)
 
-	authorized := handler.validateCredential(ctx, share, "exchanged-token-123")
+	_, authorized := handler.validateCredential(ctx, share, "exchanged-token-123")
 	if !authorized {
 		t.Error("expected authorization to succeed with valid exchanged token")
 	}
@@ -48,7 +49,7 @@ func TestValidateCredential_AcceptsSharedSecretForNonStrict(t *testing.T) {
 
 	handler := NewHandler(repo, tokenStore, nil)
 
-	authorized := handler.validateCredential(context.Background(), share, share.SharedSecret)
+	_, authorized := handler.validateCredential(context.Background(), share, share.SharedSecret)
 	if !authorized {
 		t.Error("expected shared-secret authorization to succeed for non-strict share")
 	}
@@ -63,7 +64,7 @@ func TestValidateCredential_RejectsSharedSecretForStrict(t *testing.T) {
 
 	handler := NewHandler(repo, tokenStore, nil)
 
-	authorized := handler.validateCredential(context.Background(), share, share.SharedSecret)
+	_, authorized := handler.validateCredential(context.Background(), share, share.SharedSecret)
 	if authorized {
 		t.Error("expected shared-secret authorization to fail for strict share")
 	}
@@ -83,12 +84,35 @@ func TestValidateCredential_RejectsWrongShareBinding(t *testing.T) {
 
 	handler := NewHandler(repo, tokenStore, nil)
 
-	authorized := handler.validateCredential(ctx, share, "bound-to-other-share")
+	_, authorized := handler.validateCredential(ctx, share, "bound-to-other-share")
 	if authorized {
 		t.Error("expected authorization to fail for wrong share binding")
 	}
 }
 
+func TestValidateCredential_RejectsRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	repo := newMockOutgoingShareRepo()
+	tokenStore := newMockTokenStore()
+	share := seedShareWithRequirements(t, repo, "share-1", []string{spec.RequirementMustExchangeToken})
+
+	refresh := unexpiredTestToken("refresh-token-123", share.ShareID)
+	refresh.Kind = token.KindRefresh
+
+	ctx := context.Background()
+	if err := tokenStore.Store(ctx, refresh); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewHandler(repo, tokenStore, nil)
+
+	_, authorized := handler.validateCredential(ctx, share, "refresh-token-123")
+	if authorized {
+		t.Error("expected a refresh token to be rejected as a WebDAV bearer")
+	}
+}
+
 func TestValidateCredential_RejectsNearMissSecret(t *testing.T) {
 	t.Parallel()
 
@@ -97,7 +121,7 @@ func TestValidateCredential_RejectsNearMissSecret(t *testing.T) {
 
 	handler := NewHandler(repo, newMockTokenStore(), nil)
 
-	authorized := handler.validateCredential(context.Background(), share, "secret124")
+	_, authorized := handler.validateCredential(context.Background(), share, "secret124")
 	if authorized {
 		t.Error("expected equal-length near-miss shared secret to be rejected")
 	}
@@ -112,7 +136,7 @@ func TestValidateCredential_RejectsUnknownToken(t *testing.T) {
 
 	handler := NewHandler(repo, tokenStore, nil)
 
-	authorized := handler.validateCredential(context.Background(), share, "unknown-token")
+	_, authorized := handler.validateCredential(context.Background(), share, "unknown-token")
 	if authorized {
 		t.Error("expected authorization to fail for unknown token")
 	}
@@ -398,3 +422,86 @@ func TestServeHTTP_NonStrictSharedSecretSucceeds(t *testing.T) {
 		t.Fatalf("expected 200 for non-strict shared-secret bearer, got %d: %s", w.Code, w.Body.String())
 	}
 }
+
+func TestServeHTTP_RevokedShareReturns410(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	filePath := filepath.Join(dir, "hello.txt")
+	if err := os.WriteFile(filePath, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := newMockOutgoingShareRepo()
+	share := seedShare(t, repo)
+
+	share.LocalPath = filePath
+	share.Status = shares.OutgoingShareStatusRevoked
+
+	if err := repo.Update(context.Background(), share); err != nil {
+		t.Fatal(err)
+	}
+
+	tokenStore := newMockTokenStore()
+	if err := tokenStore.Store(context.Background(), unexpiredTestToken("valid-token", share.ShareID)); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewHandler(repo, tokenStore, nil)
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/webdav/ocm/"+testWebDAVID+"/hello.txt", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusGone {
+		t.Fatalf("expected 410, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if strings.Contains(w.Body.String(), "hello") {
+		t.Errorf("revoked share leaked content: %s", w.Body.String())
+	}
+}
+
+func TestServeHTTP_ExpiredShareReturns410(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	filePath := filepath.Join(dir, "hello.txt")
+	if err := os.WriteFile(filePath, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := newMockOutgoingShareRepo()
+	share := seedShare(t, repo)
+
+	// Still accepted: the janitor has not swept it yet, the time alone
+	// ends access.
+	expiresAt := time.Now().Add(-time.Second)
+	share.LocalPath = filePath
+	share.ExpiresAt = &expiresAt
+
+	if err := repo.Update(context.Background(), share); err != nil {
+		t.Fatal(err)
+	}
+
+	tokenStore := newMockTokenStore()
+	if err := tokenStore.Store(context.Background(), unexpiredTestToken("valid-token", share.ShareID)); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewHandler(repo, tokenStore, nil)
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/webdav/ocm/"+testWebDAVID+"/hello.txt", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusGone {
+		t.Fatalf("expected 410, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if strings.Contains(w.Body.String(), "hello") {
+		t.Errorf("expired share leaked content: %s", w.Body.String())
+	}
+}
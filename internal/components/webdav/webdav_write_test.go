@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package webdav
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var readWrite = []string{"read", "write"}
+
+const lockBody = `<?xml version="1.0" encoding="utf-8"?>
+<D:lockinfo xmlns:D="DAV:">
+  <D:lockscope><D:exclusive/></D:lockscope>
+  <D:locktype><D:write/></D:locktype>
+  <D:owner>receiver</D:owner>
+</D:lockinfo>`
+
+// serveWriteRequest sends an authorized request with a body and extra headers.
+func serveWriteRequest(t *testing.T, handler *Handler, method, subPath, body string, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(context.Background(), method, "/webdav/ocm/"+testWebDAVID+subPath, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer valid-token")
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	return w
+}
+
+func assertFileContent(t *testing.T, path, want string) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	if string(got) != want {
+		t.Errorf("%s = %q, want %q", path, got, want)
+	}
+}
+
+func assertNotExist(t *testing.T, path string) {
+	t.Helper()
+
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		t.Errorf("expected %s to not exist, got err=%v", path, err)
+	}
+}
+
+func TestServeHTTP_FolderWritePutCreatesAndOverwrites(t *testing.T) {
+	t.Parallel()
+
+	handler, root, _ := seedFolderShareWithPermissions(t, readWrite, readWrite)
+
+	w := serveWriteRequest(t, handler, http.MethodPut, "/sub/new.txt", "fresh", nil)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("PUT new file: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertFileContent(t, filepath.Join(root, "sub", "new.txt"), "fresh")
+
+	w = serveWriteRequest(t, handler, http.MethodPut, "/top.txt", "updated", nil)
+	if w.Code != http.StatusCreated && w.Code != http.StatusNoContent {
+		t.Fatalf("PUT existing file: expected 201 or 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertFileContent(t, filepath.Join(root, "top.txt"), "updated")
+}
+
+func TestServeHTTP_FolderWriteMkcolMoveCopyDelete(t *testing.T) {
+	t.Parallel()
+
+	handler, root, _ := seedFolderShareWithPermissions(t, readWrite, readWrite)
+	dest := "http://example.com/webdav/ocm/" + testWebDAVID
+
+	if w := serveWriteRequest(t, handler, "MKCOL", "/made", "", nil); w.Code != http.StatusCreated {
+		t.Fatalf("MKCOL: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if info, err := os.Stat(filepath.Join(root, "made")); err != nil || !info.IsDir() {
+		t.Fatalf("MKCOL did not create a directory: %v", err)
+	}
+
+	w := serveWriteRequest(t, handler, "COPY", "/top.txt", "", map[string]string{"Destination": dest + "/made/copy.txt"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("COPY: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertFileContent(t, filepath.Join(root, "made", "copy.txt"), "top")
+
+	w = serveWriteRequest(t, handler, "MOVE", "/sub/nested.txt", "", map[string]string{"Destination": dest + "/moved.txt"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("MOVE: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertFileContent(t, filepath.Join(root, "moved.txt"), "nested")
+	assertNotExist(t, filepath.Join(root, "sub", "nested.txt"))
+
+	if w := serveWriteRequest(t, handler, http.MethodDelete, "/made", "", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("DELETE: expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertNotExist(t, filepath.Join(root, "made"))
+}
+
+func TestServeHTTP_FolderWriteRequiresShareAndTokenPermission(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		sharePerms []string
+		tokenPerms []string
+	}{
+		{"share read-only", []string{"read"}, readWrite},
+		{"token read-only", readWrite, []string{"read"}},
+		{"token without permissions", readWrite, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler, root, _ := seedFolderShareWithPermissions(t, tc.sharePerms, tc.tokenPerms)
+
+			w := serveWriteRequest(t, handler, http.MethodPut, "/new.txt", "x", nil)
+			if w.Code != http.StatusForbidden {
+				t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+			}
+
+			assertNotExist(t, filepath.Join(root, "new.txt"))
+
+			if w := serveFolderRequest(t, handler, http.MethodGet, "/top.txt", ""); w.Code != http.StatusOK {
+				t.Errorf("reads must still work, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestServeHTTP_FolderWriteUnauthenticatedReturns401(t *testing.T) {
+	t.Parallel()
+
+	handler, root, _ := seedFolderShareWithPermissions(t, readWrite, readWrite)
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPut, "/webdav/ocm/"+testWebDAVID+"/new.txt", strings.NewReader("x"))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+
+	assertNotExist(t, filepath.Join(root, "new.txt"))
+}
+
+func TestServeHTTP_FolderWriteStaysConfined(t *testing.T) {
+	t.Parallel()
+
+	handler, root, outside := seedFolderShareWithPermissions(t, readWrite, readWrite)
+
+	for _, subPath := range []string{"/escape/planted.txt", "/escape/secret.txt"} {
+		w := serveWriteRequest(t, handler, http.MethodPut, subPath, "planted", nil)
+		if w.Code < 400 {
+			t.Errorf("PUT %s: expected an error status, got %d", subPath, w.Code)
+		}
+	}
+
+	assertNotExist(t, filepath.Join(outside, "planted.txt"))
+	assertFileContent(t, filepath.Join(outside, "secret.txt"), "secret")
+
+	// ".." is cleaned against the share root, so the write lands inside it.
+	if w := serveWriteRequest(t, handler, http.MethodPut, "/../../dotdot.txt", "inside", nil); w.Code != http.StatusCreated {
+		t.Fatalf("PUT with ..: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertFileContent(t, filepath.Join(root, "dotdot.txt"), "inside")
+
+	if w := serveWriteRequest(t, handler, http.MethodDelete, "/escape/secret.txt", "", nil); w.Code < 400 {
+		t.Errorf("DELETE through symlink: expected an error status, got %d", w.Code)
+	}
+
+	assertFileContent(t, filepath.Join(outside, "secret.txt"), "secret")
+
+	if w := serveWriteRequest(t, handler, http.MethodDelete, "/", "", nil); w.Code < 400 {
+		t.Errorf("DELETE share root: expected an error status, got %d", w.Code)
+	}
+
+	if _, err := os.Stat(root); err != nil {
+		t.Fatalf("share root must survive DELETE /: %v", err)
+	}
+
+	dest := "http://example.com/webdav/ocm/" + testWebDAVID + "/escape/stolen.txt"
+	if w := serveWriteRequest(t, handler, "MOVE", "/top.txt", "", map[string]string{"Destination": dest}); w.Code < 400 {
+		t.Errorf("MOVE through symlink: expected an error status, got %d", w.Code)
+	}
+
+	assertNotExist(t, filepath.Join(outside, "stolen.txt"))
+	assertFileContent(t, filepath.Join(root, "top.txt"), "top")
+}
+
+func TestServeHTTP_FolderLockPersistsAcrossRequests(t *testing.T) {
+	t.Parallel()
+
+	handler, root, _ := seedFolderShareWithPermissions(t, readWrite, readWrite)
+
+	w := serveWriteRequest(t, handler, "LOCK", "/top.txt", lockBody, map[string]string{"Timeout": "Second-60"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("LOCK: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	lockToken := w.Header().Get("Lock-Token")
+	if lockToken == "" {
+		t.Fatal("LOCK response missing Lock-Token")
+	}
+
+	if w := serveWriteRequest(t, handler, http.MethodPut, "/top.txt", "blocked", nil); w.Code != http.StatusLocked {
+		t.Fatalf("PUT without lock token: expected 423, got %d", w.Code)
+	}
+
+	assertFileContent(t, filepath.Join(root, "top.txt"), "top")
+
+	w = serveWriteRequest(t, handler, http.MethodPut, "/top.txt", "holder", map[string]string{"If": "(" + lockToken + ")"})
+	if w.Code != http.StatusCreated && w.Code != http.StatusNoContent {
+		t.Fatalf("PUT with lock token: expected 201 or 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertFileContent(t, filepath.Join(root, "top.txt"), "holder")
+
+	if w := serveWriteRequest(t, handler, "UNLOCK", "/top.txt", "", map[string]string{"Lock-Token": lockToken}); w.Code != http.StatusNoContent {
+		t.Fatalf("UNLOCK: expected 204, got %d", w.Code)
+	}
+
+	if w := serveWriteRequest(t, handler, http.MethodPut, "/top.txt", "after", nil); w.Code != http.StatusCreated && w.Code != http.StatusNoContent {
+		t.Fatalf("PUT after UNLOCK: expected 201 or 204, got %d", w.Code)
+	}
+}
+
+func TestLockSystem_DropsIdleShares(t *testing.T) {
+	t.Parallel()
+
+	handler := NewHandler(nil, nil, nil)
+	clock := time.Now()
+	handler.now = func() time.Time { return clock }
+
+	idle := handler.lockSystem("idle")
+	active := handler.lockSystem("active")
+
+	clock = clock.Add(lockIdleTTL / 2)
+
+	if handler.lockSystem("active") != active {
+		t.Fatal("lock system of an active share was replaced")
+	}
+
+	clock = clock.Add(lockIdleTTL/2 + lockSweepInterval)
+	handler.lockSystem("other")
+
+	if _, ok := handler.locks["idle"]; ok {
+		t.Error("idle share's lock system was kept past lockIdleTTL")
+	}
+
+	if handler.locks["active"] == nil || handler.locks["active"].ls != active {
+		t.Error("lock system used within lockIdleTTL was dropped")
+	}
+
+	if handler.lockSystem("idle") == idle {
+		t.Error("dropped lock system came back")
+	}
+}
+
+func TestServeHTTP_FileShareWrite(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "doc.txt")
+	mustWrite(t, filePath, "original")
+
+	repo := newMockOutgoingShareRepo()
+	share := seedShare(t, repo)
+	share.LocalPath = filePath
+	share.Permissions = readWrite
+
+	tok := unexpiredTestToken("valid-token", share.ShareID)
+	tok.Permissions = readWrite
+
+	tokenStore := newMockTokenStore()
+	if err := tokenStore.Store(context.Background(), tok); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewHandler(repo, tokenStore, nil)
+
+	w := serveWriteRequest(t, handler, http.MethodPut, "/doc.txt", "rewritten", nil)
+	if w.Code != http.StatusCreated && w.Code != http.StatusNoContent {
+		t.Fatalf("PUT: expected 201 or 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertFileContent(t, filePath, "rewritten")
+
+	dest := "http://example.com/webdav/ocm/" + testWebDAVID + "/other.txt"
+	for _, method := range []string{"COPY", "MOVE"} {
+		if w := serveWriteRequest(t, handler, method, "/doc.txt", "", map[string]string{"Destination": dest}); w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s on file share: expected 405, got %d", method, w.Code)
+		}
+	}
+
+	if w := serveWriteRequest(t, handler, http.MethodDelete, "/doc.txt", "", nil); w.Code < 400 {
+		t.Errorf("DELETE on file share: expected an error status, got %d", w.Code)
+	}
+
+	assertFileContent(t, filePath, "rewritten")
+}
+
+func TestServeHTTP_SharedSecretCarriesSharePermissions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "doc.txt")
+	mustWrite(t, filePath, "original")
+
+	repo := newMockOutgoingShareRepo()
+	share := seedShare(t, repo)
+	share.LocalPath = filePath
+	share.Permissions = readWrite
+
+	handler := NewHandler(repo, newMockTokenStore(), nil)
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPut, "/webdav/ocm/"+testWebDAVID+"/doc.txt", strings.NewReader("legacy"))
+	req.Header.Set("Authorization", "Bearer "+share.SharedSecret)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated && w.Code != http.StatusNoContent {
+		t.Fatalf("PUT with shared secret: expected 201 or 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertFileContent(t, filePath, "legacy")
+}
@@ -22,6 +22,8 @@ const (
 	BuildUI BuildKey = "ui"
 	// BuildWebDAV is the WebDAV service build key.
 	BuildWebDAV BuildKey = "webdav"
+	// BuildMetrics is the metrics service build key.
+	BuildMetrics BuildKey = "metrics"
 )
 
 // Descriptor is the canonical registration entry for one core HTTP service.
@@ -71,6 +73,12 @@ var descriptors = []Descriptor{
 		Prefix:      "webdav",
 		Build:       BuildWebDAV,
 	},
+	{
+		Name:        string(BuildMetrics),
+		MountAtRoot: false,
+		Prefix:      "metrics",
+		Build:       BuildMetrics,
+	},
 }
 
 // Descriptors returns the canonical core service descriptor table in mount order.
@@ -49,6 +49,8 @@ const (
 	SurfaceAPI SurfaceClass = "api"
 	// SurfaceWebDAV is the WebDAV route surface class.
 	SurfaceWebDAV SurfaceClass = "webdav"
+	// SurfaceMetrics is the operational metrics route surface class.
+	SurfaceMetrics SurfaceClass = "metrics"
 )
 
 // TrustClass names peer-trust expectations for protocol routes.
@@ -99,6 +101,8 @@ const (
 	PeerResolutionToken PeerResolution = "token"
 	// PeerResolutionNotifications is the notifications peer resolution strategy.
 	PeerResolutionNotifications PeerResolution = "notifications"
+	// PeerResolutionRequestShare is the request-share peer resolution strategy.
+	PeerResolutionRequestShare PeerResolution = "request-share"
 )
 
 // OCMProtocolBodyLimitBytes is the pre-verification request body limit for OCM POST routes.
@@ -58,7 +58,7 @@ func TestValidateBuiltServices_CountMismatch(t *testing.T) {
 		t.Fatal("ValidateBuiltServices() = nil, want count mismatch error")
 	}
 
-	if !strings.Contains(err.Error(), "built service count = 5, want 6 from descriptor table") {
+	if !strings.Contains(err.Error(), "built service count = 6, want 7 from descriptor table") {
 		t.Fatalf("ValidateBuiltServices() error = %q, want count mismatch message", err)
 	}
 }
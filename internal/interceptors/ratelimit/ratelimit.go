@@ -19,6 +19,7 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/interceptors"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
 )
 
 // Config defines rate limiting parameters decoded from interceptor config.
@@ -100,6 +101,8 @@ func (l *Limiter) Wrap(next http.Handler) http.Handler {
 		}
 
 		if count > l.limit {
+			metrics.RateLimitRejection()
+
 			retryAfter := max(int(time.Until(resetAt).Seconds()), 1)
 
 			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
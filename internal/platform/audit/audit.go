@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package audit is the append-only audit trail of protocol and
+// administrative events. Every event is appended to audit.jsonl with a
+// SHA-256 hash chained to the previous line, so editing, removing or
+// reordering lines is detectable, and indexed in the audit_events table of
+// audit.db for admin queries.
+//
+// Events carry identifiers (usernames, OCM addresses, peer hosts, share and
+// invite IDs), never secrets, tokens or passwords.
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	// ActionShareCreate is a share created locally or received from a peer.
+	ActionShareCreate = "share.create"
+	// ActionShareAccept is a share accepted by its recipient.
+	ActionShareAccept = "share.accept"
+	// ActionShareDecline is a share declined by its recipient.
+	ActionShareDecline = "share.decline"
+	// ActionShareUnshare is a share revoked by its owner.
+	ActionShareUnshare = "share.unshare"
+	// ActionInviteAccept is an invite accepted by its recipient.
+	ActionInviteAccept = "invite.accept"
+	// ActionTokenIssue is an access token issued by the token endpoint.
+	ActionTokenIssue = "token.issue"
+	// ActionAdminLogin is a login attempt on an admin account.
+	ActionAdminLogin = "admin.login"
+)
+
+// Outcomes.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Directions, recorded in Details[DetailDirection] for share and invite
+// events.
+const (
+	// DetailDirection is the Details key holding the direction.
+	DetailDirection = "direction"
+	// DirectionIncoming is a share or invite received from a peer.
+	DirectionIncoming = "incoming"
+	// DirectionOutgoing is a share or invite sent to a peer.
+	DirectionOutgoing = "outgoing"
+)
+
+// Event is one audit record. Seq, Time, PrevHash and Hash are assigned by
+// the log; callers fill in the rest.
+type Event struct {
+	Seq    int64     `json:"seq"`
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	// Outcome defaults to OutcomeSuccess.
+	Outcome string `json:"outcome"`
+	// Actor is the local username or the remote OCM address that acted.
+	// Empty takes the actor stored on the context by WithActor.
+	Actor string `json:"actor,omitempty"`
+	// Peer is the remote provider host involved, if any.
+	Peer string `json:"peer,omitempty"`
+	// Resource is the share, invite or account the event is about.
+	Resource string            `json:"resource,omitempty"`
+	Details  map[string]string `json:"details,omitempty"`
+	PrevHash string            `json:"prevHash"`
+	Hash     string            `json:"hash"`
+}
+
+// Recorder records audit events. Record never fails the caller: a sink that
+// cannot write logs the error.
+type Recorder interface {
+	Record(ctx context.Context, e Event)
+}
+
+// Filter selects events for Query. Zero fields do not filter.
+type Filter struct {
+	// Since and Until bound the event time; Since is inclusive, Until exclusive.
+	Since time.Time
+	Until time.Time
+	Actor string
+	Peer  string
+	// Action matches one of the Action* constants.
+	Action string
+	// Limit caps the result; zero or negative uses DefaultQueryLimit.
+	Limit int
+}
+
+// Query limits.
+const (
+	DefaultQueryLimit = 100
+	MaxQueryLimit     = 1000
+)
+
+type nopRecorder struct{}
+
+func (nopRecorder) Record(context.Context, Event) {}
+
+// Nop returns a Recorder that drops every event; handlers use it until an
+// audit log is wired.
+func Nop() Recorder {
+	return nopRecorder{}
+}
+
+// OrNop returns r, or Nop when r is nil.
+func OrNop(r Recorder) Recorder {
+	if r == nil {
+		return Nop()
+	}
+
+	return r
+}
+
+type actorKey struct{}
+
+// WithActor returns a context whose events default to actor. The session
+// gate stores the authenticated username here.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, or "".
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+
+	return actor
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GenesisHash is the PrevHash of the first event in a log.
+var GenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// maxLineBytes bounds one JSONL line when reading the log back.
+const maxLineBytes = 1 << 20
+
+// HashEvent returns the chain hash of e: the hex SHA-256 of its JSON
+// encoding with Hash empty. PrevHash is part of the encoding, which links
+// each event to the one before it.
+func HashEvent(e Event) (string, error) {
+	e.Hash = ""
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("audit: encode event %d: %w", e.Seq, err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyResult reports the state of a hash chain.
+type VerifyResult struct {
+	Valid bool `json:"valid"`
+	// Events is the number of events checked before the first break.
+	Events int64 `json:"events"`
+	// HeadHash is the hash of the last valid event.
+	HeadHash string `json:"headHash"`
+	// BrokenAt is the line number (1-based) of the first bad line.
+	BrokenAt int64 `json:"brokenAt,omitempty"`
+	// Reason says why the chain breaks at BrokenAt.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Verify reads a JSONL audit log and checks that sequence numbers count up
+// from 1, each PrevHash matches the previous Hash, and each Hash matches its
+// event. It stops at the first break. Only read errors are returned as
+// errors.
+func Verify(r io.Reader) (VerifyResult, error) {
+	res := VerifyResult{Valid: true, HeadHash: GenesisHash}
+
+	err := scanEvents(r, func(line int64, e Event, decodeErr error) bool {
+		reason := checkLink(res, e, decodeErr)
+		if reason != "" {
+			res.Valid = false
+			res.BrokenAt = line
+			res.Reason = reason
+
+			return false
+		}
+
+		res.Events++
+		res.HeadHash = e.Hash
+
+		return true
+	})
+
+	return res, err
+}
+
+func checkLink(prev VerifyResult, e Event, decodeErr error) string {
+	if decodeErr != nil {
+		return "line is not a valid event"
+	}
+
+	if e.Seq != prev.Events+1 {
+		return fmt.Sprintf("seq %d, want %d", e.Seq, prev.Events+1)
+	}
+
+	if e.PrevHash != prev.HeadHash {
+		return "prevHash does not match the previous event"
+	}
+
+	hash, err := HashEvent(e)
+	if err != nil || hash != e.Hash {
+		return "hash does not match the event"
+	}
+
+	return ""
+}
+
+// scanEvents calls fn for every line of r with its 1-based line number and
+// decoded event. fn returns false to stop.
+func scanEvents(r io.Reader, fn func(line int64, e Event, decodeErr error) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+
+	var line int64
+
+	for scanner.Scan() {
+		line++
+
+		var e Event
+
+		decodeErr := json.Unmarshal(scanner.Bytes(), &e)
+		if !fn(line, e, decodeErr) {
+			return nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("audit: read log: %w", err)
+	}
+
+	return nil
+}
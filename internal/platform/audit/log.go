@@ -0,0 +1,407 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	gormsqlite "github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// File names created under the audit data dir.
+const (
+	LogFileName = "audit.jsonl"
+	DBFileName  = "audit.db"
+)
+
+// eventRow is the audit.db row. OccurredAt is unix nanoseconds so a row
+// converts back to the exact time that was hashed.
+type eventRow struct {
+	Seq        int64  `gorm:"primaryKey;autoIncrement:false"`
+	OccurredAt int64  `gorm:"index;not null"`
+	Action     string `gorm:"index;not null"`
+	Outcome    string
+	Actor      string `gorm:"index"`
+	Peer       string `gorm:"index"`
+	Resource   string
+	Details    string // JSON object, empty when there are none
+	PrevHash   string
+	Hash       string
+}
+
+// TableName pins the table name independent of the Go type name.
+func (eventRow) TableName() string { return "audit_events" }
+
+// Log is the audit sink: audit.jsonl is the hash-chained record and
+// audit.db indexes it for queries. Safe for concurrent use.
+type Log struct {
+	db   *gorm.DB
+	file *os.File
+	path string
+	log  *slog.Logger
+	now  func() time.Time
+
+	mu   sync.Mutex
+	seq  int64
+	head string
+}
+
+var _ Recorder = (*Log)(nil)
+
+// Open opens (or creates) audit.jsonl and audit.db under dataDir. It
+// resumes the chain after the last line of audit.jsonl and indexes any
+// events audit.db is missing, e.g. after a crash between the two writes or
+// when audit.db was deleted. A broken chain is logged, not fatal: new events
+// chain onto the last line. The caller must call Close when done.
+func Open(dataDir string, log *slog.Logger) (*Log, error) {
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return nil, fmt.Errorf("audit: create data dir: %w", err)
+	}
+
+	db, err := openDB(filepath.Join(dataDir, DBFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log{
+		db:   db,
+		path: filepath.Join(dataDir, LogFileName),
+		log:  logutil.NoopIfNil(log),
+		now:  time.Now,
+		head: GenesisHash,
+	}
+
+	if err := l.resume(); err != nil {
+		return nil, errors.Join(err, l.closeDB())
+	}
+
+	//nolint:gosec // G304: path is the operator-configured audit data dir, not request input
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("audit: open log file: %w", err), l.closeDB())
+	}
+
+	l.file = file
+
+	return l, nil
+}
+
+func openDB(path string) (*gorm.DB, error) {
+	// Same pragmas as the persistence database: wait on a competing writer
+	// instead of failing with SQLITE_BUSY.
+	dsn := path + "?_pragma=busy_timeout(5000)&_txlock=immediate"
+
+	db, err := gorm.Open(gormsqlite.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("audit: open database: %w", err)
+	}
+
+	if migrErr := db.AutoMigrate(&eventRow{}); migrErr != nil {
+		migrErr = fmt.Errorf("audit: migrate database: %w", migrErr)
+		if sqlDB, dbErr := db.DB(); dbErr != nil {
+			return nil, errors.Join(migrErr, dbErr)
+		} else if closeErr := sqlDB.Close(); closeErr != nil {
+			return nil, errors.Join(migrErr, closeErr)
+		}
+
+		return nil, migrErr
+	}
+
+	return db, nil
+}
+
+// resume reads audit.jsonl to restore the chain head and backfill audit.db.
+func (l *Log) resume() error {
+	var indexed int64
+	if err := l.db.Model(&eventRow{}).Select("COALESCE(MAX(seq), 0)").Scan(&indexed).Error; err != nil {
+		return fmt.Errorf("audit: read index head: %w", err)
+	}
+
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("audit: open log file: %w", err)
+	}
+
+	defer f.Close() //nolint:errcheck // read-only file; close error carries no data loss
+
+	verified := VerifyResult{Valid: true, HeadHash: GenesisHash}
+
+	var backfill []eventRow
+
+	scanErr := scanEvents(f, func(line int64, e Event, decodeErr error) bool {
+		if decodeErr != nil {
+			l.log.Error("audit log has an undecodable line; skipping it", "line", line)
+
+			return true
+		}
+
+		if verified.Valid {
+			if reason := checkLink(verified, e, nil); reason != "" {
+				verified.Valid = false
+				l.log.Error("audit log hash chain is broken", "line", line, "reason", reason)
+			} else {
+				verified.Events++
+				verified.HeadHash = e.Hash
+			}
+		}
+
+		l.seq, l.head = e.Seq, e.Hash
+
+		if e.Seq > indexed {
+			backfill = append(backfill, toRow(e))
+		}
+
+		return true
+	})
+	if scanErr != nil {
+		return scanErr
+	}
+
+	if len(backfill) > 0 {
+		if err := l.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(backfill, 500).Error; err != nil {
+			return fmt.Errorf("audit: index %d events: %w", len(backfill), err)
+		}
+
+		l.log.Info("indexed audit events missing from the database", "count", len(backfill))
+	}
+
+	return nil
+}
+
+// Record appends e to the chain and indexes it. A failed append is logged
+// and the event is dropped; a failed index write is logged and repaired on
+// the next Open.
+func (l *Log) Record(ctx context.Context, e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e.Actor == "" {
+		e.Actor = ActorFromContext(ctx)
+	}
+
+	if e.Outcome == "" {
+		e.Outcome = OutcomeSuccess
+	}
+
+	e.Seq = l.seq + 1
+	e.Time = l.now().UTC()
+	e.PrevHash = l.head
+
+	hash, err := HashEvent(e)
+	if err != nil {
+		l.log.Error("failed to hash audit event", "action", e.Action, "error", err)
+
+		return
+	}
+
+	e.Hash = hash
+
+	if err := l.append(e); err != nil {
+		l.log.Error("failed to append audit event", "action", e.Action, "error", err)
+
+		return
+	}
+
+	l.seq, l.head = e.Seq, e.Hash
+
+	row := toRow(e)
+	if err := l.db.WithContext(context.WithoutCancel(ctx)).Create(&row).Error; err != nil {
+		l.log.Warn("failed to index audit event", "seq", e.Seq, "error", err)
+	}
+}
+
+func (l *Log) append(e Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("audit: encode event: %w", err)
+	}
+
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("audit: write log file: %w", err)
+	}
+
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("audit: sync log file: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns the events matching f from audit.db, newest first.
+func (l *Log) Query(ctx context.Context, f Filter) ([]Event, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+
+	limit = min(limit, MaxQueryLimit)
+
+	q := l.db.WithContext(ctx).Model(&eventRow{})
+
+	if !f.Since.IsZero() {
+		q = q.Where("occurred_at >= ?", f.Since.UnixNano())
+	}
+
+	if !f.Until.IsZero() {
+		q = q.Where("occurred_at < ?", f.Until.UnixNano())
+	}
+
+	if f.Actor != "" {
+		q = q.Where("actor = ?", f.Actor)
+	}
+
+	if f.Peer != "" {
+		q = q.Where("peer = ?", f.Peer)
+	}
+
+	if f.Action != "" {
+		q = q.Where("action = ?", f.Action)
+	}
+
+	var rows []eventRow
+	if err := q.Order("seq DESC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("audit: query events: %w", err)
+	}
+
+	events := make([]Event, 0, len(rows))
+
+	for _, row := range rows {
+		e, err := fromRow(row)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// Verify checks the hash chain of audit.jsonl and that the file still ends
+// at the newest event in audit.db, which catches a truncated tail.
+func (l *Log) Verify(ctx context.Context) (VerifyResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		return VerifyResult{}, fmt.Errorf("audit: open log file: %w", err)
+	}
+
+	defer f.Close() //nolint:errcheck // read-only file; close error carries no data loss
+
+	res, err := Verify(f)
+	if err != nil || !res.Valid {
+		return res, err
+	}
+
+	var newest eventRow
+
+	err = l.db.WithContext(ctx).Order("seq DESC").Limit(1).Find(&newest).Error
+	if err != nil {
+		return VerifyResult{}, fmt.Errorf("audit: read index head: %w", err)
+	}
+
+	if newest.Seq > res.Events || (newest.Seq > 0 && newest.Seq == res.Events && newest.Hash != res.HeadHash) {
+		res.Valid = false
+		res.BrokenAt = res.Events + 1
+		res.Reason = fmt.Sprintf("log ends at seq %d but the index holds seq %d", res.Events, newest.Seq)
+	}
+
+	return res, nil
+}
+
+// Close closes audit.jsonl and audit.db.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var err error
+
+	if l.file != nil {
+		if closeErr := l.file.Close(); closeErr != nil {
+			err = fmt.Errorf("audit: close log file: %w", closeErr)
+		}
+	}
+
+	return errors.Join(err, l.closeDB())
+}
+
+func (l *Log) closeDB() error {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return fmt.Errorf("audit: get sql db: %w", err)
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		return fmt.Errorf("audit: close sql db: %w", err)
+	}
+
+	return nil
+}
+
+func toRow(e Event) eventRow {
+	row := eventRow{
+		Seq:        e.Seq,
+		OccurredAt: e.Time.UnixNano(),
+		Action:     e.Action,
+		Outcome:    e.Outcome,
+		Actor:      e.Actor,
+		Peer:       e.Peer,
+		Resource:   e.Resource,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+	}
+
+	if len(e.Details) > 0 {
+		data, _ := json.Marshal(e.Details) //nolint:errchkjson // map[string]string cannot fail to encode
+		row.Details = string(data)
+	}
+
+	return row
+}
+
+func fromRow(row eventRow) (Event, error) {
+	e := Event{
+		Seq:      row.Seq,
+		Time:     time.Unix(0, row.OccurredAt).UTC(),
+		Action:   row.Action,
+		Outcome:  row.Outcome,
+		Actor:    row.Actor,
+		Peer:     row.Peer,
+		Resource: row.Resource,
+		PrevHash: row.PrevHash,
+		Hash:     row.Hash,
+	}
+
+	if row.Details != "" {
+		if err := json.Unmarshal([]byte(row.Details), &e.Details); err != nil {
+			return Event{}, fmt.Errorf("audit: decode details of event %d: %w", row.Seq, err)
+		}
+	}
+
+	return e, nil
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package audit_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
+)
+
+func openLog(t *testing.T, dir string) *audit.Log {
+	t.Helper()
+
+	l, err := audit.Open(dir, nil)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	return l
+}
+
+func closeLog(t *testing.T, l *audit.Log) {
+	t.Helper()
+
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func recordSample(l *audit.Log) {
+	ctx := audit.WithActor(context.Background(), "admin")
+
+	l.Record(ctx, audit.Event{Action: audit.ActionAdminLogin, Resource: "admin"})
+	l.Record(ctx, audit.Event{
+		Action:   audit.ActionShareCreate,
+		Peer:     "bob.example.com",
+		Resource: "share-1",
+		Details:  map[string]string{audit.DetailDirection: audit.DirectionOutgoing},
+	})
+	l.Record(context.Background(), audit.Event{
+		Action:   audit.ActionShareAccept,
+		Actor:    "bob@bob.example.com",
+		Peer:     "bob.example.com",
+		Resource: "share-1",
+	})
+}
+
+func TestLog_RecordChainsAndQueries(t *testing.T) {
+	t.Parallel()
+
+	l := openLog(t, t.TempDir())
+	defer closeLog(t, l)
+
+	recordSample(l)
+
+	all, err := l.Query(context.Background(), audit.Filter{})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	if len(all) != 3 || all[0].Seq != 3 || all[2].Seq != 1 {
+		t.Fatalf("events = %+v, want seq 3..1", all)
+	}
+
+	if all[2].PrevHash != audit.GenesisHash || all[1].PrevHash != all[2].Hash || all[0].PrevHash != all[1].Hash {
+		t.Error("events are not chained by prevHash")
+	}
+
+	if all[1].Actor != "admin" || all[1].Outcome != audit.OutcomeSuccess || all[1].Details[audit.DetailDirection] != audit.DirectionOutgoing {
+		t.Errorf("share.create event = %+v", all[1])
+	}
+
+	for _, e := range all {
+		if hash, err := audit.HashEvent(e); err != nil || hash != e.Hash {
+			t.Errorf("event %d from the index does not rehash to its stored hash", e.Seq)
+		}
+	}
+
+	cases := []struct {
+		name   string
+		filter audit.Filter
+		want   int
+	}{
+		{"actor", audit.Filter{Actor: "admin"}, 2},
+		{"peer", audit.Filter{Peer: "bob.example.com"}, 2},
+		{"action", audit.Filter{Action: audit.ActionShareAccept}, 1},
+		{"limit", audit.Filter{Limit: 1}, 1},
+		{"until past", audit.Filter{Until: time.Now().Add(-time.Hour)}, 0},
+		{"since past", audit.Filter{Since: time.Now().Add(-time.Hour)}, 3},
+	}
+
+	for _, tc := range cases {
+		got, err := l.Query(context.Background(), tc.filter)
+		if err != nil || len(got) != tc.want {
+			t.Errorf("%s: got %d events (%v), want %d", tc.name, len(got), err, tc.want)
+		}
+	}
+
+	res, err := l.Verify(context.Background())
+	if err != nil || !res.Valid || res.Events != 3 || res.HeadHash != all[0].Hash {
+		t.Errorf("Verify = %+v, %v", res, err)
+	}
+}
+
+func TestLog_VerifyDetectsTampering(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		tamper func([]string) []string
+		broken int64
+	}{
+		{"edited line", func(lines []string) []string {
+			lines[1] = strings.Replace(lines[1], "bob.example.com", "eve.example.com", 1)
+
+			return lines
+		}, 2},
+		{"removed line", func(lines []string) []string {
+			return append(lines[:1], lines[2:]...)
+		}, 2},
+		{"truncated tail", func(lines []string) []string {
+			return lines[:2]
+		}, 3},
+	}
+
+	for _, tc := range cases {
+		dir := t.TempDir()
+		l := openLog(t, dir)
+		recordSample(l)
+
+		path := filepath.Join(dir, audit.LogFileName)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read log: %v", err)
+		}
+
+		lines := tc.tamper(strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"))
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+			t.Fatalf("write log: %v", err)
+		}
+
+		res, err := l.Verify(context.Background())
+		if err != nil || res.Valid || res.BrokenAt != tc.broken {
+			t.Errorf("%s: Verify = %+v, %v; want broken at line %d", tc.name, res, err, tc.broken)
+		}
+
+		closeLog(t, l)
+	}
+}
+
+func TestLog_ReopenResumesChainAndRebuildsIndex(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	l := openLog(t, dir)
+	recordSample(l)
+	closeLog(t, l)
+
+	// Losing audit.db must not lose events: the JSONL file is the record.
+	if err := os.Remove(filepath.Join(dir, audit.DBFileName)); err != nil {
+		t.Fatalf("remove audit.db: %v", err)
+	}
+
+	l = openLog(t, dir)
+	defer closeLog(t, l)
+
+	l.Record(context.Background(), audit.Event{Action: audit.ActionTokenIssue, Actor: "bob.example.com"})
+
+	events, err := l.Query(context.Background(), audit.Filter{})
+	if err != nil || len(events) != 4 || events[0].Seq != 4 {
+		t.Fatalf("events after reopen = %+v, %v", events, err)
+	}
+
+	if res, err := l.Verify(context.Background()); err != nil || !res.Valid || res.Events != 4 {
+		t.Errorf("Verify after reopen = %+v, %v", res, err)
+	}
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import "errors"
+
+// AuditConfig holds audit log settings ([audit]).
+type AuditConfig struct {
+	// Enabled records share, invite, token and admin login events to the
+	// hash-chained audit log. Default: false.
+	Enabled bool `toml:"enabled"`
+
+	// DataDir is the directory holding audit.jsonl and audit.db.
+	// Empty falls back to persistence.data_dir.
+	DataDir string `toml:"data_dir"`
+}
+
+// ResolveAuditDataDir returns the directory the audit log uses:
+// audit.data_dir when set, persistence.data_dir otherwise.
+func (c *Config) ResolveAuditDataDir() string {
+	if c.Audit.DataDir != "" {
+		return c.Audit.DataDir
+	}
+
+	return c.Persistence.DataDir
+}
+
+func validateAudit(cfg *Config) error {
+	if cfg.Audit.Enabled && cfg.ResolveAuditDataDir() == "" {
+		return errors.New("audit.data_dir (or persistence.data_dir) is required when audit is enabled")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAudit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantDir string
+		wantErr string
+	}{
+		{name: "disabled needs no dir"},
+		{
+			name:    "falls back to persistence.data_dir",
+			cfg:     Config{Audit: AuditConfig{Enabled: true}, Persistence: PersistenceConfig{DataDir: "/var/lib/ocm"}},
+			wantDir: "/var/lib/ocm",
+		},
+		{
+			name: "own data_dir wins",
+			cfg: Config{
+				Audit:       AuditConfig{Enabled: true, DataDir: "/var/log/ocm-audit"},
+				Persistence: PersistenceConfig{DataDir: "/var/lib/ocm"},
+			},
+			wantDir: "/var/log/ocm-audit",
+		},
+		{
+			name:    "enabled without any dir",
+			cfg:     Config{Audit: AuditConfig{Enabled: true}},
+			wantErr: "audit.data_dir (or persistence.data_dir) is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateAudit(&tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validateAudit() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("validateAudit() error = %v, want nil", err)
+			}
+
+			if got := tt.cfg.ResolveAuditDataDir(); got != tt.wantDir {
+				t.Errorf("ResolveAuditDataDir() = %q, want %q", got, tt.wantDir)
+			}
+		})
+	}
+}
@@ -53,6 +53,12 @@ type Config struct {
 	// Logging configuration
 	Logging LoggingConfig `toml:"logging"`
 
+	// Tracing configuration
+	Tracing TracingConfig `toml:"tracing"`
+
+	// Audit log configuration
+	Audit AuditConfig `toml:"audit"`
+
 	// TokenExchange configuration
 	TokenExchange TokenExchangeConfig `toml:"token_exchange"`
 
@@ -76,6 +82,9 @@ type OCMConfig struct {
 	CodeFlow    CodeFlowConfig    `toml:"code_flow"`
 	PeerMapping PeerMappingConfig `toml:"peer_compat"`
 	Invite      *InviteConfig     `toml:"invite"`
+	Webapp      WebappConfig      `toml:"webapp"`
+	SSH         SSHConfig         `toml:"ssh"`
+	DataTx      DataTxConfig      `toml:"datatx"`
 }
 
 // InviteConfig holds invite-exchange enforcement settings under [ocm.invite].
@@ -151,11 +160,84 @@ type LoggingConfig struct {
 	Level string `toml:"level"`
 }
 
+// TracingConfig holds OpenTelemetry tracing settings.
+type TracingConfig struct {
+	// Enabled turns span recording and export on. Default: false.
+	Enabled bool `toml:"enabled"`
+
+	// Exporter selects where spans go: otlp (OTLP/HTTP collector), stdout,
+	// or file (one JSON span per line). Default: stdout.
+	Exporter string `toml:"exporter"`
+
+	// Endpoint is the OTLP/HTTP collector URL, e.g.
+	// "http://localhost:4318/v1/traces". Required for the otlp exporter.
+	Endpoint string `toml:"endpoint"`
+
+	// Headers are extra HTTP headers sent to the OTLP collector.
+	Headers map[string]string `toml:"headers"`
+
+	// File is the output path for the file exporter.
+	File string `toml:"file"`
+
+	// ServiceName is the service.name resource attribute.
+	// Default: "opencloudmesh-go"
+	ServiceName string `toml:"service_name"`
+
+	// SampleRatio is the fraction of new traces recorded, from 0 to 1.
+	// Traces continued from a sampled inbound traceparent are always recorded.
+	// Default: 1
+	SampleRatio float64 `toml:"sample_ratio"`
+}
+
 // TokenExchangeConfig holds token exchange settings.
 type TokenExchangeConfig struct {
 	// Path is the token exchange endpoint path (relative to /ocm/).
 	// Default: "token"
 	Path string `toml:"path"`
+
+	// AccessTokenTTLSeconds is the lifetime of issued access tokens, reported
+	// to the receiver as expires_in.
+	// Default: 3600
+	AccessTokenTTLSeconds int `toml:"access_token_ttl_seconds"`
+
+	// RefreshTokenTTLSeconds is the lifetime of the refresh token issued
+	// alongside each access token. Refreshing rotates the refresh token but
+	// keeps its original expiry.
+	// Default: 86400
+	RefreshTokenTTLSeconds int `toml:"refresh_token_ttl_seconds"`
+
+	// Store selects where issued access tokens are kept.
+	Store TokenStoreConfig `toml:"store"`
+}
+
+// TokenStoreConfig holds the issued-token store settings
+// ([token_exchange.store]).
+type TokenStoreConfig struct {
+	// Driver is the token store driver: memory, sqlite, redis.
+	// Default: memory (tokens do not survive restart).
+	Driver string `toml:"driver"`
+
+	// DataDir is the directory holding tokens.db for the sqlite driver.
+	// Empty falls back to persistence.data_dir.
+	DataDir string `toml:"data_dir"`
+
+	// CleanupIntervalSeconds is how often expired tokens are purged.
+	// Default: 300.
+	CleanupIntervalSeconds int `toml:"cleanup_interval_seconds"`
+
+	// Redis holds the connection settings for the redis driver.
+	Redis TokenStoreRedisConfig `toml:"redis"`
+}
+
+// TokenStoreRedisConfig holds Redis/Valkey settings for the redis token store.
+type TokenStoreRedisConfig struct {
+	Addr     string `toml:"addr"`
+	Password string `toml:"password"`
+	DB       int    `toml:"db"`
+
+	// KeyPrefix namespaces token keys so replicas sharing a Redis database
+	// with other data do not collide. Default: "ocm:tokens:".
+	KeyPrefix string `toml:"key_prefix"`
 }
 
 // CacheConfig holds cache settings.
@@ -243,12 +325,31 @@ type SignatureConfig struct {
 	// CreatedMaxSkewSeconds is the maximum clock skew into the future verifiers accept.
 	CreatedMaxSkewSeconds int `toml:"created_max_skew_seconds"`
 
+	// ReplayProtection rejects a verified inbound signature already seen
+	// within its created window. Seen signatures are kept in the configured
+	// cache driver; use redis when several replicas share traffic.
+	ReplayProtection bool `toml:"replay_protection"`
+
+	// SignNonce adds a random nonce parameter to outbound signatures, so two
+	// identical requests signed in the same second get distinct signatures.
+	// Off by default: the nonce changes the Signature-Input peers receive.
+	SignNonce bool `toml:"sign_nonce"`
+
 	// AllowedAlgorithms lists permitted asymmetric RFC 9421 algorithms for
 	// inbound verification and outbound SignRequest. The local private key
-	// (default Ed25519) still performs signing; this list must include that
-	// key's algorithm or SignRequest fails before the request is sent.
+	// still performs signing; this list must include that key's algorithm or
+	// SignRequest fails before the request is sent.
 	AllowedAlgorithms []string `toml:"allowed_algorithms"`
 
+	// KeyAlgorithm is the RFC 9421 algorithm newly generated signing keys use
+	// (default: ed25519). JOSE names (EdDSA, ES256, RS256, ...) are accepted
+	// and normalized. An existing key keeps the algorithm of its key type.
+	KeyAlgorithm string `toml:"key_algorithm"`
+
+	// RSAKeyBits is the modulus size for generated RSA signing keys
+	// (default: 3072, minimum: 2048).
+	RSAKeyBits int `toml:"rsa_key_bits"`
+
 	// JwksURI optionally overrides the local JWKS URL advertised in
 	// discovery. Empty derives it from the route inventory as
 	// <endPoint>/jwks.
@@ -257,6 +358,9 @@ type SignatureConfig struct {
 	// MinRSAModulusBits is the local minimum RSA modulus size for inbound
 	// signature verification. Zero means use the default (2048).
 	MinRSAModulusBits int `toml:"min_rsa_modulus_bits"`
+
+	// Rotation controls the signing keyring lifecycle.
+	Rotation SignatureRotationConfig `toml:"rotation"`
 }
 
 // TLSConfig holds TLS-related settings.
@@ -60,14 +60,44 @@ func (c *Config) Redacted() string {
 	redactedFprintf(&sb, "    KidFragment: %q,\n", c.Signature.KidFragment)
 	redactedFprintf(&sb, "    CreatedMaxAgeSeconds: %d,\n", c.Signature.CreatedMaxAgeSeconds)
 	redactedFprintf(&sb, "    CreatedMaxSkewSeconds: %d,\n", c.Signature.CreatedMaxSkewSeconds)
+	redactedFprintf(&sb, "    ReplayProtection: %v,\n", c.Signature.ReplayProtection)
+	redactedFprintf(&sb, "    SignNonce: %v,\n", c.Signature.SignNonce)
 	redactedFprintf(&sb, "    AllowedAlgorithms: %v,\n", c.Signature.AllowedAlgorithms)
 	redactedFprintf(&sb, "    JwksURI: %q,\n", c.Signature.JwksURI)
+	redactedFprintf(&sb, "    KeyAlgorithm: %q,\n", c.Signature.KeyAlgorithm)
+	redactedFprintf(&sb, "    RSAKeyBits: %d,\n", c.Signature.RSAKeyBits)
+	redactedFprintf(&sb, "    Rotation: {IntervalSeconds: %d, PublishAheadSeconds: %d, GraceSeconds: %d},\n",
+		c.Signature.Rotation.IntervalSeconds, c.Signature.Rotation.PublishAheadSeconds, c.Signature.Rotation.GraceSeconds)
 	redactedWriteString(&sb, "  },\n")
 	redactedWriteString(&sb, "  Logging: {\n")
 	redactedFprintf(&sb, "    Level: %q,\n", c.Logging.Level)
 	redactedWriteString(&sb, "  },\n")
+	redactedWriteString(&sb, "  Tracing: {\n")
+	redactedFprintf(&sb, "    Enabled: %v,\n", c.Tracing.Enabled)
+	redactedFprintf(&sb, "    Exporter: %q,\n", c.Tracing.Exporter)
+	redactedFprintf(&sb, "    Endpoint: %q,\n", c.Tracing.Endpoint)
+	redactedFprintf(&sb, "    HeadersCount: %d,\n", len(c.Tracing.Headers))
+	redactedFprintf(&sb, "    File: %q,\n", c.Tracing.File)
+	redactedFprintf(&sb, "    ServiceName: %q,\n", c.Tracing.ServiceName)
+	redactedFprintf(&sb, "    SampleRatio: %v,\n", c.Tracing.SampleRatio)
+	redactedWriteString(&sb, "  },\n")
+	redactedWriteString(&sb, "  Audit: {\n")
+	redactedFprintf(&sb, "    Enabled: %v,\n", c.Audit.Enabled)
+	redactedFprintf(&sb, "    DataDir: %q,\n", c.Audit.DataDir)
+	redactedWriteString(&sb, "  },\n")
 	redactedWriteString(&sb, "  TokenExchange: {\n")
 	redactedFprintf(&sb, "    Path: %q,\n", c.TokenExchange.Path)
+	redactedFprintf(&sb, "    AccessTokenTTLSeconds: %d,\n", c.TokenExchange.AccessTokenTTLSeconds)
+	redactedFprintf(&sb, "    RefreshTokenTTLSeconds: %d,\n", c.TokenExchange.RefreshTokenTTLSeconds)
+	redactedWriteString(&sb, "    Store: {\n")
+	redactedFprintf(&sb, "      Driver: %q,\n", c.TokenExchange.Store.Driver)
+	redactedFprintf(&sb, "      DataDir: %q,\n", c.TokenExchange.Store.DataDir)
+	redactedFprintf(&sb, "      CleanupIntervalSeconds: %d,\n", c.TokenExchange.Store.CleanupIntervalSeconds)
+	redactedFprintf(&sb, "      Redis.Addr: %q,\n", c.TokenExchange.Store.Redis.Addr)
+	redactedWriteString(&sb, "      Redis.Password: [REDACTED],\n")
+	redactedFprintf(&sb, "      Redis.DB: %d,\n", c.TokenExchange.Store.Redis.DB)
+	redactedFprintf(&sb, "      Redis.KeyPrefix: %q,\n", c.TokenExchange.Store.Redis.KeyPrefix)
+	redactedWriteString(&sb, "    },\n")
 	redactedWriteString(&sb, "  },\n")
 	redactedWriteString(&sb, "  HTTP: {\n")
 	redactedFprintf(&sb, "    ServicesCount: %d,\n", len(c.HTTP.Services))
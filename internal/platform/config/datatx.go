@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultDataTxStorageDir is the CWD-relative directory holding datatx
+// copies of users without a storage root, one subdirectory per user id.
+const DefaultDataTxStorageDir = ".ocm/transfers"
+
+// DataTxConfig holds the datatx transfer settings under [ocm.datatx].
+type DataTxConfig struct {
+	// StorageDir receives transfers for users without a storage root, under
+	// StorageDir/{userId}. Users with a storage root receive them there.
+	StorageDir string `toml:"storage_dir"`
+
+	// MaxConcurrent caps how many transfers download at once. 0 uses the
+	// built-in default.
+	MaxConcurrent int `toml:"max_concurrent"`
+
+	// MaxAttempts is the number of attempts before a transfer is marked
+	// failed. 0 uses the built-in default.
+	MaxAttempts int `toml:"max_attempts"`
+
+	// MaxBytes caps the size of one transfer. 0 uses the built-in default.
+	MaxBytes int64 `toml:"max_bytes"`
+}
+
+func validateDataTx(cfg *Config) error {
+	dc := cfg.OCM.DataTx
+
+	if strings.TrimSpace(dc.StorageDir) == "" {
+		return errors.New("ocm.datatx.storage_dir must not be empty")
+	}
+
+	if dc.MaxConcurrent < 0 {
+		return fmt.Errorf("invalid ocm.datatx.max_concurrent %d: must not be negative", dc.MaxConcurrent)
+	}
+
+	if dc.MaxAttempts < 0 {
+		return fmt.Errorf("invalid ocm.datatx.max_attempts %d: must not be negative", dc.MaxAttempts)
+	}
+
+	if dc.MaxBytes < 0 {
+		return fmt.Errorf("invalid ocm.datatx.max_bytes %d: must not be negative", dc.MaxBytes)
+	}
+
+	return nil
+}
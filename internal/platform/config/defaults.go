@@ -50,6 +50,8 @@ const (
 	DefaultSignatureCreatedMaxAge  = 300
 	DefaultSignatureCreatedMaxSkew = 60
 	DefaultMinRSAModulusBits       = 2048
+	DefaultSignatureKeyAlgorithm   = sigalg.Ed25519
+	DefaultSignatureRSAKeyBits     = 3072
 )
 
 // Test-oriented outbound and wait defaults (integration harness + unit tests).
@@ -117,5 +119,11 @@ func DefaultSignatureConfig() SignatureConfig {
 		CreatedMaxSkewSeconds: DefaultSignatureCreatedMaxSkew,
 		AllowedAlgorithms:     append([]string(nil), sigalg.DefaultAllowed()...),
 		MinRSAModulusBits:     DefaultMinRSAModulusBits,
+		KeyAlgorithm:          DefaultSignatureKeyAlgorithm,
+		RSAKeyBits:            DefaultSignatureRSAKeyBits,
+		Rotation: SignatureRotationConfig{
+			PublishAheadSeconds: DefaultSignatureRotationPublishAheadSeconds,
+			GraceSeconds:        DefaultSignatureRotationGraceSeconds,
+		},
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -137,6 +138,10 @@ func applyTLSDirDefaults(cfg *Config, md toml.MetaData) error {
 		cfg.Signature.KeyPath = filepath.Join(tlsDir, "keys", "signing.pem")
 	}
 
+	if !md.IsDefined("ocm", "ssh", "host_key_path") {
+		cfg.OCM.SSH.HostKeyPath = filepath.Join(tlsDir, "keys", filepath.Base(DefaultSSHHostKeyPath))
+	}
+
 	return nil
 }
 
@@ -287,6 +292,16 @@ func applySignatureDefaults(cfg *Config) {
 	if len(cfg.Signature.AllowedAlgorithms) == 0 {
 		cfg.Signature.AllowedAlgorithms = append([]string(nil), defaults.AllowedAlgorithms...)
 	}
+
+	if cfg.Signature.KeyAlgorithm == "" {
+		cfg.Signature.KeyAlgorithm = defaults.KeyAlgorithm
+	}
+
+	if cfg.Signature.RSAKeyBits == 0 {
+		cfg.Signature.RSAKeyBits = defaults.RSAKeyBits
+	}
+
+	applySignatureRotationDefaults(&cfg.Signature.Rotation)
 }
 
 func normalizeAllowedAlgorithm(alg string) (string, error) {
@@ -342,5 +357,20 @@ func normalizeSignatureConfig(sig *SignatureConfig) error {
 
 	sig.AllowedAlgorithms = normalized
 
+	keyAlgorithm, err := normalizeAllowedAlgorithm(sig.KeyAlgorithm)
+	if err != nil {
+		return fmt.Errorf("signature.key_algorithm: %w", err)
+	}
+
+	if !slices.Contains(sig.AllowedAlgorithms, keyAlgorithm) {
+		return fmt.Errorf("signature.key_algorithm %q is not in signature.allowed_algorithms", keyAlgorithm)
+	}
+
+	sig.KeyAlgorithm = keyAlgorithm
+
+	if sig.RSAKeyBits < sigalg.MinRSAModulusBits {
+		return fmt.Errorf("signature.rsa_key_bits must be at least %d, got %d", sigalg.MinRSAModulusBits, sig.RSAKeyBits)
+	}
+
 	return nil
 }
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoad_OCMDataTx_Defaults(t *testing.T) {
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+	cfg, err := Load(LoaderOptions{})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := DataTxConfig{StorageDir: DefaultDataTxStorageDir}
+	if cfg.OCM.DataTx != want {
+		t.Errorf("datatx = %+v, want %+v", cfg.OCM.DataTx, want)
+	}
+}
+
+func TestLoad_OCMDataTx_Overlay(t *testing.T) {
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+	path := writeTempConfig(t, `
+mode = "dev"
+
+[ocm.datatx]
+storage_dir = "/srv/ocm/transfers"
+max_concurrent = 4
+max_attempts = 8
+max_bytes = 1048576
+`)
+
+	cfg, err := Load(LoaderOptions{ConfigPath: path})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := DataTxConfig{StorageDir: "/srv/ocm/transfers", MaxConcurrent: 4, MaxAttempts: 8, MaxBytes: 1 << 20}
+	if cfg.OCM.DataTx != want {
+		t.Errorf("datatx = %+v, want %+v", cfg.OCM.DataTx, want)
+	}
+}
+
+func TestLoad_OCMDataTx_Invalid(t *testing.T) {
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+	tests := []struct {
+		name    string
+		section string
+		wantErr string
+	}{
+		{"blank storage dir", `storage_dir = "  "`, "ocm.datatx.storage_dir"},
+		{"negative concurrency", `max_concurrent = -1`, "ocm.datatx.max_concurrent"},
+		{"negative attempts", `max_attempts = -2`, "ocm.datatx.max_attempts"},
+		{"negative byte limit", `max_bytes = -1`, "ocm.datatx.max_bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTempConfig(t, "mode = \"dev\"\n\n[ocm.datatx]\n"+tt.section+"\n")
+
+			_, err := Load(LoaderOptions{ConfigPath: path})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load() error = %v, want it to mention %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoad_OCMSSH_DefaultDisabled(t *testing.T) {
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+	cfg, err := Load(LoaderOptions{})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.OCM.SSH.Enabled() || cfg.OCM.SSH.Advertised(cfg.PublicOrigin) != "" {
+		t.Errorf("ssh must be disabled when [ocm.ssh] is unset: %+v", cfg.OCM.SSH)
+	}
+
+	if cfg.OCM.SSH.HostKeyPath != DefaultSSHHostKeyPath {
+		t.Errorf("host key path = %q, want %q", cfg.OCM.SSH.HostKeyPath, DefaultSSHHostKeyPath)
+	}
+}
+
+func TestLoad_OCMSSH_AdvertisedDefaultsToPublicHost(t *testing.T) {
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+	path := writeTempConfig(t, `
+mode = "dev"
+public_origin = "https://cloud.example.org:9200"
+
+[ocm.ssh]
+listen_addr = ":2022"
+`)
+
+	cfg, err := Load(LoaderOptions{ConfigPath: path})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := cfg.OCM.SSH.Advertised(cfg.PublicOrigin); got != "cloud.example.org:2022" {
+		t.Errorf("Advertised() = %q, want cloud.example.org:2022", got)
+	}
+}
+
+func TestLoad_OCMSSH_ExplicitAdvertisedAndTLSDir(t *testing.T) {
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+	path := writeTempConfig(t, `
+mode = "dev"
+public_origin = "https://cloud.example.org"
+
+[tls]
+tls_dir = "/data/tls"
+
+[ocm.ssh]
+listen_addr = "127.0.0.1:0"
+advertised_addr = "sftp.example.org:22"
+`)
+
+	cfg, err := Load(LoaderOptions{ConfigPath: path})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := cfg.OCM.SSH.Advertised(cfg.PublicOrigin); got != "sftp.example.org:22" {
+		t.Errorf("Advertised() = %q, want sftp.example.org:22", got)
+	}
+
+	if cfg.OCM.SSH.HostKeyPath != "/data/tls/keys/ssh_host_ed25519.pem" {
+		t.Errorf("host key path = %q, want it re-rooted under tls_dir", cfg.OCM.SSH.HostKeyPath)
+	}
+}
+
+func TestLoad_OCMSSH_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		wantErr string
+	}{
+		{name: "listen without port", section: `listen_addr = "localhost"`, wantErr: "must be host:port"},
+		{name: "ephemeral port without advertised", section: `listen_addr = "127.0.0.1:0"`, wantErr: "advertised_addr is required"},
+		{
+			name:    "advertised without host",
+			section: "listen_addr = \":2022\"\nadvertised_addr = \":2022\"",
+			wantErr: "invalid ocm.ssh.advertised_addr",
+		},
+		{
+			name:    "advertised bad port",
+			section: "listen_addr = \":2022\"\nadvertised_addr = \"h:0\"",
+			wantErr: "port must be 1-65535",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+			path := writeTempConfig(t, "mode = \"dev\"\npublic_origin = \"https://cloud.example.org\"\n\n[ocm.ssh]\n"+tt.section+"\n")
+
+			_, err := Load(LoaderOptions{ConfigPath: path})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
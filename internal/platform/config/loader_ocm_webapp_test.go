@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoad_OCMWebapp_DefaultDisabled(t *testing.T) {
+	// Clear ambient env override so the default load is deterministic.
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+	cfg, err := Load(LoaderOptions{})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.OCM.Webapp.Enabled() {
+		t.Error("webapp arm must be disabled when [ocm.webapp] is unset")
+	}
+
+	if !slices.Equal(cfg.OCM.Webapp.Targets, []string{DefaultWebappTarget}) {
+		t.Errorf("default targets = %v, want [%s]", cfg.OCM.Webapp.Targets, DefaultWebappTarget)
+	}
+}
+
+func TestLoad_OCMWebapp_Overlay(t *testing.T) {
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+	path := writeTempConfig(t, `
+mode = "dev"
+
+[ocm.webapp]
+viewer_url_template = "https://viewer.example.org/open/{webdavId}/{name}"
+targets = ["iframe", "blank"]
+`)
+
+	cfg, err := Load(LoaderOptions{ConfigPath: path})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if !cfg.OCM.Webapp.Enabled() || !slices.Equal(cfg.OCM.Webapp.Targets, []string{"iframe", "blank"}) {
+		t.Fatalf("webapp config = %+v", cfg.OCM.Webapp)
+	}
+
+	if got := cfg.OCM.Webapp.ViewerURL("id/1", "my file.txt"); got != "https://viewer.example.org/open/id%2F1/my%20file.txt" {
+		t.Errorf("ViewerURL() = %q", got)
+	}
+}
+
+func TestLoad_OCMWebapp_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		wantErr string
+	}{
+		{
+			name:    "missing webdavId placeholder",
+			section: `viewer_url_template = "https://viewer.example.org/open"`,
+			wantErr: "must contain {webdavId}",
+		},
+		{
+			name:    "relative template",
+			section: `viewer_url_template = "/open/{webdavId}"`,
+			wantErr: "absolute http(s) URL",
+		},
+		{
+			name:    "non-http scheme",
+			section: `viewer_url_template = "javascript:{webdavId}"`,
+			wantErr: "absolute http(s) URL",
+		},
+		{
+			name: "blank target",
+			section: `viewer_url_template = "https://viewer.example.org/{webdavId}"
+targets = ["blank", " "]`,
+			wantErr: "must not contain empty entries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+
+			path := writeTempConfig(t, "mode = \"dev\"\n\n[ocm.webapp]\n"+tt.section+"\n")
+
+			_, err := Load(LoaderOptions{ConfigPath: path})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestTokenExchangeConfig_DefaultsPerMode(t *testing.T) {
@@ -137,3 +138,221 @@ func TestLoad_TokenExchangeConfig_DefaultPathWhenSectionMissing(t *testing.T) {
 		t.Errorf("expected token_exchange.path 'token' by default, got %q", cfg.TokenExchange.Path)
 	}
 }
+
+func TestTokenExchangeConfig_DefaultTTLs(t *testing.T) {
+	t.Parallel()
+
+	for _, cfg := range []*Config{StrictConfig(), DevConfig()} {
+		if got := cfg.TokenExchange.AccessTokenTTL(); got != time.Hour {
+			t.Errorf("%s: AccessTokenTTL() = %v, want 1h", cfg.Mode, got)
+		}
+
+		if got := cfg.TokenExchange.RefreshTokenTTL(); got != 24*time.Hour {
+			t.Errorf("%s: RefreshTokenTTL() = %v, want 24h", cfg.Mode, got)
+		}
+	}
+}
+
+func TestLoad_TokenExchangeConfig_TTLsFromTOML(t *testing.T) {
+	// Clear ambient env override so the token exchange load is deterministic.
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+
+	tomlContent := `
+mode = "dev"
+
+[token_exchange]
+access_token_ttl_seconds = 300
+refresh_token_ttl_seconds = 7200
+`
+	if err := os.WriteFile(configPath, []byte(tomlContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(LoaderOptions{ConfigPath: configPath})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := cfg.TokenExchange.AccessTokenTTL(); got != 5*time.Minute {
+		t.Errorf("AccessTokenTTL() = %v, want 5m", got)
+	}
+
+	if got := cfg.TokenExchange.RefreshTokenTTL(); got != 2*time.Hour {
+		t.Errorf("RefreshTokenTTL() = %v, want 2h", got)
+	}
+
+	if !strings.Contains(cfg.Redacted(), "AccessTokenTTLSeconds: 300") {
+		t.Error("Redacted() should print the access token TTL")
+	}
+}
+
+func TestLoad_TokenExchangeConfig_InvalidTTL_FailsFast(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		wantErr string
+	}{
+		{"negative access ttl", "access_token_ttl_seconds = -1", "access_token_ttl_seconds"},
+		{"negative refresh ttl", "refresh_token_ttl_seconds = -60", "refresh_token_ttl_seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Clear ambient env override so the validation is deterministic.
+			t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "config.toml")
+
+			tomlContent := "mode = \"dev\"\n\n[token_exchange]\n" + tt.section + "\n"
+			if err := os.WriteFile(configPath, []byte(tomlContent), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			_, err := Load(LoaderOptions{ConfigPath: configPath})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load() error = %v, want mention of %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenStoreConfig_DefaultsToMemory(t *testing.T) {
+	t.Parallel()
+
+	for _, cfg := range []*Config{StrictConfig(), DevConfig()} {
+		store := cfg.TokenExchange.Store
+		if store.Driver != TokenStoreDriverMemory {
+			t.Errorf("%s: token_exchange.store.driver = %q, want memory", cfg.Mode, store.Driver)
+		}
+
+		if store.CleanupIntervalSeconds != DefaultTokenStoreCleanupIntervalSeconds {
+			t.Errorf("%s: cleanup_interval_seconds = %d, want %d",
+				cfg.Mode, store.CleanupIntervalSeconds, DefaultTokenStoreCleanupIntervalSeconds)
+		}
+
+		if store.Redis.KeyPrefix != DefaultTokenStoreRedisKeyPrefix {
+			t.Errorf("%s: redis.key_prefix = %q, want %q", cfg.Mode, store.Redis.KeyPrefix, DefaultTokenStoreRedisKeyPrefix)
+		}
+	}
+}
+
+func TestLoad_TokenStoreConfig_FromTOML(t *testing.T) {
+	// Clear ambient env override so the token store load is deterministic.
+	t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+
+	tomlContent := `
+mode = "dev"
+
+[token_exchange.store]
+driver = "redis"
+cleanup_interval_seconds = 60
+
+[token_exchange.store.redis]
+addr = "valkey.internal:6379"
+password = "hunter2"
+db = 3
+key_prefix = "peer-a:tokens:"
+`
+	if err := os.WriteFile(configPath, []byte(tomlContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(LoaderOptions{ConfigPath: configPath})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	store := cfg.TokenExchange.Store
+	if store.Driver != TokenStoreDriverRedis || store.CleanupIntervalSeconds != 60 {
+		t.Errorf("driver/cleanup = %q/%d, want redis/60", store.Driver, store.CleanupIntervalSeconds)
+	}
+
+	want := TokenStoreRedisConfig{Addr: "valkey.internal:6379", Password: "hunter2", DB: 3, KeyPrefix: "peer-a:tokens:"}
+	if store.Redis != want {
+		t.Errorf("redis = %+v, want %+v", store.Redis, want)
+	}
+
+	if cfg.TokenExchange.Path != "token" {
+		t.Errorf("token_exchange.path = %q, want default 'token' kept", cfg.TokenExchange.Path)
+	}
+
+	redacted := cfg.Redacted()
+	if strings.Contains(redacted, "hunter2") {
+		t.Error("Redacted() must not print the token store redis password")
+	}
+
+	if !strings.Contains(redacted, `Redis.Addr: "valkey.internal:6379"`) {
+		t.Errorf("Redacted() should print the redis addr, got:\n%s", redacted)
+	}
+}
+
+func TestLoad_TokenStoreConfig_SQLiteDataDir(t *testing.T) {
+	t.Parallel()
+
+	cfg := DevConfig()
+	cfg.TokenExchange.Store.Driver = TokenStoreDriverSQLite
+
+	if err := validateTokenStore(cfg); err == nil || !strings.Contains(err.Error(), "token_exchange.store.data_dir") {
+		t.Fatalf("sqlite without any data dir: err = %v, want data_dir error", err)
+	}
+
+	cfg.Persistence.DataDir = "/var/lib/ocm"
+	if err := validateTokenStore(cfg); err != nil {
+		t.Fatalf("sqlite with persistence.data_dir: %v", err)
+	}
+
+	if got := cfg.ResolveTokenStoreDataDir(); got != "/var/lib/ocm" {
+		t.Errorf("ResolveTokenStoreDataDir() = %q, want persistence.data_dir", got)
+	}
+
+	cfg.TokenExchange.Store.DataDir = "/var/lib/ocm-tokens"
+	if got := cfg.ResolveTokenStoreDataDir(); got != "/var/lib/ocm-tokens" {
+		t.Errorf("ResolveTokenStoreDataDir() = %q, want token_exchange.store.data_dir", got)
+	}
+}
+
+func TestValidateTokenStore_RedisRequiresAddr(t *testing.T) {
+	t.Parallel()
+
+	cfg := DevConfig()
+	cfg.TokenExchange.Store.Driver = TokenStoreDriverRedis
+	cfg.TokenExchange.Store.Redis.Addr = ""
+
+	if err := validateTokenStore(cfg); err == nil || !strings.Contains(err.Error(), "token_exchange.store.redis.addr") {
+		t.Fatalf("redis without addr: err = %v, want addr error", err)
+	}
+}
+
+func TestLoad_TokenStoreConfig_Invalid_FailsFast(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		wantErr string
+	}{
+		{"unknown driver", `driver = "etcd"`, "token_exchange.store.driver"},
+		{"negative cleanup interval", `cleanup_interval_seconds = -5`, "cleanup_interval_seconds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Clear ambient env override so the validation is deterministic.
+			t.Setenv("OCM_CONFIG_OUTBOUND_HTTP_USE_ENV_FALLBACK", "")
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "config.toml")
+
+			tomlContent := "mode = \"dev\"\n\n[token_exchange.store]\n" + tt.section + "\n"
+			if err := os.WriteFile(configPath, []byte(tomlContent), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			_, err := Load(LoaderOptions{ConfigPath: configPath})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load() error = %v, want mention of %s", err, tt.wantErr)
+			}
+		})
+	}
+}
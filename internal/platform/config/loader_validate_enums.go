@@ -237,13 +237,21 @@ func validateEnums(cfg *Config) error {
 		validateSSRFRoutePolicyRef,
 		validateSignatureFields,
 		validateSignatureJwksURI,
+		validateSignatureRotation,
 		validateCacheDriver,
 		validatePeerTrust,
 		validateLoggingLevel,
 		validateTokenExchangePath,
+		validateTokenTTLs,
 		validatePersistenceBackend,
+		validateTokenStore,
+		validateTracing,
+		validateAudit,
 		validateCompatibilityScope,
 		validateDiscoveryPolicies,
+		validateWebapp,
+		validateSSH,
+		validateDataTx,
 		validateSSRFRoutePolicyGuardrails,
 		validateStrictModeGuardrails,
 		validateRatelimitConfig,
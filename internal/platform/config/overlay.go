@@ -54,6 +54,8 @@ type fileConfig struct {
 	Cache         *cacheConfig            `toml:"cache"`
 	PeerTrust     *peerTrustConfig        `toml:"peer_trust"`
 	Logging       *loggingConfig          `toml:"logging"`
+	Tracing       *tracingFileConfig      `toml:"tracing"`
+	Audit         *auditFileConfig        `toml:"audit"`
 	TokenExchange *tokenExchangeConfig    `toml:"token_exchange"`
 	HTTP          *httpFileConfig         `toml:"http"`
 	Persistence   *persistenceFileConfig  `toml:"persistence"`
@@ -67,6 +69,30 @@ type ocmFileConfig struct {
 	CodeFlow           *CodeFlowConfig      `toml:"code_flow"`
 	PeerMapping        *PeerMappingConfig   `toml:"peer_compat"`
 	Invite             *inviteFileConfig    `toml:"invite"`
+	Webapp             *webappFileConfig    `toml:"webapp"`
+	SSH                *sshFileConfig       `toml:"ssh"`
+	DataTx             *dataTxFileConfig    `toml:"datatx"`
+}
+
+// dataTxFileConfig holds datatx transfer settings from TOML.
+type dataTxFileConfig struct {
+	StorageDir    string `toml:"storage_dir"`
+	MaxConcurrent int    `toml:"max_concurrent"`
+	MaxAttempts   int    `toml:"max_attempts"`
+	MaxBytes      int64  `toml:"max_bytes"`
+}
+
+// sshFileConfig holds built-in SFTP server settings from TOML.
+type sshFileConfig struct {
+	ListenAddr     string `toml:"listen_addr"`
+	AdvertisedAddr string `toml:"advertised_addr"`
+	HostKeyPath    string `toml:"host_key_path"`
+}
+
+// webappFileConfig holds outbound webapp settings from TOML.
+type webappFileConfig struct {
+	ViewerURLTemplate string   `toml:"viewer_url_template"`
+	Targets           []string `toml:"targets"`
 }
 
 // inviteFileConfig holds invite enforcement settings from TOML.
@@ -91,9 +117,45 @@ type loggingConfig struct {
 	Level string `toml:"level"`
 }
 
+// tracingFileConfig holds tracing settings from TOML.
+type tracingFileConfig struct {
+	Enabled     *bool             `toml:"enabled"`
+	Exporter    string            `toml:"exporter"`
+	Endpoint    string            `toml:"endpoint"`
+	Headers     map[string]string `toml:"headers"`
+	File        string            `toml:"file"`
+	ServiceName string            `toml:"service_name"`
+	SampleRatio *float64          `toml:"sample_ratio"`
+}
+
+// auditFileConfig holds audit log settings from TOML.
+type auditFileConfig struct {
+	Enabled *bool  `toml:"enabled"`
+	DataDir string `toml:"data_dir"`
+}
+
 // tokenExchangeConfig holds token exchange settings from TOML.
 type tokenExchangeConfig struct {
-	Path string `toml:"path"`
+	Path                   string                `toml:"path"`
+	AccessTokenTTLSeconds  int                   `toml:"access_token_ttl_seconds"`
+	RefreshTokenTTLSeconds int                   `toml:"refresh_token_ttl_seconds"`
+	Store                  *tokenStoreFileConfig `toml:"store"`
+}
+
+// tokenStoreFileConfig holds issued-token store settings from TOML.
+type tokenStoreFileConfig struct {
+	Driver                 string                     `toml:"driver"`
+	DataDir                string                     `toml:"data_dir"`
+	CleanupIntervalSeconds int                        `toml:"cleanup_interval_seconds"`
+	Redis                  *tokenStoreRedisFileConfig `toml:"redis"`
+}
+
+// tokenStoreRedisFileConfig holds redis token store settings from TOML.
+type tokenStoreRedisFileConfig struct {
+	Addr      string `toml:"addr"`
+	Password  string `toml:"password"`
+	DB        int    `toml:"db"`
+	KeyPrefix string `toml:"key_prefix"`
 }
 
 // cacheConfig holds cache settings from TOML.
@@ -286,6 +348,14 @@ func overlaySignatureConfig(cfg *Config, fc *SignatureConfig) {
 		cfg.Signature.CreatedMaxSkewSeconds = fc.CreatedMaxSkewSeconds
 	}
 
+	if fc.ReplayProtection {
+		cfg.Signature.ReplayProtection = true
+	}
+
+	if fc.SignNonce {
+		cfg.Signature.SignNonce = true
+	}
+
 	if len(fc.AllowedAlgorithms) > 0 {
 		cfg.Signature.AllowedAlgorithms = fc.AllowedAlgorithms
 	}
@@ -293,6 +363,26 @@ func overlaySignatureConfig(cfg *Config, fc *SignatureConfig) {
 	if fc.JwksURI != "" {
 		cfg.Signature.JwksURI = fc.JwksURI
 	}
+
+	if fc.KeyAlgorithm != "" {
+		cfg.Signature.KeyAlgorithm = fc.KeyAlgorithm
+	}
+
+	if fc.RSAKeyBits != 0 {
+		cfg.Signature.RSAKeyBits = fc.RSAKeyBits
+	}
+
+	if fc.Rotation.IntervalSeconds != 0 {
+		cfg.Signature.Rotation.IntervalSeconds = fc.Rotation.IntervalSeconds
+	}
+
+	if fc.Rotation.PublishAheadSeconds != 0 {
+		cfg.Signature.Rotation.PublishAheadSeconds = fc.Rotation.PublishAheadSeconds
+	}
+
+	if fc.Rotation.GraceSeconds != 0 {
+		cfg.Signature.Rotation.GraceSeconds = fc.Rotation.GraceSeconds
+	}
 }
 
 func overlayCacheConfig(cfg *Config, fc *cacheConfig) {
@@ -350,6 +440,54 @@ func overlayLoggingConfig(cfg *Config, fc *loggingConfig) {
 	}
 }
 
+func overlayAuditConfig(cfg *Config, fc *auditFileConfig) {
+	if fc == nil {
+		return
+	}
+
+	if fc.Enabled != nil {
+		cfg.Audit.Enabled = *fc.Enabled
+	}
+
+	if fc.DataDir != "" {
+		cfg.Audit.DataDir = fc.DataDir
+	}
+}
+
+func overlayTracingConfig(cfg *Config, fc *tracingFileConfig) {
+	if fc == nil {
+		return
+	}
+
+	if fc.Enabled != nil {
+		cfg.Tracing.Enabled = *fc.Enabled
+	}
+
+	if fc.Exporter != "" {
+		cfg.Tracing.Exporter = fc.Exporter
+	}
+
+	if fc.Endpoint != "" {
+		cfg.Tracing.Endpoint = fc.Endpoint
+	}
+
+	if fc.Headers != nil {
+		cfg.Tracing.Headers = fc.Headers
+	}
+
+	if fc.File != "" {
+		cfg.Tracing.File = fc.File
+	}
+
+	if fc.ServiceName != "" {
+		cfg.Tracing.ServiceName = fc.ServiceName
+	}
+
+	if fc.SampleRatio != nil {
+		cfg.Tracing.SampleRatio = *fc.SampleRatio
+	}
+}
+
 func overlayTokenExchangeConfig(cfg *Config, fc *tokenExchangeConfig) {
 	if fc == nil {
 		return
@@ -358,6 +496,54 @@ func overlayTokenExchangeConfig(cfg *Config, fc *tokenExchangeConfig) {
 	if fc.Path != "" {
 		cfg.TokenExchange.Path = fc.Path
 	}
+
+	if fc.AccessTokenTTLSeconds != 0 {
+		cfg.TokenExchange.AccessTokenTTLSeconds = fc.AccessTokenTTLSeconds
+	}
+
+	if fc.RefreshTokenTTLSeconds != 0 {
+		cfg.TokenExchange.RefreshTokenTTLSeconds = fc.RefreshTokenTTLSeconds
+	}
+
+	overlayTokenStoreConfig(&cfg.TokenExchange.Store, fc.Store)
+}
+
+func overlayTokenStoreConfig(cfg *TokenStoreConfig, fc *tokenStoreFileConfig) {
+	if fc == nil {
+		return
+	}
+
+	if fc.Driver != "" {
+		cfg.Driver = fc.Driver
+	}
+
+	if fc.DataDir != "" {
+		cfg.DataDir = fc.DataDir
+	}
+
+	if fc.CleanupIntervalSeconds != 0 {
+		cfg.CleanupIntervalSeconds = fc.CleanupIntervalSeconds
+	}
+
+	if fc.Redis == nil {
+		return
+	}
+
+	if fc.Redis.Addr != "" {
+		cfg.Redis.Addr = fc.Redis.Addr
+	}
+
+	if fc.Redis.Password != "" {
+		cfg.Redis.Password = fc.Redis.Password
+	}
+
+	if fc.Redis.DB != 0 {
+		cfg.Redis.DB = fc.Redis.DB
+	}
+
+	if fc.Redis.KeyPrefix != "" {
+		cfg.Redis.KeyPrefix = fc.Redis.KeyPrefix
+	}
 }
 
 func overlayHTTPConfig(cfg *Config, fc *httpFileConfig) {
@@ -53,6 +53,60 @@ func overlayOCMInviteConfig(cfg *Config, fc *inviteFileConfig) {
 	cfg.OCM.Invite.EnforceMustInvite = fc.EnforceMustInvite
 }
 
+func overlayOCMWebappConfig(cfg *Config, fc *webappFileConfig) {
+	if fc == nil {
+		return
+	}
+
+	if fc.ViewerURLTemplate != "" {
+		cfg.OCM.Webapp.ViewerURLTemplate = fc.ViewerURLTemplate
+	}
+
+	if len(fc.Targets) > 0 {
+		cfg.OCM.Webapp.Targets = fc.Targets
+	}
+}
+
+func overlayOCMSSHConfig(cfg *Config, fc *sshFileConfig) {
+	if fc == nil {
+		return
+	}
+
+	if fc.ListenAddr != "" {
+		cfg.OCM.SSH.ListenAddr = fc.ListenAddr
+	}
+
+	if fc.AdvertisedAddr != "" {
+		cfg.OCM.SSH.AdvertisedAddr = fc.AdvertisedAddr
+	}
+
+	if fc.HostKeyPath != "" {
+		cfg.OCM.SSH.HostKeyPath = fc.HostKeyPath
+	}
+}
+
+func overlayOCMDataTxConfig(cfg *Config, fc *dataTxFileConfig) {
+	if fc == nil {
+		return
+	}
+
+	if fc.StorageDir != "" {
+		cfg.OCM.DataTx.StorageDir = fc.StorageDir
+	}
+
+	if fc.MaxConcurrent != 0 {
+		cfg.OCM.DataTx.MaxConcurrent = fc.MaxConcurrent
+	}
+
+	if fc.MaxAttempts != 0 {
+		cfg.OCM.DataTx.MaxAttempts = fc.MaxAttempts
+	}
+
+	if fc.MaxBytes != 0 {
+		cfg.OCM.DataTx.MaxBytes = fc.MaxBytes
+	}
+}
+
 func overlayOCMConfig(cfg *Config, fc *ocmFileConfig) {
 	if fc == nil {
 		return
@@ -63,6 +117,9 @@ func overlayOCMConfig(cfg *Config, fc *ocmFileConfig) {
 	overlayOCMCodeFlowConfig(cfg, fc.CodeFlow)
 	overlayOCMInviteConfig(cfg, fc.Invite)
 	overlayOCMPeerMappingConfig(cfg, fc.PeerMapping)
+	overlayOCMWebappConfig(cfg, fc.Webapp)
+	overlayOCMSSHConfig(cfg, fc.SSH)
+	overlayOCMDataTxConfig(cfg, fc.DataTx)
 }
 
 // overlayFileConfig applies TOML file values onto cfg.
@@ -86,6 +143,8 @@ func overlayFileConfig(cfg *Config, fc *fileConfig) {
 	overlayCacheConfig(cfg, fc.Cache)
 	overlayPeerTrustConfig(cfg, fc.PeerTrust)
 	overlayLoggingConfig(cfg, fc.Logging)
+	overlayTracingConfig(cfg, fc.Tracing)
+	overlayAuditConfig(cfg, fc.Audit)
 	overlayTokenExchangeConfig(cfg, fc.TokenExchange)
 	overlayHTTPConfig(cfg, fc.HTTP)
 	overlayPersistenceConfig(cfg, fc.Persistence)
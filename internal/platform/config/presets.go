@@ -97,8 +97,12 @@ func StrictConfig() *Config {
 		Logging: LoggingConfig{
 			Level: "info",
 		},
+		Tracing: DefaultTracingConfig(),
 		TokenExchange: TokenExchangeConfig{
-			Path: "token",
+			Path:                   "token",
+			AccessTokenTTLSeconds:  DefaultAccessTokenTTLSeconds,
+			RefreshTokenTTLSeconds: DefaultRefreshTokenTTLSeconds,
+			Store:                  DefaultTokenStoreConfig(),
 		},
 		Persistence: PersistenceConfig{
 			Backend:    BackendSQLite,
@@ -114,6 +118,9 @@ func StrictConfig() *Config {
 			// strict-by-default rejection is not required.
 			// https://github.com/cs3org/OCM-API/blob/6a0586183cbef10ecae9dedc42561806447eb2f5/IETF-OCM.md#L630-L631
 			Discovery: DefaultDiscoveryConfig(),
+			Webapp:    WebappConfig{Targets: []string{DefaultWebappTarget}},
+			SSH:       SSHConfig{HostKeyPath: DefaultSSHHostKeyPath},
+			DataTx:    DataTxConfig{StorageDir: DefaultDataTxStorageDir},
 		},
 	}
 	if err := normalizeSignatureConfig(&cfg.Signature); err != nil {
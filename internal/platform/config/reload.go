@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// reloadableKeys are the TOML keys a running server applies on reload
+// (SIGHUP or POST /api/admin/config/reload). Changes to any other key are
+// reported as needing a restart.
+var reloadableKeys = []string{
+	"logging.level",
+	"outbound_http.ssrf.route_policies",
+	"outbound_http.ssrf.route_policy",
+	"peer_trust.config_paths",
+	"peer_trust.policy.allow_list",
+	"peer_trust.policy.deny_list",
+	"signature.created_max_age_seconds",
+	"signature.created_max_skew_seconds",
+	"signature.replay_protection",
+}
+
+// ReloadableKeys returns the TOML keys applied without a restart.
+func ReloadableKeys() []string {
+	return slices.Clone(reloadableKeys)
+}
+
+// ReloadReport describes a config reload. Applied lists reloadable keys whose
+// value changed; RestartRequired lists changed keys that only take effect
+// after a restart. Both hold key names only, never values.
+type ReloadReport struct {
+	Applied         []string `json:"applied"`
+	RestartRequired []string `json:"restartRequired"`
+}
+
+// DiffForReload compares the running config with a freshly loaded one. Both
+// key lists are sorted.
+func DiffForReload(running, reloaded *Config) ReloadReport {
+	report := ReloadReport{Applied: []string{}, RestartRequired: []string{}}
+
+	for _, key := range changedKeys("", reflect.ValueOf(*running), reflect.ValueOf(*reloaded)) {
+		if slices.Contains(reloadableKeys, key) {
+			report.Applied = append(report.Applied, key)
+		} else {
+			report.RestartRequired = append(report.RestartRequired, key)
+		}
+	}
+
+	slices.Sort(report.Applied)
+	slices.Sort(report.RestartRequired)
+
+	return report
+}
+
+// WithReloadable returns a copy of running with the reloadable settings taken
+// from reloaded. running is not modified.
+func WithReloadable(running, reloaded *Config) *Config {
+	next := *running
+
+	next.Logging.Level = reloaded.Logging.Level
+	next.OutboundHTTP.SSRF.RoutePolicy = reloaded.OutboundHTTP.SSRF.RoutePolicy
+	next.OutboundHTTP.SSRF.RoutePolicies = reloaded.OutboundHTTP.SSRF.RoutePolicies
+	next.PeerTrust.ConfigPaths = reloaded.PeerTrust.ConfigPaths
+	next.PeerTrust.Policy = reloaded.PeerTrust.Policy
+	next.Signature.CreatedMaxAgeSeconds = reloaded.Signature.CreatedMaxAgeSeconds
+	next.Signature.CreatedMaxSkewSeconds = reloaded.Signature.CreatedMaxSkewSeconds
+	next.Signature.ReplayProtection = reloaded.Signature.ReplayProtection
+
+	return &next
+}
+
+// changedKeys walks two values of the same struct type and returns the dotted
+// TOML key of every leaf that differs. Maps and slices are compared whole.
+func changedKeys(prefix string, a, b reflect.Value) []string {
+	if a.Kind() != reflect.Struct {
+		if reflect.DeepEqual(a.Interface(), b.Interface()) {
+			return nil
+		}
+
+		return []string{prefix}
+	}
+
+	var keys []string
+
+	for i := range a.NumField() {
+		field := a.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		keys = append(keys, changedKeys(name, a.Field(i), b.Field(i))...)
+	}
+
+	return keys
+}
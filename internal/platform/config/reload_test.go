@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffForReload_ClassifiesChangedKeys(t *testing.T) {
+	t.Parallel()
+
+	running := DevConfig()
+	reloaded := DevConfig()
+
+	reloaded.Logging.Level = "warn"
+	reloaded.PeerTrust.Policy.DenyList = []string{"bad.example.com"}
+	reloaded.Signature.CreatedMaxAgeSeconds = running.Signature.CreatedMaxAgeSeconds + 60
+	reloaded.Signature.ReplayProtection = true
+	reloaded.OutboundHTTP.SSRF.RoutePolicy = "corp"
+	reloaded.OutboundHTTP.SSRF.RoutePolicies = map[string]SSRFRoutePolicyConfig{"corp": {AllowedPorts: []int{443}}}
+	reloaded.ListenAddr = ":9999"
+	reloaded.OutboundHTTP.SSRF.Mode = "strict"
+
+	report := DiffForReload(running, reloaded)
+
+	wantApplied := []string{
+		"logging.level",
+		"outbound_http.ssrf.route_policies",
+		"outbound_http.ssrf.route_policy",
+		"peer_trust.policy.deny_list",
+		"signature.created_max_age_seconds",
+		"signature.replay_protection",
+	}
+	wantRestart := []string{"listen_addr", "outbound_http.ssrf.mode"}
+
+	if !slices.Equal(report.Applied, wantApplied) {
+		t.Errorf("Applied = %v, want %v", report.Applied, wantApplied)
+	}
+
+	if !slices.Equal(report.RestartRequired, wantRestart) {
+		t.Errorf("RestartRequired = %v, want %v", report.RestartRequired, wantRestart)
+	}
+
+	if unchanged := DiffForReload(running, DevConfig()); len(unchanged.Applied) != 0 || len(unchanged.RestartRequired) != 0 {
+		t.Errorf("identical configs reported changes: %+v", unchanged)
+	}
+}
+
+func TestWithReloadable_TakesOnlyReloadableSettings(t *testing.T) {
+	t.Parallel()
+
+	running := DevConfig()
+	reloaded := DevConfig()
+
+	reloaded.Logging.Level = "warn"
+	reloaded.PeerTrust.Policy.AllowList = []string{"good.example.com"}
+	reloaded.ListenAddr = ":9999"
+
+	applied := WithReloadable(running, reloaded)
+
+	if applied.Logging.Level != "warn" || !slices.Equal(applied.PeerTrust.Policy.AllowList, []string{"good.example.com"}) {
+		t.Errorf("reloadable settings not applied: level=%q allow=%v", applied.Logging.Level, applied.PeerTrust.Policy.AllowList)
+	}
+
+	if applied.ListenAddr != running.ListenAddr {
+		t.Errorf("ListenAddr = %q, want the running %q", applied.ListenAddr, running.ListenAddr)
+	}
+
+	if running.Logging.Level == "warn" {
+		t.Error("WithReloadable modified the running config")
+	}
+}
@@ -313,3 +313,45 @@ allowed_algorithms = ` + tc.algs + `
 		})
 	}
 }
+
+func TestLoad_SignatureKeyAlgorithm(t *testing.T) { //nolint:paralleltest // uses loadJwksURITOML helper that calls t.Setenv, which mutates process-global env and is incompatible with t.Parallel
+	cases := []struct {
+		name    string
+		extra   string
+		want    string
+		wantSub string
+	}{
+		{name: "default", want: sigalg.Ed25519},
+		{name: "jose-alias", extra: `key_algorithm = "ES384"`, want: sigalg.ECDSAP384SHA384},
+		{name: "rsa", extra: "key_algorithm = \"rsa-v1_5-sha256\"\nrsa_key_bits = 2048", want: sigalg.RSAPKCS1SHA256},
+		{name: "unknown", extra: `key_algorithm = "no-such-alg"`, wantSub: "signature.key_algorithm"},
+		{name: "not-allowed", extra: "key_algorithm = \"ES256\"\nallowed_algorithms = [\"ed25519\"]", wantSub: "not in signature.allowed_algorithms"},
+		{name: "weak-rsa", extra: `rsa_key_bits = 1024`, wantSub: "signature.rsa_key_bits"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := loadJwksURITOML(t, `
+mode = "dev"
+public_origin = "http://localhost:9200"
+
+[signature]
+`+tc.extra+"\n")
+
+			if tc.wantSub != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantSub) {
+					t.Fatalf("Load error = %v, want it to mention %q", err, tc.wantSub)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			if cfg.Signature.KeyAlgorithm != tc.want {
+				t.Fatalf("KeyAlgorithm = %q, want %q", cfg.Signature.KeyAlgorithm, tc.want)
+			}
+		})
+	}
+}
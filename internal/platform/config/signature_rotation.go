@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// Signing key rotation defaults for [signature.rotation]. The publish-ahead
+// window and grace window both sit well above the one-minute JWKS cache
+// peers apply, and the grace window above created_max_age_seconds.
+const (
+	DefaultSignatureRotationPublishAheadSeconds = 900
+	DefaultSignatureRotationGraceSeconds        = 3600
+)
+
+// SignatureRotationConfig holds signing key rotation settings under
+// [signature.rotation].
+type SignatureRotationConfig struct {
+	// IntervalSeconds is how long a key signs before a scheduled rotation
+	// replaces it. Zero disables scheduled rotation; admin-triggered
+	// rotation still works.
+	IntervalSeconds int `toml:"interval_seconds"`
+
+	// PublishAheadSeconds is how long a next key is published in the JWKS
+	// before it starts signing.
+	PublishAheadSeconds int `toml:"publish_ahead_seconds"`
+
+	// GraceSeconds is how long a retired key stays published after it stops
+	// signing.
+	GraceSeconds int `toml:"grace_seconds"`
+}
+
+// Interval returns the scheduled rotation interval; zero means disabled.
+func (c SignatureRotationConfig) Interval() time.Duration {
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+// PublishAhead returns the publish-ahead window.
+func (c SignatureRotationConfig) PublishAhead() time.Duration {
+	return time.Duration(c.PublishAheadSeconds) * time.Second
+}
+
+// Grace returns the retirement grace window.
+func (c SignatureRotationConfig) Grace() time.Duration {
+	return time.Duration(c.GraceSeconds) * time.Second
+}
+
+func applySignatureRotationDefaults(rc *SignatureRotationConfig) {
+	if rc.PublishAheadSeconds == 0 {
+		rc.PublishAheadSeconds = DefaultSignatureRotationPublishAheadSeconds
+	}
+
+	if rc.GraceSeconds == 0 {
+		rc.GraceSeconds = DefaultSignatureRotationGraceSeconds
+	}
+}
+
+func validateSignatureRotation(cfg *Config) error {
+	rc := cfg.Signature.Rotation
+
+	if rc.IntervalSeconds < 0 {
+		return errors.New("signature.rotation.interval_seconds must be non-negative")
+	}
+
+	if rc.PublishAheadSeconds <= 0 {
+		return errors.New("signature.rotation.publish_ahead_seconds must be positive")
+	}
+
+	if rc.GraceSeconds < cfg.Signature.CreatedMaxAgeSeconds {
+		return errors.New("signature.rotation.grace_seconds must be at least signature.created_max_age_seconds")
+	}
+
+	if rc.IntervalSeconds > 0 && rc.IntervalSeconds <= rc.PublishAheadSeconds {
+		return errors.New("signature.rotation.interval_seconds must exceed publish_ahead_seconds")
+	}
+
+	return nil
+}
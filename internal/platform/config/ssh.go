@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultSSHHostKeyPath is the SFTP server host key location when
+// [ocm.ssh] host_key_path is unset. tls.tls_dir re-roots it.
+const DefaultSSHHostKeyPath = ".ocm/keys/ssh_host_ed25519.pem"
+
+// SSHConfig holds the built-in SFTP server settings under [ocm.ssh].
+type SSHConfig struct {
+	// ListenAddr is the host:port the SFTP server binds. Empty disables the
+	// server, the ssh discovery role, and the outbound ssh arm.
+	// Example: ":2022"
+	ListenAddr string `toml:"listen_addr"`
+
+	// AdvertisedAddr is the host:port peers connect to, sent as the ssh
+	// discovery role and in protocol.ssh.uri. Default: the public_origin
+	// hostname with the listen_addr port.
+	AdvertisedAddr string `toml:"advertised_addr"`
+
+	// HostKeyPath is the ed25519 host key (PKCS#8 PEM), generated when missing.
+	HostKeyPath string `toml:"host_key_path"`
+}
+
+// Enabled reports whether the SFTP server runs.
+func (c SSHConfig) Enabled() bool {
+	return c.ListenAddr != ""
+}
+
+// Advertised returns the address peers use to reach the SFTP server, or ""
+// when the server is disabled.
+func (c SSHConfig) Advertised(publicOrigin string) string {
+	if !c.Enabled() {
+		return ""
+	}
+
+	if c.AdvertisedAddr != "" {
+		return c.AdvertisedAddr
+	}
+
+	_, port, err := net.SplitHostPort(c.ListenAddr)
+	if err != nil {
+		return ""
+	}
+
+	u, err := url.Parse(publicOrigin)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+func validateSSH(cfg *Config) error {
+	sc := cfg.OCM.SSH
+	if !sc.Enabled() {
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(sc.ListenAddr); err != nil {
+		return fmt.Errorf("invalid ocm.ssh.listen_addr %q: must be host:port", sc.ListenAddr)
+	}
+
+	if sc.AdvertisedAddr != "" {
+		host, port, err := net.SplitHostPort(sc.AdvertisedAddr)
+		if err != nil || host == "" {
+			return fmt.Errorf("invalid ocm.ssh.advertised_addr %q: must be host:port", sc.AdvertisedAddr)
+		}
+
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("invalid ocm.ssh.advertised_addr %q: port must be 1-65535", sc.AdvertisedAddr)
+		}
+	} else if sc.Advertised(cfg.PublicOrigin) == "" || strings.HasSuffix(sc.ListenAddr, ":0") {
+		return fmt.Errorf("ocm.ssh.advertised_addr is required when listen_addr %q has no fixed port or public_origin has no host", sc.ListenAddr)
+	}
+
+	if strings.TrimSpace(sc.HostKeyPath) == "" {
+		return errors.New("ocm.ssh.host_key_path must not be empty")
+	}
+
+	return nil
+}
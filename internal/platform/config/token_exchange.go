@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// Token lifetime defaults for [token_exchange].
+const (
+	DefaultAccessTokenTTLSeconds  = 3600
+	DefaultRefreshTokenTTLSeconds = 86400
+)
+
+// AccessTokenTTL returns the configured access token lifetime.
+func (c TokenExchangeConfig) AccessTokenTTL() time.Duration {
+	return time.Duration(c.AccessTokenTTLSeconds) * time.Second
+}
+
+// RefreshTokenTTL returns the configured refresh token lifetime.
+func (c TokenExchangeConfig) RefreshTokenTTL() time.Duration {
+	return time.Duration(c.RefreshTokenTTLSeconds) * time.Second
+}
+
+func validateTokenTTLs(cfg *Config) error {
+	if cfg.TokenExchange.AccessTokenTTLSeconds <= 0 {
+		return errors.New("token_exchange.access_token_ttl_seconds must be positive")
+	}
+
+	if cfg.TokenExchange.RefreshTokenTTLSeconds <= 0 {
+		return errors.New("token_exchange.refresh_token_ttl_seconds must be positive")
+	}
+
+	return nil
+}
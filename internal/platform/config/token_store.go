@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Token store driver name constants for TokenStoreConfig.Driver. memory and
+// sqlite share their names with the persistence backends.
+const (
+	TokenStoreDriverMemory = BackendMemory
+	TokenStoreDriverSQLite = BackendSQLite
+	TokenStoreDriverRedis  = "redis"
+)
+
+// Issued-token store defaults.
+const (
+	DefaultTokenStoreCleanupIntervalSeconds = 300
+	DefaultTokenStoreRedisAddr              = "localhost:6379"
+	DefaultTokenStoreRedisKeyPrefix         = "ocm:tokens:"
+)
+
+// DefaultTokenStoreConfig returns the preset issued-token store settings:
+// the in-memory driver with the default cleanup interval.
+func DefaultTokenStoreConfig() TokenStoreConfig {
+	return TokenStoreConfig{
+		Driver:                 TokenStoreDriverMemory,
+		CleanupIntervalSeconds: DefaultTokenStoreCleanupIntervalSeconds,
+		Redis: TokenStoreRedisConfig{
+			Addr:      DefaultTokenStoreRedisAddr,
+			KeyPrefix: DefaultTokenStoreRedisKeyPrefix,
+		},
+	}
+}
+
+// ResolveTokenStoreDataDir returns the directory the sqlite token store uses:
+// token_exchange.store.data_dir when set, persistence.data_dir otherwise.
+func (c *Config) ResolveTokenStoreDataDir() string {
+	if c.TokenExchange.Store.DataDir != "" {
+		return c.TokenExchange.Store.DataDir
+	}
+
+	return c.Persistence.DataDir
+}
+
+func validateTokenStore(cfg *Config) error {
+	store := cfg.TokenExchange.Store
+
+	if store.CleanupIntervalSeconds <= 0 {
+		return errors.New("token_exchange.store.cleanup_interval_seconds must be positive")
+	}
+
+	switch store.Driver {
+	case TokenStoreDriverMemory:
+		return nil
+	case TokenStoreDriverSQLite:
+		if cfg.ResolveTokenStoreDataDir() == "" {
+			return errors.New(
+				"token_exchange.store.data_dir (or persistence.data_dir) is required for token store driver \"sqlite\"",
+			)
+		}
+
+		return nil
+	case TokenStoreDriverRedis:
+		if store.Redis.Addr == "" {
+			return errors.New("token_exchange.store.redis.addr is required for token store driver \"redis\"")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf(
+			"invalid token_exchange.store.driver %q: must be one of memory, sqlite, redis",
+			store.Driver,
+		)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Tracing exporter name constants for TracingConfig.Exporter.
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+)
+
+// Tracing defaults.
+const (
+	DefaultTracingServiceName = "opencloudmesh-go"
+	DefaultTracingSampleRatio = 1.0
+)
+
+// DefaultTracingConfig returns the preset tracing settings: disabled, with
+// the stdout exporter selected so enabling it needs no collector.
+func DefaultTracingConfig() TracingConfig {
+	return TracingConfig{
+		Enabled:     false,
+		Exporter:    TracingExporterStdout,
+		ServiceName: DefaultTracingServiceName,
+		SampleRatio: DefaultTracingSampleRatio,
+	}
+}
+
+func validateTracing(cfg *Config) error {
+	tr := cfg.Tracing
+	if !tr.Enabled {
+		return nil
+	}
+
+	if tr.SampleRatio < 0 || tr.SampleRatio > 1 {
+		return fmt.Errorf("invalid tracing.sample_ratio %v: must be between 0 and 1", tr.SampleRatio)
+	}
+
+	switch tr.Exporter {
+	case TracingExporterStdout:
+		return nil
+	case TracingExporterFile:
+		if tr.File == "" {
+			return errors.New("tracing.file is required for tracing exporter \"file\"")
+		}
+
+		return nil
+	case TracingExporterOTLP:
+		if tr.Endpoint == "" {
+			return errors.New("tracing.endpoint is required for tracing exporter \"otlp\"")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("invalid tracing.exporter %q: must be one of otlp, stdout, file", tr.Exporter)
+	}
+}
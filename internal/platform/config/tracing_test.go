@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateTracing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mutate  func(*TracingConfig)
+		wantErr string
+	}{
+		{name: "disabled ignores exporter", mutate: func(c *TracingConfig) { c.Exporter = "bogus" }},
+		{name: "stdout", mutate: func(c *TracingConfig) { c.Enabled = true }},
+		{
+			name:    "file requires path",
+			mutate:  func(c *TracingConfig) { c.Enabled = true; c.Exporter = TracingExporterFile },
+			wantErr: "tracing.file is required",
+		},
+		{
+			name:    "otlp requires endpoint",
+			mutate:  func(c *TracingConfig) { c.Enabled = true; c.Exporter = TracingExporterOTLP },
+			wantErr: "tracing.endpoint is required",
+		},
+		{
+			name: "otlp with endpoint",
+			mutate: func(c *TracingConfig) {
+				c.Enabled = true
+				c.Exporter = TracingExporterOTLP
+				c.Endpoint = "http://localhost:4318/v1/traces"
+			},
+		},
+		{
+			name:    "unknown exporter",
+			mutate:  func(c *TracingConfig) { c.Enabled = true; c.Exporter = "zipkin" },
+			wantErr: "invalid tracing.exporter",
+		},
+		{
+			name:    "ratio out of range",
+			mutate:  func(c *TracingConfig) { c.Enabled = true; c.SampleRatio = 1.5 },
+			wantErr: "invalid tracing.sample_ratio",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &Config{Tracing: DefaultTracingConfig()}
+			tt.mutate(&cfg.Tracing)
+
+			err := validateTracing(cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateTracing() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateTracing() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_TracingOverlay(t *testing.T) {
+	t.Parallel()
+
+	path := writeTempConfig(t, `
+mode = "dev"
+
+[tracing]
+enabled = true
+exporter = "otlp"
+endpoint = "http://collector:4318/v1/traces"
+sample_ratio = 0.25
+
+[tracing.headers]
+authorization = "Bearer collector-token"
+`)
+
+	cfg, err := Load(LoaderOptions{ConfigPath: path})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if !cfg.Tracing.Enabled || cfg.Tracing.Exporter != TracingExporterOTLP {
+		t.Errorf("tracing = %+v, want enabled otlp", cfg.Tracing)
+	}
+
+	if cfg.Tracing.SampleRatio != 0.25 {
+		t.Errorf("sample_ratio = %v, want 0.25", cfg.Tracing.SampleRatio)
+	}
+
+	if cfg.Tracing.ServiceName != DefaultTracingServiceName {
+		t.Errorf("service_name = %q, want default", cfg.Tracing.ServiceName)
+	}
+
+	if strings.Contains(cfg.Redacted(), "collector-token") {
+		t.Error("Redacted() leaks tracing header values")
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Placeholders expanded in [ocm.webapp] viewer_url_template.
+const (
+	WebappPlaceholderWebDAVID = "{webdavId}"
+	WebappPlaceholderName     = "{name}"
+)
+
+// DefaultWebappTarget is the single protocol.webapp.targets entry used when
+// [ocm.webapp] targets is unset.
+const DefaultWebappTarget = "blank"
+
+// WebappConfig holds outbound webapp protocol settings under [ocm.webapp].
+type WebappConfig struct {
+	// ViewerURLTemplate is sent as protocol.webapp.uri on outgoing shares that
+	// request webapp permissions. "{webdavId}" (required) expands to the
+	// share's WebDAV id and "{name}" to the path-escaped resource name.
+	// Empty disables the outbound webapp arm.
+	// Example: "https://viewer.example.org/open/{webdavId}"
+	ViewerURLTemplate string `toml:"viewer_url_template"`
+
+	// Targets is sent as protocol.webapp.targets. Default: ["blank"].
+	Targets []string `toml:"targets"`
+}
+
+// Enabled reports whether outgoing shares may carry a webapp arm.
+func (c WebappConfig) Enabled() bool {
+	return c.ViewerURLTemplate != ""
+}
+
+// ViewerURL expands the viewer URL template for one share.
+func (c WebappConfig) ViewerURL(webdavID, name string) string {
+	return strings.NewReplacer(
+		WebappPlaceholderWebDAVID, url.PathEscape(webdavID),
+		WebappPlaceholderName, url.PathEscape(name),
+	).Replace(c.ViewerURLTemplate)
+}
+
+func validateWebapp(cfg *Config) error {
+	wa := cfg.OCM.Webapp
+	if !wa.Enabled() {
+		return nil
+	}
+
+	if !strings.Contains(wa.ViewerURLTemplate, WebappPlaceholderWebDAVID) {
+		return fmt.Errorf("ocm.webapp.viewer_url_template must contain %s", WebappPlaceholderWebDAVID)
+	}
+
+	u, err := url.Parse(wa.ViewerURL("id", "name"))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("invalid ocm.webapp.viewer_url_template %q: must be an absolute http(s) URL", wa.ViewerURLTemplate)
+	}
+
+	if len(wa.Targets) == 0 {
+		return errors.New("ocm.webapp.targets must not be empty")
+	}
+
+	for _, target := range wa.Targets {
+		if strings.TrimSpace(target) == "" {
+			return errors.New("ocm.webapp.targets must not contain empty entries")
+		}
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@
 package crypto
 
 import (
+	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -28,7 +29,9 @@ type RFC9421Options struct {
 	AllowedAlgorithms  []string
 	RequiredComponents []string
 	MinRSAModulusBits  int
-	Now                func() time.Time
+	// SignNonce makes the signer add a random nonce parameter.
+	SignNonce bool
+	Now       func() time.Time
 }
 
 // DefaultRFC9421Options returns OCM IETF Appendix B (informative) defaults.
@@ -76,6 +79,7 @@ func RFC9421OptionsFromConfig(sig config.SignatureConfig) RFC9421Options {
 		CreatedMaxSkew:    maxSkew,
 		AllowedAlgorithms: append([]string(nil), allowed...),
 		MinRSAModulusBits: minRSA,
+		SignNonce:         sig.SignNonce,
 		Now:               time.Now,
 	}
 }
@@ -176,12 +180,21 @@ func (s *RFC9421Signer) SignRequest(req *http.Request, body []byte) error {
 	components := s.presentComponents(req, s.opts.RequiredComponents)
 	created := s.opts.Now().Unix()
 
+	// With SignNonce, a fresh nonce keeps two identical requests signed in
+	// the same second from producing the same signature, which a verifier
+	// with replay protection would reject as a replay.
+	var nonce string
+	if s.opts.SignNonce {
+		nonce = newSignatureNonce()
+	}
+
 	sigInput := sigparams.FormatSignatureInput(
 		s.opts.Label,
 		components,
 		created,
 		key.KeyID,
 		key.Algorithm,
+		nonce,
 	)
 	sigParamsValue := strings.TrimPrefix(sigInput, s.opts.Label+"=")
 
@@ -192,7 +205,9 @@ func (s *RFC9421Signer) SignRequest(req *http.Request, body []byte) error {
 
 	fullBase := sigBase + "\"@signature-params\": " + sigParamsValue
 
-	sig, err := s.keyManager.Sign([]byte(fullBase))
+	// Sign with the key named in Signature-Input, not whatever is current
+	// now: a rotation may promote a new key between the two calls.
+	sig, err := key.Sign([]byte(fullBase))
 	if err != nil {
 		return fmt.Errorf("failed to sign: %w", err)
 	}
@@ -203,6 +218,14 @@ func (s *RFC9421Signer) SignRequest(req *http.Request, body []byte) error {
 	return nil
 }
 
+// newSignatureNonce returns 128 random bits, base64url encoded.
+func newSignatureNonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // crypto/rand.Read never returns an error
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
 // Sign satisfies tokenoutgoing.RequestSigner by reading and restoring the
 // request body, then delegating to SignRequest.
 func (s *RFC9421Signer) Sign(req *http.Request) error {
@@ -288,14 +311,24 @@ const (
 	ReasonCryptoFail        = "crypto_fail"
 	ReasonContentDigest     = "content_digest"
 	ReasonUnsigned          = "unsigned"
+	// ReasonReplayed is a valid signature already seen within its created
+	// window; set by the inbound middleware, not by VerifyRequest.
+	ReasonReplayed = "replayed"
+	// ReasonReplayCheckFailed means the seen-signature cache could not be
+	// consulted; set by the inbound middleware.
+	ReasonReplayCheckFailed = "replay_check_failed"
 )
 
 // VerificationResult contains the result of signature verification.
 type VerificationResult struct {
 	Verified bool
 	KeyID    string
-	Error    error
-	Reason   string
+	// SignatureBase is the signed signature base; nil unless Verified.
+	// Replay checks key on it rather than on the signature bytes, which are
+	// malleable for ECDSA: (r, s) and (r, n-s) both verify.
+	SignatureBase []byte
+	Error         error
+	Reason        string
 }
 
 // VerifyRequest verifies an HTTP request signature.
@@ -475,5 +508,5 @@ func (v *RFC9421Verifier) verifySignature(
 		return &VerificationResult{Verified: false, KeyID: params.KeyID, Reason: ReasonCryptoFail, Error: fmt.Errorf("signature verification failed: %w", err)}
 	}
 
-	return &VerificationResult{Verified: true, KeyID: params.KeyID}
+	return &VerificationResult{Verified: true, KeyID: params.KeyID, SignatureBase: []byte(fullBase)}
 }
@@ -201,7 +201,7 @@ func TestResolveExactKeyID_RejectsNonExactKeyID(t *testing.T) {
 func TestResolveExactKeyID_RejectsAmbiguousExactKid(t *testing.T) {
 	t.Parallel()
 	km := mustHTTPSigKeyManager(t)
-	key := jwks.Ed25519Key(km.GetKeyID(), km.GetSigningKey().PublicKey)
+	key := jwks.Ed25519Key(km.GetKeyID(), km.GetSigningKey().PublicKey.(ed25519.PublicKey))
 	set := jwks.Set{Keys: []jwks.Key{key, key}}
 
 	_, err := set.ResolveExactKeyID(km.GetKeyID())
@@ -281,7 +281,7 @@ func TestVerifyRequest_RejectsKeyIDWithoutExactKid(t *testing.T) {
 
 	// The peer JWKS holds the same key material under a canonically
 	// equivalent but not byte-equal kid; exact matching must miss it.
-	peerSet := jwks.SetFromEd25519PublicKey("example.com:443#key1", km.GetSigningKey().PublicKey)
+	peerSet := jwks.SetFromEd25519PublicKey("example.com:443#key1", km.GetSigningKey().PublicKey.(ed25519.PublicKey))
 
 	result := verifier.VerifyRequest(req, body, peerSet.ResolveExactKeyID)
 	if result.Verified {
@@ -174,6 +174,40 @@ func TestHTTPSig_GoldenDefaultSignatureInput(t *testing.T) {
 	}
 }
 
+func TestHTTPSig_SignNonceAddsFreshNonce(t *testing.T) {
+	t.Parallel()
+	km := mustHTTPSigKeyManager(t)
+
+	opts := httpsigFixedOptions()
+	opts.SignNonce = true
+	signer := crypto.NewRFC9421SignerWithOptions(km, opts)
+
+	nonceRe := regexp.MustCompile(`;alg="ed25519";nonce="([A-Za-z0-9_-]{22})";tag="ocm"$`)
+	seen := make(map[string]bool)
+
+	for range 2 {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "https://example.com/ocm/shares", bytes.NewReader(httpsigTestBodyJSON))
+		if err != nil {
+			t.Fatalf("NewRequest failed: %v", err)
+		}
+
+		if err := signer.SignRequest(req, httpsigTestBodyJSON); err != nil {
+			t.Fatalf("SignRequest failed: %v", err)
+		}
+
+		m := nonceRe.FindStringSubmatch(req.Header.Get("Signature-Input"))
+		if m == nil {
+			t.Fatalf("Signature-Input = %q, want a nonce parameter", req.Header.Get("Signature-Input"))
+		}
+
+		seen[m[1]] = true
+	}
+
+	if len(seen) != 2 {
+		t.Error("two signatures in the same second share a nonce")
+	}
+}
+
 func TestSignRequest_DefaultSigningDoesNotCreateDate(t *testing.T) {
 	t.Parallel()
 	km := mustHTTPSigKeyManager(t)
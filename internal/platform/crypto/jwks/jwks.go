@@ -8,11 +8,15 @@ package jwks
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/rsa"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"strings"
 	"sync"
@@ -77,6 +81,55 @@ func SetFromEd25519PublicKey(kid string, pub ed25519.PublicKey) Set {
 	return Set{Keys: []Key{Ed25519Key(kid, pub)}}
 }
 
+// PublicKeyJWK builds a JWKS key entry for a local signing key. alg is the
+// RFC 9421 (or JOSE) algorithm the key signs with; the entry carries the
+// matching JOSE alg so peers derive the same algorithm from it.
+func PublicKeyJWK(kid, alg string, pub crypto.PublicKey) (Key, error) {
+	jwkAlg, err := sigalg.JWKAlg(alg)
+	if err != nil {
+		return Key{}, fmt.Errorf("jwks: %w", err)
+	}
+
+	switch key := pub.(type) {
+	case ed25519.PublicKey:
+		return Ed25519Key(kid, key), nil
+	case *ecdsa.PublicKey:
+		return ecdsaKey(kid, jwkAlg, key)
+	case *rsa.PublicKey:
+		return Key{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: jwkAlg,
+			N:   encodeBase64URL(key.N.Bytes()),
+			E:   encodeBase64URL(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	default:
+		return Key{}, fmt.Errorf("jwks: unsupported public key type %T", pub)
+	}
+}
+
+// ecdsaKey encodes x and y at the full coordinate size (RFC 7518 section
+// 6.2.1.2), taken from the uncompressed point 0x04 || x || y.
+func ecdsaKey(kid, jwkAlg string, pub *ecdsa.PublicKey) (Key, error) {
+	point, err := pub.Bytes()
+	if err != nil {
+		return Key{}, fmt.Errorf("jwks: encode EC public key: %w", err)
+	}
+
+	coordSize := (len(point) - 1) / 2
+
+	return Key{
+		Kty: "EC",
+		Kid: kid,
+		Use: "sig",
+		Alg: jwkAlg,
+		Crv: pub.Curve.Params().Name,
+		X:   encodeBase64URL(point[1 : 1+coordSize]),
+		Y:   encodeBase64URL(point[1+coordSize:]),
+	}, nil
+}
+
 // MarshalJSON encodes the JWKS set.
 func (s Set) MarshalJSON() ([]byte, error) {
 	if s.Keys == nil {
@@ -13,8 +13,12 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/sigalg"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/hostport"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tracing"
 )
 
 // ResolveURL fetches the JWKS at the explicit advertised URL and returns the
@@ -58,10 +62,21 @@ func (r *Resolver) ResolveURL(
 
 func (r *Resolver) loadSet(ctx context.Context, jwksURL string, forceRefresh bool) (Set, bool, error) {
 	now := r.now()
-	if set, ok := r.cachedSet(jwksURL, forceRefresh, now); ok {
+
+	set, ok := r.cachedSet(jwksURL, forceRefresh, now)
+	if !forceRefresh {
+		metrics.CacheLookup(metrics.CacheJWKS, ok)
+	}
+
+	if ok {
 		return set, false, nil
 	}
 
+	trigger := metrics.FetchMiss
+	if forceRefresh {
+		trigger = metrics.FetchRefresh
+	}
+
 	type result struct {
 		set   Set
 		fresh bool
@@ -73,7 +88,14 @@ func (r *Resolver) loadSet(ctx context.Context, jwksURL string, forceRefresh boo
 			return result{set: set, fresh: false}, nil
 		}
 
-		set, err := FetchURLLimited(ctx, r.client, jwksURL, r.maxResponseBytes)
+		fetchCtx, span := tracing.Start(ctx, "jwks.fetch",
+			attribute.String("url.full", jwksURL),
+			attribute.String("cache.trigger", trigger),
+		)
+		set, err := FetchURLLimited(fetchCtx, r.client, jwksURL, r.maxResponseBytes)
+		tracing.End(span, err)
+		metrics.CacheFetch(metrics.CacheJWKS, trigger, err)
+
 		if err != nil {
 			return nil, err
 		}
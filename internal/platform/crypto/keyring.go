@@ -0,0 +1,478 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/keyid"
+)
+
+// KeyState is the lifecycle state of a key in the signing keyring.
+type KeyState string
+
+const (
+	// KeyStateNext is a staged key: published in the JWKS, not yet signing.
+	KeyStateNext KeyState = "next"
+	// KeyStateCurrent is the key that signs outbound requests.
+	KeyStateCurrent KeyState = "current"
+	// KeyStateRetired is a former current key, published until its grace
+	// window ends.
+	KeyStateRetired KeyState = "retired"
+)
+
+// keyringVersion is the on-disk keyring format version.
+const keyringVersion = 1
+
+var (
+	// ErrNoSigningKey is returned by keyring operations before LoadOrGenerate.
+	ErrNoSigningKey = errors.New("crypto: no signing key loaded")
+
+	// ErrNextKeyStaged is returned by StageNext while a next key is already
+	// waiting for promotion.
+	ErrNextKeyStaged = errors.New("crypto: a next signing key is already staged")
+)
+
+// RotationPolicy controls scheduled rotation and retirement.
+type RotationPolicy struct {
+	// Interval is how long a key stays current before a scheduled rotation
+	// replaces it. Zero disables scheduled rotation.
+	Interval time.Duration
+
+	// PublishAhead is how long a next key is published before it signs, so
+	// peers holding a cached JWKS have refreshed by the time they see it.
+	PublishAhead time.Duration
+
+	// Grace is how long a retired key stays published after it stops signing.
+	Grace time.Duration
+}
+
+// KeyInfo describes one keyring entry. Unset timestamps are nil.
+type KeyInfo struct {
+	KeyID       string
+	Fragment    string
+	Algorithm   string
+	State       KeyState
+	CreatedAt   time.Time
+	ActivatedAt *time.Time
+	PromoteAt   *time.Time
+	RetiredAt   *time.Time
+}
+
+// RotationEvents reports what one Maintain pass changed. Values are kids.
+type RotationEvents struct {
+	Staged   string
+	Promoted string
+	Removed  []string
+}
+
+// Changed reports whether the pass changed the keyring.
+func (e RotationEvents) Changed() bool {
+	return e.Staged != "" || e.Promoted != "" || len(e.Removed) > 0
+}
+
+type ringKey struct {
+	key         *SigningKey
+	fragment    string
+	state       KeyState
+	createdAt   time.Time
+	activatedAt time.Time
+	promoteAt   time.Time
+	retiredAt   time.Time
+}
+
+func (rk *ringKey) info() KeyInfo {
+	return KeyInfo{
+		KeyID:       rk.key.KeyID,
+		Fragment:    rk.fragment,
+		Algorithm:   rk.key.Algorithm,
+		State:       rk.state,
+		CreatedAt:   rk.createdAt,
+		ActivatedAt: timePtr(rk.activatedAt),
+		PromoteAt:   timePtr(rk.promoteAt),
+		RetiredAt:   timePtr(rk.retiredAt),
+	}
+}
+
+// keyringFile is the on-disk keyring, stored next to key_path.
+type keyringFile struct {
+	Version    int            `json:"version"`
+	Generation int            `json:"generation"`
+	Keys       []keyringEntry `json:"keys"`
+}
+
+type keyringEntry struct {
+	Fragment    string     `json:"fragment"`
+	State       KeyState   `json:"state"`
+	Algorithm   string     `json:"algorithm,omitempty"`
+	PrivateKey  string     `json:"privateKey"` // PKCS#8 PEM
+	CreatedAt   time.Time  `json:"createdAt"`
+	ActivatedAt *time.Time `json:"activatedAt,omitempty"`
+	PromoteAt   *time.Time `json:"promoteAt,omitempty"`
+	RetiredAt   *time.Time `json:"retiredAt,omitempty"`
+}
+
+// KeyringPath returns the keyring file stored beside keyPath, e.g.
+// keys/signing.pem -> keys/signing.keyring.json.
+func KeyringPath(keyPath string) string {
+	return strings.TrimSuffix(keyPath, filepath.Ext(keyPath)) + ".keyring.json"
+}
+
+// Keys lists the keyring, newest first.
+func (km *KeyManager) Keys() []KeyInfo {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+
+	out := make([]KeyInfo, 0, len(km.ring))
+	for _, rk := range km.ring {
+		out = append(out, rk.info())
+	}
+
+	return out
+}
+
+// StageNext generates a next key and publishes it; Maintain promotes it to
+// current once promoteAt passes. Only one next key may be staged at a time.
+func (km *KeyManager) StageNext(now, promoteAt time.Time) (KeyInfo, error) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	if km.signingKey == nil {
+		return KeyInfo{}, ErrNoSigningKey
+	}
+
+	if km.nextLocked() != nil {
+		return KeyInfo{}, ErrNextKeyStaged
+	}
+
+	staged, err := km.stageLocked(now, promoteAt)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+
+	if err := km.persistLocked(); err != nil {
+		return KeyInfo{}, err
+	}
+
+	return staged.info(), nil
+}
+
+// Maintain advances the keyring to now under policy: it promotes a due next
+// key, drops retired keys past the grace window, and stages a next key when
+// scheduled rotation is due. Changes are persisted before it returns.
+func (km *KeyManager) Maintain(now time.Time, policy RotationPolicy) (RotationEvents, error) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	var events RotationEvents
+
+	if km.signingKey == nil {
+		return events, ErrNoSigningKey
+	}
+
+	if next := km.nextLocked(); next != nil && !now.Before(next.promoteAt) {
+		km.promoteLocked(next, now)
+		events.Promoted = next.key.KeyID
+	}
+
+	kept := km.ring[:0]
+
+	for _, rk := range km.ring {
+		if rk.state == KeyStateRetired && !now.Before(rk.retiredAt.Add(policy.Grace)) {
+			events.Removed = append(events.Removed, rk.key.KeyID)
+
+			continue
+		}
+
+		kept = append(kept, rk)
+	}
+
+	km.ring = kept
+
+	if policy.Interval > 0 && km.nextLocked() == nil {
+		due := km.currentLocked().activatedAt.Add(policy.Interval)
+		if !now.Before(due.Add(-policy.PublishAhead)) {
+			// A node that was down past the due time still publishes the
+			// next key for the full window before it signs.
+			promoteAt := due
+			if earliest := now.Add(policy.PublishAhead); promoteAt.Before(earliest) {
+				promoteAt = earliest
+			}
+
+			staged, err := km.stageLocked(now, promoteAt)
+			if err != nil {
+				return events, err
+			}
+
+			events.Staged = staged.key.KeyID
+		}
+	}
+
+	if !events.Changed() {
+		return events, nil
+	}
+
+	return events, km.persistLocked()
+}
+
+// adoptSingleKey seeds the keyring with one current key under the
+// configured fragment; this is the layout before the first rotation.
+func (km *KeyManager) adoptSingleKey(key *SigningKey, activatedAt time.Time) {
+	km.signingKey = key
+	km.generation = 1
+	km.ring = []*ringKey{{
+		key:         key,
+		fragment:    km.kidFragment,
+		state:       KeyStateCurrent,
+		createdAt:   activatedAt,
+		activatedAt: activatedAt,
+	}}
+}
+
+func (km *KeyManager) stageLocked(now, promoteAt time.Time) (*ringKey, error) {
+	generation := km.generation + 1
+	fragment := fmt.Sprintf("%s-r%d", km.kidFragment, generation)
+
+	key, err := km.generateKey(km.kidFor(fragment))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate next signing key: %w", err)
+	}
+
+	staged := &ringKey{
+		key:       key,
+		fragment:  fragment,
+		state:     KeyStateNext,
+		createdAt: now,
+		promoteAt: promoteAt,
+	}
+
+	km.generation = generation
+	km.ring = append([]*ringKey{staged}, km.ring...)
+
+	return staged, nil
+}
+
+func (km *KeyManager) promoteLocked(next *ringKey, now time.Time) {
+	if current := km.currentLocked(); current != nil {
+		current.state = KeyStateRetired
+		current.retiredAt = now
+	}
+
+	next.state = KeyStateCurrent
+	next.activatedAt = now
+	next.promoteAt = time.Time{}
+	km.signingKey = next.key
+}
+
+func (km *KeyManager) nextLocked() *ringKey {
+	for _, rk := range km.ring {
+		if rk.state == KeyStateNext {
+			return rk
+		}
+	}
+
+	return nil
+}
+
+func (km *KeyManager) currentLocked() *ringKey {
+	for _, rk := range km.ring {
+		if rk.state == KeyStateCurrent {
+			return rk
+		}
+	}
+
+	return nil
+}
+
+// kidFor builds the host#fragment kid for a keyring fragment.
+func (km *KeyManager) kidFor(fragment string) string {
+	kid, err := keyid.KidFromPublicOrigin(km.publicOrigin, fragment)
+	if err != nil {
+		return keyid.BuildKid(km.publicOrigin, fragment)
+	}
+
+	return kid
+}
+
+// loadKeyring reads the keyring file. It reports false when no keyring
+// exists, which is the case until the first rotation.
+func (km *KeyManager) loadKeyring() (bool, error) {
+	data, err := os.ReadFile(KeyringPath(km.keyPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("crypto: read keyring: %w", err)
+	}
+
+	var file keyringFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return false, fmt.Errorf("crypto: decode keyring: %w", err)
+	}
+
+	if file.Version != keyringVersion {
+		return false, fmt.Errorf("crypto: unsupported keyring version %d", file.Version)
+	}
+
+	ring, current, err := km.ringFromEntries(file.Keys)
+	if err != nil {
+		return false, err
+	}
+
+	km.ring = ring
+	km.signingKey = current.key
+	km.generation = file.Generation
+
+	return true, nil
+}
+
+func (km *KeyManager) ringFromEntries(entries []keyringEntry) ([]*ringKey, *ringKey, error) {
+	ring := make([]*ringKey, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+
+	var current, next *ringKey
+
+	for _, entry := range entries {
+		if entry.Fragment == "" || seen[entry.Fragment] {
+			return nil, nil, fmt.Errorf("crypto: keyring fragment %q is empty or repeated", entry.Fragment)
+		}
+
+		seen[entry.Fragment] = true
+
+		preferred := entry.Algorithm
+		if preferred == "" {
+			preferred = km.algorithm
+		}
+
+		key, err := parseSigningKeyPEM([]byte(entry.PrivateKey), km.kidFor(entry.Fragment), preferred)
+		if err != nil {
+			return nil, nil, fmt.Errorf("crypto: keyring key %q: %w", entry.Fragment, err)
+		}
+
+		rk := &ringKey{
+			key:         key,
+			fragment:    entry.Fragment,
+			state:       entry.State,
+			createdAt:   entry.CreatedAt,
+			activatedAt: timeValue(entry.ActivatedAt),
+			promoteAt:   timeValue(entry.PromoteAt),
+			retiredAt:   timeValue(entry.RetiredAt),
+		}
+
+		switch entry.State {
+		case KeyStateCurrent:
+			if current != nil {
+				return nil, nil, errors.New("crypto: keyring has more than one current key")
+			}
+
+			current = rk
+		case KeyStateNext:
+			if next != nil {
+				return nil, nil, errors.New("crypto: keyring has more than one next key")
+			}
+
+			next = rk
+		case KeyStateRetired:
+		default:
+			return nil, nil, fmt.Errorf("crypto: keyring key %q has unknown state %q", entry.Fragment, entry.State)
+		}
+
+		ring = append(ring, rk)
+	}
+
+	if current == nil {
+		return nil, nil, errors.New("crypto: keyring has no current key")
+	}
+
+	return ring, current, nil
+}
+
+// persistLocked writes the keyring file and then rewrites key_path with the
+// current key, so tooling that reads key_path keeps seeing the signing key.
+// Without a key_path the keyring lives in memory only.
+func (km *KeyManager) persistLocked() error {
+	if km.keyPath == "" {
+		return nil
+	}
+
+	file := keyringFile{Version: keyringVersion, Generation: km.generation}
+
+	for _, rk := range km.ring {
+		pemData, err := encodeSigningKeyPEM(rk.key)
+		if err != nil {
+			return err
+		}
+
+		file.Keys = append(file.Keys, keyringEntry{
+			Fragment:    rk.fragment,
+			State:       rk.state,
+			Algorithm:   rk.key.Algorithm,
+			PrivateKey:  string(pemData),
+			CreatedAt:   rk.createdAt,
+			ActivatedAt: timePtr(rk.activatedAt),
+			PromoteAt:   timePtr(rk.promoteAt),
+			RetiredAt:   timePtr(rk.retiredAt),
+		})
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("crypto: encode keyring: %w", err)
+	}
+
+	if err := writeFileAtomic(KeyringPath(km.keyPath), data); err != nil {
+		return err
+	}
+
+	current, err := encodeSigningKeyPEM(km.signingKey)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(km.keyPath, current)
+}
+
+// writeFileAtomic replaces path with data (mode 0600) via a rename so a
+// crash never leaves a truncated key file behind.
+func writeFileAtomic(path string, data []byte) error {
+	tempPath := path + ".tmp"
+
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return fmt.Errorf("crypto: write %s: %w", tempPath, err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		//nolint:errcheck // best-effort cleanup; error is not actionable
+		os.Remove(tempPath)
+
+		return fmt.Errorf("crypto: replace %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func timeValue(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package crypto_test
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
+)
+
+func loadKeyManager(t *testing.T, keyPath string) *crypto.KeyManager {
+	t.Helper()
+
+	km := crypto.NewKeyManager(keyPath, "https://example.com")
+	if err := km.LoadOrGenerate(); err != nil {
+		t.Fatalf("LoadOrGenerate: %v", err)
+	}
+
+	return km
+}
+
+func jwksKids(km *crypto.KeyManager) []string {
+	var kids []string
+	for _, k := range km.JWKS().Keys {
+		kids = append(kids, k.Kid)
+	}
+
+	return kids
+}
+
+func TestKeyManager_ScheduledRotationLifecycle(t *testing.T) {
+	t.Parallel()
+
+	keyPath := filepath.Join(t.TempDir(), "signing.pem")
+	km := loadKeyManager(t, keyPath)
+	original := km.GetSigningKey()
+
+	policy := crypto.RotationPolicy{Interval: 24 * time.Hour, PublishAhead: time.Hour, Grace: 2 * time.Hour}
+	activated := *km.Keys()[0].ActivatedAt
+
+	// Not due yet: nothing changes and nothing is written.
+	if events, err := km.Maintain(activated.Add(time.Hour), policy); err != nil || events.Changed() {
+		t.Fatalf("early Maintain = %+v, %v", events, err)
+	}
+
+	if _, err := os.Stat(crypto.KeyringPath(keyPath)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("keyring written before the first rotation: %v", err)
+	}
+
+	// When the publish-ahead window opens a next key is staged and published,
+	// but the current key still signs.
+	stageAt := activated.Add(policy.Interval - policy.PublishAhead)
+
+	events, err := km.Maintain(stageAt, policy)
+	if err != nil || events.Staged != "example.com#key1-r2" {
+		t.Fatalf("stage Maintain = %+v, %v", events, err)
+	}
+
+	if got := jwksKids(km); !slices.Equal(got, []string{"example.com#key1-r2", "example.com#key1"}) {
+		t.Fatalf("JWKS kids after staging = %v", got)
+	}
+
+	if km.GetKeyID() != "example.com#key1" {
+		t.Fatalf("signing kid after staging = %s", km.GetKeyID())
+	}
+
+	// At the due time the next key takes over; the old one stays published.
+	promoteAt := activated.Add(policy.Interval)
+
+	events, err = km.Maintain(promoteAt, policy)
+	if err != nil || events.Promoted != "example.com#key1-r2" {
+		t.Fatalf("promote Maintain = %+v, %v", events, err)
+	}
+
+	current := km.GetSigningKey()
+	currentPub, _ := current.PublicKey.(ed25519.PublicKey)
+
+	if current.KeyID != "example.com#key1-r2" || currentPub.Equal(original.PublicKey) {
+		t.Fatalf("current key after promotion = %s", current.KeyID)
+	}
+
+	sig, err := km.Sign([]byte("payload"))
+	if err != nil || !ed25519.Verify(currentPub, []byte("payload"), sig) {
+		t.Fatalf("Sign after promotion does not use the new key: %v", err)
+	}
+
+	states := map[string]crypto.KeyState{}
+	for _, k := range km.Keys() {
+		states[k.KeyID] = k.State
+	}
+
+	if states["example.com#key1"] != crypto.KeyStateRetired || states["example.com#key1-r2"] != crypto.KeyStateCurrent {
+		t.Fatalf("states after promotion = %v", states)
+	}
+
+	if got := jwksKids(km); len(got) != 2 {
+		t.Fatalf("retired key dropped from JWKS early: %v", got)
+	}
+
+	// After the grace window the retired key is gone.
+	events, err = km.Maintain(promoteAt.Add(policy.Grace), policy)
+	if err != nil || !slices.Equal(events.Removed, []string{"example.com#key1"}) {
+		t.Fatalf("retire Maintain = %+v, %v", events, err)
+	}
+
+	if got := jwksKids(km); !slices.Equal(got, []string{"example.com#key1-r2"}) {
+		t.Fatalf("JWKS kids after grace = %v", got)
+	}
+
+	// The keyring and key_path survive a restart.
+	reloaded := loadKeyManager(t, keyPath)
+	if reloaded.GetKeyID() != "example.com#key1-r2" || !currentPub.Equal(reloaded.GetSigningKey().PublicKey) {
+		t.Fatalf("reloaded current key = %s", reloaded.GetKeyID())
+	}
+
+	legacy := crypto.NewKeyManager(keyPath, "https://example.com")
+	if err := os.Remove(crypto.KeyringPath(keyPath)); err != nil {
+		t.Fatalf("remove keyring: %v", err)
+	}
+
+	if err := legacy.LoadOrGenerate(); err != nil {
+		t.Fatalf("LoadOrGenerate from key_path: %v", err)
+	}
+
+	if !currentPub.Equal(legacy.GetSigningKey().PublicKey) {
+		t.Fatal("key_path does not hold the current key")
+	}
+}
+
+func TestKeyManager_StageNextWaitsForPublishAhead(t *testing.T) {
+	t.Parallel()
+
+	km := loadKeyManager(t, filepath.Join(t.TempDir(), "signing.pem"))
+	now := time.Now()
+	policy := crypto.RotationPolicy{PublishAhead: 15 * time.Minute, Grace: time.Hour}
+
+	staged, err := km.StageNext(now, now.Add(policy.PublishAhead))
+	if err != nil {
+		t.Fatalf("StageNext: %v", err)
+	}
+
+	if staged.State != crypto.KeyStateNext || staged.PromoteAt == nil {
+		t.Fatalf("staged = %+v", staged)
+	}
+
+	if _, err := km.StageNext(now, now); !errors.Is(err, crypto.ErrNextKeyStaged) {
+		t.Fatalf("second StageNext = %v, want ErrNextKeyStaged", err)
+	}
+
+	// Scheduled rotation is off, yet the rotator still promotes the key an
+	// admin staged once its window passes.
+	if events, _ := km.Maintain(now.Add(time.Minute), policy); events.Changed() {
+		t.Fatalf("promoted before publish-ahead elapsed: %+v", events)
+	}
+
+	if events, err := km.Maintain(now.Add(policy.PublishAhead), policy); err != nil || events.Promoted != staged.KeyID {
+		t.Fatalf("Maintain = %+v, %v", events, err)
+	}
+}
+
+func TestKeyManager_CorruptKeyringFailsLoad(t *testing.T) {
+	t.Parallel()
+
+	keyPath := filepath.Join(t.TempDir(), "signing.pem")
+	if err := os.WriteFile(crypto.KeyringPath(keyPath), []byte(`{"version":1,"keys":[]}`), 0600); err != nil {
+		t.Fatalf("write keyring: %v", err)
+	}
+
+	if err := crypto.NewKeyManager(keyPath, "https://example.com").LoadOrGenerate(); err == nil {
+		t.Fatal("expected an error for a keyring without a current key")
+	}
+}
+
+func TestRotator_StartMaintainsImmediately(t *testing.T) {
+	t.Parallel()
+
+	km := loadKeyManager(t, "")
+	if _, err := km.StageNext(time.Now(), time.Now()); err != nil {
+		t.Fatalf("StageNext: %v", err)
+	}
+
+	r := crypto.NewRotator(km, crypto.RotationPolicy{Grace: time.Hour}, time.Hour, nil)
+	r.Start()
+	r.Start() // second Start is a no-op
+
+	deadline := time.Now().Add(5 * time.Second)
+	for km.GetKeyID() != "example.com#key1-r2" {
+		if time.Now().After(deadline) {
+			t.Fatal("rotator did not promote the due key on start")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if err := crypto.NewRotator(km, crypto.RotationPolicy{}, 0, nil).Close(); err != nil {
+		t.Fatalf("Close without Start: %v", err)
+	}
+}
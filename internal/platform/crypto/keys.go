@@ -7,34 +7,78 @@
 package crypto
 
 import (
+	stdcrypto "crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/jwks"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/keyid"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/sigalg"
 )
 
-// SigningKey holds an Ed25519 keypair for RFC 9421 signatures.
+// DefaultRSAKeyBits is the modulus size of generated RSA signing keys when
+// none is configured.
+const DefaultRSAKeyBits = 3072
+
+// SigningKey holds a keypair for RFC 9421 signatures. PrivateKey is an
+// ed25519.PrivateKey, *ecdsa.PrivateKey, or *rsa.PrivateKey; PublicKey is the
+// matching public key.
 type SigningKey struct {
-	PrivateKey ed25519.PrivateKey
-	PublicKey  ed25519.PublicKey
+	PrivateKey stdcrypto.Signer
+	PublicKey  stdcrypto.PublicKey
 	KeyID      string // host#fragment kid, e.g. example.com#key1
-	Algorithm  string // ed25519
+	Algorithm  string // RFC 9421 native name, e.g. ed25519 or ecdsa-p256-sha256
+}
+
+// Sign signs message with this key.
+func (k *SigningKey) Sign(message []byte) ([]byte, error) {
+	sig, err := sigalg.Sign(k.Algorithm, k.PrivateKey, message)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: sign message: %w", err)
+	}
+
+	return sig, nil
 }
 
-// KeyManager manages signing keys for an OCM instance.
+// KeyManager manages signing keys for an OCM instance. It holds a keyring of
+// published keys (see keyring.go); exactly one of them, the current key,
+// signs outbound requests.
 type KeyManager struct {
-	mu         sync.RWMutex
-	signingKey *SigningKey
-	keyPath    string
-	keyID      string
+	mu           sync.RWMutex
+	signingKey   *SigningKey
+	ring         []*ringKey // newest first; always contains the current key once loaded
+	generation   int
+	keyPath      string
+	keyID        string
+	publicOrigin string
+	kidFragment  string
+	algorithm    string
+	rsaKeyBits   int
+}
+
+// KeyManagerOptions configures NewKeyManagerWithOptions.
+type KeyManagerOptions struct {
+	// KidFragment is the host#fragment suffix; empty uses keyid.DefaultFragment.
+	KidFragment string
+
+	// Algorithm is the RFC 9421 algorithm (JOSE aliases accepted) that newly
+	// generated keys sign with; empty uses Ed25519. Keys already on disk keep
+	// the algorithm of their key type.
+	Algorithm string
+
+	// RSAKeyBits is the modulus size of generated RSA keys; zero uses
+	// DefaultRSAKeyBits.
+	RSAKeyBits int
 }
 
 // NewKeyManager creates a key manager with the default kid fragment.
@@ -42,38 +86,94 @@ func NewKeyManager(keyPath, publicOrigin string) *KeyManager {
 	return NewKeyManagerWithFragment(keyPath, publicOrigin, keyid.DefaultFragment)
 }
 
-// NewKeyManagerWithFragment creates a key manager using an explicit kid fragment.
+// NewKeyManagerWithFragment creates an Ed25519 key manager using an explicit
+// kid fragment.
 func NewKeyManagerWithFragment(keyPath, publicOrigin, kidFragment string) *KeyManager {
-	keyID, err := keyid.KidFromPublicOrigin(publicOrigin, kidFragment)
+	//nolint:errcheck // the default Ed25519 algorithm always normalizes
+	km, _ := NewKeyManagerWithOptions(keyPath, publicOrigin, KeyManagerOptions{KidFragment: kidFragment})
+
+	return km
+}
+
+// NewKeyManagerWithOptions creates a key manager that generates keys for
+// opts.Algorithm.
+func NewKeyManagerWithOptions(keyPath, publicOrigin string, opts KeyManagerOptions) (*KeyManager, error) {
+	if opts.KidFragment == "" {
+		opts.KidFragment = keyid.DefaultFragment
+	}
+
+	if opts.Algorithm == "" {
+		opts.Algorithm = sigalg.Ed25519
+	}
+
+	algorithm, err := sigalg.Normalize(opts.Algorithm)
 	if err != nil {
-		keyID = keyid.BuildKid(publicOrigin, kidFragment)
+		return nil, fmt.Errorf("crypto: signing key algorithm: %w", err)
 	}
 
-	return &KeyManager{
-		keyPath: keyPath,
-		keyID:   keyID,
+	if opts.RSAKeyBits == 0 {
+		opts.RSAKeyBits = DefaultRSAKeyBits
 	}
+
+	if isRSAAlgorithm(algorithm) && opts.RSAKeyBits < sigalg.MinRSAModulusBits {
+		return nil, fmt.Errorf("crypto: RSA key size %d below minimum %d", opts.RSAKeyBits, sigalg.MinRSAModulusBits)
+	}
+
+	km := &KeyManager{
+		keyPath:      keyPath,
+		publicOrigin: publicOrigin,
+		kidFragment:  opts.KidFragment,
+		algorithm:    algorithm,
+		rsaKeyBits:   opts.RSAKeyBits,
+	}
+	km.keyID = km.kidFor(opts.KidFragment)
+
+	return km, nil
 }
 
-// LoadOrGenerate loads existing key from disk or generates a new one.
+// LoadOrGenerate loads the keyring from disk, falling back to the single key
+// at keyPath, and generates a new key when neither exists. A keyring file
+// that exists but cannot be read is an error: silently replacing it would
+// drop keys peers still trust.
 func (km *KeyManager) LoadOrGenerate() error {
 	km.mu.Lock()
 	defer km.mu.Unlock()
 
 	if km.keyPath != "" {
-		if key, err := km.loadKey(); err == nil {
-			km.signingKey = key
+		loaded, err := km.loadKeyring()
+		if err != nil {
+			return fmt.Errorf("failed to load signing keyring: %w", err)
+		}
+
+		if loaded {
+			return nil
+		}
+
+		key, err := km.loadKey()
+		if err == nil {
+			activatedAt := time.Now()
+			if info, statErr := os.Stat(km.keyPath); statErr == nil {
+				activatedAt = info.ModTime()
+			}
+
+			km.adoptSingleKey(key, activatedAt)
 
 			return nil
 		}
+
+		// Only a missing file is replaced; an unreadable or unsupported key
+		// is an operator error and must not be overwritten.
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to load signing key: %w", err)
+		}
 	}
 
-	key, err := km.generateKey()
+	key, err := km.generateKey(km.keyID)
 	if err != nil {
 		return fmt.Errorf("failed to generate signing key: %w", err)
 	}
 
-	km.signingKey = key
+	km.adoptSingleKey(key, time.Now())
 
 	if km.keyPath != "" {
 		if err := km.saveKey(); err != nil {
@@ -104,6 +204,9 @@ func (km *KeyManager) GetSigningKey() *SigningKey {
 }
 
 // JWKS returns the local public key set served at the OCM root /jwks route.
+// It publishes every key in the keyring: the staged next key so peers learn
+// it before it signs, the current key, and retired keys until their grace
+// window ends so signatures made just before a rotation still verify.
 func (km *KeyManager) JWKS() jwks.Set {
 	km.mu.RLock()
 	defer km.mu.RUnlock()
@@ -112,11 +215,31 @@ func (km *KeyManager) JWKS() jwks.Set {
 		return jwks.Set{Keys: []jwks.Key{}}
 	}
 
-	return jwks.SetFromEd25519PublicKey(km.signingKey.KeyID, km.signingKey.PublicKey)
+	set := jwks.Set{Keys: make([]jwks.Key, 0, len(km.ring))}
+
+	for _, rk := range km.ring {
+		key, err := jwks.PublicKeyJWK(rk.key.KeyID, rk.key.Algorithm, rk.key.PublicKey)
+		if err != nil {
+			// Unreachable: every key was built for a supported algorithm.
+			continue
+		}
+
+		set.Keys = append(set.Keys, key)
+	}
+
+	return set
 }
 
-// GetKeyID returns the stable host#fragment kid.
+// GetKeyID returns the host#fragment kid of the current signing key, or the
+// configured kid before a key is loaded.
 func (km *KeyManager) GetKeyID() string {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+
+	if km.signingKey != nil {
+		return km.signingKey.KeyID
+	}
+
 	return km.keyID
 }
 
@@ -129,25 +252,35 @@ func (km *KeyManager) Sign(message []byte) ([]byte, error) {
 		return nil, errors.New("no signing key available")
 	}
 
-	sig, err := sigalg.Sign(km.signingKey.Algorithm, km.signingKey.PrivateKey, message)
-	if err != nil {
-		return nil, fmt.Errorf("crypto: sign message: %w", err)
-	}
-
-	return sig, nil
+	return km.signingKey.Sign(message)
 }
 
-func (km *KeyManager) generateKey() (*SigningKey, error) {
-	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+func (km *KeyManager) generateKey(keyID string) (*SigningKey, error) {
+	var (
+		priv stdcrypto.Signer
+		err  error
+	)
+
+	switch km.algorithm {
+	case sigalg.ECDSAP256SHA256:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case sigalg.ECDSAP384SHA384:
+		priv, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case sigalg.RSAPKCS1SHA256, sigalg.RSAPKCS1SHA384, sigalg.RSAPKCS1SHA512:
+		priv, err = rsa.GenerateKey(rand.Reader, km.rsaKeyBits)
+	default:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("crypto: generate key: %w", err)
 	}
 
 	return &SigningKey{
 		PrivateKey: priv,
-		PublicKey:  pub,
-		KeyID:      km.keyID,
-		Algorithm:  sigalg.Ed25519,
+		PublicKey:  priv.Public(),
+		KeyID:      keyID,
+		Algorithm:  km.algorithm,
 	}, nil
 }
 
@@ -157,6 +290,13 @@ func (km *KeyManager) loadKey() (*SigningKey, error) {
 		return nil, fmt.Errorf("crypto: read key file: %w", err)
 	}
 
+	return parseSigningKeyPEM(data, km.keyID, km.algorithm)
+}
+
+// parseSigningKeyPEM decodes a PKCS#8 PEM Ed25519, ECDSA P-256/P-384, or RSA
+// private key. The key type fixes the algorithm, except that an RSA key
+// signs with preferred when preferred is an RSA algorithm.
+func parseSigningKeyPEM(data []byte, keyID, preferred string) (*SigningKey, error) {
 	block, _ := pem.Decode(data)
 	if block == nil {
 		return nil, errors.New("no PEM block found")
@@ -167,40 +307,81 @@ func (km *KeyManager) loadKey() (*SigningKey, error) {
 		return nil, fmt.Errorf("failed to parse private key: %w", err)
 	}
 
-	edPriv, ok := priv.(ed25519.PrivateKey)
+	signer, ok := priv.(stdcrypto.Signer)
 	if !ok {
-		return nil, errors.New("not an Ed25519 private key")
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
 	}
 
-	publicKey, ok := edPriv.Public().(ed25519.PublicKey)
-	if !ok {
-		return nil, errors.New("Ed25519 public key type assertion failed")
+	algorithm, err := algorithmForKey(signer, preferred)
+	if err != nil {
+		return nil, err
 	}
 
 	return &SigningKey{
-		PrivateKey: edPriv,
-		PublicKey:  publicKey,
-		KeyID:      km.keyID,
-		Algorithm:  sigalg.Ed25519,
+		PrivateKey: signer,
+		PublicKey:  signer.Public(),
+		KeyID:      keyID,
+		Algorithm:  algorithm,
 	}, nil
 }
 
-func (km *KeyManager) saveKey() error {
-	if km.signingKey == nil {
-		return errors.New("no signing key to save")
+// algorithmForKey maps a private key to the RFC 9421 algorithm it signs with.
+func algorithmForKey(priv stdcrypto.Signer, preferred string) (string, error) {
+	switch key := priv.(type) {
+	case ed25519.PrivateKey:
+		return sigalg.Ed25519, nil
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return sigalg.ECDSAP256SHA256, nil
+		case elliptic.P384():
+			return sigalg.ECDSAP384SHA384, nil
+		default:
+			return "", fmt.Errorf("unsupported ECDSA curve %s", key.Curve.Params().Name)
+		}
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < sigalg.MinRSAModulusBits {
+			return "", fmt.Errorf("%w: RSA key has %d bits, minimum %d", sigalg.ErrWeakKey, key.N.BitLen(), sigalg.MinRSAModulusBits)
+		}
+
+		if isRSAAlgorithm(preferred) {
+			return preferred, nil
+		}
+
+		return sigalg.RSAPKCS1SHA256, nil
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", priv)
+	}
+}
+
+func isRSAAlgorithm(alg string) bool {
+	switch alg {
+	case sigalg.RSAPKCS1SHA256, sigalg.RSAPKCS1SHA384, sigalg.RSAPKCS1SHA512:
+		return true
+	default:
+		return false
 	}
+}
 
-	pkcs8, err := x509.MarshalPKCS8PrivateKey(km.signingKey.PrivateKey)
+// encodeSigningKeyPEM encodes a private key as PKCS#8 PEM.
+func encodeSigningKeyPEM(key *SigningKey) ([]byte, error) {
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
 	if err != nil {
-		return fmt.Errorf("crypto: marshal private key: %w", err)
+		return nil, fmt.Errorf("crypto: marshal private key: %w", err)
 	}
 
-	block := &pem.Block{
-		Type:  "PRIVATE KEY",
-		Bytes: pkcs8,
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), nil
+}
+
+func (km *KeyManager) saveKey() error {
+	if km.signingKey == nil {
+		return errors.New("no signing key to save")
 	}
 
-	data := pem.EncodeToMemory(block)
+	data, err := encodeSigningKeyPEM(km.signingKey)
+	if err != nil {
+		return err
+	}
 
 	if err := os.WriteFile(km.keyPath, data, 0600); err != nil {
 		return fmt.Errorf("crypto: write key file: %w", err)
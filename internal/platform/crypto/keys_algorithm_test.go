@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package crypto_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/sigalg"
+)
+
+// TestKeyManager_GeneratesConfiguredAlgorithm signs with a generated key of
+// every supported type, verifies against the key published in the JWKS, and
+// reloads the key from disk.
+func TestKeyManager_GeneratesConfiguredAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		configured string
+		want       string
+		kty        string
+	}{
+		{"ed25519", sigalg.Ed25519, "OKP"},
+		{"ES256", sigalg.ECDSAP256SHA256, "EC"},
+		{"ecdsa-p384-sha384", sigalg.ECDSAP384SHA384, "EC"},
+		{"RS256", sigalg.RSAPKCS1SHA256, "RSA"},
+		{"rsa-v1_5-sha512", sigalg.RSAPKCS1SHA512, "RSA"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.configured, func(t *testing.T) {
+			t.Parallel()
+
+			keyPath := filepath.Join(t.TempDir(), "signing.pem")
+			opts := crypto.KeyManagerOptions{Algorithm: tc.configured, RSAKeyBits: 2048}
+
+			km, err := crypto.NewKeyManagerWithOptions(keyPath, "https://example.com", opts)
+			if err != nil {
+				t.Fatalf("NewKeyManagerWithOptions: %v", err)
+			}
+
+			if err := km.LoadOrGenerate(); err != nil {
+				t.Fatalf("LoadOrGenerate: %v", err)
+			}
+
+			if got := km.GetSigningKey().Algorithm; got != tc.want {
+				t.Fatalf("algorithm = %q, want %q", got, tc.want)
+			}
+
+			sig, err := km.Sign([]byte("payload"))
+			if err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+
+			resolved, err := km.JWKS().ResolveExactKeyID(km.GetKeyID())
+			if err != nil {
+				t.Fatalf("ResolveExactKeyID: %v", err)
+			}
+
+			if resolved.JWKKty != tc.kty {
+				t.Errorf("JWK kty = %q, want %q", resolved.JWKKty, tc.kty)
+			}
+
+			alg, err := sigalg.DeriveFromJWK(resolved.JWKKty, resolved.JWKCrv, resolved.JWKAlg)
+			if err != nil || alg != tc.want {
+				t.Fatalf("DeriveFromJWK = %q, %v; want %q", alg, err, tc.want)
+			}
+
+			if err := sigalg.Verify(alg, resolved.PublicKey, []byte("payload"), sig); err != nil {
+				t.Fatalf("signature does not verify against the published JWK: %v", err)
+			}
+
+			reloaded, err := crypto.NewKeyManagerWithOptions(keyPath, "https://example.com", opts)
+			if err != nil {
+				t.Fatalf("NewKeyManagerWithOptions: %v", err)
+			}
+
+			if err := reloaded.LoadOrGenerate(); err != nil {
+				t.Fatalf("reload: %v", err)
+			}
+
+			if got := reloaded.GetSigningKey().Algorithm; got != tc.want {
+				t.Fatalf("reloaded algorithm = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeyManager_ExistingKeyKeepsItsAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPath := writePKCS8(t, priv)
+
+	km, err := crypto.NewKeyManagerWithOptions(keyPath, "https://example.com", crypto.KeyManagerOptions{})
+	if err != nil {
+		t.Fatalf("NewKeyManagerWithOptions: %v", err)
+	}
+
+	if err := km.LoadOrGenerate(); err != nil {
+		t.Fatalf("LoadOrGenerate: %v", err)
+	}
+
+	if got := km.GetSigningKey().Algorithm; got != sigalg.ECDSAP384SHA384 {
+		t.Fatalf("algorithm = %q, want the P-384 key's algorithm", got)
+	}
+}
+
+func TestKeyManager_RejectsWeakRSAKeys(t *testing.T) {
+	t.Parallel()
+
+	if _, err := crypto.NewKeyManagerWithOptions("", "https://example.com", crypto.KeyManagerOptions{
+		Algorithm:  sigalg.RSAPKCS1SHA256,
+		RSAKeyBits: 1024,
+	}); err == nil {
+		t.Fatal("expected an error for a 1024-bit generation size")
+	}
+
+	weak, err := rsa.GenerateKey(rand.Reader, 1024) //nolint:gosec // deliberately below the floor
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := crypto.NewKeyManager(writePKCS8(t, weak), "https://example.com").LoadOrGenerate(); err == nil {
+		t.Fatal("expected an error loading a 1024-bit RSA key")
+	}
+}
+
+func writePKCS8(t *testing.T, priv any) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "signing.pem")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return keyPath
+}
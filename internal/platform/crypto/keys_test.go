@@ -151,8 +151,7 @@ func TestParsePublicKeyPEM(t *testing.T) {
 		t.Fatalf("ParsePublicKeyPEM failed: %v", err)
 	}
 
-	key := km.GetSigningKey()
-	if len(pub) != len(key.PublicKey) {
-		t.Error("parsed key length mismatch")
+	if !pub.Equal(km.GetSigningKey().PublicKey) {
+		t.Error("parsed key mismatch")
 	}
 }
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package crypto
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// DefaultRotatorTick is how often the rotator checks the keyring when no
+// tick is configured.
+const DefaultRotatorTick = time.Minute
+
+// Rotator runs KeyManager.Maintain on a fixed tick: it carries out
+// scheduled rotations, promotes staged keys (including ones staged by an
+// admin), and retires old keys after the grace window.
+type Rotator struct {
+	keys   *KeyManager
+	policy RotationPolicy
+	tick   time.Duration
+	log    *slog.Logger
+	now    func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRotator returns a Rotator; call Start to run it. A non-positive tick
+// uses DefaultRotatorTick.
+func NewRotator(keys *KeyManager, policy RotationPolicy, tick time.Duration, log *slog.Logger) *Rotator {
+	if tick <= 0 {
+		tick = DefaultRotatorTick
+	}
+
+	return &Rotator{
+		keys:   keys,
+		policy: policy,
+		tick:   tick,
+		log:    logutil.NoopIfNil(log),
+		now:    time.Now,
+	}
+}
+
+// Start runs maintenance until Close. The first pass runs immediately so a
+// promotion or retirement that fell due while the process was down happens
+// on boot.
+func (r *Rotator) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go r.run(ctx)
+}
+
+// Close stops the rotator and waits for a running pass. Safe to call when
+// the rotator never started.
+func (r *Rotator) Close() error {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel = nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	<-done
+
+	return nil
+}
+
+func (r *Rotator) run(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.tick)
+	defer ticker.Stop()
+
+	for {
+		r.maintain()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Rotator) maintain() {
+	events, err := r.keys.Maintain(r.now(), r.policy)
+	if err != nil {
+		r.log.Warn("signing key maintenance failed", "error", err)
+	}
+
+	if events.Staged != "" {
+		r.log.Info("staged next signing key", "key_id", events.Staged)
+	}
+
+	if events.Promoted != "" {
+		r.log.Info("promoted signing key", "key_id", events.Promoted)
+	}
+
+	for _, kid := range events.Removed {
+		r.log.Info("removed retired signing key", "key_id", kid)
+	}
+}
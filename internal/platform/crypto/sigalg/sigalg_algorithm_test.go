@@ -454,3 +454,65 @@ func TestNormalizeSignatureInputAlgorithm_AcceptsNativeNames(t *testing.T) {
 		}
 	}
 }
+
+func TestSign_RoundTripsThroughVerify(t *testing.T) {
+	t.Parallel()
+
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		alg  string
+		priv crypto.Signer
+	}{
+		{sigalg.Ed25519, edKey},
+		{sigalg.ECDSAP256SHA256, p256},
+		{sigalg.ECDSAP384SHA384, p384},
+		{sigalg.RSAPKCS1SHA256, rsaKey},
+		{sigalg.RSAPKCS1SHA384, rsaKey},
+		{sigalg.RSAPKCS1SHA512, rsaKey},
+	}
+
+	message := []byte("signature base")
+
+	for _, tc := range cases {
+		sig, err := sigalg.Sign(tc.alg, tc.priv, message)
+		if err != nil {
+			t.Fatalf("%s: Sign: %v", tc.alg, err)
+		}
+
+		if err := sigalg.Verify(tc.alg, tc.priv.Public(), message, sig); err != nil {
+			t.Errorf("%s: Verify: %v", tc.alg, err)
+		}
+	}
+}
+
+func TestSign_RejectsWeakRSAKey(t *testing.T) {
+	t.Parallel()
+
+	weak, err := rsa.GenerateKey(rand.Reader, 1024) //nolint:gosec // deliberately below the floor
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sigalg.Sign(sigalg.RSAPKCS1SHA256, weak, []byte("m")); !errors.Is(err, sigalg.ErrWeakKey) {
+		t.Fatalf("got %v, want ErrWeakKey", err)
+	}
+}
@@ -234,13 +234,13 @@ func TestIsImplemented(t *testing.T) {
 	}
 }
 
-func TestSign_NonEd25519NotImplemented(t *testing.T) {
+func TestSign_ECDSACurveMismatch(t *testing.T) {
 	t.Parallel()
 	priv := mustECDSAKey(t, elliptic.P256())
 
-	_, err := sigalg.Sign(sigalg.ECDSAP256SHA256, priv, []byte(testMsg))
-	if !errors.Is(err, sigalg.ErrNotImplemented) {
-		t.Fatalf("got %v, want ErrNotImplemented", err)
+	_, err := sigalg.Sign(sigalg.ECDSAP384SHA384, priv, []byte(testMsg))
+	if !errors.Is(err, sigalg.ErrCurveMismatch) {
+		t.Fatalf("got %v, want ErrCurveMismatch", err)
 	}
 }
 
@@ -9,10 +9,16 @@ package sigalg
 import (
 	"crypto"
 	"crypto/ecdh"
+	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
 	"errors"
 	"fmt"
+	"hash"
 	"strings"
 )
 
@@ -409,7 +415,99 @@ func Sign(alg string, privateKey crypto.PrivateKey, message []byte) ([]byte, err
 		}
 
 		return ed25519.Sign(key, message), nil
+	case ECDSAP256SHA256:
+		return signECDSA(privateKey, elliptic.P256(), sha256.New, 32, message)
+	case ECDSAP384SHA384:
+		return signECDSA(privateKey, elliptic.P384(), sha512.New384, 48, message)
+	case RSAPKCS1SHA256:
+		return signRSAPKCS1(privateKey, crypto.SHA256, sha256.New, message)
+	case RSAPKCS1SHA384:
+		return signRSAPKCS1(privateKey, crypto.SHA384, sha512.New384, message)
+	case RSAPKCS1SHA512:
+		return signRSAPKCS1(privateKey, crypto.SHA512, sha512.New, message)
 	default:
 		return nil, fmt.Errorf("%w: signing for %q", ErrNotImplemented, native)
 	}
 }
+
+// signECDSA produces the fixed-size r||s form RFC 9421 section 3.3.4 and
+// 3.3.5 require, not ASN.1 DER.
+func signECDSA(
+	privateKey crypto.PrivateKey,
+	curve elliptic.Curve,
+	newHash func() hash.Hash,
+	coordSize int,
+	message []byte,
+) ([]byte, error) {
+	key, ok := privateKey.(*ecdsa.PrivateKey)
+	if !ok || key == nil {
+		return nil, fmt.Errorf("%w: expected *ecdsa.PrivateKey", ErrWrongKeyType)
+	}
+
+	if key.Curve != curve {
+		return nil, fmt.Errorf("%w: got %s want %s", ErrCurveMismatch, key.Curve.Params().Name, curve.Params().Name)
+	}
+
+	h := newHash()
+	if _, err := h.Write(message); err != nil {
+		return nil, fmt.Errorf("sigalg: hash message: %w", err)
+	}
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, h.Sum(nil))
+	if err != nil {
+		return nil, fmt.Errorf("sigalg: ecdsa sign: %w", err)
+	}
+
+	return EncodeECDSARawRS(r, s, coordSize)
+}
+
+// signRSAPKCS1 refuses keys below MinRSAModulusBits: peers apply the same
+// floor on verification and would reject the signature anyway.
+func signRSAPKCS1(privateKey crypto.PrivateKey, hashID crypto.Hash, newHash func() hash.Hash, message []byte) ([]byte, error) {
+	key, ok := privateKey.(*rsa.PrivateKey)
+	if !ok || key == nil {
+		return nil, fmt.Errorf("%w: expected *rsa.PrivateKey", ErrWrongKeyType)
+	}
+
+	if key.N.BitLen() < MinRSAModulusBits {
+		return nil, fmt.Errorf("%w: RSA modulus %d bits below minimum %d", ErrWeakKey, key.N.BitLen(), MinRSAModulusBits)
+	}
+
+	h := newHash()
+	if _, err := h.Write(message); err != nil {
+		return nil, fmt.Errorf("sigalg: hash message: %w", err)
+	}
+
+	sig, err := rsa.SignPKCS1v15(nil, key, hashID, h.Sum(nil))
+	if err != nil {
+		return nil, fmt.Errorf("sigalg: rsa sign: %w", err)
+	}
+
+	return sig, nil
+}
+
+// JWKAlg returns the JOSE-registry JWK alg that publishes native alg. Only
+// Ed25519 keeps its native spelling, matching normalizeJWKAlg.
+func JWKAlg(alg string) (string, error) {
+	native, err := Normalize(alg)
+	if err != nil {
+		return "", err
+	}
+
+	switch native {
+	case Ed25519:
+		return "Ed25519", nil
+	case ECDSAP256SHA256:
+		return joseES256, nil
+	case ECDSAP384SHA384:
+		return joseES384, nil
+	case RSAPKCS1SHA256:
+		return joseRS256, nil
+	case RSAPKCS1SHA384:
+		return joseRS384, nil
+	case RSAPKCS1SHA512:
+		return joseRS512, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrNotImplemented, native)
+	}
+}
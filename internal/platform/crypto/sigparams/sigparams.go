@@ -24,7 +24,9 @@ type Params struct {
 	Created    int64
 	KeyID      string
 	Algorithm  string
-	Raw        string
+	// Nonce is the optional nonce parameter; empty when absent.
+	Nonce string
+	Raw   string
 }
 
 // ParseSignatureInput parses a Signature-Input header dictionary and returns
@@ -82,6 +84,11 @@ func ParseSignatureInput(header, label string) (Params, error) {
 			if err != nil {
 				return Params{}, fmt.Errorf("sigparams: invalid alg: %w", err)
 			}
+		case "nonce":
+			params.Nonce, err = parseStringParam(value)
+			if err != nil {
+				return Params{}, fmt.Errorf("sigparams: invalid nonce: %w", err)
+			}
 		}
 	}
 
@@ -118,9 +125,9 @@ func ParseSignature(header, label string) ([]byte, error) {
 }
 
 // FormatSignatureInput builds a Signature-Input dictionary member value.
-// When algorithm is empty, the alg parameter is omitted.
+// When algorithm or nonce is empty, the alg or nonce parameter is omitted.
 // The formatter always appends the OCM tag parameter.
-func FormatSignatureInput(label string, components []string, created int64, keyID, algorithm string) string {
+func FormatSignatureInput(label string, components []string, created int64, keyID, algorithm, nonce string) string {
 	quoted := make([]string, len(components))
 	for i, c := range components {
 		quoted[i] = fmt.Sprintf("%q", strings.ToLower(c))
@@ -137,6 +144,10 @@ func FormatSignatureInput(label string, components []string, created int64, keyI
 		out += fmt.Sprintf(`;alg=%q`, algorithm)
 	}
 
+	if nonce != "" {
+		out += fmt.Sprintf(`;nonce=%q`, nonce)
+	}
+
 	out += fmt.Sprintf(`;tag=%q`, SignatureTagOCM)
 
 	return out
@@ -87,7 +87,7 @@ func TestFormatRoundTrip(t *testing.T) {
 
 	components := []string{"@method", "@target-uri", "date"}
 
-	raw := sigparams.FormatSignatureInput("ocm", components, 42, "example.com#key1", "ed25519")
+	raw := sigparams.FormatSignatureInput("ocm", components, 42, "example.com#key1", "ed25519", "n-1")
 	if !strings.Contains(raw, "ocm=(") {
 		t.Fatalf("formatted = %q", raw)
 	}
@@ -104,12 +104,16 @@ func TestFormatRoundTrip(t *testing.T) {
 	if params.Created != 42 {
 		t.Errorf("Created = %d", params.Created)
 	}
+
+	if params.Nonce != "n-1" {
+		t.Errorf("Nonce = %q, want n-1", params.Nonce)
+	}
 }
 
 func TestFormatSignatureInput_AlwaysIncludesTagOCM(t *testing.T) {
 	t.Parallel()
 
-	raw := sigparams.FormatSignatureInput("ocm", []string{"@method"}, 1, "example.com#k1", "ed25519")
+	raw := sigparams.FormatSignatureInput("ocm", []string{"@method"}, 1, "example.com#k1", "ed25519", "")
 	if strings.Count(raw, `tag="ocm"`) != 1 {
 		t.Fatalf("Signature-Input must contain exactly one tag=\"ocm\": %q", raw)
 	}
@@ -131,11 +135,15 @@ func TestFormatSignatureInput_AlwaysIncludesTagOCM(t *testing.T) {
 func TestFormatSignatureInput_OmitsEmptyAlg(t *testing.T) {
 	t.Parallel()
 
-	raw := sigparams.FormatSignatureInput("ocm", []string{"@method"}, 1, "example.com#k1", "")
+	raw := sigparams.FormatSignatureInput("ocm", []string{"@method"}, 1, "example.com#k1", "", "")
 	if strings.Contains(raw, "alg=") {
 		t.Fatalf("empty algorithm must omit alg=: %q", raw)
 	}
 
+	if strings.Contains(raw, "nonce=") {
+		t.Fatalf("empty nonce must omit nonce=: %q", raw)
+	}
+
 	if strings.Count(raw, `tag="ocm"`) != 1 {
 		t.Fatalf("Signature-Input must still contain exactly one tag=\"ocm\" when alg is empty: %q", raw)
 	}
@@ -15,9 +15,15 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tracing"
 )
 
 var (
@@ -58,6 +64,7 @@ type Client struct {
 	httpClient        *http.Client
 	resolver          Resolver // for context-aware DNS in SSRF checks; nil uses net.DefaultResolver
 	trustedProxyHosts map[string]struct{}
+	routes            atomic.Pointer[ssrfRoutes] // active route policies; swapped by SetRoutePolicies
 }
 
 // New creates a new safe HTTP client.
@@ -78,6 +85,7 @@ func New(cfg *config.OutboundHTTPConfig, rootCAs *x509.CertPool) *Client {
 	}
 
 	c := &Client{cfg: cfg}
+	c.SetRoutePolicies(cfg.SSRF.RoutePolicy, cfg.SSRF.RoutePolicies)
 
 	// Proxy selection and trusted-host extraction (precedence: explicit
 	// ProxyURL > env fallback > direct). See transport.go for details.
@@ -125,8 +133,40 @@ func (c *Client) DoSigned(req *http.Request) (*http.Response, error) {
 	return c.DoWithOptions(req, RequestOptions{IsSigned: true})
 }
 
-// DoWithOptions performs an HTTP request with explicit options.
+// DoWithOptions performs an HTTP request with explicit options. The request
+// runs inside a client span, and the span's traceparent is added to a copy of
+// the request headers so the peer can continue the trace. traceparent is not
+// a signed component, so adding it after signing keeps signatures valid.
 func (c *Client) DoWithOptions(req *http.Request, opts RequestOptions) (*http.Response, error) {
+	ctx, span := tracing.StartKind(req.Context(), "HTTP "+req.Method, trace.SpanKindClient,
+		attribute.String("http.request.method", req.Method),
+		attribute.String("server.address", req.URL.Host),
+		attribute.String("url.path", req.URL.Path),
+	)
+	defer span.End()
+
+	req = req.WithContext(ctx)
+	req.Header = req.Header.Clone()
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+
+	tracing.InjectHeaders(ctx, req.Header)
+
+	resp, err := c.doWithOptions(req, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	return resp, nil
+}
+
+func (c *Client) doWithOptions(req *http.Request, opts RequestOptions) (*http.Response, error) {
 	ctx := req.Context()
 
 	// Pre-flight SSRF check on the full URL (hostname + effective port).
@@ -344,3 +344,43 @@ func TestRoutePolicy_PrivateIPLiteralAllowedWithPolicy(t *testing.T) {
 		t.Errorf("route policy should allow this IP literal, got SSRF error: %v", err)
 	}
 }
+
+// TestRoutePolicy_SetRoutePoliciesSwapsActivePolicy verifies that a config
+// reload can switch the active route policy on a running client.
+func TestRoutePolicy_SetRoutePoliciesSwapsActivePolicy(t *testing.T) {
+	t.Parallel()
+
+	resolver := &fixedResolver{entries: map[string][]net.IPAddr{
+		"service.internal": {{IP: net.ParseIP("10.0.1.50")}},
+	}}
+
+	c := httpclient.New(outboundtestutil.StrictShortTimeoutConfig(), nil)
+	c.SetResolver(resolver)
+
+	get := func() error {
+		resp, err := c.Get(context.Background(), "http://service.internal/api") //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+		if resp != nil {
+			defer outboundtestutil.MustClose(t, resp.Body)
+		}
+
+		return err
+	}
+
+	if err := get(); !httpclient.IsSSRFError(err) {
+		t.Fatalf("without a route policy expected SSRF error, got: %v", err)
+	}
+
+	c.SetRoutePolicies("corp", map[string]config.SSRFRoutePolicyConfig{
+		"corp": corpPolicy([]string{"internal"}, []string{"10.0.0.0/8"}, []int{80}),
+	})
+
+	if err := get(); httpclient.IsSSRFError(err) {
+		t.Errorf("after enabling the corp policy expected no SSRF error, got: %v", err)
+	}
+
+	c.SetRoutePolicies("", nil)
+
+	if err := get(); !httpclient.IsSSRFError(err) {
+		t.Errorf("after clearing the route policy expected SSRF error, got: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tracing"
+	outboundtestutil "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+)
+
+// Not parallel: swaps the global tracer provider and propagator.
+func TestClient_DoInjectsTraceparent(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	var gotTraceparent string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTraceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	ctx, parent := tracing.Start(t.Context(), "caller")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/ocm/shares", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	resp, err := outboundtestutil.NewPermissive(nil).Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("Do() failed: %v", err)
+	}
+	defer outboundtestutil.MustClose(t, resp.Body)
+
+	parent.End()
+
+	traceID := parent.SpanContext().TraceID().String()
+	if !strings.Contains(gotTraceparent, traceID) {
+		t.Errorf("traceparent = %q, want trace id %s", gotTraceparent, traceID)
+	}
+
+	if req.Header.Get("traceparent") != "" {
+		t.Error("Do() mutated the caller's request headers")
+	}
+
+	var client sdktrace.ReadOnlySpan
+
+	for _, s := range sr.Ended() {
+		if s.SpanKind() == trace.SpanKindClient {
+			client = s
+		}
+	}
+
+	if client == nil {
+		t.Fatal("no client span recorded")
+	}
+
+	if client.Name() != "HTTP POST" || client.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("client span = %q parent %s, want HTTP POST under caller", client.Name(), client.Parent().SpanID())
+	}
+}
@@ -26,15 +26,31 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 )
 
+// ssrfRoutes is the route policy set and the name of the active policy. It
+// is replaced whole so a check never sees a name from one set and policies
+// from another.
+type ssrfRoutes struct {
+	name     string
+	policies map[string]config.SSRFRoutePolicyConfig
+}
+
+// SetRoutePolicies replaces the SSRF route policies and the active policy
+// name ([outbound_http.ssrf] route_policies and route_policy). Checks that
+// start afterwards use the new policies. The caller validates the policies
+// first; the config loader does this.
+func (c *Client) SetRoutePolicies(name string, policies map[string]config.SSRFRoutePolicyConfig) {
+	c.routes.Store(&ssrfRoutes{name: name, policies: policies})
+}
+
 // activeRoutePolicy returns the named active route policy, or nil if none is
 // configured. Returns nil when RoutePolicy is empty or the name is not found.
 func (c *Client) activeRoutePolicy() *config.SSRFRoutePolicyConfig {
-	name := c.cfg.SSRF.RoutePolicy
-	if name == "" || c.cfg.SSRF.RoutePolicies == nil {
+	routes := c.routes.Load()
+	if routes == nil || routes.name == "" || routes.policies == nil {
 		return nil
 	}
 
-	p, ok := c.cfg.SSRF.RoutePolicies[name]
+	p, ok := routes.policies[routes.name]
 	if !ok {
 		return nil
 	}
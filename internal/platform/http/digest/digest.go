@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package digest handles whole-representation SHA-256 checksums on WebDAV
+// downloads: the RFC 9530 Repr-Digest and Want-Repr-Digest fields, and the
+// ownCloud/Nextcloud OC-Checksum field many WebDAV servers send instead.
+package digest
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Header field names.
+const (
+	HeaderReprDigest     = "Repr-Digest"
+	HeaderWantReprDigest = "Want-Repr-Digest"
+	HeaderOCChecksum     = "OC-Checksum"
+)
+
+// algSHA256 is the RFC 9530 algorithm key for SHA-256.
+const algSHA256 = "sha-256"
+
+// ErrMalformed reports a checksum field that names SHA-256 but cannot be
+// decoded.
+var ErrMalformed = errors.New("digest: malformed sha-256 checksum")
+
+// RequestSHA256 asks the server for a SHA-256 Repr-Digest.
+func RequestSHA256(h http.Header) {
+	h.Set(HeaderWantReprDigest, algSHA256+"=10")
+}
+
+// WantsSHA256 reports whether Want-Repr-Digest asks for SHA-256 with a
+// non-zero preference.
+func WantsSHA256(h http.Header) bool {
+	for _, member := range members(h.Values(HeaderWantReprDigest)) {
+		key, value, _ := strings.Cut(member, "=")
+		if !strings.EqualFold(strings.TrimSpace(key), algSHA256) {
+			continue
+		}
+
+		pref, err := strconv.Atoi(strings.TrimSpace(value))
+
+		return err == nil && pref > 0
+	}
+
+	return false
+}
+
+// FormatSHA256 renders sum as a Repr-Digest field value.
+func FormatSHA256(sum []byte) string {
+	return algSHA256 + "=:" + base64.StdEncoding.EncodeToString(sum) + ":"
+}
+
+// SumSHA256 hashes everything r yields.
+func SumSHA256(r io.Reader) ([]byte, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, fmt.Errorf("digest: hash content: %w", err)
+	}
+
+	return h.Sum(nil), nil
+}
+
+// SHA256 returns the SHA-256 a response advertises in Repr-Digest, falling
+// back to OC-Checksum. ok is false when neither field carries SHA-256.
+func SHA256(h http.Header) (sum []byte, ok bool, err error) {
+	for _, member := range members(h.Values(HeaderReprDigest)) {
+		key, value, _ := strings.Cut(member, "=")
+		if !strings.EqualFold(strings.TrimSpace(key), algSHA256) {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || value[0] != ':' || value[len(value)-1] != ':' {
+			return nil, false, ErrMalformed
+		}
+
+		sum, err := base64.StdEncoding.DecodeString(value[1 : len(value)-1])
+		if err != nil || len(sum) != sha256.Size {
+			return nil, false, ErrMalformed
+		}
+
+		return sum, true, nil
+	}
+
+	// OC-Checksum is a space-separated list of ALGO:hex entries.
+	for _, entry := range strings.Fields(h.Get(HeaderOCChecksum)) {
+		algo, value, found := strings.Cut(entry, ":")
+		if !found || !strings.EqualFold(algo, "SHA256") {
+			continue
+		}
+
+		sum, err := hex.DecodeString(value)
+		if err != nil || len(sum) != sha256.Size {
+			return nil, false, ErrMalformed
+		}
+
+		return sum, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// members splits structured-field dictionary values into their members.
+func members(values []string) []string {
+	var out []string
+
+	for _, v := range values {
+		for member := range strings.SplitSeq(v, ",") {
+			if member = strings.TrimSpace(member); member != "" {
+				out = append(out, member)
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package digest_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/digest"
+)
+
+func TestWantsSHA256(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", false},
+		{"sha-256=10", true},
+		{"sha-512=3, sha-256=1", true},
+		{"sha-256=0", false},
+		{"sha-512=10", false},
+		{"sha-256=x", false},
+	}
+
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.value != "" {
+			h.Set(digest.HeaderWantReprDigest, tt.value)
+		}
+
+		if got := digest.WantsSHA256(h); got != tt.want {
+			t.Errorf("WantsSHA256(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	h := http.Header{}
+	digest.RequestSHA256(h)
+
+	if !digest.WantsSHA256(h) {
+		t.Errorf("RequestSHA256 set %q, which WantsSHA256 rejects", h.Get(digest.HeaderWantReprDigest))
+	}
+}
+
+func TestSHA256_RoundTripAndFallback(t *testing.T) {
+	t.Parallel()
+
+	sum, err := digest.SumSHA256(strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("SumSHA256: %v", err)
+	}
+
+	want := sha256.Sum256([]byte("hello"))
+	if !bytes.Equal(sum, want[:]) {
+		t.Fatalf("SumSHA256 = %x, want %x", sum, want)
+	}
+
+	h := http.Header{}
+	h.Set(digest.HeaderReprDigest, "sha-512=:AAAA:, "+digest.FormatSHA256(sum))
+
+	got, ok, err := digest.SHA256(h)
+	if err != nil || !ok || !bytes.Equal(got, sum) {
+		t.Fatalf("SHA256(Repr-Digest) = %x, %v, %v", got, ok, err)
+	}
+
+	h = http.Header{}
+	h.Set(digest.HeaderOCChecksum, "MD5:abc SHA256:"+hex.EncodeToString(sum))
+
+	got, ok, err = digest.SHA256(h)
+	if err != nil || !ok || !bytes.Equal(got, sum) {
+		t.Fatalf("SHA256(OC-Checksum) = %x, %v, %v", got, ok, err)
+	}
+
+	if _, ok, err := digest.SHA256(http.Header{}); ok || err != nil {
+		t.Fatalf("SHA256(empty) = %v, %v; want no checksum", ok, err)
+	}
+}
+
+func TestSHA256_Malformed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct{ name, value string }{
+		{digest.HeaderReprDigest, "sha-256=abc"},
+		{digest.HeaderReprDigest, "sha-256=:AAAA:"},
+		{digest.HeaderOCChecksum, "SHA256:zz"},
+	}
+
+	for _, tt := range tests {
+		h := http.Header{}
+		h.Set(tt.name, tt.value)
+
+		if _, _, err := digest.SHA256(h); !errors.Is(err, digest.ErrMalformed) {
+			t.Errorf("SHA256(%v) error = %v, want ErrMalformed", h, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tracing"
+)
+
+// RouteIDFunc maps a request method and matched chi route pattern to a
+// route-policy ID, or "" when the pattern is not a registered route.
+type RouteIDFunc func(method, pattern string) string
+
+// TracingMiddleware opens a server span per request, continuing any inbound
+// traceparent. Once routing has run, the span is renamed to
+// "<METHOD> <route pattern>" and tagged with the route ID from routeID.
+// Must be installed on the top-level router so the chi route context spans
+// every mounted service.
+func TracingMiddleware(routeID RouteIDFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := tracing.ExtractHeaders(r.Context(), r.Header)
+			ctx, span := tracing.StartKind(ctx, r.Method, trace.SpanKindServer,
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			)
+			defer span.End()
+
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil {
+				return
+			}
+
+			pattern := rctx.RoutePattern()
+			if pattern == "" {
+				return
+			}
+
+			span.SetName(r.Method + " " + pattern)
+			span.SetAttributes(attribute.String("http.route", pattern))
+
+			if routeID != nil {
+				if id := routeID(r.Method, pattern); id != "" {
+					span.SetAttributes(attribute.String("ocm.route_id", id))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// installSpanRecorder swaps in a recording tracer provider and the
+// traceparent propagator for the duration of the test.
+func installSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	return sr
+}
+
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func TestTracingMiddleware_NamesSpanByRouteAndContinuesTrace(t *testing.T) {
+	sr := installSpanRecorder(t)
+
+	ocm := chi.NewRouter()
+	ocm.Post("/shares", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	r := chi.NewRouter()
+	r.Use(TracingMiddleware(func(method, pattern string) string {
+		if method == http.MethodPost && pattern == "/ocm/shares" {
+			return "ocm-shares-create"
+		}
+
+		return ""
+	}))
+	r.Mount("/ocm", ocm)
+
+	const parent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	req := httptest.NewRequest(http.MethodPost, "/ocm/shares", nil)
+	req.Header.Set("traceparent", parent)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "POST /ocm/shares" {
+		t.Errorf("span name = %q, want %q", span.Name(), "POST /ocm/shares")
+	}
+
+	if span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span kind = %v, want server", span.SpanKind())
+	}
+
+	if got := span.SpanContext().TraceID().String(); got != "0af7651916cd43dd8448eb211c80319c" {
+		t.Errorf("trace id = %s, want inbound traceparent trace id", got)
+	}
+
+	if v, _ := spanAttr(span, "ocm.route_id"); v.AsString() != "ocm-shares-create" {
+		t.Errorf("ocm.route_id = %q, want ocm-shares-create", v.AsString())
+	}
+
+	if v, _ := spanAttr(span, "http.response.status_code"); v.AsInt64() != http.StatusCreated {
+		t.Errorf("status_code = %d, want 201", v.AsInt64())
+	}
+}
+
+func TestTracingMiddleware_UnmatchedRouteAndServerError(t *testing.T) {
+	sr := installSpanRecorder(t)
+
+	r := chi.NewRouter()
+	r.Use(TracingMiddleware(nil))
+	r.Get("/boom", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	for _, path := range []string{"/boom", "/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended spans = %d, want 2", len(spans))
+	}
+
+	if spans[0].Name() != "GET /boom" || spans[0].Status().Code != codes.Error {
+		t.Errorf("boom span = %q status %v, want GET /boom with error status", spans[0].Name(), spans[0].Status().Code)
+	}
+
+	if _, ok := spanAttr(spans[0], "ocm.route_id"); ok {
+		t.Error("ocm.route_id set without a route ID lookup")
+	}
+
+	if spans[1].Name() != http.MethodGet {
+		t.Errorf("unmatched span name = %q, want bare method", spans[1].Name())
+	}
+}
@@ -6,6 +6,8 @@
 package server
 
 import (
+	"strings"
+
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
 
@@ -49,6 +51,38 @@ func IsAuthRequired(path string, opts service.RouteOpts) bool {
 	return service.SessionAuthRequiredForPath(path, opts)
 }
 
+// routeIDLookup indexes the product routes in service.Routes(opts) by method
+// and full path so request spans carry the route-policy ID. chi reports
+// mounted patterns without a trailing slash, so keys drop it too.
+func routeIDLookup(opts service.RouteOpts) httpmw.RouteIDFunc {
+	ids := make(map[string]string)
+
+	for _, row := range service.Routes(opts) {
+		if row.Synthetic {
+			continue
+		}
+
+		ids[row.Method+" "+trimRouteSlash(row.FullPath)] = row.ID
+	}
+
+	return func(method, pattern string) string {
+		pattern = trimRouteSlash(pattern)
+		if id, ok := ids[method+" "+pattern]; ok {
+			return id
+		}
+
+		return ids["* "+pattern]
+	}
+}
+
+func trimRouteSlash(p string) string {
+	if p == "/" {
+		return p
+	}
+
+	return strings.TrimSuffix(p, "/")
+}
+
 func (s *Server) mountService(r chi.Router, svc service.Service, atRoot bool) {
 	if svc == nil {
 		return
@@ -70,12 +104,14 @@ func (s *Server) mountService(r chi.Router, svc service.Service, atRoot bool) {
 func (s *Server) setupRoutes() chi.Router {
 	r := chi.NewRouter()
 
+	routeOpts := service.RouteOptsFromConfig(s.cfg)
+
 	r.Use(chimw.RequestID)
+	r.Use(httpmw.TracingMiddleware(routeIDLookup(routeOpts)))
 	r.Use(httpmw.RequestLoggerMiddleware(s.logger, s.deps.RealIP))
 	r.Use(httpmw.AccessLogMiddleware(s.logger, s.deps.RealIP))
 	r.Use(chimw.Recoverer)
 
-	routeOpts := service.RouteOptsFromConfig(s.cfg)
 	authChecker := service.NewSessionAuthChecker(routeOpts)
 	r.Use(s.deps.AuthGate(authChecker.Required))
 
@@ -40,3 +40,23 @@ func TestPathMatchesPrefix_ViaServiceProjection(t *testing.T) {
 		}
 	}
 }
+
+func TestRouteIDLookup_MatchesChiPatterns(t *testing.T) {
+	t.Parallel()
+
+	lookup := routeIDLookup(service.DefaultRouteOpts())
+
+	cases := []struct {
+		method, pattern, want string
+	}{
+		{"POST", "/ocm/shares", "ocm-shares"},
+		{"POST", "/ocm/shares/", "ocm-shares"},
+		{"GET", "/ocm/shares", ""},
+		{"GET", "/no/such/route", ""},
+	}
+	for _, tc := range cases {
+		if got := lookup(tc.method, tc.pattern); got != tc.want {
+			t.Errorf("lookup(%s %s) = %q, want %q", tc.method, tc.pattern, got, tc.want)
+		}
+	}
+}
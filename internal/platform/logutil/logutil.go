@@ -26,3 +26,24 @@ func NoopIfNil(l *slog.Logger) *slog.Logger {
 
 	return noop
 }
+
+// LevelTrace is the level for [logging] level = "trace"; slog has no trace
+// level, so it sits below debug.
+const LevelTrace = slog.LevelDebug - 4
+
+// ParseLevel maps a [logging] level name (trace, debug, info, warn, error) to
+// a slog level. Unknown names map to info.
+func ParseLevel(level string) slog.Level {
+	switch level {
+	case "trace":
+		return LevelTrace
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package metrics holds the process-wide Prometheus collectors and the
+// recording helpers called at instrumentation points. Collectors live on a
+// private registry (not prometheus.DefaultRegisterer) so only the series
+// defined here, plus Go runtime and process stats, are exposed by Handler.
+//
+// Labels are drawn from closed sets (reason codes, endpoint kinds, statuses);
+// peer hosts, share IDs and user IDs are never used as label values.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "opencloudmesh"
+
+// Signature verification results besides the crypto.Reason* failure codes.
+const (
+	// SignatureVerified marks a request whose signature verified.
+	SignatureVerified = "verified"
+)
+
+// Outbound request statuses used when no HTTP response was received.
+const (
+	// OutboundDiscoveryError marks a POST abandoned because peer discovery failed.
+	OutboundDiscoveryError = "discovery_error"
+	// OutboundError marks a POST that failed before a response arrived.
+	OutboundError = "error"
+)
+
+// Cache names.
+const (
+	// CacheDiscovery is the remote discovery document cache.
+	CacheDiscovery = "discovery"
+	// CacheJWKS is the remote JWKS cache.
+	CacheJWKS = "jwks"
+	// CacheAccessToken is the receiver-side cache of exchanged access tokens.
+	CacheAccessToken = "access_token"
+)
+
+// Cache fetch triggers.
+const (
+	// FetchMiss is a fetch caused by a missing, expired or unusable entry.
+	FetchMiss = "miss"
+	// FetchRefresh is a forced refetch, e.g. a JWKS kid miss after rotation.
+	FetchRefresh = "refresh"
+)
+
+// Token exchange results besides the OAuth error codes.
+const (
+	// TokenIssued marks a successful token exchange.
+	TokenIssued = "issued"
+	// TokenRefreshed marks a successful refresh_token grant.
+	TokenRefreshed = "refreshed"
+)
+
+// Directions for share and invite transitions.
+const (
+	// DirectionIncoming is a share or invite received from a peer.
+	DirectionIncoming = "incoming"
+	// DirectionOutgoing is a share or invite sent to a peer.
+	DirectionOutgoing = "outgoing"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	signatureVerifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "signature_verifications_total",
+		Help:      "Inbound OCM signature checks by result (verified or a crypto reason code).",
+	}, []string{"result"})
+
+	outboundRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbound_requests_total",
+		Help:      "Outbound OCM POSTs by endpoint kind and HTTP status (or failure class).",
+	}, []string{"kind", "status"})
+
+	outboundDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "outbound_request_duration_seconds",
+		Help:      "Outbound OCM POST latency by endpoint kind, excluding discovery.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind"})
+
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Discovery and JWKS cache lookups by cache and result (hit or miss).",
+	}, []string{"cache", "result"})
+
+	cacheFetches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_fetches_total",
+		Help:      "Remote fetches that fill the discovery and JWKS caches, by trigger and result.",
+	}, []string{"cache", "trigger", "result"})
+
+	tokenExchanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "token_exchanges_total",
+		Help:      "Token endpoint requests by result (issued or an OAuth error code).",
+	}, []string{"result"})
+
+	rateLimitRejections = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ratelimit_rejections_total",
+		Help:      "Requests rejected with 429 by the rate limit interceptor.",
+	})
+
+	shareTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "share_transitions_total",
+		Help:      "Shares entering a status, by direction and new status.",
+	}, []string{"direction", "status"})
+
+	inviteTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invite_transitions_total",
+		Help:      "Invites entering a status, by direction and new status.",
+	}, []string{"direction", "status"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		signatureVerifications,
+		outboundRequests,
+		outboundDuration,
+		cacheLookups,
+		cacheFetches,
+		tokenExchanges,
+		rateLimitRejections,
+		shareTransitions,
+		inviteTransitions,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// SignatureVerification records one inbound signature check; result is
+// SignatureVerified or a crypto.Reason* code.
+func SignatureVerification(result string) {
+	signatureVerifications.WithLabelValues(result).Inc()
+}
+
+// OutboundRequest records one outbound POST to a kind endpoint. status is the
+// HTTP status code, or 0 with failure set to OutboundDiscoveryError or
+// OutboundError. A zero elapsed skips the latency histogram.
+func OutboundRequest(kind string, status int, failure string, elapsed time.Duration) {
+	label := failure
+	if status > 0 {
+		label = strconv.Itoa(status)
+	}
+
+	outboundRequests.WithLabelValues(kind, label).Inc()
+
+	if elapsed > 0 {
+		outboundDuration.WithLabelValues(kind).Observe(elapsed.Seconds())
+	}
+}
+
+// CacheLookup records a cache read against cache.
+func CacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+// CacheFetch records a remote fetch for cache; trigger is FetchMiss or FetchRefresh.
+func CacheFetch(cache, trigger string, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+
+	cacheFetches.WithLabelValues(cache, trigger, result).Inc()
+}
+
+// TokenExchange records one token endpoint outcome; result is TokenIssued,
+// TokenRefreshed, or the OAuth error code sent to the client.
+func TokenExchange(result string) {
+	tokenExchanges.WithLabelValues(result).Inc()
+}
+
+// RateLimitRejection records one request rejected by the rate limiter.
+func RateLimitRejection() {
+	rateLimitRejections.Inc()
+}
+
+// ShareTransition records a share entering status.
+func ShareTransition(direction, status string) {
+	shareTransitions.WithLabelValues(direction, status).Inc()
+}
+
+// InviteTransition records an invite entering status.
+func InviteTransition(direction, status string) {
+	inviteTransitions.WithLabelValues(direction, status).Inc()
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestOutboundRequest_StatusLabel(t *testing.T) {
+	t.Parallel()
+
+	created := delta(t, outboundRequests.WithLabelValues("shares", "201"), func() {
+		OutboundRequest("shares", http.StatusCreated, "", 10*time.Millisecond)
+	})
+	if created != 1 {
+		t.Errorf("status=201 delta = %v, want 1", created)
+	}
+
+	discovery := delta(t, outboundRequests.WithLabelValues("shares", OutboundDiscoveryError), func() {
+		OutboundRequest("shares", 0, OutboundDiscoveryError, 0)
+	})
+	if discovery != 1 {
+		t.Errorf("status=discovery_error delta = %v, want 1", discovery)
+	}
+}
+
+func TestCacheHelpers_Labels(t *testing.T) {
+	t.Parallel()
+
+	hit := delta(t, cacheLookups.WithLabelValues(CacheJWKS, "hit"), func() {
+		CacheLookup(CacheJWKS, true)
+	})
+	if hit != 1 {
+		t.Errorf("jwks hit delta = %v, want 1", hit)
+	}
+
+	failed := delta(t, cacheFetches.WithLabelValues(CacheJWKS, FetchRefresh, "error"), func() {
+		CacheFetch(CacheJWKS, FetchRefresh, errors.New("boom"))
+	})
+	if failed != 1 {
+		t.Errorf("jwks refresh error delta = %v, want 1", failed)
+	}
+}
+
+func TestHandler_ExposesNamespacedSeries(t *testing.T) {
+	t.Parallel()
+
+	SignatureVerification(SignatureVerified)
+	TokenExchange(TokenIssued)
+	RateLimitRejection()
+	ShareTransition(DirectionOutgoing, "sent")
+	InviteTransition(DirectionIncoming, "accepted")
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+
+	for _, want := range []string{
+		`opencloudmesh_signature_verifications_total{result="verified"}`,
+		`opencloudmesh_token_exchanges_total{result="issued"}`,
+		`opencloudmesh_ratelimit_rejections_total`,
+		`opencloudmesh_share_transitions_total{direction="outgoing",status="sent"}`,
+		`opencloudmesh_invite_transitions_total{direction="incoming",status="accepted"}`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("scrape output missing %q", want)
+		}
+	}
+}
+
+// delta returns how much c moved while record ran.
+func delta(t *testing.T, c prometheus.Collector, record func()) float64 {
+	t.Helper()
+
+	before := testutil.ToFloat64(c)
+	record()
+
+	return testutil.ToFloat64(c) - before
+}
@@ -34,7 +34,7 @@ func TestRepoContract(t *testing.T) {
 }
 
 // TestDurableDriversExposeAllRepoInterfaces verifies json, sqlite, and mirror
-// each expose all four app repo interfaces and that every required list
+// each expose every app repo interface and that every required list
 // operation is callable without error. repos.New returns an error if the
 // underlying store driver does not implement the fullStore union (via
 // type-assertion in newStoreRepos); this test makes that assertion visible
@@ -69,6 +69,34 @@ func TestDurableDriversExposeAllRepoInterfaces(t *testing.T) {
 				t.Fatalf("%s: IncomingInvites is nil", backend)
 			}
 
+			if r.Outbox == nil {
+				t.Fatalf("%s: Outbox is nil", backend)
+			}
+
+			if r.Parties == nil {
+				t.Fatalf("%s: Parties is nil", backend)
+			}
+
+			if r.Sessions == nil {
+				t.Fatalf("%s: Sessions is nil", backend)
+			}
+
+			if r.ShareRequests == nil {
+				t.Fatalf("%s: ShareRequests is nil", backend)
+			}
+
+			if r.Groups == nil {
+				t.Fatalf("%s: Groups is nil", backend)
+			}
+
+			if r.Transfers == nil {
+				t.Fatalf("%s: Transfers is nil", backend)
+			}
+
+			if r.PeerTrust == nil {
+				t.Fatalf("%s: PeerTrust is nil", backend)
+			}
+
 			// Smoke-test every list operation against an empty store to confirm
 			// the method is implemented and correctly wired.
 			if _, err := r.OutgoingShares.List(ctx); err != nil {
@@ -86,6 +114,34 @@ func TestDurableDriversExposeAllRepoInterfaces(t *testing.T) {
 			if _, err := r.IncomingInvites.ListByRecipientUserID(ctx, "contract-user"); err != nil {
 				t.Errorf("IncomingInvites.ListByRecipientUserID on empty store: %v", err)
 			}
+
+			if _, err := r.Outbox.List(ctx); err != nil {
+				t.Errorf("Outbox.List on empty store: %v", err)
+			}
+
+			if _, err := r.Parties.List(ctx, ""); err != nil {
+				t.Errorf("Parties.List on empty store: %v", err)
+			}
+
+			if _, err := r.ShareRequests.ListByOwnerUserID(ctx, "contract-user"); err != nil {
+				t.Errorf("ShareRequests.ListByOwnerUserID on empty store: %v", err)
+			}
+
+			if _, err := r.Groups.List(ctx); err != nil {
+				t.Errorf("Groups.List on empty store: %v", err)
+			}
+
+			if _, err := r.Transfers.List(ctx); err != nil {
+				t.Errorf("Transfers.List on empty store: %v", err)
+			}
+
+			if _, err := r.PeerTrust.ListEntries(ctx); err != nil {
+				t.Errorf("PeerTrust.ListEntries on empty store: %v", err)
+			}
+
+			if _, err := r.PeerTrust.ListTrustGroups(ctx); err != nil {
+				t.Errorf("PeerTrust.ListTrustGroups on empty store: %v", err)
+			}
 		})
 	}
 }
@@ -130,7 +186,7 @@ func TestIncomingInviteAcceptedIdentityCoalescedOnEmptyUpdate(t *testing.T) {
 	}
 }
 
-// runRepoContract exercises all four app repo interfaces against a single
+// runRepoContract exercises every app repo interface against a single
 // *repos.Repos instance. All subtests use IDs that are unique within this
 // call so no state leaks between subtests.
 func runRepoContract(t *testing.T, r *repos.Repos) {
@@ -147,4 +203,25 @@ func runRepoContract(t *testing.T, r *repos.Repos) {
 	t.Run("IncomingInvites", func(t *testing.T) {
 		runIncomingInviteRepoContract(t, r)
 	})
+	t.Run("Outbox", func(t *testing.T) {
+		runOutboxRepoContract(t, r)
+	})
+	t.Run("Parties", func(t *testing.T) {
+		runPartyRepoContract(t, r)
+	})
+	t.Run("Sessions", func(t *testing.T) {
+		runSessionRepoContract(t, r)
+	})
+	t.Run("ShareRequests", func(t *testing.T) {
+		runShareRequestRepoContract(t, r)
+	})
+	t.Run("Groups", func(t *testing.T) {
+		runGroupRepoContract(t, r)
+	})
+	t.Run("Transfers", func(t *testing.T) {
+		runTransferRepoContract(t, r)
+	})
+	t.Run("PeerTrust", func(t *testing.T) {
+		runPeerTrustRepoContract(t, r)
+	})
 }
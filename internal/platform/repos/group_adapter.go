@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// groupAdapter adapts store.GroupStore to identity.GroupRepo.
+type groupAdapter struct {
+	s store.GroupStore
+}
+
+var _ identity.GroupRepo = (*groupAdapter)(nil)
+
+func (a *groupAdapter) Create(ctx context.Context, group *identity.Group) error {
+	if group.ID == "" {
+		id, err := identity.UUIDv7()
+		if err != nil {
+			return fmt.Errorf("generate group id: %w", err)
+		}
+
+		group.ID = id
+	}
+
+	now := time.Now()
+	if group.CreatedAt.IsZero() {
+		group.CreatedAt = now
+	}
+
+	if group.UpdatedAt.IsZero() {
+		group.UpdatedAt = now
+	}
+
+	if err := a.s.CreateGroup(ctx, appGroupToStore(group)); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return identity.ErrGroupExists
+		}
+
+		return fmt.Errorf("repos: create group: %w", err)
+	}
+
+	return nil
+}
+
+func (a *groupAdapter) Get(ctx context.Context, id string) (*identity.Group, error) {
+	g, err := a.s.GetGroup(ctx, id)
+	if err != nil {
+		return nil, groupLookupError(err, "get group")
+	}
+
+	return storeGroupToApp(g), nil
+}
+
+func (a *groupAdapter) GetByName(ctx context.Context, name string) (*identity.Group, error) {
+	g, err := a.s.GetGroupByName(ctx, name)
+	if err != nil {
+		return nil, groupLookupError(err, "get group by name")
+	}
+
+	return storeGroupToApp(g), nil
+}
+
+func (a *groupAdapter) Update(ctx context.Context, group *identity.Group) error {
+	group.UpdatedAt = time.Now()
+
+	if err := a.s.UpdateGroup(ctx, appGroupToStore(group)); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return identity.ErrGroupNotFound
+		case errors.Is(err, store.ErrAlreadyExists):
+			return identity.ErrGroupExists
+		default:
+			return fmt.Errorf("repos: update group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (a *groupAdapter) Delete(ctx context.Context, id string) error {
+	if err := a.s.DeleteGroup(ctx, id); err != nil {
+		return groupLookupError(err, "delete group")
+	}
+
+	return nil
+}
+
+func (a *groupAdapter) List(ctx context.Context) ([]*identity.Group, error) {
+	storeGroups, err := a.s.ListGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repos: list groups: %w", err)
+	}
+
+	result := make([]*identity.Group, 0, len(storeGroups))
+	for _, g := range storeGroups {
+		result = append(result, storeGroupToApp(g))
+	}
+
+	slices.SortFunc(result, func(x, y *identity.Group) int {
+		return strings.Compare(x.Name, y.Name)
+	})
+
+	return result, nil
+}
+
+// groupLookupError maps store.ErrNotFound to identity.ErrGroupNotFound.
+func groupLookupError(err error, op string) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return identity.ErrGroupNotFound
+	}
+
+	return fmt.Errorf("repos: %s: %w", op, err)
+}
+
+func appGroupToStore(g *identity.Group) *store.Group {
+	return &store.Group{
+		ID:          g.ID,
+		Name:        g.Name,
+		DisplayName: g.DisplayName,
+		Members:     slices.Clone(g.Members),
+		CreatedAt:   timeToUnix(g.CreatedAt),
+		UpdatedAt:   timeToUnix(g.UpdatedAt),
+	}
+}
+
+func storeGroupToApp(g *store.Group) *identity.Group {
+	members := g.Members
+	if members == nil {
+		members = []string{}
+	}
+
+	return &identity.Group{
+		ID:          g.ID,
+		Name:        g.Name,
+		DisplayName: g.DisplayName,
+		Members:     slices.Clone(members),
+		CreatedAt:   unixToTime(g.CreatedAt),
+		UpdatedAt:   unixToTime(g.UpdatedAt),
+	}
+}
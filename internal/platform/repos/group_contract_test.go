@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+)
+
+// runGroupRepoContract verifies create auto-fill, name lookup, name
+// uniqueness, name-ordered listing, and not-found sentinels for
+// identity.GroupRepo.
+func runGroupRepoContract(t *testing.T, r *repos.Repos) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	t.Run("CreateGetUpdateDelete", func(t *testing.T) { runGroupRepoContractCRUD(t, ctx, r) })
+	t.Run("NameUniqueness", func(t *testing.T) { runGroupRepoContractNameUniqueness(t, ctx, r) })
+	t.Run("ListOrderedByName", func(t *testing.T) { runGroupRepoContractListOrdered(t, ctx, r) })
+}
+
+func runGroupRepoContractCRUD(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	group := &identity.Group{Name: "ct-group-crud", DisplayName: "CT Group", Members: []string{"ct-u1", "ct-u2"}}
+	if err := r.Groups.Create(ctx, group); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if group.ID == "" || group.CreatedAt.IsZero() || group.UpdatedAt.IsZero() {
+		t.Fatalf("Create did not fill id/timestamps: %+v", group)
+	}
+
+	got, err := r.Groups.GetByName(ctx, group.Name)
+	if err != nil {
+		t.Fatalf("GetByName: %v", err)
+	}
+
+	if got.ID != group.ID || !slices.Equal(got.Members, group.Members) {
+		t.Errorf("GetByName = %+v, want %+v", got, group)
+	}
+
+	got.Members = []string{"ct-u2"}
+	if err := r.Groups.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err = r.Groups.Get(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("Get after update: %v", err)
+	}
+
+	if !got.HasMember("ct-u2") || got.HasMember("ct-u1") {
+		t.Errorf("members after update = %v, want [ct-u2]", got.Members)
+	}
+
+	if err := r.Groups.Delete(ctx, group.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := r.Groups.Get(ctx, group.ID); !errors.Is(err, identity.ErrGroupNotFound) {
+		t.Errorf("Get after delete: expected ErrGroupNotFound, got %v", err)
+	}
+
+	if err := r.Groups.Delete(ctx, group.ID); !errors.Is(err, identity.ErrGroupNotFound) {
+		t.Errorf("Delete missing: expected ErrGroupNotFound, got %v", err)
+	}
+
+	if err := r.Groups.Update(ctx, group); !errors.Is(err, identity.ErrGroupNotFound) {
+		t.Errorf("Update missing: expected ErrGroupNotFound, got %v", err)
+	}
+}
+
+func runGroupRepoContractNameUniqueness(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	first := &identity.Group{Name: "ct-group-unique"}
+	if err := r.Groups.Create(ctx, first); err != nil {
+		t.Fatalf("Create first: %v", err)
+	}
+
+	second := &identity.Group{Name: first.Name}
+	if err := r.Groups.Create(ctx, second); !errors.Is(err, identity.ErrGroupExists) {
+		t.Errorf("Create duplicate name: expected ErrGroupExists, got %v", err)
+	}
+
+	got, err := r.Groups.Get(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("Get first: %v", err)
+	}
+
+	if got.Members == nil {
+		t.Error("Members = nil, want empty slice")
+	}
+}
+
+func runGroupRepoContractListOrdered(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	for _, name := range []string{"ct-list-zeta", "ct-list-alpha"} {
+		if err := r.Groups.Create(ctx, &identity.Group{Name: name}); err != nil {
+			t.Fatalf("Create %s: %v", name, err)
+		}
+	}
+
+	groups, err := r.Groups.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+
+	if !slices.IsSorted(names) {
+		t.Errorf("List names = %v, want sorted", names)
+	}
+
+	if !slices.Contains(names, "ct-list-alpha") || !slices.Contains(names, "ct-list-zeta") {
+		t.Errorf("List names = %v, want both created groups", names)
+	}
+}
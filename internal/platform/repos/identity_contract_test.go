@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+)
+
+// runPartyRepoContract verifies that every backend reports the same identity
+// errors and lookup semantics as identity.MemoryPartyRepo.
+func runPartyRepoContract(t *testing.T, r *repos.Repos) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	t.Run("CRUD", func(t *testing.T) { runPartyRepoContractCRUD(t, ctx, r) })
+	t.Run("Conflicts", func(t *testing.T) { runPartyRepoContractConflicts(t, ctx, r) })
+	t.Run("SuperAdminProtected", func(t *testing.T) { runPartyRepoContractSuperAdmin(t, ctx, r) })
+	t.Run("ListAndExpiry", func(t *testing.T) { runPartyRepoContractListAndExpiry(t, ctx, r) })
+}
+
+// runSessionRepoContract verifies session lifecycle and sentinel errors for
+// the identity SessionRepo interface.
+func runSessionRepoContract(t *testing.T, r *repos.Repos) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	t.Run("Lifecycle", func(t *testing.T) { runSessionRepoContractLifecycle(t, ctx, r) })
+	t.Run("Expiry", func(t *testing.T) { runSessionRepoContractExpiry(t, ctx, r) })
+}
+
+func runPartyRepoContractCRUD(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	user := &identity.User{
+		Username:     "ct-party-crud",
+		Email:        "  CT.Crud@Example.com ",
+		DisplayName:  "Contract Crud",
+		PasswordHash: "$2a$10$ct-crud",
+		Role:         identity.RoleUser,
+		StorageRoot:  "/data/ct-crud",
+	}
+	if err := r.Parties.Create(ctx, user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if user.ID == "" || user.CreatedAt.IsZero() {
+		t.Fatalf("Create did not fill ID/CreatedAt: %+v", user)
+	}
+
+	got, err := r.Parties.Get(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if got.Username != user.Username || got.PasswordHash != user.PasswordHash ||
+		got.StorageRoot != user.StorageRoot || got.Email != user.Email {
+		t.Errorf("Get = %+v, want %+v", got, user)
+	}
+
+	if got.CreatedAt.Unix() != user.CreatedAt.Unix() {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, user.CreatedAt)
+	}
+
+	if _, err := r.Parties.GetByUsername(ctx, user.Username); err != nil {
+		t.Errorf("GetByUsername: %v", err)
+	}
+
+	byEmail, err := r.Parties.GetByEmail(ctx, "ct.crud@EXAMPLE.com")
+	if err != nil || byEmail.ID != user.ID {
+		t.Errorf("GetByEmail (case-insensitive) = %+v, %v", byEmail, err)
+	}
+
+	if _, err := r.Parties.GetByEmail(ctx, "  "); !errors.Is(err, identity.ErrUserNotFound) {
+		t.Errorf("GetByEmail(blank) error = %v, want ErrUserNotFound", err)
+	}
+
+	got.DisplayName = "Renamed"
+	got.Email = "ct.crud.new@example.com"
+
+	if err := r.Parties.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if _, err := r.Parties.GetByEmail(ctx, "ct.crud@example.com"); !errors.Is(err, identity.ErrUserNotFound) {
+		t.Errorf("old email still resolves: %v", err)
+	}
+
+	updated, err := r.Parties.GetByEmail(ctx, "CT.CRUD.NEW@example.com")
+	if err != nil || updated.DisplayName != "Renamed" {
+		t.Errorf("GetByEmail after update = %+v, %v", updated, err)
+	}
+
+	if err := r.Parties.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := r.Parties.Get(ctx, user.ID); !errors.Is(err, identity.ErrUserNotFound) {
+		t.Errorf("Get after delete error = %v, want ErrUserNotFound", err)
+	}
+
+	if err := r.Parties.Delete(ctx, user.ID); !errors.Is(err, identity.ErrUserNotFound) {
+		t.Errorf("second Delete error = %v, want ErrUserNotFound", err)
+	}
+
+	if err := r.Parties.Update(ctx, user); !errors.Is(err, identity.ErrUserNotFound) {
+		t.Errorf("Update of deleted user error = %v, want ErrUserNotFound", err)
+	}
+}
+
+func runPartyRepoContractConflicts(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	first := &identity.User{Username: "ct-conflict-a", Email: "ct.conflict@example.com", Role: identity.RoleUser}
+	if err := r.Parties.Create(ctx, first); err != nil {
+		t.Fatalf("Create first: %v", err)
+	}
+
+	dupName := &identity.User{Username: "ct-conflict-a", Role: identity.RoleUser}
+	if err := r.Parties.Create(ctx, dupName); !errors.Is(err, identity.ErrUserExists) {
+		t.Errorf("duplicate username error = %v, want ErrUserExists", err)
+	}
+
+	dupEmail := &identity.User{Username: "ct-conflict-b", Email: " CT.Conflict@Example.com", Role: identity.RoleUser}
+	if err := r.Parties.Create(ctx, dupEmail); !errors.Is(err, identity.ErrEmailExists) {
+		t.Errorf("duplicate email error = %v, want ErrEmailExists", err)
+	}
+
+	second := &identity.User{Username: "ct-conflict-c", Role: identity.RoleUser}
+	if err := r.Parties.Create(ctx, second); err != nil {
+		t.Fatalf("Create second: %v", err)
+	}
+
+	second.Email = "ct.conflict@example.com"
+	if err := r.Parties.Update(ctx, second); !errors.Is(err, identity.ErrEmailExists) {
+		t.Errorf("update onto taken email error = %v, want ErrEmailExists", err)
+	}
+
+	second.Email = ""
+
+	second.Username = first.Username
+	if err := r.Parties.Update(ctx, second); !errors.Is(err, identity.ErrUserExists) {
+		t.Errorf("update onto taken username error = %v, want ErrUserExists", err)
+	}
+}
+
+func runPartyRepoContractSuperAdmin(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	admin := &identity.User{Username: "ct-super-admin", Role: identity.RoleSuperAdmin}
+	if err := r.Parties.Create(ctx, admin); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	demoted := *admin
+	demoted.Role = identity.RoleAdmin
+
+	if err := r.Parties.Update(ctx, &demoted); !errors.Is(err, identity.ErrSuperAdminRoleChange) {
+		t.Errorf("demote error = %v, want ErrSuperAdminRoleChange", err)
+	}
+
+	if err := r.Parties.Delete(ctx, admin.ID); !errors.Is(err, identity.ErrSuperAdminProtected) {
+		t.Errorf("delete error = %v, want ErrSuperAdminProtected", err)
+	}
+
+	admin.PasswordHash = "$2a$10$rotated"
+	if err := r.Parties.Update(ctx, admin); err != nil {
+		t.Fatalf("password rotation: %v", err)
+	}
+
+	got, err := r.Parties.Get(ctx, admin.ID)
+	if err != nil || got.PasswordHash != "$2a$10$rotated" || !got.IsSuperAdmin() {
+		t.Errorf("Get after rotation = %+v, %v", got, err)
+	}
+}
+
+func runPartyRepoContractListAndExpiry(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	expired := &identity.User{Username: "ct-probe-expired", Role: identity.RoleProbe, Realm: "ct-realm", ExpiresAt: &past}
+	live := &identity.User{Username: "ct-probe-live", Role: identity.RoleProbe, Realm: "ct-realm", ExpiresAt: &future}
+
+	for _, u := range []*identity.User{expired, live} {
+		if err := r.Parties.Create(ctx, u); err != nil {
+			t.Fatalf("Create %s: %v", u.Username, err)
+		}
+	}
+
+	inRealm, err := r.Parties.List(ctx, "ct-realm")
+	if err != nil {
+		t.Fatalf("List(realm): %v", err)
+	}
+
+	if len(inRealm) != 2 {
+		t.Errorf("List(ct-realm) returned %d users, want 2", len(inRealm))
+	}
+
+	n, err := r.Parties.DeleteExpired(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpired: %v", err)
+	}
+
+	if n != 1 {
+		t.Errorf("DeleteExpired removed %d users, want 1", n)
+	}
+
+	if _, err := r.Parties.Get(ctx, expired.ID); !errors.Is(err, identity.ErrUserNotFound) {
+		t.Errorf("expired probe still present: %v", err)
+	}
+
+	got, err := r.Parties.Get(ctx, live.ID)
+	if err != nil {
+		t.Fatalf("Get live probe: %v", err)
+	}
+
+	if got.ExpiresAt == nil || got.ExpiresAt.Unix() != future.Unix() {
+		t.Errorf("live probe ExpiresAt = %v, want %v", got.ExpiresAt, future)
+	}
+}
+
+func runSessionRepoContractLifecycle(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	session, err := r.Sessions.Create(ctx, "ct-session-user", time.Hour)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if session.Token == "" || session.UserID != "ct-session-user" {
+		t.Fatalf("Create returned %+v", session)
+	}
+
+	got, err := r.Sessions.Get(ctx, session.Token)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if got.UserID != session.UserID || got.ExpiresAt.Unix() != session.ExpiresAt.Unix() {
+		t.Errorf("Get = %+v, want %+v", got, session)
+	}
+
+	if _, err := r.Sessions.Get(ctx, "ct-unknown-token"); !errors.Is(err, identity.ErrSessionNotFound) {
+		t.Errorf("Get(unknown) error = %v, want ErrSessionNotFound", err)
+	}
+
+	if err := r.Sessions.Delete(ctx, session.Token); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if err := r.Sessions.Delete(ctx, session.Token); err != nil {
+		t.Errorf("Delete is not idempotent: %v", err)
+	}
+
+	if _, err := r.Sessions.Get(ctx, session.Token); !errors.Is(err, identity.ErrSessionNotFound) {
+		t.Errorf("Get after delete error = %v, want ErrSessionNotFound", err)
+	}
+
+	a, err := r.Sessions.Create(ctx, "ct-session-bulk", time.Hour)
+	if err != nil {
+		t.Fatalf("Create a: %v", err)
+	}
+
+	b, err := r.Sessions.Create(ctx, "ct-session-bulk", time.Hour)
+	if err != nil {
+		t.Fatalf("Create b: %v", err)
+	}
+
+	if err := r.Sessions.DeleteByUser(ctx, "ct-session-bulk"); err != nil {
+		t.Fatalf("DeleteByUser: %v", err)
+	}
+
+	for _, token := range []string{a.Token, b.Token} {
+		if _, err := r.Sessions.Get(ctx, token); !errors.Is(err, identity.ErrSessionNotFound) {
+			t.Errorf("session survived DeleteByUser: %v", err)
+		}
+	}
+}
+
+func runSessionRepoContractExpiry(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	expired, err := r.Sessions.Create(ctx, "ct-session-expiry", -time.Hour)
+	if err != nil {
+		t.Fatalf("Create expired: %v", err)
+	}
+
+	live, err := r.Sessions.Create(ctx, "ct-session-expiry", time.Hour)
+	if err != nil {
+		t.Fatalf("Create live: %v", err)
+	}
+
+	if _, err := r.Sessions.Get(ctx, expired.Token); !errors.Is(err, identity.ErrSessionExpired) {
+		t.Errorf("Get(expired) error = %v, want ErrSessionExpired", err)
+	}
+
+	n, err := r.Sessions.DeleteExpired(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpired: %v", err)
+	}
+
+	if n < 1 {
+		t.Errorf("DeleteExpired removed %d sessions, want at least 1", n)
+	}
+
+	if _, err := r.Sessions.Get(ctx, expired.Token); !errors.Is(err, identity.ErrSessionNotFound) {
+		t.Errorf("expired session still present after sweep: %v", err)
+	}
+
+	if _, err := r.Sessions.Get(ctx, live.Token); err != nil {
+		t.Errorf("live session removed by sweep: %v", err)
+	}
+}
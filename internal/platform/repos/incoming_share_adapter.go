@@ -91,6 +91,24 @@ func (a *incomingShareAdapter) GetByProviderID(
 	return storeIncomingShareToApp(s), nil
 }
 
+func (a *incomingShareAdapter) ListByProviderID(
+	ctx context.Context,
+	senderHost string,
+	providerID string,
+) ([]*sharesincoming.IncomingShare, error) {
+	storeShares, err := a.s.ListIncomingSharesByProviderKey(ctx, senderHost, providerID)
+	if err != nil {
+		return nil, fmt.Errorf("repos: list incoming shares by provider key: %w", err)
+	}
+
+	result := make([]*sharesincoming.IncomingShare, 0, len(storeShares))
+	for _, s := range storeShares {
+		result = append(result, storeIncomingShareToApp(s))
+	}
+
+	return result, nil
+}
+
 func (a *incomingShareAdapter) ListByRecipientUserID(
 	ctx context.Context,
 	recipientUserID string,
@@ -108,6 +126,26 @@ func (a *incomingShareAdapter) ListByRecipientUserID(
 	return result, nil
 }
 
+func (a *incomingShareAdapter) ListExpiring(
+	ctx context.Context,
+	now time.Time,
+) ([]*sharesincoming.IncomingShare, error) {
+	storeShares, err := a.s.ListExpiringIncomingShares(ctx, now.Unix(), []string{
+		string(shares.ShareStatusPending),
+		string(shares.ShareStatusAccepted),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repos: list expiring incoming shares: %w", err)
+	}
+
+	result := make([]*sharesincoming.IncomingShare, 0, len(storeShares))
+	for _, s := range storeShares {
+		result = append(result, storeIncomingShareToApp(s))
+	}
+
+	return result, nil
+}
+
 func (a *incomingShareAdapter) UpdateStatusForRecipientUserID(
 	ctx context.Context,
 	shareID string,
@@ -162,6 +200,10 @@ func storeIncomingShareToApp(s *store.IncomingShare) *sharesincoming.IncomingSha
 		WebappPermissions: permStringToSlice(s.WebappPermissions),
 		WebappURI:         s.WebappURI,
 		WebappTargets:     append([]string(nil), s.WebappTargets...),
+		WebappSecret:      s.WebappSharedSecret,
+		SSHURI:            s.SSHURI,
+		SSHAccessTypes:    append([]string(nil), s.SSHAccessTypes...),
+		WebDAVAccessTypes: append([]string(nil), s.WebDAVAccessTypes...),
 		ProtocolName:      s.ProtocolName,
 		Status:            shares.ShareStatus(s.Status),
 		RecipientUserID:   s.RecipientUserID,
@@ -176,31 +218,35 @@ func storeIncomingShareToApp(s *store.IncomingShare) *sharesincoming.IncomingSha
 // appIncomingShareToStore converts an app-layer model to the store model.
 func appIncomingShareToStore(a *sharesincoming.IncomingShare) *store.IncomingShare {
 	return &store.IncomingShare{
-		ShareID:           a.ShareID,
-		ProviderID:        a.ProviderID,
-		SenderHost:        a.SenderHost,
-		WebDAVID:          a.WebDAVID,
-		SharedSecret:      a.SharedSecret,
-		Owner:             a.Owner,
-		Sender:            a.Sender,
-		ShareWith:         a.ShareWith,
-		Name:              a.Name,
-		Description:       a.Description,
-		ResourceType:      a.ResourceType,
-		ShareType:         a.ShareType,
-		OwnerDisplayName:  a.OwnerDisplayName,
-		SenderDisplayName: a.SenderDisplayName,
-		Permissions:       permSliceToString(a.Permissions),
-		WebappPermissions: permSliceToString(a.WebappPermissions),
-		WebappURI:         a.WebappURI,
-		WebappTargets:     append([]string(nil), a.WebappTargets...),
-		ProtocolName:      a.ProtocolName,
-		Status:            string(a.Status),
-		RecipientUserID:   a.RecipientUserID,
-		OwnerHost:         a.OwnerHost,
-		Requirements:      append([]string(nil), a.Requirements...),
-		Expiration:        int64PtrToInt64(a.Expiration),
-		CreatedAt:         timeToUnix(a.CreatedAt),
-		UpdatedAt:         timeToUnix(a.UpdatedAt),
+		ShareID:            a.ShareID,
+		ProviderID:         a.ProviderID,
+		SenderHost:         a.SenderHost,
+		WebDAVID:           a.WebDAVID,
+		SharedSecret:       a.SharedSecret,
+		Owner:              a.Owner,
+		Sender:             a.Sender,
+		ShareWith:          a.ShareWith,
+		Name:               a.Name,
+		Description:        a.Description,
+		ResourceType:       a.ResourceType,
+		ShareType:          a.ShareType,
+		OwnerDisplayName:   a.OwnerDisplayName,
+		SenderDisplayName:  a.SenderDisplayName,
+		Permissions:        permSliceToString(a.Permissions),
+		WebappPermissions:  permSliceToString(a.WebappPermissions),
+		WebappURI:          a.WebappURI,
+		WebappTargets:      append([]string(nil), a.WebappTargets...),
+		WebappSharedSecret: a.WebappSecret,
+		SSHURI:             a.SSHURI,
+		SSHAccessTypes:     append([]string(nil), a.SSHAccessTypes...),
+		WebDAVAccessTypes:  append([]string(nil), a.WebDAVAccessTypes...),
+		ProtocolName:       a.ProtocolName,
+		Status:             string(a.Status),
+		RecipientUserID:    a.RecipientUserID,
+		OwnerHost:          a.OwnerHost,
+		Requirements:       append([]string(nil), a.Requirements...),
+		Expiration:         int64PtrToInt64(a.Expiration),
+		CreatedAt:          timeToUnix(a.CreatedAt),
+		UpdatedAt:          timeToUnix(a.UpdatedAt),
 	}
 }
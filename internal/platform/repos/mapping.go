@@ -4,7 +4,7 @@
 // OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
 
 // Package repos provides an app-facing persistence seam that constructs the
-// four OCM repository interfaces from a PersistenceConfig.
+// OCM repository interfaces from a PersistenceConfig.
 package repos
 
 import (
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// outboxAdapter adapts store.OutboxStore to outbox.MessageRepo.
+type outboxAdapter struct {
+	s store.OutboxStore
+}
+
+var _ outbox.MessageRepo = (*outboxAdapter)(nil)
+
+func (a *outboxAdapter) Create(ctx context.Context, msg *outbox.Message) error {
+	if msg.ID == "" {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("generate outbox message id: %w", err)
+		}
+
+		msg.ID = id.String()
+	}
+
+	if msg.CreatedAt.IsZero() {
+		msg.CreatedAt = time.Now()
+	}
+
+	if err := a.s.CreateOutboxMessage(ctx, appOutboxMessageToStore(msg)); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return fmt.Errorf("outbox message already exists: %w", store.ErrAlreadyExists)
+		}
+
+		return fmt.Errorf("repos: create outbox message: %w", err)
+	}
+
+	return nil
+}
+
+func (a *outboxAdapter) GetByID(ctx context.Context, id string) (*outbox.Message, error) {
+	m, err := a.s.GetOutboxMessage(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", outbox.ErrMessageNotFound, id)
+		}
+
+		return nil, fmt.Errorf("repos: get outbox message: %w", err)
+	}
+
+	return storeOutboxMessageToApp(m), nil
+}
+
+func (a *outboxAdapter) Update(ctx context.Context, msg *outbox.Message) error {
+	if err := a.s.UpdateOutboxMessage(ctx, appOutboxMessageToStore(msg)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return fmt.Errorf("%w: %s", outbox.ErrMessageNotFound, msg.ID)
+		}
+
+		return fmt.Errorf("repos: update outbox message: %w", err)
+	}
+
+	return nil
+}
+
+func (a *outboxAdapter) Delete(ctx context.Context, id string) error {
+	if err := a.s.DeleteOutboxMessage(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return fmt.Errorf("%w: %s", outbox.ErrMessageNotFound, id)
+		}
+
+		return fmt.Errorf("repos: delete outbox message: %w", err)
+	}
+
+	return nil
+}
+
+func (a *outboxAdapter) ListDue(ctx context.Context, now time.Time, limit int) ([]*outbox.Message, error) {
+	storeMsgs, err := a.s.ListDueOutboxMessages(ctx, now.Unix(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("repos: list due outbox messages: %w", err)
+	}
+
+	return storeOutboxMessagesToApp(storeMsgs), nil
+}
+
+func (a *outboxAdapter) List(ctx context.Context) ([]*outbox.Message, error) {
+	storeMsgs, err := a.s.ListOutboxMessages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repos: list outbox messages: %w", err)
+	}
+
+	return storeOutboxMessagesToApp(storeMsgs), nil
+}
+
+func storeOutboxMessagesToApp(storeMsgs []*store.OutboxMessage) []*outbox.Message {
+	result := make([]*outbox.Message, 0, len(storeMsgs))
+	for _, m := range storeMsgs {
+		result = append(result, storeOutboxMessageToApp(m))
+	}
+
+	return result
+}
+
+// storeOutboxMessageToApp converts a store model to the app-layer model.
+func storeOutboxMessageToApp(m *store.OutboxMessage) *outbox.Message {
+	return &outbox.Message{
+		ID:        m.ID,
+		Topic:     outbox.Topic(m.Topic),
+		SubjectID: m.SubjectID,
+		UserID:    m.UserID,
+		Request: outbound.Request{
+			TargetHost:   m.TargetHost,
+			EndpointPath: m.EndpointPath,
+			Kind:         outbound.EndpointKind(m.Kind),
+			// Copy the body so callers cannot mutate the store's backing array.
+			Body: append([]byte(nil), m.Body...),
+		},
+		Status:         outbox.Status(m.Status),
+		Attempts:       m.Attempts,
+		MaxAttempts:    m.MaxAttempts,
+		NextAttemptAt:  unixToTime(m.NextAttemptAt),
+		LastError:      m.LastError,
+		LastStatusCode: m.LastStatusCode,
+		CreatedAt:      unixToTime(m.CreatedAt),
+		UpdatedAt:      unixToTime(m.UpdatedAt),
+		DeliveredAt:    unixToTimePtr(m.DeliveredAt),
+	}
+}
+
+// appOutboxMessageToStore converts an app-layer model to the store model.
+func appOutboxMessageToStore(m *outbox.Message) *store.OutboxMessage {
+	return &store.OutboxMessage{
+		ID:           m.ID,
+		Topic:        string(m.Topic),
+		SubjectID:    m.SubjectID,
+		UserID:       m.UserID,
+		Kind:         string(m.Request.Kind),
+		TargetHost:   m.Request.TargetHost,
+		EndpointPath: m.Request.EndpointPath,
+		// Copy the body so the store cannot mutate the caller's backing array.
+		Body:           append([]byte(nil), m.Request.Body...),
+		Status:         string(m.Status),
+		Attempts:       m.Attempts,
+		MaxAttempts:    m.MaxAttempts,
+		NextAttemptAt:  timeToUnix(m.NextAttemptAt),
+		LastError:      m.LastError,
+		LastStatusCode: m.LastStatusCode,
+		CreatedAt:      timeToUnix(m.CreatedAt),
+		UpdatedAt:      timeToUnix(m.UpdatedAt),
+		DeliveredAt:    timePtrToUnix(m.DeliveredAt),
+	}
+}
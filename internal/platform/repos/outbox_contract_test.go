@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+)
+
+// runOutboxRepoContract verifies CRUD, due listing, auto-fill, and the
+// ErrMessageNotFound sentinel for the outbox MessageRepo interface.
+func runOutboxRepoContract(t *testing.T, r *repos.Repos) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	t.Run("CRUD", func(t *testing.T) { runOutboxRepoContractCRUD(t, ctx, r) })
+	t.Run("ListDue", func(t *testing.T) { runOutboxRepoContractListDue(t, ctx, r) })
+	t.Run("AutoFill", func(t *testing.T) { runOutboxRepoContractAutoFill(t, ctx, r) })
+	t.Run("ErrMessageNotFoundSentinel", func(t *testing.T) { runOutboxRepoContractErrMessageNotFound(t, ctx, r) })
+}
+
+func newContractOutboxMessage(id string, nextAttemptAt time.Time) *outbox.Message {
+	return &outbox.Message{
+		ID:        id,
+		Topic:     outbox.TopicInviteAccepted,
+		SubjectID: "invite-" + id,
+		UserID:    "ct-user-1",
+		Request: outbound.Request{
+			TargetHost:   "ct.sender.example",
+			EndpointPath: "invite-accepted",
+			Kind:         outbound.EndpointInvites,
+			Body:         []byte(`{"token":"` + id + `"}`),
+		},
+		Status:        outbox.StatusPending,
+		MaxAttempts:   4,
+		NextAttemptAt: nextAttemptAt,
+		CreatedAt:     nextAttemptAt,
+		UpdatedAt:     nextAttemptAt,
+	}
+}
+
+func runOutboxRepoContractCRUD(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	now := time.Unix(time.Now().Unix(), 0).UTC()
+	msg := newContractOutboxMessage("ct-outbox-1", now)
+
+	if err := r.Outbox.Create(ctx, msg); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := r.Outbox.GetByID(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	if got.Topic != msg.Topic || got.SubjectID != msg.SubjectID || got.UserID != msg.UserID {
+		t.Errorf("identity fields = %q/%q/%q, want %q/%q/%q",
+			got.Topic, got.SubjectID, got.UserID, msg.Topic, msg.SubjectID, msg.UserID)
+	}
+
+	if got.Request.Kind != outbound.EndpointInvites || got.Request.EndpointPath != "invite-accepted" ||
+		got.Request.TargetHost != "ct.sender.example" || string(got.Request.Body) != string(msg.Request.Body) {
+		t.Errorf("request = %+v, want %+v", got.Request, msg.Request)
+	}
+
+	if !got.NextAttemptAt.Equal(now) || got.MaxAttempts != 4 || got.DeliveredAt != nil {
+		t.Errorf("bookkeeping = next %v max %d delivered %v", got.NextAttemptAt, got.MaxAttempts, got.DeliveredAt)
+	}
+
+	got.Status = outbox.StatusDead
+	got.Attempts = 4
+	got.LastError = "peer returned status 400"
+	got.LastStatusCode = 400
+
+	if err := r.Outbox.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	updated, err := r.Outbox.GetByID(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("GetByID after update: %v", err)
+	}
+
+	if updated.Status != outbox.StatusDead || updated.Attempts != 4 ||
+		updated.LastError != "peer returned status 400" || updated.LastStatusCode != 400 {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	if err := r.Outbox.Delete(ctx, msg.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := r.Outbox.GetByID(ctx, msg.ID); !errors.Is(err, outbox.ErrMessageNotFound) {
+		t.Errorf("GetByID after delete: expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func runOutboxRepoContractListDue(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	base := time.Unix(time.Now().Unix(), 0).UTC()
+
+	for _, msg := range []*outbox.Message{
+		newContractOutboxMessage("ct-due-2", base.Add(-time.Minute)),
+		newContractOutboxMessage("ct-due-1", base.Add(-time.Hour)),
+		newContractOutboxMessage("ct-later", base.Add(time.Hour)),
+	} {
+		if err := r.Outbox.Create(ctx, msg); err != nil {
+			t.Fatalf("Create(%s): %v", msg.ID, err)
+		}
+	}
+
+	dead := newContractOutboxMessage("ct-dead", base.Add(-2*time.Hour))
+	dead.Status = outbox.StatusDead
+
+	if err := r.Outbox.Create(ctx, dead); err != nil {
+		t.Fatalf("Create(dead): %v", err)
+	}
+
+	due, err := r.Outbox.ListDue(ctx, base, 0)
+	if err != nil {
+		t.Fatalf("ListDue: %v", err)
+	}
+
+	if len(due) != 2 || due[0].ID != "ct-due-1" || due[1].ID != "ct-due-2" {
+		ids := make([]string, 0, len(due))
+		for _, m := range due {
+			ids = append(ids, m.ID)
+		}
+
+		t.Fatalf("ListDue = %v, want [ct-due-1 ct-due-2]", ids)
+	}
+
+	limited, err := r.Outbox.ListDue(ctx, base, 1)
+	if err != nil || len(limited) != 1 || limited[0].ID != "ct-due-1" {
+		t.Fatalf("ListDue limit 1 = %d messages, %v; want ct-due-1", len(limited), err)
+	}
+
+	all, err := r.Outbox.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	if len(all) < 4 {
+		t.Errorf("List returned %d messages, want at least 4", len(all))
+	}
+}
+
+func runOutboxRepoContractAutoFill(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	msg := newContractOutboxMessage("", time.Now())
+	msg.CreatedAt = time.Time{}
+
+	if err := r.Outbox.Create(ctx, msg); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if msg.ID == "" {
+		t.Error("Create must assign an id")
+	}
+
+	if msg.CreatedAt.IsZero() {
+		t.Error("Create must fill CreatedAt")
+	}
+}
+
+func runOutboxRepoContractErrMessageNotFound(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	if _, err := r.Outbox.GetByID(ctx, "ct-missing"); !errors.Is(err, outbox.ErrMessageNotFound) {
+		t.Errorf("GetByID: expected ErrMessageNotFound, got %v", err)
+	}
+
+	if err := r.Outbox.Update(ctx, newContractOutboxMessage("ct-missing", time.Now())); !errors.Is(err, outbox.ErrMessageNotFound) {
+		t.Errorf("Update: expected ErrMessageNotFound, got %v", err)
+	}
+
+	if err := r.Outbox.Delete(ctx, "ct-missing"); !errors.Is(err, outbox.ErrMessageNotFound) {
+		t.Errorf("Delete: expected ErrMessageNotFound, got %v", err)
+	}
+}
@@ -97,6 +97,20 @@ func (a *outgoingInviteAdapter) List(ctx context.Context) ([]*invitesoutgoing.Ou
 	return result, nil
 }
 
+func (a *outgoingInviteAdapter) ListExpiring(ctx context.Context, now time.Time) ([]*invitesoutgoing.OutgoingInvite, error) {
+	storeInvites, err := a.s.ListExpiringOutgoingInvites(ctx, now.Unix(), []string{string(invites.InviteStatusPending)})
+	if err != nil {
+		return nil, fmt.Errorf("repos: list expiring outgoing invites: %w", err)
+	}
+
+	result := make([]*invitesoutgoing.OutgoingInvite, 0, len(storeInvites))
+	for _, s := range storeInvites {
+		result = append(result, storeOutgoingInviteToApp(s))
+	}
+
+	return result, nil
+}
+
 func (a *outgoingInviteAdapter) UpdateStatus(
 	ctx context.Context,
 	id string,
@@ -56,7 +56,7 @@ func (a *outgoingShareAdapter) GetByID(ctx context.Context, shareID string) (*sh
 	s, err := a.s.GetOutgoingShareByID(ctx, shareID)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
-			return nil, fmt.Errorf("share not found: %s", shareID)
+			return nil, fmt.Errorf("%w: %s", sharesoutgoing.ErrShareNotFound, shareID)
 		}
 
 		return nil, fmt.Errorf("repos: get outgoing share by id: %w", err)
@@ -104,6 +104,22 @@ func (a *outgoingShareAdapter) GetBySharedSecret(ctx context.Context, sharedSecr
 	return storeOutgoingShareToApp(s), nil
 }
 
+func (a *outgoingShareAdapter) GetByWebappSharedSecret(
+	ctx context.Context,
+	webappSharedSecret string,
+) (*sharesoutgoing.OutgoingShare, error) {
+	s, err := a.s.GetOutgoingShareByWebappSharedSecret(ctx, webappSharedSecret)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, sharesoutgoing.ErrShareNotFound
+		}
+
+		return nil, fmt.Errorf("repos: get outgoing share by webapp shared secret: %w", err)
+	}
+
+	return storeOutgoingShareToApp(s), nil
+}
+
 func (a *outgoingShareAdapter) List(ctx context.Context) ([]*sharesoutgoing.OutgoingShare, error) {
 	storeShares, err := a.s.ListOutgoingShares(ctx)
 	if err != nil {
@@ -118,6 +134,24 @@ func (a *outgoingShareAdapter) List(ctx context.Context) ([]*sharesoutgoing.Outg
 	return result, nil
 }
 
+func (a *outgoingShareAdapter) ListExpiring(ctx context.Context, now time.Time) ([]*sharesoutgoing.OutgoingShare, error) {
+	storeShares, err := a.s.ListExpiringOutgoingShares(ctx, now.Unix(), []string{
+		string(shares.OutgoingShareStatusPending),
+		string(shares.OutgoingShareStatusSent),
+		string(shares.OutgoingShareStatusAccepted),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repos: list expiring outgoing shares: %w", err)
+	}
+
+	result := make([]*sharesoutgoing.OutgoingShare, 0, len(storeShares))
+	for _, s := range storeShares {
+		result = append(result, storeOutgoingShareToApp(s))
+	}
+
+	return result, nil
+}
+
 func (a *outgoingShareAdapter) Update(ctx context.Context, share *sharesoutgoing.OutgoingShare) error {
 	s := appOutgoingShareToStore(share)
 	if err := a.s.UpdateOutgoingShare(ctx, s); err != nil {
@@ -153,9 +187,17 @@ func storeOutgoingShareToApp(s *store.OutgoingShare) *sharesoutgoing.OutgoingSha
 		Status:           shares.OutgoingShareStatus(s.Status),
 		Error:            s.Error,
 		// Copy the slice so callers cannot mutate the store's backing array.
-		Requirements: append([]string(nil), s.Requirements...),
-		CreatedAt:    unixToTime(s.CreatedAt),
-		SentAt:       unixToTimePtr(s.UpdatedAt),
+		Requirements:       append([]string(nil), s.Requirements...),
+		WebappURI:          s.WebappURI,
+		WebappPermissions:  permStringToSlice(s.WebappPermissions),
+		WebappTargets:      append([]string(nil), s.WebappTargets...),
+		WebappSharedSecret: s.WebappSharedSecret,
+		CreatedAt:          unixToTime(s.CreatedAt),
+		SentAt:             unixToTimePtr(s.UpdatedAt),
+		// SSH arm fields; copy the keys like Requirements.
+		SSHURI:              s.SSHURI,
+		RecipientPublicKeys: append([]string(nil), s.RecipientPublicKeys...),
+		ExpiresAt:           unixToTimePtr(s.ExpiresAt),
 	}
 }
 
@@ -179,8 +221,16 @@ func appOutgoingShareToStore(a *sharesoutgoing.OutgoingShare) *store.OutgoingSha
 		Status:           string(a.Status),
 		Error:            a.Error,
 		// Copy the slice so the store cannot mutate the caller's backing array.
-		Requirements: append([]string(nil), a.Requirements...),
-		CreatedAt:    timeToUnix(a.CreatedAt),
-		UpdatedAt:    timePtrToUnix(a.SentAt),
+		Requirements:       append([]string(nil), a.Requirements...),
+		WebappURI:          a.WebappURI,
+		WebappPermissions:  permSliceToString(a.WebappPermissions),
+		WebappTargets:      append([]string(nil), a.WebappTargets...),
+		WebappSharedSecret: a.WebappSharedSecret,
+		CreatedAt:          timeToUnix(a.CreatedAt),
+		UpdatedAt:          timePtrToUnix(a.SentAt),
+		// SSH arm fields; copy the keys like Requirements.
+		SSHURI:              a.SSHURI,
+		RecipientPublicKeys: append([]string(nil), a.RecipientPublicKeys...),
+		ExpiresAt:           timePtrToUnix(a.ExpiresAt),
 	}
 }
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// partyAdapter adapts store.PartyStore to identity.PartyRepo. Super-admin
+// protection and username/email conflict classification live here so every
+// backend reports the same identity errors as the in-memory repo.
+type partyAdapter struct {
+	s store.PartyStore
+}
+
+var _ identity.PartyRepo = (*partyAdapter)(nil)
+
+func (a *partyAdapter) Create(ctx context.Context, user *identity.User) error {
+	if user.ID == "" {
+		id, err := identity.UUIDv7()
+		if err != nil {
+			return fmt.Errorf("generate user id: %w", err)
+		}
+
+		user.ID = id
+	}
+
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+
+	if err := a.s.CreateParty(ctx, appUserToStore(user)); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return a.conflictError(ctx, user)
+		}
+
+		return fmt.Errorf("repos: create party: %w", err)
+	}
+
+	return nil
+}
+
+func (a *partyAdapter) Get(ctx context.Context, id string) (*identity.User, error) {
+	p, err := a.s.GetParty(ctx, id)
+	if err != nil {
+		return nil, partyLookupError(err, "get party")
+	}
+
+	return storePartyToApp(p), nil
+}
+
+func (a *partyAdapter) GetByUsername(ctx context.Context, username string) (*identity.User, error) {
+	p, err := a.s.GetPartyByUsername(ctx, username)
+	if err != nil {
+		return nil, partyLookupError(err, "get party by username")
+	}
+
+	return storePartyToApp(p), nil
+}
+
+func (a *partyAdapter) GetByEmail(ctx context.Context, email string) (*identity.User, error) {
+	norm := normalizeEmail(email)
+	if norm == "" {
+		return nil, identity.ErrUserNotFound
+	}
+
+	p, err := a.s.GetPartyByEmail(ctx, norm)
+	if err != nil {
+		return nil, partyLookupError(err, "get party by email")
+	}
+
+	return storePartyToApp(p), nil
+}
+
+func (a *partyAdapter) Update(ctx context.Context, user *identity.User) error {
+	existing, err := a.s.GetParty(ctx, user.ID)
+	if err != nil {
+		return partyLookupError(err, "get party")
+	}
+
+	if existing.Role == identity.RoleSuperAdmin && user.Role != identity.RoleSuperAdmin {
+		return identity.ErrSuperAdminRoleChange
+	}
+
+	if err := a.s.UpdateParty(ctx, appUserToStore(user)); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return a.conflictError(ctx, user)
+		}
+
+		return partyLookupError(err, "update party")
+	}
+
+	return nil
+}
+
+func (a *partyAdapter) Delete(ctx context.Context, id string) error {
+	existing, err := a.s.GetParty(ctx, id)
+	if err != nil {
+		return partyLookupError(err, "get party")
+	}
+
+	if existing.Role == identity.RoleSuperAdmin {
+		return identity.ErrSuperAdminProtected
+	}
+
+	if err := a.s.DeleteParty(ctx, id); err != nil {
+		return partyLookupError(err, "delete party")
+	}
+
+	return nil
+}
+
+func (a *partyAdapter) List(ctx context.Context, realm string) ([]*identity.User, error) {
+	parties, err := a.s.ListParties(ctx, realm)
+	if err != nil {
+		return nil, fmt.Errorf("repos: list parties: %w", err)
+	}
+
+	users := make([]*identity.User, 0, len(parties))
+	for _, p := range parties {
+		users = append(users, storePartyToApp(p))
+	}
+
+	return users, nil
+}
+
+func (a *partyAdapter) DeleteExpired(ctx context.Context) (int, error) {
+	n, err := a.s.DeleteExpiredParties(ctx, time.Now().Unix())
+	if err != nil {
+		return 0, fmt.Errorf("repos: delete expired parties: %w", err)
+	}
+
+	return n, nil
+}
+
+// conflictError classifies a store uniqueness violation. A username held by
+// another party is ErrUserExists, an email held by another party is
+// ErrEmailExists, and anything else is an id clash, reported as ErrUserExists.
+func (a *partyAdapter) conflictError(ctx context.Context, user *identity.User) error {
+	if existing, err := a.s.GetPartyByUsername(ctx, user.Username); err == nil && existing.ID != user.ID {
+		return identity.ErrUserExists
+	}
+
+	if existing, err := a.s.GetPartyByEmail(ctx, normalizeEmail(user.Email)); err == nil && existing.ID != user.ID {
+		return identity.ErrEmailExists
+	}
+
+	return identity.ErrUserExists
+}
+
+// partyLookupError maps store.ErrNotFound to identity.ErrUserNotFound and
+// wraps everything else with the failed operation.
+func partyLookupError(err error, op string) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return identity.ErrUserNotFound
+	}
+
+	return fmt.Errorf("repos: %s: %w", op, err)
+}
+
+// normalizeEmail lowercases and trims an email for lookup, matching the
+// in-memory identity repo.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// storePartyToApp converts a store model to the app-layer model.
+func storePartyToApp(p *store.Party) *identity.User {
+	return &identity.User{
+		ID:           p.ID,
+		Username:     p.Username,
+		Email:        p.Email,
+		DisplayName:  p.DisplayName,
+		PasswordHash: p.PasswordHash,
+		Role:         p.Role,
+		Realm:        p.Realm,
+		StorageRoot:  p.StorageRoot,
+		CreatedAt:    unixToTime(p.CreatedAt),
+		ExpiresAt:    unixToTimePtr(p.ExpiresAt),
+
+		SSHPublicKeys: append([]string(nil), p.SSHPublicKeys...),
+	}
+}
+
+// appUserToStore converts an app-layer model to the store model.
+func appUserToStore(u *identity.User) *store.Party {
+	return &store.Party{
+		ID:              u.ID,
+		Username:        u.Username,
+		Email:           u.Email,
+		EmailNormalized: normalizeEmail(u.Email),
+		DisplayName:     u.DisplayName,
+		PasswordHash:    u.PasswordHash,
+		Role:            u.Role,
+		Realm:           u.Realm,
+		StorageRoot:     u.StorageRoot,
+		CreatedAt:       timeToUnix(u.CreatedAt),
+		ExpiresAt:       timePtrToUnix(u.ExpiresAt),
+		SSHPublicKeys:   append([]string(nil), u.SSHPublicKeys...),
+	}
+}
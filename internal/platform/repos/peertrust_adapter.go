@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// peerTrustAdapter adapts store.PeerTrustStore to peertrust.Repo.
+type peerTrustAdapter struct {
+	s store.PeerTrustStore
+}
+
+var _ peertrust.Repo = (*peerTrustAdapter)(nil)
+
+func (a *peerTrustAdapter) CreateEntry(ctx context.Context, e *peertrust.PolicyEntry) error {
+	if err := a.s.CreatePeerPolicyEntry(ctx, &store.PeerPolicyEntry{
+		List:      e.List,
+		Host:      e.Host,
+		CreatedAt: timeToUnix(e.CreatedAt),
+	}); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return peertrust.ErrEntryExists
+		}
+
+		return fmt.Errorf("repos: create peer policy entry: %w", err)
+	}
+
+	return nil
+}
+
+func (a *peerTrustAdapter) DeleteEntry(ctx context.Context, list, host string) error {
+	if err := a.s.DeletePeerPolicyEntry(ctx, list, host); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return peertrust.ErrEntryNotFound
+		}
+
+		return fmt.Errorf("repos: delete peer policy entry: %w", err)
+	}
+
+	return nil
+}
+
+func (a *peerTrustAdapter) ListEntries(ctx context.Context) ([]*peertrust.PolicyEntry, error) {
+	storeEntries, err := a.s.ListPeerPolicyEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repos: list peer policy entries: %w", err)
+	}
+
+	result := make([]*peertrust.PolicyEntry, 0, len(storeEntries))
+	for _, s := range storeEntries {
+		result = append(result, &peertrust.PolicyEntry{
+			List:      s.List,
+			Host:      s.Host,
+			CreatedAt: unixToTime(s.CreatedAt),
+		})
+	}
+
+	return result, nil
+}
+
+func (a *peerTrustAdapter) CreateTrustGroup(ctx context.Context, g *peertrust.AttachedTrustGroup) error {
+	cfg, err := json.Marshal(g.Config)
+	if err != nil {
+		return fmt.Errorf("repos: encode trust group config: %w", err)
+	}
+
+	if err := a.s.CreatePeerTrustGroup(ctx, &store.PeerTrustGroup{
+		TrustGroupID: g.Config.TrustGroupID,
+		Config:       string(cfg),
+		CreatedAt:    timeToUnix(g.CreatedAt),
+	}); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return peertrust.ErrTrustGroupExists
+		}
+
+		return fmt.Errorf("repos: create peer trust group: %w", err)
+	}
+
+	return nil
+}
+
+func (a *peerTrustAdapter) DeleteTrustGroup(ctx context.Context, trustGroupID string) error {
+	if err := a.s.DeletePeerTrustGroup(ctx, trustGroupID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return peertrust.ErrTrustGroupNotFound
+		}
+
+		return fmt.Errorf("repos: delete peer trust group: %w", err)
+	}
+
+	return nil
+}
+
+func (a *peerTrustAdapter) ListTrustGroups(ctx context.Context) ([]*peertrust.AttachedTrustGroup, error) {
+	storeGroups, err := a.s.ListPeerTrustGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repos: list peer trust groups: %w", err)
+	}
+
+	result := make([]*peertrust.AttachedTrustGroup, 0, len(storeGroups))
+
+	for _, s := range storeGroups {
+		cfg, err := peertrust.ParseTrustGroupConfig([]byte(s.Config))
+		if err != nil {
+			return nil, fmt.Errorf("repos: decode peer trust group %s: %w", s.TrustGroupID, err)
+		}
+
+		result = append(result, &peertrust.AttachedTrustGroup{
+			Config:    cfg,
+			CreatedAt: unixToTime(s.CreatedAt),
+		})
+	}
+
+	return result, nil
+}
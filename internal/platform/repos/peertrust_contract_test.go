@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/directoryservice"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+)
+
+// runPeerTrustRepoContract verifies round-tripping, duplicate rejection, and
+// not-found sentinels for peertrust.Repo.
+func runPeerTrustRepoContract(t *testing.T, r *repos.Repos) {
+	t.Helper()
+
+	ctx := context.Background()
+	created := time.Unix(1_700_000_000, 0).UTC()
+
+	entry := &peertrust.PolicyEntry{List: peertrust.ListDeny, Host: "ct-blocked.example.com", CreatedAt: created}
+	if err := r.PeerTrust.CreateEntry(ctx, entry); err != nil {
+		t.Fatalf("CreateEntry: %v", err)
+	}
+
+	if err := r.PeerTrust.CreateEntry(ctx, entry); !errors.Is(err, peertrust.ErrEntryExists) {
+		t.Errorf("duplicate CreateEntry = %v, want ErrEntryExists", err)
+	}
+
+	entries, err := r.PeerTrust.ListEntries(ctx)
+	if err != nil || len(entries) != 1 || *entries[0] != *entry {
+		t.Errorf("ListEntries = %+v, %v; want the single entry", entries, err)
+	}
+
+	if err := r.PeerTrust.DeleteEntry(ctx, peertrust.ListAllow, entry.Host); !errors.Is(err, peertrust.ErrEntryNotFound) {
+		t.Errorf("DeleteEntry on the other list = %v, want ErrEntryNotFound", err)
+	}
+
+	if err := r.PeerTrust.DeleteEntry(ctx, peertrust.ListDeny, entry.Host); err != nil {
+		t.Errorf("DeleteEntry: %v", err)
+	}
+
+	group := &peertrust.AttachedTrustGroup{
+		Config: &peertrust.TrustGroupConfig{
+			TrustGroupID: "ct-federation",
+			DirectoryServices: []directoryservice.EndpointConfig{
+				{URL: "https://directory.ct.example/listing", Enabled: true, Verification: "optional"},
+			},
+			Enabled:           true,
+			EnforceMembership: true,
+		},
+		CreatedAt: created,
+	}
+	if err := r.PeerTrust.CreateTrustGroup(ctx, group); err != nil {
+		t.Fatalf("CreateTrustGroup: %v", err)
+	}
+
+	if err := r.PeerTrust.CreateTrustGroup(ctx, group); !errors.Is(err, peertrust.ErrTrustGroupExists) {
+		t.Errorf("duplicate CreateTrustGroup = %v, want ErrTrustGroupExists", err)
+	}
+
+	groups, err := r.PeerTrust.ListTrustGroups(ctx)
+	if err != nil || len(groups) != 1 {
+		t.Fatalf("ListTrustGroups = %+v, %v; want the single group", groups, err)
+	}
+
+	if !reflect.DeepEqual(groups[0].Config, group.Config) || !groups[0].CreatedAt.Equal(created) {
+		t.Errorf("ListTrustGroups[0] = %+v, want %+v", groups[0].Config, group.Config)
+	}
+
+	if err := r.PeerTrust.DeleteTrustGroup(ctx, "ct-federation"); err != nil {
+		t.Errorf("DeleteTrustGroup: %v", err)
+	}
+
+	if err := r.PeerTrust.DeleteTrustGroup(ctx, "ct-federation"); !errors.Is(err, peertrust.ErrTrustGroupNotFound) {
+		t.Errorf("DeleteTrustGroup missing = %v, want ErrTrustGroupNotFound", err)
+	}
+}
@@ -9,8 +9,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
 	invitesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/incoming"
 	invitesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
 	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
 	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
@@ -23,7 +28,7 @@ import (
 	_ "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store/sqlite"
 )
 
-// Repos holds the four app-level repository interfaces produced by the seam.
+// Repos holds the app-level repository interfaces produced by the seam.
 // Callers must call Close when done to release resources held by the backing
 // store driver.
 type Repos struct {
@@ -31,6 +36,13 @@ type Repos struct {
 	IncomingShares  sharesincoming.IncomingShareRepo
 	OutgoingInvites invitesoutgoing.OutgoingInviteRepo
 	IncomingInvites invitesincoming.IncomingInviteRepo
+	Outbox          outbox.MessageRepo
+	Parties         identity.PartyRepo
+	Sessions        identity.SessionRepo
+	ShareRequests   sharerequests.Repo
+	Groups          identity.GroupRepo
+	Transfers       datatx.Repo
+	PeerTrust       peertrust.Repo
 
 	// driver is the backing store driver for every backend.
 	driver store.Driver
@@ -60,13 +72,20 @@ func New(ctx context.Context, cfg config.PersistenceConfig) (*Repos, error) {
 	}
 }
 
-// fullStore is the union of all four store surfaces every store driver must
+// fullStore is the union of all store surfaces every store driver must
 // implement.
 type fullStore interface {
 	store.OutgoingShareStore
 	store.IncomingShareStore
 	store.OutgoingInviteStore
 	store.IncomingInviteStore
+	store.OutboxStore
+	store.PartyStore
+	store.SessionStore
+	store.ShareRequestStore
+	store.GroupStore
+	store.TransferStore
+	store.PeerTrustStore
 }
 
 func newStoreRepos(ctx context.Context, cfg config.PersistenceConfig) (*Repos, error) {
@@ -100,6 +119,13 @@ func newStoreRepos(ctx context.Context, cfg config.PersistenceConfig) (*Repos, e
 		IncomingShares:  &incomingShareAdapter{s: fs},
 		OutgoingInvites: &outgoingInviteAdapter{s: fs},
 		IncomingInvites: &incomingInviteAdapter{s: fs},
+		Outbox:          &outboxAdapter{s: fs},
+		Parties:         &partyAdapter{s: fs},
+		Sessions:        &sessionAdapter{s: fs},
+		ShareRequests:   &shareRequestAdapter{s: fs},
+		Groups:          &groupAdapter{s: fs},
+		Transfers:       &transferAdapter{s: fs},
+		PeerTrust:       &peerTrustAdapter{s: fs},
 		driver:          drv,
 	}, nil
 }
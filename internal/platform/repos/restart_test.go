@@ -6,6 +6,7 @@
 package repos_test
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
 	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
 
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
 	invitesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/incoming"
 	invitesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/outgoing"
@@ -204,3 +206,152 @@ func assertRestartedIncomingInvite(t *testing.T, ctx context.Context, r2 *repos.
 		t.Errorf("incoming invite token index mismatch: expected %q, got %q", inInvite.ID, gotInByToken.ID)
 	}
 }
+
+// TestDurableRepos_OutboxRestart verifies that pending outbox messages survive
+// a restart and are still listed as due, which is what lets the dispatcher
+// resume delivery after the process comes back.
+func TestDurableRepos_OutboxRestart(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	for _, backend := range tsrepos.DurableBackends() {
+		t.Run(backend, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.PersistenceConfig{
+				Backend: backend,
+				DataDir: t.TempDir(),
+			}
+			now := time.Unix(time.Now().Unix(), 0).UTC()
+
+			r1, err := repos.New(ctx, cfg)
+			if err != nil {
+				t.Fatalf("repos.New (first session): %v", err)
+			}
+
+			msg := newContractOutboxMessage("restart-outbox-"+backend, now.Add(-time.Minute))
+			msg.Attempts = 2
+
+			if err := r1.Outbox.Create(ctx, msg); err != nil {
+				t.Fatalf("Outbox.Create: %v", err)
+			}
+
+			tshttp.MustClose(t, r1)
+
+			r2, err := repos.New(ctx, cfg)
+			if err != nil {
+				t.Fatalf("repos.New (second session): %v", err)
+			}
+			defer tshttp.MustClose(t, r2)
+
+			due, err := r2.Outbox.ListDue(ctx, now, 0)
+			if err != nil {
+				t.Fatalf("Outbox.ListDue after restart: %v", err)
+			}
+
+			if len(due) != 1 || due[0].ID != msg.ID {
+				t.Fatalf("ListDue after restart = %d messages, want %s", len(due), msg.ID)
+			}
+
+			got := due[0]
+			if got.Attempts != 2 || string(got.Request.Body) != string(msg.Request.Body) ||
+				got.Request.Kind != msg.Request.Kind || !got.NextAttemptAt.Equal(msg.NextAttemptAt) {
+				t.Errorf("restarted message = %+v, want %+v", got, msg)
+			}
+		})
+	}
+}
+
+// TestDurableRepos_IdentityRestart verifies that users and login sessions
+// survive a restart, so a process restart neither logs users out nor loses
+// accounts created after bootstrap.
+func TestDurableRepos_IdentityRestart(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	for _, backend := range tsrepos.DurableBackends() {
+		t.Run(backend, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.PersistenceConfig{
+				Backend: backend,
+				DataDir: t.TempDir(),
+			}
+
+			r1, err := repos.New(ctx, cfg)
+			if err != nil {
+				t.Fatalf("repos.New (first session): %v", err)
+			}
+
+			user := &identity.User{
+				Username:     "restart-user",
+				Email:        "Restart@Example.com",
+				PasswordHash: "$2a$10$restart-hash",
+				Role:         identity.RoleAdmin,
+			}
+			if err := r1.Parties.Create(ctx, user); err != nil {
+				t.Fatalf("Parties.Create: %v", err)
+			}
+
+			session, err := r1.Sessions.Create(ctx, user.ID, time.Hour)
+			if err != nil {
+				t.Fatalf("Sessions.Create: %v", err)
+			}
+
+			tshttp.MustClose(t, r1)
+
+			requireNotOnDisk(t, cfg.DataDir, session.Token)
+
+			r2, err := repos.New(ctx, cfg)
+			if err != nil {
+				t.Fatalf("repos.New (second session): %v", err)
+			}
+			defer tshttp.MustClose(t, r2)
+
+			got, err := r2.Parties.GetByEmail(ctx, "restart@example.com")
+			if err != nil {
+				t.Fatalf("Parties.GetByEmail after restart: %v", err)
+			}
+
+			if got.ID != user.ID || got.PasswordHash != user.PasswordHash || !got.IsAdmin() {
+				t.Errorf("restarted user = %+v, want %+v", got, user)
+			}
+
+			gotSession, err := r2.Sessions.Get(ctx, session.Token)
+			if err != nil {
+				t.Fatalf("Sessions.Get after restart: %v", err)
+			}
+
+			if gotSession.UserID != user.ID {
+				t.Errorf("restarted session user = %q, want %q", gotSession.UserID, user.ID)
+			}
+		})
+	}
+}
+
+// requireNotOnDisk fails when any file under dir contains secret.
+func requireNotOnDisk(t *testing.T, dir, secret string) {
+	t.Helper()
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("%s holds the plaintext session token", path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk data dir: %v", err)
+	}
+}
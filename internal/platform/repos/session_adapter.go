@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// sessionAdapter adapts store.SessionStore to identity.SessionRepo. Sessions
+// are stored under identity.HashSessionToken, never the bearer token itself.
+// Durable backends keep expiry at one-second resolution.
+type sessionAdapter struct {
+	s store.SessionStore
+}
+
+var _ identity.SessionRepo = (*sessionAdapter)(nil)
+
+func (a *sessionAdapter) Create(ctx context.Context, userID string, ttl time.Duration) (*identity.Session, error) {
+	token, err := identity.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("repos: generate session token: %w", err)
+	}
+
+	now := time.Now()
+	session := &identity.Session{
+		Token:     token,
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	if err := a.s.CreateSession(ctx, appSessionToStore(session, identity.HashSessionToken(token))); err != nil {
+		return nil, fmt.Errorf("repos: create session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (a *sessionAdapter) Get(ctx context.Context, token string) (*identity.Session, error) {
+	s, err := a.s.GetSession(ctx, identity.HashSessionToken(token))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, identity.ErrSessionNotFound
+		}
+
+		return nil, fmt.Errorf("repos: get session: %w", err)
+	}
+
+	session := storeSessionToApp(s, token)
+	if session.IsExpired() {
+		return nil, identity.ErrSessionExpired
+	}
+
+	return session, nil
+}
+
+// Delete removes a session; an unknown token is not an error (logout is
+// idempotent).
+func (a *sessionAdapter) Delete(ctx context.Context, token string) error {
+	if err := a.s.DeleteSession(ctx, identity.HashSessionToken(token)); err != nil && !errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("repos: delete session: %w", err)
+	}
+
+	return nil
+}
+
+func (a *sessionAdapter) DeleteByUser(ctx context.Context, userID string) error {
+	if err := a.s.DeleteSessionsByUser(ctx, userID); err != nil {
+		return fmt.Errorf("repos: delete user sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (a *sessionAdapter) DeleteExpired(ctx context.Context) (int, error) {
+	n, err := a.s.DeleteExpiredSessions(ctx, time.Now().Unix())
+	if err != nil {
+		return 0, fmt.Errorf("repos: delete expired sessions: %w", err)
+	}
+
+	return n, nil
+}
+
+// storeSessionToApp converts a store model to the app-layer model; token is
+// the bearer token the stored hash was looked up with.
+func storeSessionToApp(s *store.Session, token string) *identity.Session {
+	return &identity.Session{
+		Token:     token,
+		UserID:    s.UserID,
+		CreatedAt: unixToTime(s.CreatedAt),
+		ExpiresAt: unixToTime(s.ExpiresAt),
+	}
+}
+
+// appSessionToStore converts an app-layer model to the store model, keyed by
+// tokenHash.
+func appSessionToStore(s *identity.Session, tokenHash string) *store.Session {
+	return &store.Session{
+		Token:     tokenHash,
+		UserID:    s.UserID,
+		CreatedAt: timeToUnix(s.CreatedAt),
+		ExpiresAt: timeToUnix(s.ExpiresAt),
+	}
+}
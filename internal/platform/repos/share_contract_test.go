@@ -8,6 +8,7 @@ package repos_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -28,6 +29,7 @@ func runIncomingShareRepoContract(t *testing.T, r *repos.Repos) {
 	t.Run("ListByRecipientUserID", func(t *testing.T) { runIncomingShareRepoContractListByRecipientUserID(t, ctx, r) })
 	t.Run("EnforcesRecipientScope", func(t *testing.T) { runIncomingShareRepoContractEnforcesRecipientScope(t, ctx, r) })
 	t.Run("EnforcesProviderIDScope", func(t *testing.T) { runIncomingShareRepoContractEnforcesProviderIDScope(t, ctx, r) })
+	t.Run("ListByProviderIDFanOut", func(t *testing.T) { runIncomingShareRepoContractListByProviderIDFanOut(t, ctx, r) })
 	t.Run("AutoFill", func(t *testing.T) { runIncomingShareRepoContractAutoFill(t, ctx, r) })
 	t.Run("ErrShareNotFoundSentinel", func(t *testing.T) { runIncomingShareRepoContractErrShareNotFoundSentinel(t, ctx, r) })
 }
@@ -36,17 +38,23 @@ func runIncomingShareRepoContractCRUD(t *testing.T, ctx context.Context, r *repo
 	t.Helper()
 
 	share := &sharesincoming.IncomingShare{
-		ShareID:         "ct-in-s1",
-		ProviderID:      "ct-in-p1",
-		SenderHost:      "ct.sender.example",
-		ShareWith:       "bob",
-		Name:            "ct-inshare",
-		ResourceType:    "file",
-		Permissions:     []string{"read"},
-		Status:          shares.ShareStatusPending,
-		RecipientUserID: "ct-user-1",
-		CreatedAt:       time.Unix(time.Now().Unix(), 0).UTC(),
-		UpdatedAt:       time.Unix(time.Now().Unix(), 0).UTC(),
+		ShareID:           "ct-in-s1",
+		ProviderID:        "ct-in-p1",
+		SenderHost:        "ct.sender.example",
+		ShareWith:         "bob",
+		Name:              "ct-inshare",
+		ResourceType:      "file",
+		Permissions:       []string{"read"},
+		Status:            shares.ShareStatusPending,
+		RecipientUserID:   "ct-user-1",
+		WebDAVAccessTypes: []string{"datatx"},
+		WebappURI:         "https://viewer.ct.sender.example/open/1",
+		WebappTargets:     []string{"blank"},
+		WebappSecret:      "ct-webapp-secret",
+		SSHURI:            "ct-w1@sftp.ct.sender.example:2022/ct-inshare",
+		SSHAccessTypes:    []string{"remote", "datatx"},
+		CreatedAt:         time.Unix(time.Now().Unix(), 0).UTC(),
+		UpdatedAt:         time.Unix(time.Now().Unix(), 0).UTC(),
 	}
 	if err := r.IncomingShares.Create(ctx, share); err != nil {
 		t.Fatalf("Create: %v", err)
@@ -61,6 +69,18 @@ func runIncomingShareRepoContractCRUD(t *testing.T, ctx context.Context, r *repo
 		t.Errorf("ShareID: got %q, want %q", got.ShareID, share.ShareID)
 	}
 
+	if got.WebappURI != share.WebappURI || got.WebappSecret != share.WebappSecret || !slices.Equal(got.WebappTargets, share.WebappTargets) {
+		t.Errorf("webapp arm: got %q/%q/%v, want round trip", got.WebappURI, got.WebappSecret, got.WebappTargets)
+	}
+
+	if got.SSHURI != share.SSHURI || !slices.Equal(got.SSHAccessTypes, share.SSHAccessTypes) {
+		t.Errorf("ssh arm: got %q/%v, want round trip", got.SSHURI, got.SSHAccessTypes)
+	}
+
+	if !slices.Equal(got.WebDAVAccessTypes, share.WebDAVAccessTypes) {
+		t.Errorf("webdav access types: got %v, want %v", got.WebDAVAccessTypes, share.WebDAVAccessTypes)
+	}
+
 	got, err = r.IncomingShares.GetByProviderID(ctx, share.SenderHost, share.ProviderID)
 	if err != nil {
 		t.Fatalf("GetByProviderID: %v", err)
@@ -241,6 +261,58 @@ func runIncomingShareRepoContractEnforcesProviderIDScope(t *testing.T, ctx conte
 	}
 }
 
+func runIncomingShareRepoContractListByProviderIDFanOut(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	base := time.Unix(time.Now().Unix(), 0).UTC()
+
+	for i, recipient := range []string{"ct-fan-u1", "ct-fan-u2"} {
+		share := &sharesincoming.IncomingShare{
+			ProviderID:      "ct-fan-p1",
+			SenderHost:      "ct.fan.sender.example",
+			ShareWith:       "ct-fan-group",
+			Name:            "ct-fan-share",
+			ResourceType:    "file",
+			ShareType:       "group",
+			Permissions:     []string{"read"},
+			Status:          shares.ShareStatusPending,
+			RecipientUserID: recipient,
+			CreatedAt:       base.Add(time.Duration(i) * time.Second),
+			UpdatedAt:       base.Add(time.Duration(i) * time.Second),
+		}
+		if err := r.IncomingShares.Create(ctx, share); err != nil {
+			t.Fatalf("Create for %s: %v", recipient, err)
+		}
+	}
+
+	copies, err := r.IncomingShares.ListByProviderID(ctx, "ct.fan.sender.example", "ct-fan-p1")
+	if err != nil {
+		t.Fatalf("ListByProviderID: %v", err)
+	}
+
+	if len(copies) != 2 || copies[0].RecipientUserID != "ct-fan-u1" || copies[1].RecipientUserID != "ct-fan-u2" {
+		t.Fatalf("ListByProviderID = %d copies, want ct-fan-u1 then ct-fan-u2", len(copies))
+	}
+
+	oldest, err := r.IncomingShares.GetByProviderID(ctx, "ct.fan.sender.example", "ct-fan-p1")
+	if err != nil {
+		t.Fatalf("GetByProviderID: %v", err)
+	}
+
+	if oldest.RecipientUserID != "ct-fan-u1" {
+		t.Errorf("GetByProviderID recipient = %q, want oldest copy ct-fan-u1", oldest.RecipientUserID)
+	}
+
+	none, err := r.IncomingShares.ListByProviderID(ctx, "ct.fan.sender.example", "ct-fan-missing")
+	if err != nil {
+		t.Fatalf("ListByProviderID missing: %v", err)
+	}
+
+	if len(none) != 0 {
+		t.Errorf("ListByProviderID missing = %d copies, want 0", len(none))
+	}
+}
+
 func runIncomingShareRepoContractAutoFill(t *testing.T, ctx context.Context, r *repos.Repos) {
 	t.Helper()
 
@@ -295,16 +367,20 @@ func runOutgoingShareRepoContractCRUD(t *testing.T, ctx context.Context, r *repo
 	t.Helper()
 
 	share := &sharesoutgoing.OutgoingShare{
-		ShareID:      "ct-out-s1",
-		ProviderID:   "ct-out-p1",
-		WebDAVID:     "ct-out-w1",
-		SharedSecret: "ct-secret-1",
-		ShareWith:    "alice@peer",
-		Name:         "ct-outshare",
-		ResourceType: "file",
-		Permissions:  []string{"read"},
-		Status:       shares.OutgoingShareStatusSent,
-		CreatedAt:    time.Unix(time.Now().Unix(), 0).UTC(),
+		ShareID:           "ct-out-s1",
+		ProviderID:        "ct-out-p1",
+		WebDAVID:          "ct-out-w1",
+		SharedSecret:      "ct-secret-1",
+		ShareWith:         "alice@peer",
+		Name:              "ct-outshare",
+		ResourceType:      "file",
+		Permissions:       []string{"read"},
+		Status:            shares.OutgoingShareStatusSent,
+		CreatedAt:         time.Unix(time.Now().Unix(), 0).UTC(),
+		WebappURI:         "https://viewer.example/open/ct-out-w1",
+		WebappTargets:     []string{"blank", "iframe"},
+		WebappPermissions: []string{"view", "write"},
+		SSHURI:            "ct-out-w1@sftp.example:2022/ct-outshare",
 	}
 	if err := r.OutgoingShares.Create(ctx, share); err != nil {
 		t.Fatalf("Create: %v", err)
@@ -319,6 +395,21 @@ func runOutgoingShareRepoContractCRUD(t *testing.T, ctx context.Context, r *repo
 		t.Errorf("GetByID ShareID: got %q, want %q", got.ShareID, share.ShareID)
 	}
 
+	if got.WebappURI != share.WebappURI || !slices.Equal(got.WebappPermissions, share.WebappPermissions) ||
+		!slices.Equal(got.WebappTargets, share.WebappTargets) {
+		t.Errorf("webapp arm: got %q/%v/%v, want round trip", got.WebappURI, got.WebappPermissions, got.WebappTargets)
+	}
+
+	got.RecipientPublicKeys = []string{"ssh-ed25519 AAAAct"}
+	if err := r.OutgoingShares.Update(ctx, got); err != nil {
+		t.Fatalf("Update recipient keys: %v", err)
+	}
+
+	if got, err = r.OutgoingShares.GetByID(ctx, share.ShareID); err != nil ||
+		got.SSHURI != share.SSHURI || !slices.Equal(got.RecipientPublicKeys, []string{"ssh-ed25519 AAAAct"}) {
+		t.Errorf("ssh arm: got %+v, %v; want uri and recipient keys round trip", got, err)
+	}
+
 	got, err = r.OutgoingShares.GetByProviderID(ctx, share.ProviderID)
 	if err != nil {
 		t.Fatalf("GetByProviderID: %v", err)
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// shareRequestAdapter adapts store.ShareRequestStore to sharerequests.Repo.
+type shareRequestAdapter struct {
+	s store.ShareRequestStore
+}
+
+var _ sharerequests.Repo = (*shareRequestAdapter)(nil)
+
+func (a *shareRequestAdapter) Create(ctx context.Context, req *sharerequests.ShareRequest) error {
+	if req.ID == "" {
+		id, err := uuid.NewV7()
+		if err != nil {
+			req.ID = uuid.New().String()
+		} else {
+			req.ID = id.String()
+		}
+	}
+
+	now := time.Now()
+	if req.CreatedAt.IsZero() {
+		req.CreatedAt = now
+	}
+
+	if req.UpdatedAt.IsZero() {
+		req.UpdatedAt = now
+	}
+
+	if err := a.s.CreateShareRequest(ctx, appShareRequestToStore(req)); err != nil {
+		return fmt.Errorf("repos: create share request: %w", err)
+	}
+
+	return nil
+}
+
+func (a *shareRequestAdapter) GetByIDForOwnerUserID(
+	ctx context.Context,
+	id string,
+	ownerUserID string,
+) (*sharerequests.ShareRequest, error) {
+	s, err := a.s.GetShareRequestForOwner(ctx, id, ownerUserID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, sharerequests.ErrRequestNotFound
+		}
+
+		return nil, fmt.Errorf("repos: get share request: %w", err)
+	}
+
+	return storeShareRequestToApp(s), nil
+}
+
+func (a *shareRequestAdapter) ListByOwnerUserID(
+	ctx context.Context,
+	ownerUserID string,
+) ([]*sharerequests.ShareRequest, error) {
+	storeReqs, err := a.s.ListShareRequests(ctx, ownerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("repos: list share requests: %w", err)
+	}
+
+	result := make([]*sharerequests.ShareRequest, 0, len(storeReqs))
+	for _, s := range storeReqs {
+		result = append(result, storeShareRequestToApp(s))
+	}
+
+	// Newest first; ids are UUIDv7, so they break same-second ties in
+	// creation order.
+	slices.SortFunc(result, func(x, y *sharerequests.ShareRequest) int {
+		if c := y.CreatedAt.Compare(x.CreatedAt); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(y.ID, x.ID)
+	})
+
+	return result, nil
+}
+
+func (a *shareRequestAdapter) UpdateStatusForOwnerUserID(
+	ctx context.Context,
+	id string,
+	ownerUserID string,
+	status sharerequests.Status,
+	approvedShareID string,
+) error {
+	if err := a.s.UpdateShareRequestStatusForOwner(ctx, id, ownerUserID, string(status), approvedShareID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return sharerequests.ErrRequestNotFound
+		}
+
+		return fmt.Errorf("repos: update share request: %w", err)
+	}
+
+	return nil
+}
+
+func appShareRequestToStore(a *sharerequests.ShareRequest) *store.ShareRequest {
+	return &store.ShareRequest{
+		ID:              a.ID,
+		OwnerUserID:     a.OwnerUserID,
+		ProviderID:      a.ProviderID,
+		ShareID:         a.ShareID,
+		Name:            a.Name,
+		ResourceType:    a.ResourceType,
+		ShareWith:       a.ShareWith,
+		RequesterHost:   a.RequesterHost,
+		Status:          string(a.Status),
+		ApprovedShareID: a.ApprovedShareID,
+		CreatedAt:       timeToUnix(a.CreatedAt),
+		UpdatedAt:       timeToUnix(a.UpdatedAt),
+	}
+}
+
+func storeShareRequestToApp(s *store.ShareRequest) *sharerequests.ShareRequest {
+	return &sharerequests.ShareRequest{
+		ID:              s.ID,
+		OwnerUserID:     s.OwnerUserID,
+		ProviderID:      s.ProviderID,
+		ShareID:         s.ShareID,
+		Name:            s.Name,
+		ResourceType:    s.ResourceType,
+		ShareWith:       s.ShareWith,
+		RequesterHost:   s.RequesterHost,
+		Status:          sharerequests.Status(s.Status),
+		ApprovedShareID: s.ApprovedShareID,
+		CreatedAt:       unixToTime(s.CreatedAt),
+		UpdatedAt:       unixToTime(s.UpdatedAt),
+	}
+}
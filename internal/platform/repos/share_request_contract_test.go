@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+)
+
+// runShareRequestRepoContract verifies create auto-fill, owner scoping,
+// newest-first listing, and status updates for sharerequests.Repo.
+func runShareRequestRepoContract(t *testing.T, r *repos.Repos) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	t.Run("CreateGetUpdate", func(t *testing.T) { runShareRequestRepoContractCreateGetUpdate(t, ctx, r) })
+	t.Run("OwnerScopedNewestFirst", func(t *testing.T) { runShareRequestRepoContractOwnerScoped(t, ctx, r) })
+}
+
+func newContractShareRequest(owner, providerID string, createdAt time.Time) *sharerequests.ShareRequest {
+	return &sharerequests.ShareRequest{
+		OwnerUserID:   owner,
+		ProviderID:    providerID,
+		ShareID:       "share-" + providerID,
+		Name:          "notes.txt",
+		ResourceType:  "file",
+		ShareWith:     "bob@ct.requester.example",
+		RequesterHost: "ct.requester.example",
+		Status:        sharerequests.StatusPending,
+		CreatedAt:     createdAt,
+	}
+}
+
+func runShareRequestRepoContractCreateGetUpdate(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	req := newContractShareRequest("ct-sr-owner-1", "ct-sr-provider-1", time.Time{})
+	if err := r.ShareRequests.Create(ctx, req); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if req.ID == "" || req.CreatedAt.IsZero() || req.UpdatedAt.IsZero() {
+		t.Fatalf("Create did not fill id/timestamps: %+v", req)
+	}
+
+	got, err := r.ShareRequests.GetByIDForOwnerUserID(ctx, req.ID, req.OwnerUserID)
+	if err != nil {
+		t.Fatalf("GetByIDForOwnerUserID: %v", err)
+	}
+
+	if got.ProviderID != req.ProviderID || got.ShareWith != req.ShareWith || got.Status != sharerequests.StatusPending {
+		t.Errorf("got %+v, want %+v", got, req)
+	}
+
+	if err := r.ShareRequests.UpdateStatusForOwnerUserID(ctx, req.ID, req.OwnerUserID, sharerequests.StatusApproved, "ct-new-share"); err != nil {
+		t.Fatalf("UpdateStatusForOwnerUserID: %v", err)
+	}
+
+	got, err = r.ShareRequests.GetByIDForOwnerUserID(ctx, req.ID, req.OwnerUserID)
+	if err != nil {
+		t.Fatalf("GetByIDForOwnerUserID after update: %v", err)
+	}
+
+	if got.Status != sharerequests.StatusApproved || got.ApprovedShareID != "ct-new-share" {
+		t.Errorf("after update status=%q approvedShareID=%q", got.Status, got.ApprovedShareID)
+	}
+
+	if _, err := r.ShareRequests.GetByIDForOwnerUserID(ctx, req.ID, "ct-sr-other"); !errors.Is(err, sharerequests.ErrRequestNotFound) {
+		t.Errorf("cross-owner get error = %v, want ErrRequestNotFound", err)
+	}
+
+	err = r.ShareRequests.UpdateStatusForOwnerUserID(ctx, req.ID, "ct-sr-other", sharerequests.StatusDeclined, "")
+	if !errors.Is(err, sharerequests.ErrRequestNotFound) {
+		t.Errorf("cross-owner update error = %v, want ErrRequestNotFound", err)
+	}
+}
+
+func runShareRequestRepoContractOwnerScoped(t *testing.T, ctx context.Context, r *repos.Repos) {
+	t.Helper()
+
+	base := time.Unix(time.Now().Unix(), 0).UTC()
+	older := newContractShareRequest("ct-sr-owner-2", "ct-sr-older", base.Add(-time.Hour))
+	newer := newContractShareRequest("ct-sr-owner-2", "ct-sr-newer", base)
+	other := newContractShareRequest("ct-sr-owner-3", "ct-sr-other", base)
+
+	for _, req := range []*sharerequests.ShareRequest{older, newer, other} {
+		if err := r.ShareRequests.Create(ctx, req); err != nil {
+			t.Fatalf("Create(%s): %v", req.ProviderID, err)
+		}
+	}
+
+	list, err := r.ShareRequests.ListByOwnerUserID(ctx, "ct-sr-owner-2")
+	if err != nil {
+		t.Fatalf("ListByOwnerUserID: %v", err)
+	}
+
+	if len(list) != 2 {
+		t.Fatalf("ListByOwnerUserID returned %d requests, want 2", len(list))
+	}
+
+	if list[0].ProviderID != "ct-sr-newer" || list[1].ProviderID != "ct-sr-older" {
+		t.Errorf("order = [%s %s], want newest first", list[0].ProviderID, list[1].ProviderID)
+	}
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// transferAdapter adapts store.TransferStore to datatx.Repo.
+type transferAdapter struct {
+	s store.TransferStore
+}
+
+var _ datatx.Repo = (*transferAdapter)(nil)
+
+func (a *transferAdapter) Create(ctx context.Context, t *datatx.Transfer) error {
+	if err := a.s.CreateTransfer(ctx, appTransferToStore(t)); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return datatx.ErrTransferExists
+		}
+
+		return fmt.Errorf("repos: create transfer: %w", err)
+	}
+
+	return nil
+}
+
+func (a *transferAdapter) Get(ctx context.Context, shareID string) (*datatx.Transfer, error) {
+	s, err := a.s.GetTransfer(ctx, shareID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, datatx.ErrTransferNotFound
+		}
+
+		return nil, fmt.Errorf("repos: get transfer: %w", err)
+	}
+
+	return storeTransferToApp(s), nil
+}
+
+func (a *transferAdapter) Update(ctx context.Context, t *datatx.Transfer) error {
+	if err := a.s.UpdateTransfer(ctx, appTransferToStore(t)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return datatx.ErrTransferNotFound
+		}
+
+		return fmt.Errorf("repos: update transfer: %w", err)
+	}
+
+	return nil
+}
+
+func (a *transferAdapter) List(ctx context.Context) ([]*datatx.Transfer, error) {
+	storeTransfers, err := a.s.ListTransfers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repos: list transfers: %w", err)
+	}
+
+	result := make([]*datatx.Transfer, 0, len(storeTransfers))
+	for _, s := range storeTransfers {
+		result = append(result, storeTransferToApp(s))
+	}
+
+	return result, nil
+}
+
+func appTransferToStore(a *datatx.Transfer) *store.Transfer {
+	return &store.Transfer{
+		ShareID:         a.ShareID,
+		RecipientUserID: a.RecipientUserID,
+		Status:          string(a.Status),
+		Destination:     a.Destination,
+		BytesTotal:      a.BytesTotal,
+		BytesDone:       a.BytesDone,
+		FilesTotal:      a.FilesTotal,
+		FilesDone:       a.FilesDone,
+		Attempts:        a.Attempts,
+		LastError:       a.LastError,
+		CreatedAt:       timeToUnix(a.CreatedAt),
+		UpdatedAt:       timeToUnix(a.UpdatedAt),
+		CompletedAt:     timePtrToUnix(a.CompletedAt),
+	}
+}
+
+func storeTransferToApp(s *store.Transfer) *datatx.Transfer {
+	return &datatx.Transfer{
+		ShareID:         s.ShareID,
+		RecipientUserID: s.RecipientUserID,
+		Status:          datatx.Status(s.Status),
+		Destination:     s.Destination,
+		BytesTotal:      s.BytesTotal,
+		BytesDone:       s.BytesDone,
+		FilesTotal:      s.FilesTotal,
+		FilesDone:       s.FilesDone,
+		Attempts:        s.Attempts,
+		LastError:       s.LastError,
+		CreatedAt:       unixToTime(s.CreatedAt),
+		UpdatedAt:       unixToTime(s.UpdatedAt),
+		CompletedAt:     unixToTimePtr(s.CompletedAt),
+	}
+}
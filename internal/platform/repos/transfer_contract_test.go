@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+)
+
+// runTransferRepoContract verifies round-tripping, duplicate rejection, and
+// not-found sentinels for datatx.Repo.
+func runTransferRepoContract(t *testing.T, r *repos.Repos) {
+	t.Helper()
+
+	ctx := context.Background()
+	created := time.Unix(1_700_000_000, 0).UTC()
+
+	transfer := &datatx.Transfer{
+		ShareID:         "ct-transfer-1",
+		RecipientUserID: "ct-user",
+		Status:          datatx.StatusQueued,
+		CreatedAt:       created,
+		UpdatedAt:       created,
+	}
+	if err := r.Transfers.Create(ctx, transfer); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := r.Transfers.Create(ctx, transfer); !errors.Is(err, datatx.ErrTransferExists) {
+		t.Errorf("duplicate Create = %v, want ErrTransferExists", err)
+	}
+
+	completed := created.Add(time.Minute)
+	transfer.Status = datatx.StatusCompleted
+	transfer.Destination = "/data/ct-user/report.pdf"
+	transfer.BytesTotal, transfer.BytesDone = 2048, 2048
+	transfer.FilesTotal, transfer.FilesDone = 1, 1
+	transfer.Attempts = 2
+	transfer.LastError = ""
+	transfer.UpdatedAt = completed
+	transfer.CompletedAt = &completed
+
+	if err := r.Transfers.Update(ctx, transfer); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := r.Transfers.Get(ctx, transfer.ShareID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if got.RecipientUserID != "ct-user" || got.Status != datatx.StatusCompleted ||
+		got.Destination != transfer.Destination || got.BytesDone != 2048 || got.FilesDone != 1 ||
+		got.Attempts != 2 || got.CompletedAt == nil || !got.CompletedAt.Equal(completed) ||
+		!got.CreatedAt.Equal(created) {
+		t.Errorf("Get = %+v, want %+v", got, transfer)
+	}
+
+	list, err := r.Transfers.List(ctx)
+	if err != nil || len(list) != 1 || list[0].ShareID != transfer.ShareID {
+		t.Errorf("List = %v, %v; want the single transfer", list, err)
+	}
+
+	if _, err := r.Transfers.Get(ctx, "ct-transfer-missing"); !errors.Is(err, datatx.ErrTransferNotFound) {
+		t.Errorf("Get missing = %v, want ErrTransferNotFound", err)
+	}
+
+	if err := r.Transfers.Update(ctx, &datatx.Transfer{ShareID: "ct-transfer-missing"}); !errors.Is(err, datatx.ErrTransferNotFound) {
+		t.Errorf("Update missing = %v, want ErrTransferNotFound", err)
+	}
+}
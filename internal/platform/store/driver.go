@@ -32,30 +32,43 @@ type Driver interface {
 }
 
 // OutgoingShareStore manages outgoing share persistence (sender-side).
-// Lookup is available by local share id, provider id, webdav id, and shared secret.
+// Lookup is available by local share id, provider id, webdav id, shared
+// secret, and webapp shared secret.
+// ListExpiringOutgoingShares returns the rows in one of statuses whose expiry
+// is set and not after now.
 type OutgoingShareStore interface {
 	CreateOutgoingShare(ctx context.Context, share *OutgoingShare) error
 	GetOutgoingShareByID(ctx context.Context, shareID string) (*OutgoingShare, error)
 	GetOutgoingShare(ctx context.Context, providerID string) (*OutgoingShare, error)
 	GetOutgoingShareByWebDAVID(ctx context.Context, webdavID string) (*OutgoingShare, error)
 	GetOutgoingShareBySharedSecret(ctx context.Context, sharedSecret string) (*OutgoingShare, error)
+	GetOutgoingShareByWebappSharedSecret(ctx context.Context, webappSharedSecret string) (*OutgoingShare, error)
 	UpdateOutgoingShare(ctx context.Context, share *OutgoingShare) error
 	DeleteOutgoingShare(ctx context.Context, providerID string) error
 	ListOutgoingShares(ctx context.Context) ([]*OutgoingShare, error)
+	ListExpiringOutgoingShares(ctx context.Context, now int64, statuses []string) ([]*OutgoingShare, error)
 }
 
 // IncomingShareStore manages incoming share persistence (receiver-side).
 // All mutating operations are scoped by recipientUserID to prevent cross-user access.
+// A provider key holds one row per recipient (group shares fan out):
+// GetIncomingShareByProviderKey returns the oldest row, ListIncomingSharesByProviderKey
+// returns all of them. ListExpiringIncomingShares spans every recipient and
+// returns the rows in one of statuses whose expiration is set and not after now.
 type IncomingShareStore interface {
 	CreateIncomingShare(ctx context.Context, share *IncomingShare) error
 	GetIncomingShareByIDForRecipient(ctx context.Context, shareID string, recipientUserID string) (*IncomingShare, error)
 	GetIncomingShareByProviderKey(ctx context.Context, senderHost, providerID string) (*IncomingShare, error)
+	ListIncomingSharesByProviderKey(ctx context.Context, senderHost, providerID string) ([]*IncomingShare, error)
 	ListIncomingSharesByRecipient(ctx context.Context, recipientUserID string) ([]*IncomingShare, error)
+	ListExpiringIncomingShares(ctx context.Context, now int64, statuses []string) ([]*IncomingShare, error)
 	UpdateIncomingShareStatusForRecipient(ctx context.Context, shareID string, recipientUserID string, status string) error
 	DeleteIncomingShareForRecipient(ctx context.Context, shareID string, recipientUserID string) error
 }
 
 // OutgoingInviteStore manages outgoing invite persistence (initiator-side).
+// ListExpiringOutgoingInvites spans every user and returns the rows in one of
+// statuses whose expiry is set and not after now.
 type OutgoingInviteStore interface {
 	CreateOutgoingInvite(ctx context.Context, invite *OutgoingInvite) error
 	GetOutgoingInvite(ctx context.Context, id string) (*OutgoingInvite, error)
@@ -63,6 +76,7 @@ type OutgoingInviteStore interface {
 	UpdateOutgoingInvite(ctx context.Context, invite *OutgoingInvite) error
 	DeleteOutgoingInvite(ctx context.Context, id string) error
 	ListOutgoingInvites(ctx context.Context, userID string) ([]*OutgoingInvite, error)
+	ListExpiringOutgoingInvites(ctx context.Context, now int64, statuses []string) ([]*OutgoingInvite, error)
 }
 
 // IncomingInviteStore manages incoming invite persistence (acceptor-side).
@@ -78,6 +92,86 @@ type IncomingInviteStore interface {
 	ListIncomingInvites(ctx context.Context, recipientUserID string) ([]*IncomingInvite, error)
 }
 
+// OutboxStore manages the durable outbound delivery queue. ListDue returns
+// pending messages whose next attempt is due at or before now, oldest due
+// first, capped at limit (limit <= 0 means no cap).
+type OutboxStore interface {
+	CreateOutboxMessage(ctx context.Context, msg *OutboxMessage) error
+	GetOutboxMessage(ctx context.Context, id string) (*OutboxMessage, error)
+	UpdateOutboxMessage(ctx context.Context, msg *OutboxMessage) error
+	DeleteOutboxMessage(ctx context.Context, id string) error
+	ListDueOutboxMessages(ctx context.Context, now int64, limit int) ([]*OutboxMessage, error)
+	ListOutboxMessages(ctx context.Context) ([]*OutboxMessage, error)
+}
+
+// PartyStore manages local user (party) persistence. Username and non-empty
+// EmailNormalized values are unique; GetPartyByEmail matches on the normalized
+// key. ListParties returns every party when realm is empty.
+// DeleteExpiredParties removes parties whose ExpiresAt is set and before now.
+type PartyStore interface {
+	CreateParty(ctx context.Context, party *Party) error
+	GetParty(ctx context.Context, id string) (*Party, error)
+	GetPartyByUsername(ctx context.Context, username string) (*Party, error)
+	GetPartyByEmail(ctx context.Context, emailNormalized string) (*Party, error)
+	UpdateParty(ctx context.Context, party *Party) error
+	DeleteParty(ctx context.Context, id string) error
+	ListParties(ctx context.Context, realm string) ([]*Party, error)
+	DeleteExpiredParties(ctx context.Context, now int64) (int, error)
+}
+
+// SessionStore manages login session persistence keyed by Session.Token, the
+// hash of the session token.
+// DeleteExpiredSessions removes sessions whose ExpiresAt is before now.
+type SessionStore interface {
+	CreateSession(ctx context.Context, session *Session) error
+	GetSession(ctx context.Context, token string) (*Session, error)
+	DeleteSession(ctx context.Context, token string) error
+	DeleteSessionsByUser(ctx context.Context, userID string) error
+	DeleteExpiredSessions(ctx context.Context, now int64) (int, error)
+	ListSessions(ctx context.Context) ([]*Session, error)
+}
+
+// ShareRequestStore manages received share request persistence (owner-side).
+// Reads and status updates are scoped by ownerUserID to prevent cross-user
+// access. ListShareRequests returns every request when ownerUserID is empty.
+type ShareRequestStore interface {
+	CreateShareRequest(ctx context.Context, req *ShareRequest) error
+	GetShareRequestForOwner(ctx context.Context, id string, ownerUserID string) (*ShareRequest, error)
+	UpdateShareRequestStatusForOwner(ctx context.Context, id string, ownerUserID string, status string, approvedShareID string) error
+	ListShareRequests(ctx context.Context, ownerUserID string) ([]*ShareRequest, error)
+}
+
+// GroupStore manages local group persistence. Group names are unique.
+type GroupStore interface {
+	CreateGroup(ctx context.Context, group *Group) error
+	GetGroup(ctx context.Context, id string) (*Group, error)
+	GetGroupByName(ctx context.Context, name string) (*Group, error)
+	UpdateGroup(ctx context.Context, group *Group) error
+	DeleteGroup(ctx context.Context, id string) error
+	ListGroups(ctx context.Context) ([]*Group, error)
+}
+
+// TransferStore manages datatx transfer persistence (receiver-side), keyed by
+// the incoming share id. ListTransfers returns every transfer.
+type TransferStore interface {
+	CreateTransfer(ctx context.Context, transfer *Transfer) error
+	GetTransfer(ctx context.Context, shareID string) (*Transfer, error)
+	UpdateTransfer(ctx context.Context, transfer *Transfer) error
+	ListTransfers(ctx context.Context) ([]*Transfer, error)
+}
+
+// PeerTrustStore manages the peer trust entries added through the admin API:
+// allow/deny list entries keyed by list and host, and attached trust groups
+// keyed by trust group id. Entries from the config file are not stored here.
+type PeerTrustStore interface {
+	CreatePeerPolicyEntry(ctx context.Context, entry *PeerPolicyEntry) error
+	DeletePeerPolicyEntry(ctx context.Context, list, host string) error
+	ListPeerPolicyEntries(ctx context.Context) ([]*PeerPolicyEntry, error)
+	CreatePeerTrustGroup(ctx context.Context, group *PeerTrustGroup) error
+	DeletePeerTrustGroup(ctx context.Context, trustGroupID string) error
+	ListPeerTrustGroups(ctx context.Context) ([]*PeerTrustGroup, error)
+}
+
 // OutgoingShare represents a share created by this instance (sender-side).
 type OutgoingShare struct {
 	ShareID    string `gorm:"uniqueIndex" json:"shareId"`    // sender-local identity (UUIDv7)
@@ -99,20 +193,34 @@ type OutgoingShare struct {
 	Status           string   `gorm:"column:status"                                                    json:"status"` // sent, accepted, declined
 	Error            string   `json:"error,omitempty"`
 	Requirements     []string `gorm:"serializer:json"                                                  json:"requirements,omitempty"`
-	CreatedAt        int64    `json:"createdAt"`
-	UpdatedAt        int64    `json:"updatedAt"`
+	// Webapp arm columns, empty when the share was sent without one.
+	// WebappPermissions is comma-joined like Permissions.
+	WebappURI         string   `json:"webappUri,omitempty"`
+	WebappPermissions string   `json:"webappPermissions,omitempty"`
+	WebappTargets     []string `gorm:"serializer:json"                                                  json:"webappTargets,omitempty"`
+	CreatedAt         int64    `json:"createdAt"`
+	UpdatedAt         int64    `json:"updatedAt"`
+	// SSH arm columns, empty when the share was sent without one.
+	// RecipientPublicKeys come from the receiver's 201 response.
+	SSHURI              string   `json:"sshUri,omitempty"`
+	RecipientPublicKeys []string `gorm:"serializer:json"                                                  json:"recipientPublicKeys,omitempty"`
+	// ExpiresAt is a Unix epoch; 0 means the share does not expire.
+	ExpiresAt int64 `json:"expiresAt,omitempty"`
+	// WebappSharedSecret is the webapp arm's own secret, indexed for the
+	// token exchange lookup; omitempty for redaction.
+	WebappSharedSecret string `gorm:"index:idx_outgoing_shares_webapp_secret" json:"webappSharedSecret,omitempty"`
 }
 
 // IncomingShare represents a share received by this instance (receiver-side).
-// The composite unique index on (senderHost, providerID) enforces that one
-// provider-key pair can be stored at most once, matching GetIncomingShareByProviderKey
-// singular semantics.
+// The composite unique index on (senderHost, providerID, recipientUserID)
+// enforces that one provider-key pair is stored at most once per recipient; a
+// group share fans out into one row per member under the same provider key.
 type IncomingShare struct {
-	ShareID           string `gorm:"primaryKey"                                                      json:"shareId"`    // receiver-local id (UUIDv7)
-	SenderHost        string `gorm:"column:sender_host;uniqueIndex:idx_incoming_shares_provider_key" json:"senderHost"` // sender's host
-	ProviderID        string `gorm:"uniqueIndex:idx_incoming_shares_provider_key"                    json:"providerId"` // sender's share id
-	WebDAVID          string `json:"webdavId,omitempty"`                                                                // relative or absolute webdav URI
-	SharedSecret      string `json:"sharedSecret,omitempty"`                                                            // omitempty for redaction
+	ShareID           string `gorm:"primaryKey"                                                            json:"shareId"`    // receiver-local id (UUIDv7)
+	SenderHost        string `gorm:"column:sender_host;uniqueIndex:idx_incoming_shares_provider_recipient" json:"senderHost"` // sender's host
+	ProviderID        string `gorm:"uniqueIndex:idx_incoming_shares_provider_recipient"                    json:"providerId"` // sender's share id
+	WebDAVID          string `json:"webdavId,omitempty"`                                                                      // relative or absolute webdav URI
+	SharedSecret      string `json:"sharedSecret,omitempty"`                                                                  // omitempty for redaction
 	Owner             string `json:"owner"`
 	Sender            string `json:"sender"`
 	ShareWith         string `json:"shareWith"`
@@ -123,17 +231,24 @@ type IncomingShare struct {
 	OwnerDisplayName  string `json:"ownerDisplayName,omitempty"`
 	SenderDisplayName string `json:"senderDisplayName,omitempty"`
 	Permissions       string `json:"permissions"`
+	// WebDAVAccessTypes is the webdav arm accessTypes list; empty means remote.
+	WebDAVAccessTypes []string `gorm:"serializer:json" json:"webdavAccessTypes,omitempty"`
 	// Webapp arm columns. WebappPermissions mirrors the comma-joined
 	// Permissions pattern; WebappTargets uses the json serializer like
 	// Requirements. Legacy rows leave these empty.
 	WebappPermissions string   `json:"webappPermissions,omitempty"`
 	WebappURI         string   `json:"webappUri,omitempty"`
-	WebappTargets     []string `gorm:"serializer:json"                json:"webappTargets,omitempty"`
-	ProtocolName      string   `json:"protocolName,omitempty"`
-	Status            string   `gorm:"column:status"                  json:"status"` // pending, accepted, declined
-	RecipientUserID   string   `gorm:"column:recipient_user_id;index" json:"recipientUserId"`
-	OwnerHost         string   `json:"ownerHost"`
-	Requirements      []string `gorm:"serializer:json"                json:"requirements,omitempty"`
+	WebappTargets     []string `gorm:"serializer:json"                                                                   json:"webappTargets,omitempty"`
+	// WebappSharedSecret is the webapp arm secret; omitempty for redaction.
+	WebappSharedSecret string   `json:"webappSharedSecret,omitempty"`
+	ProtocolName       string   `json:"protocolName,omitempty"`
+	Status             string   `gorm:"column:status"                                                                     json:"status"` // pending, accepted, declined
+	RecipientUserID    string   `gorm:"column:recipient_user_id;index;uniqueIndex:idx_incoming_shares_provider_recipient" json:"recipientUserId"`
+	OwnerHost          string   `json:"ownerHost"`
+	Requirements       []string `gorm:"serializer:json"                                                                   json:"requirements,omitempty"`
+	// SSH arm columns, empty when the share was sent without one.
+	SSHURI         string   `json:"sshUri,omitempty"`
+	SSHAccessTypes []string `gorm:"serializer:json"                                                                   json:"sshAccessTypes,omitempty"`
 	// Expiration is a Unix epoch; 0 means no expiration.
 	Expiration int64 `json:"expiration,omitempty"`
 	CreatedAt  int64 `json:"createdAt"`
@@ -180,3 +295,121 @@ type IncomingInvite struct {
 	ReceivedAt           int64  `json:"receivedAt"`
 	UpdatedAt            int64  `json:"updatedAt"`
 }
+
+// OutboxMessage is the persistence model for one queued outbound OCM POST.
+// Body holds the encoded request and may carry share secrets, so exports must
+// redact it. Status is pending, delivered, or dead.
+type OutboxMessage struct {
+	ID             string `gorm:"primaryKey"                 json:"id"`
+	Topic          string `json:"topic"`
+	SubjectID      string `json:"subjectId,omitempty"`
+	UserID         string `json:"userId,omitempty"`
+	Kind           string `json:"kind"`
+	TargetHost     string `json:"targetHost"`
+	EndpointPath   string `json:"endpointPath"`
+	Body           []byte `json:"body,omitempty"`
+	Status         string `gorm:"index:idx_outbox_due,priority:1" json:"status"`
+	Attempts       int    `json:"attempts"`
+	MaxAttempts    int    `json:"maxAttempts"`
+	NextAttemptAt  int64  `gorm:"index:idx_outbox_due,priority:2" json:"nextAttemptAt"`
+	LastError      string `json:"lastError,omitempty"`
+	LastStatusCode int    `json:"lastStatusCode,omitempty"`
+	CreatedAt      int64  `json:"createdAt"`
+	UpdatedAt      int64  `json:"updatedAt"`
+	DeliveredAt    int64  `json:"deliveredAt,omitempty"` // unix epoch; 0 = not delivered
+}
+
+// Party is the persistence model for a local user account. PasswordHash is a
+// bcrypt hash and Email keeps the user's spelling; EmailNormalized is the
+// lowercased, trimmed lookup key set by the caller. Exports must redact
+// PasswordHash.
+type Party struct {
+	ID              string `gorm:"primaryKey"                                                 json:"id"`
+	Username        string `gorm:"uniqueIndex"                                                json:"username"`
+	Email           string `json:"email,omitempty"`
+	EmailNormalized string `gorm:"index:idx_party_email,unique,where:email_normalized <> ''" json:"emailNormalized,omitempty"`
+	DisplayName     string `json:"displayName,omitempty"`
+	PasswordHash    string `json:"passwordHash,omitempty"`
+	Role            string `json:"role"`
+	Realm           string `gorm:"index"                                                      json:"realm,omitempty"`
+	StorageRoot     string `json:"storageRoot,omitempty"`
+	CreatedAt       int64  `json:"createdAt"`
+	ExpiresAt       int64  `json:"expiresAt,omitempty"` // unix epoch; 0 = never expires
+	// SSHPublicKeys are normalized authorized_keys lines for SFTP access.
+	SSHPublicKeys []string `gorm:"serializer:json"                                            json:"sshPublicKeys,omitempty"`
+}
+
+// Session is the persistence model for a login session. Token is the
+// identity.HashSessionToken digest of the bearer credential presented in the
+// session cookie, never the credential itself; exports still redact it.
+type Session struct {
+	Token     string `gorm:"primaryKey" json:"token"`
+	UserID    string `gorm:"index"      json:"userId"`
+	CreatedAt int64  `json:"createdAt"`
+	ExpiresAt int64  `gorm:"index"      json:"expiresAt"`
+}
+
+// ShareRequest is the persistence model for a request-share call received
+// from a remote user asking a local owner for access to one of their shares.
+// Status is pending, approved, or declined.
+type ShareRequest struct {
+	ID              string `gorm:"primaryKey" json:"id"`
+	OwnerUserID     string `gorm:"index"      json:"ownerUserId"`
+	ProviderID      string `json:"providerId"`
+	ShareID         string `json:"shareId"`
+	Name            string `json:"name"`
+	ResourceType    string `json:"resourceType"`
+	ShareWith       string `json:"shareWith"`
+	RequesterHost   string `json:"requesterHost"`
+	Status          string `json:"status"`
+	ApprovedShareID string `json:"approvedShareId,omitempty"`
+	CreatedAt       int64  `json:"createdAt"`
+	UpdatedAt       int64  `json:"updatedAt"`
+}
+
+// Group is the persistence model for a local, admin-managed group. Members
+// holds canonical local user ids.
+type Group struct {
+	ID          string   `gorm:"primaryKey"      json:"id"`
+	Name        string   `gorm:"uniqueIndex"     json:"name"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Members     []string `gorm:"serializer:json" json:"members"`
+	CreatedAt   int64    `json:"createdAt"`
+	UpdatedAt   int64    `json:"updatedAt"`
+}
+
+// Transfer is the persistence model for a datatx copy of an accepted incoming
+// share into the recipient's storage. Status is queued, running, completed,
+// or failed.
+type Transfer struct {
+	ShareID         string `gorm:"primaryKey" json:"shareId"`
+	RecipientUserID string `gorm:"index"      json:"recipientUserId"`
+	Status          string `json:"status"`
+	Destination     string `json:"destination,omitempty"`
+	BytesTotal      int64  `json:"bytesTotal"`
+	BytesDone       int64  `json:"bytesDone"`
+	FilesTotal      int    `json:"filesTotal"`
+	FilesDone       int    `json:"filesDone"`
+	Attempts        int    `json:"attempts"`
+	LastError       string `json:"lastError,omitempty"`
+	CreatedAt       int64  `json:"createdAt"`
+	UpdatedAt       int64  `json:"updatedAt"`
+	CompletedAt     int64  `json:"completedAt,omitempty"`
+}
+
+// PeerPolicyEntry is the persistence model for a peer trust allow/deny list
+// entry added at runtime. List is allow or deny; Host is lowercased.
+type PeerPolicyEntry struct {
+	List      string `gorm:"primaryKey" json:"list"`
+	Host      string `gorm:"primaryKey" json:"host"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// PeerTrustGroup is the persistence model for a Directory Service trust group
+// attached at runtime. Config is the trust group JSON document, in the format
+// of the files under peer_trust.config_paths.
+type PeerTrustGroup struct {
+	TrustGroupID string `gorm:"primaryKey" json:"trustGroupId"`
+	Config       string `json:"config"`
+	CreatedAt    int64  `json:"createdAt"`
+}
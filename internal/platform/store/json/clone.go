@@ -8,7 +8,7 @@ package json
 import "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
 
 // Clone helpers return copies at value boundaries. IncomingShare carries a
-// Requirements slice that must be cloned separately.
+// Requirements slice and OutboxMessage a Body that must be cloned separately.
 
 func cloneOutgoingShare(s *store.OutgoingShare) *store.OutgoingShare {
 	c := *s
@@ -16,6 +16,14 @@ func cloneOutgoingShare(s *store.OutgoingShare) *store.OutgoingShare {
 		c.Requirements = append([]string(nil), s.Requirements...)
 	}
 
+	if len(s.WebappTargets) > 0 {
+		c.WebappTargets = append([]string(nil), s.WebappTargets...)
+	}
+
+	if len(s.RecipientPublicKeys) > 0 {
+		c.RecipientPublicKeys = append([]string(nil), s.RecipientPublicKeys...)
+	}
+
 	return &c
 }
 
@@ -29,6 +37,14 @@ func cloneIncomingShare(s *store.IncomingShare) *store.IncomingShare {
 		c.WebappTargets = append([]string(nil), s.WebappTargets...)
 	}
 
+	if len(s.SSHAccessTypes) > 0 {
+		c.SSHAccessTypes = append([]string(nil), s.SSHAccessTypes...)
+	}
+
+	if len(s.WebDAVAccessTypes) > 0 {
+		c.WebDAVAccessTypes = append([]string(nil), s.WebDAVAccessTypes...)
+	}
+
 	return &c
 }
 
@@ -43,3 +59,60 @@ func cloneIncomingInvite(i *store.IncomingInvite) *store.IncomingInvite {
 
 	return &c
 }
+
+func cloneOutboxMessage(m *store.OutboxMessage) *store.OutboxMessage {
+	c := *m
+	if m.Body != nil {
+		c.Body = append([]byte(nil), m.Body...)
+	}
+
+	return &c
+}
+
+func cloneParty(p *store.Party) *store.Party {
+	c := *p
+	if len(p.SSHPublicKeys) > 0 {
+		c.SSHPublicKeys = append([]string(nil), p.SSHPublicKeys...)
+	}
+
+	return &c
+}
+
+func cloneSession(s *store.Session) *store.Session {
+	c := *s
+
+	return &c
+}
+
+func cloneShareRequest(r *store.ShareRequest) *store.ShareRequest {
+	c := *r
+
+	return &c
+}
+
+func cloneGroup(g *store.Group) *store.Group {
+	c := *g
+	if len(g.Members) > 0 {
+		c.Members = append([]string(nil), g.Members...)
+	}
+
+	return &c
+}
+
+func cloneTransfer(t *store.Transfer) *store.Transfer {
+	c := *t
+
+	return &c
+}
+
+func clonePeerPolicyEntry(e *store.PeerPolicyEntry) *store.PeerPolicyEntry {
+	c := *e
+
+	return &c
+}
+
+func clonePeerTrustGroup(g *store.PeerTrustGroup) *store.PeerTrustGroup {
+	c := *g
+
+	return &c
+}
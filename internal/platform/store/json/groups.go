@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package json
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// groupNameTaken reports whether another group already holds g's name.
+// Callers must hold d.mu.
+func (d *Driver) groupNameTaken(g *store.Group) bool {
+	for id, existing := range d.groups {
+		if id != g.ID && existing.Name == g.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateGroup stores a new group.
+func (d *Driver) CreateGroup(_ context.Context, group *store.Group) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := d.groups[group.ID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	if d.groupNameTaken(group) {
+		return store.ErrAlreadyExists
+	}
+
+	d.groups[group.ID] = cloneGroup(group)
+
+	if err := d.saveFile(fileGroups, d.groups); err != nil {
+		// Rollback: remove the in-memory entry so state stays consistent with disk.
+		delete(d.groups, group.ID)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetGroup retrieves a group by id.
+func (d *Driver) GetGroup(_ context.Context, id string) (*store.Group, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	group, ok := d.groups[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneGroup(group), nil
+}
+
+// GetGroupByName retrieves a group by its unique name.
+func (d *Driver) GetGroupByName(_ context.Context, name string) (*store.Group, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	for _, group := range d.groups {
+		if group.Name == name {
+			return cloneGroup(group), nil
+		}
+	}
+
+	return nil, store.ErrNotFound
+}
+
+// UpdateGroup replaces an existing group.
+func (d *Driver) UpdateGroup(_ context.Context, group *store.Group) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	old, exists := d.groups[group.ID]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	if d.groupNameTaken(group) {
+		return store.ErrAlreadyExists
+	}
+
+	d.groups[group.ID] = cloneGroup(group)
+
+	if err := d.saveFile(fileGroups, d.groups); err != nil {
+		// Rollback: restore the previous group.
+		d.groups[group.ID] = old
+
+		return err
+	}
+
+	return nil
+}
+
+// DeleteGroup removes a group.
+func (d *Driver) DeleteGroup(_ context.Context, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	group, exists := d.groups[id]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	delete(d.groups, id)
+
+	if err := d.saveFile(fileGroups, d.groups); err != nil {
+		// Rollback: restore the deleted group.
+		d.groups[id] = group
+
+		return err
+	}
+
+	return nil
+}
+
+// ListGroups returns every group.
+func (d *Driver) ListGroups(_ context.Context) ([]*store.Group, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	groups := make([]*store.Group, 0, len(d.groups))
+	for _, group := range d.groups {
+		groups = append(groups, cloneGroup(group))
+	}
+
+	return groups, nil
+}
@@ -8,6 +8,7 @@ package json
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
@@ -215,3 +216,24 @@ func (d *Driver) ListOutgoingInvites(_ context.Context, userID string) ([]*store
 
 	return invites, nil
 }
+
+// ListExpiringOutgoingInvites returns the outgoing invites of every user in
+// one of statuses whose expiry is set and not after now.
+func (d *Driver) ListExpiringOutgoingInvites(_ context.Context, now int64, statuses []string) ([]*store.OutgoingInvite, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	invites := make([]*store.OutgoingInvite, 0)
+
+	for _, invite := range d.outgoingInvites {
+		if invite.ExpiresAt > 0 && invite.ExpiresAt <= now && slices.Contains(statuses, invite.Status) {
+			invites = append(invites, cloneOutgoingInvite(invite))
+		}
+	}
+
+	return invites, nil
+}
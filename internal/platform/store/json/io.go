@@ -28,13 +28,21 @@ const (
 	fileIncomingShares  = "incoming_shares.json"
 	fileOutgoingInvites = "outgoing_invites.json"
 	fileIncomingInvites = "incoming_invites.json"
+	fileOutbox          = "outbox.json"
+	fileParties         = "parties.json"
+	fileSessions        = "sessions.json"
+	fileShareRequests   = "share_requests.json"
+	fileGroups          = "groups.json"
+	fileTransfers       = "transfers.json"
+	filePeerPolicy      = "peer_policy_entries.json"
+	filePeerTrustGroups = "peer_trust_groups.json"
 )
 
 // loadFile loads a JSON file into the target map.
 func (d *Driver) loadFile(filename string, target any) error {
 	path := filepath.Join(d.dataDir, filename)
 
-	data, err := os.ReadFile(path) //nolint:gosec // G304: path is filepath.Join of the operator-configured persistence data dir and one of four fixed package file-name constants
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is filepath.Join of the operator-configured persistence data dir and one of the fixed package file-name constants
 	if err != nil {
 		return err //nolint:wrapcheck // preserve raw fs.PathError so callers' os.IsNotExist detects missing-file init
 	}
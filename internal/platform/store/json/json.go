@@ -28,10 +28,18 @@ type Driver struct {
 	closed  bool
 
 	// In-memory state loaded from JSON
-	outgoingShares  map[string]*store.OutgoingShare  // keyed by providerID
-	incomingShares  map[string]*store.IncomingShare  // keyed by shareID
-	outgoingInvites map[string]*store.OutgoingInvite // keyed by id
-	incomingInvites map[string]*store.IncomingInvite // keyed by id
+	outgoingShares  map[string]*store.OutgoingShare   // keyed by providerID
+	incomingShares  map[string]*store.IncomingShare   // keyed by shareID
+	outgoingInvites map[string]*store.OutgoingInvite  // keyed by id
+	incomingInvites map[string]*store.IncomingInvite  // keyed by id
+	outbox          map[string]*store.OutboxMessage   // keyed by id
+	parties         map[string]*store.Party           // keyed by id
+	sessions        map[string]*store.Session         // keyed by token
+	shareRequests   map[string]*store.ShareRequest    // keyed by id
+	groups          map[string]*store.Group           // keyed by id
+	transfers       map[string]*store.Transfer        // keyed by incoming share id
+	peerPolicy      map[string]*store.PeerPolicyEntry // keyed by "list:host"
+	peerTrustGroups map[string]*store.PeerTrustGroup  // keyed by trust group id
 
 	// Secondary indexes for outgoing shares
 	webdavIndex  map[string]string // webdavID -> providerID
@@ -39,7 +47,7 @@ type Driver struct {
 	secretIndex  map[string]string // sharedSecret -> providerID
 
 	// Secondary indexes for incoming shares
-	providerIndex map[string]string // "sendingServer:providerID" -> shareID
+	providerIndex map[string]string // "sendingServer:providerID\x00recipientUserID" -> shareID
 
 	// Secondary indexes for invites
 	outgoingInviteTokenIndex     map[string]string // token -> outgoing invite id
@@ -58,6 +66,14 @@ func NewDriver(cfg *store.DriverConfig) (store.Driver, error) {
 		incomingShares:               make(map[string]*store.IncomingShare),
 		outgoingInvites:              make(map[string]*store.OutgoingInvite),
 		incomingInvites:              make(map[string]*store.IncomingInvite),
+		outbox:                       make(map[string]*store.OutboxMessage),
+		parties:                      make(map[string]*store.Party),
+		sessions:                     make(map[string]*store.Session),
+		shareRequests:                make(map[string]*store.ShareRequest),
+		groups:                       make(map[string]*store.Group),
+		transfers:                    make(map[string]*store.Transfer),
+		peerPolicy:                   make(map[string]*store.PeerPolicyEntry),
+		peerTrustGroups:              make(map[string]*store.PeerTrustGroup),
 		webdavIndex:                  make(map[string]string),
 		shareIDIndex:                 make(map[string]string),
 		secretIndex:                  make(map[string]string),
@@ -97,6 +113,38 @@ func (d *Driver) Init(_ context.Context) error {
 		return fmt.Errorf("failed to load incoming invites: %w", err)
 	}
 
+	if err := d.loadFile(fileOutbox, &d.outbox); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load outbox: %w", err)
+	}
+
+	if err := d.loadFile(fileParties, &d.parties); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load parties: %w", err)
+	}
+
+	if err := d.loadFile(fileSessions, &d.sessions); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+
+	if err := d.loadFile(fileShareRequests, &d.shareRequests); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load share requests: %w", err)
+	}
+
+	if err := d.loadFile(fileGroups, &d.groups); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load groups: %w", err)
+	}
+
+	if err := d.loadFile(fileTransfers, &d.transfers); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load transfers: %w", err)
+	}
+
+	if err := d.loadFile(filePeerPolicy, &d.peerPolicy); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load peer policy entries: %w", err)
+	}
+
+	if err := d.loadFile(filePeerTrustGroups, &d.peerTrustGroups); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load peer trust groups: %w", err)
+	}
+
 	if err := d.rebuildIndexes(); err != nil {
 		return fmt.Errorf("failed to rebuild indexes: %w", err)
 	}
@@ -120,3 +168,10 @@ var _ store.OutgoingShareStore = (*Driver)(nil)
 var _ store.IncomingShareStore = (*Driver)(nil)
 var _ store.OutgoingInviteStore = (*Driver)(nil)
 var _ store.IncomingInviteStore = (*Driver)(nil)
+var _ store.OutboxStore = (*Driver)(nil)
+var _ store.PartyStore = (*Driver)(nil)
+var _ store.SessionStore = (*Driver)(nil)
+var _ store.ShareRequestStore = (*Driver)(nil)
+var _ store.GroupStore = (*Driver)(nil)
+var _ store.TransferStore = (*Driver)(nil)
+var _ store.PeerTrustStore = (*Driver)(nil)
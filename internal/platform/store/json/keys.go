@@ -7,9 +7,10 @@ package json
 
 import "fmt"
 
-// providerKey creates a lookup key for incoming shares.
-func providerKey(senderHost, providerID string) string {
-	return senderHost + ":" + providerID
+// providerKey creates a lookup key for incoming shares scoped to a recipient;
+// a group share stores one row per member under the same provider key.
+func providerKey(senderHost, providerID, recipientUserID string) string {
+	return senderHost + ":" + providerID + "\x00" + recipientUserID
 }
 
 // tokenUserKey creates a lookup key for incoming invites scoped to a recipient.
@@ -88,11 +89,11 @@ func (d *Driver) rebuildOutgoingShareIndexes() error {
 
 func (d *Driver) rebuildIncomingShareIndexes() error {
 	for shareID, share := range d.incomingShares {
-		key := providerKey(share.SenderHost, share.ProviderID)
+		key := providerKey(share.SenderHost, share.ProviderID, share.RecipientUserID)
 		if existingID, exists := d.providerIndex[key]; exists {
 			return fmt.Errorf(
-				"corrupt data: duplicate incoming share (senderHost=%q, providerId=%q): ids %q and %q",
-				share.SenderHost, share.ProviderID, existingID, shareID,
+				"corrupt data: duplicate incoming share (senderHost=%q, providerId=%q, recipientUserId=%q): ids %q and %q",
+				share.SenderHost, share.ProviderID, share.RecipientUserID, existingID, shareID,
 			)
 		}
 
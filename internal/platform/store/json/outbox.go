@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package json
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// CreateOutboxMessage queues a new outbox message.
+func (d *Driver) CreateOutboxMessage(_ context.Context, msg *store.OutboxMessage) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := d.outbox[msg.ID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	d.outbox[msg.ID] = cloneOutboxMessage(msg)
+
+	if err := d.saveFile(fileOutbox, d.outbox); err != nil {
+		// Rollback: remove the in-memory entry so state stays consistent with disk.
+		delete(d.outbox, msg.ID)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetOutboxMessage retrieves an outbox message by id.
+func (d *Driver) GetOutboxMessage(_ context.Context, id string) (*store.OutboxMessage, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	msg, ok := d.outbox[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneOutboxMessage(msg), nil
+}
+
+// UpdateOutboxMessage replaces an existing outbox message.
+func (d *Driver) UpdateOutboxMessage(_ context.Context, msg *store.OutboxMessage) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	old, exists := d.outbox[msg.ID]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	d.outbox[msg.ID] = cloneOutboxMessage(msg)
+
+	if err := d.saveFile(fileOutbox, d.outbox); err != nil {
+		// Rollback: restore the previous message.
+		d.outbox[msg.ID] = old
+
+		return err
+	}
+
+	return nil
+}
+
+// DeleteOutboxMessage removes an outbox message.
+func (d *Driver) DeleteOutboxMessage(_ context.Context, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	msg, exists := d.outbox[id]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	delete(d.outbox, id)
+
+	if err := d.saveFile(fileOutbox, d.outbox); err != nil {
+		// Rollback: restore the deleted message.
+		d.outbox[id] = msg
+
+		return err
+	}
+
+	return nil
+}
+
+// ListDueOutboxMessages returns pending messages due at or before now, oldest
+// due first.
+func (d *Driver) ListDueOutboxMessages(_ context.Context, now int64, limit int) ([]*store.OutboxMessage, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	due := make([]*store.OutboxMessage, 0)
+
+	for _, msg := range d.outbox {
+		if msg.Status == string(outbox.StatusPending) && msg.NextAttemptAt <= now {
+			due = append(due, msg)
+		}
+	}
+
+	slices.SortFunc(due, func(a, b *store.OutboxMessage) int {
+		if c := cmp.Compare(a.NextAttemptAt, b.NextAttemptAt); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	for i, msg := range due {
+		due[i] = cloneOutboxMessage(msg)
+	}
+
+	return due, nil
+}
+
+// ListOutboxMessages returns every outbox message.
+func (d *Driver) ListOutboxMessages(_ context.Context) ([]*store.OutboxMessage, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	msgs := make([]*store.OutboxMessage, 0, len(d.outbox))
+	for _, msg := range d.outbox {
+		msgs = append(msgs, cloneOutboxMessage(msg))
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package json
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// partyConflicts reports whether another party already holds p's username or
+// non-empty normalized email. Callers must hold d.mu.
+func (d *Driver) partyConflicts(p *store.Party) bool {
+	for id, existing := range d.parties {
+		if id == p.ID {
+			continue
+		}
+
+		if existing.Username == p.Username {
+			return true
+		}
+
+		if p.EmailNormalized != "" && existing.EmailNormalized == p.EmailNormalized {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateParty creates a new party.
+func (d *Driver) CreateParty(_ context.Context, party *store.Party) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := d.parties[party.ID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	if d.partyConflicts(party) {
+		return store.ErrAlreadyExists
+	}
+
+	d.parties[party.ID] = cloneParty(party)
+
+	if err := d.saveFile(fileParties, d.parties); err != nil {
+		// Rollback: remove the in-memory entry so state stays consistent with disk.
+		delete(d.parties, party.ID)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetParty retrieves a party by id.
+func (d *Driver) GetParty(_ context.Context, id string) (*store.Party, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	party, ok := d.parties[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneParty(party), nil
+}
+
+// GetPartyByUsername retrieves a party by username.
+func (d *Driver) GetPartyByUsername(_ context.Context, username string) (*store.Party, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	for _, party := range d.parties {
+		if party.Username == username {
+			return cloneParty(party), nil
+		}
+	}
+
+	return nil, store.ErrNotFound
+}
+
+// GetPartyByEmail retrieves a party by normalized email. An empty key never
+// matches.
+func (d *Driver) GetPartyByEmail(_ context.Context, emailNormalized string) (*store.Party, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	if emailNormalized == "" {
+		return nil, store.ErrNotFound
+	}
+
+	for _, party := range d.parties {
+		if party.EmailNormalized == emailNormalized {
+			return cloneParty(party), nil
+		}
+	}
+
+	return nil, store.ErrNotFound
+}
+
+// UpdateParty replaces an existing party.
+func (d *Driver) UpdateParty(_ context.Context, party *store.Party) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	old, exists := d.parties[party.ID]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	if d.partyConflicts(party) {
+		return store.ErrAlreadyExists
+	}
+
+	d.parties[party.ID] = cloneParty(party)
+
+	if err := d.saveFile(fileParties, d.parties); err != nil {
+		// Rollback: restore the previous party.
+		d.parties[party.ID] = old
+
+		return err
+	}
+
+	return nil
+}
+
+// DeleteParty removes a party.
+func (d *Driver) DeleteParty(_ context.Context, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	party, exists := d.parties[id]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	delete(d.parties, id)
+
+	if err := d.saveFile(fileParties, d.parties); err != nil {
+		// Rollback: restore the deleted party.
+		d.parties[id] = party
+
+		return err
+	}
+
+	return nil
+}
+
+// ListParties returns parties in realm, or every party when realm is empty.
+func (d *Driver) ListParties(_ context.Context, realm string) ([]*store.Party, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	parties := make([]*store.Party, 0, len(d.parties))
+
+	for _, party := range d.parties {
+		if realm == "" || party.Realm == realm {
+			parties = append(parties, cloneParty(party))
+		}
+	}
+
+	return parties, nil
+}
+
+// DeleteExpiredParties removes parties that expired before now.
+func (d *Driver) DeleteExpiredParties(_ context.Context, now int64) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return 0, store.ErrClosed
+	}
+
+	removed := make(map[string]*store.Party)
+
+	for id, party := range d.parties {
+		if party.ExpiresAt != 0 && party.ExpiresAt < now {
+			removed[id] = party
+			delete(d.parties, id)
+		}
+	}
+
+	if len(removed) == 0 {
+		return 0, nil
+	}
+
+	if err := d.saveFile(fileParties, d.parties); err != nil {
+		// Rollback: restore every removed party.
+		for id, party := range removed {
+			d.parties[id] = party
+		}
+
+		return 0, err
+	}
+
+	return len(removed), nil
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package json
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+func peerPolicyKey(list, host string) string {
+	return list + ":" + host
+}
+
+// CreatePeerPolicyEntry stores a new allow/deny list entry.
+func (d *Driver) CreatePeerPolicyEntry(_ context.Context, entry *store.PeerPolicyEntry) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	key := peerPolicyKey(entry.List, entry.Host)
+	if _, exists := d.peerPolicy[key]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	d.peerPolicy[key] = clonePeerPolicyEntry(entry)
+
+	if err := d.saveFile(filePeerPolicy, d.peerPolicy); err != nil {
+		// Rollback: remove the in-memory entry so state stays consistent with disk.
+		delete(d.peerPolicy, key)
+
+		return err
+	}
+
+	return nil
+}
+
+// DeletePeerPolicyEntry removes an allow/deny list entry.
+func (d *Driver) DeletePeerPolicyEntry(_ context.Context, list, host string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	key := peerPolicyKey(list, host)
+
+	entry, exists := d.peerPolicy[key]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	delete(d.peerPolicy, key)
+
+	if err := d.saveFile(filePeerPolicy, d.peerPolicy); err != nil {
+		// Rollback: restore the deleted entry.
+		d.peerPolicy[key] = entry
+
+		return err
+	}
+
+	return nil
+}
+
+// ListPeerPolicyEntries returns every allow/deny list entry.
+func (d *Driver) ListPeerPolicyEntries(_ context.Context) ([]*store.PeerPolicyEntry, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	entries := make([]*store.PeerPolicyEntry, 0, len(d.peerPolicy))
+	for _, entry := range d.peerPolicy {
+		entries = append(entries, clonePeerPolicyEntry(entry))
+	}
+
+	return entries, nil
+}
+
+// CreatePeerTrustGroup stores a new attached trust group.
+func (d *Driver) CreatePeerTrustGroup(_ context.Context, group *store.PeerTrustGroup) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := d.peerTrustGroups[group.TrustGroupID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	d.peerTrustGroups[group.TrustGroupID] = clonePeerTrustGroup(group)
+
+	if err := d.saveFile(filePeerTrustGroups, d.peerTrustGroups); err != nil {
+		// Rollback: remove the in-memory entry so state stays consistent with disk.
+		delete(d.peerTrustGroups, group.TrustGroupID)
+
+		return err
+	}
+
+	return nil
+}
+
+// DeletePeerTrustGroup removes an attached trust group.
+func (d *Driver) DeletePeerTrustGroup(_ context.Context, trustGroupID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	group, exists := d.peerTrustGroups[trustGroupID]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	delete(d.peerTrustGroups, trustGroupID)
+
+	if err := d.saveFile(filePeerTrustGroups, d.peerTrustGroups); err != nil {
+		// Rollback: restore the deleted trust group.
+		d.peerTrustGroups[trustGroupID] = group
+
+		return err
+	}
+
+	return nil
+}
+
+// ListPeerTrustGroups returns every attached trust group.
+func (d *Driver) ListPeerTrustGroups(_ context.Context) ([]*store.PeerTrustGroup, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	groups := make([]*store.PeerTrustGroup, 0, len(d.peerTrustGroups))
+	for _, group := range d.peerTrustGroups {
+		groups = append(groups, clonePeerTrustGroup(group))
+	}
+
+	return groups, nil
+}
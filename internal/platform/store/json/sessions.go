@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package json
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// CreateSession stores a new session.
+func (d *Driver) CreateSession(_ context.Context, session *store.Session) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := d.sessions[session.Token]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	d.sessions[session.Token] = cloneSession(session)
+
+	if err := d.saveFile(fileSessions, d.sessions); err != nil {
+		// Rollback: remove the in-memory entry so state stays consistent with disk.
+		delete(d.sessions, session.Token)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetSession retrieves a session by token.
+func (d *Driver) GetSession(_ context.Context, token string) (*store.Session, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	session, ok := d.sessions[token]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneSession(session), nil
+}
+
+// DeleteSession removes a session by token.
+func (d *Driver) DeleteSession(_ context.Context, token string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	session, exists := d.sessions[token]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	delete(d.sessions, token)
+
+	if err := d.saveFile(fileSessions, d.sessions); err != nil {
+		// Rollback: restore the deleted session.
+		d.sessions[token] = session
+
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSessionsByUser removes every session belonging to userID.
+func (d *Driver) DeleteSessionsByUser(_ context.Context, userID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	_, err := d.deleteSessionsWhere(func(s *store.Session) bool { return s.UserID == userID })
+
+	return err
+}
+
+// DeleteExpiredSessions removes sessions that expired before now.
+func (d *Driver) DeleteExpiredSessions(_ context.Context, now int64) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return 0, store.ErrClosed
+	}
+
+	return d.deleteSessionsWhere(func(s *store.Session) bool { return s.ExpiresAt < now })
+}
+
+// deleteSessionsWhere removes matching sessions and persists the result,
+// restoring them if the save fails. Callers must hold d.mu.
+func (d *Driver) deleteSessionsWhere(match func(*store.Session) bool) (int, error) {
+	removed := make(map[string]*store.Session)
+
+	for token, session := range d.sessions {
+		if match(session) {
+			removed[token] = session
+			delete(d.sessions, token)
+		}
+	}
+
+	if len(removed) == 0 {
+		return 0, nil
+	}
+
+	if err := d.saveFile(fileSessions, d.sessions); err != nil {
+		// Rollback: restore every removed session.
+		for token, session := range removed {
+			d.sessions[token] = session
+		}
+
+		return 0, err
+	}
+
+	return len(removed), nil
+}
+
+// ListSessions returns every session.
+func (d *Driver) ListSessions(_ context.Context) ([]*store.Session, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	sessions := make([]*store.Session, 0, len(d.sessions))
+	for _, session := range d.sessions {
+		sessions = append(sessions, cloneSession(session))
+	}
+
+	return sessions, nil
+}
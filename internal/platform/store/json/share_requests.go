@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package json
+
+import (
+	"context"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// CreateShareRequest stores a new share request.
+func (d *Driver) CreateShareRequest(_ context.Context, req *store.ShareRequest) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := d.shareRequests[req.ID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	d.shareRequests[req.ID] = cloneShareRequest(req)
+
+	if err := d.saveFile(fileShareRequests, d.shareRequests); err != nil {
+		// Rollback: remove the in-memory entry so state stays consistent with disk.
+		delete(d.shareRequests, req.ID)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetShareRequestForOwner retrieves a share request by id, scoped to an owner.
+func (d *Driver) GetShareRequestForOwner(_ context.Context, id string, ownerUserID string) (*store.ShareRequest, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	req, ok := d.shareRequests[id]
+	if !ok || req.OwnerUserID != ownerUserID {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneShareRequest(req), nil
+}
+
+// UpdateShareRequestStatusForOwner sets the status (and approved share id) of
+// a share request, scoped to an owner.
+func (d *Driver) UpdateShareRequestStatusForOwner(
+	_ context.Context,
+	id string,
+	ownerUserID string,
+	status string,
+	approvedShareID string,
+) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	req, ok := d.shareRequests[id]
+	if !ok || req.OwnerUserID != ownerUserID {
+		return store.ErrNotFound
+	}
+
+	oldStatus := req.Status
+	oldApprovedShareID := req.ApprovedShareID
+	oldUpdatedAt := req.UpdatedAt
+	req.Status = status
+	req.ApprovedShareID = approvedShareID
+	req.UpdatedAt = time.Now().Unix()
+
+	if err := d.saveFile(fileShareRequests, d.shareRequests); err != nil {
+		// Rollback: restore the old field values on the in-place pointer.
+		req.Status = oldStatus
+		req.ApprovedShareID = oldApprovedShareID
+		req.UpdatedAt = oldUpdatedAt
+
+		return err
+	}
+
+	return nil
+}
+
+// ListShareRequests returns share requests for ownerUserID, or every share
+// request when ownerUserID is empty.
+func (d *Driver) ListShareRequests(_ context.Context, ownerUserID string) ([]*store.ShareRequest, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	reqs := make([]*store.ShareRequest, 0, len(d.shareRequests))
+	for _, req := range d.shareRequests {
+		if ownerUserID == "" || req.OwnerUserID == ownerUserID {
+			reqs = append(reqs, cloneShareRequest(req))
+		}
+	}
+
+	return reqs, nil
+}
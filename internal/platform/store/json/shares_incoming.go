@@ -6,7 +6,9 @@
 package json
 
 import (
+	"cmp"
 	"context"
+	"slices"
 	"time"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
@@ -25,7 +27,7 @@ func (d *Driver) CreateIncomingShare(_ context.Context, share *store.IncomingSha
 		return store.ErrAlreadyExists
 	}
 
-	key := providerKey(share.SenderHost, share.ProviderID)
+	key := providerKey(share.SenderHost, share.ProviderID, share.RecipientUserID)
 	if _, exists := d.providerIndex[key]; exists {
 		return store.ErrAlreadyExists
 	}
@@ -61,7 +63,8 @@ func (d *Driver) GetIncomingShareByIDForRecipient(_ context.Context, shareID str
 	return cloneIncomingShare(share), nil
 }
 
-// GetIncomingShareByProviderKey retrieves an incoming share by sending server and providerID.
+// GetIncomingShareByProviderKey retrieves the oldest incoming share for a
+// sending server and providerID.
 func (d *Driver) GetIncomingShareByProviderKey(_ context.Context, senderHost, providerID string) (*store.IncomingShare, error) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -70,17 +73,54 @@ func (d *Driver) GetIncomingShareByProviderKey(_ context.Context, senderHost, pr
 		return nil, store.ErrClosed
 	}
 
-	shareID, ok := d.providerIndex[providerKey(senderHost, providerID)]
-	if !ok {
+	shares := d.incomingSharesByProviderKey(senderHost, providerID)
+	if len(shares) == 0 {
 		return nil, store.ErrNotFound
 	}
 
-	share, ok := d.incomingShares[shareID]
-	if !ok {
-		return nil, store.ErrNotFound
+	return cloneIncomingShare(shares[0]), nil
+}
+
+// ListIncomingSharesByProviderKey returns every recipient's incoming share for
+// a sending server and providerID, oldest first.
+func (d *Driver) ListIncomingSharesByProviderKey(_ context.Context, senderHost, providerID string) ([]*store.IncomingShare, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
 	}
 
-	return cloneIncomingShare(share), nil
+	matches := d.incomingSharesByProviderKey(senderHost, providerID)
+
+	shares := make([]*store.IncomingShare, 0, len(matches))
+	for _, share := range matches {
+		shares = append(shares, cloneIncomingShare(share))
+	}
+
+	return shares, nil
+}
+
+// incomingSharesByProviderKey returns the stored (uncloned) rows for a
+// provider key ordered by creation time, then shareID. Callers must hold d.mu.
+func (d *Driver) incomingSharesByProviderKey(senderHost, providerID string) []*store.IncomingShare {
+	var matches []*store.IncomingShare
+
+	for _, share := range d.incomingShares {
+		if share.SenderHost == senderHost && share.ProviderID == providerID {
+			matches = append(matches, share)
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b *store.IncomingShare) int {
+		if n := cmp.Compare(a.CreatedAt, b.CreatedAt); n != 0 {
+			return n
+		}
+
+		return cmp.Compare(a.ShareID, b.ShareID)
+	})
+
+	return matches
 }
 
 // ListIncomingSharesByRecipient returns incoming shares for the given recipient user.
@@ -103,6 +143,27 @@ func (d *Driver) ListIncomingSharesByRecipient(_ context.Context, recipientUserI
 	return shares, nil
 }
 
+// ListExpiringIncomingShares returns every recipient's incoming shares in one
+// of statuses whose expiration is set and not after now.
+func (d *Driver) ListExpiringIncomingShares(_ context.Context, now int64, statuses []string) ([]*store.IncomingShare, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	shares := make([]*store.IncomingShare, 0)
+
+	for _, share := range d.incomingShares {
+		if share.Expiration > 0 && share.Expiration <= now && slices.Contains(statuses, share.Status) {
+			shares = append(shares, cloneIncomingShare(share))
+		}
+	}
+
+	return shares, nil
+}
+
 // UpdateIncomingShareStatusForRecipient updates the status of an incoming share, scoped to a recipient.
 func (d *Driver) UpdateIncomingShareStatusForRecipient(_ context.Context, shareID string, recipientUserID string, status string) error {
 	d.mu.Lock()
@@ -147,7 +208,7 @@ func (d *Driver) DeleteIncomingShareForRecipient(_ context.Context, shareID stri
 		return store.ErrNotFound
 	}
 
-	key := providerKey(share.SenderHost, share.ProviderID)
+	key := providerKey(share.SenderHost, share.ProviderID, share.RecipientUserID)
 	delete(d.providerIndex, key)
 	delete(d.incomingShares, shareID)
 
@@ -7,6 +7,7 @@ package json
 
 import (
 	"context"
+	"slices"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
 )
@@ -175,6 +176,30 @@ func (d *Driver) GetOutgoingShareBySharedSecret(_ context.Context, sharedSecret
 	return cloneOutgoingShare(share), nil
 }
 
+// GetOutgoingShareByWebappSharedSecret retrieves an outgoing share by its
+// webapp arm secret. It is only consulted when the WebDAV secret lookup
+// misses, so it scans instead of keeping a second index.
+func (d *Driver) GetOutgoingShareByWebappSharedSecret(_ context.Context, webappSharedSecret string) (*store.OutgoingShare, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	if webappSharedSecret == "" {
+		return nil, store.ErrNotFound
+	}
+
+	for _, share := range d.outgoingShares {
+		if share.WebappSharedSecret == webappSharedSecret {
+			return cloneOutgoingShare(share), nil
+		}
+	}
+
+	return nil, store.ErrNotFound
+}
+
 // UpdateOutgoingShare updates an existing outgoing share.
 func (d *Driver) UpdateOutgoingShare(_ context.Context, share *store.OutgoingShare) error {
 	d.mu.Lock()
@@ -315,3 +340,24 @@ func (d *Driver) ListOutgoingShares(_ context.Context) ([]*store.OutgoingShare,
 
 	return shares, nil
 }
+
+// ListExpiringOutgoingShares returns the outgoing shares in one of statuses
+// whose expiry is set and not after now.
+func (d *Driver) ListExpiringOutgoingShares(_ context.Context, now int64, statuses []string) ([]*store.OutgoingShare, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	shares := make([]*store.OutgoingShare, 0)
+
+	for _, share := range d.outgoingShares {
+		if share.ExpiresAt > 0 && share.ExpiresAt <= now && slices.Contains(statuses, share.Status) {
+			shares = append(shares, cloneOutgoingShare(share))
+		}
+	}
+
+	return shares, nil
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package json
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// CreateTransfer stores a new transfer.
+func (d *Driver) CreateTransfer(_ context.Context, transfer *store.Transfer) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := d.transfers[transfer.ShareID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	d.transfers[transfer.ShareID] = cloneTransfer(transfer)
+
+	if err := d.saveFile(fileTransfers, d.transfers); err != nil {
+		// Rollback: remove the in-memory entry so state stays consistent with disk.
+		delete(d.transfers, transfer.ShareID)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetTransfer retrieves the transfer of an incoming share.
+func (d *Driver) GetTransfer(_ context.Context, shareID string) (*store.Transfer, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	transfer, ok := d.transfers[shareID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneTransfer(transfer), nil
+}
+
+// UpdateTransfer replaces an existing transfer.
+func (d *Driver) UpdateTransfer(_ context.Context, transfer *store.Transfer) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return store.ErrClosed
+	}
+
+	old, exists := d.transfers[transfer.ShareID]
+	if !exists {
+		return store.ErrNotFound
+	}
+
+	d.transfers[transfer.ShareID] = cloneTransfer(transfer)
+
+	if err := d.saveFile(fileTransfers, d.transfers); err != nil {
+		// Rollback: restore the previous transfer.
+		d.transfers[transfer.ShareID] = old
+
+		return err
+	}
+
+	return nil
+}
+
+// ListTransfers returns every transfer.
+func (d *Driver) ListTransfers(_ context.Context) ([]*store.Transfer, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, store.ErrClosed
+	}
+
+	transfers := make([]*store.Transfer, 0, len(d.transfers))
+	for _, transfer := range d.transfers {
+		transfers = append(transfers, cloneTransfer(transfer))
+	}
+
+	return transfers, nil
+}
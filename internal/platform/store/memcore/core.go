@@ -14,16 +14,24 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
 )
 
-// Core holds the in-memory state for all four persistence surfaces and
+// Core holds the in-memory state for every persistence surface and
 // provides their full CRUD layer. Lifecycle: NewCore -> CRUD -> Close.
 type Core struct {
 	mu     sync.RWMutex
 	closed bool
 
-	outgoingShares  map[string]*store.OutgoingShare  // keyed by providerID
-	incomingShares  map[string]*store.IncomingShare  // keyed by shareID
-	outgoingInvites map[string]*store.OutgoingInvite // keyed by id
-	incomingInvites map[string]*store.IncomingInvite // keyed by id
+	outgoingShares  map[string]*store.OutgoingShare   // keyed by providerID
+	incomingShares  map[string]*store.IncomingShare   // keyed by shareID
+	outgoingInvites map[string]*store.OutgoingInvite  // keyed by id
+	incomingInvites map[string]*store.IncomingInvite  // keyed by id
+	outbox          map[string]*store.OutboxMessage   // keyed by id
+	parties         map[string]*store.Party           // keyed by id
+	sessions        map[string]*store.Session         // keyed by token
+	shareRequests   map[string]*store.ShareRequest    // keyed by id
+	groups          map[string]*store.Group           // keyed by id
+	transfers       map[string]*store.Transfer        // keyed by incoming share id
+	peerPolicy      map[string]*store.PeerPolicyEntry // keyed by "list:host"
+	peerTrustGroups map[string]*store.PeerTrustGroup  // keyed by trust group id
 
 	// Secondary indexes for outgoing shares
 	webdavIndex  map[string]string // webdavID -> providerID
@@ -31,7 +39,7 @@ type Core struct {
 	secretIndex  map[string]string // sharedSecret -> providerID
 
 	// Secondary index for incoming shares
-	providerIndex map[string]string // "sendingServer:providerID" -> shareID
+	providerIndex map[string]string // "sendingServer:providerID\x00recipientUserID" -> shareID
 
 	// Secondary indexes for invites
 	outgoingInviteTokenIndex     map[string]string // token -> outgoing invite id
@@ -45,6 +53,14 @@ func NewCore() *Core {
 		incomingShares:               make(map[string]*store.IncomingShare),
 		outgoingInvites:              make(map[string]*store.OutgoingInvite),
 		incomingInvites:              make(map[string]*store.IncomingInvite),
+		outbox:                       make(map[string]*store.OutboxMessage),
+		parties:                      make(map[string]*store.Party),
+		sessions:                     make(map[string]*store.Session),
+		shareRequests:                make(map[string]*store.ShareRequest),
+		groups:                       make(map[string]*store.Group),
+		transfers:                    make(map[string]*store.Transfer),
+		peerPolicy:                   make(map[string]*store.PeerPolicyEntry),
+		peerTrustGroups:              make(map[string]*store.PeerTrustGroup),
 		webdavIndex:                  make(map[string]string),
 		shareIDIndex:                 make(map[string]string),
 		secretIndex:                  make(map[string]string),
@@ -74,3 +90,10 @@ var _ store.OutgoingShareStore = (*Core)(nil)
 var _ store.IncomingShareStore = (*Core)(nil)
 var _ store.OutgoingInviteStore = (*Core)(nil)
 var _ store.IncomingInviteStore = (*Core)(nil)
+var _ store.OutboxStore = (*Core)(nil)
+var _ store.PartyStore = (*Core)(nil)
+var _ store.SessionStore = (*Core)(nil)
+var _ store.ShareRequestStore = (*Core)(nil)
+var _ store.GroupStore = (*Core)(nil)
+var _ store.TransferStore = (*Core)(nil)
+var _ store.PeerTrustStore = (*Core)(nil)
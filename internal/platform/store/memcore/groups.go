@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package memcore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// groupNameTaken reports whether another group already holds g's name.
+// Callers must hold c.mu.
+func (c *Core) groupNameTaken(g *store.Group) bool {
+	for id, existing := range c.groups {
+		if id != g.ID && existing.Name == g.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateGroup stores a new group.
+func (c *Core) CreateGroup(_ context.Context, group *store.Group) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.groups[group.ID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	if c.groupNameTaken(group) {
+		return store.ErrAlreadyExists
+	}
+
+	c.groups[group.ID] = cloneGroup(group)
+
+	return nil
+}
+
+// GetGroup retrieves a group by id.
+func (c *Core) GetGroup(_ context.Context, id string) (*store.Group, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	group, ok := c.groups[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneGroup(group), nil
+}
+
+// GetGroupByName retrieves a group by its unique name.
+func (c *Core) GetGroupByName(_ context.Context, name string) (*store.Group, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	for _, group := range c.groups {
+		if group.Name == name {
+			return cloneGroup(group), nil
+		}
+	}
+
+	return nil, store.ErrNotFound
+}
+
+// UpdateGroup replaces an existing group.
+func (c *Core) UpdateGroup(_ context.Context, group *store.Group) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.groups[group.ID]; !exists {
+		return store.ErrNotFound
+	}
+
+	if c.groupNameTaken(group) {
+		return store.ErrAlreadyExists
+	}
+
+	c.groups[group.ID] = cloneGroup(group)
+
+	return nil
+}
+
+// DeleteGroup removes a group.
+func (c *Core) DeleteGroup(_ context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.groups[id]; !exists {
+		return store.ErrNotFound
+	}
+
+	delete(c.groups, id)
+
+	return nil
+}
+
+// ListGroups returns every group.
+func (c *Core) ListGroups(_ context.Context) ([]*store.Group, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	groups := make([]*store.Group, 0, len(c.groups))
+	for _, group := range c.groups {
+		groups = append(groups, cloneGroup(group))
+	}
+
+	return groups, nil
+}
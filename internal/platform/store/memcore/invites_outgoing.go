@@ -8,6 +8,7 @@ package memcore
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
@@ -174,3 +175,24 @@ func (c *Core) ListOutgoingInvites(_ context.Context, userID string) ([]*store.O
 
 	return invites, nil
 }
+
+// ListExpiringOutgoingInvites returns the outgoing invites of every user in
+// one of statuses whose expiry is set and not after now.
+func (c *Core) ListExpiringOutgoingInvites(_ context.Context, now int64, statuses []string) ([]*store.OutgoingInvite, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	invites := make([]*store.OutgoingInvite, 0)
+
+	for _, invite := range c.outgoingInvites {
+		if invite.ExpiresAt > 0 && invite.ExpiresAt <= now && slices.Contains(statuses, invite.Status) {
+			invites = append(invites, cloneOutgoingInvite(invite))
+		}
+	}
+
+	return invites, nil
+}
@@ -5,9 +5,10 @@
 
 package memcore
 
-// providerKey creates a lookup key for incoming shares.
-func providerKey(senderHost, providerID string) string {
-	return senderHost + ":" + providerID
+// providerKey creates a lookup key for incoming shares scoped to a recipient;
+// a group share stores one row per member under the same provider key.
+func providerKey(senderHost, providerID, recipientUserID string) string {
+	return senderHost + ":" + providerID + "\x00" + recipientUserID
 }
 
 // tokenUserKey creates a lookup key for incoming invites scoped to a recipient.
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package memcore
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// CreateOutboxMessage queues a new outbox message.
+func (c *Core) CreateOutboxMessage(_ context.Context, msg *store.OutboxMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.outbox[msg.ID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	c.outbox[msg.ID] = cloneOutboxMessage(msg)
+
+	return nil
+}
+
+// GetOutboxMessage retrieves an outbox message by id.
+func (c *Core) GetOutboxMessage(_ context.Context, id string) (*store.OutboxMessage, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	msg, ok := c.outbox[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneOutboxMessage(msg), nil
+}
+
+// UpdateOutboxMessage replaces an existing outbox message.
+func (c *Core) UpdateOutboxMessage(_ context.Context, msg *store.OutboxMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.outbox[msg.ID]; !exists {
+		return store.ErrNotFound
+	}
+
+	c.outbox[msg.ID] = cloneOutboxMessage(msg)
+
+	return nil
+}
+
+// DeleteOutboxMessage removes an outbox message.
+func (c *Core) DeleteOutboxMessage(_ context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.outbox[id]; !exists {
+		return store.ErrNotFound
+	}
+
+	delete(c.outbox, id)
+
+	return nil
+}
+
+// ListDueOutboxMessages returns pending messages due at or before now, oldest
+// due first.
+func (c *Core) ListDueOutboxMessages(_ context.Context, now int64, limit int) ([]*store.OutboxMessage, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	due := make([]*store.OutboxMessage, 0)
+
+	for _, msg := range c.outbox {
+		if msg.Status == string(outbox.StatusPending) && msg.NextAttemptAt <= now {
+			due = append(due, msg)
+		}
+	}
+
+	slices.SortFunc(due, func(a, b *store.OutboxMessage) int {
+		if c := cmp.Compare(a.NextAttemptAt, b.NextAttemptAt); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	for i, msg := range due {
+		due[i] = cloneOutboxMessage(msg)
+	}
+
+	return due, nil
+}
+
+// ListOutboxMessages returns every outbox message.
+func (c *Core) ListOutboxMessages(_ context.Context) ([]*store.OutboxMessage, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	msgs := make([]*store.OutboxMessage, 0, len(c.outbox))
+	for _, msg := range c.outbox {
+		msgs = append(msgs, cloneOutboxMessage(msg))
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package memcore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// partyConflicts reports whether another party already holds p's username or
+// non-empty normalized email. Callers must hold c.mu.
+func (c *Core) partyConflicts(p *store.Party) bool {
+	for id, existing := range c.parties {
+		if id == p.ID {
+			continue
+		}
+
+		if existing.Username == p.Username {
+			return true
+		}
+
+		if p.EmailNormalized != "" && existing.EmailNormalized == p.EmailNormalized {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateParty creates a new party.
+func (c *Core) CreateParty(_ context.Context, party *store.Party) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.parties[party.ID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	if c.partyConflicts(party) {
+		return store.ErrAlreadyExists
+	}
+
+	c.parties[party.ID] = cloneParty(party)
+
+	return nil
+}
+
+// GetParty retrieves a party by id.
+func (c *Core) GetParty(_ context.Context, id string) (*store.Party, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	party, ok := c.parties[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneParty(party), nil
+}
+
+// GetPartyByUsername retrieves a party by username.
+func (c *Core) GetPartyByUsername(_ context.Context, username string) (*store.Party, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	for _, party := range c.parties {
+		if party.Username == username {
+			return cloneParty(party), nil
+		}
+	}
+
+	return nil, store.ErrNotFound
+}
+
+// GetPartyByEmail retrieves a party by normalized email. An empty key never
+// matches.
+func (c *Core) GetPartyByEmail(_ context.Context, emailNormalized string) (*store.Party, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	if emailNormalized == "" {
+		return nil, store.ErrNotFound
+	}
+
+	for _, party := range c.parties {
+		if party.EmailNormalized == emailNormalized {
+			return cloneParty(party), nil
+		}
+	}
+
+	return nil, store.ErrNotFound
+}
+
+// UpdateParty replaces an existing party.
+func (c *Core) UpdateParty(_ context.Context, party *store.Party) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.parties[party.ID]; !exists {
+		return store.ErrNotFound
+	}
+
+	if c.partyConflicts(party) {
+		return store.ErrAlreadyExists
+	}
+
+	c.parties[party.ID] = cloneParty(party)
+
+	return nil
+}
+
+// DeleteParty removes a party.
+func (c *Core) DeleteParty(_ context.Context, id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.parties[id]; !exists {
+		return store.ErrNotFound
+	}
+
+	delete(c.parties, id)
+
+	return nil
+}
+
+// ListParties returns parties in realm, or every party when realm is empty.
+func (c *Core) ListParties(_ context.Context, realm string) ([]*store.Party, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	parties := make([]*store.Party, 0, len(c.parties))
+
+	for _, party := range c.parties {
+		if realm == "" || party.Realm == realm {
+			parties = append(parties, cloneParty(party))
+		}
+	}
+
+	return parties, nil
+}
+
+// DeleteExpiredParties removes parties that expired before now.
+func (c *Core) DeleteExpiredParties(_ context.Context, now int64) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return 0, store.ErrClosed
+	}
+
+	var count int
+
+	for id, party := range c.parties {
+		if party.ExpiresAt != 0 && party.ExpiresAt < now {
+			delete(c.parties, id)
+
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package memcore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+func peerPolicyKey(list, host string) string {
+	return list + ":" + host
+}
+
+// CreatePeerPolicyEntry stores a new allow/deny list entry.
+func (c *Core) CreatePeerPolicyEntry(_ context.Context, entry *store.PeerPolicyEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	key := peerPolicyKey(entry.List, entry.Host)
+	if _, exists := c.peerPolicy[key]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	c.peerPolicy[key] = clonePeerPolicyEntry(entry)
+
+	return nil
+}
+
+// DeletePeerPolicyEntry removes an allow/deny list entry.
+func (c *Core) DeletePeerPolicyEntry(_ context.Context, list, host string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	key := peerPolicyKey(list, host)
+	if _, exists := c.peerPolicy[key]; !exists {
+		return store.ErrNotFound
+	}
+
+	delete(c.peerPolicy, key)
+
+	return nil
+}
+
+// ListPeerPolicyEntries returns every allow/deny list entry.
+func (c *Core) ListPeerPolicyEntries(_ context.Context) ([]*store.PeerPolicyEntry, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	entries := make([]*store.PeerPolicyEntry, 0, len(c.peerPolicy))
+	for _, entry := range c.peerPolicy {
+		entries = append(entries, clonePeerPolicyEntry(entry))
+	}
+
+	return entries, nil
+}
+
+// CreatePeerTrustGroup stores a new attached trust group.
+func (c *Core) CreatePeerTrustGroup(_ context.Context, group *store.PeerTrustGroup) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.peerTrustGroups[group.TrustGroupID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	c.peerTrustGroups[group.TrustGroupID] = clonePeerTrustGroup(group)
+
+	return nil
+}
+
+// DeletePeerTrustGroup removes an attached trust group.
+func (c *Core) DeletePeerTrustGroup(_ context.Context, trustGroupID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.peerTrustGroups[trustGroupID]; !exists {
+		return store.ErrNotFound
+	}
+
+	delete(c.peerTrustGroups, trustGroupID)
+
+	return nil
+}
+
+// ListPeerTrustGroups returns every attached trust group.
+func (c *Core) ListPeerTrustGroups(_ context.Context) ([]*store.PeerTrustGroup, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	groups := make([]*store.PeerTrustGroup, 0, len(c.peerTrustGroups))
+	for _, group := range c.peerTrustGroups {
+		groups = append(groups, clonePeerTrustGroup(group))
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package memcore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// CreateSession stores a new session.
+func (c *Core) CreateSession(_ context.Context, session *store.Session) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.sessions[session.Token]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	c.sessions[session.Token] = cloneSession(session)
+
+	return nil
+}
+
+// GetSession retrieves a session by token.
+func (c *Core) GetSession(_ context.Context, token string) (*store.Session, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	session, ok := c.sessions[token]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneSession(session), nil
+}
+
+// DeleteSession removes a session by token.
+func (c *Core) DeleteSession(_ context.Context, token string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.sessions[token]; !exists {
+		return store.ErrNotFound
+	}
+
+	delete(c.sessions, token)
+
+	return nil
+}
+
+// DeleteSessionsByUser removes every session belonging to userID.
+func (c *Core) DeleteSessionsByUser(_ context.Context, userID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	for token, session := range c.sessions {
+		if session.UserID == userID {
+			delete(c.sessions, token)
+		}
+	}
+
+	return nil
+}
+
+// DeleteExpiredSessions removes sessions that expired before now.
+func (c *Core) DeleteExpiredSessions(_ context.Context, now int64) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return 0, store.ErrClosed
+	}
+
+	var count int
+
+	for token, session := range c.sessions {
+		if session.ExpiresAt < now {
+			delete(c.sessions, token)
+
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// ListSessions returns every session.
+func (c *Core) ListSessions(_ context.Context) ([]*store.Session, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	sessions := make([]*store.Session, 0, len(c.sessions))
+	for _, session := range c.sessions {
+		sessions = append(sessions, cloneSession(session))
+	}
+
+	return sessions, nil
+}
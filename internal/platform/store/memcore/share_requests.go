@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package memcore
+
+import (
+	"context"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// CreateShareRequest stores a new share request.
+func (c *Core) CreateShareRequest(_ context.Context, req *store.ShareRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.shareRequests[req.ID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	c.shareRequests[req.ID] = cloneShareRequest(req)
+
+	return nil
+}
+
+// GetShareRequestForOwner retrieves a share request by id, scoped to an owner.
+func (c *Core) GetShareRequestForOwner(_ context.Context, id string, ownerUserID string) (*store.ShareRequest, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	req, ok := c.shareRequests[id]
+	if !ok || req.OwnerUserID != ownerUserID {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneShareRequest(req), nil
+}
+
+// UpdateShareRequestStatusForOwner sets the status (and approved share id) of
+// a share request, scoped to an owner.
+func (c *Core) UpdateShareRequestStatusForOwner(
+	_ context.Context,
+	id string,
+	ownerUserID string,
+	status string,
+	approvedShareID string,
+) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	req, ok := c.shareRequests[id]
+	if !ok || req.OwnerUserID != ownerUserID {
+		return store.ErrNotFound
+	}
+
+	req.Status = status
+	req.ApprovedShareID = approvedShareID
+	req.UpdatedAt = time.Now().Unix()
+
+	return nil
+}
+
+// ListShareRequests returns share requests for ownerUserID, or every share
+// request when ownerUserID is empty.
+func (c *Core) ListShareRequests(_ context.Context, ownerUserID string) ([]*store.ShareRequest, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	reqs := make([]*store.ShareRequest, 0, len(c.shareRequests))
+	for _, req := range c.shareRequests {
+		if ownerUserID == "" || req.OwnerUserID == ownerUserID {
+			reqs = append(reqs, cloneShareRequest(req))
+		}
+	}
+
+	return reqs, nil
+}
@@ -6,7 +6,9 @@
 package memcore
 
 import (
+	"cmp"
 	"context"
+	"slices"
 	"time"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
@@ -25,7 +27,7 @@ func (c *Core) CreateIncomingShare(_ context.Context, share *store.IncomingShare
 		return store.ErrAlreadyExists
 	}
 
-	key := providerKey(share.SenderHost, share.ProviderID)
+	key := providerKey(share.SenderHost, share.ProviderID, share.RecipientUserID)
 	if _, exists := c.providerIndex[key]; exists {
 		return store.ErrAlreadyExists
 	}
@@ -53,7 +55,8 @@ func (c *Core) GetIncomingShareByIDForRecipient(_ context.Context, shareID strin
 	return cloneIncomingShare(share), nil
 }
 
-// GetIncomingShareByProviderKey retrieves an incoming share by sending server and providerID.
+// GetIncomingShareByProviderKey retrieves the oldest incoming share for a
+// sending server and providerID.
 func (c *Core) GetIncomingShareByProviderKey(_ context.Context, senderHost, providerID string) (*store.IncomingShare, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -62,17 +65,54 @@ func (c *Core) GetIncomingShareByProviderKey(_ context.Context, senderHost, prov
 		return nil, store.ErrClosed
 	}
 
-	shareID, ok := c.providerIndex[providerKey(senderHost, providerID)]
-	if !ok {
+	shares := c.incomingSharesByProviderKey(senderHost, providerID)
+	if len(shares) == 0 {
 		return nil, store.ErrNotFound
 	}
 
-	share, ok := c.incomingShares[shareID]
-	if !ok {
-		return nil, store.ErrNotFound
+	return cloneIncomingShare(shares[0]), nil
+}
+
+// ListIncomingSharesByProviderKey returns every recipient's incoming share for
+// a sending server and providerID, oldest first.
+func (c *Core) ListIncomingSharesByProviderKey(_ context.Context, senderHost, providerID string) ([]*store.IncomingShare, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
 	}
 
-	return cloneIncomingShare(share), nil
+	matches := c.incomingSharesByProviderKey(senderHost, providerID)
+
+	shares := make([]*store.IncomingShare, 0, len(matches))
+	for _, share := range matches {
+		shares = append(shares, cloneIncomingShare(share))
+	}
+
+	return shares, nil
+}
+
+// incomingSharesByProviderKey returns the stored (uncloned) rows for a
+// provider key ordered by creation time, then shareID. Callers must hold c.mu.
+func (c *Core) incomingSharesByProviderKey(senderHost, providerID string) []*store.IncomingShare {
+	var matches []*store.IncomingShare
+
+	for _, share := range c.incomingShares {
+		if share.SenderHost == senderHost && share.ProviderID == providerID {
+			matches = append(matches, share)
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b *store.IncomingShare) int {
+		if n := cmp.Compare(a.CreatedAt, b.CreatedAt); n != 0 {
+			return n
+		}
+
+		return cmp.Compare(a.ShareID, b.ShareID)
+	})
+
+	return matches
 }
 
 // ListIncomingSharesByRecipient returns incoming shares for the given recipient user.
@@ -95,6 +135,27 @@ func (c *Core) ListIncomingSharesByRecipient(_ context.Context, recipientUserID
 	return shares, nil
 }
 
+// ListExpiringIncomingShares returns every recipient's incoming shares in one
+// of statuses whose expiration is set and not after now.
+func (c *Core) ListExpiringIncomingShares(_ context.Context, now int64, statuses []string) ([]*store.IncomingShare, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	shares := make([]*store.IncomingShare, 0)
+
+	for _, share := range c.incomingShares {
+		if share.Expiration > 0 && share.Expiration <= now && slices.Contains(statuses, share.Status) {
+			shares = append(shares, cloneIncomingShare(share))
+		}
+	}
+
+	return shares, nil
+}
+
 // UpdateIncomingShareStatusForRecipient updates the status of an incoming share, scoped to a recipient.
 func (c *Core) UpdateIncomingShareStatusForRecipient(_ context.Context, shareID string, recipientUserID string, status string) error {
 	c.mu.Lock()
@@ -129,7 +190,7 @@ func (c *Core) DeleteIncomingShareForRecipient(_ context.Context, shareID string
 		return store.ErrNotFound
 	}
 
-	delete(c.providerIndex, providerKey(share.SenderHost, share.ProviderID))
+	delete(c.providerIndex, providerKey(share.SenderHost, share.ProviderID, share.RecipientUserID))
 	delete(c.incomingShares, shareID)
 
 	return nil
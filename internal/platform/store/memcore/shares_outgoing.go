@@ -7,6 +7,7 @@ package memcore
 
 import (
 	"context"
+	"slices"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
 )
@@ -167,6 +168,30 @@ func (c *Core) GetOutgoingShareBySharedSecret(_ context.Context, sharedSecret st
 	return cloneOutgoingShare(share), nil
 }
 
+// GetOutgoingShareByWebappSharedSecret retrieves an outgoing share by its
+// webapp arm secret. It is only consulted when the WebDAV secret lookup
+// misses, so it scans instead of keeping a second index.
+func (c *Core) GetOutgoingShareByWebappSharedSecret(_ context.Context, webappSharedSecret string) (*store.OutgoingShare, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	if webappSharedSecret == "" {
+		return nil, store.ErrNotFound
+	}
+
+	for _, share := range c.outgoingShares {
+		if share.WebappSharedSecret == webappSharedSecret {
+			return cloneOutgoingShare(share), nil
+		}
+	}
+
+	return nil, store.ErrNotFound
+}
+
 // UpdateOutgoingShare updates an existing outgoing share.
 func (c *Core) UpdateOutgoingShare(_ context.Context, share *store.OutgoingShare) error {
 	c.mu.Lock()
@@ -249,3 +274,24 @@ func (c *Core) ListOutgoingShares(_ context.Context) ([]*store.OutgoingShare, er
 
 	return shares, nil
 }
+
+// ListExpiringOutgoingShares returns the outgoing shares in one of statuses
+// whose expiry is set and not after now.
+func (c *Core) ListExpiringOutgoingShares(_ context.Context, now int64, statuses []string) ([]*store.OutgoingShare, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	shares := make([]*store.OutgoingShare, 0)
+
+	for _, share := range c.outgoingShares {
+		if share.ExpiresAt > 0 && share.ExpiresAt <= now && slices.Contains(statuses, share.Status) {
+			shares = append(shares, cloneOutgoingShare(share))
+		}
+	}
+
+	return shares, nil
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package memcore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// CreateTransfer stores a new transfer.
+func (c *Core) CreateTransfer(_ context.Context, transfer *store.Transfer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.transfers[transfer.ShareID]; exists {
+		return store.ErrAlreadyExists
+	}
+
+	c.transfers[transfer.ShareID] = cloneTransfer(transfer)
+
+	return nil
+}
+
+// GetTransfer retrieves the transfer of an incoming share.
+func (c *Core) GetTransfer(_ context.Context, shareID string) (*store.Transfer, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	transfer, ok := c.transfers[shareID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return cloneTransfer(transfer), nil
+}
+
+// UpdateTransfer replaces an existing transfer.
+func (c *Core) UpdateTransfer(_ context.Context, transfer *store.Transfer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return store.ErrClosed
+	}
+
+	if _, exists := c.transfers[transfer.ShareID]; !exists {
+		return store.ErrNotFound
+	}
+
+	c.transfers[transfer.ShareID] = cloneTransfer(transfer)
+
+	return nil
+}
+
+// ListTransfers returns every transfer.
+func (c *Core) ListTransfers(_ context.Context) ([]*store.Transfer, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed {
+		return nil, store.ErrClosed
+	}
+
+	transfers := make([]*store.Transfer, 0, len(c.transfers))
+	for _, transfer := range c.transfers {
+		transfers = append(transfers, cloneTransfer(transfer))
+	}
+
+	return transfers, nil
+}
@@ -54,6 +54,38 @@ func TestMirrorDriverSecretRedaction(t *testing.T) {
 	tshttp.MustClose(t, driver)
 }
 
+// TestMirrorDriverIncomingSecretRedaction verifies that both the webdav and
+// the webapp secrets of an incoming share stay out of the JSON export.
+func TestMirrorDriverIncomingSecretRedaction(t *testing.T) {
+	t.Parallel()
+	tempDir := testutil.TempDataDir(t, "ocm-test-mirror-redact-in-*")
+
+	driver := testutil.OpenDriver(t, &store.DriverConfig{Driver: "mirror", DataDir: tempDir})
+
+	share := testutil.NewIncomingShareFixture()
+	share.SharedSecret = "incoming-webdav-secret"
+	share.WebappSharedSecret = "incoming-webapp-secret"
+
+	if err := requireIncomingShareStore(t, driver).CreateIncomingShare(context.Background(), share); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "mirror", "incoming_shares.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), share.ProviderID) {
+		t.Fatalf("incoming share missing from mirror JSON export: %s", data)
+	}
+
+	if strings.Contains(string(data), "incoming-webdav-secret") || strings.Contains(string(data), "incoming-webapp-secret") {
+		t.Errorf("shared secrets must not appear in mirror JSON export: %s", data)
+	}
+
+	tshttp.MustClose(t, driver)
+}
+
 // TestMirrorInviteExportOnInit verifies that Init exports both invite surfaces
 // to JSON and redacts invite tokens from exported files.
 func TestMirrorInviteExportOnInit(t *testing.T) {
@@ -113,3 +145,141 @@ func TestMirrorInviteExportOnInit(t *testing.T) {
 		t.Errorf("incoming_invites.json must not contain token %q", inInvite.Token)
 	}
 }
+
+// TestMirrorOutboxExportRedactsBody verifies that queued request bodies, which
+// carry share secrets, never reach the JSON mirror.
+func TestMirrorOutboxExportRedactsBody(t *testing.T) {
+	t.Parallel()
+	tempDir := testutil.TempDataDir(t, "ocm-test-mirror-outbox-*")
+
+	ctx := context.Background()
+
+	driver := testutil.OpenDriver(t, &store.DriverConfig{
+		Driver:  "mirror",
+		DataDir: tempDir,
+	})
+	defer tshttp.MustClose(t, driver)
+
+	outboxStore, ok := driver.(store.OutboxStore)
+	if !ok {
+		t.Fatal("mirror driver does not implement OutboxStore")
+	}
+
+	msg := &store.OutboxMessage{
+		ID:           "outbox-redact",
+		Topic:        "share",
+		Kind:         "shares",
+		TargetHost:   "receiver.example.com",
+		EndpointPath: "shares",
+		Body:         []byte(`{"sharedSecret":"queued-secret-value"}`),
+		Status:       "pending",
+	}
+	if err := outboxStore.CreateOutboxMessage(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "mirror", "outbox.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), "outbox-redact") {
+		t.Errorf("outbox export is missing the message: %s", data)
+	}
+
+	if strings.Contains(string(data), "queued-secret-value") || strings.Contains(string(data), `"body"`) {
+		t.Errorf("outbox export must not contain request bodies: %s", data)
+	}
+
+	got, err := outboxStore.GetOutboxMessage(ctx, msg.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got.Body) != string(msg.Body) {
+		t.Errorf("stored body = %q, want it unchanged by redaction", got.Body)
+	}
+}
+
+// TestMirrorIdentityExportRedactsCredentials verifies that password hashes and
+// session tokens never reach the JSON mirror while the stored rows keep them.
+func TestMirrorIdentityExportRedactsCredentials(t *testing.T) {
+	t.Parallel()
+	tempDir := testutil.TempDataDir(t, "ocm-test-mirror-identity-*")
+
+	ctx := context.Background()
+
+	driver := testutil.OpenDriver(t, &store.DriverConfig{
+		Driver:  "mirror",
+		DataDir: tempDir,
+	})
+	defer tshttp.MustClose(t, driver)
+
+	partyStore, ok := driver.(store.PartyStore)
+	if !ok {
+		t.Fatal("mirror driver does not implement PartyStore")
+	}
+
+	sessionStore, ok := driver.(store.SessionStore)
+	if !ok {
+		t.Fatal("mirror driver does not implement SessionStore")
+	}
+
+	party := &store.Party{
+		ID:           "party-redact",
+		Username:     "redact-user",
+		PasswordHash: "$2a$10$secret-password-hash",
+		Role:         "user",
+	}
+	if err := partyStore.CreateParty(ctx, party); err != nil {
+		t.Fatal(err)
+	}
+
+	session := &store.Session{
+		Token:     "live-session-token",
+		UserID:    party.ID,
+		ExpiresAt: 5000,
+	}
+	if err := sessionStore.CreateSession(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+
+	parties, err := os.ReadFile(filepath.Join(tempDir, "mirror", "parties.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(parties), "redact-user") {
+		t.Errorf("parties export is missing the party: %s", parties)
+	}
+
+	if strings.Contains(string(parties), "secret-password-hash") || strings.Contains(string(parties), `"passwordHash"`) {
+		t.Errorf("parties export must not contain password hashes: %s", parties)
+	}
+
+	sessions, err := os.ReadFile(filepath.Join(tempDir, "mirror", "sessions.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(sessions), party.ID) {
+		t.Errorf("sessions export is missing the session: %s", sessions)
+	}
+
+	if strings.Contains(string(sessions), session.Token) {
+		t.Errorf("sessions export must not contain session tokens: %s", sessions)
+	}
+
+	got, err := partyStore.GetParty(ctx, party.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.PasswordHash != party.PasswordHash {
+		t.Errorf("stored password hash = %q, want it unchanged by redaction", got.PasswordHash)
+	}
+
+	if _, err := sessionStore.GetSession(ctx, session.Token); err != nil {
+		t.Errorf("stored session lookup after export: %v", err)
+	}
+}
@@ -32,6 +32,17 @@ func requireOutgoingShareStore(t *testing.T, d store.Driver) store.OutgoingShare
 	return s
 }
 
+func requireIncomingShareStore(t *testing.T, d store.Driver) store.IncomingShareStore {
+	t.Helper()
+
+	s, ok := d.(store.IncomingShareStore)
+	if !ok {
+		t.Fatal("driver does not implement IncomingShareStore")
+	}
+
+	return s
+}
+
 func requireOutgoingInviteStore(t *testing.T, d store.Driver) store.OutgoingInviteStore {
 	t.Helper()
 
@@ -33,7 +33,7 @@ func tolerateDirSync(dir string) error {
 //   - Redaction is applied to in-memory copies only; stored rows are unchanged.
 // ----------------------------------------------------------------------------
 
-// exportAll exports all four persistence surfaces to JSON files.
+// exportAll exports every persistence surface to JSON files.
 // It holds mu for the duration so concurrent writes do not interleave exports.
 func (d *Driver) exportAll(ctx context.Context) error {
 	d.mu.Lock()
@@ -55,6 +55,38 @@ func (d *Driver) exportAll(ctx context.Context) error {
 		return err
 	}
 
+	if err := d.exportOutbox(ctx); err != nil {
+		return err
+	}
+
+	if err := d.exportParties(ctx); err != nil {
+		return err
+	}
+
+	if err := d.exportSessions(ctx); err != nil {
+		return err
+	}
+
+	if err := d.exportShareRequests(ctx); err != nil {
+		return err
+	}
+
+	if err := d.exportGroups(ctx); err != nil {
+		return err
+	}
+
+	if err := d.exportTransfers(ctx); err != nil {
+		return err
+	}
+
+	if err := d.exportPeerPolicyEntries(ctx); err != nil {
+		return err
+	}
+
+	if err := d.exportPeerTrustGroups(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -76,6 +108,7 @@ func (d *Driver) exportOutgoingShares(ctx context.Context) error {
 
 	for _, share := range shares {
 		share.SharedSecret = ""
+		share.WebappSharedSecret = ""
 	}
 
 	return d.writeJSON("outgoing_shares.json", shares)
@@ -90,6 +123,7 @@ func (d *Driver) exportIncomingShares(ctx context.Context) error {
 
 	for _, share := range shares {
 		share.SharedSecret = ""
+		share.WebappSharedSecret = ""
 	}
 
 	return d.writeJSON("incoming_shares.json", shares)
@@ -124,6 +158,106 @@ func (d *Driver) exportIncomingInvites(ctx context.Context) error {
 	return d.writeJSON("incoming_invites.json", invites)
 }
 
+// exportOutbox projects all outbox messages to JSON with request bodies
+// redacted; a queued share body carries the share's shared secret.
+func (d *Driver) exportOutbox(ctx context.Context) error {
+	msgs, err := d.core.ListOutboxMessages(ctx)
+	if err != nil {
+		return fmt.Errorf("store: list outbox messages: %w", err)
+	}
+
+	for _, msg := range msgs {
+		msg.Body = nil
+	}
+
+	return d.writeJSON("outbox.json", msgs)
+}
+
+// exportParties projects all parties to JSON with password hashes redacted.
+func (d *Driver) exportParties(ctx context.Context) error {
+	parties, err := d.core.ListParties(ctx, "")
+	if err != nil {
+		return fmt.Errorf("store: list parties: %w", err)
+	}
+
+	for _, party := range parties {
+		party.PasswordHash = ""
+	}
+
+	return d.writeJSON("parties.json", parties)
+}
+
+// exportSessions projects all sessions to JSON with tokens redacted; a
+// session token is a live login credential.
+func (d *Driver) exportSessions(ctx context.Context) error {
+	sessions, err := d.core.ListSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("store: list sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		session.Token = ""
+	}
+
+	return d.writeJSON("sessions.json", sessions)
+}
+
+// exportShareRequests projects all share requests to JSON; they carry no
+// secrets, so nothing is redacted.
+func (d *Driver) exportShareRequests(ctx context.Context) error {
+	// Empty ownerUserID means all requests; see sqlitecore.ListShareRequests.
+	reqs, err := d.core.ListShareRequests(ctx, "")
+	if err != nil {
+		return fmt.Errorf("store: list share requests: %w", err)
+	}
+
+	return d.writeJSON("share_requests.json", reqs)
+}
+
+// exportGroups projects all groups to JSON; they carry no secrets, so
+// nothing is redacted.
+func (d *Driver) exportGroups(ctx context.Context) error {
+	groups, err := d.core.ListGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("store: list groups: %w", err)
+	}
+
+	return d.writeJSON("groups.json", groups)
+}
+
+// exportTransfers projects all datatx transfers to JSON; they carry no
+// secrets, so nothing is redacted.
+func (d *Driver) exportTransfers(ctx context.Context) error {
+	transfers, err := d.core.ListTransfers(ctx)
+	if err != nil {
+		return fmt.Errorf("store: list transfers: %w", err)
+	}
+
+	return d.writeJSON("transfers.json", transfers)
+}
+
+// exportPeerPolicyEntries projects the runtime peer allow/deny list entries
+// to JSON; they carry no secrets, so nothing is redacted.
+func (d *Driver) exportPeerPolicyEntries(ctx context.Context) error {
+	entries, err := d.core.ListPeerPolicyEntries(ctx)
+	if err != nil {
+		return fmt.Errorf("store: list peer policy entries: %w", err)
+	}
+
+	return d.writeJSON("peer_policy_entries.json", entries)
+}
+
+// exportPeerTrustGroups projects the attached trust groups to JSON; their
+// configs hold directory URLs and public keys only, so nothing is redacted.
+func (d *Driver) exportPeerTrustGroups(ctx context.Context) error {
+	groups, err := d.core.ListPeerTrustGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("store: list peer trust groups: %w", err)
+	}
+
+	return d.writeJSON("peer_trust_groups.json", groups)
+}
+
 // writeJSON atomically writes data to a JSON file in the mirror directory.
 // It writes to a temp file, syncs, then renames to avoid partial reads.
 func (d *Driver) writeJSON(filename string, data any) error {
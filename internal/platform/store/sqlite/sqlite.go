@@ -21,8 +21,10 @@ func init() {
 }
 
 // Driver implements the store.Driver interface using the shared SQLite core.
-// It implements all four persistence surfaces: OutgoingShareStore,
-// IncomingShareStore, OutgoingInviteStore, and IncomingInviteStore.
+// It implements every persistence surface: OutgoingShareStore,
+// IncomingShareStore, OutgoingInviteStore, IncomingInviteStore, OutboxStore,
+// PartyStore, SessionStore, ShareRequestStore, GroupStore, TransferStore,
+// and PeerTrustStore.
 type Driver struct {
 	dataDir string
 	core    *sqlitecore.Core
@@ -112,6 +114,17 @@ func (d *Driver) GetOutgoingShareBySharedSecret(ctx context.Context, sharedSecre
 	return v, nil
 }
 
+// GetOutgoingShareByWebappSharedSecret retrieves an outgoing share by its
+// webapp arm secret.
+func (d *Driver) GetOutgoingShareByWebappSharedSecret(ctx context.Context, webappSharedSecret string) (*store.OutgoingShare, error) {
+	v, err := d.core.GetOutgoingShareByWebappSharedSecret(ctx, webappSharedSecret)
+	if err != nil {
+		return v, fmt.Errorf("store: get outgoing share by webapp shared secret: %w", err)
+	}
+
+	return v, nil
+}
+
 // UpdateOutgoingShare updates an existing outgoing share.
 func (d *Driver) UpdateOutgoingShare(ctx context.Context, share *store.OutgoingShare) error {
 	if err := d.core.UpdateOutgoingShare(ctx, share); err != nil {
@@ -140,6 +153,17 @@ func (d *Driver) ListOutgoingShares(ctx context.Context) ([]*store.OutgoingShare
 	return v, nil
 }
 
+// ListExpiringOutgoingShares returns the outgoing shares in one of statuses
+// whose expiry is set and not after now.
+func (d *Driver) ListExpiringOutgoingShares(ctx context.Context, now int64, statuses []string) ([]*store.OutgoingShare, error) {
+	v, err := d.core.ListExpiringOutgoingShares(ctx, now, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("store: list expiring outgoing shares: %w", err)
+	}
+
+	return v, nil
+}
+
 // CreateIncomingShare creates a new incoming share.
 func (d *Driver) CreateIncomingShare(ctx context.Context, share *store.IncomingShare) error {
 	if err := d.core.CreateIncomingShare(ctx, share); err != nil {
@@ -169,6 +193,17 @@ func (d *Driver) GetIncomingShareByProviderKey(ctx context.Context, sendingServe
 	return v, nil
 }
 
+// ListIncomingSharesByProviderKey returns every recipient's incoming share for
+// a sending server and provider id, oldest first.
+func (d *Driver) ListIncomingSharesByProviderKey(ctx context.Context, sendingServer, providerID string) ([]*store.IncomingShare, error) {
+	v, err := d.core.ListIncomingSharesByProviderKey(ctx, sendingServer, providerID)
+	if err != nil {
+		return nil, fmt.Errorf("store: list incoming shares by provider key: %w", err)
+	}
+
+	return v, nil
+}
+
 // ListIncomingSharesByRecipient returns incoming shares for the given recipient user.
 func (d *Driver) ListIncomingSharesByRecipient(ctx context.Context, recipientUserID string) ([]*store.IncomingShare, error) {
 	v, err := d.core.ListIncomingSharesByRecipient(ctx, recipientUserID)
@@ -179,6 +214,17 @@ func (d *Driver) ListIncomingSharesByRecipient(ctx context.Context, recipientUse
 	return v, nil
 }
 
+// ListExpiringIncomingShares returns every recipient's incoming shares in one
+// of statuses whose expiration is set and not after now.
+func (d *Driver) ListExpiringIncomingShares(ctx context.Context, now int64, statuses []string) ([]*store.IncomingShare, error) {
+	v, err := d.core.ListExpiringIncomingShares(ctx, now, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("store: list expiring incoming shares: %w", err)
+	}
+
+	return v, nil
+}
+
 // UpdateIncomingShareStatusForRecipient updates the status of an incoming share scoped to a recipient.
 func (d *Driver) UpdateIncomingShareStatusForRecipient(ctx context.Context, shareID string, recipientUserID string, status string) error {
 	if err := d.core.UpdateIncomingShareStatusForRecipient(ctx, shareID, recipientUserID, status); err != nil {
@@ -254,6 +300,17 @@ func (d *Driver) ListOutgoingInvites(ctx context.Context, userID string) ([]*sto
 	return v, nil
 }
 
+// ListExpiringOutgoingInvites returns the outgoing invites of every user in
+// one of statuses whose expiry is set and not after now.
+func (d *Driver) ListExpiringOutgoingInvites(ctx context.Context, now int64, statuses []string) ([]*store.OutgoingInvite, error) {
+	v, err := d.core.ListExpiringOutgoingInvites(ctx, now, statuses)
+	if err != nil {
+		return nil, fmt.Errorf("store: list expiring outgoing invites: %w", err)
+	}
+
+	return v, nil
+}
+
 // CreateIncomingInvite creates a new incoming invite.
 func (d *Driver) CreateIncomingInvite(ctx context.Context, invite *store.IncomingInvite) error {
 	if err := d.core.CreateIncomingInvite(ctx, invite); err != nil {
@@ -311,9 +368,404 @@ func (d *Driver) ListIncomingInvites(ctx context.Context, recipientUserID string
 	return v, nil
 }
 
+// CreateOutboxMessage queues a new outbox message.
+func (d *Driver) CreateOutboxMessage(ctx context.Context, msg *store.OutboxMessage) error {
+	if err := d.core.CreateOutboxMessage(ctx, msg); err != nil {
+		return fmt.Errorf("store: create outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// GetOutboxMessage retrieves an outbox message by id.
+func (d *Driver) GetOutboxMessage(ctx context.Context, id string) (*store.OutboxMessage, error) {
+	msg, err := d.core.GetOutboxMessage(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("store: get outbox message: %w", err)
+	}
+
+	return msg, nil
+}
+
+// UpdateOutboxMessage replaces an existing outbox message.
+func (d *Driver) UpdateOutboxMessage(ctx context.Context, msg *store.OutboxMessage) error {
+	if err := d.core.UpdateOutboxMessage(ctx, msg); err != nil {
+		return fmt.Errorf("store: update outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOutboxMessage removes an outbox message.
+func (d *Driver) DeleteOutboxMessage(ctx context.Context, id string) error {
+	if err := d.core.DeleteOutboxMessage(ctx, id); err != nil {
+		return fmt.Errorf("store: delete outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// ListDueOutboxMessages returns pending outbox messages due at or before now.
+func (d *Driver) ListDueOutboxMessages(ctx context.Context, now int64, limit int) ([]*store.OutboxMessage, error) {
+	msgs, err := d.core.ListDueOutboxMessages(ctx, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("store: list due outbox messages: %w", err)
+	}
+
+	return msgs, nil
+}
+
+// ListOutboxMessages returns every outbox message.
+func (d *Driver) ListOutboxMessages(ctx context.Context) ([]*store.OutboxMessage, error) {
+	msgs, err := d.core.ListOutboxMessages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("store: list outbox messages: %w", err)
+	}
+
+	return msgs, nil
+}
+
+// CreateParty creates a new party.
+func (d *Driver) CreateParty(ctx context.Context, party *store.Party) error {
+	if err := d.core.CreateParty(ctx, party); err != nil {
+		return fmt.Errorf("store: create party: %w", err)
+	}
+
+	return nil
+}
+
+// GetParty retrieves a party by id.
+func (d *Driver) GetParty(ctx context.Context, id string) (*store.Party, error) {
+	party, err := d.core.GetParty(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("store: get party: %w", err)
+	}
+
+	return party, nil
+}
+
+// GetPartyByUsername retrieves a party by username.
+func (d *Driver) GetPartyByUsername(ctx context.Context, username string) (*store.Party, error) {
+	party, err := d.core.GetPartyByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("store: get party by username: %w", err)
+	}
+
+	return party, nil
+}
+
+// GetPartyByEmail retrieves a party by normalized email.
+func (d *Driver) GetPartyByEmail(ctx context.Context, emailNormalized string) (*store.Party, error) {
+	party, err := d.core.GetPartyByEmail(ctx, emailNormalized)
+	if err != nil {
+		return nil, fmt.Errorf("store: get party by email: %w", err)
+	}
+
+	return party, nil
+}
+
+// UpdateParty replaces an existing party.
+func (d *Driver) UpdateParty(ctx context.Context, party *store.Party) error {
+	if err := d.core.UpdateParty(ctx, party); err != nil {
+		return fmt.Errorf("store: update party: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteParty removes a party.
+func (d *Driver) DeleteParty(ctx context.Context, id string) error {
+	if err := d.core.DeleteParty(ctx, id); err != nil {
+		return fmt.Errorf("store: delete party: %w", err)
+	}
+
+	return nil
+}
+
+// ListParties returns parties in realm, or every party when realm is empty.
+func (d *Driver) ListParties(ctx context.Context, realm string) ([]*store.Party, error) {
+	parties, err := d.core.ListParties(ctx, realm)
+	if err != nil {
+		return nil, fmt.Errorf("store: list parties: %w", err)
+	}
+
+	return parties, nil
+}
+
+// DeleteExpiredParties removes parties that expired before now.
+func (d *Driver) DeleteExpiredParties(ctx context.Context, now int64) (int, error) {
+	n, err := d.core.DeleteExpiredParties(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("store: delete expired parties: %w", err)
+	}
+
+	return n, nil
+}
+
+// CreateSession stores a new session.
+func (d *Driver) CreateSession(ctx context.Context, session *store.Session) error {
+	if err := d.core.CreateSession(ctx, session); err != nil {
+		return fmt.Errorf("store: create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetSession retrieves a session by token.
+func (d *Driver) GetSession(ctx context.Context, token string) (*store.Session, error) {
+	session, err := d.core.GetSession(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("store: get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// DeleteSession removes a session by token.
+func (d *Driver) DeleteSession(ctx context.Context, token string) error {
+	if err := d.core.DeleteSession(ctx, token); err != nil {
+		return fmt.Errorf("store: delete session: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSessionsByUser removes every session belonging to userID.
+func (d *Driver) DeleteSessionsByUser(ctx context.Context, userID string) error {
+	if err := d.core.DeleteSessionsByUser(ctx, userID); err != nil {
+		return fmt.Errorf("store: delete user sessions: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredSessions removes sessions that expired before now.
+func (d *Driver) DeleteExpiredSessions(ctx context.Context, now int64) (int, error) {
+	n, err := d.core.DeleteExpiredSessions(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("store: delete expired sessions: %w", err)
+	}
+
+	return n, nil
+}
+
+// ListSessions returns every session.
+func (d *Driver) ListSessions(ctx context.Context) ([]*store.Session, error) {
+	sessions, err := d.core.ListSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("store: list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// CreateShareRequest stores a new share request.
+func (d *Driver) CreateShareRequest(ctx context.Context, req *store.ShareRequest) error {
+	if err := d.core.CreateShareRequest(ctx, req); err != nil {
+		return fmt.Errorf("store: create share request: %w", err)
+	}
+
+	return nil
+}
+
+// GetShareRequestForOwner retrieves a share request by id, scoped to an owner.
+func (d *Driver) GetShareRequestForOwner(ctx context.Context, id string, ownerUserID string) (*store.ShareRequest, error) {
+	req, err := d.core.GetShareRequestForOwner(ctx, id, ownerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("store: get share request: %w", err)
+	}
+
+	return req, nil
+}
+
+// UpdateShareRequestStatusForOwner sets the status (and approved share id) of
+// a share request, scoped to an owner.
+func (d *Driver) UpdateShareRequestStatusForOwner(
+	ctx context.Context,
+	id string,
+	ownerUserID string,
+	status string,
+	approvedShareID string,
+) error {
+	if err := d.core.UpdateShareRequestStatusForOwner(ctx, id, ownerUserID, status, approvedShareID); err != nil {
+		return fmt.Errorf("store: update share request status: %w", err)
+	}
+
+	return nil
+}
+
+// ListShareRequests returns share requests for ownerUserID, or every share
+// request when ownerUserID is empty.
+func (d *Driver) ListShareRequests(ctx context.Context, ownerUserID string) ([]*store.ShareRequest, error) {
+	reqs, err := d.core.ListShareRequests(ctx, ownerUserID)
+	if err != nil {
+		return nil, fmt.Errorf("store: list share requests: %w", err)
+	}
+
+	return reqs, nil
+}
+
+// CreateGroup stores a new group.
+func (d *Driver) CreateGroup(ctx context.Context, group *store.Group) error {
+	if err := d.core.CreateGroup(ctx, group); err != nil {
+		return fmt.Errorf("store: create group: %w", err)
+	}
+
+	return nil
+}
+
+// GetGroup retrieves a group by id.
+func (d *Driver) GetGroup(ctx context.Context, id string) (*store.Group, error) {
+	group, err := d.core.GetGroup(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("store: get group: %w", err)
+	}
+
+	return group, nil
+}
+
+// GetGroupByName retrieves a group by its unique name.
+func (d *Driver) GetGroupByName(ctx context.Context, name string) (*store.Group, error) {
+	group, err := d.core.GetGroupByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("store: get group by name: %w", err)
+	}
+
+	return group, nil
+}
+
+// UpdateGroup replaces an existing group.
+func (d *Driver) UpdateGroup(ctx context.Context, group *store.Group) error {
+	if err := d.core.UpdateGroup(ctx, group); err != nil {
+		return fmt.Errorf("store: update group: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteGroup removes a group.
+func (d *Driver) DeleteGroup(ctx context.Context, id string) error {
+	if err := d.core.DeleteGroup(ctx, id); err != nil {
+		return fmt.Errorf("store: delete group: %w", err)
+	}
+
+	return nil
+}
+
+// ListGroups returns every group.
+func (d *Driver) ListGroups(ctx context.Context) ([]*store.Group, error) {
+	groups, err := d.core.ListGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("store: list groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// CreateTransfer stores a new transfer.
+func (d *Driver) CreateTransfer(ctx context.Context, transfer *store.Transfer) error {
+	if err := d.core.CreateTransfer(ctx, transfer); err != nil {
+		return fmt.Errorf("store: create transfer: %w", err)
+	}
+
+	return nil
+}
+
+// GetTransfer retrieves the transfer of an incoming share.
+func (d *Driver) GetTransfer(ctx context.Context, shareID string) (*store.Transfer, error) {
+	transfer, err := d.core.GetTransfer(ctx, shareID)
+	if err != nil {
+		return nil, fmt.Errorf("store: get transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// UpdateTransfer replaces an existing transfer.
+func (d *Driver) UpdateTransfer(ctx context.Context, transfer *store.Transfer) error {
+	if err := d.core.UpdateTransfer(ctx, transfer); err != nil {
+		return fmt.Errorf("store: update transfer: %w", err)
+	}
+
+	return nil
+}
+
+// ListTransfers returns every transfer.
+func (d *Driver) ListTransfers(ctx context.Context) ([]*store.Transfer, error) {
+	transfers, err := d.core.ListTransfers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("store: list transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// CreatePeerPolicyEntry stores a new allow/deny list entry.
+func (d *Driver) CreatePeerPolicyEntry(ctx context.Context, entry *store.PeerPolicyEntry) error {
+	if err := d.core.CreatePeerPolicyEntry(ctx, entry); err != nil {
+		return fmt.Errorf("store: create peer policy entry: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePeerPolicyEntry removes an allow/deny list entry.
+func (d *Driver) DeletePeerPolicyEntry(ctx context.Context, list, host string) error {
+	if err := d.core.DeletePeerPolicyEntry(ctx, list, host); err != nil {
+		return fmt.Errorf("store: delete peer policy entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListPeerPolicyEntries returns every allow/deny list entry.
+func (d *Driver) ListPeerPolicyEntries(ctx context.Context) ([]*store.PeerPolicyEntry, error) {
+	entries, err := d.core.ListPeerPolicyEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("store: list peer policy entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CreatePeerTrustGroup stores a new attached trust group.
+func (d *Driver) CreatePeerTrustGroup(ctx context.Context, group *store.PeerTrustGroup) error {
+	if err := d.core.CreatePeerTrustGroup(ctx, group); err != nil {
+		return fmt.Errorf("store: create peer trust group: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePeerTrustGroup removes an attached trust group.
+func (d *Driver) DeletePeerTrustGroup(ctx context.Context, trustGroupID string) error {
+	if err := d.core.DeletePeerTrustGroup(ctx, trustGroupID); err != nil {
+		return fmt.Errorf("store: delete peer trust group: %w", err)
+	}
+
+	return nil
+}
+
+// ListPeerTrustGroups returns every attached trust group.
+func (d *Driver) ListPeerTrustGroups(ctx context.Context) ([]*store.PeerTrustGroup, error) {
+	groups, err := d.core.ListPeerTrustGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("store: list peer trust groups: %w", err)
+	}
+
+	return groups, nil
+}
+
 // Compile-time interface checks.
 var _ store.Driver = (*Driver)(nil)
 var _ store.OutgoingShareStore = (*Driver)(nil)
 var _ store.IncomingShareStore = (*Driver)(nil)
 var _ store.OutgoingInviteStore = (*Driver)(nil)
 var _ store.IncomingInviteStore = (*Driver)(nil)
+var _ store.OutboxStore = (*Driver)(nil)
+var _ store.PartyStore = (*Driver)(nil)
+var _ store.SessionStore = (*Driver)(nil)
+var _ store.ShareRequestStore = (*Driver)(nil)
+var _ store.GroupStore = (*Driver)(nil)
+var _ store.TransferStore = (*Driver)(nil)
+var _ store.PeerTrustStore = (*Driver)(nil)
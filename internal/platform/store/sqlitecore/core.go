@@ -5,7 +5,7 @@
 
 // Package sqlitecore is the private shared SQLite/GORM persistence engine used
 // by the sqlite and mirror drivers. It owns DB lifecycle (open/migrate/close)
-// and the full four-surface CRUD layer. Driver-specific behaviour (JSON export,
+// and the full eleven-surface CRUD layer. Driver-specific behaviour (JSON export,
 // secret redaction) lives in the drivers themselves and is not part of this
 // package.
 package sqlitecore
@@ -25,13 +25,13 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
 )
 
-// Core holds an open GORM/SQLite handle and provides the full four-surface
+// Core holds an open GORM/SQLite handle and provides the full eleven-surface
 // CRUD layer. Lifecycle: Open -> CRUD -> Close.
 type Core struct {
 	db *gorm.DB
 }
 
-// Open opens (or creates) ocm.db under dataDir, runs AutoMigrate for all four
+// Open opens (or creates) ocm.db under dataDir, runs AutoMigrate for all twelve
 // persistence models, and returns a ready Core. The caller owns the Core and
 // must call Close when done.
 func Open(dataDir string) (*Core, error) {
@@ -63,12 +63,7 @@ func Open(dataDir string) (*Core, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	if migrErr := db.AutoMigrate(
-		&store.OutgoingShare{},
-		&store.IncomingShare{},
-		&store.OutgoingInvite{},
-		&store.IncomingInvite{},
-	); migrErr != nil {
+	if migrErr := migrate(db); migrErr != nil {
 		migrErr = fmt.Errorf("failed to migrate database: %w", migrErr)
 		if sqlDB, dbErr := db.DB(); dbErr != nil {
 			return nil, errors.Join(migrErr, dbErr)
@@ -82,6 +77,39 @@ func Open(dataDir string) (*Core, error) {
 	return &Core{db: db}, nil
 }
 
+// legacyIncomingShareProviderKeyIndex is the pre-group-share unique index on
+// (sender_host, provider_id). It blocks group fan-out, so migrate drops it
+// once the recipient-scoped replacement exists.
+const legacyIncomingShareProviderKeyIndex = "idx_incoming_shares_provider_key"
+
+func migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(
+		&store.OutgoingShare{},
+		&store.IncomingShare{},
+		&store.OutgoingInvite{},
+		&store.IncomingInvite{},
+		&store.OutboxMessage{},
+		&store.Party{},
+		&store.Session{},
+		&store.ShareRequest{},
+		&store.Group{},
+		&store.Transfer{},
+		&store.PeerPolicyEntry{},
+		&store.PeerTrustGroup{},
+	); err != nil {
+		return err
+	}
+
+	migrator := db.Migrator()
+	if migrator.HasIndex(&store.IncomingShare{}, legacyIncomingShareProviderKeyIndex) {
+		if err := migrator.DropIndex(&store.IncomingShare{}, legacyIncomingShareProviderKeyIndex); err != nil {
+			return fmt.Errorf("drop %s: %w", legacyIncomingShareProviderKeyIndex, err)
+		}
+	}
+
+	return nil
+}
+
 // Close releases the underlying database connection. Safe to call on a nil Core.
 func (c *Core) Close() error {
 	if c == nil || c.db == nil {
@@ -188,6 +216,23 @@ func (c *Core) GetOutgoingShareBySharedSecret(ctx context.Context, sharedSecret
 	return &share, nil
 }
 
+// GetOutgoingShareByWebappSharedSecret retrieves an outgoing share by its
+// webapp arm secret.
+func (c *Core) GetOutgoingShareByWebappSharedSecret(ctx context.Context, webappSharedSecret string) (*store.OutgoingShare, error) {
+	if webappSharedSecret == "" {
+		return nil, store.ErrNotFound
+	}
+
+	var share store.OutgoingShare
+
+	result := c.db.WithContext(ctx).First(&share, "webapp_shared_secret = ?", webappSharedSecret)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &share, nil
+}
+
 // UpdateOutgoingShare updates an existing outgoing share.
 // Returns ErrNotFound when no row matches the ProviderID (prevents silent upsert).
 // Uses a single UPDATE statement so there is no TOCTOU race between existence
@@ -233,6 +278,19 @@ func (c *Core) ListOutgoingShares(ctx context.Context) ([]*store.OutgoingShare,
 	return shares, nil
 }
 
+// ListExpiringOutgoingShares returns the outgoing shares in one of statuses
+// whose expiry is set and not after now.
+func (c *Core) ListExpiringOutgoingShares(ctx context.Context, now int64, statuses []string) ([]*store.OutgoingShare, error) {
+	var shares []*store.OutgoingShare
+	if err := c.db.WithContext(ctx).
+		Where("expires_at > 0 AND expires_at <= ? AND status IN ?", now, statuses).
+		Find(&shares).Error; err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
+
 // ----------------------------------------------------------------------------
 // IncomingShare CRUD
 // ----------------------------------------------------------------------------
@@ -258,11 +316,14 @@ func (c *Core) GetIncomingShareByIDForRecipient(ctx context.Context, shareID str
 	return &share, nil
 }
 
-// GetIncomingShareByProviderKey retrieves an incoming share by sending server and providerID.
+// GetIncomingShareByProviderKey retrieves the oldest incoming share for a
+// sending server and providerID.
 func (c *Core) GetIncomingShareByProviderKey(ctx context.Context, senderHost, providerID string) (*store.IncomingShare, error) {
 	var share store.IncomingShare
 
-	result := c.db.WithContext(ctx).First(&share, "sender_host = ? AND provider_id = ?", senderHost, providerID)
+	result := c.db.WithContext(ctx).
+		Order("created_at, share_id").
+		First(&share, "sender_host = ? AND provider_id = ?", senderHost, providerID)
 	if result.Error != nil {
 		return nil, normNotFound(result.Error)
 	}
@@ -270,6 +331,21 @@ func (c *Core) GetIncomingShareByProviderKey(ctx context.Context, senderHost, pr
 	return &share, nil
 }
 
+// ListIncomingSharesByProviderKey returns every recipient's incoming share for
+// a sending server and providerID, oldest first.
+func (c *Core) ListIncomingSharesByProviderKey(ctx context.Context, senderHost, providerID string) ([]*store.IncomingShare, error) {
+	var shares []*store.IncomingShare
+
+	if err := c.db.WithContext(ctx).
+		Where("sender_host = ? AND provider_id = ?", senderHost, providerID).
+		Order("created_at, share_id").
+		Find(&shares).Error; err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
+
 // ListIncomingSharesByRecipient returns incoming shares for the given recipient user.
 func (c *Core) ListIncomingSharesByRecipient(ctx context.Context, recipientUserID string) ([]*store.IncomingShare, error) {
 	var shares []*store.IncomingShare
@@ -280,6 +356,19 @@ func (c *Core) ListIncomingSharesByRecipient(ctx context.Context, recipientUserI
 	return shares, nil
 }
 
+// ListExpiringIncomingShares returns every recipient's incoming shares in one
+// of statuses whose expiration is set and not after now.
+func (c *Core) ListExpiringIncomingShares(ctx context.Context, now int64, statuses []string) ([]*store.IncomingShare, error) {
+	var shares []*store.IncomingShare
+	if err := c.db.WithContext(ctx).
+		Where("expiration > 0 AND expiration <= ? AND status IN ?", now, statuses).
+		Find(&shares).Error; err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
+
 // UpdateIncomingShareStatusForRecipient updates the status of an incoming share scoped to a
 // recipient. Only status and updated_at are written; other fields are not changed here.
 func (c *Core) UpdateIncomingShareStatusForRecipient(ctx context.Context, shareID string, recipientUserID string, status string) error {
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sqlitecore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// ----------------------------------------------------------------------------
+// Group CRUD
+// ----------------------------------------------------------------------------
+
+// CreateGroup stores a new group.
+func (c *Core) CreateGroup(ctx context.Context, group *store.Group) error {
+	if err := c.db.WithContext(ctx).Create(group).Error; err != nil {
+		return normWrite(err)
+	}
+
+	return nil
+}
+
+// GetGroup retrieves a group by id.
+func (c *Core) GetGroup(ctx context.Context, id string) (*store.Group, error) {
+	var group store.Group
+
+	result := c.db.WithContext(ctx).First(&group, "id = ?", id)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &group, nil
+}
+
+// GetGroupByName retrieves a group by its unique name.
+func (c *Core) GetGroupByName(ctx context.Context, name string) (*store.Group, error) {
+	var group store.Group
+
+	result := c.db.WithContext(ctx).First(&group, "name = ?", name)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &group, nil
+}
+
+// UpdateGroup replaces an existing group.
+func (c *Core) UpdateGroup(ctx context.Context, group *store.Group) error {
+	result := c.db.WithContext(ctx). //nolint:unqueryvet // intentional: select all columns for this GORM Updates chain; column list is intentionally open
+						Model(&store.Group{}).
+						Where("id = ?", group.ID).
+						Select("*").
+						Updates(group)
+	if result.Error != nil {
+		return normWrite(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteGroup removes a group.
+func (c *Core) DeleteGroup(ctx context.Context, id string) error {
+	result := c.db.WithContext(ctx).Delete(&store.Group{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListGroups returns every group.
+func (c *Core) ListGroups(ctx context.Context) ([]*store.Group, error) {
+	var groups []*store.Group
+	if err := c.db.WithContext(ctx).Find(&groups).Error; err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
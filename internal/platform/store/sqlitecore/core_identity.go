@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sqlitecore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// ----------------------------------------------------------------------------
+// Party CRUD
+// ----------------------------------------------------------------------------
+
+// CreateParty creates a new party. Username and non-empty normalized email
+// collisions surface as store.ErrAlreadyExists via the unique indexes.
+func (c *Core) CreateParty(ctx context.Context, party *store.Party) error {
+	if err := c.db.WithContext(ctx).Create(party).Error; err != nil {
+		return normWrite(err)
+	}
+
+	return nil
+}
+
+// GetParty retrieves a party by id.
+func (c *Core) GetParty(ctx context.Context, id string) (*store.Party, error) {
+	var party store.Party
+
+	result := c.db.WithContext(ctx).First(&party, "id = ?", id)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &party, nil
+}
+
+// GetPartyByUsername retrieves a party by username.
+func (c *Core) GetPartyByUsername(ctx context.Context, username string) (*store.Party, error) {
+	var party store.Party
+
+	result := c.db.WithContext(ctx).First(&party, "username = ?", username)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &party, nil
+}
+
+// GetPartyByEmail retrieves a party by normalized email. An empty key never
+// matches.
+func (c *Core) GetPartyByEmail(ctx context.Context, emailNormalized string) (*store.Party, error) {
+	if emailNormalized == "" {
+		return nil, store.ErrNotFound
+	}
+
+	var party store.Party
+
+	result := c.db.WithContext(ctx).First(&party, "email_normalized = ?", emailNormalized)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &party, nil
+}
+
+// UpdateParty replaces an existing party.
+// Returns ErrNotFound when no row matches the id (prevents silent upsert).
+func (c *Core) UpdateParty(ctx context.Context, party *store.Party) error {
+	result := c.db.WithContext(ctx). //nolint:unqueryvet // intentional: select all columns for this GORM Updates chain; column list is intentionally open
+						Model(&store.Party{}).
+						Where("id = ?", party.ID).
+						Select("*").
+						Updates(party)
+	if result.Error != nil {
+		return normWrite(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteParty removes a party.
+func (c *Core) DeleteParty(ctx context.Context, id string) error {
+	result := c.db.WithContext(ctx).Delete(&store.Party{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListParties returns parties in realm, or every party when realm is empty.
+func (c *Core) ListParties(ctx context.Context, realm string) ([]*store.Party, error) {
+	query := c.db.WithContext(ctx)
+	if realm != "" {
+		query = query.Where("realm = ?", realm)
+	}
+
+	var parties []*store.Party
+	if err := query.Find(&parties).Error; err != nil {
+		return nil, err
+	}
+
+	return parties, nil
+}
+
+// DeleteExpiredParties removes parties that expired before now.
+func (c *Core) DeleteExpiredParties(ctx context.Context, now int64) (int, error) {
+	result := c.db.WithContext(ctx).Delete(&store.Party{}, "expires_at <> 0 AND expires_at < ?", now)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// ----------------------------------------------------------------------------
+// Session CRUD
+// ----------------------------------------------------------------------------
+
+// CreateSession stores a new session.
+func (c *Core) CreateSession(ctx context.Context, session *store.Session) error {
+	if err := c.db.WithContext(ctx).Create(session).Error; err != nil {
+		return normWrite(err)
+	}
+
+	return nil
+}
+
+// GetSession retrieves a session by token.
+func (c *Core) GetSession(ctx context.Context, token string) (*store.Session, error) {
+	var session store.Session
+
+	result := c.db.WithContext(ctx).First(&session, "token = ?", token)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &session, nil
+}
+
+// DeleteSession removes a session by token.
+func (c *Core) DeleteSession(ctx context.Context, token string) error {
+	result := c.db.WithContext(ctx).Delete(&store.Session{}, "token = ?", token)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteSessionsByUser removes every session belonging to userID.
+func (c *Core) DeleteSessionsByUser(ctx context.Context, userID string) error {
+	return c.db.WithContext(ctx).Delete(&store.Session{}, "user_id = ?", userID).Error
+}
+
+// DeleteExpiredSessions removes sessions that expired before now.
+func (c *Core) DeleteExpiredSessions(ctx context.Context, now int64) (int, error) {
+	result := c.db.WithContext(ctx).Delete(&store.Session{}, "expires_at < ?", now)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// ListSessions returns every session.
+func (c *Core) ListSessions(ctx context.Context) ([]*store.Session, error) {
+	var sessions []*store.Session
+	if err := c.db.WithContext(ctx).Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
@@ -139,6 +139,19 @@ func (c *Core) ListOutgoingInvites(ctx context.Context, userID string) ([]*store
 	return invites, nil
 }
 
+// ListExpiringOutgoingInvites returns the outgoing invites of every user in
+// one of statuses whose expiry is set and not after now.
+func (c *Core) ListExpiringOutgoingInvites(ctx context.Context, now int64, statuses []string) ([]*store.OutgoingInvite, error) {
+	var invites []*store.OutgoingInvite
+	if err := c.db.WithContext(ctx).
+		Where("expires_at > 0 AND expires_at <= ? AND status IN ?", now, statuses).
+		Find(&invites).Error; err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
 // ----------------------------------------------------------------------------
 // IncomingInvite CRUD
 // ----------------------------------------------------------------------------
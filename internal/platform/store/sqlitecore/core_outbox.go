@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sqlitecore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// ----------------------------------------------------------------------------
+// Outbox CRUD
+// ----------------------------------------------------------------------------
+
+// CreateOutboxMessage queues a new outbox message.
+func (c *Core) CreateOutboxMessage(ctx context.Context, msg *store.OutboxMessage) error {
+	if err := c.db.WithContext(ctx).Create(msg).Error; err != nil {
+		return normWrite(err)
+	}
+
+	return nil
+}
+
+// GetOutboxMessage retrieves an outbox message by id.
+func (c *Core) GetOutboxMessage(ctx context.Context, id string) (*store.OutboxMessage, error) {
+	var msg store.OutboxMessage
+
+	result := c.db.WithContext(ctx).First(&msg, "id = ?", id)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &msg, nil
+}
+
+// UpdateOutboxMessage replaces an existing outbox message.
+// Returns ErrNotFound when no row matches the id (prevents silent upsert).
+func (c *Core) UpdateOutboxMessage(ctx context.Context, msg *store.OutboxMessage) error {
+	result := c.db.WithContext(ctx). //nolint:unqueryvet // intentional: select all columns for this GORM Updates chain; column list is intentionally open
+						Model(&store.OutboxMessage{}).
+						Where("id = ?", msg.ID).
+						Select("*").
+						Updates(msg)
+	if result.Error != nil {
+		return normWrite(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteOutboxMessage removes an outbox message.
+func (c *Core) DeleteOutboxMessage(ctx context.Context, id string) error {
+	result := c.db.WithContext(ctx).Delete(&store.OutboxMessage{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListDueOutboxMessages returns pending messages due at or before now, oldest
+// due first.
+func (c *Core) ListDueOutboxMessages(ctx context.Context, now int64, limit int) ([]*store.OutboxMessage, error) {
+	query := c.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", string(outbox.StatusPending), now).
+		Order("next_attempt_at, id")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var msgs []*store.OutboxMessage
+	if err := query.Find(&msgs).Error; err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+// ListOutboxMessages returns every outbox message.
+func (c *Core) ListOutboxMessages(ctx context.Context) ([]*store.OutboxMessage, error) {
+	var msgs []*store.OutboxMessage
+	if err := c.db.WithContext(ctx).Find(&msgs).Error; err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
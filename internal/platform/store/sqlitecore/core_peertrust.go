@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sqlitecore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// ----------------------------------------------------------------------------
+// Peer trust CRUD
+// ----------------------------------------------------------------------------
+
+// CreatePeerPolicyEntry stores a new allow/deny list entry.
+func (c *Core) CreatePeerPolicyEntry(ctx context.Context, entry *store.PeerPolicyEntry) error {
+	if err := c.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return normWrite(err)
+	}
+
+	return nil
+}
+
+// DeletePeerPolicyEntry removes an allow/deny list entry.
+func (c *Core) DeletePeerPolicyEntry(ctx context.Context, list, host string) error {
+	result := c.db.WithContext(ctx).Delete(&store.PeerPolicyEntry{}, "list = ? AND host = ?", list, host)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListPeerPolicyEntries returns every allow/deny list entry.
+func (c *Core) ListPeerPolicyEntries(ctx context.Context) ([]*store.PeerPolicyEntry, error) {
+	var entries []*store.PeerPolicyEntry
+	if err := c.db.WithContext(ctx).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CreatePeerTrustGroup stores a new attached trust group.
+func (c *Core) CreatePeerTrustGroup(ctx context.Context, group *store.PeerTrustGroup) error {
+	if err := c.db.WithContext(ctx).Create(group).Error; err != nil {
+		return normWrite(err)
+	}
+
+	return nil
+}
+
+// DeletePeerTrustGroup removes an attached trust group.
+func (c *Core) DeletePeerTrustGroup(ctx context.Context, trustGroupID string) error {
+	result := c.db.WithContext(ctx).Delete(&store.PeerTrustGroup{}, "trust_group_id = ?", trustGroupID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListPeerTrustGroups returns every attached trust group.
+func (c *Core) ListPeerTrustGroups(ctx context.Context) ([]*store.PeerTrustGroup, error) {
+	var groups []*store.PeerTrustGroup
+	if err := c.db.WithContext(ctx).Find(&groups).Error; err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
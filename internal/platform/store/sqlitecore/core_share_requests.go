@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sqlitecore
+
+import (
+	"context"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// ----------------------------------------------------------------------------
+// ShareRequest CRUD
+// ----------------------------------------------------------------------------
+
+// CreateShareRequest stores a new share request.
+func (c *Core) CreateShareRequest(ctx context.Context, req *store.ShareRequest) error {
+	if err := c.db.WithContext(ctx).Create(req).Error; err != nil {
+		return normWrite(err)
+	}
+
+	return nil
+}
+
+// GetShareRequestForOwner retrieves a share request by id, scoped to an owner.
+func (c *Core) GetShareRequestForOwner(ctx context.Context, id string, ownerUserID string) (*store.ShareRequest, error) {
+	var req store.ShareRequest
+
+	result := c.db.WithContext(ctx).First(&req, "id = ? AND owner_user_id = ?", id, ownerUserID)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &req, nil
+}
+
+// UpdateShareRequestStatusForOwner sets the status (and approved share id) of
+// a share request scoped to an owner. Only status, approved_share_id, and
+// updated_at are written.
+func (c *Core) UpdateShareRequestStatusForOwner(
+	ctx context.Context,
+	id string,
+	ownerUserID string,
+	status string,
+	approvedShareID string,
+) error {
+	result := c.db.WithContext(ctx).
+		Model(&store.ShareRequest{}).
+		Where("id = ? AND owner_user_id = ?", id, ownerUserID).
+		Updates(map[string]any{
+			"status":            status,
+			"approved_share_id": approvedShareID,
+			"updated_at":        time.Now().Unix(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListShareRequests returns share requests for ownerUserID, or every share
+// request when ownerUserID is empty.
+func (c *Core) ListShareRequests(ctx context.Context, ownerUserID string) ([]*store.ShareRequest, error) {
+	var reqs []*store.ShareRequest
+
+	query := c.db.WithContext(ctx)
+	if ownerUserID != "" {
+		query = query.Where("owner_user_id = ?", ownerUserID)
+	}
+
+	if err := query.Find(&reqs).Error; err != nil {
+		return nil, err
+	}
+
+	return reqs, nil
+}
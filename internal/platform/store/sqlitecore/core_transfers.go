@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sqlitecore
+
+import (
+	"context"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// ----------------------------------------------------------------------------
+// Transfer CRUD
+// ----------------------------------------------------------------------------
+
+// CreateTransfer stores a new transfer.
+func (c *Core) CreateTransfer(ctx context.Context, transfer *store.Transfer) error {
+	if err := c.db.WithContext(ctx).Create(transfer).Error; err != nil {
+		return normWrite(err)
+	}
+
+	return nil
+}
+
+// GetTransfer retrieves the transfer of an incoming share.
+func (c *Core) GetTransfer(ctx context.Context, shareID string) (*store.Transfer, error) {
+	var transfer store.Transfer
+
+	result := c.db.WithContext(ctx).First(&transfer, "share_id = ?", shareID)
+	if result.Error != nil {
+		return nil, normNotFound(result.Error)
+	}
+
+	return &transfer, nil
+}
+
+// UpdateTransfer replaces an existing transfer.
+func (c *Core) UpdateTransfer(ctx context.Context, transfer *store.Transfer) error {
+	result := c.db.WithContext(ctx). //nolint:unqueryvet // intentional: select all columns for this GORM Updates chain; column list is intentionally open
+						Model(&store.Transfer{}).
+						Where("share_id = ?", transfer.ShareID).
+						Select("*").
+						Updates(transfer)
+	if result.Error != nil {
+		return normWrite(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListTransfers returns every transfer.
+func (c *Core) ListTransfers(ctx context.Context) ([]*store.Transfer, error) {
+	var transfers []*store.Transfer
+	if err := c.db.WithContext(ctx).Find(&transfers).Error; err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
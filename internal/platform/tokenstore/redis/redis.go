@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package redis is the Redis/Valkey-backed issued-token store, for replicas
+// that must accept each other's tokens. Fail-fast: New returns an error when
+// the server is unreachable.
+//
+// Layout under the configured key prefix:
+//
+//	<prefix>t:<hash>        JSON token record, expiring shortly after the token
+//	<prefix>share:<shareID> set of token hashes issued for the share
+//
+// <hash> is token.HashAccessToken; the plaintext bearer token is never stored.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+)
+
+const (
+	// expiredRetention keeps a token record past its expiry so Get reports
+	// token.ErrTokenExpired rather than not-found until CleanExpired runs;
+	// Redis drops the key on its own after this.
+	expiredRetention = time.Hour
+	// scanCount is the SCAN page size hint used by CleanExpired.
+	scanCount = 100
+)
+
+// Config holds the Redis connection settings for the token store.
+type Config struct {
+	Addr        string
+	Password    string
+	DB          int
+	KeyPrefix   string
+	DialTimeout time.Duration
+	ConnTimeout time.Duration
+}
+
+// record is the stored token value.
+type record struct {
+	ShareID     string   `json:"shareId"`
+	ClientID    string   `json:"clientId"`
+	Kind        string   `json:"kind,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	IssuedAt    int64    `json:"issuedAt"`
+	ExpiresAt   int64    `json:"expiresAt"`
+}
+
+// Store implements token.TokenStore on Redis/Valkey.
+type Store struct {
+	client valkey.Client
+	prefix string
+}
+
+var _ token.TokenStore = (*Store)(nil)
+
+// New connects to Redis and verifies the connection with PING.
+func New(cfg Config) (*Store, error) {
+	dialTimeout := cfg.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = 5 * time.Second
+	}
+
+	connTimeout := cfg.ConnTimeout
+	if connTimeout <= 0 {
+		connTimeout = 3 * time.Second
+	}
+
+	client, err := valkey.NewClient(valkey.ClientOption{
+		InitAddress: []string{cfg.Addr},
+		Password:    cfg.Password,
+		SelectDB:    cfg.DB,
+		Dialer: net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: 30 * time.Second,
+		},
+		ConnWriteTimeout: connTimeout,
+		DisableCache:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tokenstore: create redis client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	if err := client.Do(ctx, client.B().Ping().Build()).Error(); err != nil {
+		client.Close()
+
+		return nil, fmt.Errorf("tokenstore: redis ping: %w", err)
+	}
+
+	return &Store{client: client, prefix: cfg.KeyPrefix}, nil
+}
+
+// Close closes the Redis client.
+func (s *Store) Close() error {
+	s.client.Close()
+
+	return nil
+}
+
+// Store saves the token and indexes it under its share.
+func (s *Store) Store(ctx context.Context, t *token.IssuedToken) error {
+	hash := token.HashAccessToken(t.AccessToken)
+
+	data, err := json.Marshal(record{
+		ShareID:     t.ShareID,
+		ClientID:    t.ClientID,
+		Kind:        t.Kind,
+		Permissions: t.Permissions,
+		IssuedAt:    t.IssuedAt.Unix(),
+		ExpiresAt:   t.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("tokenstore: encode token: %w", err)
+	}
+
+	expireAt := t.ExpiresAt.Add(expiredRetention).UnixMilli()
+
+	for _, resp := range s.client.DoMulti(ctx,
+		s.client.B().Set().Key(s.tokenKey(hash)).Value(string(data)).PxatMillisecondsTimestamp(expireAt).Build(),
+		s.client.B().Sadd().Key(s.shareKey(t.ShareID)).Member(hash).Build(),
+	) {
+		if err := resp.Error(); err != nil {
+			return fmt.Errorf("tokenstore: store token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Get returns the token for accessToken, or token.ErrTokenNotFound /
+// token.ErrTokenExpired.
+func (s *Store) Get(ctx context.Context, accessToken string) (*token.IssuedToken, error) {
+	rec, err := s.load(ctx, token.HashAccessToken(accessToken))
+	if err != nil {
+		return nil, err
+	}
+
+	t := &token.IssuedToken{
+		AccessToken: accessToken,
+		ShareID:     rec.ShareID,
+		ClientID:    rec.ClientID,
+		Kind:        rec.Kind,
+		Permissions: rec.Permissions,
+		IssuedAt:    time.Unix(rec.IssuedAt, 0).UTC(),
+		ExpiresAt:   time.Unix(rec.ExpiresAt, 0).UTC(),
+	}
+
+	if t.IsExpired() {
+		return nil, token.ErrTokenExpired
+	}
+
+	return t, nil
+}
+
+// Delete removes the token for accessToken; deleting a missing token is not an error.
+func (s *Store) Delete(ctx context.Context, accessToken string) error {
+	hash := token.HashAccessToken(accessToken)
+
+	rec, err := s.load(ctx, hash)
+	if errors.Is(err, token.ErrTokenNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return s.remove(ctx, rec.ShareID, hash)
+}
+
+// Claim removes the token for accessToken and returns token.ErrTokenNotFound
+// when it was already gone. The DEL reply count decides between concurrent
+// claims: only the caller whose DEL removed the key wins.
+func (s *Store) Claim(ctx context.Context, accessToken string) error {
+	hash := token.HashAccessToken(accessToken)
+
+	rec, err := s.load(ctx, hash)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.client.Do(ctx, s.client.B().Del().Key(s.tokenKey(hash)).Build()).AsInt64()
+	if err != nil {
+		return fmt.Errorf("tokenstore: claim token: %w", err)
+	}
+
+	if deleted == 0 {
+		return token.ErrTokenNotFound
+	}
+
+	if err := s.client.Do(ctx, s.client.B().Srem().Key(s.shareKey(rec.ShareID)).Member(hash).Build()).Error(); err != nil {
+		return fmt.Errorf("tokenstore: claim token: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByShareID removes every token indexed under shareID.
+func (s *Store) DeleteByShareID(ctx context.Context, shareID string) error {
+	hashes, err := s.client.Do(ctx, s.client.B().Smembers().Key(s.shareKey(shareID)).Build()).AsStrSlice()
+	if err != nil {
+		return fmt.Errorf("tokenstore: list share tokens: %w", err)
+	}
+
+	// One DEL per key: valkey-go rejects multi-key commands whose keys could
+	// land in different cluster slots.
+	cmds := make(valkey.Commands, 0, len(hashes)+1)
+	for _, hash := range hashes {
+		cmds = append(cmds, s.client.B().Del().Key(s.tokenKey(hash)).Build())
+	}
+
+	cmds = append(cmds, s.client.B().Del().Key(s.shareKey(shareID)).Build())
+
+	for _, resp := range s.client.DoMulti(ctx, cmds...) {
+		if err := resp.Error(); err != nil {
+			return fmt.Errorf("tokenstore: delete share tokens: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CleanExpired walks the share indexes, deleting expired token records and
+// pruning index members whose record Redis already dropped.
+func (s *Store) CleanExpired(ctx context.Context) error {
+	var cursor uint64
+
+	for {
+		entry, err := s.client.Do(ctx, s.client.B().Scan().Cursor(cursor).
+			Match(s.shareKey("*")).Count(scanCount).Build()).AsScanEntry()
+		if err != nil {
+			return fmt.Errorf("tokenstore: scan share indexes: %w", err)
+		}
+
+		for _, key := range entry.Elements {
+			if err := s.cleanShare(ctx, strings.TrimPrefix(key, s.shareKey(""))); err != nil {
+				return err
+			}
+		}
+
+		cursor = entry.Cursor
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+func (s *Store) cleanShare(ctx context.Context, shareID string) error {
+	hashes, err := s.client.Do(ctx, s.client.B().Smembers().Key(s.shareKey(shareID)).Build()).AsStrSlice()
+	if err != nil {
+		return fmt.Errorf("tokenstore: list share tokens: %w", err)
+	}
+
+	now := time.Now().Unix()
+
+	for _, hash := range hashes {
+		rec, err := s.load(ctx, hash)
+		if err != nil && !errors.Is(err, token.ErrTokenNotFound) {
+			return err
+		}
+
+		if err == nil && rec.ExpiresAt >= now {
+			continue
+		}
+
+		if err := s.remove(ctx, shareID, hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) load(ctx context.Context, hash string) (*record, error) {
+	data, err := s.client.Do(ctx, s.client.B().Get().Key(s.tokenKey(hash)).Build()).AsBytes()
+	if valkey.IsValkeyNil(err) {
+		return nil, token.ErrTokenNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("tokenstore: get token: %w", err)
+	}
+
+	var rec record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("tokenstore: decode token: %w", err)
+	}
+
+	return &rec, nil
+}
+
+func (s *Store) remove(ctx context.Context, shareID, hash string) error {
+	for _, resp := range s.client.DoMulti(ctx,
+		s.client.B().Del().Key(s.tokenKey(hash)).Build(),
+		s.client.B().Srem().Key(s.shareKey(shareID)).Member(hash).Build(),
+	) {
+		if err := resp.Error(); err != nil {
+			return fmt.Errorf("tokenstore: delete token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) tokenKey(hash string) string {
+	return s.prefix + "t:" + hash
+}
+
+func (s *Store) shareKey(shareID string) string {
+	return s.prefix + "share:" + shareID
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package redis_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tokenstore/redis"
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	tstokenstore "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/tokenstore"
+)
+
+const testPrefix = "test:tokens:"
+
+func newStore(t *testing.T, addr string) *redis.Store {
+	t.Helper()
+
+	s, err := redis.New(redis.Config{Addr: addr, KeyPrefix: testPrefix, DialTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	t.Cleanup(func() { tshttp.MustClose(t, s) })
+
+	return s
+}
+
+func TestStore_Contract(t *testing.T) {
+	t.Parallel()
+
+	tstokenstore.RunContract(t, func(t *testing.T) token.TokenStore {
+		return newStore(t, miniredis.RunT(t).Addr())
+	})
+}
+
+func TestNew_FailFastUnreachable(t *testing.T) {
+	t.Parallel()
+
+	_, err := redis.New(redis.Config{Addr: "localhost:59998", DialTimeout: 100 * time.Millisecond})
+	if err == nil {
+		t.Fatal("expected error when Redis is unreachable")
+	}
+}
+
+func TestStore_SharedAcrossClients(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	replicaA := newStore(t, srv.Addr())
+	replicaB := newStore(t, srv.Addr())
+
+	issued := tstokenstore.Issued("shared-token", "share-1", time.Hour)
+	if err := replicaA.Store(t.Context(), issued); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if _, err := replicaB.Get(t.Context(), issued.AccessToken); err != nil {
+		t.Fatalf("Get from second client: %v", err)
+	}
+
+	if err := replicaB.DeleteByShareID(t.Context(), "share-1"); err != nil {
+		t.Fatalf("DeleteByShareID: %v", err)
+	}
+
+	if _, err := replicaA.Get(t.Context(), issued.AccessToken); err == nil {
+		t.Fatal("token revoked on one client must be gone for the other")
+	}
+}
+
+func TestStore_HashesTokensAtRest(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	s := newStore(t, srv.Addr())
+
+	accessToken := "plaintext-bearer-9a7e41"
+	if err := s.Store(t.Context(), tstokenstore.Issued(accessToken, "share-1", time.Hour)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	hash := token.HashAccessToken(accessToken)
+	if !srv.Exists(testPrefix + "t:" + hash) {
+		t.Errorf("expected token key under its hash, keys = %v", srv.Keys())
+	}
+
+	for _, key := range srv.Keys() {
+		if strings.Contains(key, accessToken) {
+			t.Errorf("key %q contains the plaintext access token", key)
+		}
+
+		if val, err := srv.Get(key); err == nil && strings.Contains(val, accessToken) {
+			t.Errorf("value of %q contains the plaintext access token", key)
+		}
+	}
+}
+
+func TestStore_CleanExpiredPrunesDroppedIndexMembers(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	s := newStore(t, srv.Addr())
+
+	if err := s.Store(t.Context(), tstokenstore.Issued("short-lived", "share-1", time.Minute)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	// Let Redis drop the key on its own, leaving a stale index member.
+	srv.FastForward(2 * time.Hour)
+
+	if err := s.CleanExpired(t.Context()); err != nil {
+		t.Fatalf("CleanExpired: %v", err)
+	}
+
+	if srv.Exists(testPrefix + "share:share-1") {
+		t.Error("CleanExpired must remove the emptied share index")
+	}
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package sqlite is the SQLite-backed issued-token store. Tokens live in their
+// own tokens.db so token churn never contends with the persistence database,
+// and rows are keyed by token.HashAccessToken: the plaintext bearer token is
+// never written to disk.
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gormsqlite "github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+)
+
+// DBFileName is the token database file created under the data dir.
+const DBFileName = "tokens.db"
+
+// IssuedToken is the tokens.db row. Timestamps are unix seconds, matching the
+// persistence store models.
+type IssuedToken struct {
+	TokenHash   string `gorm:"primaryKey"`
+	ShareID     string `gorm:"index;not null"`
+	ClientID    string
+	Kind        string
+	Permissions string // comma-joined
+	IssuedAt    int64
+	ExpiresAt   int64 `gorm:"index"`
+}
+
+// TableName pins the table name independent of the Go type name.
+func (IssuedToken) TableName() string { return "issued_tokens" }
+
+// Store implements token.TokenStore on SQLite.
+type Store struct {
+	db *gorm.DB
+}
+
+var _ token.TokenStore = (*Store)(nil)
+
+// Open opens (or creates) tokens.db under dataDir and migrates it. The caller
+// must call Close when done.
+func Open(dataDir string) (*Store, error) {
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return nil, fmt.Errorf("tokenstore: create data dir: %w", err)
+	}
+
+	// Same pragmas as the persistence database: wait on a competing writer
+	// instead of failing with SQLITE_BUSY when replicas share the file.
+	dsn := filepath.Join(dataDir, DBFileName) + "?_pragma=busy_timeout(5000)&_txlock=immediate"
+
+	db, err := gorm.Open(gormsqlite.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tokenstore: open database: %w", err)
+	}
+
+	if migrErr := db.AutoMigrate(&IssuedToken{}); migrErr != nil {
+		migrErr = fmt.Errorf("tokenstore: migrate database: %w", migrErr)
+		if sqlDB, dbErr := db.DB(); dbErr != nil {
+			return nil, errors.Join(migrErr, dbErr)
+		} else if closeErr := sqlDB.Close(); closeErr != nil {
+			return nil, errors.Join(migrErr, closeErr)
+		}
+
+		return nil, migrErr
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close releases the database connection.
+func (s *Store) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return fmt.Errorf("tokenstore: get sql db: %w", err)
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		return fmt.Errorf("tokenstore: close sql db: %w", err)
+	}
+
+	return nil
+}
+
+// Store saves the token, replacing any row with the same hash.
+func (s *Store) Store(ctx context.Context, t *token.IssuedToken) error {
+	row := &IssuedToken{
+		TokenHash:   token.HashAccessToken(t.AccessToken),
+		ShareID:     t.ShareID,
+		ClientID:    t.ClientID,
+		Kind:        t.Kind,
+		Permissions: strings.Join(t.Permissions, ","),
+		IssuedAt:    t.IssuedAt.Unix(),
+		ExpiresAt:   t.ExpiresAt.Unix(),
+	}
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(row).Error; err != nil {
+		return fmt.Errorf("tokenstore: store token: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the token for accessToken, or token.ErrTokenNotFound /
+// token.ErrTokenExpired.
+func (s *Store) Get(ctx context.Context, accessToken string) (*token.IssuedToken, error) {
+	var row IssuedToken
+
+	err := s.db.WithContext(ctx).
+		Where("token_hash = ?", token.HashAccessToken(accessToken)).
+		Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, token.ErrTokenNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("tokenstore: get token: %w", err)
+	}
+
+	t := &token.IssuedToken{
+		AccessToken: accessToken,
+		ShareID:     row.ShareID,
+		ClientID:    row.ClientID,
+		Kind:        row.Kind,
+		IssuedAt:    time.Unix(row.IssuedAt, 0).UTC(),
+		ExpiresAt:   time.Unix(row.ExpiresAt, 0).UTC(),
+	}
+	if row.Permissions != "" {
+		t.Permissions = strings.Split(row.Permissions, ",")
+	}
+
+	if time.Now().After(t.ExpiresAt) {
+		return nil, token.ErrTokenExpired
+	}
+
+	return t, nil
+}
+
+// Delete removes the token for accessToken; deleting a missing token is not an error.
+func (s *Store) Delete(ctx context.Context, accessToken string) error {
+	err := s.db.WithContext(ctx).
+		Where("token_hash = ?", token.HashAccessToken(accessToken)).
+		Delete(&IssuedToken{}).Error
+	if err != nil {
+		return fmt.Errorf("tokenstore: delete token: %w", err)
+	}
+
+	return nil
+}
+
+// Claim removes the token for accessToken and returns token.ErrTokenNotFound
+// when no row was deleted, which is how a losing concurrent claim sees it.
+func (s *Store) Claim(ctx context.Context, accessToken string) error {
+	res := s.db.WithContext(ctx).
+		Where("token_hash = ?", token.HashAccessToken(accessToken)).
+		Delete(&IssuedToken{})
+	if res.Error != nil {
+		return fmt.Errorf("tokenstore: claim token: %w", res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return token.ErrTokenNotFound
+	}
+
+	return nil
+}
+
+// DeleteByShareID removes every token issued for shareID using the share_id index.
+func (s *Store) DeleteByShareID(ctx context.Context, shareID string) error {
+	if err := s.db.WithContext(ctx).Where("share_id = ?", shareID).Delete(&IssuedToken{}).Error; err != nil {
+		return fmt.Errorf("tokenstore: delete tokens by share: %w", err)
+	}
+
+	return nil
+}
+
+// CleanExpired removes every token past its expiry.
+func (s *Store) CleanExpired(ctx context.Context) error {
+	if err := s.db.WithContext(ctx).Where("expires_at < ?", time.Now().Unix()).Delete(&IssuedToken{}).Error; err != nil {
+		return fmt.Errorf("tokenstore: clean expired tokens: %w", err)
+	}
+
+	return nil
+}
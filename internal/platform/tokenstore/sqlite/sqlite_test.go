@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package sqlite_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tokenstore/sqlite"
+	tstokenstore "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/tokenstore"
+)
+
+func openStore(t *testing.T, dir string) *sqlite.Store {
+	t.Helper()
+
+	s, err := sqlite.Open(dir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	return s
+}
+
+func TestStore_Contract(t *testing.T) {
+	t.Parallel()
+
+	tstokenstore.RunContract(t, func(t *testing.T) token.TokenStore {
+		s := openStore(t, t.TempDir())
+		t.Cleanup(func() {
+			if err := s.Close(); err != nil {
+				t.Errorf("Close: %v", err)
+			}
+		})
+
+		return s
+	})
+}
+
+func TestStore_SurvivesReopen(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	issued := tstokenstore.Issued("durable-token", "share-1", time.Hour)
+
+	first := openStore(t, dir)
+	if err := first.Store(t.Context(), issued); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	second := openStore(t, dir)
+	defer func() {
+		if err := second.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	}()
+
+	got, err := second.Get(t.Context(), issued.AccessToken)
+	if err != nil {
+		t.Fatalf("Get after reopen: %v", err)
+	}
+
+	if got.ShareID != issued.ShareID || !got.ExpiresAt.Equal(issued.ExpiresAt) {
+		t.Errorf("Get after reopen = %+v, want %+v", got, issued)
+	}
+}
+
+func TestStore_HashesTokensAtRest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	accessToken := "plaintext-bearer-6f1d2c"
+
+	s := openStore(t, dir)
+	if err := s.Store(t.Context(), tstokenstore.Issued(accessToken, "share-1", time.Hour)); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, sqlite.DBFileName))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	if bytes.Contains(data, []byte(accessToken)) {
+		t.Error("tokens.db contains the plaintext access token")
+	}
+
+	if !bytes.Contains(data, []byte(token.HashAccessToken(accessToken))) {
+		t.Error("tokens.db does not contain the token hash")
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package tokenstore builds the issued-token store selected by
+// [token_exchange.store] and runs its scheduled expiry cleanup.
+package tokenstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tokenstore/redis"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tokenstore/sqlite"
+)
+
+// Store is a token.TokenStore whose cleanup sweep is running. Callers must
+// call Close on shutdown to stop the sweep and release the backend.
+type Store interface {
+	token.TokenStore
+	io.Closer
+}
+
+type managed struct {
+	token.TokenStore
+
+	janitor *token.Janitor
+	backend io.Closer
+}
+
+// New opens the store for cfg.TokenExchange.Store and starts its cleanup
+// sweep. The sqlite driver resolves its directory via
+// cfg.ResolveTokenStoreDataDir.
+func New(cfg *config.Config, log *slog.Logger) (Store, error) {
+	storeCfg := cfg.TokenExchange.Store
+
+	var (
+		backend token.TokenStore
+		closer  io.Closer
+	)
+
+	switch storeCfg.Driver {
+	case "", config.TokenStoreDriverMemory:
+		backend = token.NewMemoryTokenStore()
+	case config.TokenStoreDriverSQLite:
+		s, err := sqlite.Open(cfg.ResolveTokenStoreDataDir())
+		if err != nil {
+			return nil, err
+		}
+
+		backend, closer = s, s
+	case config.TokenStoreDriverRedis:
+		s, err := redis.New(redis.Config{
+			Addr:      storeCfg.Redis.Addr,
+			Password:  storeCfg.Redis.Password,
+			DB:        storeCfg.Redis.DB,
+			KeyPrefix: storeCfg.Redis.KeyPrefix,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		backend, closer = s, s
+	default:
+		return nil, fmt.Errorf("tokenstore: unknown driver %q", storeCfg.Driver)
+	}
+
+	interval := time.Duration(storeCfg.CleanupIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = config.DefaultTokenStoreCleanupIntervalSeconds * time.Second
+	}
+
+	janitor := token.NewJanitor(backend, interval, log)
+	janitor.Start()
+
+	return &managed{TokenStore: backend, janitor: janitor, backend: closer}, nil
+}
+
+// Close stops the cleanup sweep, then closes the backend.
+func (m *managed) Close() error {
+	err := m.janitor.Close()
+
+	if m.backend != nil {
+		err = errors.Join(err, m.backend.Close())
+	}
+
+	return err
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package tokenstore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tokenstore"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tokenstore/sqlite"
+	tslog "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/log"
+	tstokenstore "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/tokenstore"
+)
+
+func TestNew_Drivers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		patch func(t *testing.T, cfg *config.Config)
+	}{
+		{
+			name:  "memory",
+			patch: func(*testing.T, *config.Config) {},
+		},
+		{
+			name: "sqlite falls back to persistence data dir",
+			patch: func(t *testing.T, cfg *config.Config) {
+				t.Helper()
+				cfg.TokenExchange.Store.Driver = config.TokenStoreDriverSQLite
+				cfg.Persistence.DataDir = t.TempDir()
+			},
+		},
+		{
+			name: "redis",
+			patch: func(t *testing.T, cfg *config.Config) {
+				t.Helper()
+				cfg.TokenExchange.Store.Driver = config.TokenStoreDriverRedis
+				cfg.TokenExchange.Store.Redis.Addr = miniredis.RunT(t).Addr()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.DevConfig()
+			tc.patch(t, cfg)
+
+			s, err := tokenstore.New(cfg, tslog.DiscardLogger())
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			issued := tstokenstore.Issued("access", "share-1", time.Hour)
+			if err := s.Store(t.Context(), issued); err != nil {
+				t.Fatalf("Store: %v", err)
+			}
+
+			if _, err := s.Get(t.Context(), issued.AccessToken); err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+
+			if err := s.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			if cfg.TokenExchange.Store.Driver == config.TokenStoreDriverSQLite {
+				if _, err := os.Stat(filepath.Join(cfg.Persistence.DataDir, sqlite.DBFileName)); err != nil {
+					t.Errorf("expected %s under persistence.data_dir: %v", sqlite.DBFileName, err)
+				}
+			}
+		})
+	}
+}
+
+func TestNew_RejectsUnknownDriver(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DevConfig()
+	cfg.TokenExchange.Store.Driver = "etcd"
+
+	if _, err := tokenstore.New(cfg, tslog.DiscardLogger()); err == nil {
+		t.Fatal("expected error for unknown token store driver")
+	}
+}
+
+func TestNew_RedisUnreachableFailsFast(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DevConfig()
+	cfg.TokenExchange.Store.Driver = config.TokenStoreDriverRedis
+	cfg.TokenExchange.Store.Redis.Addr = "localhost:59997"
+
+	if _, err := tokenstore.New(cfg, tslog.DiscardLogger()); err == nil {
+		t.Fatal("expected error when Redis is unreachable")
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package tracing configures OpenTelemetry tracing and provides the span
+// helpers used by the HTTP server, outbound HTTP client, and discovery/JWKS
+// fetches.
+//
+// Until Setup installs a provider, the global OpenTelemetry tracer is a no-op
+// and no traceparent headers are written, so instrumented code costs nearly
+// nothing when tracing is disabled.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+)
+
+// instrumentationName names the tracer that every span here comes from.
+const instrumentationName = "github.com/MahdiBaghbani/opencloudmesh-go"
+
+// Provider owns the installed tracer provider and the exporter sink.
+type Provider struct {
+	tp     *sdktrace.TracerProvider
+	closer io.Closer
+}
+
+// Setup installs a global tracer provider and trace context (traceparent) propagator
+// from cfg. When tracing is disabled it installs nothing and the returned
+// Provider's Shutdown is a no-op.
+func Setup(ctx context.Context, cfg config.TracingConfig) (*Provider, error) {
+	if !cfg.Enabled {
+		return &Provider{}, nil
+	}
+
+	exporter, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("tracing: build resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return &Provider{tp: tp, closer: closer}, nil
+}
+
+// Shutdown flushes pending spans and releases the exporter sink.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil || p.tp == nil {
+		return nil
+	}
+
+	err := p.tp.Shutdown(ctx)
+	if p.closer != nil {
+		err = errors.Join(err, p.closer.Close())
+	}
+
+	if err != nil {
+		return fmt.Errorf("tracing: shutdown: %w", err)
+	}
+
+	return nil
+}
+
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case config.TracingExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, nil, fmt.Errorf("tracing: create stdout exporter: %w", err)
+		}
+
+		return exp, nil, nil
+	case config.TracingExporterFile:
+		if err := os.MkdirAll(filepath.Dir(cfg.File), 0o750); err != nil {
+			return nil, nil, fmt.Errorf("tracing: create trace file dir: %w", err)
+		}
+
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tracing: open trace file: %w", err)
+		}
+
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			//nolint:errcheck // best-effort cleanup; exporter creation error is returned
+			f.Close()
+
+			return nil, nil, fmt.Errorf("tracing: create file exporter: %w", err)
+		}
+
+		return exp, f, nil
+	case config.TracingExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(cfg.Endpoint)}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+
+		exp, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tracing: create otlp exporter: %w", err)
+		}
+
+		return exp, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+	}
+}
+
+// Start begins an internal span named name as a child of any span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return StartKind(ctx, name, trace.SpanKindInternal, attrs...)
+}
+
+// StartKind begins a span of the given kind as a child of any span in ctx.
+func StartKind(
+	ctx context.Context,
+	name string,
+	kind trace.SpanKind,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// End records err on span (when non-nil) and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// InjectHeaders writes the traceparent for the span in ctx into h. It writes
+// nothing while tracing is disabled.
+func InjectHeaders(ctx context.Context, h http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(h))
+}
+
+// ExtractHeaders returns ctx carrying the remote span context from h.
+func ExtractHeaders(ctx context.Context, h http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(h))
+}
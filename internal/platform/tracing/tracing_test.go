@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package tracing_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tracing"
+)
+
+// resetGlobals restores the no-op tracer provider and empty propagator that
+// Setup replaces, so later tests see tracing disabled again.
+func resetGlobals(t *testing.T) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+}
+
+func TestSetup_DisabledInjectsNothing(t *testing.T) {
+	p, err := tracing.Setup(t.Context(), config.DefaultTracingConfig())
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	ctx, span := tracing.Start(t.Context(), "disabled")
+	h := http.Header{}
+	tracing.InjectHeaders(ctx, h)
+	span.End()
+
+	if got := h.Get("traceparent"); got != "" {
+		t.Errorf("traceparent = %q, want none while tracing is disabled", got)
+	}
+
+	if err := p.Shutdown(t.Context()); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+}
+
+func TestSetup_FileExporterWritesSpansAndPropagates(t *testing.T) {
+	resetGlobals(t)
+
+	cfg := config.DefaultTracingConfig()
+	cfg.Enabled = true
+	cfg.Exporter = config.TracingExporterFile
+	cfg.File = filepath.Join(t.TempDir(), "traces", "spans.jsonl")
+
+	p, err := tracing.Setup(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	ctx, span := tracing.Start(t.Context(), "file-export-check")
+
+	h := http.Header{}
+	tracing.InjectHeaders(ctx, h)
+
+	traceID := span.SpanContext().TraceID().String()
+	if !strings.Contains(h.Get("traceparent"), traceID) {
+		t.Errorf("traceparent = %q, want trace id %s", h.Get("traceparent"), traceID)
+	}
+
+	remote := tracing.ExtractHeaders(t.Context(), h)
+	_, child := tracing.Start(remote, "continued")
+
+	if got := child.SpanContext().TraceID().String(); got != traceID {
+		t.Errorf("extracted child trace id = %s, want %s", got, traceID)
+	}
+
+	child.End()
+	tracing.End(span, nil)
+
+	if err := p.Shutdown(t.Context()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	data, err := os.ReadFile(cfg.File)
+	if err != nil {
+		t.Fatalf("read trace file: %v", err)
+	}
+
+	for _, want := range []string{"file-export-check", "continued", traceID} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("trace file missing %q", want)
+		}
+	}
+}
@@ -12,19 +12,31 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api"
+	adminaudit "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/audit"
+	admingroups "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/groups"
+	adminkeys "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/keys"
+	adminpeers "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/peers"
+	adminreload "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/reload"
+	adminusers "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/users"
 	inboxinvites "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/inbox/invites"
+	inboxsharerequests "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/inbox/sharerequests"
 	inboxshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/inbox/shares"
 	outgoinginvites "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/invites"
+	outgoingsharerequests "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/sharerequests"
 	outgoingshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/shares"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity/sessiongate"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/access"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/expiry"
 	notificationsoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/notifications/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
 	tokenoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service"
 	svccfg "github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service/cfg"
@@ -32,6 +44,7 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/interceptors"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/interceptors/ratelimit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
 )
 
@@ -39,6 +52,8 @@ import (
 type Config struct {
 	Ratelimit    RatelimitConfig `mapstructure:"ratelimit"`
 	AllowedPaths []string        `mapstructure:"allowed_paths"`
+	Outbox       OutboxConfig    `mapstructure:"outbox"`
+	Expiry       ExpiryConfig    `mapstructure:"expiry"`
 }
 
 // RatelimitConfig holds the per-service rate limiting opt-in.
@@ -46,6 +61,33 @@ type RatelimitConfig struct {
 	Profile string `mapstructure:"profile"`
 }
 
+// OutboxConfig tunes retries for queued outbound shares, notifications, and
+// invite-accepted calls. Zero values use the outbox package defaults.
+type OutboxConfig struct {
+	MaxAttempts           int `mapstructure:"max_attempts"`
+	InitialBackoffSeconds int `mapstructure:"initial_backoff_seconds"`
+	MaxBackoffSeconds     int `mapstructure:"max_backoff_seconds"`
+	PollIntervalSeconds   int `mapstructure:"poll_interval_seconds"`
+	AttemptTimeoutSeconds int `mapstructure:"attempt_timeout_seconds"`
+}
+
+// ExpiryConfig paces the janitor that expires shares and invites. Zero uses
+// the expiry package default.
+type ExpiryConfig struct {
+	SweepIntervalSeconds int `mapstructure:"sweep_interval_seconds"`
+}
+
+// Policy converts the config to an outbox retry policy.
+func (c OutboxConfig) Policy() outbox.Policy {
+	return outbox.Policy{
+		MaxAttempts:    c.MaxAttempts,
+		InitialBackoff: time.Duration(c.InitialBackoffSeconds) * time.Second,
+		MaxBackoff:     time.Duration(c.MaxBackoffSeconds) * time.Second,
+		PollInterval:   time.Duration(c.PollIntervalSeconds) * time.Second,
+		AttemptTimeout: time.Duration(c.AttemptTimeoutSeconds) * time.Second,
+	}
+}
+
 // ApplyDefaults implements cfg.Setter.
 func (c *Config) ApplyDefaults() {}
 
@@ -54,6 +96,10 @@ type Service struct {
 	router          chi.Router
 	conf            *Config
 	outgoingHandler *outgoingshares.Handler
+	outbox          *outbox.Dispatcher
+	transfers       *datatx.Manager
+	expiry          *expiry.Janitor
+	rotator         *crypto.Rotator
 }
 
 // New creates a new API service from narrow injected inputs.
@@ -76,6 +122,7 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 	}
 
 	authHandler := api.NewAuthHandler(inputs.PartyRepo, inputs.SessionRepo, inputs.UserAuth)
+	authHandler.SetAudit(inputs.Audit)
 
 	currentUser := func(ctx context.Context) (*identity.User, error) {
 		u := sessiongate.GetUserFromContext(ctx)
@@ -98,18 +145,22 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 		inputs.PeerOrigin,
 	)
 
+	notificationSender := notificationsoutgoing.NewSender(outbound.NewPoster(
+		inputs.HTTPClient,
+		inputs.DiscoveryClient,
+		inputs.Signer,
+		inputs.PeerOrigin,
+	))
+
 	inboxSharesHandler := inboxshares.NewHandler(
 		inputs.IncomingShareRepo,
 		accessClient,
-		notificationsoutgoing.NewSender(outbound.NewPoster(
-			inputs.HTTPClient,
-			inputs.DiscoveryClient,
-			inputs.Signer,
-			inputs.PeerOrigin,
-		)),
+		notificationSender,
 		currentUser,
 		log,
 	)
+	inboxSharesHandler.SetWebappOpener(accessClient)
+	inboxSharesHandler.SetAudit(inputs.Audit)
 
 	outgoingHandler := outgoingshares.NewHandler(
 		inputs.OutgoingShareRepo,
@@ -123,6 +174,14 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 		inputs.LocalTokenEndpoint,
 	)
 	outgoingHandler.SetPeerOrigin(inputs.PeerOrigin)
+	outgoingHandler.SetNotifier(notificationSender)
+	outgoingHandler.SetWebapp(inputs.Webapp)
+	outgoingHandler.SetSSH(inputs.SSHAddr)
+	outgoingHandler.SetAudit(inputs.Audit)
+
+	if inputs.TokenStore != nil {
+		outgoingHandler.SetTokenRevoker(inputs.TokenStore)
+	}
 
 	allowedPaths, err := resolveOutgoingAllowedPaths(inputs.ContentDir, c.AllowedPaths)
 	if err != nil {
@@ -146,6 +205,7 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 		currentUser,
 		log,
 	)
+	inboxInvitesHandler.SetAudit(inputs.Audit)
 
 	outgoingInvitesHandler := outgoinginvites.NewHandler(
 		inputs.OutgoingInviteRepo,
@@ -154,6 +214,54 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 		log,
 	)
 
+	inboxShareRequestsHandler := inboxsharerequests.NewHandler(
+		inputs.ShareRequestRepo,
+		inputs.OutgoingShareRepo,
+		outgoingHandler,
+		currentUser,
+		log,
+	)
+
+	outgoingShareRequestsHandler := outgoingsharerequests.NewHandler(
+		NewRequestSharePoster(outbound.NewPoster(
+			inputs.HTTPClient,
+			inputs.DiscoveryClient,
+			inputs.Signer,
+			inputs.PeerOrigin,
+		)),
+		inputs.LocalIdentity.ProviderDomain,
+		currentUser,
+		log,
+	)
+
+	adminUsersHandler := adminusers.NewHandler(
+		inputs.PartyRepo,
+		inputs.SessionRepo,
+		inputs.UserAuth,
+		inputs.DataTx.StorageDir,
+		currentUser,
+		log,
+	)
+
+	adminGroupsHandler := admingroups.NewHandler(
+		inputs.GroupRepo,
+		inputs.PartyRepo,
+		currentUser,
+		log,
+	)
+
+	// A nil KeyManager (crypto skipped) must reach the handler as a nil
+	// interface, not a typed nil.
+	var keyring adminkeys.Keyring
+	if inputs.KeyManager != nil {
+		keyring = inputs.KeyManager
+	}
+
+	adminKeysHandler := adminkeys.NewHandler(keyring, inputs.KeyRotation.PublishAhead(), currentUser, log)
+	adminReloadHandler := adminreload.NewHandler(inputs.Reloader, currentUser, log)
+	adminAuditHandler := adminaudit.NewHandler(inputs.AuditLog, currentUser, log)
+	adminPeersHandler := adminpeers.NewHandler(inputs.PolicyEngine, inputs.TrustGroupMgr, inputs.PeerTrustRepo, currentUser, log)
+
 	var loginMiddleware func(http.Handler) http.Handler
 
 	if c.Ratelimit.Profile != "" {
@@ -168,12 +276,81 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 		}
 	}
 
+	var dispatcher *outbox.Dispatcher
+
+	if inputs.OutboxRepo != nil {
+		dispatcher = outbox.NewDispatcher(inputs.OutboxRepo, outbound.NewPoster(
+			inputs.HTTPClient,
+			inputs.DiscoveryClient,
+			inputs.Signer,
+			inputs.PeerOrigin,
+		), c.Outbox.Policy(), log)
+		outgoingHandler.SetOutbox(dispatcher)
+		notificationSender.SetOutbox(dispatcher)
+		inboxInvitesHandler.SetOutbox(dispatcher)
+		dispatcher.Start()
+	}
+
+	var transfers *datatx.Manager
+
+	if inputs.TransferRepo != nil {
+		transfers = datatx.NewManager(
+			inputs.TransferRepo,
+			inputs.IncomingShareRepo,
+			inputs.PartyRepo,
+			accessClient,
+			inputs.DataTx.StorageDir,
+			datatx.Policy{
+				MaxConcurrent: inputs.DataTx.MaxConcurrent,
+				MaxAttempts:   inputs.DataTx.MaxAttempts,
+				MaxBytes:      inputs.DataTx.MaxBytes,
+			},
+			log,
+		)
+		inboxSharesHandler.SetTransfers(transfers)
+		transfers.Start()
+	}
+
+	var janitor *expiry.Janitor
+
+	if inputs.OutgoingShareRepo != nil && inputs.IncomingShareRepo != nil && inputs.OutgoingInviteRepo != nil {
+		janitor = expiry.NewJanitor(
+			inputs.OutgoingShareRepo,
+			inputs.IncomingShareRepo,
+			inputs.OutgoingInviteRepo,
+			time.Duration(c.Expiry.SweepIntervalSeconds)*time.Second,
+			log,
+		)
+		janitor.SetNotifier(notificationSender)
+
+		if inputs.TokenStore != nil {
+			janitor.SetTokenRevoker(inputs.TokenStore)
+		}
+
+		janitor.Start()
+	}
+
+	var rotator *crypto.Rotator
+
+	if inputs.KeyManager != nil {
+		rotator = crypto.NewRotator(inputs.KeyManager, crypto.RotationPolicy{
+			Interval:     inputs.KeyRotation.Interval(),
+			PublishAhead: inputs.KeyRotation.PublishAhead(),
+			Grace:        inputs.KeyRotation.Grace(),
+		}, 0, log)
+		rotator.Start()
+	}
+
 	r := chi.NewRouter()
 
 	s := &Service{
 		router:          r,
 		conf:            &c,
 		outgoingHandler: outgoingHandler,
+		outbox:          dispatcher,
+		transfers:       transfers,
+		expiry:          janitor,
+		rotator:         rotator,
 	}
 
 	r.Get(RouteHealthz, api.HealthHandler)
@@ -186,19 +363,61 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 
 	r.Post(RouteAuthLogout, authHandler.Logout)
 	r.Get(RouteAuthMe, authHandler.GetCurrentUser)
+	r.Get(RouteAuthMeSSHKeys, authHandler.GetSSHKeys)
+	r.Put(RouteAuthMeSSHKeys, authHandler.PutSSHKeys)
 
 	r.Get(RouteInboxShares, inboxSharesHandler.HandleList)
 	r.Get(RouteInboxShareDetail, inboxSharesHandler.HandleGetDetail)
 	r.Post(RouteInboxShareAccept, inboxSharesHandler.HandleAccept)
 	r.Post(RouteInboxShareDecline, inboxSharesHandler.HandleDecline)
 	r.Post(RouteInboxShareVerifyAccess, inboxSharesHandler.HandleVerifyAccess)
+	r.Post(RouteInboxShareOpenWebapp, inboxSharesHandler.HandleOpenWebapp)
+	r.Get(RouteInboxShareContent, inboxSharesHandler.HandleContent)
 	r.Get(RouteInboxInvites, inboxInvitesHandler.HandleList)
 	r.Post(RouteInboxInviteImport, inboxInvitesHandler.HandleImport)
 	r.Post(RouteInboxInviteAccept, inboxInvitesHandler.HandleAccept)
 	r.Post(RouteInboxInviteDecline, inboxInvitesHandler.HandleDecline)
+	r.Get(RouteInboxShareRequests, inboxShareRequestsHandler.HandleList)
+	r.Post(RouteInboxShareRequestApprove, inboxShareRequestsHandler.HandleApprove)
+	r.Post(RouteInboxShareRequestDecline, inboxShareRequestsHandler.HandleDecline)
 
+	r.Get(RouteSharesOutgoing, outgoingHandler.HandleList)
 	r.Post(RouteSharesOutgoing, outgoingHandler.HandleCreate)
+	r.Get(RouteSharesOutgoingDetail, outgoingHandler.HandleGetDetail)
+	r.Delete(RouteSharesOutgoingDetail, outgoingHandler.HandleRevoke)
 	r.Post(RouteInvitesOutgoing, outgoingInvitesHandler.HandleCreateOutgoing)
+	r.Post(RouteShareRequestsOutgoing, outgoingShareRequestsHandler.HandleCreate)
+
+	r.Get(RouteAdminUsers, adminUsersHandler.HandleList)
+	r.Post(RouteAdminUsers, adminUsersHandler.HandleCreate)
+	r.Post(RouteAdminUsersExpireProbes, adminUsersHandler.HandleExpireProbes)
+	r.Post(RouteAdminUserPassword, adminUsersHandler.HandleResetPassword)
+	r.Put(RouteAdminUserRole, adminUsersHandler.HandleChangeRole)
+	r.Delete(RouteAdminUser, adminUsersHandler.HandleDelete)
+
+	r.Get(RouteAdminGroups, adminGroupsHandler.HandleList)
+	r.Post(RouteAdminGroups, adminGroupsHandler.HandleCreate)
+	r.Get(RouteAdminGroup, adminGroupsHandler.HandleGet)
+	r.Put(RouteAdminGroup, adminGroupsHandler.HandleUpdate)
+	r.Delete(RouteAdminGroup, adminGroupsHandler.HandleDelete)
+
+	r.Get(RouteAdminKeys, adminKeysHandler.HandleList)
+	r.Post(RouteAdminKeysRotate, adminKeysHandler.HandleRotate)
+
+	r.Post(RouteAdminConfigReload, adminReloadHandler.HandleReload)
+
+	r.Get(RouteAdminAudit, adminAuditHandler.HandleList)
+	r.Get(RouteAdminAuditVerify, adminAuditHandler.HandleVerify)
+
+	r.Get(RouteAdminPeers, adminPeersHandler.HandleOverview)
+	r.Get(RouteAdminPeersDecision, adminPeersHandler.HandleDecision)
+	r.Post(RouteAdminPeersAllow, adminPeersHandler.HandleAddAllow)
+	r.Delete(RouteAdminPeersAllowHost, adminPeersHandler.HandleRemoveAllow)
+	r.Post(RouteAdminPeersDeny, adminPeersHandler.HandleAddDeny)
+	r.Delete(RouteAdminPeersDenyHost, adminPeersHandler.HandleRemoveDeny)
+	r.Post(RouteAdminPeersTrustGroups, adminPeersHandler.HandleAttachTrustGroup)
+	r.Delete(RouteAdminPeersTrustGroup, adminPeersHandler.HandleDetachTrustGroup)
+	r.Post(RouteAdminPeersTrustGroupsRefresh, adminPeersHandler.HandleRefreshTrustGroups)
 
 	return s, nil
 }
@@ -243,7 +462,35 @@ func (s *Service) Prefix() string {
 	return string(service.BuildAPI)
 }
 
-// Close performs no cleanup for this service; implements service.Service.
+// Close stops the key rotator, the expiry janitor, and the datatx and outbox
+// workers; queued messages and unfinished transfers stay persisted for the
+// next start. Implements service.Service.
 func (s *Service) Close() error {
+	if s.rotator != nil {
+		if err := s.rotator.Close(); err != nil {
+			return fmt.Errorf("api: close key rotator: %w", err)
+		}
+	}
+
+	if s.expiry != nil {
+		if err := s.expiry.Close(); err != nil {
+			return fmt.Errorf("api: close expiry janitor: %w", err)
+		}
+	}
+
+	if s.transfers != nil {
+		if err := s.transfers.Close(); err != nil {
+			return fmt.Errorf("api: close datatx transfers: %w", err)
+		}
+	}
+
+	if s.outbox == nil {
+		return nil
+	}
+
+	if err := s.outbox.Close(); err != nil {
+		return fmt.Errorf("api: close outbox: %w", err)
+	}
+
 	return nil
 }
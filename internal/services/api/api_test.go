@@ -14,8 +14,11 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
 	_ "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache/loader"
+	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
 )
 
 func TestNew_FailsWithoutRequiredInputs(t *testing.T) {
@@ -94,6 +97,89 @@ func TestService_Close(t *testing.T) {
 	}
 }
 
+func TestService_CloseStopsOutbox(t *testing.T) {
+	t.Parallel()
+
+	var logBuf testLogBuffer
+
+	log := slog.New(slog.NewJSONHandler(&logBuf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	inputs := testAPIInputs(t)
+	inputs.OutboxRepo = tsrepos.OpenMemory(t).Outbox
+
+	m := map[string]any{
+		"outbox": map[string]any{
+			"max_attempts":            5,
+			"initial_backoff_seconds": 10,
+			"max_backoff_seconds":     600,
+			"poll_interval_seconds":   1,
+			"attempt_timeout_seconds": 15,
+		},
+	}
+
+	svc, err := New(inputs, m, log)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if logBuf.contains("unused config keys") {
+		t.Errorf("outbox config keys reported as unused: %s", logBuf.data)
+	}
+
+	if svc.(*Service).outbox == nil {
+		t.Fatal("expected an outbox dispatcher when OutboxRepo is set")
+	}
+
+	if err := svc.Close(); err != nil {
+		t.Errorf("unexpected error on Close: %v", err)
+	}
+}
+
+func TestService_CloseStopsTransfers(t *testing.T) {
+	t.Parallel()
+
+	inputs := testAPIInputs(t)
+	inputs.TransferRepo = tsrepos.OpenMemory(t).Transfers
+	inputs.DataTx.StorageDir = t.TempDir()
+
+	svc, err := New(inputs, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if svc.(*Service).transfers == nil {
+		t.Fatal("expected a datatx manager when TransferRepo is set")
+	}
+
+	if err := svc.Close(); err != nil {
+		t.Errorf("unexpected error on Close: %v", err)
+	}
+}
+
+func TestOutboxConfig_Policy(t *testing.T) {
+	t.Parallel()
+
+	got := OutboxConfig{
+		MaxAttempts:           5,
+		InitialBackoffSeconds: 10,
+		MaxBackoffSeconds:     600,
+		PollIntervalSeconds:   2,
+		AttemptTimeoutSeconds: 15,
+	}.Policy()
+
+	want := outbox.Policy{
+		MaxAttempts:    5,
+		InitialBackoff: 10 * time.Second,
+		MaxBackoff:     10 * time.Minute,
+		PollInterval:   2 * time.Second,
+		AttemptTimeout: 15 * time.Second,
+	}
+
+	if got != want {
+		t.Errorf("Policy() = %+v, want %+v", got, want)
+	}
+}
+
 func TestService_HealthzEndpoint(t *testing.T) {
 	t.Parallel()
 
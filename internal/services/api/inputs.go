@@ -6,15 +6,24 @@
 package api
 
 import (
+	adminaudit "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/audit"
+	adminreload "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/reload"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery"
 	invitesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/incoming"
 	invitesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peerorigin"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/policy"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
 	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
 	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/interceptors/ratelimit"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
 	httpclient "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/client"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/localidentity"
@@ -28,20 +37,36 @@ type outgoingFactsResolver interface {
 // Inputs holds dependencies for the API service constructor.
 type Inputs struct {
 	PartyRepo             identity.PartyRepo
+	GroupRepo             identity.GroupRepo
 	SessionRepo           identity.SessionRepo
 	UserAuth              *identity.UserAuth
 	IncomingShareRepo     sharesincoming.IncomingShareRepo
 	OutgoingShareRepo     sharesoutgoing.OutgoingShareRepo
 	IncomingInviteRepo    invitesincoming.IncomingInviteRepo
 	OutgoingInviteRepo    invitesoutgoing.OutgoingInviteRepo
+	ShareRequestRepo      sharerequests.Repo
+	TokenStore            token.TokenStore
+	OutboxRepo            outbox.MessageRepo
+	TransferRepo          datatx.Repo
 	HTTPClient            *httpclient.ContextClient
 	DiscoveryClient       *discovery.Client
 	Signer                *crypto.RFC9421Signer
+	KeyManager            *crypto.KeyManager
+	KeyRotation           config.SignatureRotationConfig
+	Reloader              adminreload.Reloader
+	Audit                 audit.Recorder
+	AuditLog              adminaudit.Log
+	PolicyEngine          *peertrust.PolicyEngine
+	TrustGroupMgr         *peertrust.TrustGroupManager
+	PeerTrustRepo         peertrust.Repo
 	PeerOrigin            *peerorigin.Resolver
 	OutgoingFactsResolver outgoingFactsResolver
 	LocalTokenEndpoint    string
 	LocalIdentity         localidentity.Identity
 	ContentDir            string
+	Webapp                config.WebappConfig
+	SSHAddr               string
+	DataTx                config.DataTxConfig
 	Ratelimit             ratelimit.Inputs
 	InterceptorProfiles   map[string]map[string]any
 }
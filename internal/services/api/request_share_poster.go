@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbound"
+	sharerequestsoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests/outgoing"
+)
+
+// requestSharePoster adapts outbound.Poster to the sharerequests/outgoing
+// RequestSharePoster domain port, fixing the request-share endpoint kind and
+// path.
+type requestSharePoster struct {
+	poster *outbound.Poster
+}
+
+var _ sharerequestsoutgoing.RequestSharePoster = (*requestSharePoster)(nil)
+
+// NewRequestSharePoster wires an outbound.Poster as the request-share domain
+// poster.
+func NewRequestSharePoster(poster *outbound.Poster) sharerequestsoutgoing.RequestSharePoster {
+	return &requestSharePoster{poster: poster}
+}
+
+// PostRequestShare posts the request-share protocol call to the owner's server.
+func (a *requestSharePoster) PostRequestShare(ctx context.Context, targetHost string, body []byte) (*http.Response, error) {
+	resp, err := a.poster.Send(ctx, outbound.Request{
+		TargetHost:   targetHost,
+		EndpointPath: "request-share",
+		Kind:         outbound.EndpointRequestShare,
+		Body:         body,
+	})
+	if err != nil {
+		return resp, fmt.Errorf("services: post request share: %w", err)
+	}
+
+	return resp, nil
+}
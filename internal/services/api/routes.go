@@ -20,6 +20,8 @@ const (
 	RouteAuthLogout = "/auth/logout"
 	// RouteAuthMe is the API current-user route path.
 	RouteAuthMe = "/auth/me"
+	// RouteAuthMeSSHKeys is the API current-user SSH public keys route path.
+	RouteAuthMeSSHKeys = "/auth/me/ssh-keys"
 	// RouteInboxShares is the API inbox shares list route path.
 	RouteInboxShares = "/inbox/shares"
 	// RouteInboxShareDetail is the API inbox share detail route path.
@@ -30,6 +32,10 @@ const (
 	RouteInboxShareDecline = "/inbox/shares/{shareId}/decline"
 	// RouteInboxShareVerifyAccess is the API inbox share verify-access route path.
 	RouteInboxShareVerifyAccess = "/inbox/shares/{shareId}/verify-access"
+	// RouteInboxShareOpenWebapp is the API inbox share open-webapp route path.
+	RouteInboxShareOpenWebapp = "/inbox/shares/{shareId}/open-webapp"
+	// RouteInboxShareContent is the API inbox share content route path.
+	RouteInboxShareContent = "/inbox/shares/{shareId}/content"
 	// RouteInboxInvites is the API inbox invites list route path.
 	RouteInboxInvites = "/inbox/invites"
 	// RouteInboxInviteImport is the API inbox invite import route path.
@@ -38,10 +44,62 @@ const (
 	RouteInboxInviteAccept = "/inbox/invites/{inviteId}/accept"
 	// RouteInboxInviteDecline is the API inbox invite decline route path.
 	RouteInboxInviteDecline = "/inbox/invites/{inviteId}/decline"
+	// RouteInboxShareRequests is the API share request inbox list route path.
+	RouteInboxShareRequests = "/inbox/share-requests"
+	// RouteInboxShareRequestApprove is the API share request approve route path.
+	RouteInboxShareRequestApprove = "/inbox/share-requests/{requestId}/approve"
+	// RouteInboxShareRequestDecline is the API share request decline route path.
+	RouteInboxShareRequestDecline = "/inbox/share-requests/{requestId}/decline"
+	// RouteShareRequestsOutgoing is the API outgoing share request route path.
+	RouteShareRequestsOutgoing = "/share-requests/outgoing"
 	// RouteSharesOutgoing is the API outgoing shares route path.
 	RouteSharesOutgoing = "/shares/outgoing"
+	// RouteSharesOutgoingDetail is the API single outgoing share route path.
+	RouteSharesOutgoingDetail = "/shares/outgoing/{shareId}"
 	// RouteInvitesOutgoing is the API outgoing invites route path.
 	RouteInvitesOutgoing = "/invites/outgoing"
+	// RouteAdminUsers is the admin user list and create route path.
+	RouteAdminUsers = "/admin/users"
+	// RouteAdminUsersExpireProbes is the admin expired probe user cleanup route path.
+	RouteAdminUsersExpireProbes = "/admin/users/expire-probes"
+	// RouteAdminUser is the admin single user route path.
+	RouteAdminUser = "/admin/users/{userId}"
+	// RouteAdminUserPassword is the admin password reset route path.
+	RouteAdminUserPassword = "/admin/users/{userId}/password"
+	// RouteAdminUserRole is the admin role change route path.
+	RouteAdminUserRole = "/admin/users/{userId}/role"
+	// RouteAdminGroups is the admin group list and create route path.
+	RouteAdminGroups = "/admin/groups"
+	// RouteAdminGroup is the admin single group route path.
+	RouteAdminGroup = "/admin/groups/{groupId}"
+	// RouteAdminKeys is the admin signing keyring list route path.
+	RouteAdminKeys = "/admin/keys"
+	// RouteAdminKeysRotate is the admin signing key rotation route path.
+	RouteAdminKeysRotate = "/admin/keys/rotate"
+	// RouteAdminConfigReload is the admin config reload route path.
+	RouteAdminConfigReload = "/admin/config/reload"
+	// RouteAdminAudit is the admin audit log query route path.
+	RouteAdminAudit = "/admin/audit"
+	// RouteAdminAuditVerify is the admin audit hash chain check route path.
+	RouteAdminAuditVerify = "/admin/audit/verify"
+	// RouteAdminPeers is the admin peer trust overview route path.
+	RouteAdminPeers = "/admin/peers"
+	// RouteAdminPeersDecision is the admin peer policy decision route path.
+	RouteAdminPeersDecision = "/admin/peers/decision"
+	// RouteAdminPeersAllow is the admin runtime allowlist add route path.
+	RouteAdminPeersAllow = "/admin/peers/allow"
+	// RouteAdminPeersAllowHost is the admin runtime allowlist entry route path.
+	RouteAdminPeersAllowHost = "/admin/peers/allow/{host}"
+	// RouteAdminPeersDeny is the admin runtime denylist add route path.
+	RouteAdminPeersDeny = "/admin/peers/deny"
+	// RouteAdminPeersDenyHost is the admin runtime denylist entry route path.
+	RouteAdminPeersDenyHost = "/admin/peers/deny/{host}"
+	// RouteAdminPeersTrustGroups is the admin trust group attach route path.
+	RouteAdminPeersTrustGroups = "/admin/peers/trust-groups"
+	// RouteAdminPeersTrustGroup is the admin single trust group route path.
+	RouteAdminPeersTrustGroup = "/admin/peers/trust-groups/{trustGroupId}"
+	// RouteAdminPeersTrustGroupsRefresh is the admin trust group refresh route path.
+	RouteAdminPeersTrustGroupsRefresh = "/admin/peers/trust-groups/refresh"
 )
 
 func init() {
@@ -91,6 +149,26 @@ func registeredRouteSpecs(service.RouteOpts) []service.RouteSpec {
 			SurfaceClass:  service.SurfaceAPI,
 			TrustClass:    service.TrustPeerNone,
 		},
+		{
+			ID:            "api-auth-me-ssh-keys-get",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteAuthMeSSHKeys,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-auth-me-ssh-keys-put",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPut,
+			Pattern:       RouteAuthMeSSHKeys,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
 		{
 			ID:            "api-inbox-shares-list",
 			Service:       string(service.BuildAPI),
@@ -142,6 +220,28 @@ func registeredRouteSpecs(service.RouteOpts) []service.RouteSpec {
 			OutboundProtocolKind: service.OutboundAccess,
 			TrustClass:           service.TrustPeerNone,
 		},
+		{
+			ID:                   "api-inbox-share-open-webapp",
+			Service:              string(service.BuildAPI),
+			Method:               http.MethodPost,
+			Pattern:              RouteInboxShareOpenWebapp,
+			SessionPolicy:        service.SessionProtected,
+			HandlerAuth:          service.HandlerAuthCurrentUser,
+			SurfaceClass:         service.SurfaceAPI,
+			OutboundProtocolKind: service.OutboundAccess,
+			TrustClass:           service.TrustPeerNone,
+		},
+		{
+			ID:                   "api-inbox-share-content",
+			Service:              string(service.BuildAPI),
+			Method:               http.MethodGet,
+			Pattern:              RouteInboxShareContent,
+			SessionPolicy:        service.SessionProtected,
+			HandlerAuth:          service.HandlerAuthCurrentUser,
+			SurfaceClass:         service.SurfaceAPI,
+			OutboundProtocolKind: service.OutboundAccess,
+			TrustClass:           service.TrustPeerNone,
+		},
 		{
 			ID:            "api-inbox-invites-list",
 			Service:       string(service.BuildAPI),
@@ -185,6 +285,48 @@ func registeredRouteSpecs(service.RouteOpts) []service.RouteSpec {
 			OutboundProtocolKind: service.OutboundInvites,
 			TrustClass:           service.TrustPeerNone,
 		},
+		{
+			ID:            "api-inbox-share-requests-list",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteInboxShareRequests,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:                   "api-inbox-share-request-approve",
+			Service:              string(service.BuildAPI),
+			Method:               http.MethodPost,
+			Pattern:              RouteInboxShareRequestApprove,
+			SessionPolicy:        service.SessionProtected,
+			HandlerAuth:          service.HandlerAuthCurrentUser,
+			SurfaceClass:         service.SurfaceAPI,
+			OutboundProtocolKind: service.OutboundShares,
+			TrustClass:           service.TrustPeerNone,
+		},
+		{
+			ID:            "api-inbox-share-request-decline",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteInboxShareRequestDecline,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:                   "api-share-requests-outgoing",
+			Service:              string(service.BuildAPI),
+			Method:               http.MethodPost,
+			Pattern:              RouteShareRequestsOutgoing,
+			SessionPolicy:        service.SessionProtected,
+			HandlerAuth:          service.HandlerAuthCurrentUser,
+			SurfaceClass:         service.SurfaceAPI,
+			OutboundProtocolKind: service.OutboundShares,
+			TrustClass:           service.TrustPeerNone,
+		},
 		{
 			ID:                   "api-shares-outgoing",
 			Service:              string(service.BuildAPI),
@@ -196,6 +338,36 @@ func registeredRouteSpecs(service.RouteOpts) []service.RouteSpec {
 			OutboundProtocolKind: service.OutboundShares,
 			TrustClass:           service.TrustPeerNone,
 		},
+		{
+			ID:            "api-shares-outgoing-list",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteSharesOutgoing,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-shares-outgoing-detail",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteSharesOutgoingDetail,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-shares-outgoing-revoke",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodDelete,
+			Pattern:       RouteSharesOutgoingDetail,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
 		{
 			ID:            "api-invites-outgoing",
 			Service:       string(service.BuildAPI),
@@ -206,5 +378,255 @@ func registeredRouteSpecs(service.RouteOpts) []service.RouteSpec {
 			SurfaceClass:  service.SurfaceAPI,
 			TrustClass:    service.TrustPeerNone,
 		},
+		{
+			ID:            "api-admin-users-list",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteAdminUsers,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-users-create",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminUsers,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-users-expire-probes",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminUsersExpireProbes,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-user-password",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminUserPassword,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-user-role",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPut,
+			Pattern:       RouteAdminUserRole,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-user-delete",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodDelete,
+			Pattern:       RouteAdminUser,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-groups-list",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteAdminGroups,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-groups-create",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminGroups,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-group-get",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteAdminGroup,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-group-update",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPut,
+			Pattern:       RouteAdminGroup,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-group-delete",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodDelete,
+			Pattern:       RouteAdminGroup,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-keys-list",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteAdminKeys,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-keys-rotate",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminKeysRotate,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-config-reload",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminConfigReload,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-audit-list",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteAdminAudit,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-audit-verify",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteAdminAuditVerify,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-peers-overview",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteAdminPeers,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-peers-decision",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodGet,
+			Pattern:       RouteAdminPeersDecision,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-peers-allow-add",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminPeersAllow,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-peers-allow-remove",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodDelete,
+			Pattern:       RouteAdminPeersAllowHost,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-peers-deny-add",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminPeersDeny,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-peers-deny-remove",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodDelete,
+			Pattern:       RouteAdminPeersDenyHost,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-peers-trust-groups-attach",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminPeersTrustGroups,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-peers-trust-groups-detach",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodDelete,
+			Pattern:       RouteAdminPeersTrustGroup,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
+		{
+			ID:            "api-admin-peers-trust-groups-refresh",
+			Service:       string(service.BuildAPI),
+			Method:        http.MethodPost,
+			Pattern:       RouteAdminPeersTrustGroupsRefresh,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthCurrentUser,
+			SurfaceClass:  service.SurfaceAPI,
+			TrustClass:    service.TrustPeerNone,
+		},
 	}
 }
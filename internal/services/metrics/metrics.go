@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package metrics provides the /metrics Prometheus scrape endpoint as a registry service.
+package metrics
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service"
+	svccfg "github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service/cfg"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service/httpwrap"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+	platformmetrics "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/metrics"
+)
+
+// Config holds metrics service configuration.
+type Config struct {
+	// BearerToken, when set, must be presented as "Authorization: Bearer <token>"
+	// by scrapers. Empty leaves the endpoint open.
+	BearerToken string `mapstructure:"bearer_token"`
+}
+
+// ApplyDefaults implements cfg.Setter.
+func (c *Config) ApplyDefaults() {}
+
+// Service is the metrics service.
+type Service struct {
+	router chi.Router
+	conf   *Config
+	log    *slog.Logger
+}
+
+// New creates a new metrics service.
+func New(m map[string]any, log *slog.Logger) (service.Service, error) {
+	log = logutil.NoopIfNil(log)
+
+	var c Config
+
+	unused, err := svccfg.DecodeWithUnused(m, &c)
+	if err != nil {
+		return nil, fmt.Errorf("services: decode metrics config: %w", err)
+	}
+
+	if len(unused) > 0 {
+		log.Warn("unused config keys", "service", "metrics", "unused_keys", unused)
+	}
+
+	if c.BearerToken == "" {
+		log.Info("metrics endpoint is unauthenticated; set http.services.metrics.bearer_token to restrict scrapes")
+	}
+
+	r := chi.NewRouter()
+	r.With(requireBearer(c.BearerToken)).Get(RouteMetrics, platformmetrics.Handler().ServeHTTP)
+
+	return &Service{router: r, conf: &c, log: log}, nil
+}
+
+// requireBearer rejects requests without the configured token. An empty
+// token disables the check.
+func requireBearer(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if token == "" {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Handler returns the service HTTP handler; implements service.Service.
+func (s *Service) Handler() http.Handler {
+	return httpwrap.ClearRawPath(s.router)
+}
+
+// Prefix returns the service URL prefix; implements service.Service.
+func (s *Service) Prefix() string {
+	return "metrics"
+}
+
+// Close performs no cleanup for this service; implements service.Service.
+func (s *Service) Close() error {
+	return nil
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service"
+)
+
+func TestNew_OpenEndpointServesScrape(t *testing.T) {
+	t.Parallel()
+
+	svc, err := New(nil, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if svc.Prefix() != "metrics" {
+		t.Errorf("Prefix() = %q, want metrics", svc.Prefix())
+	}
+
+	rec := httptest.NewRecorder()
+	svc.Handler().ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	if !strings.Contains(rec.Body.String(), "go_goroutines") {
+		t.Error("scrape output missing go_goroutines")
+	}
+}
+
+func TestNew_BearerTokenRequired(t *testing.T) {
+	t.Parallel()
+
+	svc, err := New(map[string]any{"bearer_token": "scrape-secret"}, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "missing", header: "", want: http.StatusUnauthorized},
+		{name: "wrong", header: "Bearer nope", want: http.StatusUnauthorized},
+		{name: "not bearer", header: "Basic scrape-secret", want: http.StatusUnauthorized},
+		{name: "valid", header: "Bearer scrape-secret", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			svc.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisteredRouteSpecs(t *testing.T) {
+	t.Parallel()
+
+	specs := registeredRouteSpecs(service.DefaultRouteOpts())
+	if len(specs) != 1 {
+		t.Fatalf("expected 1 route spec, got %d", len(specs))
+	}
+
+	if specs[0].SurfaceClass != service.SurfaceMetrics {
+		t.Errorf("surface = %q, want metrics", specs[0].SurfaceClass)
+	}
+
+	if specs[0].SessionPolicy != service.SessionPublic {
+		t.Errorf("session = %q, want public", specs[0].SessionPolicy)
+	}
+}
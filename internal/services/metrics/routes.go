@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package metrics
+
+import (
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service"
+)
+
+const (
+	// RouteMetrics is the Prometheus scrape route path (mounted as /metrics).
+	RouteMetrics = "/"
+)
+
+func init() {
+	service.RegisterRouteSpecs(registeredRouteSpecs)
+}
+
+func registeredRouteSpecs(service.RouteOpts) []service.RouteSpec {
+	return []service.RouteSpec{
+		{
+			ID:            "metrics-scrape",
+			Service:       "metrics",
+			Method:        "GET",
+			Pattern:       RouteMetrics,
+			SessionPolicy: service.SessionPublic,
+			HandlerAuth:   service.HandlerAuthBearer,
+			SurfaceClass:  service.SurfaceMetrics,
+			TrustClass:    service.TrustPeerNone,
+		},
+	}
+}
@@ -6,15 +6,19 @@
 package ocm
 
 import (
+	"time"
+
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
 	inboundsignature "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/inbound/signature"
 	invitesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/incoming"
 	invitesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/policy"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
 	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
 	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/localidentity"
 )
@@ -25,7 +29,9 @@ type Inputs struct {
 	OutgoingShareRepo   sharesoutgoing.OutgoingShareRepo
 	IncomingInviteRepo  invitesincoming.IncomingInviteRepo
 	OutgoingInviteRepo  invitesoutgoing.OutgoingInviteRepo
+	ShareRequestRepo    sharerequests.Repo
 	PartyRepo           identity.PartyRepo
+	GroupRepo           identity.GroupRepo
 	PolicyEngine        *peertrust.PolicyEngine
 	CodeFlow            *policy.CodeFlow
 	PeerMappingResolver *policy.PeerMappingResolver
@@ -33,7 +39,13 @@ type Inputs struct {
 	TokenStore          token.TokenStore
 	SignatureMiddleware *inboundsignature.SignatureMiddleware
 	TokenExchangePath   string
-	KeyManager          *crypto.KeyManager
+	// AccessTokenTTL and RefreshTokenTTL come from [token_exchange]; zero
+	// keeps the token handler defaults.
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	KeyManager      *crypto.KeyManager
 	// MustInviteEnforced gates inbound share creation on an exchanged invite.
 	MustInviteEnforced bool
+	// Audit records share, invite and token events; nil disables it.
+	Audit audit.Recorder
 }
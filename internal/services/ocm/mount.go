@@ -20,6 +20,7 @@ type routeHandlers struct {
 	inviteAccepted http.HandlerFunc
 	token          http.HandlerFunc
 	notifications  http.HandlerFunc
+	requestShare   http.HandlerFunc
 }
 
 func mountProtocolRoutes(
@@ -77,6 +78,8 @@ func handlerForRow(row service.RouteRow, handlers routeHandlers) (http.HandlerFu
 		return handlers.inviteAccepted, nil
 	case "ocm-notifications":
 		return handlers.notifications, nil
+	case "ocm-request-share":
+		return handlers.requestShare, nil
 	case service.RouteIDOCMToken:
 		return handlers.token, nil
 	default:
@@ -115,6 +118,8 @@ func middlewaresForRow(
 		middlewares = append(middlewares, sig.VerifyOCMRequestRequireSignatureAndPeer(peerResolver.ResolveTokenRequest))
 	case service.PeerResolutionNotifications:
 		middlewares = append(middlewares, sig.VerifyOCMRequestRequireSignature())
+	case service.PeerResolutionRequestShare:
+		middlewares = append(middlewares, sig.VerifyOCMRequestRequireSignatureAndPeer(peerResolver.ResolveRequestShareRequest))
 	default:
 		return nil, fmt.Errorf("ocm: route %q missing peer resolution metadata", row.ID)
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/outgoing/accepted"
 	notificationsincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/notifications/incoming"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peer"
+	sharerequestsincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests/incoming"
 	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
 	tokenincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token/incoming"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service"
@@ -79,6 +80,7 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 	sharesHandler := sharesincoming.NewHandler(
 		inputs.IncomingShareRepo,
 		inputs.PartyRepo,
+		inputs.GroupRepo,
 		inputs.PolicyEngine,
 		inputs.IncomingInviteRepo,
 		inputs.OutgoingInviteRepo,
@@ -101,12 +103,28 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 		inputs.CodeFlow,
 		inputs.LocalIdentity.Origin,
 	)
+	tokenHandler.SetTokenTTLs(inputs.AccessTokenTTL, inputs.RefreshTokenTTL)
+	sharesHandler.SetAudit(inputs.Audit)
+	invitesHandler.SetAudit(inputs.Audit)
+	tokenHandler.SetAudit(inputs.Audit)
+
 	notificationsHandler := notificationsincoming.NewHandler(
 		inputs.OutgoingShareRepo,
 		inputs.IncomingShareRepo,
 		inputs.LocalIdentity.Scheme,
 		log,
 	)
+	notificationsHandler.SetAudit(inputs.Audit)
+
+	requestShareHandler := sharerequestsincoming.NewHandler(
+		inputs.ShareRequestRepo,
+		inputs.OutgoingShareRepo,
+		inputs.PartyRepo,
+		inputs.PolicyEngine,
+		inputs.LocalIdentity.ProviderDomain,
+		inputs.LocalIdentity.ProviderDomainCompare,
+		inputs.LocalIdentity.Scheme,
+	)
 
 	peerResolver := peer.NewResolver()
 	r := chi.NewRouter()
@@ -120,6 +138,7 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 		inviteAccepted: invitesHandler.HandleInviteAccepted,
 		token:          tokenHandler.HandleToken,
 		notifications:  notificationsHandler.HandleNotification,
+		requestShare:   requestShareHandler.HandleRequestShare,
 	}, peerResolver); err != nil {
 		return nil, err
 	}
@@ -57,6 +57,7 @@ func expectedOCMPostPaths(t *testing.T) []string {
 		"/ocm" + RouteShares,
 		"/ocm" + RouteInviteAccepted,
 		"/ocm" + RouteNotifications,
+		"/ocm" + RouteRequestShare,
 		tokenPath,
 	}
 	slices.Sort(paths)
@@ -101,7 +102,7 @@ func TestOCMPostRoutes_RequireSignatures(t *testing.T) {
 			switch mountedPath {
 			case "/ocm" + RouteInviteAccepted:
 				body = []byte(`{"recipientProvider":"remote.example","token":"invite-token","userID":"user-1","email":"user@remote.example","name":"Remote User"}`)
-			case "/ocm" + RouteShares:
+			case "/ocm" + RouteShares, "/ocm" + RouteRequestShare:
 			default:
 				contentType = "application/x-www-form-urlencoded"
 				body = []byte("grant_type=authorization_code&client_id=remote.example&code=secret-code")
@@ -172,6 +173,7 @@ func TestService_RoutingSmoke(t *testing.T) {
 		{"shares", "/shares"},
 		{"invite-accepted", "/invite-accepted"},
 		{"notifications", "/notifications"},
+		{"request-share", "/request-share"},
 		{"token", "/token"},
 	}
 
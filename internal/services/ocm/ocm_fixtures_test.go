@@ -151,6 +151,22 @@ func (s *identityCapturingTokenStore) Delete(ctx context.Context, accessToken st
 	return nil
 }
 
+func (s *identityCapturingTokenStore) Claim(ctx context.Context, accessToken string) error {
+	if err := s.inner.Claim(ctx, accessToken); err != nil {
+		return fmt.Errorf("services: claim token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *identityCapturingTokenStore) DeleteByShareID(ctx context.Context, shareID string) error {
+	if err := s.inner.DeleteByShareID(ctx, shareID); err != nil {
+		return fmt.Errorf("services: delete tokens by share: %w", err)
+	}
+
+	return nil
+}
+
 func (s *identityCapturingTokenStore) CleanExpired(ctx context.Context) error {
 	if err := s.inner.CleanExpired(ctx); err != nil {
 		return fmt.Errorf("services: clean expired tokens: %w", err)
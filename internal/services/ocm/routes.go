@@ -18,6 +18,8 @@ const (
 	RouteInviteAccepted = "/invite-accepted"
 	// RouteNotifications is the OCM notifications route path.
 	RouteNotifications = "/notifications"
+	// RouteRequestShare is the OCM request-share route path.
+	RouteRequestShare = "/request-share"
 	// RouteJWKS is the OCM local JWKS route path.
 	RouteJWKS = "/jwks"
 )
@@ -75,6 +77,18 @@ func registeredRouteSpecs(opts service.RouteOpts) []service.RouteSpec {
 			BodyLimitBytes: service.OCMProtocolBodyLimitBytes,
 			PeerResolution: service.PeerResolutionNotifications,
 		},
+		{
+			ID:             "ocm-request-share",
+			Service:        string(service.BuildOCM),
+			Method:         http.MethodPost,
+			Pattern:        RouteRequestShare,
+			SessionPolicy:  service.SessionPublic,
+			HandlerAuth:    service.HandlerAuthRequiredHTTPSig,
+			SurfaceClass:   service.SurfaceProtocol,
+			TrustClass:     service.TrustPeerRequired,
+			BodyLimitBytes: service.OCMProtocolBodyLimitBytes,
+			PeerResolution: service.PeerResolutionRequestShare,
+		},
 		{
 			ID:              service.RouteIDOCMToken,
 			Service:         string(service.BuildOCM),
@@ -25,7 +25,8 @@ import (
 
 // Config holds ocmaux service configuration.
 type Config struct {
-	Ratelimit RatelimitConfig `mapstructure:"ratelimit"`
+	Ratelimit   RatelimitConfig                `mapstructure:"ratelimit"`
+	Federations ocmauxcomp.FederationsSettings `mapstructure:"federations"`
 }
 
 // RatelimitConfig holds the per-service rate limiting opt-in.
@@ -34,7 +35,9 @@ type RatelimitConfig struct {
 }
 
 // ApplyDefaults implements cfg.Setter.
-func (c *Config) ApplyDefaults() {}
+func (c *Config) ApplyDefaults() {
+	c.Federations.ApplyDefaults()
+}
 
 // Service is the ocm-aux service.
 type Service struct {
@@ -62,7 +65,11 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 		log.Warn("unused config keys", "service", "ocmaux", "unused_keys", unused)
 	}
 
-	auxHandler := ocmauxcomp.NewAuxHandler(inputs.TrustGroupMgr, inputs.DiscoveryClient, log)
+	if err := c.Federations.Validate(); err != nil {
+		return nil, fmt.Errorf("ocmaux: %w", err)
+	}
+
+	auxHandler := ocmauxcomp.NewAuxHandlerWithSettings(inputs.TrustGroupMgr, inputs.DiscoveryClient, c.Federations, log)
 
 	var discoverMiddleware func(http.Handler) http.Handler
 
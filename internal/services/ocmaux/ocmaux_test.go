@@ -175,6 +175,19 @@ func TestNew_WarnsOnUnusedConfigKeys(t *testing.T) {
 	}
 }
 
+func TestNew_RejectsInvalidFederationsSettings(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]any{
+		"federations": map[string]any{"enrich_concurrency": -1},
+	}
+
+	_, err := New(testOCMAuxInputs(), m, testLog())
+	if err == nil || !strings.Contains(err.Error(), "federations.enrich_concurrency") {
+		t.Fatalf("err = %v, want federations.enrich_concurrency error", err)
+	}
+}
+
 type testLogBuffer struct {
 	data []byte
 }
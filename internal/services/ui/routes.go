@@ -19,6 +19,8 @@ const (
 	RouteInbox = "/inbox"
 	// RouteOutgoing is the UI outgoing route path.
 	RouteOutgoing = "/outgoing"
+	// RouteUsers is the UI admin user management route path.
+	RouteUsers = "/users"
 	// RouteWAYF is the UI WAYF route path.
 	RouteWAYF = "/wayf"
 	// RouteAcceptInvite is the UI accept-invite route path.
@@ -61,6 +63,16 @@ func registeredRouteSpecs(service.RouteOpts) []service.RouteSpec {
 			SurfaceClass:  service.SurfaceUI,
 			TrustClass:    service.TrustPeerNone,
 		},
+		{
+			ID:            "ui-users",
+			Service:       "ui",
+			Method:        http.MethodGet,
+			Pattern:       RouteUsers,
+			SessionPolicy: service.SessionProtected,
+			HandlerAuth:   service.HandlerAuthNone,
+			SurfaceClass:  service.SurfaceUI,
+			TrustClass:    service.TrustPeerNone,
+		},
 		{
 			ID:               service.RouteIDUIWAYF,
 			Service:          "ui",
@@ -23,8 +23,8 @@ func TestRegisteredRouteSpecs_BaseRoutes(t *testing.T) {
 		}
 	}
 
-	if baseCount != 3 {
-		t.Fatalf("expected 3 base ui route specs, got %d", baseCount)
+	if baseCount != 4 {
+		t.Fatalf("expected 4 base ui route specs, got %d", baseCount)
 	}
 }
 
@@ -45,8 +45,8 @@ func TestRegisteredRouteSpecs_WayfAndAcceptInviteSeparate(t *testing.T) {
 		}
 	}
 
-	if len(specs) != 5 {
-		t.Fatalf("expected 5 ui route specs with WAYF, got %d", len(specs))
+	if len(specs) != 6 {
+		t.Fatalf("expected 6 ui route specs with WAYF, got %d", len(specs))
 	}
 
 	var wayfSpec, acceptSpec *service.RouteSpec
@@ -70,6 +70,7 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 	r.Get(RouteLogin, uiHandler.Login)
 	r.Get(RouteInbox, uiHandler.Inbox)
 	r.Get(RouteOutgoing, uiHandler.Outgoing)
+	r.Get(RouteUsers, uiHandler.Users)
 
 	if c.Wayf.Enabled {
 		r.Get(RouteWAYF, uiHandler.Wayf)
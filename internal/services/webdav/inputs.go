@@ -8,10 +8,13 @@ package webdav
 import (
 	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 )
 
 // Inputs holds dependencies for the WebDAV service constructor.
 type Inputs struct {
 	OutgoingShareRepo sharesoutgoing.OutgoingShareRepo
 	TokenStore        token.TokenStore
+	// SSH starts the built-in SFTP server for ssh shares when enabled.
+	SSH config.SSHConfig
 }
@@ -6,6 +6,8 @@
 package webdav
 
 import (
+	"github.com/go-chi/chi/v5"
+
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service"
 )
 
@@ -14,7 +16,16 @@ const (
 	RouteOCMWildcard = "/ocm/*"
 )
 
+// webdavMethods are the RFC 4918 methods chi does not know. chi answers
+// unknown methods with 405 before any handler runs, so they are registered
+// up front, before any router is built.
+var webdavMethods = []string{"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK"}
+
 func init() {
+	for _, method := range webdavMethods {
+		chi.RegisterMethod(method)
+	}
+
 	service.RegisterRouteSpecs(registeredRouteSpecs)
 }
 
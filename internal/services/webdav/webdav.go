@@ -7,12 +7,15 @@
 package webdav
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/sftp"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/webdav"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service"
 	svccfg "github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service/cfg"
@@ -32,6 +35,8 @@ type Service struct {
 	conf    *Config
 	log     *slog.Logger
 	handler *webdav.Handler
+	// sftp is the built-in SFTP server, nil when [ocm.ssh] is disabled.
+	sftp *sftp.Server
 }
 
 // New creates a new WebDAV service from narrow injected inputs.
@@ -58,7 +63,41 @@ func New(inputs Inputs, m map[string]any, log *slog.Logger) (service.Service, er
 	r := chi.NewRouter()
 	r.HandleFunc(RouteOCMWildcard, handler.ServeHTTP)
 
-	return &Service{router: r, conf: &c, log: log, handler: handler}, nil
+	var sftpServer *sftp.Server
+	if inputs.SSH.Enabled() {
+		if sftpServer, err = startSFTP(inputs, log); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Service{router: r, conf: &c, log: log, handler: handler, sftp: sftpServer}, nil
+}
+
+// startSFTP listens on [ocm.ssh] listen_addr and serves ssh shares in the
+// background until Close.
+func startSFTP(inputs Inputs, log *slog.Logger) (*sftp.Server, error) {
+	hostKey, err := sftp.LoadOrCreateHostKey(inputs.SSH.HostKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("services: load sftp host key: %w", err)
+	}
+
+	ln, err := net.Listen("tcp", inputs.SSH.ListenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("services: listen for sftp: %w", err)
+	}
+
+	sftpLog := log.With("component", "sftp")
+	server := sftp.NewServer(inputs.OutgoingShareRepo, hostKey, sftpLog)
+
+	go func() {
+		if err := server.Serve(ln); err != nil && !errors.Is(err, net.ErrClosed) {
+			sftpLog.Error("SFTP server stopped", "error", err)
+		}
+	}()
+
+	sftpLog.Info("SFTP server listening", "addr", ln.Addr().String())
+
+	return server, nil
 }
 
 // Handler returns the service HTTP handler; implements service.Service.
@@ -71,7 +110,15 @@ func (s *Service) Prefix() string {
 	return "webdav"
 }
 
-// Close performs no cleanup for this service; implements service.Service.
+// Close stops the SFTP server when one runs; implements service.Service.
 func (s *Service) Close() error {
+	if s.sftp == nil {
+		return nil
+	}
+
+	if err := s.sftp.Close(); err != nil {
+		return fmt.Errorf("webdav: close sftp server: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package webdav
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+)
+
+func TestNew_SSHDisabledStartsNoSFTPServer(t *testing.T) {
+	t.Parallel()
+
+	svc, err := New(testWebDAVInputs(t), map[string]any{}, testLog())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if s, ok := svc.(*Service); !ok || s.sftp != nil {
+		t.Fatal("expected no SFTP server without [ocm.ssh] listen_addr")
+	}
+
+	if err := svc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestNew_SSHEnabledStartsAndStopsSFTPServer(t *testing.T) {
+	t.Parallel()
+
+	hostKeyPath := filepath.Join(t.TempDir(), "keys", "ssh_host_ed25519.pem")
+
+	inputs := testWebDAVInputs(t)
+	inputs.SSH = config.SSHConfig{ListenAddr: "127.0.0.1:0", AdvertisedAddr: "sftp.example.org:2022", HostKeyPath: hostKeyPath}
+
+	svc, err := New(inputs, map[string]any{}, testLog())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if s, ok := svc.(*Service); !ok || s.sftp == nil {
+		t.Fatal("expected an SFTP server")
+	}
+
+	if _, err := os.Stat(hostKeyPath); err != nil {
+		t.Fatalf("host key not created: %v", err)
+	}
+
+	if err := svc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestNew_SSHListenFailure(t *testing.T) {
+	t.Parallel()
+
+	inputs := testWebDAVInputs(t)
+	inputs.SSH = config.SSHConfig{ListenAddr: "256.0.0.1:1", HostKeyPath: filepath.Join(t.TempDir(), "host.pem")}
+
+	if _, err := New(inputs, map[string]any{}, testLog()); err == nil {
+		t.Fatal("expected New to fail when the SFTP listener cannot bind")
+	}
+}
@@ -181,6 +181,28 @@ func TestService_Handler(t *testing.T) {
 	}
 }
 
+// TestService_HandlerRoutesWebDAVMethods guards the chi method registration:
+// without it PROPFIND is refused with 405 before the WebDAV handler runs.
+func TestService_HandlerRoutesWebDAVMethods(t *testing.T) {
+	t.Parallel()
+
+	svc, err := New(testWebDAVInputs(t), map[string]any{}, testLog())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, method := range webdavMethods {
+		req := httptest.NewRequestWithContext(context.Background(), method, "/ocm/11111111-1111-1111-1111-111111111111", nil)
+		w := httptest.NewRecorder()
+
+		svc.Handler().ServeHTTP(w, req)
+
+		if w.Code == http.StatusMethodNotAllowed {
+			t.Errorf("%s: refused by the router, want it routed to the WebDAV handler", method)
+		}
+	}
+}
+
 func TestService_Close(t *testing.T) {
 	t.Parallel()
 
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery/resolve"
@@ -17,7 +18,23 @@ import (
 )
 
 type ocmHandler struct {
+	// data is the document built at startup.
 	data *spec.Discovery
+
+	// params and lists rebuild the document when the peer trust lists gain
+	// or lose their last entry; lists is nil when peer trust is off.
+	params discovery.BuildParams
+	lists  resolve.PeerTrustLists
+	log    *slog.Logger
+	live   atomic.Pointer[listsDocument]
+}
+
+// listsDocument is the discovery document for one state of the peer trust
+// lists.
+type listsDocument struct {
+	denylist  bool
+	allowlist bool
+	disc      *spec.Discovery
 }
 
 func newOCMHandler(
@@ -31,14 +48,50 @@ func newOCMHandler(
 	built := resolve.Resolve(c, rawOCMProvider, in)
 	disc := discovery.BuildDiscovery(built.Params, log)
 
-	return &ocmHandler{
-		data: disc,
+	h := &ocmHandler{
+		data:   disc,
+		params: built.Params,
+		lists:  in.PeerTrustLists,
+		log:    log,
+	}
+	h.live.Store(&listsDocument{
+		denylist:  built.Params.AdvertiseDenylist,
+		allowlist: built.Params.AdvertiseAllowlist,
+		disc:      disc,
+	})
+
+	return h
+}
+
+// document returns the discovery document for the current peer trust lists.
+func (h *ocmHandler) document() *spec.Discovery {
+	if h.lists == nil {
+		return h.data
 	}
+
+	denylist, allowlist := h.lists.HasDenylist(), h.lists.HasAllowlist()
+
+	if cur := h.live.Load(); cur.denylist == denylist && cur.allowlist == allowlist {
+		return cur.disc
+	}
+
+	params := h.params
+	params.AdvertiseDenylist = denylist
+	params.AdvertiseAllowlist = allowlist
+
+	doc := &listsDocument{
+		denylist:  denylist,
+		allowlist: allowlist,
+		disc:      discovery.BuildDiscovery(params, h.log),
+	}
+	h.live.Store(doc)
+
+	return doc.disc
 }
 
 func (h *ocmHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	//nolint:errcheck,errchkjson // response already committed after WriteHeader; write error cannot be recovered or meaningfully handled; payload encodes to fixed JSON, so encode error is always nil
-	json.NewEncoder(w).Encode(h.data)
+	json.NewEncoder(w).Encode(h.document())
 }
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery/resolve"
@@ -87,3 +88,52 @@ func TestOCMHandler_ServeHTTP_DisabledDiscovery(t *testing.T) {
 		t.Error("expected Enabled=false in response")
 	}
 }
+
+// fakePeerTrustLists is a resolve.PeerTrustLists whose answers tests flip.
+type fakePeerTrustLists struct {
+	denylist, allowlist atomic.Bool
+}
+
+func (f *fakePeerTrustLists) HasDenylist() bool  { return f.denylist.Load() }
+func (f *fakePeerTrustLists) HasAllowlist() bool { return f.allowlist.Load() }
+
+func TestOCMHandler_ServeHTTP_FollowsLivePeerTrustLists(t *testing.T) {
+	t.Parallel()
+
+	lists := &fakePeerTrustLists{}
+	in := handlerResolveInputs(t, "")
+	in.PeerTrustLists = lists
+
+	h := newOCMHandler(&resolve.ProviderConfig{}, nil, in, testLogger())
+
+	criteria := func() *spec.Discovery {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/.well-known/ocm", nil))
+
+		var disc spec.Discovery
+		if err := json.NewDecoder(w.Body).Decode(&disc); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		return &disc
+	}
+
+	if disc := criteria(); disc.HasCriteria(spec.CriteriaDenylist) || disc.HasCriteria(spec.CriteriaAllowlist) {
+		t.Errorf("criteria = %v, want no list criteria while both lists are empty", disc.Criteria)
+	}
+
+	lists.denylist.Store(true)
+
+	if disc := criteria(); !disc.HasCriteria(spec.CriteriaDenylist) || disc.HasCriteria(spec.CriteriaAllowlist) {
+		t.Errorf("criteria = %v, want denylist only after an entry is added", disc.Criteria)
+	}
+
+	lists.denylist.Store(false)
+	lists.allowlist.Store(true)
+
+	if disc := criteria(); disc.HasCriteria(spec.CriteriaDenylist) || !disc.HasCriteria(spec.CriteriaAllowlist) {
+		t.Errorf("criteria = %v, want allowlist only", disc.Criteria)
+	}
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHKey is a generated ed25519 SSH key pair for tests.
+type SSHKey struct {
+	Signer ssh.Signer
+	// AuthorizedKey is the normalized authorized_keys line (no comment).
+	AuthorizedKey string
+}
+
+// MustSSHKey generates a fresh ed25519 SSH key pair.
+func MustSSHKey(tb testing.TB) SSHKey {
+	tb.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		tb.Fatalf("generate ed25519 key: %v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		tb.Fatalf("ssh signer: %v", err)
+	}
+
+	return SSHKey{
+		Signer:        signer,
+		AuthorizedKey: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))),
+	}
+}
@@ -8,14 +8,18 @@ package store
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
 )
 
 // RunDriverTests runs the standard test suite against a driver.
-// All four persistence surfaces are required: OutgoingShareStore,
-// IncomingShareStore, OutgoingInviteStore, and IncomingInviteStore.
+// Every persistence surface is required: OutgoingShareStore,
+// IncomingShareStore, OutgoingInviteStore, IncomingInviteStore, OutboxStore,
+// PartyStore, SessionStore, ShareRequestStore, GroupStore, TransferStore, and
+// PeerTrustStore.
 //
 // Each subtest creates and closes its own fresh driver in a subdirectory of
 // cfg.DataDir, so no store state leaks between siblings. A preflight driver is
@@ -44,6 +48,20 @@ func RunDriverTests(t *testing.T, driverName string, cfg *store.DriverConfig) {
 	requireDriverImplements(t, driverName, preflight.Close, ok, "OutgoingInviteStore")
 	_, ok = preflight.(store.IncomingInviteStore)
 	requireDriverImplements(t, driverName, preflight.Close, ok, "IncomingInviteStore")
+	_, ok = preflight.(store.OutboxStore)
+	requireDriverImplements(t, driverName, preflight.Close, ok, "OutboxStore")
+	_, ok = preflight.(store.PartyStore)
+	requireDriverImplements(t, driverName, preflight.Close, ok, "PartyStore")
+	_, ok = preflight.(store.SessionStore)
+	requireDriverImplements(t, driverName, preflight.Close, ok, "SessionStore")
+	_, ok = preflight.(store.ShareRequestStore)
+	requireDriverImplements(t, driverName, preflight.Close, ok, "ShareRequestStore")
+	_, ok = preflight.(store.GroupStore)
+	requireDriverImplements(t, driverName, preflight.Close, ok, "GroupStore")
+	_, ok = preflight.(store.TransferStore)
+	requireDriverImplements(t, driverName, preflight.Close, ok, "TransferStore")
+	_, ok = preflight.(store.PeerTrustStore)
+	requireDriverImplements(t, driverName, preflight.Close, ok, "PeerTrustStore")
 
 	if err := preflight.Close(); err != nil {
 		t.Fatalf("close preflight %s driver: %v", driverName, err)
@@ -95,11 +113,21 @@ func RunDriverTests(t *testing.T, driverName string, cfg *store.DriverConfig) {
 		runOutgoingShareEmptySharedSecretLookup(t, ctx, requireOutgoingShareStore(t, d))
 	})
 
+	t.Run("OutgoingShareWebappSharedSecretLookup", func(t *testing.T) {
+		d := newSubDriver(t)
+		runOutgoingShareWebappSharedSecretLookup(t, ctx, requireOutgoingShareStore(t, d))
+	})
+
 	t.Run("OutgoingShareUpdateNotFound", func(t *testing.T) {
 		d := newSubDriver(t)
 		runOutgoingShareUpdateNotFound(t, ctx, requireOutgoingShareStore(t, d))
 	})
 
+	t.Run("OutgoingShareListExpiring", func(t *testing.T) {
+		d := newSubDriver(t)
+		runOutgoingShareListExpiring(t, ctx, requireOutgoingShareStore(t, d))
+	})
+
 	t.Run("IncomingShareCRUD", func(t *testing.T) {
 		d := newSubDriver(t)
 		runIncomingShareCRUD(t, ctx, requireIncomingShareStore(t, d))
@@ -125,6 +153,11 @@ func RunDriverTests(t *testing.T, driverName string, cfg *store.DriverConfig) {
 		runOutgoingInviteAcceptedIdentityCoalescedOnEmptyUpdate(t, ctx, requireOutgoingInviteStore(t, d))
 	})
 
+	t.Run("OutgoingInviteListExpiring", func(t *testing.T) {
+		d := newSubDriver(t)
+		runOutgoingInviteListExpiring(t, ctx, requireOutgoingInviteStore(t, d))
+	})
+
 	t.Run("IncomingInviteStatusContract", func(t *testing.T) {
 		d := newSubDriver(t)
 		runIncomingInviteStatusContract(t, ctx, requireIncomingInviteStore(t, d))
@@ -144,6 +177,81 @@ func RunDriverTests(t *testing.T, driverName string, cfg *store.DriverConfig) {
 		d := newSubDriver(t)
 		runIncomingShareProviderKeyUniqueness(t, ctx, requireIncomingShareStore(t, d))
 	})
+
+	t.Run("IncomingShareProviderKeyFanOut", func(t *testing.T) {
+		d := newSubDriver(t)
+		runIncomingShareProviderKeyFanOut(t, ctx, requireIncomingShareStore(t, d))
+	})
+
+	t.Run("IncomingShareListExpiring", func(t *testing.T) {
+		d := newSubDriver(t)
+		runIncomingShareListExpiring(t, ctx, requireIncomingShareStore(t, d))
+	})
+
+	t.Run("OutboxCRUD", func(t *testing.T) {
+		d := newSubDriver(t)
+		runOutboxCRUD(t, ctx, requireOutboxStore(t, d))
+	})
+
+	t.Run("OutboxListDue", func(t *testing.T) {
+		d := newSubDriver(t)
+		runOutboxListDue(t, ctx, requireOutboxStore(t, d))
+	})
+
+	t.Run("PartyCRUD", func(t *testing.T) {
+		d := newSubDriver(t)
+		runPartyCRUD(t, ctx, requirePartyStore(t, d))
+	})
+
+	t.Run("PartyUniqueness", func(t *testing.T) {
+		d := newSubDriver(t)
+		runPartyUniqueness(t, ctx, requirePartyStore(t, d))
+	})
+
+	t.Run("PartyListAndExpiry", func(t *testing.T) {
+		d := newSubDriver(t)
+		runPartyListAndExpiry(t, ctx, requirePartyStore(t, d))
+	})
+
+	t.Run("SessionCRUD", func(t *testing.T) {
+		d := newSubDriver(t)
+		runSessionCRUD(t, ctx, requireSessionStore(t, d))
+	})
+
+	t.Run("SessionBulkDelete", func(t *testing.T) {
+		d := newSubDriver(t)
+		runSessionBulkDelete(t, ctx, requireSessionStore(t, d))
+	})
+
+	t.Run("ShareRequestCRUD", func(t *testing.T) {
+		d := newSubDriver(t)
+		runShareRequestCRUD(t, ctx, requireShareRequestStore(t, d))
+	})
+
+	t.Run("ShareRequestOwnerScoping", func(t *testing.T) {
+		d := newSubDriver(t)
+		runShareRequestOwnerScoping(t, ctx, requireShareRequestStore(t, d))
+	})
+
+	t.Run("GroupCRUD", func(t *testing.T) {
+		d := newSubDriver(t)
+		runGroupCRUD(t, ctx, requireGroupStore(t, d))
+	})
+
+	t.Run("GroupNameUniqueness", func(t *testing.T) {
+		d := newSubDriver(t)
+		runGroupNameUniqueness(t, ctx, requireGroupStore(t, d))
+	})
+
+	t.Run("TransferCRUD", func(t *testing.T) {
+		d := newSubDriver(t)
+		runTransferCRUD(t, ctx, requireTransferStore(t, d))
+	})
+
+	t.Run("PeerTrustCRUD", func(t *testing.T) {
+		d := newSubDriver(t)
+		runPeerTrustCRUD(t, ctx, requirePeerTrustStore(t, d))
+	})
 }
 
 func createPreflightDriver(t *testing.T, ctx context.Context, driverName string, cfg *store.DriverConfig) store.Driver {
@@ -191,6 +299,83 @@ func cloneConfig(cfg *store.DriverConfig, dir string) *store.DriverConfig {
 	return &c
 }
 
+func requireOutboxStore(t *testing.T, d store.Driver) store.OutboxStore {
+	t.Helper()
+
+	s, ok := d.(store.OutboxStore)
+	if !ok {
+		t.Fatal("driver does not implement OutboxStore")
+	}
+
+	return s
+}
+
+func requirePartyStore(t *testing.T, d store.Driver) store.PartyStore {
+	t.Helper()
+
+	s, ok := d.(store.PartyStore)
+	if !ok {
+		t.Fatal("driver does not implement PartyStore")
+	}
+
+	return s
+}
+
+func requireSessionStore(t *testing.T, d store.Driver) store.SessionStore {
+	t.Helper()
+
+	s, ok := d.(store.SessionStore)
+	if !ok {
+		t.Fatal("driver does not implement SessionStore")
+	}
+
+	return s
+}
+
+func requireShareRequestStore(t *testing.T, d store.Driver) store.ShareRequestStore {
+	t.Helper()
+
+	s, ok := d.(store.ShareRequestStore)
+	if !ok {
+		t.Fatal("driver does not implement ShareRequestStore")
+	}
+
+	return s
+}
+
+func requireGroupStore(t *testing.T, d store.Driver) store.GroupStore {
+	t.Helper()
+
+	s, ok := d.(store.GroupStore)
+	if !ok {
+		t.Fatal("driver does not implement GroupStore")
+	}
+
+	return s
+}
+
+func requireTransferStore(t *testing.T, d store.Driver) store.TransferStore {
+	t.Helper()
+
+	s, ok := d.(store.TransferStore)
+	if !ok {
+		t.Fatal("driver does not implement TransferStore")
+	}
+
+	return s
+}
+
+func requirePeerTrustStore(t *testing.T, d store.Driver) store.PeerTrustStore {
+	t.Helper()
+
+	s, ok := d.(store.PeerTrustStore)
+	if !ok {
+		t.Fatal("driver does not implement PeerTrustStore")
+	}
+
+	return s
+}
+
 func requireOutgoingShareStore(t *testing.T, d store.Driver) store.OutgoingShareStore {
 	t.Helper()
 
@@ -369,3 +554,47 @@ func requireOutgoingShareNotFound(t *testing.T, ctx context.Context, s store.Out
 		t.Errorf("expected ErrNotFound after delete, got %v", err)
 	}
 }
+
+// runOutgoingShareListExpiring verifies that the expiring listing returns only
+// rows in the requested statuses whose expiry is set and has passed.
+func runOutgoingShareListExpiring(t *testing.T, ctx context.Context, s store.OutgoingShareStore) {
+	t.Helper()
+
+	now := time.Now().Unix()
+
+	for _, tc := range []struct {
+		id, status string
+		expiresAt  int64
+	}{
+		{"expiring-sent", fixtureStatusSent, now - 60},
+		{"expiring-accepted", fixtureStatusAccepted, now},
+		{"expiring-live", fixtureStatusSent, now + 3600},
+		{"expiring-never", fixtureStatusAccepted, 0},
+		{"expiring-declined", "declined", now - 60},
+	} {
+		share := NewOutgoingShareFixture()
+		share.ShareID = tc.id
+		share.ProviderID = tc.id
+		share.WebDAVID = "webdav-" + tc.id
+		share.SharedSecret = "secret-" + tc.id
+		share.Status = tc.status
+		share.ExpiresAt = tc.expiresAt
+		createOutgoingShare(t, ctx, s, share)
+	}
+
+	got, err := s.ListExpiringOutgoingShares(ctx, now, []string{fixtureStatusSent, fixtureStatusAccepted})
+	if err != nil {
+		t.Fatalf("ListExpiringOutgoingShares: %v", err)
+	}
+
+	ids := make([]string, 0, len(got))
+	for _, share := range got {
+		ids = append(ids, share.ShareID)
+	}
+
+	slices.Sort(ids)
+
+	if want := []string{"expiring-accepted", "expiring-sent"}; !slices.Equal(ids, want) {
+		t.Errorf("ListExpiringOutgoingShares = %v, want %v", ids, want)
+	}
+}
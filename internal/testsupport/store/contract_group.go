@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+func createGroup(t *testing.T, ctx context.Context, s store.GroupStore, id, name string, members ...string) *store.Group {
+	t.Helper()
+
+	group := &store.Group{
+		ID:          id,
+		Name:        name,
+		DisplayName: "Group " + name,
+		Members:     members,
+		CreatedAt:   1000,
+		UpdatedAt:   1000,
+	}
+	if err := s.CreateGroup(ctx, group); err != nil {
+		t.Fatalf("CreateGroup(%s) failed: %v", id, err)
+	}
+
+	return group
+}
+
+func runGroupCRUD(t *testing.T, ctx context.Context, s store.GroupStore) {
+	t.Helper()
+
+	group := createGroup(t, ctx, s, "group-1", "engineering", fixtureUserAlice, fixtureUserBob)
+
+	got, err := s.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+
+	if got.Name != group.Name || !slices.Equal(got.Members, group.Members) {
+		t.Errorf("GetGroup = %+v, want %+v", got, group)
+	}
+
+	got, err = s.GetGroupByName(ctx, group.Name)
+	if err != nil {
+		t.Fatalf("GetGroupByName failed: %v", err)
+	}
+
+	if got.ID != group.ID {
+		t.Errorf("GetGroupByName id = %q, want %q", got.ID, group.ID)
+	}
+
+	got.Members = []string{fixtureUserBob}
+
+	if err := s.UpdateGroup(ctx, got); err != nil {
+		t.Fatalf("UpdateGroup failed: %v", err)
+	}
+
+	updated, err := s.GetGroup(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("GetGroup after update failed: %v", err)
+	}
+
+	if !slices.Equal(updated.Members, []string{fixtureUserBob}) {
+		t.Errorf("after update members = %v, want [bob]", updated.Members)
+	}
+
+	groups, err := s.ListGroups(ctx)
+	if err != nil {
+		t.Fatalf("ListGroups failed: %v", err)
+	}
+
+	if len(groups) != 1 {
+		t.Errorf("ListGroups returned %d groups, want 1", len(groups))
+	}
+
+	if err := s.DeleteGroup(ctx, group.ID); err != nil {
+		t.Fatalf("DeleteGroup failed: %v", err)
+	}
+
+	if _, err := s.GetGroup(ctx, group.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetGroup after delete error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.DeleteGroup(ctx, group.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("DeleteGroup(missing) error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.UpdateGroup(ctx, group); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateGroup(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func runGroupNameUniqueness(t *testing.T, ctx context.Context, s store.GroupStore) {
+	t.Helper()
+
+	first := createGroup(t, ctx, s, "group-1", "engineering", fixtureUserAlice)
+	second := createGroup(t, ctx, s, "group-2", "design")
+
+	if err := s.CreateGroup(ctx, first); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate CreateGroup id error = %v, want ErrAlreadyExists", err)
+	}
+
+	clash := &store.Group{ID: "group-3", Name: first.Name, Members: []string{}}
+	if err := s.CreateGroup(ctx, clash); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate CreateGroup name error = %v, want ErrAlreadyExists", err)
+	}
+
+	second.Name = first.Name
+	if err := s.UpdateGroup(ctx, second); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("UpdateGroup onto taken name error = %v, want ErrAlreadyExists", err)
+	}
+
+	if _, err := s.GetGroupByName(ctx, "missing"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetGroupByName(missing) error = %v, want ErrNotFound", err)
+	}
+}
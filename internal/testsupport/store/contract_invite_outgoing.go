@@ -8,7 +8,9 @@ package store
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
 )
@@ -241,3 +243,51 @@ func runOutgoingInviteAcceptedIdentityCoalescedOnEmptyUpdate(t *testing.T, ctx c
 		t.Errorf("AcceptedProviderFQDN after empty update: got %q, want empty (raw FQDN follows replace semantics, not coalesced)", got.AcceptedProviderFQDN)
 	}
 }
+
+// runOutgoingInviteListExpiring verifies that the expiring listing spans
+// users and returns only rows in the requested statuses whose expiry has
+// passed.
+func runOutgoingInviteListExpiring(t *testing.T, ctx context.Context, s store.OutgoingInviteStore) {
+	t.Helper()
+
+	now := time.Now().Unix()
+
+	for _, tc := range []struct {
+		id, user  string
+		expiresAt int64
+		accepted  bool
+	}{
+		{"expiring-alice", fixtureUserAlice, now - 60, false},
+		{"expiring-bob", fixtureUserBob, now, false},
+		{"expiring-live", fixtureUserAlice, now + 3600, false},
+		{"expiring-never", fixtureUserAlice, 0, false},
+		{"expiring-accepted", fixtureUserBob, now - 60, true},
+	} {
+		invite := NewOutgoingInviteFixture()
+		invite.ID = tc.id
+		invite.Token = "token-" + tc.id
+		invite.CreatedByUserID = tc.user
+		invite.ExpiresAt = tc.expiresAt
+		createOutgoingInvite(t, ctx, s, invite)
+
+		if tc.accepted {
+			updateOutgoingInviteStatusAccepted(t, ctx, s, invite)
+		}
+	}
+
+	got, err := s.ListExpiringOutgoingInvites(ctx, now, []string{fixtureStatusPending})
+	if err != nil {
+		t.Fatalf("ListExpiringOutgoingInvites: %v", err)
+	}
+
+	ids := make([]string, 0, len(got))
+	for _, invite := range got {
+		ids = append(ids, invite.ID)
+	}
+
+	slices.Sort(ids)
+
+	if want := []string{"expiring-alice", "expiring-bob"}; !slices.Equal(ids, want) {
+		t.Errorf("ListExpiringOutgoingInvites = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// newOutboxMessageFixture returns a pending outbox message due at nextAttemptAt.
+func newOutboxMessageFixture(id string, nextAttemptAt int64) *store.OutboxMessage {
+	return &store.OutboxMessage{
+		ID:            id,
+		Topic:         "share",
+		SubjectID:     "share-" + id,
+		Kind:          "shares",
+		TargetHost:    "receiver.example.com",
+		EndpointPath:  "shares",
+		Body:          []byte(`{"providerId":"` + id + `"}`),
+		Status:        "pending",
+		MaxAttempts:   5,
+		NextAttemptAt: nextAttemptAt,
+		CreatedAt:     1000,
+		UpdatedAt:     1000,
+	}
+}
+
+func runOutboxCRUD(t *testing.T, ctx context.Context, s store.OutboxStore) {
+	t.Helper()
+
+	msg := newOutboxMessageFixture("outbox-1", 1000)
+	if err := s.CreateOutboxMessage(ctx, msg); err != nil {
+		t.Fatalf("CreateOutboxMessage failed: %v", err)
+	}
+
+	if err := s.CreateOutboxMessage(ctx, msg); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists on duplicate create, got %v", err)
+	}
+
+	got, err := s.GetOutboxMessage(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("GetOutboxMessage failed: %v", err)
+	}
+
+	if string(got.Body) != string(msg.Body) || got.SubjectID != msg.SubjectID || got.NextAttemptAt != 1000 {
+		t.Errorf("GetOutboxMessage = %+v, want %+v", got, msg)
+	}
+
+	// Mutating the returned copy must not leak into the store.
+	got.Body[0] = 'X'
+
+	got.Status = "dead"
+	got.Attempts = 5
+	got.LastError = "peer returned status 400"
+	got.LastStatusCode = 400
+
+	if err := s.UpdateOutboxMessage(ctx, got); err != nil {
+		t.Fatalf("UpdateOutboxMessage failed: %v", err)
+	}
+
+	updated, err := s.GetOutboxMessage(ctx, msg.ID)
+	if err != nil {
+		t.Fatalf("GetOutboxMessage after update failed: %v", err)
+	}
+
+	if updated.Status != "dead" || updated.Attempts != 5 || updated.LastStatusCode != 400 {
+		t.Errorf("updated message = %+v", updated)
+	}
+
+	all, err := s.ListOutboxMessages(ctx)
+	if err != nil || len(all) != 1 {
+		t.Fatalf("ListOutboxMessages = %d messages, %v; want 1", len(all), err)
+	}
+
+	if err := s.DeleteOutboxMessage(ctx, msg.ID); err != nil {
+		t.Fatalf("DeleteOutboxMessage failed: %v", err)
+	}
+
+	if _, err := s.GetOutboxMessage(ctx, msg.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+
+	if err := s.DeleteOutboxMessage(ctx, msg.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting a missing message, got %v", err)
+	}
+
+	if err := s.UpdateOutboxMessage(ctx, msg); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expected ErrNotFound updating a missing message, got %v", err)
+	}
+}
+
+func runOutboxListDue(t *testing.T, ctx context.Context, s store.OutboxStore) {
+	t.Helper()
+
+	for _, msg := range []*store.OutboxMessage{
+		newOutboxMessageFixture("due-b", 200),
+		newOutboxMessageFixture("due-a", 200),
+		newOutboxMessageFixture("due-c", 100),
+		newOutboxMessageFixture("later", 900),
+	} {
+		if err := s.CreateOutboxMessage(ctx, msg); err != nil {
+			t.Fatalf("CreateOutboxMessage(%s) failed: %v", msg.ID, err)
+		}
+	}
+
+	dead := newOutboxMessageFixture("dead", 50)
+	dead.Status = "dead"
+
+	if err := s.CreateOutboxMessage(ctx, dead); err != nil {
+		t.Fatalf("CreateOutboxMessage(dead) failed: %v", err)
+	}
+
+	ids := func(msgs []*store.OutboxMessage) string {
+		out := make([]string, 0, len(msgs))
+		for _, m := range msgs {
+			out = append(out, m.ID)
+		}
+
+		return strings.Join(out, ",")
+	}
+
+	due, err := s.ListDueOutboxMessages(ctx, 500, 0)
+	if err != nil {
+		t.Fatalf("ListDueOutboxMessages failed: %v", err)
+	}
+
+	if got := ids(due); got != "due-c,due-a,due-b" {
+		t.Errorf("due = %s, want due-c,due-a,due-b", got)
+	}
+
+	limited, err := s.ListDueOutboxMessages(ctx, 500, 2)
+	if err != nil {
+		t.Fatalf("ListDueOutboxMessages with limit failed: %v", err)
+	}
+
+	if got := ids(limited); got != "due-c,due-a" {
+		t.Errorf("limited due = %s, want due-c,due-a", got)
+	}
+
+	none, err := s.ListDueOutboxMessages(ctx, 99, 0)
+	if err != nil || len(none) != 0 {
+		t.Errorf("ListDueOutboxMessages before any is due = %s, %v; want none", ids(none), err)
+	}
+}
@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+// newPartyFixture returns a party whose username and normalized email derive
+// from id.
+func newPartyFixture(id string) *store.Party {
+	return &store.Party{
+		ID:              id,
+		Username:        "user-" + id,
+		Email:           "User-" + id + "@Example.com",
+		EmailNormalized: "user-" + id + "@example.com",
+		DisplayName:     "User " + id,
+		PasswordHash:    "$2a$10$hash-" + id,
+		Role:            "user",
+		StorageRoot:     "/data/" + id,
+		CreatedAt:       1000,
+		SSHPublicKeys:   []string{"ssh-ed25519 AAAA" + id},
+	}
+}
+
+func createParty(t *testing.T, ctx context.Context, s store.PartyStore, party *store.Party) {
+	t.Helper()
+
+	if err := s.CreateParty(ctx, party); err != nil {
+		t.Fatalf("CreateParty(%s) failed: %v", party.ID, err)
+	}
+}
+
+func runPartyCRUD(t *testing.T, ctx context.Context, s store.PartyStore) {
+	t.Helper()
+
+	party := newPartyFixture("party-1")
+	createParty(t, ctx, s, party)
+
+	got, err := s.GetParty(ctx, party.ID)
+	if err != nil {
+		t.Fatalf("GetParty failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, party) {
+		t.Errorf("GetParty = %+v, want %+v", got, party)
+	}
+
+	byName, err := s.GetPartyByUsername(ctx, party.Username)
+	if err != nil || byName.ID != party.ID {
+		t.Errorf("GetPartyByUsername = %+v, %v; want %s", byName, err, party.ID)
+	}
+
+	byEmail, err := s.GetPartyByEmail(ctx, party.EmailNormalized)
+	if err != nil || byEmail.ID != party.ID || byEmail.Email != party.Email {
+		t.Errorf("GetPartyByEmail = %+v, %v; want %s with original spelling", byEmail, err, party.ID)
+	}
+
+	if _, err := s.GetPartyByEmail(ctx, ""); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetPartyByEmail(\"\") error = %v, want ErrNotFound", err)
+	}
+
+	// Mutating the returned copy must not leak into the store.
+	got.Role = "admin"
+	got.Username = "renamed"
+	got.ExpiresAt = 2000
+	got.SSHPublicKeys[0] = "ssh-ed25519 BBBB"
+
+	if err := s.UpdateParty(ctx, got); err != nil {
+		t.Fatalf("UpdateParty failed: %v", err)
+	}
+
+	updated, err := s.GetParty(ctx, party.ID)
+	if err != nil {
+		t.Fatalf("GetParty after update failed: %v", err)
+	}
+
+	if updated.Role != "admin" || updated.Username != "renamed" || updated.ExpiresAt != 2000 ||
+		!slices.Equal(updated.SSHPublicKeys, []string{"ssh-ed25519 BBBB"}) {
+		t.Errorf("updated party = %+v", updated)
+	}
+
+	if _, err := s.GetPartyByUsername(ctx, party.Username); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("lookup by old username error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.DeleteParty(ctx, party.ID); err != nil {
+		t.Fatalf("DeleteParty failed: %v", err)
+	}
+
+	if _, err := s.GetParty(ctx, party.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetParty after delete error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.DeleteParty(ctx, party.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("second DeleteParty error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.UpdateParty(ctx, party); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateParty on missing party error = %v, want ErrNotFound", err)
+	}
+}
+
+func runPartyUniqueness(t *testing.T, ctx context.Context, s store.PartyStore) {
+	t.Helper()
+
+	alice := newPartyFixture("alice")
+	createParty(t, ctx, s, alice)
+
+	sameID := newPartyFixture("alice")
+	sameID.Username = "other-name"
+	sameID.EmailNormalized = ""
+
+	if err := s.CreateParty(ctx, sameID); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate id error = %v, want ErrAlreadyExists", err)
+	}
+
+	sameName := newPartyFixture("bob")
+	sameName.Username = alice.Username
+
+	if err := s.CreateParty(ctx, sameName); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate username error = %v, want ErrAlreadyExists", err)
+	}
+
+	sameEmail := newPartyFixture("carol")
+	sameEmail.EmailNormalized = alice.EmailNormalized
+
+	if err := s.CreateParty(ctx, sameEmail); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate email error = %v, want ErrAlreadyExists", err)
+	}
+
+	// Empty emails never collide with each other.
+	for _, id := range []string{"no-email-1", "no-email-2"} {
+		p := newPartyFixture(id)
+		p.Email = ""
+		p.EmailNormalized = ""
+		createParty(t, ctx, s, p)
+	}
+
+	dave := newPartyFixture("dave")
+	createParty(t, ctx, s, dave)
+
+	dave.Username = alice.Username
+	if err := s.UpdateParty(ctx, dave); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("update onto taken username error = %v, want ErrAlreadyExists", err)
+	}
+
+	dave.Username = "user-dave"
+
+	dave.EmailNormalized = alice.EmailNormalized
+	if err := s.UpdateParty(ctx, dave); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("update onto taken email error = %v, want ErrAlreadyExists", err)
+	}
+
+	got, err := s.GetParty(ctx, dave.ID)
+	if err != nil {
+		t.Fatalf("GetParty(dave) failed: %v", err)
+	}
+
+	if got.Username != "user-dave" || got.EmailNormalized != "user-dave@example.com" {
+		t.Errorf("rejected update changed the stored party: %+v", got)
+	}
+}
+
+func runPartyListAndExpiry(t *testing.T, ctx context.Context, s store.PartyStore) {
+	t.Helper()
+
+	regular := newPartyFixture("regular")
+	createParty(t, ctx, s, regular)
+
+	expired := newPartyFixture("expired-probe")
+	expired.Role = "probe"
+	expired.Realm = "probe-realm"
+	expired.ExpiresAt = 1500
+	createParty(t, ctx, s, expired)
+
+	live := newPartyFixture("live-probe")
+	live.Role = "probe"
+	live.Realm = "probe-realm"
+	live.ExpiresAt = 5000
+	createParty(t, ctx, s, live)
+
+	all, err := s.ListParties(ctx, "")
+	if err != nil {
+		t.Fatalf("ListParties failed: %v", err)
+	}
+
+	if len(all) != 3 {
+		t.Errorf("ListParties(\"\") returned %d parties, want 3", len(all))
+	}
+
+	inRealm, err := s.ListParties(ctx, "probe-realm")
+	if err != nil {
+		t.Fatalf("ListParties(realm) failed: %v", err)
+	}
+
+	if len(inRealm) != 2 {
+		t.Errorf("ListParties(probe-realm) returned %d parties, want 2", len(inRealm))
+	}
+
+	n, err := s.DeleteExpiredParties(ctx, 2000)
+	if err != nil {
+		t.Fatalf("DeleteExpiredParties failed: %v", err)
+	}
+
+	if n != 1 {
+		t.Errorf("DeleteExpiredParties removed %d parties, want 1", n)
+	}
+
+	if _, err := s.GetParty(ctx, expired.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expired party still present: %v", err)
+	}
+
+	for _, id := range []string{regular.ID, live.ID} {
+		if _, err := s.GetParty(ctx, id); err != nil {
+			t.Errorf("GetParty(%s) after expiry sweep: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+func runPeerTrustCRUD(t *testing.T, ctx context.Context, s store.PeerTrustStore) {
+	t.Helper()
+
+	deny := &store.PeerPolicyEntry{List: "deny", Host: "blocked.example.com", CreatedAt: 1000}
+	if err := s.CreatePeerPolicyEntry(ctx, deny); err != nil {
+		t.Fatalf("CreatePeerPolicyEntry failed: %v", err)
+	}
+
+	if err := s.CreatePeerPolicyEntry(ctx, deny); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate CreatePeerPolicyEntry: expected ErrAlreadyExists, got %v", err)
+	}
+
+	// The same host may sit on both lists; the key is list and host.
+	allow := &store.PeerPolicyEntry{List: "allow", Host: "blocked.example.com", CreatedAt: 1001}
+	if err := s.CreatePeerPolicyEntry(ctx, allow); err != nil {
+		t.Fatalf("CreatePeerPolicyEntry on the other list failed: %v", err)
+	}
+
+	entries, err := s.ListPeerPolicyEntries(ctx)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("ListPeerPolicyEntries = %+v, %v; want two entries", entries, err)
+	}
+
+	if err := s.DeletePeerPolicyEntry(ctx, "allow", "blocked.example.com"); err != nil {
+		t.Fatalf("DeletePeerPolicyEntry failed: %v", err)
+	}
+
+	if err := s.DeletePeerPolicyEntry(ctx, "allow", "blocked.example.com"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("DeletePeerPolicyEntry missing: expected ErrNotFound, got %v", err)
+	}
+
+	entries, err = s.ListPeerPolicyEntries(ctx)
+	if err != nil || len(entries) != 1 || *entries[0] != *deny {
+		t.Errorf("ListPeerPolicyEntries after delete = %+v, %v; want the deny entry", entries, err)
+	}
+
+	group := &store.PeerTrustGroup{
+		TrustGroupID: "example-federation",
+		Config:       `{"trustGroupId":"example-federation","enabled":true}`,
+		CreatedAt:    2000,
+	}
+	if err := s.CreatePeerTrustGroup(ctx, group); err != nil {
+		t.Fatalf("CreatePeerTrustGroup failed: %v", err)
+	}
+
+	if err := s.CreatePeerTrustGroup(ctx, group); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate CreatePeerTrustGroup: expected ErrAlreadyExists, got %v", err)
+	}
+
+	groups, err := s.ListPeerTrustGroups(ctx)
+	if err != nil || len(groups) != 1 || *groups[0] != *group {
+		t.Errorf("ListPeerTrustGroups = %+v, %v; want the one group", groups, err)
+	}
+
+	if err := s.DeletePeerTrustGroup(ctx, group.TrustGroupID); err != nil {
+		t.Fatalf("DeletePeerTrustGroup failed: %v", err)
+	}
+
+	if err := s.DeletePeerTrustGroup(ctx, group.TrustGroupID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("DeletePeerTrustGroup missing: expected ErrNotFound, got %v", err)
+	}
+}
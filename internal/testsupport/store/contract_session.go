@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+func createSession(t *testing.T, ctx context.Context, s store.SessionStore, token, userID string, expiresAt int64) *store.Session {
+	t.Helper()
+
+	session := &store.Session{
+		Token:     token,
+		UserID:    userID,
+		CreatedAt: 1000,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.CreateSession(ctx, session); err != nil {
+		t.Fatalf("CreateSession(%s) failed: %v", token, err)
+	}
+
+	return session
+}
+
+func runSessionCRUD(t *testing.T, ctx context.Context, s store.SessionStore) {
+	t.Helper()
+
+	session := createSession(t, ctx, s, "token-1", "user-1", 5000)
+
+	if err := s.CreateSession(ctx, session); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate CreateSession error = %v, want ErrAlreadyExists", err)
+	}
+
+	got, err := s.GetSession(ctx, session.Token)
+	if err != nil {
+		t.Fatalf("GetSession failed: %v", err)
+	}
+
+	if *got != *session {
+		t.Errorf("GetSession = %+v, want %+v", got, session)
+	}
+
+	list, err := s.ListSessions(ctx)
+	if err != nil {
+		t.Fatalf("ListSessions failed: %v", err)
+	}
+
+	if len(list) != 1 {
+		t.Errorf("ListSessions returned %d sessions, want 1", len(list))
+	}
+
+	if err := s.DeleteSession(ctx, session.Token); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+
+	if _, err := s.GetSession(ctx, session.Token); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetSession after delete error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.DeleteSession(ctx, session.Token); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("second DeleteSession error = %v, want ErrNotFound", err)
+	}
+}
+
+func runSessionBulkDelete(t *testing.T, ctx context.Context, s store.SessionStore) {
+	t.Helper()
+
+	createSession(t, ctx, s, "alice-1", "alice", 5000)
+	createSession(t, ctx, s, "alice-2", "alice", 5000)
+	createSession(t, ctx, s, "bob-live", "bob", 5000)
+	createSession(t, ctx, s, "bob-expired", "bob", 1500)
+
+	if err := s.DeleteSessionsByUser(ctx, "alice"); err != nil {
+		t.Fatalf("DeleteSessionsByUser failed: %v", err)
+	}
+
+	for _, token := range []string{"alice-1", "alice-2"} {
+		if _, err := s.GetSession(ctx, token); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("session %s survived DeleteSessionsByUser: %v", token, err)
+		}
+	}
+
+	if err := s.DeleteSessionsByUser(ctx, "nobody"); err != nil {
+		t.Errorf("DeleteSessionsByUser for a user without sessions: %v", err)
+	}
+
+	n, err := s.DeleteExpiredSessions(ctx, 2000)
+	if err != nil {
+		t.Fatalf("DeleteExpiredSessions failed: %v", err)
+	}
+
+	if n != 1 {
+		t.Errorf("DeleteExpiredSessions removed %d sessions, want 1", n)
+	}
+
+	if _, err := s.GetSession(ctx, "bob-expired"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("expired session still present: %v", err)
+	}
+
+	if _, err := s.GetSession(ctx, "bob-live"); err != nil {
+		t.Errorf("live session removed by expiry sweep: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ package store
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -198,9 +199,10 @@ func runProviderKeyScopedLookup(t *testing.T, ctx context.Context, s store.Incom
 	}
 }
 
-// runIncomingShareProviderKeyUniqueness verifies that (sendingServer, providerID)
-// is enforced as a composite unique key across all backends:
-// - a duplicate pair returns ErrAlreadyExists
+// runIncomingShareProviderKeyUniqueness verifies that (sendingServer,
+// providerID, recipient) is enforced as a composite unique key across all
+// backends:
+// - a duplicate triple returns ErrAlreadyExists
 // - the original record is still returned by GetIncomingShareByProviderKey
 // - the same providerID with a different sendingServer still succeeds.
 func runIncomingShareProviderKeyUniqueness(
@@ -235,7 +237,7 @@ func runIncomingShareProviderKeyUniqueness(
 		}
 	})
 
-	// Same (sendingServer, providerID), different shareID: must fail.
+	// Same (sendingServer, providerID, recipient), different shareID: must fail.
 	second := &store.IncomingShare{
 		ShareID:         "provider-key-unique-2",
 		SenderHost:      "provider-key-server.com",
@@ -252,7 +254,7 @@ func runIncomingShareProviderKeyUniqueness(
 		UpdatedAt:       time.Now().Unix(),
 	}
 	if err := s.CreateIncomingShare(ctx, second); !errors.Is(err, store.ErrAlreadyExists) {
-		t.Fatalf("expected ErrAlreadyExists for duplicate (sendingServer, providerID), got %v", err)
+		t.Fatalf("expected ErrAlreadyExists for duplicate (sendingServer, providerID, recipient), got %v", err)
 	}
 
 	// Original must still be found by provider key lookup.
@@ -303,3 +305,103 @@ func runIncomingShareProviderKeyUniqueness(
 		t.Errorf("expected provider-key-unique-3, got %q", gotThird.ShareID)
 	}
 }
+
+// runIncomingShareProviderKeyFanOut verifies that one provider key can hold a
+// copy per recipient (group share fan-out): lookup returns the oldest copy and
+// listing returns every copy, oldest first.
+func runIncomingShareProviderKeyFanOut(
+	t *testing.T,
+	ctx context.Context,
+	s store.IncomingShareStore,
+) {
+	t.Helper()
+
+	now := time.Now().Unix()
+
+	for i, recipient := range []string{fixtureUserBob, fixtureUserAlice} {
+		share := NewIncomingShareFixture()
+		share.ShareID = "fan-out-" + recipient
+		share.SenderHost = "fan-out-server.com"
+		share.ProviderID = fixtureProviderKeyUniquePID
+		share.ShareType = "group"
+		share.RecipientUserID = recipient
+		share.CreatedAt = now + int64(i)
+		share.UpdatedAt = now + int64(i)
+		createIncomingShare(t, ctx, s, share)
+	}
+
+	got, err := s.GetIncomingShareByProviderKey(ctx, "fan-out-server.com", fixtureProviderKeyUniquePID)
+	if err != nil {
+		t.Fatalf("GetIncomingShareByProviderKey: %v", err)
+	}
+
+	if got.RecipientUserID != fixtureUserBob {
+		t.Errorf("GetIncomingShareByProviderKey recipient = %q, want oldest copy %q", got.RecipientUserID, fixtureUserBob)
+	}
+
+	copies, err := s.ListIncomingSharesByProviderKey(ctx, "fan-out-server.com", fixtureProviderKeyUniquePID)
+	if err != nil {
+		t.Fatalf("ListIncomingSharesByProviderKey: %v", err)
+	}
+
+	if len(copies) != 2 {
+		t.Fatalf("ListIncomingSharesByProviderKey returned %d copies, want 2", len(copies))
+	}
+
+	if copies[0].RecipientUserID != fixtureUserBob || copies[1].RecipientUserID != fixtureUserAlice {
+		t.Errorf("copies = [%s %s], want [bob alice]", copies[0].RecipientUserID, copies[1].RecipientUserID)
+	}
+
+	missing, err := s.ListIncomingSharesByProviderKey(ctx, "fan-out-server.com", "missing")
+	if err != nil {
+		t.Fatalf("ListIncomingSharesByProviderKey(missing): %v", err)
+	}
+
+	if len(missing) != 0 {
+		t.Errorf("ListIncomingSharesByProviderKey(missing) returned %d copies, want 0", len(missing))
+	}
+}
+
+// runIncomingShareListExpiring verifies that the expiring listing spans
+// recipients and returns only rows in the requested statuses whose expiration
+// is set and has passed.
+func runIncomingShareListExpiring(t *testing.T, ctx context.Context, s store.IncomingShareStore) {
+	t.Helper()
+
+	now := time.Now().Unix()
+
+	for _, tc := range []struct {
+		id, recipient, status string
+		expiration            int64
+	}{
+		{"expiring-bob", fixtureUserBob, fixtureStatusPending, now - 60},
+		{"expiring-alice", fixtureUserAlice, fixtureStatusAccepted, now},
+		{"expiring-live", fixtureUserBob, fixtureStatusPending, now + 3600},
+		{"expiring-never", fixtureUserBob, fixtureStatusAccepted, 0},
+		{"expiring-declined", fixtureUserAlice, "declined", now - 60},
+	} {
+		share := NewIncomingShareFixture()
+		share.ShareID = tc.id
+		share.ProviderID = tc.id
+		share.RecipientUserID = tc.recipient
+		share.Status = tc.status
+		share.Expiration = tc.expiration
+		createIncomingShare(t, ctx, s, share)
+	}
+
+	got, err := s.ListExpiringIncomingShares(ctx, now, []string{fixtureStatusPending, fixtureStatusAccepted})
+	if err != nil {
+		t.Fatalf("ListExpiringIncomingShares: %v", err)
+	}
+
+	ids := make([]string, 0, len(got))
+	for _, share := range got {
+		ids = append(ids, share.ShareID)
+	}
+
+	slices.Sort(ids)
+
+	if want := []string{"expiring-alice", "expiring-bob"}; !slices.Equal(ids, want) {
+		t.Errorf("ListExpiringIncomingShares = %v, want %v", ids, want)
+	}
+}
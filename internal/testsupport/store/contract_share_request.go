@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+func createShareRequest(t *testing.T, ctx context.Context, s store.ShareRequestStore, id, ownerUserID string) *store.ShareRequest {
+	t.Helper()
+
+	req := &store.ShareRequest{
+		ID:            id,
+		OwnerUserID:   ownerUserID,
+		ProviderID:    "provider-" + id,
+		ShareID:       "share-" + id,
+		Name:          "report.txt",
+		ResourceType:  "file",
+		ShareWith:     "bob@requester.example",
+		RequesterHost: "requester.example",
+		Status:        "pending",
+		CreatedAt:     1000,
+		UpdatedAt:     1000,
+	}
+	if err := s.CreateShareRequest(ctx, req); err != nil {
+		t.Fatalf("CreateShareRequest(%s) failed: %v", id, err)
+	}
+
+	return req
+}
+
+func runShareRequestCRUD(t *testing.T, ctx context.Context, s store.ShareRequestStore) {
+	t.Helper()
+
+	req := createShareRequest(t, ctx, s, "req-1", "alice")
+
+	if err := s.CreateShareRequest(ctx, req); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate CreateShareRequest error = %v, want ErrAlreadyExists", err)
+	}
+
+	got, err := s.GetShareRequestForOwner(ctx, req.ID, "alice")
+	if err != nil {
+		t.Fatalf("GetShareRequestForOwner failed: %v", err)
+	}
+
+	if *got != *req {
+		t.Errorf("GetShareRequestForOwner = %+v, want %+v", got, req)
+	}
+
+	if err := s.UpdateShareRequestStatusForOwner(ctx, req.ID, "alice", "approved", "share-42"); err != nil {
+		t.Fatalf("UpdateShareRequestStatusForOwner failed: %v", err)
+	}
+
+	got, err = s.GetShareRequestForOwner(ctx, req.ID, "alice")
+	if err != nil {
+		t.Fatalf("GetShareRequestForOwner after update failed: %v", err)
+	}
+
+	if got.Status != "approved" || got.ApprovedShareID != "share-42" {
+		t.Errorf("after update status=%q approvedShareID=%q, want approved/share-42", got.Status, got.ApprovedShareID)
+	}
+
+	if got.UpdatedAt <= req.UpdatedAt {
+		t.Errorf("UpdatedAt = %d, want later than %d", got.UpdatedAt, req.UpdatedAt)
+	}
+
+	if err := s.UpdateShareRequestStatusForOwner(ctx, "missing", "alice", "declined", ""); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateShareRequestStatusForOwner(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func runShareRequestOwnerScoping(t *testing.T, ctx context.Context, s store.ShareRequestStore) {
+	t.Helper()
+
+	createShareRequest(t, ctx, s, "alice-1", "alice")
+	createShareRequest(t, ctx, s, "alice-2", "alice")
+	bobReq := createShareRequest(t, ctx, s, "bob-1", "bob")
+
+	if _, err := s.GetShareRequestForOwner(ctx, bobReq.ID, "alice"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("cross-owner GetShareRequestForOwner error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.UpdateShareRequestStatusForOwner(ctx, bobReq.ID, "alice", "declined", ""); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("cross-owner UpdateShareRequestStatusForOwner error = %v, want ErrNotFound", err)
+	}
+
+	aliceList, err := s.ListShareRequests(ctx, "alice")
+	if err != nil {
+		t.Fatalf("ListShareRequests(alice) failed: %v", err)
+	}
+
+	if len(aliceList) != 2 {
+		t.Errorf("ListShareRequests(alice) returned %d requests, want 2", len(aliceList))
+	}
+
+	all, err := s.ListShareRequests(ctx, "")
+	if err != nil {
+		t.Fatalf("ListShareRequests(all) failed: %v", err)
+	}
+
+	if len(all) != 3 {
+		t.Errorf("ListShareRequests(all) returned %d requests, want 3", len(all))
+	}
+}
@@ -214,3 +214,59 @@ func runOutgoingShareUpdateNotFound(t *testing.T, ctx context.Context, s store.O
 		t.Errorf("expected ErrNotFound for update of missing outgoing share, got %v", err)
 	}
 }
+
+// runOutgoingShareWebappSharedSecretLookup verifies that the webapp arm's
+// secret resolves its share and is a separate key from the WebDAV secret:
+//   - GetOutgoingShareByWebappSharedSecret finds the share by its webapp secret
+//   - the WebDAV secret does not resolve through the webapp lookup, nor the
+//     webapp secret through GetOutgoingShareBySharedSecret
+//   - the empty string is not a lookup key
+func runOutgoingShareWebappSharedSecretLookup(
+	t *testing.T,
+	ctx context.Context,
+	s store.OutgoingShareStore,
+) {
+	t.Helper()
+
+	share := &store.OutgoingShare{
+		ShareID:            "webapp-secret-share-1",
+		ProviderID:         "webapp-secret-provider-1",
+		WebDAVID:           "webapp-secret-webdav-1",
+		SharedSecret:       "webapp-lookup-webdav-secret",
+		WebappSharedSecret: "webapp-lookup-webapp-secret",
+		LocalPath:          "/path/webapp",
+		Owner:              fixtureOwnerAliceExample,
+		Sender:             fixtureOwnerAliceExample,
+		ShareWith:          fixtureShareWithBobRemote,
+		ReceiverHost:       fixtureReceiverRemote,
+		Name:               fixtureNameFileTxt,
+		ResourceType:       fixtureResourceTypeFile,
+		Permissions:        fixturePermissionsRead,
+		Status:             fixtureStatusSent,
+		CreatedAt:          time.Now().Unix(),
+		UpdatedAt:          time.Now().Unix(),
+	}
+	createOutgoingShare(t, ctx, s, share)
+
+	got, err := s.GetOutgoingShareByWebappSharedSecret(ctx, share.WebappSharedSecret)
+	if err != nil {
+		t.Fatalf("GetOutgoingShareByWebappSharedSecret: %v", err)
+	}
+
+	if got.ProviderID != share.ProviderID || got.WebappSharedSecret != share.WebappSharedSecret {
+		t.Errorf("GetOutgoingShareByWebappSharedSecret = %q/%q, want %q/%q",
+			got.ProviderID, got.WebappSharedSecret, share.ProviderID, share.WebappSharedSecret)
+	}
+
+	if _, err := s.GetOutgoingShareByWebappSharedSecret(ctx, share.SharedSecret); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetOutgoingShareByWebappSharedSecret(webdav secret) expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := s.GetOutgoingShareBySharedSecret(ctx, share.WebappSharedSecret); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetOutgoingShareBySharedSecret(webapp secret) expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := s.GetOutgoingShareByWebappSharedSecret(ctx, ""); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetOutgoingShareByWebappSharedSecret(\"\") expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/store"
+)
+
+func runTransferCRUD(t *testing.T, ctx context.Context, s store.TransferStore) {
+	t.Helper()
+
+	transfer := &store.Transfer{
+		ShareID:         "share-1",
+		RecipientUserID: fixtureUserAlice,
+		Status:          "queued",
+		BytesTotal:      1024,
+		CreatedAt:       1000,
+		UpdatedAt:       1000,
+	}
+	if err := s.CreateTransfer(ctx, transfer); err != nil {
+		t.Fatalf("CreateTransfer failed: %v", err)
+	}
+
+	if err := s.CreateTransfer(ctx, transfer); !errors.Is(err, store.ErrAlreadyExists) {
+		t.Errorf("duplicate CreateTransfer: expected ErrAlreadyExists, got %v", err)
+	}
+
+	got, err := s.GetTransfer(ctx, transfer.ShareID)
+	if err != nil {
+		t.Fatalf("GetTransfer failed: %v", err)
+	}
+
+	if got.RecipientUserID != fixtureUserAlice || got.Status != "queued" || got.BytesTotal != 1024 {
+		t.Errorf("GetTransfer = %+v, want %+v", got, transfer)
+	}
+
+	got.Status = "completed"
+	got.BytesDone = 1024
+	got.FilesDone = 1
+	got.Destination = "/data/alice/report.pdf"
+	got.CompletedAt = 2000
+
+	if err := s.UpdateTransfer(ctx, got); err != nil {
+		t.Fatalf("UpdateTransfer failed: %v", err)
+	}
+
+	updated, err := s.GetTransfer(ctx, transfer.ShareID)
+	if err != nil {
+		t.Fatalf("GetTransfer after update failed: %v", err)
+	}
+
+	if updated.Status != "completed" || updated.BytesDone != 1024 || updated.Destination != got.Destination || updated.CompletedAt != 2000 {
+		t.Errorf("updated transfer = %+v", updated)
+	}
+
+	if err := s.UpdateTransfer(ctx, &store.Transfer{ShareID: "missing"}); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("UpdateTransfer missing: expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := s.GetTransfer(ctx, "missing"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetTransfer missing: expected ErrNotFound, got %v", err)
+	}
+
+	transfers, err := s.ListTransfers(ctx)
+	if err != nil {
+		t.Fatalf("ListTransfers failed: %v", err)
+	}
+
+	if len(transfers) != 1 || transfers[0].ShareID != transfer.ShareID {
+		t.Errorf("ListTransfers = %+v, want the one transfer", transfers)
+	}
+}
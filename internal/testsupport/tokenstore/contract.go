@@ -0,0 +1,289 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+// Package tokenstore holds the shared token.TokenStore contract suite run
+// against every issued-token store driver.
+package tokenstore
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+)
+
+// RunContract runs the TokenStore contract. newStore must return a fresh,
+// empty store per call; it owns cleanup via t.Cleanup.
+func RunContract(t *testing.T, newStore func(t *testing.T) token.TokenStore) {
+	t.Helper()
+
+	t.Run("StoreGetRoundTrip", func(t *testing.T) {
+		t.Parallel()
+		testStoreGetRoundTrip(t, newStore(t))
+	})
+	t.Run("RefreshKindRoundTrip", func(t *testing.T) {
+		t.Parallel()
+		testRefreshKindRoundTrip(t, newStore(t))
+	})
+	t.Run("GetMissing", func(t *testing.T) {
+		t.Parallel()
+		testGetMissing(t, newStore(t))
+	})
+	t.Run("GetExpired", func(t *testing.T) {
+		t.Parallel()
+		testGetExpired(t, newStore(t))
+	})
+	t.Run("StoreReplaces", func(t *testing.T) {
+		t.Parallel()
+		testStoreReplaces(t, newStore(t))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		t.Parallel()
+		testDelete(t, newStore(t))
+	})
+	t.Run("Claim", func(t *testing.T) {
+		t.Parallel()
+		testClaim(t, newStore(t))
+	})
+	t.Run("ConcurrentClaim", func(t *testing.T) {
+		t.Parallel()
+		testConcurrentClaim(t, newStore(t))
+	})
+	t.Run("DeleteByShareID", func(t *testing.T) {
+		t.Parallel()
+		testDeleteByShareID(t, newStore(t))
+	})
+	t.Run("CleanExpired", func(t *testing.T) {
+		t.Parallel()
+		testCleanExpired(t, newStore(t))
+	})
+}
+
+// Issued builds a token for shareID expiring ttl from now (negative ttl
+// yields an already-expired token). Timestamps are whole seconds so drivers
+// that store unix seconds round-trip them exactly.
+func Issued(accessToken, shareID string, ttl time.Duration) *token.IssuedToken {
+	now := time.Now().Truncate(time.Second)
+
+	return &token.IssuedToken{
+		AccessToken: accessToken,
+		ShareID:     shareID,
+		ClientID:    "receiver.example.org",
+		Permissions: []string{"read", "write"},
+		IssuedAt:    now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+func mustStore(t *testing.T, s token.TokenStore, toks ...*token.IssuedToken) {
+	t.Helper()
+
+	for _, tok := range toks {
+		if err := s.Store(t.Context(), tok); err != nil {
+			t.Fatalf("Store(%s): %v", tok.AccessToken, err)
+		}
+	}
+}
+
+func requireGone(t *testing.T, s token.TokenStore, accessTokens ...string) {
+	t.Helper()
+
+	for _, accessToken := range accessTokens {
+		if _, err := s.Get(t.Context(), accessToken); !errors.Is(err, token.ErrTokenNotFound) {
+			t.Errorf("Get(%s) = %v, want ErrTokenNotFound", accessToken, err)
+		}
+	}
+}
+
+func requirePresent(t *testing.T, s token.TokenStore, accessTokens ...string) {
+	t.Helper()
+
+	for _, accessToken := range accessTokens {
+		if _, err := s.Get(t.Context(), accessToken); err != nil {
+			t.Errorf("Get(%s): %v, want token present", accessToken, err)
+		}
+	}
+}
+
+func testStoreGetRoundTrip(t *testing.T, s token.TokenStore) {
+	want := Issued("round-trip", "share-1", time.Hour)
+	mustStore(t, s, want)
+
+	got, err := s.Get(t.Context(), want.AccessToken)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if got.AccessToken != want.AccessToken || got.ShareID != want.ShareID || got.ClientID != want.ClientID {
+		t.Errorf("Get = %+v, want %+v", got, want)
+	}
+
+	if !slices.Equal(got.Permissions, want.Permissions) {
+		t.Errorf("Permissions = %v, want %v", got.Permissions, want.Permissions)
+	}
+
+	if !got.IssuedAt.Equal(want.IssuedAt) || !got.ExpiresAt.Equal(want.ExpiresAt) {
+		t.Errorf("IssuedAt/ExpiresAt = %v/%v, want %v/%v", got.IssuedAt, got.ExpiresAt, want.IssuedAt, want.ExpiresAt)
+	}
+}
+
+func testRefreshKindRoundTrip(t *testing.T, s token.TokenStore) {
+	refresh := Issued("refresh", "share-1", time.Hour)
+	refresh.Kind = token.KindRefresh
+	mustStore(t, s, refresh, Issued("access", "share-1", time.Hour))
+
+	got, err := s.Get(t.Context(), "refresh")
+	if err != nil {
+		t.Fatalf("Get(refresh): %v", err)
+	}
+
+	if !got.IsRefresh() {
+		t.Errorf("Get(refresh).Kind = %q, want %q", got.Kind, token.KindRefresh)
+	}
+
+	got, err = s.Get(t.Context(), "access")
+	if err != nil {
+		t.Fatalf("Get(access): %v", err)
+	}
+
+	if got.IsRefresh() {
+		t.Errorf("Get(access).Kind = %q, want empty", got.Kind)
+	}
+}
+
+func testGetMissing(t *testing.T, s token.TokenStore) {
+	requireGone(t, s, "never-issued")
+}
+
+func testGetExpired(t *testing.T, s token.TokenStore) {
+	mustStore(t, s, Issued("expired", "share-1", -time.Minute))
+
+	if _, err := s.Get(t.Context(), "expired"); !errors.Is(err, token.ErrTokenExpired) {
+		t.Fatalf("Get(expired) = %v, want ErrTokenExpired", err)
+	}
+}
+
+func testStoreReplaces(t *testing.T, s token.TokenStore) {
+	mustStore(t, s, Issued("same", "share-1", time.Hour))
+
+	replacement := Issued("same", "share-1", 2*time.Hour)
+	replacement.Permissions = []string{"read"}
+	mustStore(t, s, replacement)
+
+	got, err := s.Get(t.Context(), "same")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if !got.ExpiresAt.Equal(replacement.ExpiresAt) || !slices.Equal(got.Permissions, []string{"read"}) {
+		t.Errorf("Get = %+v, want the replacement", got)
+	}
+}
+
+func testDelete(t *testing.T, s token.TokenStore) {
+	mustStore(t, s, Issued("doomed", "share-1", time.Hour), Issued("kept", "share-1", time.Hour))
+
+	if err := s.Delete(t.Context(), "doomed"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if err := s.Delete(t.Context(), "never-issued"); err != nil {
+		t.Fatalf("Delete(missing) = %v, want nil", err)
+	}
+
+	requireGone(t, s, "doomed")
+	requirePresent(t, s, "kept")
+}
+
+func testClaim(t *testing.T, s token.TokenStore) {
+	mustStore(t, s, Issued("claimed", "share-1", time.Hour), Issued("kept", "share-1", time.Hour))
+
+	if err := s.Claim(t.Context(), "claimed"); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	if err := s.Claim(t.Context(), "claimed"); !errors.Is(err, token.ErrTokenNotFound) {
+		t.Errorf("second Claim = %v, want ErrTokenNotFound", err)
+	}
+
+	if err := s.Claim(t.Context(), "never-issued"); !errors.Is(err, token.ErrTokenNotFound) {
+		t.Errorf("Claim(missing) = %v, want ErrTokenNotFound", err)
+	}
+
+	requireGone(t, s, "claimed")
+	requirePresent(t, s, "kept")
+}
+
+func testConcurrentClaim(t *testing.T, s token.TokenStore) {
+	mustStore(t, s, Issued("contended", "share-1", time.Hour))
+
+	const claimers = 8
+
+	var (
+		wg   sync.WaitGroup
+		wins atomic.Int32
+	)
+
+	for range claimers {
+		wg.Go(func() {
+			err := s.Claim(t.Context(), "contended")
+			if err == nil {
+				wins.Add(1)
+			} else if !errors.Is(err, token.ErrTokenNotFound) {
+				t.Errorf("Claim: %v", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if got := wins.Load(); got != 1 {
+		t.Errorf("%d concurrent claims succeeded, want exactly 1", got)
+	}
+}
+
+func testDeleteByShareID(t *testing.T, s token.TokenStore) {
+	mustStore(t, s,
+		Issued("a1", "share-a", time.Hour),
+		Issued("a2", "share-a", time.Hour),
+		Issued("b1", "share-b", time.Hour),
+	)
+
+	if err := s.DeleteByShareID(t.Context(), "share-a"); err != nil {
+		t.Fatalf("DeleteByShareID: %v", err)
+	}
+
+	if err := s.DeleteByShareID(t.Context(), "share-none"); err != nil {
+		t.Fatalf("DeleteByShareID(unknown) = %v, want nil", err)
+	}
+
+	requireGone(t, s, "a1", "a2")
+	requirePresent(t, s, "b1")
+}
+
+func testCleanExpired(t *testing.T, s token.TokenStore) {
+	mustStore(t, s,
+		Issued("expired-1", "share-a", -time.Hour),
+		Issued("expired-2", "share-b", -time.Minute),
+		Issued("live", "share-a", time.Hour),
+	)
+
+	if err := s.CleanExpired(t.Context()); err != nil {
+		t.Fatalf("CleanExpired: %v", err)
+	}
+
+	requireGone(t, s, "expired-1", "expired-2")
+	requirePresent(t, s, "live")
+
+	if err := s.DeleteByShareID(t.Context(), "share-a"); err != nil {
+		t.Fatalf("DeleteByShareID after CleanExpired: %v", err)
+	}
+
+	requireGone(t, s, "live")
+}
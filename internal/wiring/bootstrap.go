@@ -6,6 +6,7 @@
 package wiring
 
 import (
+	"context"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -22,7 +23,7 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peerorigin"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/policy"
-	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache/memory"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
@@ -32,6 +33,7 @@ import (
 	tlspkg "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/tls"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/localidentity"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tokenstore"
 )
 
 // ErrMsgNilDepsAfterBuild is logged when Build returns a nil Deps pointer.
@@ -59,6 +61,14 @@ type BuildOpts struct {
 	// SkipDiscoveryCache wires a no-op cache for the discovery client instead
 	// of the shared in-memory cache.
 	SkipDiscoveryCache bool
+
+	// ReloadConfig re-runs the config loader with the startup options. When
+	// set, Build wires Deps.Reloader; nil leaves config reload off.
+	ReloadConfig func() (*config.Config, error)
+
+	// LogLevel is the level of the process logger. The reloader sets it from
+	// [logging] level; nil leaves the level fixed.
+	LogLevel *slog.LevelVar
 }
 
 // BuildResult holds values built by wiring.Build that callers need after the call.
@@ -72,6 +82,15 @@ type BuildResult struct {
 	// Persistence holds the wired persistence repos. Callers must call
 	// Persistence.Close() on shutdown; Close is a no-op for the memory backend.
 	Persistence *repos.Repos
+
+	// TokenStore is the issued-token store selected by
+	// [token_exchange.store]. Callers must call TokenStore.Close() on
+	// shutdown to stop its cleanup sweep and release the backend.
+	TokenStore tokenstore.Store
+
+	// Audit is the audit log, nil unless [audit] is enabled. Callers must
+	// call Audit.Close() on shutdown.
+	Audit *audit.Log
 }
 
 // wireSharedDeps builds shared infrastructure from config and persistence repos.
@@ -101,9 +120,6 @@ func wireSharedDeps(cfg *config.Config, logger *slog.Logger, opts BuildOpts, per
 		return BuildResult{}, fmt.Errorf("invalid signature.jwks_uri: %w", validateErr)
 	}
 
-	partyRepo := identity.NewMemoryPartyRepo()
-	sessionRepo := identity.NewMemorySessionRepo()
-
 	userAuth := buildUserAuth(opts)
 
 	keyManager, err := buildKeyManager(cfg, localIdentity, opts, logger)
@@ -150,6 +166,10 @@ func wireSharedDeps(cfg *config.Config, logger *slog.Logger, opts BuildOpts, per
 		return BuildResult{}, err
 	}
 
+	if err := loadRuntimePeerTrust(context.Background(), persistence.PeerTrust, trustGroupMgr, policyEngine, logger); err != nil {
+		return BuildResult{}, err
+	}
+
 	signer := buildSigner(cfg, keyManager)
 
 	peerDiscoveryAdapter := discovery.NewPeerDiscoveryAdapter(rawHTTPClient, discoveryClient)
@@ -162,19 +182,45 @@ func wireSharedDeps(cfg *config.Config, logger *slog.Logger, opts BuildOpts, per
 		logger,
 	)
 	signatureMiddleware.SetLocalHTTPSigPolicy(facts.RequiresHTTPRequestSignatures, keyManager != nil)
+	// Seen signatures share the rate-limit cache: it has no LRU bound, so an
+	// entry lives for its whole replay window.
+	signatureMiddleware.SetReplayCache(ratelimitCacheInstance)
+
+	tokenStore, err := tokenstore.New(cfg, logger)
+	if err != nil {
+		return BuildResult{}, fmt.Errorf("open token store: %w", err)
+	}
+
+	var auditLog *audit.Log
+
+	if cfg.Audit.Enabled {
+		auditLog, err = audit.Open(cfg.ResolveAuditDataDir(), logger)
+		if err != nil {
+			return BuildResult{}, errors.Join(fmt.Errorf("open audit log: %w", err), tokenStore.Close())
+		}
+	}
 
-	tokenStore := token.NewMemoryTokenStore()
 	realIPExtractor := realip.NewTrustedProxies(cfg.Server.TrustedProxies)
 
+	var reloader *Reloader
+	if opts.ReloadConfig != nil {
+		reloader = newReloader(cfg, opts, rawHTTPClient, signatureMiddleware, trustGroupMgr, policyEngine, logger)
+	}
+
 	built := &Deps{
-		PartyRepo:           partyRepo,
-		SessionRepo:         sessionRepo,
+		PartyRepo:           persistence.Parties,
+		GroupRepo:           persistence.Groups,
+		SessionRepo:         persistence.Sessions,
 		UserAuth:            userAuth,
 		IncomingShareRepo:   persistence.IncomingShares,
 		OutgoingShareRepo:   persistence.OutgoingShares,
 		OutgoingInviteRepo:  persistence.OutgoingInvites,
 		IncomingInviteRepo:  persistence.IncomingInvites,
+		ShareRequestRepo:    persistence.ShareRequests,
 		TokenStore:          tokenStore,
+		OutboxRepo:          persistence.Outbox,
+		TransferRepo:        persistence.Transfers,
+		PeerTrustRepo:       persistence.PeerTrust,
 		HTTPClient:          httpClient,
 		DiscoveryClient:     discoveryClient,
 		CodeFlow:            codeFlow,
@@ -188,12 +234,16 @@ func wireSharedDeps(cfg *config.Config, logger *slog.Logger, opts BuildOpts, per
 		Config:              cfg,
 		Cache:               ratelimitCacheInstance,
 		RealIP:              realIPExtractor,
+		Reloader:            reloader,
+		Audit:               auditLog,
 	}
 
 	return BuildResult{
 		Deps:        built,
 		RootCAPool:  rootCAPool,
 		Persistence: persistence,
+		TokenStore:  tokenStore,
+		Audit:       auditLog,
 	}, nil
 }
 
@@ -222,16 +272,33 @@ func buildKeyManager(
 		}
 	}
 
-	keyManager := crypto.NewKeyManagerWithFragment(
+	keyManager, err := crypto.NewKeyManagerWithOptions(
 		cfg.Signature.KeyPath,
 		localIdentity.Origin,
-		cfg.Signature.KidFragment,
+		crypto.KeyManagerOptions{
+			KidFragment: cfg.Signature.KidFragment,
+			Algorithm:   cfg.Signature.KeyAlgorithm,
+			RSAKeyBits:  cfg.Signature.RSAKeyBits,
+		},
 	)
+	if err != nil {
+		return nil, fmt.Errorf("configure signing key: %w", err)
+	}
+
 	if err := keyManager.LoadOrGenerate(); err != nil {
 		return nil, fmt.Errorf("initialize signing key: %w", err)
 	}
 
-	logger.Info("initialized signing key", "keyId", keyManager.GetKeyID())
+	signingKey := keyManager.GetSigningKey()
+	logger.Info("initialized signing key", "keyId", signingKey.KeyID, "algorithm", signingKey.Algorithm)
+
+	if signingKey.Algorithm != cfg.Signature.KeyAlgorithm {
+		// The key on disk wins; the configured algorithm applies to the next
+		// generated key, so a rotation switches algorithms.
+		logger.Warn("signing key algorithm differs from signature.key_algorithm; rotate the key to switch",
+			"algorithm", signingKey.Algorithm,
+			"key_algorithm", cfg.Signature.KeyAlgorithm)
+	}
 
 	return keyManager, nil
 }
@@ -343,6 +410,49 @@ func buildPeerTrust(
 	return trustGroupMgr, policyEngine, nil
 }
 
+// loadRuntimePeerTrust applies the allow/deny entries and trust groups added
+// through the admin API. A stored trust group whose ID a config file already
+// uses is skipped: the config file wins.
+func loadRuntimePeerTrust(
+	ctx context.Context,
+	repo peertrust.Repo,
+	trustGroupMgr *peertrust.TrustGroupManager,
+	policyEngine *peertrust.PolicyEngine,
+	logger *slog.Logger,
+) error {
+	if repo == nil || policyEngine == nil {
+		return nil
+	}
+
+	entries, err := repo.ListEntries(ctx)
+	if err != nil {
+		return fmt.Errorf("load peer policy entries: %w", err)
+	}
+
+	policyEngine.SetStoredPolicy(peertrust.PolicyFromEntries(entries))
+
+	groups, err := repo.ListTrustGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("load peer trust groups: %w", err)
+	}
+
+	for _, group := range groups {
+		if err := trustGroupMgr.AttachTrustGroup(group.Config); err != nil {
+			logger.Warn("stored trust group not attached", "trust_group_id", group.Config.TrustGroupID, "error", err)
+
+			continue
+		}
+
+		logger.Info("attached stored trust group", "trust_group_id", group.Config.TrustGroupID)
+	}
+
+	if len(entries) > 0 || len(groups) > 0 {
+		logger.Info("loaded runtime peer trust", "policy_entries", len(entries), "trust_groups", len(groups))
+	}
+
+	return nil
+}
+
 func buildSigner(cfg *config.Config, keyManager *crypto.KeyManager) *crypto.RFC9421Signer {
 	if keyManager == nil {
 		return nil
@@ -9,24 +9,34 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	tokensqlite "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tokenstore/sqlite"
 	tslog "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/log"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/wiring"
 
 	_ "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache/loader"
 )
 
-func assertMemoryBackedTokenStore(t *testing.T, d *wiring.Deps) {
+// assertMemoryBackedTokenStore checks that the default token store does not
+// follow the persistence backend onto disk.
+func assertMemoryBackedTokenStore(t *testing.T, result wiring.BuildResult, dataDir string) {
 	t.Helper()
 
-	if d.TokenStore == nil {
+	if result.Deps.TokenStore == nil || result.TokenStore == nil {
 		t.Fatal("TokenStore must be non-nil")
 	}
 
-	if _, ok := d.TokenStore.(*token.MemoryTokenStore); !ok {
-		t.Errorf("TokenStore must stay memory-backed, got %T", d.TokenStore)
+	if dataDir != "" {
+		if _, err := os.Stat(filepath.Join(dataDir, tokensqlite.DBFileName)); !os.IsNotExist(err) {
+			t.Errorf("memory token store must not create %s, stat err = %v", tokensqlite.DBFileName, err)
+		}
+	}
+
+	if err := result.TokenStore.Close(); err != nil {
+		t.Errorf("TokenStore.Close(): %v", err)
 	}
 }
 
@@ -66,11 +76,23 @@ func TestPersistence_MemoryBackend(t *testing.T) { //nolint:paralleltest // uses
 		t.Error("IncomingInviteRepo must be non-nil")
 	}
 
+	if d.ShareRequestRepo == nil {
+		t.Error("ShareRequestRepo must be non-nil")
+	}
+
+	if d.GroupRepo == nil {
+		t.Error("GroupRepo must be non-nil")
+	}
+
+	if d.TransferRepo == nil {
+		t.Error("TransferRepo must be non-nil")
+	}
+
 	if result.Persistence == nil {
 		t.Fatal("Persistence must be non-nil")
 	}
 
-	assertMemoryBackedTokenStore(t, d)
+	assertMemoryBackedTokenStore(t, result, "")
 
 	if err := result.Persistence.Close(); err != nil {
 		t.Errorf("Persistence.Close() for memory backend: %v", err)
@@ -111,17 +133,79 @@ func TestPersistence_JSONBackend(t *testing.T) {
 		t.Error("IncomingInviteRepo must be non-nil")
 	}
 
+	if d.ShareRequestRepo == nil {
+		t.Error("ShareRequestRepo must be non-nil")
+	}
+
+	if d.GroupRepo == nil {
+		t.Error("GroupRepo must be non-nil")
+	}
+
+	if d.TransferRepo == nil {
+		t.Error("TransferRepo must be non-nil")
+	}
+
 	if result.Persistence == nil {
 		t.Fatal("Persistence must be non-nil")
 	}
 
-	assertMemoryBackedTokenStore(t, d)
+	assertMemoryBackedTokenStore(t, result, cfg.Persistence.DataDir)
 
 	if err := result.Persistence.Close(); err != nil {
 		t.Errorf("Persistence.Close() for json backend: %v", err)
 	}
 }
 
+func TestTokenStore_SQLiteDriverSurvivesRebuild(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DevConfig()
+	cfg.TokenExchange.Store.Driver = config.TokenStoreDriverSQLite
+	cfg.TokenExchange.Store.DataDir = t.TempDir()
+
+	issued := &token.IssuedToken{
+		AccessToken: "restart-token",
+		ShareID:     "share-1",
+		ClientID:    "receiver.example.org",
+		Permissions: []string{"read", "write"},
+		IssuedAt:    time.Now(),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	first, err := wiring.Build(cfg, tslog.DiscardLogger(), harnessBuildOpts())
+	if err != nil {
+		t.Fatalf("first Build failed: %v", err)
+	}
+
+	if err := first.Deps.TokenStore.Store(t.Context(), issued); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if err := first.TokenStore.Close(); err != nil {
+		t.Fatalf("first TokenStore.Close(): %v", err)
+	}
+
+	second, err := wiring.Build(cfg, tslog.DiscardLogger(), harnessBuildOpts())
+	if err != nil {
+		t.Fatalf("second Build failed: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := second.TokenStore.Close(); err != nil {
+			t.Errorf("second TokenStore.Close(): %v", err)
+		}
+	})
+
+	got, err := second.Deps.TokenStore.Get(t.Context(), issued.AccessToken)
+	if err != nil {
+		t.Fatalf("Get after rebuild: %v", err)
+	}
+
+	if got.ShareID != issued.ShareID || len(got.Permissions) != 2 {
+		t.Errorf("Get after rebuild = %+v, want share-1 with read,write", got)
+	}
+}
+
 func TestPersistence_RejectsUnknownBackend(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package wiring_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	tslog "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/log"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/wiring"
+
+	_ "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache/loader"
+)
+
+func TestReloader_AppliesReloadableSettings(t *testing.T) {
+	t.Parallel()
+
+	next := peerTrustCfg()
+
+	opts := harnessBuildOpts()
+	opts.SkipPeerTrust = false
+	opts.LogLevel = new(slog.LevelVar)
+	opts.ReloadConfig = func() (*config.Config, error) {
+		cfg := *next
+
+		return &cfg, nil
+	}
+
+	result, err := wiring.Build(peerTrustCfg(), tslog.DiscardLogger(), opts)
+	if err != nil {
+		t.Fatalf("bootstrap failed: %v", err)
+	}
+
+	reloader := result.Deps.Reloader
+	if reloader == nil {
+		t.Fatal("Reloader must be set when ReloadConfig is provided")
+	}
+
+	if d := result.Deps.PolicyEngine.Evaluate(context.Background(), "bad.example.com", true); d.ReasonCode == "denied_by_denylist" {
+		t.Fatalf("bad.example.com denied before reload: %+v", d)
+	}
+
+	next.Logging.Level = "warn"
+	next.PeerTrust.Policy.DenyList = []string{"bad.example.com"}
+	next.ListenAddr = ":9999"
+
+	report, err := reloader.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if !slices.Equal(report.Applied, []string{"logging.level", "peer_trust.policy.deny_list"}) {
+		t.Errorf("Applied = %v", report.Applied)
+	}
+
+	if !slices.Equal(report.RestartRequired, []string{"listen_addr"}) {
+		t.Errorf("RestartRequired = %v", report.RestartRequired)
+	}
+
+	if d := result.Deps.PolicyEngine.Evaluate(context.Background(), "bad.example.com", true); d.ReasonCode != "denied_by_denylist" {
+		t.Errorf("bad.example.com after reload = %+v, want denied_by_denylist", d)
+	}
+
+	if got := opts.LogLevel.Level(); got != slog.LevelWarn {
+		t.Errorf("log level after reload = %v, want WARN", got)
+	}
+}
+
+func TestReloader_FailedLoadKeepsRunningConfig(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "missing-trust-group.json")
+	loadErr := errors.New("invalid config")
+
+	var next *config.Config
+
+	opts := harnessBuildOpts()
+	opts.SkipPeerTrust = false
+	opts.ReloadConfig = func() (*config.Config, error) {
+		if next == nil {
+			return nil, loadErr
+		}
+
+		return next, nil
+	}
+
+	result, err := wiring.Build(peerTrustCfg(), tslog.DiscardLogger(), opts)
+	if err != nil {
+		t.Fatalf("bootstrap failed: %v", err)
+	}
+
+	if _, err := result.Deps.Reloader.Reload(); !errors.Is(err, loadErr) {
+		t.Fatalf("Reload with a failing loader = %v, want %v", err, loadErr)
+	}
+
+	// A trust group file that cannot be read fails the whole reload, so the
+	// deny list below is not applied either.
+	next = peerTrustCfg()
+	next.PeerTrust.ConfigPaths = []string{missing}
+	next.PeerTrust.Policy.DenyList = []string{"bad.example.com"}
+
+	if _, err := result.Deps.Reloader.Reload(); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Reload with a missing trust group file = %v, want ErrNotExist", err)
+	}
+
+	if d := result.Deps.PolicyEngine.Evaluate(context.Background(), "bad.example.com", true); d.ReasonCode == "denied_by_denylist" {
+		t.Errorf("deny list applied from a failed reload: %+v", d)
+	}
+}
+
+func TestReloader_NilWithoutReloadConfig(t *testing.T) {
+	t.Parallel()
+
+	result, err := wiring.Build(config.DevConfig(), tslog.DiscardLogger(), harnessBuildOpts())
+	if err != nil {
+		t.Fatalf("bootstrap failed: %v", err)
+	}
+
+	if result.Deps.Reloader != nil {
+		t.Error("Reloader must be nil when ReloadConfig is not set")
+	}
+}
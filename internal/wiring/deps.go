@@ -7,16 +7,20 @@ package wiring
 
 import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/identity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/datatx"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/inbound/signature"
 	invitesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/incoming"
 	invitesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/invites/outgoing"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/outbox"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peerorigin"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/policy"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/sharerequests"
 	sharesincoming "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/incoming"
 	sharesoutgoing "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/shares/outgoing"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/token"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/cache"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto"
@@ -29,6 +33,7 @@ import (
 type Deps struct {
 	// Identity (for session-gated endpoints)
 	PartyRepo   identity.PartyRepo
+	GroupRepo   identity.GroupRepo
 	SessionRepo identity.SessionRepo
 	UserAuth    *identity.UserAuth
 
@@ -37,7 +42,11 @@ type Deps struct {
 	OutgoingShareRepo  sharesoutgoing.OutgoingShareRepo
 	OutgoingInviteRepo invitesoutgoing.OutgoingInviteRepo
 	IncomingInviteRepo invitesincoming.IncomingInviteRepo
+	ShareRequestRepo   sharerequests.Repo
 	TokenStore         token.TokenStore
+	OutboxRepo         outbox.MessageRepo
+	TransferRepo       datatx.Repo
+	PeerTrustRepo      peertrust.Repo
 
 	// Clients
 	HTTPClient      *httpclient.ContextClient
@@ -68,4 +77,11 @@ type Deps struct {
 
 	// RealIP provides trusted-proxy-aware client IP extraction.
 	RealIP *realip.TrustedProxies
+
+	// Reloader applies config reloads (optional; nil when BuildOpts has no
+	// ReloadConfig).
+	Reloader *Reloader
+
+	// Audit records audit events (optional; nil when [audit] is disabled).
+	Audit *audit.Log
 }
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package wiring
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/inbound/signature"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/peertrust"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	httpclient "github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/client"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/localidentity"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/logutil"
+)
+
+// Reloader re-runs the config loader and applies the settings listed in
+// config.ReloadableKeys to the running components: peer trust allow/deny
+// lists and trust group files, SSRF route policies, signature timing
+// windows, and the log level. It is triggered by SIGHUP and by
+// POST /api/admin/config/reload.
+type Reloader struct {
+	load          func() (*config.Config, error)
+	logLevel      *slog.LevelVar
+	outbound      *httpclient.Client
+	sigMiddleware *signature.SignatureMiddleware
+	trustGroupMgr *peertrust.TrustGroupManager
+	policyEngine  *peertrust.PolicyEngine
+	logger        *slog.Logger
+
+	mu      sync.Mutex
+	running *config.Config
+}
+
+func newReloader(
+	running *config.Config,
+	opts BuildOpts,
+	outbound *httpclient.Client,
+	sigMiddleware *signature.SignatureMiddleware,
+	trustGroupMgr *peertrust.TrustGroupManager,
+	policyEngine *peertrust.PolicyEngine,
+	logger *slog.Logger,
+) *Reloader {
+	return &Reloader{
+		load:          opts.ReloadConfig,
+		logLevel:      opts.LogLevel,
+		outbound:      outbound,
+		sigMiddleware: sigMiddleware,
+		trustGroupMgr: trustGroupMgr,
+		policyEngine:  policyEngine,
+		logger:        logutil.NoopIfNil(logger),
+		running:       running,
+	}
+}
+
+// Reload loads and validates the config, then swaps in its reloadable
+// settings. The report lists the reloadable keys that changed and the changed
+// keys that need a restart. When loading or validation fails, including any
+// trust group file, nothing is applied.
+func (r *Reloader) Reload() (config.ReloadReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := r.load()
+	if err != nil {
+		return config.ReloadReport{}, fmt.Errorf("reload config: %w", err)
+	}
+
+	// Mirror the startup normalization so it does not show up as a change.
+	localIdentity, err := localidentity.Derive(next.PublicOrigin, next.ExternalBasePath)
+	if err != nil {
+		return config.ReloadReport{}, fmt.Errorf("reload config: derive local public identity: %w", err)
+	}
+
+	next.ExternalBasePath = localIdentity.ExternalBasePath
+
+	var trustGroups []*peertrust.TrustGroupConfig
+
+	if r.trustGroupMgr != nil {
+		for _, cfgPath := range next.PeerTrust.ConfigPaths {
+			tgCfg, loadErr := peertrust.LoadTrustGroupConfig(cfgPath)
+			if loadErr != nil {
+				return config.ReloadReport{}, fmt.Errorf("reload config: %w", loadErr)
+			}
+
+			trustGroups = append(trustGroups, tgCfg)
+		}
+	}
+
+	report := config.DiffForReload(r.running, next)
+	if r.policyEngine == nil {
+		// Peer trust is off; its settings only matter after enabling it,
+		// which needs a restart.
+		report = deferPeerTrustKeys(report)
+	}
+
+	applied := config.WithReloadable(r.running, next)
+
+	if r.policyEngine != nil {
+		r.policyEngine.UpdatePolicy(peertrustPolicyFromConfig(&applied.PeerTrust.Policy))
+		r.trustGroupMgr.ReplaceTrustGroups(trustGroups)
+	}
+
+	if r.outbound != nil {
+		r.outbound.SetRoutePolicies(applied.OutboundHTTP.SSRF.RoutePolicy, applied.OutboundHTTP.SSRF.RoutePolicies)
+	}
+
+	if r.sigMiddleware != nil {
+		r.sigMiddleware.UpdateSignatureConfig(applied.Signature)
+	}
+
+	if r.logLevel != nil {
+		r.logLevel.Set(logutil.ParseLevel(applied.Logging.Level))
+	}
+
+	r.running = applied
+
+	r.logger.Info("configuration reloaded",
+		"applied", report.Applied,
+		"restart_required", report.RestartRequired)
+
+	return report, nil
+}
+
+func deferPeerTrustKeys(report config.ReloadReport) config.ReloadReport {
+	applied := []string{}
+
+	for _, key := range report.Applied {
+		if strings.HasPrefix(key, "peer_trust.") {
+			report.RestartRequired = append(report.RestartRequired, key)
+
+			continue
+		}
+
+		applied = append(applied, key)
+	}
+
+	report.Applied = applied
+	slices.Sort(report.RestartRequired)
+
+	return report
+}
@@ -19,10 +19,18 @@ func resolveInputs(cfg *config.Config, d *Deps) resolve.ResolveInputs {
 
 	var advertiseDenylist, advertiseAllowlist bool
 
+	// The policy engine also holds the lists edited through the admin API,
+	// so discovery follows it when it is wired.
+	var peerTrustLists resolve.PeerTrustLists
+
 	if cfg.PeerTrust.Enabled {
 		policyCfg := peertrustPolicyFromConfig(&cfg.PeerTrust.Policy)
 		advertiseDenylist = policyCfg.HasDenylist()
 		advertiseAllowlist = policyCfg.HasAllowlist()
+
+		if d.PolicyEngine != nil {
+			peerTrustLists = d.PolicyEngine
+		}
 	}
 
 	return resolve.ResolveInputs{
@@ -35,8 +43,10 @@ func resolveInputs(cfg *config.Config, d *Deps) resolve.ResolveInputs {
 		JwksURIOverride:        cfg.Signature.JwksURI,
 		AdvertiseDenylist:      advertiseDenylist,
 		AdvertiseAllowlist:     advertiseAllowlist,
+		PeerTrustLists:         peerTrustLists,
 		AdvertiseMustInvite:    cfg.OCM.MustInviteEnforced(),
 		AdvertiseNotifications: true,
+		SSHAddr:                cfg.OCM.SSH.Advertised(cfg.PublicOrigin),
 	}
 }
 
@@ -9,14 +9,18 @@ import (
 	"fmt"
 	"log/slog"
 
+	adminaudit "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/audit"
+	adminreload "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/admin/reload"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/discovery/resolve"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/ocm/policy"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service"
 	svccfg "github.com/MahdiBaghbani/opencloudmesh-go/internal/frameworks/service/cfg"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/interceptors/ratelimit"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/server"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/services/api"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/services/metrics"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/services/ocm"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/services/ocmaux"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/services/ui"
@@ -39,6 +43,7 @@ var coreServiceBuilders = map[service.BuildKey]coreServiceBuilder{
 	service.BuildAPI:       buildAPIService,
 	service.BuildUI:        buildUIService,
 	service.BuildWebDAV:    buildWebDAVService,
+	service.BuildMetrics:   buildMetricsService,
 }
 
 // RegisteredBuildKeys returns the build keys wired in this package.
@@ -107,6 +112,16 @@ func ratelimitInputs(d *Deps) ratelimit.Inputs {
 	}
 }
 
+// auditRecorder returns the audit log as a Recorder, or a nil interface when
+// audit is disabled.
+func auditRecorder(d *Deps) audit.Recorder {
+	if d.Audit == nil {
+		return nil
+	}
+
+	return d.Audit
+}
+
 func buildWellknownService(cfg *config.Config, svcCfg map[string]any, log *slog.Logger, d *Deps) (service.Service, error) {
 	svc, err := wellknown.New(wellknown.Inputs{
 		Resolve:             resolveInputs(cfg, d),
@@ -132,7 +147,9 @@ func buildOCMService(cfg *config.Config, svcCfg map[string]any, log *slog.Logger
 		OutgoingShareRepo:   d.OutgoingShareRepo,
 		IncomingInviteRepo:  d.IncomingInviteRepo,
 		OutgoingInviteRepo:  d.OutgoingInviteRepo,
+		ShareRequestRepo:    d.ShareRequestRepo,
 		PartyRepo:           d.PartyRepo,
+		GroupRepo:           d.GroupRepo,
 		PolicyEngine:        d.PolicyEngine,
 		CodeFlow:            d.CodeFlow,
 		PeerMappingResolver: peerMappingResolver,
@@ -140,8 +157,11 @@ func buildOCMService(cfg *config.Config, svcCfg map[string]any, log *slog.Logger
 		TokenStore:          d.TokenStore,
 		SignatureMiddleware: d.SignatureMiddleware,
 		TokenExchangePath:   tokenPath,
+		AccessTokenTTL:      cfg.TokenExchange.AccessTokenTTL(),
+		RefreshTokenTTL:     cfg.TokenExchange.RefreshTokenTTL(),
 		KeyManager:          d.KeyManager,
 		MustInviteEnforced:  cfg.OCM.MustInviteEnforced(),
+		Audit:               auditRecorder(d),
 	}, svcCfg, log)
 	if err != nil {
 		return nil, fmt.Errorf("wiring: wire ocm service: %w", err)
@@ -198,22 +218,49 @@ func buildAPIService(cfg *config.Config, svcCfg map[string]any, log *slog.Logger
 	resolved := resolve.Resolve(&providerCfg, rawOCMProvider, resolveInputs(cfg, d))
 	localTokenEndpoint := resolved.Params.TokenEndPoint
 
+	// A nil Reloader must reach the handler as a nil interface.
+	var reloader adminreload.Reloader
+	if d.Reloader != nil {
+		reloader = d.Reloader
+	}
+
+	var auditLog adminaudit.Log
+	if d.Audit != nil {
+		auditLog = d.Audit
+	}
+
 	svc, err := api.New(api.Inputs{
 		PartyRepo:             d.PartyRepo,
+		GroupRepo:             d.GroupRepo,
 		SessionRepo:           d.SessionRepo,
 		UserAuth:              d.UserAuth,
 		IncomingShareRepo:     d.IncomingShareRepo,
 		OutgoingShareRepo:     d.OutgoingShareRepo,
 		IncomingInviteRepo:    d.IncomingInviteRepo,
 		OutgoingInviteRepo:    d.OutgoingInviteRepo,
+		ShareRequestRepo:      d.ShareRequestRepo,
+		TokenStore:            d.TokenStore,
+		OutboxRepo:            d.OutboxRepo,
+		TransferRepo:          d.TransferRepo,
 		HTTPClient:            d.HTTPClient,
 		DiscoveryClient:       d.DiscoveryClient,
 		Signer:                d.Signer,
+		KeyManager:            d.KeyManager,
+		KeyRotation:           cfg.Signature.Rotation,
+		Reloader:              reloader,
+		Audit:                 auditRecorder(d),
+		AuditLog:              auditLog,
+		PolicyEngine:          d.PolicyEngine,
+		TrustGroupMgr:         d.TrustGroupMgr,
+		PeerTrustRepo:         d.PeerTrustRepo,
 		PeerOrigin:            d.PeerOrigin,
 		OutgoingFactsResolver: peerMappingResolver,
 		LocalTokenEndpoint:    localTokenEndpoint,
 		LocalIdentity:         d.LocalIdentity,
 		ContentDir:            cfg.Persistence.ContentDir,
+		Webapp:                cfg.OCM.Webapp,
+		SSHAddr:               cfg.OCM.SSH.Advertised(cfg.PublicOrigin),
+		DataTx:                cfg.OCM.DataTx,
 		Ratelimit:             ratelimitInputs(d),
 		InterceptorProfiles:   profiles,
 	}, svcCfg, log)
@@ -235,10 +282,11 @@ func buildUIService(_ *config.Config, svcCfg map[string]any, log *slog.Logger, d
 	return svc, nil
 }
 
-func buildWebDAVService(_ *config.Config, svcCfg map[string]any, log *slog.Logger, d *Deps) (service.Service, error) {
+func buildWebDAVService(cfg *config.Config, svcCfg map[string]any, log *slog.Logger, d *Deps) (service.Service, error) {
 	svc, err := webdav.New(webdav.Inputs{
 		OutgoingShareRepo: d.OutgoingShareRepo,
 		TokenStore:        d.TokenStore,
+		SSH:               cfg.OCM.SSH,
 	}, svcCfg, log)
 	if err != nil {
 		return nil, fmt.Errorf("wiring: wire webdav service: %w", err)
@@ -246,3 +294,12 @@ func buildWebDAVService(_ *config.Config, svcCfg map[string]any, log *slog.Logge
 
 	return svc, nil
 }
+
+func buildMetricsService(_ *config.Config, svcCfg map[string]any, log *slog.Logger, _ *Deps) (service.Service, error) {
+	svc, err := metrics.New(svcCfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("wiring: wire metrics service: %w", err)
+	}
+
+	return svc, nil
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+// TestAdminUsers_Lifecycle drives /api/admin/users end to end: an admin creates
+// a user who can then sign in but not administer, a password reset ends that
+// user's session, and deletion removes the account.
+func TestAdminUsers_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	ts := harness.StartTestServer(t)
+	defer ts.Stop(t)
+
+	adminToken, _, ok := tryLogin(t, ts.BaseURL, "admin", "admin")
+	if !ok {
+		t.Fatal("harness admin login failed")
+	}
+
+	status, body := adminUsersRequest(t, ts.BaseURL, adminToken, http.MethodPost, "/api/admin/users",
+		map[string]string{"username": "carol", "password": "carol-password", "realm": "lab"})
+	if status != http.StatusCreated {
+		t.Fatalf("create user: status %d: %s", status, body)
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		t.Fatalf("decode created user: %v", err)
+	}
+
+	carolToken, loginBody, ok := tryLogin(t, ts.BaseURL, "carol", "carol-password")
+	if !ok {
+		t.Fatalf("created user cannot sign in: %s", loginBody)
+	}
+
+	if status, body := adminUsersRequest(t, ts.BaseURL, carolToken, http.MethodGet, "/api/admin/users", nil); status != http.StatusForbidden {
+		t.Fatalf("non-admin list: status %d, want 403: %s", status, body)
+	}
+
+	status, body = adminUsersRequest(t, ts.BaseURL, adminToken, http.MethodGet, "/api/admin/users?realm=lab", nil)
+	if status != http.StatusOK {
+		t.Fatalf("list realm: status %d: %s", status, body)
+	}
+
+	var listed struct {
+		Users []struct {
+			Username string `json:"username"`
+		} `json:"users"`
+	}
+	if err := json.Unmarshal([]byte(body), &listed); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+
+	if len(listed.Users) != 1 || listed.Users[0].Username != "carol" {
+		t.Fatalf("realm=lab users = %+v, want only carol", listed.Users)
+	}
+
+	status, body = adminUsersRequest(t, ts.BaseURL, adminToken, http.MethodPost,
+		"/api/admin/users/"+created.ID+"/password", map[string]string{"password": "carol-rotated"})
+	if status != http.StatusNoContent {
+		t.Fatalf("reset password: status %d: %s", status, body)
+	}
+
+	if status, _ := adminUsersRequest(t, ts.BaseURL, carolToken, http.MethodGet, "/api/auth/me", nil); status != http.StatusUnauthorized {
+		t.Fatalf("session after password reset: status %d, want 401", status)
+	}
+
+	if _, _, ok := tryLogin(t, ts.BaseURL, "carol", "carol-rotated"); !ok {
+		t.Fatal("login with reset password failed")
+	}
+
+	status, body = adminUsersRequest(t, ts.BaseURL, adminToken, http.MethodDelete, "/api/admin/users/"+created.ID, nil)
+	if status != http.StatusNoContent {
+		t.Fatalf("delete user: status %d: %s", status, body)
+	}
+
+	if _, _, ok := tryLogin(t, ts.BaseURL, "carol", "carol-rotated"); ok {
+		t.Fatal("deleted user can still sign in")
+	}
+}
+
+func adminUsersRequest(t *testing.T, baseURL, token, method, path string, payload any) (int, string) {
+	t.Helper()
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(tshttp.MustMarshalJSON(t, payload))
+	}
+
+	req, err := http.NewRequestWithContext(t.Context(), method, baseURL+path, reqBody)
+	if err != nil {
+		t.Fatalf("build %s %s: %v", method, path, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer tshttp.MustClose(t, resp.Body)
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response body: %v", err)
+	}
+
+	return resp.StatusCode, string(respBody)
+}
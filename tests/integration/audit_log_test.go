@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/audit"
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	tsprotocol "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/protocol"
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+const auditEnabledConfig = "\n[audit]\nenabled = true\n"
+
+// TestAuditLog_RecordsShareLifecycleOnBothPeers exchanges an invite and
+// accepts a share between two audited instances, then reads both audit logs
+// through /api/admin/audit and checks the hash chain via the verify endpoint
+// and on disk.
+func TestAuditLog_RecordsShareLifecycleOnBothPeers(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	pair := harness.StartStrictProtocolPairWithOptions(t, harness.StrictProtocolPairStartOptions{
+		TLSRootCAFile: tsprotocol.StrictProtocolTLSRootCA(harness.FindProjectRoot(t)),
+		ExtraConfigBuilder: func(allowedPorts []int, moduleRoot, loopbackHost string) string {
+			return tsprotocol.StrictProtocolPairExtraConfig(tsprotocol.StrictProtocolPairExtraConfigOptions{
+				ModuleRoot:   moduleRoot,
+				LoopbackHost: loopbackHost,
+				AllowedPorts: allowedPorts,
+				Variant:      tsprotocol.VariantProtocolPair,
+			})
+		},
+		Server1ExtraConfig: auditEnabledConfig,
+		Server2ExtraConfig: auditEnabledConfig,
+	})
+	defer pair.Stop(t)
+
+	provider := pair.Server1
+	consumer := pair.Server2
+
+	providerToken := loginSubprocessAdminWithClient(t, provider)
+	consumerToken := loginSubprocessAdminWithClient(t, consumer)
+	consumerHost := hostFromBaseURL(t, consumer.BaseURL)
+
+	exchangeInvitesBetweenPair(t, provider, consumer, providerToken, consumerToken)
+
+	testFile := writeShareFileInContentRoot(t, provider.TempDir, "audited.txt", []byte("audited"))
+
+	status, body := createOutgoingShareWithClient(t, provider, providerToken, map[string]any{
+		"receiverDomain": consumerHost,
+		"shareWith":      "admin@" + consumerHost,
+		"localPath":      testFile,
+		"permissions":    []string{"read"},
+	})
+	if status != http.StatusCreated {
+		provider.DumpLogs(t)
+		t.Fatalf("outgoing share: expected 201, got %d: %s", status, body)
+	}
+
+	var created struct {
+		ShareID    string `json:"shareId"`
+		ProviderID string `json:"providerId"`
+	}
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		t.Fatalf("decode outgoing share response: %v", err)
+	}
+
+	inboxShareID := waitForInboxShareByProvider(t, consumer, consumerToken, created.ProviderID)
+
+	if status, body = shareRequestAPI(t, consumer, consumerToken, "/api/inbox/shares/"+inboxShareID+"/accept", nil); status != http.StatusOK {
+		t.Fatalf("accept inbox share: status %d: %s", status, body)
+	}
+
+	// The provider learns about the accept from an async notification.
+	accepted := waitForAuditEvents(t, provider, providerToken, url.Values{"action": {audit.ActionShareAccept}}, 1)
+	assertAuditEvent(t, accepted[0], audit.ActionShareAccept, created.ShareID, audit.DirectionOutgoing)
+
+	providerShare := queryAudit(t, provider, providerToken, url.Values{"action": {audit.ActionShareCreate}, "actor": {"admin"}})
+	if len(providerShare) != 1 {
+		t.Fatalf("provider share.create events = %+v, want 1", providerShare)
+	}
+
+	assertAuditEvent(t, providerShare[0], audit.ActionShareCreate, created.ShareID, audit.DirectionOutgoing)
+
+	if providerShare[0].Peer != accepted[0].Peer {
+		t.Errorf("share.create peer %q and share.accept peer %q differ", providerShare[0].Peer, accepted[0].Peer)
+	}
+
+	byPeer := queryAudit(t, provider, providerToken, url.Values{"peer": {providerShare[0].Peer}})
+	if len(byPeer) < 3 {
+		t.Errorf("provider events for peer %s = %d, want invite.accept, share.create and share.accept", providerShare[0].Peer, len(byPeer))
+	}
+
+	consumerEvents := queryAudit(t, consumer, consumerToken, nil)
+	actions := map[string]audit.Event{}
+
+	for _, e := range consumerEvents {
+		if _, seen := actions[e.Action]; !seen {
+			actions[e.Action] = e
+		}
+	}
+
+	for _, want := range []string{audit.ActionAdminLogin, audit.ActionInviteAccept, audit.ActionShareCreate, audit.ActionShareAccept} {
+		if _, ok := actions[want]; !ok {
+			t.Errorf("consumer audit log has no %s event: %+v", want, consumerEvents)
+		}
+	}
+
+	assertAuditEvent(t, actions[audit.ActionShareAccept], audit.ActionShareAccept, inboxShareID, audit.DirectionIncoming)
+
+	if got := actions[audit.ActionShareAccept].Actor; got != "admin" {
+		t.Errorf("consumer share.accept actor = %q, want admin", got)
+	}
+
+	if got := actions[audit.ActionInviteAccept].Actor; got != "admin" {
+		t.Errorf("consumer invite.accept actor = %q, want admin", got)
+	}
+
+	for _, srv := range []struct {
+		server *harness.SubprocessServer
+		token  string
+	}{{provider, providerToken}, {consumer, consumerToken}} {
+		assertAuditChainValid(t, srv.server, srv.token)
+	}
+}
+
+func queryAudit(t *testing.T, srv *harness.SubprocessServer, token string, query url.Values) []audit.Event {
+	t.Helper()
+
+	status, body := adminAuditGet(t, srv, token, "/api/admin/audit?"+query.Encode())
+	if status != http.StatusOK {
+		srv.DumpLogs(t)
+		t.Fatalf("GET /api/admin/audit: expected 200, got %d: %s", status, body)
+	}
+
+	var resp struct {
+		Events []audit.Event `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatalf("decode audit events: %v", err)
+	}
+
+	return resp.Events
+}
+
+func waitForAuditEvents(t *testing.T, srv *harness.SubprocessServer, token string, query url.Values, want int) []audit.Event {
+	t.Helper()
+
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		if events := queryAudit(t, srv, token, query); len(events) >= want {
+			return events
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	srv.DumpLogs(t)
+	t.Fatalf("timed out waiting for %d audit events matching %s on %s", want, query.Encode(), srv.Name)
+
+	return nil
+}
+
+func assertAuditEvent(t *testing.T, e audit.Event, action, resource, direction string) {
+	t.Helper()
+
+	if e.Action != action || e.Resource != resource || e.Outcome != audit.OutcomeSuccess ||
+		e.Details[audit.DetailDirection] != direction || e.Peer == "" {
+		t.Errorf("event = %+v, want %s of %s (%s) with a peer", e, action, resource, direction)
+	}
+}
+
+// assertAuditChainValid checks the chain through the admin API and by
+// re-reading audit.jsonl from the server's data dir.
+func assertAuditChainValid(t *testing.T, srv *harness.SubprocessServer, token string) {
+	t.Helper()
+
+	status, body := adminAuditGet(t, srv, token, "/api/admin/audit/verify")
+	if status != http.StatusOK {
+		t.Fatalf("GET /api/admin/audit/verify on %s: expected 200, got %d: %s", srv.Name, status, body)
+	}
+
+	var res audit.VerifyResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatalf("decode verify result: %v", err)
+	}
+
+	if !res.Valid || res.Events < 4 {
+		t.Errorf("%s: verify = %+v, want a valid chain of at least 4 events", srv.Name, res)
+	}
+
+	f, err := os.Open(filepath.Join(srv.TempDir, "data", audit.LogFileName))
+	if err != nil {
+		t.Fatalf("open %s audit log: %v", srv.Name, err)
+	}
+	defer mustClose(t, f)
+
+	onDisk, err := audit.Verify(f)
+	if err != nil || !onDisk.Valid || onDisk.HeadHash != res.HeadHash {
+		t.Errorf("%s: on-disk verify = %+v, %v; want the API head %s", srv.Name, onDisk, err, res.HeadHash)
+	}
+}
+
+func adminAuditGet(t *testing.T, srv *harness.SubprocessServer, token, path string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.BaseURL+path, nil)
+	if err != nil {
+		t.Fatalf("build %s request: %v", path, err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := srv.Client().Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer tshttp.MustClose(t, resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response body: %v", err)
+	}
+
+	return resp.StatusCode, strings.TrimSpace(string(body))
+}
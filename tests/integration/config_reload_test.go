@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+// TestConfigReload_SIGHUPAndAdminAPI edits config.toml of a running server,
+// reloads it with SIGHUP, and checks the admin reload report: the deny list is
+// applied in place while the membership cache TTL is reported as needing a
+// restart.
+func TestConfigReload_SIGHUPAndAdminAPI(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	binaryPath := harness.BuildBinary(t)
+
+	srv := harness.StartSubprocessServer(t, binaryPath, harness.SubprocessConfig{
+		Name: "config-reload",
+		Mode: "dev",
+		ExtraFiles: map[string]string{
+			"trust-group.json": `{"trustGroupId": "reload-tg", "enabled": true, "directoryServices": [], "keys": []}`,
+		},
+		ExtraConfig: `
+[peer_trust]
+enabled = true
+config_paths = ["trust-group.json"]
+
+[peer_trust.policy]
+deny_list = []
+
+[peer_trust.membership_cache]
+ttl_seconds = 300
+`,
+	})
+	defer srv.Stop(t)
+
+	token := loginSubprocessAdminWithClient(t, srv)
+
+	type reloadReport struct {
+		Applied         []string `json:"applied"`
+		RestartRequired []string `json:"restartRequired"`
+	}
+
+	reload := func() reloadReport {
+		t.Helper()
+
+		status, body := shareRequestAPI(t, srv, token, "/api/admin/config/reload", nil)
+		if status != http.StatusOK {
+			srv.DumpLogs(t)
+			t.Fatalf("reload: expected 200, got %d: %s", status, body)
+		}
+
+		var report reloadReport
+		if err := json.Unmarshal([]byte(body), &report); err != nil {
+			t.Fatalf("decode reload report: %v", err)
+		}
+
+		return report
+	}
+
+	if report := reload(); len(report.Applied) != 0 || len(report.RestartRequired) != 0 {
+		t.Fatalf("unchanged config reported changes: %+v", report)
+	}
+
+	srv.RewriteConfig(t, func(content string) string {
+		content = strings.Replace(content, "deny_list = []", `deny_list = ["blocked.example.com"]`, 1)
+
+		return strings.Replace(content, "ttl_seconds = 300", "ttl_seconds = 600", 1)
+	})
+
+	srv.Signal(t, syscall.SIGHUP)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !srv.LogContainsAny("configuration reloaded") {
+		if time.Now().After(deadline) {
+			srv.DumpLogs(t)
+			t.Fatal("server did not log a reload after SIGHUP")
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// The deny list is already in effect, so a second reload applies nothing
+	// new; the TTL change still waits for a restart.
+	report := reload()
+	if len(report.Applied) != 0 {
+		t.Errorf("Applied after SIGHUP = %v, want none", report.Applied)
+	}
+
+	if !slices.Equal(report.RestartRequired, []string{"peer_trust.membership_cache.ttl_seconds"}) {
+		t.Errorf("RestartRequired = %v", report.RestartRequired)
+	}
+
+	srv.RewriteConfig(t, func(content string) string {
+		return strings.Replace(content, `mode = "dev"`, `mode = "bogus"`, 1)
+	})
+
+	status, body := shareRequestAPI(t, srv, token, "/api/admin/config/reload", nil)
+	if status != http.StatusUnprocessableEntity {
+		t.Errorf("reload of an invalid config: expected 422, got %d: %s", status, body)
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	tsprotocol "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/protocol"
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+// inboxTransfer is the transfer field of the inbox share detail.
+type inboxTransfer struct {
+	Status      string `json:"status"`
+	BytesTotal  int64  `json:"bytesTotal"`
+	BytesDone   int64  `json:"bytesDone"`
+	FilesTotal  int    `json:"filesTotal"`
+	FilesDone   int    `json:"filesDone"`
+	LastError   string `json:"lastError"`
+	Destination string `json:"destination"`
+}
+
+// TestDataTxShare_CopiedIntoRecipientStorage sends a file and a folder with
+// "datatx": true. Accepting them on the receiver copies both over WebDAV
+// into the recipient's storage, and the inbox detail reports the progress.
+func TestDataTxShare_CopiedIntoRecipientStorage(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	storageDir := t.TempDir()
+
+	pair := harness.StartStrictProtocolPairWithOptions(t, harness.StrictProtocolPairStartOptions{
+		TLSRootCAFile: tsprotocol.StrictProtocolTLSRootCA(harness.FindProjectRoot(t)),
+		ExtraConfigBuilder: func(allowedPorts []int, moduleRoot, loopbackHost string) string {
+			return tsprotocol.StrictProtocolPairExtraConfig(tsprotocol.StrictProtocolPairExtraConfigOptions{
+				ModuleRoot:   moduleRoot,
+				LoopbackHost: loopbackHost,
+				AllowedPorts: allowedPorts,
+				Variant:      tsprotocol.VariantProtocolPair,
+			})
+		},
+		Server2ExtraConfig: "\n[ocm.datatx]\nstorage_dir = \"" + filepath.ToSlash(storageDir) + "\"\n",
+	})
+	defer pair.Stop(t)
+
+	provider := pair.Server1
+	consumer := pair.Server2
+
+	content := []byte(strings.Repeat("datatx payload ", 4096))
+	testFile := writeShareFileInContentRoot(t, provider.TempDir, "dataset.bin", content)
+
+	folder := filepath.Join(filepath.Dir(testFile), "results")
+	writeTree(t, folder, map[string]string{"summary.txt": "ok", "run1/out.csv": "a,b\n1,2\n"})
+
+	providerToken := loginSubprocessAdminWithClient(t, provider)
+	consumerToken := loginSubprocessAdminWithClient(t, consumer)
+
+	consumerHost := hostFromBaseURL(t, consumer.BaseURL)
+
+	exchangeInvitesBetweenPair(t, provider, consumer, providerToken, consumerToken)
+
+	fileTransfer := sendAndAcceptDataTxShare(t, pair, providerToken, consumerToken, testFile, consumerHost)
+	if fileTransfer.BytesTotal != int64(len(content)) || fileTransfer.BytesDone != fileTransfer.BytesTotal ||
+		fileTransfer.FilesDone != 1 {
+		t.Errorf("file transfer progress = %+v", fileTransfer)
+	}
+
+	if filepath.Dir(filepath.Dir(fileTransfer.Destination)) != storageDir || filepath.Base(fileTransfer.Destination) != "dataset.bin" {
+		t.Errorf("file destination = %q, want storage_dir/{userId}/dataset.bin", fileTransfer.Destination)
+	}
+
+	if got, err := os.ReadFile(fileTransfer.Destination); err != nil || string(got) != string(content) {
+		t.Fatalf("copied file differs from the shared one: %v", err)
+	}
+
+	folderTransfer := sendAndAcceptDataTxShare(t, pair, providerToken, consumerToken, folder, consumerHost)
+	if folderTransfer.FilesTotal != 2 || folderTransfer.FilesDone != 2 {
+		t.Errorf("folder transfer progress = %+v", folderTransfer)
+	}
+
+	for rel, want := range map[string]string{"summary.txt": "ok", "run1/out.csv": "a,b\n1,2\n"} {
+		got, err := os.ReadFile(filepath.Join(folderTransfer.Destination, filepath.FromSlash(rel)))
+		if err != nil || string(got) != want {
+			t.Errorf("copied %s = %q, %v; want %q", rel, got, err, want)
+		}
+	}
+}
+
+// sendAndAcceptDataTxShare shares localPath with datatx, accepts it on the
+// consumer, and waits for the transfer to complete.
+func sendAndAcceptDataTxShare(
+	t *testing.T,
+	pair *harness.StrictProtocolPair,
+	providerToken, consumerToken, localPath, consumerHost string,
+) inboxTransfer {
+	t.Helper()
+
+	provider, consumer := pair.Server1, pair.Server2
+
+	status, body := createOutgoingShareWithClient(t, provider, providerToken, map[string]any{
+		"receiverDomain": consumerHost,
+		"shareWith":      "admin@" + consumerHost,
+		"localPath":      localPath,
+		"permissions":    []string{"read"},
+		"datatx":         true,
+	})
+	if status != http.StatusCreated {
+		provider.DumpLogs(t)
+		consumer.DumpLogs(t)
+		t.Fatalf("outgoing datatx share: expected 201, got %d: %s", status, body)
+	}
+
+	var created struct {
+		ProviderID string `json:"providerId"`
+	}
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		t.Fatalf("decode outgoing share response: %v", err)
+	}
+
+	shareID := waitForInboxShareByProvider(t, consumer, consumerToken, created.ProviderID)
+
+	if transfer := inboxShareTransfer(t, consumer, consumerToken, shareID); transfer != nil {
+		t.Fatalf("transfer before accept = %+v, want none", transfer)
+	}
+
+	if status, body = shareRequestAPI(t, consumer, consumerToken, "/api/inbox/shares/"+shareID+"/accept", nil); status != http.StatusOK {
+		t.Fatalf("accept inbox share: status %d: %s", status, body)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+
+	for time.Now().Before(deadline) {
+		transfer := inboxShareTransfer(t, consumer, consumerToken, shareID)
+		if transfer != nil && transfer.Status == "completed" {
+			return *transfer
+		}
+
+		if transfer != nil && transfer.Status == "failed" {
+			consumer.DumpLogs(t)
+			t.Fatalf("transfer failed: %s", transfer.LastError)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	consumer.DumpLogs(t)
+	t.Fatal("transfer did not complete")
+
+	return inboxTransfer{}
+}
+
+// inboxShareTransfer reads the transfer field of the inbox share detail.
+func inboxShareTransfer(t *testing.T, srv *harness.SubprocessServer, token, shareID string) *inboxTransfer {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.BaseURL+"/api/inbox/shares/"+shareID, nil)
+	if err != nil {
+		t.Fatalf("build inbox detail request: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := srv.Client().Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("GET inbox share: %v", err)
+	}
+	defer tshttp.MustClose(t, resp.Body)
+
+	var detail struct {
+		Transfer *inboxTransfer `json:"transfer"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		t.Fatalf("decode inbox share detail: %v", err)
+	}
+
+	return detail.Transfer
+}
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			t.Fatalf("mkdir %s: %v", filepath.Dir(p), err)
+		}
+
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+}
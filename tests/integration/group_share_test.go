@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// TestGroupShare_StrictTwoServer has the consumer admin create a local group
+// and the provider send a share to that group's address. The share must land
+// in the member's inbox as a group share.
+func TestGroupShare_StrictTwoServer(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	pair := startStrictProtocolPair(t)
+	defer pair.Stop(t)
+
+	provider := pair.Server1
+	consumer := pair.Server2
+
+	testFile := writeShareFileInContentRoot(t, provider.TempDir, "group-share.txt", []byte("group content"))
+
+	providerToken := loginSubprocessAdminWithClient(t, provider)
+	consumerToken := loginSubprocessAdminWithClient(t, consumer)
+
+	consumerHost := hostFromBaseURL(t, consumer.BaseURL)
+
+	exchangeInvitesBetweenPair(t, provider, consumer, providerToken, consumerToken)
+
+	status, body := shareRequestAPI(t, consumer, consumerToken, "/api/admin/groups",
+		map[string]any{"name": "team", "displayName": "Team", "members": []string{"admin"}})
+	if status != http.StatusCreated {
+		t.Fatalf("create group: status %d: %s", status, body)
+	}
+
+	status, body = createOutgoingShareWithClient(t, provider, providerToken, map[string]any{
+		"receiverDomain": consumerHost,
+		"shareWith":      "team@" + consumerHost,
+		"shareType":      "group",
+		"localPath":      testFile,
+		"permissions":    []string{"read"},
+	})
+	if status != http.StatusCreated {
+		provider.DumpLogs(t)
+		consumer.DumpLogs(t)
+		t.Fatalf("outgoing group share: expected 201, got %d: %s", status, body)
+	}
+
+	var created struct {
+		ProviderID string `json:"providerId"`
+		Status     string `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		t.Fatalf("decode outgoing share response: %v", err)
+	}
+
+	if created.Status != "sent" {
+		t.Fatalf("outgoing status = %q, want sent", created.Status)
+	}
+
+	shareID := waitForInboxShareByProvider(t, consumer, consumerToken, created.ProviderID)
+
+	detail := getInboxShareDetail(t, consumer, consumerToken, shareID)
+	if detail["shareType"] != "group" {
+		t.Fatalf("inbox shareType = %v, want group", detail["shareType"])
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/http/server"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/repos"
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/tokenstore"
 	"github.com/MahdiBaghbani/opencloudmesh-go/internal/wiring"
 
 	// Register cache drivers.
@@ -41,6 +42,7 @@ type TestServer struct {
 	TempDir     string
 	Deps        *wiring.Deps
 	persistence *repos.Repos
+	tokenStore  tokenstore.Store
 	once        sync.Once
 }
 
@@ -193,6 +195,7 @@ func startTestServer(t *testing.T, patch func(*config.Config), buildOpts wiring.
 		TempDir:     tempDir,
 		Deps:        d,
 		persistence: buildResult.Persistence,
+		tokenStore:  buildResult.TokenStore,
 	}
 
 	t.Cleanup(func() { ts.Stop(t) })
@@ -212,6 +215,12 @@ func (ts *TestServer) Stop(t *testing.T) {
 			t.Logf("warning: shutdown error: %v", err)
 		}
 
+		if ts.tokenStore != nil {
+			if err := ts.tokenStore.Close(); err != nil {
+				t.Logf("warning: token store close error: %v", err)
+			}
+		}
+
 		if ts.persistence != nil {
 			if err := ts.persistence.Close(); err != nil {
 				t.Logf("warning: persistence close error: %v", err)
@@ -261,6 +270,18 @@ func localListenerBaseURL(tlsMode string, port int) string {
 	return fmt.Sprintf("%s://localhost:%d", localListenerScheme(tlsMode), port)
 }
 
+// FreePort returns an available TCP port for an extra subprocess listener.
+func FreePort(t *testing.T) int {
+	t.Helper()
+
+	port, err := getFreePort(t.Context())
+	if err != nil {
+		t.Fatalf("reserve free port: %v", err)
+	}
+
+	return port
+}
+
 // getFreePort finds an available TCP port.
 func getFreePort(ctx context.Context) (int, error) {
 	// integration test harness: intentional bind for test network
@@ -6,6 +6,7 @@
 package harness
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -212,7 +213,9 @@ func TestIETFIntegrationBuildOpts_MatchesWiringBuildOpts(t *testing.T) {
 		OutboundOverride:   got.OutboundOverride,
 		SkipDiscoveryCache: true,
 	}
-	if got != want {
+	// BuildOpts holds a func field, so compare with DeepEqual; it matches
+	// nil funcs only.
+	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("IETFIntegrationBuildOpts() = %+v, want %+v", got, want)
 	}
 }
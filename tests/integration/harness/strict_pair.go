@@ -28,6 +28,11 @@ type StrictProtocolPairStartOptions struct {
 	TLSRootCAFile      string
 	ExtraFiles         map[string]string
 	ExtraAllowedPorts  []int
+	// Server1ExtraConfig is appended to Server1's config only, for settings
+	// that must differ between the two instances (listen ports, for example).
+	Server1ExtraConfig string
+	// Server2ExtraConfig is appended to Server2's config only.
+	Server2ExtraConfig string
 }
 
 // StartStrictProtocolPairWithOptions starts two strict subprocess servers using
@@ -75,10 +80,12 @@ func StartStrictProtocolPairWithOptions(t *testing.T, opts StrictProtocolPairSta
 	cfg1 := base
 	cfg1.Name = "strict-pair-1"
 	cfg1.Port = port1
+	cfg1.ExtraConfig += opts.Server1ExtraConfig
 
 	cfg2 := base
 	cfg2.Name = "strict-pair-2"
 	cfg2.Port = port2
+	cfg2.ExtraConfig += opts.Server2ExtraConfig
 
 	server1 := StartSubprocessServer(t, binaryPath, cfg1)
 	server2 := StartSubprocessServer(t, binaryPath, cfg2)
@@ -275,6 +275,31 @@ func (s *SubprocessServer) Stop(t *testing.T) {
 	}
 }
 
+// RewriteConfig replaces the running server's config.toml with the result of
+// edit applied to its current contents. The server only sees the change after
+// a reload or restart.
+func (s *SubprocessServer) RewriteConfig(t *testing.T, edit func(string) string) {
+	t.Helper()
+
+	content, err := os.ReadFile(s.configFile)
+	if err != nil {
+		t.Fatalf("failed to read config for %s: %v", s.Name, err)
+	}
+
+	if err := os.WriteFile(s.configFile, []byte(edit(string(content))), 0600); err != nil {
+		t.Fatalf("failed to write config for %s: %v", s.Name, err)
+	}
+}
+
+// Signal sends sig to the server process.
+func (s *SubprocessServer) Signal(t *testing.T, sig os.Signal) {
+	t.Helper()
+
+	if err := s.cmd.Process.Signal(sig); err != nil {
+		t.Fatalf("failed to signal %s: %v", s.Name, err)
+	}
+}
+
 // ReadLog returns the current server.log contents after syncing the shared log
 // file. stdout and stderr are redirected to the same file.
 func (s *SubprocessServer) ReadLog(t *testing.T) string {
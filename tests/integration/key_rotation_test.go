@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/crypto/jwks"
+)
+
+// TestKeyRotation_AdminStagedKeyIsPublished stages a next signing key through
+// the admin API: the provider publishes it next to the current key in its
+// JWKS while signed share delivery keeps working on the current key.
+func TestKeyRotation_AdminStagedKeyIsPublished(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	pair := startStrictProtocolPair(t)
+	defer pair.Stop(t)
+
+	provider := pair.Server1
+	consumer := pair.Server2
+
+	file := writeShareFileInContentRoot(t, provider.TempDir, "rotation.txt", []byte("signed"))
+
+	providerToken := loginSubprocessAdminWithClient(t, provider)
+	consumerToken := loginSubprocessAdminWithClient(t, consumer)
+
+	consumerHost := hostFromBaseURL(t, consumer.BaseURL)
+
+	exchangeInvitesBetweenPair(t, provider, consumer, providerToken, consumerToken)
+
+	status, body := shareRequestAPI(t, provider, providerToken, "/api/admin/keys/rotate", nil)
+	if status != http.StatusAccepted {
+		t.Fatalf("rotate: expected 202, got %d: %s", status, body)
+	}
+
+	var staged struct {
+		KeyID string `json:"keyId"`
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal([]byte(body), &staged); err != nil {
+		t.Fatalf("decode rotate response: %v", err)
+	}
+
+	if staged.State != "next" {
+		t.Fatalf("staged key state = %q, want next", staged.State)
+	}
+
+	status, _, body = getShareContent(t, provider, providerToken, "/api/admin/keys", "")
+	if status != http.StatusOK {
+		t.Fatalf("list keys: expected 200, got %d: %s", status, body)
+	}
+
+	var listed struct {
+		Keys []struct {
+			KeyID string `json:"keyId"`
+			State string `json:"state"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(body), &listed); err != nil {
+		t.Fatalf("decode keys: %v", err)
+	}
+
+	if len(listed.Keys) != 2 || listed.Keys[1].State != "current" {
+		t.Fatalf("keys = %s", body)
+	}
+
+	status, _, body = getShareContent(t, provider, "", "/ocm/jwks", "")
+	if status != http.StatusOK {
+		t.Fatalf("jwks: expected 200, got %d: %s", status, body)
+	}
+
+	var set jwks.Set
+	if err := json.Unmarshal([]byte(body), &set); err != nil {
+		t.Fatalf("decode jwks: %v", err)
+	}
+
+	published := map[string]bool{}
+	for _, k := range set.Keys {
+		published[k.Kid] = true
+	}
+
+	if len(set.Keys) != 2 || !published[staged.KeyID] || !published[listed.Keys[1].KeyID] {
+		t.Fatalf("jwks kids = %v, want the current and the staged key", published)
+	}
+
+	status, body = createOutgoingShareWithClient(t, provider, providerToken, map[string]any{
+		"receiverDomain": consumerHost,
+		"shareWith":      "admin@" + consumerHost,
+		"localPath":      file,
+		"permissions":    []string{"read"},
+	})
+	if status != http.StatusCreated {
+		provider.DumpLogs(t)
+		consumer.DumpLogs(t)
+		t.Fatalf("outgoing share after staging: expected 201, got %d: %s", status, body)
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/platform/config"
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+// TestMetrics_ScrapeAfterUnsignedRequest checks that /metrics honors the
+// configured bearer token and reports the signature outcome recorded while
+// the server rejected an unsigned token request.
+func TestMetrics_ScrapeAfterUnsignedRequest(t *testing.T) {
+	t.Parallel()
+
+	ts := harness.StartTestServerWithConfig(t, func(cfg *config.Config) {
+		if cfg.HTTP.Services == nil {
+			cfg.HTTP.Services = make(map[string]map[string]any)
+		}
+
+		cfg.HTTP.Services["metrics"] = map[string]any{"bearer_token": "scrape-secret"}
+	})
+	defer ts.Stop(t)
+
+	if status, _ := scrapeMetrics(t, ts.BaseURL, ""); status != http.StatusUnauthorized {
+		t.Fatalf("scrape without token: status %d, want 401", status)
+	}
+
+	form := url.Values{"client_id": {"peer.example.org"}, "code": {"test"}}
+
+	resp, err := http.PostForm(ts.BaseURL+"/ocm/token", form) //nolint:bodyclose,noctx // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper; test request needs no cancellation
+	if err != nil {
+		t.Fatalf("POST /ocm/token: %v", err)
+	}
+
+	tshttp.MustClose(t, resp.Body)
+
+	status, body := scrapeMetrics(t, ts.BaseURL, "scrape-secret")
+	if status != http.StatusOK {
+		t.Fatalf("scrape: status %d, want 200", status)
+	}
+
+	for _, want := range []string{
+		`opencloudmesh_signature_verifications_total{result="unsigned"}`,
+		`opencloudmesh_ratelimit_rejections_total`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape output missing %q", want)
+		}
+	}
+}
+
+func scrapeMetrics(t *testing.T, baseURL, token string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, baseURL+"/metrics", nil)
+	if err != nil {
+		t.Fatalf("build scrape request: %v", err)
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer tshttp.MustClose(t, resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read scrape body: %v", err)
+	}
+
+	return resp.StatusCode, string(body)
+}
@@ -16,6 +16,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
+
 	tsrepos "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/repos"
 
 	outgoingshares "github.com/MahdiBaghbani/opencloudmesh-go/internal/components/api/outgoing/shares"
@@ -30,8 +32,9 @@ import (
 	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
 )
 
-// TestOutgoingShareResponseRedactsSharedSecret proves the browser-facing response
-// from POST /api/shares/outgoing never contains the generated sharedSecret.
+// TestOutgoingShareResponseRedactsSharedSecret proves the browser-facing responses
+// from POST /api/shares/outgoing, and the list and detail GETs that follow it,
+// never contain the generated sharedSecret.
 // The secret is still forwarded to the remote receiver inside the WebDAV protocol
 // payload, but it must not be echoed back to the browser.
 func TestOutgoingShareResponseRedactsSharedSecret(t *testing.T) {
@@ -78,6 +81,39 @@ func TestOutgoingShareResponseRedactsSharedSecret(t *testing.T) {
 	if strings.Contains(strings.ToLower(w.Body.String()), "sharedsecret") {
 		t.Fatalf("browser-facing response contains sharedSecret field: %s", w.Body.String())
 	}
+
+	var created struct {
+		ShareID string `json:"shareId"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.ShareID == "" {
+		t.Fatalf("create response has no shareId: %s", w.Body.String())
+	}
+
+	router := chi.NewRouter()
+	router.Get("/api/shares/outgoing", handler.HandleList)
+	router.Get("/api/shares/outgoing/{shareId}", handler.HandleGetDetail)
+
+	for _, target := range []string{"/api/shares/outgoing", "/api/shares/outgoing/" + created.ShareID} {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d: %s", target, w.Code, w.Body.String())
+		}
+
+		if !strings.Contains(w.Body.String(), created.ShareID) {
+			t.Fatalf("GET %s: response does not include the share: %s", target, w.Body.String())
+		}
+
+		if strings.Contains(w.Body.String(), sharedSecret) {
+			t.Fatalf("GET %s: response contains sharedSecret %q: %s", target, sharedSecret, w.Body.String())
+		}
+
+		if strings.Contains(strings.ToLower(w.Body.String()), "sharedsecret") {
+			t.Fatalf("GET %s: response contains sharedSecret field: %s", target, w.Body.String())
+		}
+	}
 }
 
 func makeCapturingReceiverTLSServerForRedaction(t *testing.T, capabilities, criteria []string) (*httptest.Server, *spec.NewShareRequest) {
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
 
@@ -103,8 +104,8 @@ func assertStrictDiscoveryFileResource(t *testing.T, serverName string, disc spe
 		t.Fatalf("%s discovery missing file resource type", serverName)
 	}
 
-	if len(fileRT.ShareTypes) != 1 || fileRT.ShareTypes[0] != "user" {
-		t.Fatalf("%s file shareTypes = %v, want [user]", serverName, fileRT.ShareTypes)
+	if !slices.Equal(fileRT.ShareTypes, []string{"user", "group"}) {
+		t.Fatalf("%s file shareTypes = %v, want [user group]", serverName, fileRT.ShareTypes)
 	}
 
 	webdavRole, ok := fileRT.Protocols.StringRole("webdav")
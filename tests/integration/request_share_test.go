@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+// TestRequestShare_StrictTwoServer has the consumer ask the provider for its
+// own copy of a known share over a signed POST /ocm/request-share. The
+// provider owner sees the request in the share request inbox and approves it,
+// which delivers a new share to the consumer.
+func TestRequestShare_StrictTwoServer(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	pair := startStrictProtocolPair(t)
+	defer pair.Stop(t)
+
+	provider := pair.Server1
+	consumer := pair.Server2
+
+	testFile := writeShareFileInContentRoot(t, provider.TempDir, "request-share.txt", []byte("requested content"))
+
+	providerToken := loginSubprocessAdminWithClient(t, provider)
+	consumerToken := loginSubprocessAdminWithClient(t, consumer)
+
+	consumerHost := hostFromBaseURL(t, consumer.BaseURL)
+	providerHost := hostFromBaseURL(t, provider.BaseURL)
+
+	exchangeInvitesBetweenPair(t, provider, consumer, providerToken, consumerToken)
+
+	providerID, _ := createAndVerifyMultiShare(t, provider, consumer, providerToken, consumerToken, consumerHost, testFile)
+
+	status, body := shareRequestAPI(t, consumer, consumerToken, "/api/share-requests/outgoing",
+		map[string]string{"owner": "admin@" + providerHost, "share": providerID})
+	if status != http.StatusCreated {
+		provider.DumpLogs(t)
+		consumer.DumpLogs(t)
+		t.Fatalf("request share: status %d: %s", status, body)
+	}
+
+	if status, body := shareRequestAPI(t, consumer, consumerToken, "/api/share-requests/outgoing",
+		map[string]string{"owner": "admin@" + providerHost, "share": "unknown-share"}); status != http.StatusNotFound {
+		t.Fatalf("request unknown share: status %d, want 404: %s", status, body)
+	}
+
+	requestID := pendingShareRequestID(t, provider, providerToken, providerID)
+
+	status, body = shareRequestAPI(t, provider, providerToken, "/api/inbox/share-requests/"+requestID+"/approve", nil)
+	if status != http.StatusCreated {
+		provider.DumpLogs(t)
+		consumer.DumpLogs(t)
+		t.Fatalf("approve share request: status %d: %s", status, body)
+	}
+
+	var approved struct {
+		ProviderID string `json:"providerId"`
+	}
+	if err := json.Unmarshal([]byte(body), &approved); err != nil {
+		t.Fatalf("decode approve response: %v", err)
+	}
+
+	if approved.ProviderID == "" || approved.ProviderID == providerID {
+		t.Fatalf("approve must create a new share, got providerId %q", approved.ProviderID)
+	}
+
+	waitForInboxShareByProvider(t, consumer, consumerToken, approved.ProviderID)
+
+	if status, body := shareRequestAPI(t, provider, providerToken,
+		"/api/inbox/share-requests/"+requestID+"/decline", nil); status != http.StatusConflict {
+		t.Fatalf("decline approved request: status %d, want 409: %s", status, body)
+	}
+}
+
+// pendingShareRequestID returns the ID of the single pending share request
+// for providerID in the owner's share request inbox.
+func pendingShareRequestID(t *testing.T, srv *harness.SubprocessServer, token, providerID string) string {
+	t.Helper()
+
+	status, body := shareRequestAPI(t, srv, token, "/api/inbox/share-requests", nil)
+	if status != http.StatusOK {
+		t.Fatalf("list share requests: status %d: %s", status, body)
+	}
+
+	var listed struct {
+		Requests []struct {
+			ID         string `json:"id"`
+			ProviderID string `json:"providerId"`
+			Status     string `json:"status"`
+		} `json:"requests"`
+	}
+	if err := json.Unmarshal([]byte(body), &listed); err != nil {
+		t.Fatalf("decode share requests: %v", err)
+	}
+
+	if len(listed.Requests) != 1 || listed.Requests[0].ProviderID != providerID || listed.Requests[0].Status != "pending" {
+		t.Fatalf("share requests = %+v, want one pending request for %s", listed.Requests, providerID)
+	}
+
+	return listed.Requests[0].ID
+}
+
+// shareRequestAPI calls a session-gated share request route: GET when payload
+// is nil and path is the inbox list, POST otherwise.
+func shareRequestAPI(t *testing.T, srv *harness.SubprocessServer, token, path string, payload any) (int, string) {
+	t.Helper()
+
+	method := http.MethodPost
+	if path == "/api/inbox/share-requests" {
+		method = http.MethodGet
+	}
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(tshttp.MustMarshalJSON(t, payload))
+	}
+
+	req, err := http.NewRequestWithContext(t.Context(), method, srv.BaseURL+path, reqBody)
+	if err != nil {
+		t.Fatalf("build %s request: %v", path, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := srv.Client().Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer tshttp.MustClose(t, resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response body: %v", err)
+	}
+
+	return resp.StatusCode, string(body)
+}
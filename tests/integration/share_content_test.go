@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+// TestShareContent_BrowseAndDownloadFolder shares a folder and reads it
+// through the receiver's content endpoint: the receiver lists it with
+// PROPFIND and streams a file from it with a ranged GET, both over the
+// exchanged token.
+func TestShareContent_BrowseAndDownloadFolder(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	pair := startStrictProtocolPair(t)
+	defer pair.Stop(t)
+
+	provider := pair.Server1
+	consumer := pair.Server2
+
+	marker := writeShareFileInContentRoot(t, provider.TempDir, "content-marker.txt", []byte("marker"))
+	folder := filepath.Join(filepath.Dir(marker), "reports")
+	writeTree(t, folder, map[string]string{"summary.txt": "all good", "q1/sales.csv": "region,total\nnorth,42\n"})
+
+	providerToken := loginSubprocessAdminWithClient(t, provider)
+	consumerToken := loginSubprocessAdminWithClient(t, consumer)
+
+	consumerHost := hostFromBaseURL(t, consumer.BaseURL)
+
+	exchangeInvitesBetweenPair(t, provider, consumer, providerToken, consumerToken)
+
+	status, body := createOutgoingShareWithClient(t, provider, providerToken, map[string]any{
+		"receiverDomain": consumerHost,
+		"shareWith":      "admin@" + consumerHost,
+		"localPath":      folder,
+		"permissions":    []string{"read"},
+	})
+	if status != http.StatusCreated {
+		t.Fatalf("outgoing folder share: expected 201, got %d: %s", status, body)
+	}
+
+	var created struct {
+		ProviderID string `json:"providerId"`
+	}
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		t.Fatalf("decode outgoing share response: %v", err)
+	}
+
+	shareID := waitForInboxShareByProvider(t, consumer, consumerToken, created.ProviderID)
+	contentPath := "/api/inbox/shares/" + shareID + "/content"
+
+	if status, _, _ := getShareContent(t, consumer, consumerToken, contentPath, ""); status != http.StatusBadRequest {
+		t.Fatalf("content before accept: expected 400, got %d", status)
+	}
+
+	if status, body = shareRequestAPI(t, consumer, consumerToken, "/api/inbox/shares/"+shareID+"/accept", nil); status != http.StatusOK {
+		t.Fatalf("accept inbox share: status %d: %s", status, body)
+	}
+
+	status, _, listingBody := getShareContent(t, consumer, consumerToken, contentPath, "")
+	if status != http.StatusOK {
+		consumer.DumpLogs(t)
+		t.Fatalf("list folder: expected 200, got %d: %s", status, listingBody)
+	}
+
+	var listing struct {
+		Entries []struct {
+			Name string `json:"name"`
+			Path string `json:"path"`
+			Type string `json:"type"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(listingBody), &listing); err != nil {
+		t.Fatalf("decode listing: %v", err)
+	}
+
+	if len(listing.Entries) != 2 || listing.Entries[0].Name != "q1" || listing.Entries[0].Type != "folder" ||
+		listing.Entries[1].Name != "summary.txt" {
+		t.Fatalf("listing = %s", listingBody)
+	}
+
+	status, header, fileBody := getShareContent(t, consumer, consumerToken, contentPath+"?path=q1/sales.csv", "bytes=13-")
+	if status != http.StatusPartialContent || fileBody != "north,42\n" {
+		t.Fatalf("ranged download = %d %q", status, fileBody)
+	}
+
+	if got := header.Get("Content-Disposition"); got != "attachment; filename=sales.csv" {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+}
+
+// getShareContent GETs a content path on srv, optionally with a Range.
+func getShareContent(t *testing.T, srv *harness.SubprocessServer, token, path, rangeHeader string) (int, http.Header, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.BaseURL+path, nil)
+	if err != nil {
+		t.Fatalf("build content request: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	if rangeHeader != "" {
+		req.Header.Set("Range", rangeHeader)
+	}
+
+	resp, err := srv.Client().Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer tshttp.MustClose(t, resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read content body: %v", err)
+	}
+
+	return resp.StatusCode, resp.Header, string(body)
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestShareExpiry_ContentRefusedAfterExpiration sends a share that expires a
+// few seconds out: the receiver reads it while it is live and refuses it,
+// showing it as expired, once the sent expiration passes.
+func TestShareExpiry_ContentRefusedAfterExpiration(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	pair := startStrictProtocolPair(t)
+	defer pair.Stop(t)
+
+	provider := pair.Server1
+	consumer := pair.Server2
+
+	file := writeShareFileInContentRoot(t, provider.TempDir, "expiring.txt", []byte("short-lived"))
+
+	providerToken := loginSubprocessAdminWithClient(t, provider)
+	consumerToken := loginSubprocessAdminWithClient(t, consumer)
+
+	consumerHost := hostFromBaseURL(t, consumer.BaseURL)
+
+	exchangeInvitesBetweenPair(t, provider, consumer, providerToken, consumerToken)
+
+	expiresAt := time.Now().Add(4 * time.Second).UTC().Truncate(time.Second)
+
+	status, body := createOutgoingShareWithClient(t, provider, providerToken, map[string]any{
+		"receiverDomain": consumerHost,
+		"shareWith":      "admin@" + consumerHost,
+		"localPath":      file,
+		"permissions":    []string{"read"},
+		"expiresAt":      expiresAt.Format(time.RFC3339),
+	})
+	if status != http.StatusCreated {
+		t.Fatalf("outgoing share: expected 201, got %d: %s", status, body)
+	}
+
+	var created struct {
+		ProviderID string `json:"providerId"`
+	}
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		t.Fatalf("decode outgoing share response: %v", err)
+	}
+
+	shareID := waitForInboxShareByProvider(t, consumer, consumerToken, created.ProviderID)
+
+	if status, body = shareRequestAPI(t, consumer, consumerToken, "/api/inbox/shares/"+shareID+"/accept", nil); status != http.StatusOK {
+		t.Fatalf("accept inbox share: status %d: %s", status, body)
+	}
+
+	contentPath := "/api/inbox/shares/" + shareID + "/content"
+	if status, _, got := getShareContent(t, consumer, consumerToken, contentPath, ""); status != http.StatusOK || got != "short-lived" {
+		t.Fatalf("content before expiration = %d %q", status, got)
+	}
+
+	time.Sleep(time.Until(expiresAt) + time.Second)
+
+	if status, _, got := getShareContent(t, consumer, consumerToken, contentPath, ""); status != http.StatusGone {
+		t.Fatalf("content after expiration: expected 410, got %d: %s", status, got)
+	}
+
+	for _, s := range listInboxShares(t, consumer, consumerToken) {
+		if s["shareId"] == shareID && s["status"] != "expired" {
+			t.Errorf("inbox status = %v, want expired", s["status"])
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	pkgsftp "github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/MahdiBaghbani/opencloudmesh-go/internal/components/sftp"
+	tscrypto "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/crypto"
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	tsprotocol "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/protocol"
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+// TestSSHShare_RecipientDownloadsOverSFTP sends a share with an ssh arm from
+// an instance running the built-in SFTP server. The receiver's admin has an
+// SSH key registered, so the create-share response carries it and the
+// receiver can read the file over SFTP with that key, and nothing else.
+func TestSSHShare_RecipientDownloadsOverSFTP(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	sshAddr := "127.0.0.1:" + strconv.Itoa(harness.FreePort(t))
+	hostKeyPath := filepath.Join(t.TempDir(), "ssh_host_ed25519.pem")
+
+	pair := harness.StartStrictProtocolPairWithOptions(t, harness.StrictProtocolPairStartOptions{
+		TLSRootCAFile: tsprotocol.StrictProtocolTLSRootCA(harness.FindProjectRoot(t)),
+		ExtraConfigBuilder: func(allowedPorts []int, moduleRoot, loopbackHost string) string {
+			return tsprotocol.StrictProtocolPairExtraConfig(tsprotocol.StrictProtocolPairExtraConfigOptions{
+				ModuleRoot:   moduleRoot,
+				LoopbackHost: loopbackHost,
+				AllowedPorts: allowedPorts,
+				Variant:      tsprotocol.VariantProtocolPair,
+			})
+		},
+		Server1ExtraConfig: "\n[ocm.ssh]\nlisten_addr = \"" + sshAddr + "\"\nadvertised_addr = \"" + sshAddr +
+			"\"\nhost_key_path = \"" + filepath.ToSlash(hostKeyPath) + "\"\n",
+	})
+	defer pair.Stop(t)
+
+	provider := pair.Server1
+	consumer := pair.Server2
+
+	content := []byte("multi-GB dataset, abridged")
+	testFile := writeShareFileInContentRoot(t, provider.TempDir, "dataset.bin", content)
+
+	providerToken := loginSubprocessAdminWithClient(t, provider)
+	consumerToken := loginSubprocessAdminWithClient(t, consumer)
+
+	consumerHost := hostFromBaseURL(t, consumer.BaseURL)
+
+	exchangeInvitesBetweenPair(t, provider, consumer, providerToken, consumerToken)
+
+	key := tscrypto.MustSSHKey(t)
+	putSSHKeys(t, consumer, consumerToken, key.AuthorizedKey+" admin@laptop")
+
+	status, body := createOutgoingShareWithClient(t, provider, providerToken, map[string]any{
+		"receiverDomain": consumerHost,
+		"shareWith":      "admin@" + consumerHost,
+		"localPath":      testFile,
+		"permissions":    []string{"read"},
+		"ssh":            true,
+	})
+	if status != http.StatusCreated {
+		provider.DumpLogs(t)
+		consumer.DumpLogs(t)
+		t.Fatalf("outgoing ssh share: expected 201, got %d: %s", status, body)
+	}
+
+	var created struct {
+		ProviderID string `json:"providerId"`
+		WebDAVID   string `json:"webdavId"`
+	}
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		t.Fatalf("decode outgoing share response: %v", err)
+	}
+
+	shareID := waitForInboxShareByProvider(t, consumer, consumerToken, created.ProviderID)
+	sshURI := inboxShareSSHURI(t, consumer, consumerToken, shareID)
+
+	if want := created.WebDAVID + "@" + sshAddr + "/dataset.bin"; sshURI != want {
+		t.Fatalf("inbox ssh uri = %q, want %q", sshURI, want)
+	}
+
+	hostKey, err := sftp.LoadOrCreateHostKey(hostKeyPath)
+	if err != nil {
+		t.Fatalf("load provider host key: %v", err)
+	}
+
+	client := dialSFTP(t, sshAddr, created.WebDAVID, key, hostKey.PublicKey())
+
+	got := readSFTPFile(t, client, "/dataset.bin")
+	if !bytes.Equal(got, content) {
+		t.Fatalf("sftp read = %q, want %q", got, content)
+	}
+
+	if _, err := client.Create("/dataset.bin"); err == nil {
+		t.Fatal("expected sftp write to be denied")
+	}
+
+	if _, err := ssh.Dial("tcp", sshAddr, sftpClientConfig(created.WebDAVID, tscrypto.MustSSHKey(t), hostKey.PublicKey())); err == nil {
+		t.Fatal("expected login with an unregistered key to fail")
+	}
+}
+
+func putSSHKeys(t *testing.T, srv *harness.SubprocessServer, token string, keys ...string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPut, srv.BaseURL+"/api/auth/me/ssh-keys",
+		bytes.NewReader(tshttp.MustMarshalJSON(t, map[string][]string{"keys": keys})))
+	if err != nil {
+		t.Fatalf("build ssh-keys request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := srv.Client().Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("PUT ssh-keys: %v", err)
+	}
+	defer tshttp.MustClose(t, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		t.Fatalf("PUT ssh-keys: status %d: %s", resp.StatusCode, respBody)
+	}
+}
+
+// inboxShareSSHURI reads protocol.ssh.uri from the inbox share detail.
+func inboxShareSSHURI(t *testing.T, srv *harness.SubprocessServer, token, shareID string) string {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.BaseURL+"/api/inbox/shares/"+shareID, nil)
+	if err != nil {
+		t.Fatalf("build inbox detail request: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := srv.Client().Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("GET inbox share: %v", err)
+	}
+	defer tshttp.MustClose(t, resp.Body)
+
+	var detail struct {
+		Protocol struct {
+			SSH *struct {
+				URI string `json:"uri"`
+			} `json:"ssh"`
+		} `json:"protocol"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+		t.Fatalf("decode inbox share detail: %v", err)
+	}
+
+	if detail.Protocol.SSH == nil {
+		t.Fatal("inbox share detail has no ssh arm")
+	}
+
+	return detail.Protocol.SSH.URI
+}
+
+func sftpClientConfig(user string, key tscrypto.SSHKey, hostKey ssh.PublicKey) *ssh.ClientConfig {
+	return &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(key.Signer)},
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+	}
+}
+
+func dialSFTP(t *testing.T, addr, user string, key tscrypto.SSHKey, hostKey ssh.PublicKey) *pkgsftp.Client {
+	t.Helper()
+
+	conn, err := ssh.Dial("tcp", addr, sftpClientConfig(user, key, hostKey))
+	if err != nil {
+		t.Fatalf("ssh dial %s: %v", addr, err)
+	}
+
+	client, err := pkgsftp.NewClient(conn)
+	if err != nil {
+		t.Fatalf("sftp client: %v", err)
+	}
+
+	t.Cleanup(func() {
+		//nolint:errcheck // test cleanup: session teardown
+		client.Close()
+		//nolint:errcheck // test cleanup: connection teardown
+		conn.Close()
+	})
+
+	return client
+}
+
+func readSFTPFile(t *testing.T, client *pkgsftp.Client, name string) []byte {
+	t.Helper()
+
+	f, err := client.Open(name)
+	if err != nil {
+		t.Fatalf("sftp open %s: %v", name, err)
+	}
+
+	defer func() {
+		//nolint:errcheck // test cleanup: read-only handle
+		f.Close()
+	}()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("sftp read %s: %v", name, err)
+	}
+
+	return data
+}
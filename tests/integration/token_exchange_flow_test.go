@@ -44,6 +44,9 @@ func TestTokenExchangeFlow(t *testing.T) {
 		ExtraConfig: `
 [outbound_http.ssrf]
 mode = "off"
+
+[token_exchange]
+access_token_ttl_seconds = 120
 `,
 	})
 	defer sender.Stop(t)
@@ -62,8 +65,29 @@ mode = "off"
 	form.Set("code", sharedSecret)
 
 	assertUnsignedTokenRejected(t, sender, form)
-	accessToken := exchangeSignedTokenFlowCode(t, sender, receiver, form)
-	assertWebDAVBearerContent(t, sender.BaseURL, webdavID, testFile, accessToken, testContent)
+
+	exchanged := exchangeSignedTokenFlowCode(t, sender, receiver, form)
+	if exchanged.ExpiresIn != 120 {
+		t.Errorf("expires_in = %d, want configured 120", exchanged.ExpiresIn)
+	}
+
+	assertWebDAVBearerContent(t, sender.BaseURL, webdavID, testFile, exchanged.AccessToken, testContent)
+
+	if exchanged.RefreshToken == "" {
+		t.Fatal("token exchange returned no refresh_token")
+	}
+
+	refreshForm := url.Values{}
+	refreshForm.Set("grant_type", "refresh_token")
+	refreshForm.Set("client_id", receiver.peerDomain)
+	refreshForm.Set("refresh_token", exchanged.RefreshToken)
+
+	refreshed := exchangeSignedTokenFlowCode(t, sender, receiver, refreshForm)
+	if refreshed.AccessToken == exchanged.AccessToken || refreshed.RefreshToken == exchanged.RefreshToken {
+		t.Fatal("refresh_token grant must issue a new access token and rotate the refresh token")
+	}
+
+	assertWebDAVBearerContent(t, sender.BaseURL, webdavID, testFile, refreshed.AccessToken, testContent)
 }
 
 // createTokenFlowShare creates the outgoing share to the strict receiver and
@@ -150,8 +174,8 @@ func assertUnsignedTokenRejected(t *testing.T, sender *harness.SubprocessServer,
 }
 
 // exchangeSignedTokenFlowCode posts the signed token request and returns the
-// exchanged access token.
-func exchangeSignedTokenFlowCode(t *testing.T, sender *harness.SubprocessServer, receiver *strictCodeFlowReceiver, form url.Values) string {
+// token response.
+func exchangeSignedTokenFlowCode(t *testing.T, sender *harness.SubprocessServer, receiver *strictCodeFlowReceiver, form url.Values) spec.TokenResponse {
 	t.Helper()
 
 	signedReq, err := http.NewRequestWithContext(
@@ -194,7 +218,7 @@ func exchangeSignedTokenFlowCode(t *testing.T, sender *harness.SubprocessServer,
 		t.Fatal("signed token exchange returned empty access_token")
 	}
 
-	return tokenResp.AccessToken
+	return tokenResp
 }
 
 // assertWebDAVBearerContent checks bearer access to the shared file over WebDAV.
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+// TestTracing_TwoInstancesShareTrace sends a traced /ocm-aux/discover request
+// to instance1, which fetches instance2's discovery document. Both instances
+// export spans to files, and both files must carry the caller's trace ID.
+func TestTracing_TwoInstancesShareTrace(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	traceDir := t.TempDir()
+	tracingConfig := func(name string) string {
+		return fmt.Sprintf("[tracing]\nenabled = true\nexporter = \"file\"\nfile = %q\n",
+			filepath.Join(traceDir, name+".jsonl"))
+	}
+
+	h := harness.StartTwoInstances(t,
+		harness.SubprocessConfig{Name: "trace1", Mode: "dev", ExtraConfig: tracingConfig("trace1")},
+		harness.SubprocessConfig{Name: "trace2", Mode: "dev", ExtraConfig: tracingConfig("trace2")},
+	)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet,
+		h.Server1.BaseURL+"/ocm-aux/discover?base="+h.Server2.BaseURL, nil)
+	if err != nil {
+		t.Fatalf("build discover request: %v", err)
+	}
+
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	resp, err := http.DefaultClient.Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		h.DumpLogs(t)
+		h.Stop(t)
+		t.Fatalf("call /ocm-aux/discover: %v", err)
+	}
+
+	tshttp.MustClose(t, resp.Body)
+
+	// Spans are batched; stopping each instance flushes them to its file.
+	h.Stop(t)
+
+	wantSpans := map[string]string{"trace1": "GET /ocm-aux/discover", "trace2": "GET /.well-known/ocm"}
+
+	for name, span := range wantSpans {
+		data, err := os.ReadFile(filepath.Join(traceDir, name+".jsonl"))
+		if err != nil {
+			t.Fatalf("%s: read trace file: %v", name, err)
+		}
+
+		if !strings.Contains(string(data), traceID) {
+			t.Errorf("%s: trace file does not contain trace id %s", name, traceID)
+		}
+
+		if !strings.Contains(string(data), span) {
+			t.Errorf("%s: trace file has no %q span", name, span)
+		}
+	}
+}
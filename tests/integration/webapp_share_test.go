@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// SPDX-FileCopyrightText: 2026 Mohammad Mahdi Baghbani Pourvahid <mahdi-baghbani@azadehafzar.io>
+//
+// OpenCloudMesh Go - a runnable Open Cloud Mesh peer in Go, focused on a strict, WebDAV-centered subset of the protocol.
+
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tshttp "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/http"
+	tsprotocol "github.com/MahdiBaghbani/opencloudmesh-go/internal/testsupport/protocol"
+	"github.com/MahdiBaghbani/opencloudmesh-go/tests/integration/harness"
+)
+
+const webappViewerPrefix = "https://viewer.example.org/open/"
+
+// TestWebappShare_OpenInRemoteApp sends a share with a webapp arm from an
+// instance that has [ocm.webapp] configured. The receiver accepts it and asks
+// open-webapp for a launch; the returned token must open the file over the
+// sender's WebDAV.
+func TestWebappShare_OpenInRemoteApp(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping subprocess test in short mode")
+	}
+
+	pair := harness.StartStrictProtocolPairWithOptions(t, harness.StrictProtocolPairStartOptions{
+		TLSRootCAFile: tsprotocol.StrictProtocolTLSRootCA(harness.FindProjectRoot(t)),
+		ExtraConfigBuilder: func(allowedPorts []int, moduleRoot, loopbackHost string) string {
+			return tsprotocol.StrictProtocolPairExtraConfig(tsprotocol.StrictProtocolPairExtraConfigOptions{
+				ModuleRoot:   moduleRoot,
+				LoopbackHost: loopbackHost,
+				AllowedPorts: allowedPorts,
+				Variant:      tsprotocol.VariantProtocolPair,
+			}) + "\n[ocm.webapp]\nviewer_url_template = \"" + webappViewerPrefix + "{webdavId}\"\n"
+		},
+	})
+	defer pair.Stop(t)
+
+	provider := pair.Server1
+	consumer := pair.Server2
+
+	content := []byte("webapp content")
+	testFile := writeShareFileInContentRoot(t, provider.TempDir, "webapp-share.txt", content)
+
+	providerToken := loginSubprocessAdminWithClient(t, provider)
+	consumerToken := loginSubprocessAdminWithClient(t, consumer)
+
+	consumerHost := hostFromBaseURL(t, consumer.BaseURL)
+
+	exchangeInvitesBetweenPair(t, provider, consumer, providerToken, consumerToken)
+
+	status, body := createOutgoingShareWithClient(t, provider, providerToken, map[string]any{
+		"receiverDomain":    consumerHost,
+		"shareWith":         "admin@" + consumerHost,
+		"localPath":         testFile,
+		"permissions":       []string{"read"},
+		"webappPermissions": []string{"view"},
+	})
+	if status != http.StatusCreated {
+		provider.DumpLogs(t)
+		consumer.DumpLogs(t)
+		t.Fatalf("outgoing webapp share: expected 201, got %d: %s", status, body)
+	}
+
+	var created struct {
+		ProviderID string `json:"providerId"`
+		WebDAVID   string `json:"webdavId"`
+	}
+	if err := json.Unmarshal([]byte(body), &created); err != nil {
+		t.Fatalf("decode outgoing share response: %v", err)
+	}
+
+	shareID := waitForInboxShareByProvider(t, consumer, consumerToken, created.ProviderID)
+
+	status, body = shareRequestAPI(t, consumer, consumerToken, "/api/inbox/shares/"+shareID+"/open-webapp", nil)
+	if status != http.StatusBadRequest {
+		t.Fatalf("open-webapp before accept: expected 400, got %d: %s", status, body)
+	}
+
+	if status, body = shareRequestAPI(t, consumer, consumerToken, "/api/inbox/shares/"+shareID+"/accept", nil); status != http.StatusOK {
+		t.Fatalf("accept inbox share: status %d: %s", status, body)
+	}
+
+	status, body = shareRequestAPI(t, consumer, consumerToken, "/api/inbox/shares/"+shareID+"/open-webapp", nil)
+	if status != http.StatusOK {
+		consumer.DumpLogs(t)
+		t.Fatalf("open-webapp: expected 200, got %d: %s", status, body)
+	}
+
+	var launch struct {
+		URL         string   `json:"url"`
+		Targets     []string `json:"targets"`
+		Permissions []string `json:"permissions"`
+		AccessToken string   `json:"accessToken"`
+	}
+	if err := json.Unmarshal([]byte(body), &launch); err != nil {
+		t.Fatalf("decode open-webapp response: %v", err)
+	}
+
+	if launch.URL != webappViewerPrefix+created.WebDAVID || launch.AccessToken == "" {
+		t.Fatalf("launch = %+v, want viewer URL for %s and a token", launch, created.WebDAVID)
+	}
+
+	if strings.Join(launch.Targets, ",") != "blank" || strings.Join(launch.Permissions, ",") != "view" {
+		t.Fatalf("launch targets/permissions = %v/%v", launch.Targets, launch.Permissions)
+	}
+
+	assertBearerReadsSharedFile(t, provider, created.WebDAVID, testFile, launch.AccessToken, content)
+}
+
+// assertBearerReadsSharedFile GETs the shared file from srv's WebDAV endpoint
+// with accessToken through srv's TLS-aware client.
+func assertBearerReadsSharedFile(t *testing.T, srv *harness.SubprocessServer, webdavID, testFile, accessToken string, want []byte) {
+	t.Helper()
+
+	webdavURL := srv.BaseURL + "/webdav/ocm/" + webdavID + "/" + url.PathEscape(filepath.Base(testFile))
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, webdavURL, nil)
+	if err != nil {
+		t.Fatalf("build WebDAV request: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := srv.Client().Do(req) //nolint:bodyclose // response body closed inside shared tshttp.MustClose SSOT helper; bodyclose cannot trace close through helper
+	if err != nil {
+		t.Fatalf("WebDAV GET: %v", err)
+	}
+	defer tshttp.MustClose(t, resp.Body)
+
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read WebDAV body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || string(got) != string(want) {
+		t.Fatalf("WebDAV GET = %d %q, want 200 %q", resp.StatusCode, got, want)
+	}
+}